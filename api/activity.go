@@ -0,0 +1,214 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type createActivityRequest struct {
+	StudentID    int64          `json:"student_id" binding:"required,min=1"`
+	ActivityType string         `json:"activity_type" binding:"required,oneof=call email meeting whatsapp"`
+	Direction    string         `json:"direction" binding:"omitempty,oneof=inbound outbound"`
+	OccurredAt   time.Time      `json:"occurred_at"`
+	Summary      string         `json:"summary" binding:"required"`
+	Notes        sql.NullString `json:"notes"`
+}
+
+// createActivity logs a call, email, meeting or WhatsApp message with a student.
+// The activity is outbound unless given, and occurred now unless given.
+func (server *Server) createActivity(ctx *gin.Context) {
+	var req createActivityRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateActivityParams{
+		StudentID:    req.StudentID,
+		ActivityType: req.ActivityType,
+		Direction:    req.Direction,
+		OccurredAt:   req.OccurredAt,
+		Summary:      req.Summary,
+		Notes:        req.Notes,
+	}
+
+	if arg.Direction == "" {
+		arg.Direction = db.ActivityDirectionOutbound
+	}
+
+	if arg.OccurredAt.IsZero() {
+		arg.OccurredAt = time.Now().UTC().Truncate(time.Second)
+	}
+
+	activity, err := server.store.CreateActivity(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, activity)
+}
+
+type getActivityRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getActivity(ctx *gin.Context) {
+	var req getActivityRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	activity, err := server.store.GetActivity(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, activity)
+}
+
+type listActivitiesRequest struct {
+	StudentID    int64  `form:"student_id" binding:"omitempty,min=1"`
+	ActivityType string `form:"activity_type" binding:"omitempty,oneof=call email meeting whatsapp"`
+	PageID       int32  `form:"page_id" binding:"required,min=1"`
+	PageSize     int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listActivities returns the activities, latest first, optionally of a single student or type.
+func (server *Server) listActivities(ctx *gin.Context) {
+	var req listActivitiesRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListActivitiesParams{
+		StudentID:    sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0},
+		ActivityType: sql.NullString{String: req.ActivityType, Valid: req.ActivityType != ""},
+		Limit:        req.PageSize,
+		Offset:       (req.PageID - 1) * req.PageSize,
+	}
+
+	activities, err := server.store.ListActivities(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, activities)
+}
+
+type updateActivityRequest struct {
+	ActivityID   int64          `json:"activity_id" binding:"required,min=1"`
+	ActivityType string         `json:"activity_type" binding:"required,oneof=call email meeting whatsapp"`
+	Direction    string         `json:"direction" binding:"required,oneof=inbound outbound"`
+	OccurredAt   time.Time      `json:"occurred_at" binding:"required"`
+	Summary      string         `json:"summary" binding:"required"`
+	Notes        sql.NullString `json:"notes"`
+}
+
+func (server *Server) updateActivity(ctx *gin.Context) {
+	var req updateActivityRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateActivityParams{
+		ActivityID:   req.ActivityID,
+		ActivityType: req.ActivityType,
+		Direction:    req.Direction,
+		OccurredAt:   req.OccurredAt,
+		Summary:      req.Summary,
+		Notes:        req.Notes,
+	}
+
+	err := server.store.UpdateActivity(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Activity updated successfully"))
+}
+
+func (server *Server) deleteActivity(ctx *gin.Context) {
+	var req getActivityRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeleteActivity(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Activity deleted successfully"))
+}
+
+type getStudentTimelineRequest struct {
+	StartDate time.Time `form:"start_date" time_format:"2006-01-02" time_utc:"1"`
+	EndDate   time.Time `form:"end_date" time_format:"2006-01-02" time_utc:"1"`
+	PageID    int32     `form:"page_id" binding:"required,min=1"`
+	PageSize  int32     `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// getStudentTimeline returns the timeline of a student: the activities, lessons, invoices and receipts
+// of the student in one feed, latest first, optionally of a date range.
+func (server *Server) getStudentTimeline(ctx *gin.Context) {
+	var uri studentUriRequest
+	var req getStudentTimelineRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.GetStudentTimelineParams{
+		StudentID:     uri.StudentID,
+		StartDatetime: sql.NullTime{Time: req.StartDate, Valid: !req.StartDate.IsZero()},
+		Limit:         req.PageSize,
+		Offset:        (req.PageID - 1) * req.PageSize,
+	}
+
+	// the end date is inclusive
+	if !req.EndDate.IsZero() {
+		arg.EndDatetime = sql.NullTime{Time: req.EndDate.AddDate(0, 0, 1), Valid: true}
+	}
+
+	timeline, err := server.store.GetStudentTimeline(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timeline)
+}
@@ -0,0 +1,452 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestActivityAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createActivity":     createActivityTestCasesBuilder(),
+		"Test_getActivity":        getActivityTestCasesBuilder(),
+		"Test_listActivities":     listActivitiesTestCasesBuilder(),
+		"Test_updateActivity":     updateActivityTestCasesBuilder(),
+		"Test_deleteActivity":     deleteActivityTestCasesBuilder(),
+		"Test_getStudentTimeline": getStudentTimelineTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomActivity() db.Activity {
+	return db.Activity{
+		ActivityID:   util.RandomInt64(1, 1000),
+		StudentID:    util.RandomInt64(1, 1000),
+		ActivityType: db.ActivityTypeWhatsApp,
+		Direction:    db.ActivityDirectionInbound,
+		OccurredAt:   time.Now().UTC().Add(-time.Hour).Truncate(time.Second),
+		Summary:      util.RandomNote(),
+		Notes:        sql.NullString{String: util.RandomNote(), Valid: true},
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// createActivityTestCasesBuilder creates a slice of test cases for the createActivity API
+func createActivityTestCasesBuilder() testCases {
+	var testCases testCases
+
+	activity := randomActivity()
+	req := createActivityRequest{
+		StudentID:    activity.StudentID,
+		ActivityType: activity.ActivityType,
+		Direction:    activity.Direction,
+		OccurredAt:   activity.OccurredAt,
+		Summary:      activity.Summary,
+		Notes:        activity.Notes,
+	}
+
+	arg := db.CreateActivityParams{
+		StudentID:    activity.StudentID,
+		ActivityType: activity.ActivityType,
+		Direction:    activity.Direction,
+		OccurredAt:   activity.OccurredAt,
+		Summary:      activity.Summary,
+		Notes:        activity.Notes,
+	}
+
+	methodName := "CreateActivity"
+	url := "/activities"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(activity, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, activity)
+		},
+	})
+
+	// create a test case for StatusOK response of an outbound activity that occurred now
+	defaultReq := req
+	defaultReq.Direction = ""
+	defaultReq.OccurredAt = time.Time{}
+	testCases = append(testCases, testCase{
+		name:       "Defaults",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       defaultReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.MatchedBy(func(arg db.CreateActivityParams) bool {
+				return arg.Direction == db.ActivityDirectionOutbound && time.Since(arg.OccurredAt) < time.Minute
+			})).
+				Return(activity, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Type response
+	invalidReq := req
+	invalidReq.ActivityType = "sms"
+	testCases = append(testCases, testCase{
+		name:       "Invalid Type",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.Activity{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// getActivityTestCasesBuilder creates a slice of test cases for the getActivity API
+func getActivityTestCasesBuilder() testCases {
+	var testCases testCases
+
+	activity := randomActivity()
+	methodName := "GetActivity"
+	url := fmt.Sprintf("/activities/%d", activity.ActivityID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, activity.ActivityID).
+				Return(activity, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, activity)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, activity.ActivityID).
+				Return(db.Activity{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// listActivitiesTestCasesBuilder creates a slice of test cases for the listActivities API
+func listActivitiesTestCasesBuilder() testCases {
+	var testCases testCases
+
+	activities := []db.Activity{randomActivity(), randomActivity()}
+	studentID := util.RandomInt64(1, 1000)
+
+	arg := db.ListActivitiesParams{
+		StudentID:    sql.NullInt64{Int64: studentID, Valid: true},
+		ActivityType: sql.NullString{String: db.ActivityTypeCall, Valid: true},
+		Limit:        5,
+		Offset:       0,
+	}
+
+	methodName := "ListActivities"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/activities?student_id=%d&activity_type=call&page_id=1&page_size=5", studentID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(activities, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, activities)
+		},
+	})
+
+	return testCases
+}
+
+// updateActivityTestCasesBuilder creates a slice of test cases for the updateActivity API
+func updateActivityTestCasesBuilder() testCases {
+	var testCases testCases
+
+	activity := randomActivity()
+	req := updateActivityRequest{
+		ActivityID:   activity.ActivityID,
+		ActivityType: db.ActivityTypeMeeting,
+		Direction:    activity.Direction,
+		OccurredAt:   activity.OccurredAt,
+		Summary:      activity.Summary,
+		Notes:        activity.Notes,
+	}
+
+	arg := db.UpdateActivityParams{
+		ActivityID:   activity.ActivityID,
+		ActivityType: db.ActivityTypeMeeting,
+		Direction:    activity.Direction,
+		OccurredAt:   activity.OccurredAt,
+		Summary:      activity.Summary,
+		Notes:        activity.Notes,
+	}
+
+	methodName := "UpdateActivity"
+	url := "/activities"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Direction response
+	invalidReq := req
+	invalidReq.Direction = "sideways"
+	testCases = append(testCases, testCase{
+		name:       "Invalid Direction",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// deleteActivityTestCasesBuilder creates a slice of test cases for the deleteActivity API
+func deleteActivityTestCasesBuilder() testCases {
+	var testCases testCases
+
+	activityID := util.RandomInt64(1, 1000)
+	methodName := "DeleteActivity"
+	url := fmt.Sprintf("/activities/%d", activityID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, activityID).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, activityID).
+				Return(int64(0), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// getStudentTimelineTestCasesBuilder creates a slice of test cases for the getStudentTimeline API
+func getStudentTimelineTestCasesBuilder() testCases {
+	var testCases testCases
+
+	activity := randomActivity()
+	timeline := []db.GetStudentTimelineRow{
+		{
+			Kind:         db.TimelineKindActivity,
+			ItemID:       activity.ActivityID,
+			OccurredAt:   activity.OccurredAt,
+			ActivityType: sql.NullString{String: activity.ActivityType, Valid: true},
+			Direction:    sql.NullString{String: activity.Direction, Valid: true},
+			Summary:      sql.NullString{String: activity.Summary, Valid: true},
+			Notes:        activity.Notes,
+		},
+		{
+			Kind:       db.TimelineKindInvoice,
+			ItemID:     util.RandomInt64(1, 1000),
+			OccurredAt: activity.OccurredAt.Add(-time.Hour),
+			Amount:     sql.NullFloat64{Float64: util.RandomInvoiceAmount(), Valid: true},
+		},
+	}
+
+	arg := db.GetStudentTimelineParams{
+		StudentID: activity.StudentID,
+		Limit:     10,
+		Offset:    0,
+	}
+
+	// the end date is inclusive
+	rangeArg := db.GetStudentTimelineParams{
+		StudentID:     activity.StudentID,
+		StartDatetime: sql.NullTime{Time: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		EndDatetime:   sql.NullTime{Time: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		Limit:         5,
+		Offset:        5,
+	}
+
+	methodName := "GetStudentTimeline"
+	url := fmt.Sprintf("/students/%d/timeline", activity.StudentID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url + "?page_id=1&page_size=10",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(timeline, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, timeline)
+		},
+	})
+
+	// create a test case for StatusOK response of a date range
+	testCases = append(testCases, testCase{
+		name:       "OK Date Range",
+		httpMethod: http.MethodGet,
+		url:        url + "?start_date=2024-03-01&end_date=2024-03-31&page_id=2&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, rangeArg).
+				Return(timeline, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Date response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Date",
+		httpMethod: http.MethodGet,
+		url:        url + "?start_date=March&page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url + "?page_id=1&page_size=10",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return([]db.GetStudentTimelineRow{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// agingReportRequest contains the query parameters of the accounts receivable aging reports.
+// AsOfDate is the date the balances are aged at, and defaults to today.
+// Format is either json (default) or csv.
+type agingReportRequest struct {
+	AsOfDate time.Time `form:"as_of_date" time_format:"2006-01-02" time_utc:"1"`
+	Format   string    `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+// asOfDatetime returns the exclusive upper bound of the invoices and receipts taken into account.
+func (req agingReportRequest) asOfDatetime() time.Time {
+	asOfDate := req.AsOfDate
+	if asOfDate.IsZero() {
+		asOfDate = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+
+	return asOfDate.AddDate(0, 0, 1)
+}
+
+func (server *Server) getAgingReport(ctx *gin.Context) {
+	var req agingReportRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	report, err := server.store.GetAgingReport(ctx, req.asOfDatetime())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if req.Format == csvFormat {
+		records := [][]string{{
+			"student_id", "first_name", "last_name",
+			"days_0_to_30", "days_31_to_60", "days_61_to_90", "days_over_90", "total_amount",
+		}}
+
+		for _, row := range report {
+			records = append(records, []string{
+				strconv.FormatInt(row.StudentID, 10),
+				row.FirstName,
+				row.LastName,
+				formatAmount(row.Days0To30),
+				formatAmount(row.Days31To60),
+				formatAmount(row.Days61To90),
+				formatAmount(row.DaysOver90),
+				formatAmount(row.TotalAmount),
+			})
+		}
+
+		csvResponse(ctx, "aging_report.csv", records)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+type getStudentAgingReportRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getStudentAgingReport(ctx *gin.Context) {
+	var uriReq getStudentAgingReportRequest
+	var req agingReportRequest
+
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.GetStudentAgingInvoicesParams{
+		AsOfDatetime: req.asOfDatetime(),
+		StudentID:    uriReq.ID,
+	}
+
+	invoices, err := server.store.GetStudentAgingInvoices(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if req.Format == csvFormat {
+		records := [][]string{{
+			"invoice_id", "lesson_id", "invoice_datetime", "amount", "unpaid_amount", "age_days",
+		}}
+
+		for _, row := range invoices {
+			records = append(records, []string{
+				strconv.FormatInt(row.InvoiceID, 10),
+				strconv.FormatInt(row.LessonID, 10),
+				row.InvoiceDatetime.Format(time.RFC3339),
+				formatAmount(row.Amount),
+				formatAmount(row.UnpaidAmount),
+				strconv.FormatInt(row.AgeDays, 10),
+			})
+		}
+
+		csvResponse(ctx, fmt.Sprintf("aging_report_student_%d.csv", uriReq.ID), records)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, invoices)
+}
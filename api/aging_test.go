@@ -0,0 +1,278 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgingReportAPIs(t *testing.T) {
+	tests := tests{
+		"Test_getAgingReport":        getAgingReportTestCasesBuilder(),
+		"Test_getStudentAgingReport": getStudentAgingReportTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+// randomAgingReportRow creates a new random GetAgingReportRow struct.
+func randomAgingReportRow() db.GetAgingReportRow {
+	student := randomStudent()
+	row := db.GetAgingReportRow{
+		StudentID:  student.StudentID,
+		FirstName:  student.FirstName,
+		LastName:   student.LastName,
+		Days0To30:  util.RandomInvoiceAmount(),
+		Days31To60: util.RandomInvoiceAmount(),
+		Days61To90: 0,
+		DaysOver90: util.RandomInvoiceAmount(),
+	}
+	row.TotalAmount = row.Days0To30 + row.Days31To60 + row.Days61To90 + row.DaysOver90
+
+	return row
+}
+
+// getAgingReportTestCasesBuilder creates a slice of test cases for the getAgingReport API
+func getAgingReportTestCasesBuilder() testCases {
+	var testCases testCases
+
+	n := 5
+	report := make([]db.GetAgingReportRow, n)
+	for i := 0; i < n; i++ {
+		report[i] = randomAgingReportRow()
+	}
+
+	asOfDate := time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC)
+	asOfDatetime := asOfDate.AddDate(0, 0, 1)
+
+	methodName := "GetAgingReport"
+	url := fmt.Sprintf("/reports/aging?as_of_date=%s", asOfDate.Format("2006-01-02"))
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, asOfDatetime).
+				Return(report, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, report)
+		},
+	})
+
+	// create a test case for StatusOK response aged as of today
+	testCases = append(testCases, testCase{
+		name:       "Default As Of Date",
+		httpMethod: http.MethodGet,
+		url:        "/reports/aging",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.AnythingOfType("time.Time")).
+				Return(report, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, report)
+		},
+	})
+
+	// create a test case for StatusOK response with a CSV export
+	testCases = append(testCases, testCase{
+		name:       "CSV Export",
+		httpMethod: http.MethodGet,
+		url:        url + "&format=csv",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, asOfDatetime).
+				Return(report, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "text/csv", recorder.Header().Get("Content-Type"))
+
+			records, err := csv.NewReader(recorder.Body).ReadAll()
+			require.NoError(t, err)
+			require.Len(t, records, n+1)
+
+			for i, row := range report {
+				assert.Equal(t, fmt.Sprint(row.StudentID), records[i+1][0])
+				assert.Equal(t, row.LastName, records[i+1][2])
+				assert.Equal(t, formatAmount(row.TotalAmount), records[i+1][7])
+			}
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return([]db.GetAgingReportRow{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Format response by passing url with format=pdf
+	testCases = append(testCases, testCase{
+		name:       "Invalid Format Parameter",
+		httpMethod: http.MethodGet,
+		url:        url + "&format=pdf",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getStudentAgingReportTestCasesBuilder creates a slice of test cases for the getStudentAgingReport API
+func getStudentAgingReportTestCasesBuilder() testCases {
+	var testCases testCases
+
+	student := randomStudent()
+	asOfDate := time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	n := 3
+	invoices := make([]db.GetStudentAgingInvoicesRow, n)
+	for i := 0; i < n; i++ {
+		amount := util.RandomInvoiceAmount()
+		invoices[i] = db.GetStudentAgingInvoicesRow{
+			InvoiceID:       util.RandomInt64(1, 1000),
+			LessonID:        util.RandomInt64(1, 1000),
+			InvoiceDatetime: asOfDate.AddDate(0, 0, -30*i),
+			Amount:          amount,
+			UnpaidAmount:    amount,
+			AgeDays:         int64(30 * i),
+		}
+	}
+
+	arg := db.GetStudentAgingInvoicesParams{
+		AsOfDatetime: asOfDate.AddDate(0, 0, 1),
+		StudentID:    student.StudentID,
+	}
+
+	methodName := "GetStudentAgingInvoices"
+	url := fmt.Sprintf("/reports/aging/%d?as_of_date=%s", student.StudentID, asOfDate.Format("2006-01-02"))
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(invoices, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, invoices)
+		},
+	})
+
+	// create a test case for StatusOK response with a CSV export
+	testCases = append(testCases, testCase{
+		name:       "CSV Export",
+		httpMethod: http.MethodGet,
+		url:        url + "&format=csv",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(invoices, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+
+			records, err := csv.NewReader(recorder.Body).ReadAll()
+			require.NoError(t, err)
+			require.Len(t, records, n+1)
+
+			for i, row := range invoices {
+				assert.Equal(t, fmt.Sprint(row.InvoiceID), records[i+1][0])
+				assert.Equal(t, formatAmount(row.UnpaidAmount), records[i+1][4])
+				assert.Equal(t, fmt.Sprint(row.AgeDays), records[i+1][5])
+			}
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return([]db.GetStudentAgingInvoicesRow{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/reports/aging/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
@@ -0,0 +1,368 @@
+package api
+
+import (
+	"bytes"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/storage"
+)
+
+// multipartOverhead is the size allowed for the form fields and boundaries of an upload, in addition to the file.
+const multipartOverhead = 64 << 10
+
+// attachmentKeyPrefix is the prefix of the blob keys of the attachment files.
+const attachmentKeyPrefix = "attachments/"
+
+var (
+	// errAttachmentOwner is returned when an attachment isn't of exactly one student or lesson.
+	errAttachmentOwner = errors.New("exactly one of student_id and lesson_id is required")
+	// errAttachmentTooLarge is returned when an uploaded file is larger than the maximum size of the attachments.
+	errAttachmentTooLarge = errors.New("file is too large")
+	// errAttachmentContentType is returned when the MIME type of an uploaded file isn't accepted.
+	errAttachmentContentType = errors.New("file type is not accepted")
+	// errAttachmentContentMismatch is returned when the content of an uploaded file isn't of its declared MIME type.
+	errAttachmentContentMismatch = errors.New("file content doesn't match its type")
+)
+
+// sniffLen is the number of bytes the MIME type of a file is detected from, as by http.DetectContentType.
+const sniffLen = 512
+
+// oleContentType is the MIME type of OLE compound files, such as Word 97-2003 documents, that http.DetectContentType doesn't detect.
+const oleContentType = "application/x-ole-storage"
+
+// oleSignature are the first bytes of OLE compound files.
+var oleSignature = []byte("\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1")
+
+// containerContentTypes are the MIME types detected from the content of formats stored in a generic container,
+// keyed by the MIME type of the format, such as application/zip for Word documents.
+var containerContentTypes = map[string]string{
+	"application/msword": oleContentType,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": "application/zip",
+}
+
+// attachmentResponse is an attachment with the link its file is downloaded from.
+type attachmentResponse struct {
+	db.Attachment
+	DownloadURL string `json:"download_url"`
+}
+
+func newAttachmentResponse(attachment db.Attachment) attachmentResponse {
+	return attachmentResponse{
+		Attachment:  attachment,
+		DownloadURL: fmt.Sprintf("/attachments/%d/download", attachment.AttachmentID),
+	}
+}
+
+type createAttachmentRequest struct {
+	File        *multipart.FileHeader `form:"file" binding:"required"`
+	StudentID   int64                 `form:"student_id" binding:"omitempty,min=1"`
+	LessonID    int64                 `form:"lesson_id" binding:"omitempty,min=1"`
+	Description string                `form:"description"`
+}
+
+// createAttachment uploads a file, such as a worksheet, exam paper or signed agreement, and attaches it
+// to a student or to a lesson. The request is a multipart form with the file in its file field.
+// Files larger than the maximum size are rejected with 413, and files of types that aren't accepted,
+// or whose content isn't of their declared type, with 415.
+func (server *Server) createAttachment(ctx *gin.Context) {
+	var req createAttachmentRequest
+
+	limits := server.attachmentLimits
+	if limits.MaxSize > 0 {
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, limits.MaxSize+multipartOverhead)
+	}
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(errAttachmentTooLarge))
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if (req.StudentID == 0) == (req.LessonID == 0) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errAttachmentOwner))
+		return
+	}
+
+	if limits.MaxSize > 0 && req.File.Size > limits.MaxSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(errAttachmentTooLarge))
+		return
+	}
+
+	contentType, ok := limits.contentType(req.File)
+	if !ok {
+		ctx.JSON(http.StatusUnsupportedMediaType, errorResponse(errAttachmentContentType))
+		return
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	// the declared type of a file is only trusted if its content is of that type
+	if len(limits.ContentTypes) > 0 {
+		sniffed, err := sniffContentType(file)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if !contentTypeMatches(contentType, sniffed) {
+			ctx.JSON(http.StatusUnsupportedMediaType, errorResponse(errAttachmentContentMismatch))
+			return
+		}
+	}
+
+	key, err := newAttachmentKey(req.File.Filename)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err := server.blobs.Put(ctx, key, file, req.File.Size, contentType); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateAttachmentParams{
+		StudentID:   sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0},
+		LessonID:    sql.NullInt64{Int64: req.LessonID, Valid: req.LessonID != 0},
+		Filename:    path.Base(strings.ReplaceAll(req.File.Filename, "\\", "/")),
+		ContentType: contentType,
+		Size:        req.File.Size,
+		StorageKey:  key,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+	}
+
+	attachment, err := server.store.CreateAttachmentTx(ctx, arg)
+	if err != nil {
+		// the file of an attachment that wasn't created is deleted
+		_ = server.blobs.Delete(ctx, key)
+
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAttachmentResponse(attachment))
+}
+
+// contentType returns the MIME type of an uploaded file, without its parameters,
+// and whether the type is accepted. Files without a valid type are application/octet-stream.
+func (limits AttachmentLimits) contentType(file *multipart.FileHeader) (string, bool) {
+	contentType, _, err := mime.ParseMediaType(file.Header.Get("Content-Type"))
+	if err != nil {
+		contentType = "application/octet-stream"
+	}
+
+	if len(limits.ContentTypes) == 0 {
+		return contentType, true
+	}
+
+	for _, accepted := range limits.ContentTypes {
+		if strings.EqualFold(strings.TrimSpace(accepted), contentType) {
+			return contentType, true
+		}
+	}
+
+	return contentType, false
+}
+
+// sniffContentType returns the MIME type detected from the first bytes of a file, without its parameters,
+// and rewinds the file so it is read from the start again.
+func sniffContentType(file multipart.File) (string, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	head = head[:n]
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	if bytes.HasPrefix(head, oleSignature) {
+		return oleContentType, nil
+	}
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "application/octet-stream", nil
+	}
+
+	return contentType, nil
+}
+
+// contentTypeMatches returns true if the MIME type detected from the content of a file is its declared type,
+// or the container of the declared type.
+func contentTypeMatches(declared, sniffed string) bool {
+	declared = strings.ToLower(declared)
+	return declared == sniffed || containerContentTypes[declared] == sniffed
+}
+
+// newAttachmentKey returns a new random blob key of an attachment file, with the extension of its file name.
+func newAttachmentKey(filename string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	ext := strings.ToLower(path.Ext(strings.ReplaceAll(filename, "\\", "/")))
+	if len(ext) > 10 || strings.ContainsAny(ext, " /") {
+		ext = ""
+	}
+
+	return attachmentKeyPrefix + hex.EncodeToString(b) + ext, nil
+}
+
+type getAttachmentRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// bindAttachment binds the attachment ID and gets the attachment.
+// In case of an error the error response is sent and ok is false.
+func (server *Server) bindAttachment(ctx *gin.Context) (attachment db.Attachment, ok bool) {
+	var req getAttachmentRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return attachment, false
+	}
+
+	attachment, err := server.store.GetAttachment(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return attachment, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return attachment, false
+	}
+
+	return attachment, true
+}
+
+// getAttachment returns an attachment with its download link.
+func (server *Server) getAttachment(ctx *gin.Context) {
+	attachment, ok := server.bindAttachment(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAttachmentResponse(attachment))
+}
+
+// downloadAttachment returns the file of an attachment.
+func (server *Server) downloadAttachment(ctx *gin.Context) {
+	attachment, ok := server.bindAttachment(ctx)
+	if !ok {
+		return
+	}
+
+	file, err := server.blobs.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	headers := map[string]string{
+		"Content-Disposition": attachmentDisposition(attachment.Filename),
+	}
+	ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, file, headers)
+}
+
+type listAttachmentsRequest struct {
+	StudentID int64 `form:"student_id" binding:"omitempty,min=1"`
+	LessonID  int64 `form:"lesson_id" binding:"omitempty,min=1"`
+	PageID    int32 `form:"page_id" binding:"required,min=1"`
+	PageSize  int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listAttachments returns the attachments, newest first, optionally of a single student or lesson.
+func (server *Server) listAttachments(ctx *gin.Context) {
+	var req listAttachmentsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListAttachmentsParams{
+		StudentID: sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0},
+		LessonID:  sql.NullInt64{Int64: req.LessonID, Valid: req.LessonID != 0},
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	}
+
+	attachments, err := server.store.ListAttachments(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]attachmentResponse, len(attachments))
+	for i, attachment := range attachments {
+		rsp[i] = newAttachmentResponse(attachment)
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// deleteAttachment deletes an attachment and its file.
+func (server *Server) deleteAttachment(ctx *gin.Context) {
+	attachment, ok := server.bindAttachment(ctx)
+	if !ok {
+		return
+	}
+
+	// the file is deleted first, so the attachment is kept to retry the deletion if it fails
+	err := server.blobs.Delete(ctx, attachment.StorageKey)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeleteAttachment(ctx, attachment.AttachmentID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Attachment deleted successfully"))
+}
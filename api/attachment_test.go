@@ -0,0 +1,695 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/storage"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// memoryBlobStore is a storage.BlobStore that keeps the blobs in memory
+type memoryBlobStore struct {
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	deleteErr error // returned by Delete if not nil
+}
+
+func (s *memoryBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *memoryBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryBlobStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	delete(s.blobs, key)
+	return nil
+}
+
+// failDelete sets the error returned by Delete, or clears it if err is nil
+func (s *memoryBlobStore) failDelete(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleteErr = err
+}
+
+// has returns true if the blob key exists
+func (s *memoryBlobStore) has(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.blobs[key]
+	return ok
+}
+
+// testBlobs is the blob store of the test servers
+var testBlobs = &memoryBlobStore{blobs: make(map[string][]byte)}
+
+// testAttachmentLimits are the attachment limits of the test servers
+var testAttachmentLimits = AttachmentLimits{
+	MaxSize:      1024,
+	ContentTypes: []string{"application/pdf", "text/plain"},
+}
+
+func TestAttachmentAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createAttachment":   createAttachmentTestCasesBuilder(),
+		"Test_getAttachment":      getAttachmentTestCasesBuilder(),
+		"Test_downloadAttachment": downloadAttachmentTestCasesBuilder(),
+		"Test_listAttachments":    listAttachmentsTestCasesBuilder(),
+		"Test_deleteAttachment":   deleteAttachmentTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomAttachment() db.Attachment {
+	data := []byte(util.RandomNote())
+
+	return db.Attachment{
+		AttachmentID: util.RandomInt64(1, 1000),
+		StudentID:    sql.NullInt64{Int64: util.RandomInt64(1, 1000), Valid: true},
+		Filename:     util.RandomName() + ".txt",
+		ContentType:  "text/plain",
+		Size:         int64(len(data)),
+		StorageKey:   fmt.Sprintf("attachments/%d.txt", util.RandomInt64(1, 1000000)),
+		Description:  sql.NullString{String: util.RandomNote(), Valid: true},
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// newAttachmentForm creates the multipart form of an upload with a file and form fields
+func newAttachmentForm(filename, contentType string, data []byte, fields map[string]string) multipartBody {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			panic(err)
+		}
+	}
+
+	if filename != "" {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, filename))
+		header.Set("Content-Type", contentType)
+
+		part, err := w.CreatePart(header)
+		if err != nil {
+			panic(err)
+		}
+		if _, err := part.Write(data); err != nil {
+			panic(err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		panic(err)
+	}
+
+	return multipartBody{contentType: w.FormDataContentType(), data: buf.Bytes()}
+}
+
+// createAttachmentTestCasesBuilder creates a slice of test cases for the createAttachment API
+func createAttachmentTestCasesBuilder() testCases {
+	var testCases testCases
+
+	attachment := randomAttachment()
+	data := []byte(util.RandomNote())
+	attachment.Size = int64(len(data))
+
+	fields := map[string]string{
+		"student_id":  fmt.Sprint(attachment.StudentID.Int64),
+		"description": attachment.Description.String,
+	}
+	body := newAttachmentForm(attachment.Filename, "text/plain; charset=utf-8", data, fields)
+
+	// matchArg matches the arguments of the attachment and records the storage key
+	var storageKey string
+	matchArg := mock.MatchedBy(func(arg db.CreateAttachmentParams) bool {
+		storageKey = arg.StorageKey
+		return arg.StudentID == attachment.StudentID &&
+			!arg.LessonID.Valid &&
+			arg.Filename == attachment.Filename &&
+			arg.ContentType == "text/plain" &&
+			arg.Size == attachment.Size &&
+			arg.Description == attachment.Description &&
+			strings.HasPrefix(arg.StorageKey, attachmentKeyPrefix) &&
+			strings.HasSuffix(arg.StorageKey, ".txt")
+	})
+
+	methodName := "CreateAttachmentTx"
+	url := "/attachments"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       body,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, matchArg).
+				Return(attachment, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, newAttachmentResponse(attachment))
+
+			// the file is stored in the blob store
+			stored, err := testBlobs.Get(context.Background(), storageKey)
+			assert.NoError(t, err)
+			storedData, _ := io.ReadAll(stored)
+			assert.Equal(t, data, storedData)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       body,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, matchArg).
+				Return(db.Attachment{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+			// the file of the attachment that wasn't created is deleted
+			assert.False(t, testBlobs.has(storageKey))
+		},
+	})
+
+	// create a test case for Invalid Owner response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Owner",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body: newAttachmentForm(attachment.Filename, "text/plain", data, map[string]string{
+			"student_id": fmt.Sprint(attachment.StudentID.Int64),
+			"lesson_id":  fmt.Sprint(util.RandomInt64(1, 1000)),
+		}),
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Missing File response
+	testCases = append(testCases, testCase{
+		name:       "Missing File",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       newAttachmentForm("", "", nil, fields),
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Too Large response
+	testCases = append(testCases, testCase{
+		name:       "Too Large",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       newAttachmentForm(attachment.Filename, "text/plain", bytes.Repeat([]byte("a"), 2048), fields),
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Too Large Body response, rejected while the body is read
+	testCases = append(testCases, testCase{
+		name:       "Too Large Body",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       newAttachmentForm(attachment.Filename, "text/plain", bytes.Repeat([]byte("a"), 2*multipartOverhead), fields),
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Unsupported Media Type response
+	testCases = append(testCases, testCase{
+		name:       "Unsupported Media Type",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       newAttachmentForm("run.exe", "application/x-msdownload", data, fields),
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create test cases for Content Mismatch responses, of files labelled as an accepted type
+	mismatches := map[string][]byte{
+		"Executable As PDF": append([]byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00"), make([]byte, 64)...),
+		"Text As PDF":       data,
+		"HTML As Text":      []byte("<!DOCTYPE html><html><script>alert(1)</script></html>"),
+	}
+	for name, content := range mismatches {
+		declared := "application/pdf"
+		if name == "HTML As Text" {
+			declared = "text/plain"
+		}
+
+		testCases = append(testCases, testCase{
+			name:       "Content Mismatch " + name,
+			httpMethod: http.MethodPost,
+			url:        url,
+			body:       newAttachmentForm("file.pdf", declared, content, fields),
+			buildStub: func(mockStore *mocks.MockStore) {
+				mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+			},
+			checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+				mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+			},
+		})
+	}
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       body,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.Attachment{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// getAttachmentTestCasesBuilder creates a slice of test cases for the getAttachment API
+func getAttachmentTestCasesBuilder() testCases {
+	var testCases testCases
+
+	attachment := randomAttachment()
+	methodName := "GetAttachment"
+	url := fmt.Sprintf("/attachments/%d", attachment.AttachmentID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, attachment.AttachmentID).
+				Return(attachment, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, newAttachmentResponse(attachment))
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, attachment.AttachmentID).
+				Return(db.Attachment{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/attachments/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// downloadAttachmentTestCasesBuilder creates a slice of test cases for the downloadAttachment API
+func downloadAttachmentTestCasesBuilder() testCases {
+	var testCases testCases
+
+	attachment := randomAttachment()
+	attachment.Filename = "résumé \"final\".txt"
+	data := []byte(util.RandomNote())
+	attachment.Size = int64(len(data))
+
+	methodName := "GetAttachment"
+	url := fmt.Sprintf("/attachments/%d/download", attachment.AttachmentID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			_ = testBlobs.Put(context.Background(), attachment.StorageKey, bytes.NewReader(data), attachment.Size, attachment.ContentType)
+
+			mockStore.On(methodName, mock.Anything, attachment.AttachmentID).
+				Return(attachment, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, attachment.ContentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, `attachment; filename*=utf-8''r%C3%A9sum%C3%A9%20%22final%22.txt`, recorder.Header().Get("Content-Disposition"))
+			assert.Equal(t, data, recorder.Body.Bytes())
+		},
+	})
+
+	// create a test case for Not Found response of a missing file
+	testCases = append(testCases, testCase{
+		name:       "File Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			_ = testBlobs.Delete(context.Background(), attachment.StorageKey)
+
+			mockStore.On(methodName, mock.Anything, attachment.AttachmentID).
+				Return(attachment, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// listAttachmentsTestCasesBuilder creates a slice of test cases for the listAttachments API
+func listAttachmentsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	lessonID := util.RandomInt64(1, 1000)
+	var attachments []db.Attachment
+	var rsp []attachmentResponse
+	for i := 0; i < 5; i++ {
+		attachment := randomAttachment()
+		attachment.StudentID = sql.NullInt64{}
+		attachment.LessonID = sql.NullInt64{Int64: lessonID, Valid: true}
+		attachments = append(attachments, attachment)
+		rsp = append(rsp, newAttachmentResponse(attachment))
+	}
+
+	arg := db.ListAttachmentsParams{
+		LessonID: sql.NullInt64{Int64: lessonID, Valid: true},
+		Limit:    5,
+		Offset:   0,
+	}
+
+	methodName := "ListAttachments"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/attachments?lesson_id=%d&page_id=1&page_size=5", lessonID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(attachments, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, rsp)
+		},
+	})
+
+	// create a test case for Invalid Page ID response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Page ID",
+		httpMethod: http.MethodGet,
+		url:        "/attachments?page_id=0&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// deleteAttachmentTestCasesBuilder creates a slice of test cases for the deleteAttachment API
+func deleteAttachmentTestCasesBuilder() testCases {
+	var testCases testCases
+
+	attachment := randomAttachment()
+	url := fmt.Sprintf("/attachments/%d", attachment.AttachmentID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			_ = testBlobs.Put(context.Background(), attachment.StorageKey, strings.NewReader("data"), 4, attachment.ContentType)
+
+			mockStore.On("GetAttachment", mock.Anything, attachment.AttachmentID).
+				Return(attachment, nil).
+				Once()
+			mockStore.On("DeleteAttachment", mock.Anything, attachment.AttachmentID).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+
+			// the file of the attachment is deleted
+			assert.False(t, testBlobs.has(attachment.StorageKey))
+		},
+	})
+
+	// create a test case for StatusOK response when the file is already deleted
+	testCases = append(testCases, testCase{
+		name:       "OK File Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			testBlobs.failDelete(storage.ErrNotFound)
+
+			mockStore.On("GetAttachment", mock.Anything, attachment.AttachmentID).
+				Return(attachment, nil).
+				Once()
+			mockStore.On("DeleteAttachment", mock.Anything, attachment.AttachmentID).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			testBlobs.failDelete(nil)
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response when the file can't be deleted
+	testCases = append(testCases, testCase{
+		name:       "File Delete Error",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			_ = testBlobs.Put(context.Background(), attachment.StorageKey, strings.NewReader("data"), 4, attachment.ContentType)
+			testBlobs.failDelete(errors.New("storage is unavailable"))
+
+			mockStore.On("GetAttachment", mock.Anything, attachment.AttachmentID).
+				Return(attachment, nil).
+				Once()
+			mockStore.On("DeleteAttachment", mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			testBlobs.failDelete(nil)
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+			// the attachment is kept with its file, so the deletion can be retried
+			mockStore.AssertNotCalled(t, "DeleteAttachment", mock.Anything, mock.Anything)
+			mockStore.On("DeleteAttachment", mock.Anything, mock.Anything).Unset()
+			assert.True(t, testBlobs.has(attachment.StorageKey))
+			_ = testBlobs.Delete(context.Background(), attachment.StorageKey)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetAttachment", mock.Anything, attachment.AttachmentID).
+				Return(db.Attachment{}, sql.ErrNoRows).
+				Once()
+			mockStore.On("DeleteAttachment", mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+			mockStore.On("DeleteAttachment", mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+func TestAttachmentDisposition(t *testing.T) {
+	filenames := []string{"report.pdf", "lesson notes.pdf", `a;b\c.pdf`, "résumé.pdf", "a\r\nX-Injected: 1.pdf"}
+
+	for _, filename := range filenames {
+		disposition := attachmentDisposition(filename)
+		assert.NotContains(t, disposition, "\r")
+		assert.NotContains(t, disposition, "\n")
+
+		// the header is parsed back into the same file name
+		mediaType, params, err := mime.ParseMediaType(disposition)
+		assert.NoError(t, err)
+		assert.Equal(t, "attachment", mediaType)
+		assert.Equal(t, filename, params["filename"])
+	}
+}
+
+func TestContentTypeMatches(t *testing.T) {
+	docx := "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+	testCases := []struct {
+		name     string
+		declared string
+		content  []byte
+		matches  bool
+	}{
+		{"PDF", "application/pdf", []byte("%PDF-1.7\n"), true},
+		{"Text", "text/plain", []byte("hello"), true},
+		{"PNG", "image/png", []byte("\x89PNG\x0D\x0A\x1A\x0A"), true},
+		{"Word Document", docx, []byte("PK\x03\x04"), true},
+		{"Word 97 Document", "application/msword", append(oleSignature, make([]byte, 8)...), true},
+		{"Text As PNG", "image/png", []byte("hello"), false},
+		{"Text As Word Document", docx, []byte("hello"), false},
+		{"Binary As Word 97 Document", "application/msword", []byte{0x00, 0x01, 0x02}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			file := nopCloserFile{bytes.NewReader(tc.content)}
+
+			sniffed, err := sniffContentType(file)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.matches, contentTypeMatches(tc.declared, sniffed), sniffed)
+
+			// the file is read from the start again
+			content, err := io.ReadAll(file)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.content, content)
+		})
+	}
+}
+
+// nopCloserFile is a multipart.File of bytes in memory
+type nopCloserFile struct {
+	*bytes.Reader
+}
+
+func (nopCloserFile) Close() error {
+	return nil
+}
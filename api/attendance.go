@@ -0,0 +1,153 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type lessonUriRequest struct {
+	LessonID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type addLessonStudentRequest struct {
+	StudentID int64           `json:"student_id" binding:"required,min=1"`
+	HourlyFee sql.NullFloat64 `json:"hourly_fee"`
+	Discount  float64         `json:"discount" binding:"min=0,max=1"`
+	Free      bool            `json:"free"`
+	Notes     sql.NullString  `json:"notes"`
+}
+
+// addLessonStudent adds a student to an existing lesson, invoiced unless attending for free,
+// and recalculates the invoices of a group priced lesson.
+func (server *Server) addLessonStudent(ctx *gin.Context) {
+	var uri lessonUriRequest
+	var req addLessonStudentRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.AddLessonStudentTxParams{
+		LessonID:  uri.LessonID,
+		StudentID: req.StudentID,
+		HourlyFee: req.HourlyFee,
+		Discount:  req.Discount,
+		Free:      req.Free,
+		Notes:     req.Notes,
+	}
+
+	lesson, err := server.store.AddLessonStudentTx(ctx, arg)
+	if err != nil {
+		server.lessonStudentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lesson)
+}
+
+type removeLessonStudentRequest struct {
+	LessonID  int64 `uri:"id" binding:"required,min=1"`
+	StudentID int64 `uri:"student_id" binding:"required,min=1"`
+}
+
+// removeLessonStudent removes a student from a lesson, and recalculates the invoices of a group priced lesson.
+func (server *Server) removeLessonStudent(ctx *gin.Context) {
+	var req removeLessonStudentRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	lesson, err := server.store.RemoveLessonStudentTx(ctx, req.LessonID, req.StudentID)
+	if err != nil {
+		server.lessonStudentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lesson)
+}
+
+// getLessonAttendance returns the attendance of all the students of a lesson.
+func (server *Server) getLessonAttendance(ctx *gin.Context) {
+	var req lessonUriRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	attendance, err := server.store.GetLessonAttendanceByLesson(ctx, req.LessonID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attendance)
+}
+
+type updateLessonAttendanceRequest struct {
+	StudentID int64          `json:"student_id" binding:"required,min=1"`
+	Status    string         `json:"status" binding:"required,oneof=attended absent excused"`
+	Notes     sql.NullString `json:"notes"`
+}
+
+// updateLessonAttendance updates the attendance of a student of a lesson. The invoice of the student isn't changed.
+func (server *Server) updateLessonAttendance(ctx *gin.Context) {
+	var uri lessonUriRequest
+	var req updateLessonAttendanceRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateLessonAttendanceParams{
+		LessonID:  uri.LessonID,
+		StudentID: req.StudentID,
+		Status:    req.Status,
+		Notes:     req.Notes,
+	}
+
+	attendance, err := server.store.UpdateLessonAttendance(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attendance)
+}
+
+// lessonStudentErrorResponse responds with the status of an error returned by the transactions adding or removing lesson students.
+func (server *Server) lessonStudentErrorResponse(ctx *gin.Context, err error) {
+	switch {
+	case err == sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, db.ErrNoPricingTier), errors.Is(err, db.ErrNoHourlyFee):
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	case errors.Is(err, db.ErrStudentInLesson):
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
@@ -0,0 +1,366 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAttendanceAPIs(t *testing.T) {
+	tests := tests{
+		"Test_addLessonStudent":       addLessonStudentTestCasesBuilder(),
+		"Test_removeLessonStudent":    removeLessonStudentTestCasesBuilder(),
+		"Test_getLessonAttendance":    getLessonAttendanceTestCasesBuilder(),
+		"Test_updateLessonAttendance": updateLessonAttendanceTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomLessonAttendance(lesson db.LessonWithInvoices) []db.LessonAttendance {
+	var attendance []db.LessonAttendance
+	for _, invoice := range lesson.Invoices {
+		attendance = append(attendance, db.LessonAttendance{
+			LessonID:  lesson.Lesson.LessonID,
+			StudentID: invoice.StudentID,
+			Status:    db.AttendanceStatusAttended,
+			UpdatedAt: lesson.Lesson.LessonDatetime,
+		})
+	}
+	return attendance
+}
+
+// addLessonStudentTestCasesBuilder creates a slice of test cases for the addLessonStudent API
+func addLessonStudentTestCasesBuilder() testCases {
+	var testCases testCases
+
+	plan := randomGroupPricingPlan(db.PricingModePerHead)
+	lesson := randomGroupLesson(plan, 3)
+	req := addLessonStudentRequest{
+		StudentID: 3,
+		HourlyFee: sql.NullFloat64{Float64: 120, Valid: true},
+		Discount:  0.2,
+	}
+
+	arg := db.AddLessonStudentTxParams{
+		LessonID:  lesson.Lesson.LessonID,
+		StudentID: req.StudentID,
+		HourlyFee: req.HourlyFee,
+		Discount:  req.Discount,
+	}
+
+	methodName := "AddLessonStudentTx"
+	url := fmt.Sprintf("/lessons/%d/students", lesson.Lesson.LessonID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(lesson, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, lesson)
+		},
+	})
+
+	// create a test case for Student In Lesson response
+	testCases = append(testCases, testCase{
+		name:       "Student In Lesson",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.LessonWithInvoices{}, db.ErrStudentInLesson).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusConflict, recorder.Code)
+		},
+	})
+
+	// create a test case for No Hourly Fee response
+	testCases = append(testCases, testCase{
+		name:       "No Hourly Fee",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.LessonWithInvoices{}, db.ErrNoHourlyFee).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		},
+	})
+
+	// create a test case for StatusOK response of a student attending for free
+	freeReq := addLessonStudentRequest{StudentID: 4, Free: true}
+	freeArg := db.AddLessonStudentTxParams{LessonID: lesson.Lesson.LessonID, StudentID: 4, Free: true}
+
+	testCases = append(testCases, testCase{
+		name:       "OK Free",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       freeReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, freeArg).
+				Return(lesson, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodPost,
+		url:        "/lessons/0/students",
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// removeLessonStudentTestCasesBuilder creates a slice of test cases for the removeLessonStudent API
+func removeLessonStudentTestCasesBuilder() testCases {
+	var testCases testCases
+
+	plan := randomGroupPricingPlan(db.PricingModeSplit)
+	lesson := randomGroupLesson(plan, 2)
+	lessonID := lesson.Lesson.LessonID
+	studentID := int64(3)
+
+	methodName := "RemoveLessonStudentTx"
+	url := fmt.Sprintf("/lessons/%d/students/%d", lessonID, studentID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, lessonID, studentID).
+				Return(lesson, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, lesson)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, lessonID, studentID).
+				Return(db.LessonWithInvoices{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Student ID response by passing url with student_id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid Student ID",
+		httpMethod: http.MethodDelete,
+		url:        fmt.Sprintf("/lessons/%d/students/0", lessonID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getLessonAttendanceTestCasesBuilder creates a slice of test cases for the getLessonAttendance API
+func getLessonAttendanceTestCasesBuilder() testCases {
+	var testCases testCases
+
+	lesson := randomGroupLesson(randomGroupPricingPlan(db.PricingModePerHead), 3)
+	attendance := randomLessonAttendance(lesson)
+	id := lesson.Lesson.LessonID
+	methodName := "GetLessonAttendanceByLesson"
+	url := fmt.Sprintf("/lessons/%d/attendance", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(attendance, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, attendance)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return([]db.LessonAttendance{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/lessons/0/attendance",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// updateLessonAttendanceTestCasesBuilder creates a slice of test cases for the updateLessonAttendance API
+func updateLessonAttendanceTestCasesBuilder() testCases {
+	var testCases testCases
+
+	lesson := randomGroupLesson(randomGroupPricingPlan(db.PricingModePerHead), 1)
+	attendance := randomLessonAttendance(lesson)[0]
+	attendance.Status = db.AttendanceStatusAbsent
+	attendance.Notes = sql.NullString{String: "sick", Valid: true}
+
+	req := updateLessonAttendanceRequest{
+		StudentID: attendance.StudentID,
+		Status:    attendance.Status,
+		Notes:     attendance.Notes,
+	}
+
+	arg := db.UpdateLessonAttendanceParams{
+		LessonID:  attendance.LessonID,
+		StudentID: attendance.StudentID,
+		Status:    attendance.Status,
+		Notes:     attendance.Notes,
+	}
+
+	methodName := "UpdateLessonAttendance"
+	url := fmt.Sprintf("/lessons/%d/attendance", attendance.LessonID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(attendance, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, attendance)
+		},
+	})
+
+	// create a test case for Not Found response of a student not in the lesson
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.LessonAttendance{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Status response
+	invalidReq := req
+	invalidReq.Status = "late"
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Status",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
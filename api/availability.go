@@ -0,0 +1,302 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type createAvailabilityWindowRequest struct {
+	Weekday     int32  `json:"weekday" binding:"min=0,max=6"`
+	StartMinute int32  `json:"start_minute" binding:"min=0,max=1439"`
+	EndMinute   int32  `json:"end_minute" binding:"required,max=1440,gtfield=StartMinute"`
+	Timezone    string `json:"timezone"`
+	LocationID  int64  `json:"location_id" binding:"required,min=1"`
+}
+
+// createAvailabilityWindow adds a window to the weekly availability of the tutor.
+// The times of the window are minutes after midnight in its timezone, UTC unless given.
+func (server *Server) createAvailabilityWindow(ctx *gin.Context) {
+	var req createAvailabilityWindowRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	timezone, err := availabilityTimezone(req.Timezone)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateAvailabilityWindowParams{
+		Weekday:     req.Weekday,
+		StartMinute: req.StartMinute,
+		EndMinute:   req.EndMinute,
+		Timezone:    timezone,
+		LocationID:  req.LocationID,
+	}
+
+	window, err := server.store.CreateAvailabilityWindow(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, window)
+}
+
+// availabilityTimezone returns the timezone of an availability window, UTC if it is empty,
+// or an error if it isn't a valid IANA timezone.
+func availabilityTimezone(timezone string) (string, error) {
+	if timezone == "" {
+		return "UTC", nil
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return "", err
+	}
+
+	return timezone, nil
+}
+
+type getAvailabilityWindowRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getAvailabilityWindow(ctx *gin.Context) {
+	var req getAvailabilityWindowRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	window, err := server.store.GetAvailabilityWindow(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, window)
+}
+
+// listAvailabilityWindows returns the weekly availability of the tutor, by weekday and time.
+func (server *Server) listAvailabilityWindows(ctx *gin.Context) {
+	windows, err := server.store.ListAvailabilityWindows(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, windows)
+}
+
+type updateAvailabilityWindowRequest struct {
+	WindowID    int64  `json:"window_id" binding:"required"`
+	Weekday     int32  `json:"weekday" binding:"min=0,max=6"`
+	StartMinute int32  `json:"start_minute" binding:"min=0,max=1439"`
+	EndMinute   int32  `json:"end_minute" binding:"required,max=1440,gtfield=StartMinute"`
+	Timezone    string `json:"timezone"`
+	LocationID  int64  `json:"location_id" binding:"required,min=1"`
+}
+
+func (server *Server) updateAvailabilityWindow(ctx *gin.Context) {
+	var req updateAvailabilityWindowRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	timezone, err := availabilityTimezone(req.Timezone)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateAvailabilityWindowParams{
+		WindowID:    req.WindowID,
+		Weekday:     req.Weekday,
+		StartMinute: req.StartMinute,
+		EndMinute:   req.EndMinute,
+		Timezone:    timezone,
+		LocationID:  req.LocationID,
+	}
+
+	err = server.store.UpdateAvailabilityWindow(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Availability window updated successfully"))
+}
+
+func (server *Server) deleteAvailabilityWindow(ctx *gin.Context) {
+	var req getAvailabilityWindowRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeleteAvailabilityWindow(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Availability window deleted successfully"))
+}
+
+type createAvailabilityExceptionRequest struct {
+	StartDatetime time.Time      `json:"start_datetime" binding:"required"`
+	EndDatetime   time.Time      `json:"end_datetime" binding:"required,gtfield=StartDatetime"`
+	Available     bool           `json:"available"`
+	LocationID    int64          `json:"location_id" binding:"required_if=Available true"`
+	Notes         sql.NullString `json:"notes"`
+}
+
+// createAvailabilityException adds an exception to the weekly availability of the tutor,
+// either a period the tutor is unavailable, such as a holiday, or extra hours at a location.
+func (server *Server) createAvailabilityException(ctx *gin.Context) {
+	var req createAvailabilityExceptionRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateAvailabilityExceptionParams{
+		StartDatetime: req.StartDatetime,
+		EndDatetime:   req.EndDatetime,
+		Available:     req.Available,
+		LocationID:    sql.NullInt64{Int64: req.LocationID, Valid: req.LocationID != 0},
+		Notes:         req.Notes,
+	}
+
+	exception, err := server.store.CreateAvailabilityException(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exception)
+}
+
+type getAvailabilityExceptionRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getAvailabilityException(ctx *gin.Context) {
+	var req getAvailabilityExceptionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	exception, err := server.store.GetAvailabilityException(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exception)
+}
+
+type listAvailabilityExceptionsRequest struct {
+	StartDate string `form:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" binding:"required,datetime=2006-01-02"`
+}
+
+// listAvailabilityExceptions returns the availability exceptions overlapping a period, by start datetime.
+// The end date is inclusive.
+func (server *Server) listAvailabilityExceptions(ctx *gin.Context) {
+	var req listAvailabilityExceptionsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	start, end, err := parsePeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	exceptions, err := server.store.ListAvailabilityExceptions(ctx, db.ListAvailabilityExceptionsParams{
+		StartDatetime: start,
+		EndDatetime:   end,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exceptions)
+}
+
+func (server *Server) deleteAvailabilityException(ctx *gin.Context) {
+	var req getAvailabilityExceptionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeleteAvailabilityException(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Availability exception deleted successfully"))
+}
+
+// parsePeriod parses the start and end dates of a period. The end date is inclusive,
+// so the returned end is the midnight after it.
+func parsePeriod(startDate, endDate string) (start, end time.Time, err error) {
+	start, err = time.Parse(dateLayout, startDate)
+	if err != nil {
+		return start, end, err
+	}
+
+	end, err = time.Parse(dateLayout, endDate)
+	if err != nil {
+		return start, end, err
+	}
+
+	if end.Before(start) {
+		return start, end, errInvalidPeriod
+	}
+
+	// the end date is inclusive
+	return start, end.AddDate(0, 0, 1), nil
+}
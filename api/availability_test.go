@@ -0,0 +1,427 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAvailabilityAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createAvailabilityWindow":    createAvailabilityWindowTestCasesBuilder(),
+		"Test_listAvailabilityWindows":     listAvailabilityWindowsTestCasesBuilder(),
+		"Test_updateAvailabilityWindow":    updateAvailabilityWindowTestCasesBuilder(),
+		"Test_deleteAvailabilityWindow":    deleteAvailabilityWindowTestCasesBuilder(),
+		"Test_createAvailabilityException": createAvailabilityExceptionTestCasesBuilder(),
+		"Test_listAvailabilityExceptions":  listAvailabilityExceptionsTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomAvailabilityWindow() db.AvailabilityWindow {
+	start := int32(util.RandomInt64(8, 16) * 60)
+
+	return db.AvailabilityWindow{
+		WindowID:    util.RandomInt64(1, 1000),
+		Weekday:     int32(util.RandomInt64(0, 6)),
+		StartMinute: start,
+		EndMinute:   start + 120,
+		Timezone:    "Asia/Jerusalem",
+		LocationID:  util.RandomInt64(1, 1000),
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// createAvailabilityWindowTestCasesBuilder creates a slice of test cases for the createAvailabilityWindow API
+func createAvailabilityWindowTestCasesBuilder() testCases {
+	var testCases testCases
+
+	window := randomAvailabilityWindow()
+	req := createAvailabilityWindowRequest{
+		Weekday:     window.Weekday,
+		StartMinute: window.StartMinute,
+		EndMinute:   window.EndMinute,
+		Timezone:    window.Timezone,
+		LocationID:  window.LocationID,
+	}
+
+	arg := db.CreateAvailabilityWindowParams{
+		Weekday:     window.Weekday,
+		StartMinute: window.StartMinute,
+		EndMinute:   window.EndMinute,
+		Timezone:    window.Timezone,
+		LocationID:  window.LocationID,
+	}
+
+	methodName := "CreateAvailabilityWindow"
+	url := "/availability_windows"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(window, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, window)
+		},
+	})
+
+	// create a test case for StatusOK response of a window in UTC
+	utcReq := req
+	utcReq.Timezone = ""
+	utcArg := arg
+	utcArg.Timezone = "UTC"
+	testCases = append(testCases, testCase{
+		name:       "Default Timezone",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       utcReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, utcArg).
+				Return(window, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Timezone response
+	invalidReq := req
+	invalidReq.Timezone = "Nowhere/Nothing"
+	testCases = append(testCases, testCase{
+		name:       "Invalid Timezone",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Invalid End Minute response
+	invalidReq = req
+	invalidReq.EndMinute = req.StartMinute
+	testCases = append(testCases, testCase{
+		name:       "Invalid End Minute",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.AvailabilityWindow{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// listAvailabilityWindowsTestCasesBuilder creates a slice of test cases for the listAvailabilityWindows API
+func listAvailabilityWindowsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	windows := []db.AvailabilityWindow{randomAvailabilityWindow(), randomAvailabilityWindow()}
+	methodName := "ListAvailabilityWindows"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/availability_windows",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything).
+				Return(windows, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, windows)
+		},
+	})
+
+	return testCases
+}
+
+// updateAvailabilityWindowTestCasesBuilder creates a slice of test cases for the updateAvailabilityWindow API
+func updateAvailabilityWindowTestCasesBuilder() testCases {
+	var testCases testCases
+
+	window := randomAvailabilityWindow()
+	req := updateAvailabilityWindowRequest{
+		WindowID:    window.WindowID,
+		Weekday:     window.Weekday,
+		StartMinute: window.StartMinute,
+		EndMinute:   window.EndMinute,
+		Timezone:    window.Timezone,
+		LocationID:  window.LocationID,
+	}
+
+	arg := db.UpdateAvailabilityWindowParams{
+		WindowID:    window.WindowID,
+		Weekday:     window.Weekday,
+		StartMinute: window.StartMinute,
+		EndMinute:   window.EndMinute,
+		Timezone:    window.Timezone,
+		LocationID:  window.LocationID,
+	}
+
+	methodName := "UpdateAvailabilityWindow"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        "/availability_windows",
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// deleteAvailabilityWindowTestCasesBuilder creates a slice of test cases for the deleteAvailabilityWindow API
+func deleteAvailabilityWindowTestCasesBuilder() testCases {
+	var testCases testCases
+
+	windowID := util.RandomInt64(1, 1000)
+	methodName := "DeleteAvailabilityWindow"
+	url := fmt.Sprintf("/availability_windows/%d", windowID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, windowID).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, windowID).
+				Return(int64(0), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// createAvailabilityExceptionTestCasesBuilder creates a slice of test cases for the createAvailabilityException API
+func createAvailabilityExceptionTestCasesBuilder() testCases {
+	var testCases testCases
+
+	start := time.Now().UTC().AddDate(0, 0, 7).Truncate(time.Hour)
+	exception := db.AvailabilityException{
+		ExceptionID:   util.RandomInt64(1, 1000),
+		StartDatetime: start,
+		EndDatetime:   start.AddDate(0, 0, 7),
+		Available:     false,
+		Notes:         sql.NullString{String: util.RandomNote(), Valid: true},
+		CreatedAt:     time.Now().UTC().Truncate(time.Second),
+	}
+
+	req := createAvailabilityExceptionRequest{
+		StartDatetime: exception.StartDatetime,
+		EndDatetime:   exception.EndDatetime,
+		Available:     exception.Available,
+		Notes:         exception.Notes,
+	}
+
+	arg := db.CreateAvailabilityExceptionParams{
+		StartDatetime: exception.StartDatetime,
+		EndDatetime:   exception.EndDatetime,
+		Available:     exception.Available,
+		Notes:         exception.Notes,
+	}
+
+	methodName := "CreateAvailabilityException"
+	url := "/availability_exceptions"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(exception, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, exception)
+		},
+	})
+
+	// create a test case for Missing Location response of an available period
+	invalidReq := req
+	invalidReq.Available = true
+	testCases = append(testCases, testCase{
+		name:       "Missing Location",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Invalid Period response
+	invalidReq = req
+	invalidReq.EndDatetime = req.StartDatetime
+	testCases = append(testCases, testCase{
+		name:       "Invalid Period",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// listAvailabilityExceptionsTestCasesBuilder creates a slice of test cases for the listAvailabilityExceptions API
+func listAvailabilityExceptionsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	exceptions := []db.AvailabilityException{{
+		ExceptionID:   util.RandomInt64(1, 1000),
+		StartDatetime: time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC),
+		EndDatetime:   time.Date(2024, time.June, 12, 0, 0, 0, 0, time.UTC),
+		Available:     false,
+	}}
+
+	// the end date is inclusive
+	arg := db.ListAvailabilityExceptionsParams{
+		StartDatetime: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+		EndDatetime:   time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	methodName := "ListAvailabilityExceptions"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/availability_exceptions?start_date=2024-06-01&end_date=2024-06-30",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(exceptions, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, exceptions)
+		},
+	})
+
+	// create a test case for Invalid Period response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Period",
+		httpMethod: http.MethodGet,
+		url:        "/availability_exceptions?start_date=2024-06-30&end_date=2024-06-01",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
@@ -0,0 +1,277 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/github-real-lb/tutor-management-web/booking"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// maxBookingPeriod is the longest period the free slots are returned for.
+const maxBookingPeriod = 31 * 24 * time.Hour
+
+var (
+	// errBookingPeriodTooLong is returned when the free slots are requested for a period longer than maxBookingPeriod.
+	errBookingPeriodTooLong = errors.New("the period must not be longer than 31 days")
+	// errBookingSlotNotFree is returned when a lesson is requested at a time the tutor isn't available.
+	errBookingSlotNotFree = errors.New("the requested time is not available")
+)
+
+type listBookingSlotsRequest struct {
+	StartDate string `form:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" binding:"required,datetime=2006-01-02"`
+	Duration  int64  `form:"duration" binding:"required,min=15,max=480"`
+}
+
+// listBookingSlots returns the free slots for a lesson of duration minutes in a period, by start time.
+// The slots are computed from the availability of the tutor minus the existing lessons,
+// and start no earlier than now. The end date is inclusive.
+func (server *Server) listBookingSlots(ctx *gin.Context) {
+	var req listBookingSlotsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	start, end, err := parsePeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if end.Sub(start) > maxBookingPeriod {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errBookingPeriodTooLong))
+		return
+	}
+
+	if now := time.Now().UTC(); start.Before(now) {
+		start = now
+	}
+
+	slots := []booking.Slot{}
+	if start.Before(end) {
+		availability, err := server.store.GetAvailabilityTx(ctx, start, end)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		free, err := booking.FreeSlots(availability, start, end, time.Duration(req.Duration)*time.Minute)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		slots = append(slots, free...)
+	}
+
+	ctx.JSON(http.StatusOK, slots)
+}
+
+type createBookingRequestRequest struct {
+	FirstName      string         `json:"first_name" binding:"required"`
+	LastName       string         `json:"last_name" binding:"required"`
+	Email          string         `json:"email" binding:"required_without=PhoneNumber,omitempty,email"`
+	PhoneNumber    string         `json:"phone_number"`
+	SubjectID      int64          `json:"subject_id" binding:"required,min=1"`
+	LessonDatetime time.Time      `json:"lesson_datetime" binding:"required"`
+	Duration       int64          `json:"duration" binding:"required,min=15,max=480"`
+	Notes          sql.NullString `json:"notes"`
+}
+
+// createBookingRequest requests a lesson at a free slot, for the tutor to approve.
+// The lesson is held at the location the tutor is available at. A request at a time
+// the tutor isn't available, or in the past, is rejected with 409, and a request for
+// an unknown subject with 404.
+func (server *Server) createBookingRequest(ctx *gin.Context) {
+	var req createBookingRequestRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	duration := time.Duration(req.Duration) * time.Minute
+	if req.LessonDatetime.Before(time.Now()) {
+		ctx.JSON(http.StatusConflict, errorResponse(errBookingSlotNotFree))
+		return
+	}
+
+	if _, err := server.store.GetLessonSubject(ctx, req.SubjectID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	availability, err := server.store.GetAvailabilityTx(ctx, req.LessonDatetime, req.LessonDatetime.Add(duration))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	slot, ok, err := booking.IsFree(availability, req.LessonDatetime, duration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !ok {
+		ctx.JSON(http.StatusConflict, errorResponse(errBookingSlotNotFree))
+		return
+	}
+
+	arg := db.CreateBookingRequestParams{
+		FirstName:      req.FirstName,
+		LastName:       req.LastName,
+		Email:          sql.NullString{String: req.Email, Valid: req.Email != ""},
+		PhoneNumber:    sql.NullString{String: req.PhoneNumber, Valid: req.PhoneNumber != ""},
+		SubjectID:      req.SubjectID,
+		LocationID:     slot.LocationID,
+		LessonDatetime: req.LessonDatetime,
+		Duration:       req.Duration,
+		Notes:          req.Notes,
+	}
+
+	request, err := server.store.CreateBookingRequest(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+type getBookingRequestRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getBookingRequest(ctx *gin.Context) {
+	var req getBookingRequestRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	request, err := server.store.GetBookingRequest(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+type listBookingRequestsRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending approved declined"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listBookingRequests returns the booking requests, oldest first, optionally with a single status.
+func (server *Server) listBookingRequests(ctx *gin.Context) {
+	var req listBookingRequestsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListBookingRequestsParams{
+		Status: sql.NullString{String: req.Status, Valid: req.Status != ""},
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	requests, err := server.store.ListBookingRequests(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, requests)
+}
+
+type approveBookingRequestRequest struct {
+	StudentID int64           `json:"student_id" binding:"omitempty,min=1"`
+	HourlyFee sql.NullFloat64 `json:"hourly_fee"`
+}
+
+// approveBookingRequest approves a pending booking request and creates its lesson, for an existing student
+// or for a new student with the contact details of the request.
+func (server *Server) approveBookingRequest(ctx *gin.Context) {
+	var uri getBookingRequestRequest
+	var req approveBookingRequestRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.ApproveBookingRequestTx(ctx, db.ApproveBookingRequestTxParams{
+		BookingRequestID: uri.ID,
+		StudentID:        sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0},
+		HourlyFee:        req.HourlyFee,
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, db.ErrNoHourlyFee):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, db.ErrBookingRequestNotPending), errors.Is(err, db.ErrBookingSlotTaken), errors.Is(err, db.ErrBookingSlotUnavailable):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// declineBookingRequest declines a pending booking request.
+func (server *Server) declineBookingRequest(ctx *gin.Context) {
+	var req getBookingRequestRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	request, err := server.store.DeclineBookingRequest(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// the request doesn't exist or isn't pending
+			if _, err := server.store.GetBookingRequest(ctx, req.ID); err == nil {
+				ctx.JSON(http.StatusConflict, errorResponse(db.ErrBookingRequestNotPending))
+				return
+			}
+
+			ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
@@ -0,0 +1,526 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/booking"
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookingAPIs(t *testing.T) {
+	tests := tests{
+		"Test_listBookingSlots":      listBookingSlotsTestCasesBuilder(),
+		"Test_createBookingRequest":  createBookingRequestTestCasesBuilder(),
+		"Test_getBookingRequest":     getBookingRequestTestCasesBuilder(),
+		"Test_listBookingRequests":   listBookingRequestsTestCasesBuilder(),
+		"Test_approveBookingRequest": approveBookingRequestTestCasesBuilder(),
+		"Test_declineBookingRequest": declineBookingRequestTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+// randomBookingAvailability returns the availability of a day next week, from 10:00 to 12:00 UTC,
+// with a lesson from 10:00 to 11:00.
+func randomBookingAvailability() (day time.Time, availability db.Availability) {
+	day = time.Now().UTC().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+
+	availability = db.Availability{
+		Windows: []db.AvailabilityWindow{{
+			WindowID:    util.RandomInt64(1, 1000),
+			Weekday:     int32(day.Weekday()),
+			StartMinute: 10 * 60,
+			EndMinute:   12 * 60,
+			Timezone:    "UTC",
+			LocationID:  util.RandomInt64(1, 1000),
+		}},
+		Lessons: []db.Lesson{{
+			LessonID:       util.RandomInt64(1, 1000),
+			LessonDatetime: day.Add(10 * time.Hour),
+			Duration:       60,
+		}},
+	}
+
+	return day, availability
+}
+
+// randomBookingRequest returns a pending booking request of the free hour of the availability of day
+func randomBookingRequest(day time.Time, availability db.Availability) db.BookingRequest {
+	return db.BookingRequest{
+		BookingRequestID: util.RandomInt64(1, 1000),
+		FirstName:        util.RandomName(),
+		LastName:         util.RandomName(),
+		Email:            sql.NullString{String: util.RandomEmail(), Valid: true},
+		SubjectID:        util.RandomInt64(1, 1000),
+		LocationID:       availability.Windows[0].LocationID,
+		LessonDatetime:   day.Add(11 * time.Hour),
+		Duration:         60,
+		Notes:            sql.NullString{String: util.RandomNote(), Valid: true},
+		Status:           db.BookingStatusPending,
+		CreatedAt:        time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// listBookingSlotsTestCasesBuilder creates a slice of test cases for the listBookingSlots API
+func listBookingSlotsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	day, availability := randomBookingAvailability()
+	methodName := "GetAvailabilityTx"
+	date := day.Format(dateLayout)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/booking/slots?start_date=%s&end_date=%s&duration=30", date, date),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, day, day.AddDate(0, 0, 1)).
+				Return(availability, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+
+			// the lesson is excluded from the window
+			locationID := availability.Windows[0].LocationID
+			requireBodyMatchStruct(t, recorder.Body, []booking.Slot{
+				{Start: day.Add(11 * time.Hour), End: day.Add(11*time.Hour + 30*time.Minute), LocationID: locationID},
+				{Start: day.Add(11*time.Hour + 30*time.Minute), End: day.Add(12 * time.Hour), LocationID: locationID},
+			})
+		},
+	})
+
+	// create a test case for StatusOK response of a past period
+	testCases = append(testCases, testCase{
+		name:       "Past Period",
+		httpMethod: http.MethodGet,
+		url:        "/booking/slots?start_date=2020-01-01&end_date=2020-01-07&duration=60",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, []booking.Slot{})
+			mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Period Too Long response
+	testCases = append(testCases, testCase{
+		name:       "Period Too Long",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/booking/slots?start_date=%s&end_date=%s&duration=60", date, day.AddDate(0, 2, 0).Format(dateLayout)),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// createBookingRequestTestCasesBuilder creates a slice of test cases for the createBookingRequest API
+func createBookingRequestTestCasesBuilder() testCases {
+	var testCases testCases
+
+	day, availability := randomBookingAvailability()
+	request := randomBookingRequest(day, availability)
+	req := createBookingRequestRequest{
+		FirstName:      request.FirstName,
+		LastName:       request.LastName,
+		Email:          request.Email.String,
+		SubjectID:      request.SubjectID,
+		LessonDatetime: request.LessonDatetime,
+		Duration:       request.Duration,
+		Notes:          request.Notes,
+	}
+
+	arg := db.CreateBookingRequestParams{
+		FirstName:      request.FirstName,
+		LastName:       request.LastName,
+		Email:          request.Email,
+		SubjectID:      request.SubjectID,
+		LocationID:     request.LocationID,
+		LessonDatetime: request.LessonDatetime,
+		Duration:       request.Duration,
+		Notes:          request.Notes,
+	}
+
+	subject := db.LessonSubject{SubjectID: request.SubjectID, Name: util.RandomName()}
+	end := request.LessonDatetime.Add(time.Hour)
+	url := "/booking/requests"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetLessonSubject", mock.Anything, request.SubjectID).
+				Return(subject, nil).
+				Once()
+			mockStore.On("GetAvailabilityTx", mock.Anything, request.LessonDatetime, end).
+				Return(availability, nil).
+				Once()
+			mockStore.On("CreateBookingRequest", mock.Anything, arg).
+				Return(request, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, request)
+		},
+	})
+
+	// create a test case for Not Free response of a request overlapping the lesson
+	takenReq := req
+	takenReq.LessonDatetime = request.LessonDatetime.Add(-30 * time.Minute)
+	testCases = append(testCases, testCase{
+		name:       "Not Free",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       takenReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetLessonSubject", mock.Anything, request.SubjectID).
+				Return(subject, nil).
+				Once()
+			mockStore.On("GetAvailabilityTx", mock.Anything, mock.Anything, mock.Anything).
+				Return(availability, nil).
+				Once()
+			mockStore.On("CreateBookingRequest", mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusConflict, recorder.Code)
+			mockStore.On("CreateBookingRequest", mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Not Found response of an unknown subject
+	testCases = append(testCases, testCase{
+		name:       "Subject Not Found",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetLessonSubject", mock.Anything, request.SubjectID).
+				Return(db.LessonSubject{}, sql.ErrNoRows).
+				Once()
+			mockStore.On("GetAvailabilityTx", mock.Anything, mock.Anything, mock.Anything).Times(0)
+			mockStore.On("CreateBookingRequest", mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+			mockStore.On("GetAvailabilityTx", mock.Anything, mock.Anything, mock.Anything).Unset()
+			mockStore.On("CreateBookingRequest", mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Not Free response of a request in the past
+	pastReq := req
+	pastReq.LessonDatetime = time.Now().UTC().AddDate(0, 0, -1)
+	testCases = append(testCases, testCase{
+		name:       "Past",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       pastReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetAvailabilityTx", mock.Anything, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusConflict, recorder.Code)
+			mockStore.On("GetAvailabilityTx", mock.Anything, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Missing Contact response
+	invalidReq := req
+	invalidReq.Email = ""
+	testCases = append(testCases, testCase{
+		name:       "Missing Contact",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetAvailabilityTx", mock.Anything, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On("GetAvailabilityTx", mock.Anything, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getBookingRequestTestCasesBuilder creates a slice of test cases for the getBookingRequest API
+func getBookingRequestTestCasesBuilder() testCases {
+	var testCases testCases
+
+	request := randomBookingRequest(randomBookingAvailability())
+	methodName := "GetBookingRequest"
+	url := fmt.Sprintf("/booking_requests/%d", request.BookingRequestID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, request.BookingRequestID).
+				Return(request, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, request)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, request.BookingRequestID).
+				Return(db.BookingRequest{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// listBookingRequestsTestCasesBuilder creates a slice of test cases for the listBookingRequests API
+func listBookingRequestsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	requests := []db.BookingRequest{randomBookingRequest(randomBookingAvailability()), randomBookingRequest(randomBookingAvailability())}
+	arg := db.ListBookingRequestsParams{
+		Status: sql.NullString{String: db.BookingStatusPending, Valid: true},
+		Limit:  5,
+		Offset: 0,
+	}
+
+	methodName := "ListBookingRequests"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/booking_requests?status=pending&page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(requests, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, requests)
+		},
+	})
+
+	// create a test case for Invalid Status response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Status",
+		httpMethod: http.MethodGet,
+		url:        "/booking_requests?status=cancelled&page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// approveBookingRequestTestCasesBuilder creates a slice of test cases for the approveBookingRequest API
+func approveBookingRequestTestCasesBuilder() testCases {
+	var testCases testCases
+
+	request := randomBookingRequest(randomBookingAvailability())
+	studentID := util.RandomInt64(1, 1000)
+	request.Status = db.BookingStatusApproved
+	request.StudentID = sql.NullInt64{Int64: studentID, Valid: true}
+	request.LessonID = sql.NullInt64{Int64: util.RandomInt64(1, 1000), Valid: true}
+
+	result := db.ApprovedBookingRequest{
+		BookingRequest: request,
+		Lesson: db.LessonWithInvoices{
+			Lesson: db.Lesson{
+				LessonID:       request.LessonID.Int64,
+				LessonDatetime: request.LessonDatetime,
+				Duration:       request.Duration,
+				LocationID:     request.LocationID,
+				SubjectID:      request.SubjectID,
+			},
+		},
+	}
+
+	req := approveBookingRequestRequest{StudentID: studentID}
+	arg := db.ApproveBookingRequestTxParams{
+		BookingRequestID: request.BookingRequestID,
+		StudentID:        sql.NullInt64{Int64: studentID, Valid: true},
+	}
+
+	methodName := "ApproveBookingRequestTx"
+	url := fmt.Sprintf("/booking_requests/%d/approve", request.BookingRequestID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(result, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, result)
+		},
+	})
+
+	// create test cases for the error responses of the transaction
+	for name, c := range map[string]struct {
+		err  error
+		code int
+	}{
+		"Not Found":        {sql.ErrNoRows, http.StatusNotFound},
+		"Not Pending":      {db.ErrBookingRequestNotPending, http.StatusConflict},
+		"Slot Taken":       {db.ErrBookingSlotTaken, http.StatusConflict},
+		"Slot Unavailable": {db.ErrBookingSlotUnavailable, http.StatusConflict},
+		"No Hourly Fee":    {db.ErrNoHourlyFee, http.StatusBadRequest},
+		"Internal Error":   {sql.ErrConnDone, http.StatusInternalServerError},
+	} {
+		err, code := c.err, c.code
+		testCases = append(testCases, testCase{
+			name:       name,
+			httpMethod: http.MethodPost,
+			url:        url,
+			body:       req,
+			buildStub: func(mockStore *mocks.MockStore) {
+				mockStore.On(methodName, mock.Anything, arg).
+					Return(db.ApprovedBookingRequest{}, err).
+					Once()
+			},
+			checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, code, recorder.Code)
+			},
+		})
+	}
+
+	return testCases
+}
+
+// declineBookingRequestTestCasesBuilder creates a slice of test cases for the declineBookingRequest API
+func declineBookingRequestTestCasesBuilder() testCases {
+	var testCases testCases
+
+	request := randomBookingRequest(randomBookingAvailability())
+	request.Status = db.BookingStatusDeclined
+	url := fmt.Sprintf("/booking_requests/%d/decline", request.BookingRequestID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("DeclineBookingRequest", mock.Anything, request.BookingRequestID).
+				Return(request, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, request)
+		},
+	})
+
+	// create a test case for Not Pending response
+	testCases = append(testCases, testCase{
+		name:       "Not Pending",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("DeclineBookingRequest", mock.Anything, request.BookingRequestID).
+				Return(db.BookingRequest{}, sql.ErrNoRows).
+				Once()
+			mockStore.On("GetBookingRequest", mock.Anything, request.BookingRequestID).
+				Return(request, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusConflict, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("DeclineBookingRequest", mock.Anything, request.BookingRequestID).
+				Return(db.BookingRequest{}, sql.ErrNoRows).
+				Once()
+			mockStore.On("GetBookingRequest", mock.Anything, request.BookingRequestID).
+				Return(db.BookingRequest{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// csvFormat is the value of the format query parameter used to request a CSV export.
+const csvFormat = "csv"
+
+// csvResponse writes records as a CSV file attachment named filename.
+// The first record is expected to be the header row.
+func csvResponse(ctx *gin.Context, filename string, records [][]string) {
+	ctx.Header("Content-Disposition", attachmentDisposition(filename))
+	ctx.Header("Content-Type", "text/csv")
+	ctx.Status(http.StatusOK)
+
+	w := csv.NewWriter(ctx.Writer)
+	if err := w.WriteAll(records); err != nil {
+		ctx.Error(err)
+	}
+}
+
+// formatAmount formats a money amount for CSV exports.
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
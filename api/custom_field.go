@@ -0,0 +1,292 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// errInvalidCustomFieldOptions is returned when the options of a custom field don't match its type.
+var errInvalidCustomFieldOptions = errors.New("options must be set, non-empty and distinct for enum fields, and only for enum fields")
+
+// validateCustomFieldOptions returns an error if the options of a custom field don't match its type.
+// Enum fields need at least one option, and the other types have none.
+func validateCustomFieldOptions(fieldType string, options []string) error {
+	if (fieldType == db.CustomFieldTypeEnum) != (len(options) > 0) {
+		return errInvalidCustomFieldOptions
+	}
+
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		if strings.TrimSpace(option) == "" || seen[option] {
+			return errInvalidCustomFieldOptions
+		}
+		seen[option] = true
+	}
+
+	return nil
+}
+
+type createCustomFieldRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	FieldType string   `json:"field_type" binding:"required,oneof=text number date enum"`
+	Options   []string `json:"options"`
+}
+
+// createCustomField defines a custom field of the students. Enum fields list their allowed values as options.
+func (server *Server) createCustomField(ctx *gin.Context) {
+	var req createCustomFieldRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := validateCustomFieldOptions(req.FieldType, req.Options); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateCustomFieldParams{
+		Name:      req.Name,
+		FieldType: req.FieldType,
+		Options:   req.Options,
+	}
+
+	if arg.Options == nil {
+		arg.Options = []string{}
+	}
+
+	field, err := server.store.CreateCustomField(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, field)
+}
+
+type getCustomFieldRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getCustomField(ctx *gin.Context) {
+	var req getCustomFieldRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	field, err := server.store.GetCustomField(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, field)
+}
+
+type listCustomFieldsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listCustomFields returns the custom fields by name.
+func (server *Server) listCustomFields(ctx *gin.Context) {
+	var req listCustomFieldsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListCustomFieldsParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	fields, err := server.store.ListCustomFields(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, fields)
+}
+
+type updateCustomFieldRequest struct {
+	FieldID int64    `json:"field_id" binding:"required,min=1"`
+	Name    string   `json:"name" binding:"required"`
+	Options []string `json:"options"`
+}
+
+// updateCustomField renames a custom field and updates its enum options. The type of a field can't be changed.
+func (server *Server) updateCustomField(ctx *gin.Context) {
+	var req updateCustomFieldRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	field, err := server.store.GetCustomField(ctx, req.FieldID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err := validateCustomFieldOptions(field.FieldType, req.Options); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateCustomFieldParams{
+		FieldID: req.FieldID,
+		Name:    req.Name,
+		Options: req.Options,
+	}
+
+	if arg.Options == nil {
+		arg.Options = []string{}
+	}
+
+	err = server.store.UpdateCustomField(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Custom field updated successfully"))
+}
+
+// deleteCustomField deletes a custom field and its values of the students.
+func (server *Server) deleteCustomField(ctx *gin.Context) {
+	var req getCustomFieldRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeleteCustomField(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Custom field deleted successfully"))
+}
+
+// getStudentCustomValues returns the custom field values of a student, by field name.
+func (server *Server) getStudentCustomValues(ctx *gin.Context) {
+	var uri studentUriRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	values, err := server.store.GetStudentCustomValues(ctx, uri.StudentID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, values)
+}
+
+type setStudentCustomValueRequest struct {
+	FieldID int64  `json:"field_id" binding:"required,min=1"`
+	Value   string `json:"value" binding:"required"`
+}
+
+// setStudentCustomValue sets the value of a custom field of a student.
+// Numbers and dates (2006-01-02) are stored in their canonical form, and enum values must be one of the field options.
+func (server *Server) setStudentCustomValue(ctx *gin.Context) {
+	var uri studentUriRequest
+	var req setStudentCustomValueRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpsertStudentCustomValueParams{
+		StudentID: uri.StudentID,
+		FieldID:   req.FieldID,
+		Value:     req.Value,
+	}
+
+	value, err := server.store.SetStudentCustomValueTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrInvalidCustomFieldValue) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, value)
+}
+
+type deleteStudentCustomValueRequest struct {
+	StudentID int64 `uri:"id" binding:"required,min=1"`
+	FieldID   int64 `uri:"field_id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteStudentCustomValue(ctx *gin.Context) {
+	var req deleteStudentCustomValueRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeleteStudentCustomValue(ctx, db.DeleteStudentCustomValueParams{
+		StudentID: req.StudentID,
+		FieldID:   req.FieldID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Custom field value deleted successfully"))
+}
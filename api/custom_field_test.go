@@ -0,0 +1,529 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomFieldAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createCustomField":        createCustomFieldTestCasesBuilder(),
+		"Test_getCustomField":           getCustomFieldTestCasesBuilder(),
+		"Test_listCustomFields":         listCustomFieldsTestCasesBuilder(),
+		"Test_updateCustomField":        updateCustomFieldTestCasesBuilder(),
+		"Test_deleteCustomField":        deleteCustomFieldTestCasesBuilder(),
+		"Test_getStudentCustomValues":   getStudentCustomValuesTestCasesBuilder(),
+		"Test_setStudentCustomValue":    setStudentCustomValueTestCasesBuilder(),
+		"Test_deleteStudentCustomValue": deleteStudentCustomValueTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+// randomCustomField creates a new random custom field of the field type. Enum fields have two options.
+func randomCustomField(fieldType string) db.CustomField {
+	field := db.CustomField{
+		FieldID:   util.RandomInt64(1, 1000),
+		Name:      util.RandomName(),
+		FieldType: fieldType,
+		Options:   []string{},
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if fieldType == db.CustomFieldTypeEnum {
+		field.Options = []string{util.RandomName(), util.RandomName() + "2"}
+	}
+
+	return field
+}
+
+// createCustomFieldTestCasesBuilder creates a slice of test cases for the createCustomField API
+func createCustomFieldTestCasesBuilder() testCases {
+	var testCases testCases
+
+	field := randomCustomField(db.CustomFieldTypeEnum)
+	req := createCustomFieldRequest{
+		Name:      field.Name,
+		FieldType: field.FieldType,
+		Options:   field.Options,
+	}
+
+	arg := db.CreateCustomFieldParams{
+		Name:      field.Name,
+		FieldType: field.FieldType,
+		Options:   field.Options,
+	}
+
+	methodName := "CreateCustomField"
+	url := "/custom_fields"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(field, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, field)
+		},
+	})
+
+	// create a test case for StatusOK response of a field without options
+	date := randomCustomField(db.CustomFieldTypeDate)
+	testCases = append(testCases, testCase{
+		name:       "OK Without Options",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       createCustomFieldRequest{Name: date.Name, FieldType: date.FieldType},
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, db.CreateCustomFieldParams{
+				Name:      date.Name,
+				FieldType: date.FieldType,
+				Options:   []string{},
+			}).Return(date, nil).Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create test cases for invalid requests
+	invalidRequests := map[string]createCustomFieldRequest{
+		"Invalid Field Type":     {Name: field.Name, FieldType: "boolean"},
+		"Enum Without Options":   {Name: field.Name, FieldType: db.CustomFieldTypeEnum},
+		"Options Of Text Field":  {Name: field.Name, FieldType: db.CustomFieldTypeText, Options: field.Options},
+		"Duplicate Enum Options": {Name: field.Name, FieldType: db.CustomFieldTypeEnum, Options: []string{"a", "a"}},
+	}
+
+	for name, invalidReq := range invalidRequests {
+		testCases = append(testCases, testCase{
+			name:       name,
+			httpMethod: http.MethodPost,
+			url:        url,
+			body:       invalidReq,
+			buildStub: func(mockStore *mocks.MockStore) {
+				mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+			},
+			checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+				mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+			},
+		})
+	}
+
+	return testCases
+}
+
+// getCustomFieldTestCasesBuilder creates a slice of test cases for the getCustomField API
+func getCustomFieldTestCasesBuilder() testCases {
+	var testCases testCases
+
+	field := randomCustomField(db.CustomFieldTypeText)
+	methodName := "GetCustomField"
+	url := fmt.Sprintf("/custom_fields/%d", field.FieldID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, field.FieldID).
+				Return(field, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, field)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, field.FieldID).
+				Return(db.CustomField{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// listCustomFieldsTestCasesBuilder creates a slice of test cases for the listCustomFields API
+func listCustomFieldsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	fields := []db.CustomField{randomCustomField(db.CustomFieldTypeNumber), randomCustomField(db.CustomFieldTypeEnum)}
+	methodName := "ListCustomFields"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/custom_fields?page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, db.ListCustomFieldsParams{Limit: 5, Offset: 0}).
+				Return(fields, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, fields)
+		},
+	})
+
+	return testCases
+}
+
+// updateCustomFieldTestCasesBuilder creates a slice of test cases for the updateCustomField API
+func updateCustomFieldTestCasesBuilder() testCases {
+	var testCases testCases
+
+	field := randomCustomField(db.CustomFieldTypeEnum)
+	options := append(field.Options, util.RandomName()+"3")
+	req := updateCustomFieldRequest{
+		FieldID: field.FieldID,
+		Name:    util.RandomName(),
+		Options: options,
+	}
+
+	arg := db.UpdateCustomFieldParams{
+		FieldID: field.FieldID,
+		Name:    req.Name,
+		Options: options,
+	}
+
+	methodName := "UpdateCustomField"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        "/custom_fields",
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetCustomField", mock.Anything, field.FieldID).
+				Return(field, nil).
+				Once()
+
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Options response, as the options of an enum field can't be removed
+	invalidReq := req
+	invalidReq.Options = nil
+	testCases = append(testCases, testCase{
+		name:       "Invalid Options",
+		httpMethod: http.MethodPut,
+		url:        "/custom_fields",
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetCustomField", mock.Anything, field.FieldID).
+				Return(field, nil).
+				Once()
+
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodPut,
+		url:        "/custom_fields",
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetCustomField", mock.Anything, field.FieldID).
+				Return(db.CustomField{}, sql.ErrNoRows).
+				Once()
+
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// deleteCustomFieldTestCasesBuilder creates a slice of test cases for the deleteCustomField API
+func deleteCustomFieldTestCasesBuilder() testCases {
+	var testCases testCases
+
+	fieldID := util.RandomInt64(1, 1000)
+	methodName := "DeleteCustomField"
+	url := fmt.Sprintf("/custom_fields/%d", fieldID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, fieldID).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, fieldID).
+				Return(int64(0), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// getStudentCustomValuesTestCasesBuilder creates a slice of test cases for the getStudentCustomValues API
+func getStudentCustomValuesTestCasesBuilder() testCases {
+	var testCases testCases
+
+	studentID := util.RandomInt64(1, 1000)
+	field := randomCustomField(db.CustomFieldTypeNumber)
+	values := []db.GetStudentCustomValuesRow{
+		{
+			FieldID:   field.FieldID,
+			Name:      field.Name,
+			FieldType: field.FieldType,
+			Value:     "12",
+			UpdatedAt: time.Now().UTC().Truncate(time.Second),
+		},
+	}
+
+	methodName := "GetStudentCustomValues"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/students/%d/custom_fields", studentID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, studentID).
+				Return(values, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, values)
+		},
+	})
+
+	return testCases
+}
+
+// setStudentCustomValueTestCasesBuilder creates a slice of test cases for the setStudentCustomValue API
+func setStudentCustomValueTestCasesBuilder() testCases {
+	var testCases testCases
+
+	studentID := util.RandomInt64(1, 1000)
+	fieldID := util.RandomInt64(1, 1000)
+	req := setStudentCustomValueRequest{FieldID: fieldID, Value: "2010-05-04"}
+
+	arg := db.UpsertStudentCustomValueParams{
+		StudentID: studentID,
+		FieldID:   fieldID,
+		Value:     req.Value,
+	}
+
+	value := db.StudentCustomValue{
+		StudentID: studentID,
+		FieldID:   fieldID,
+		Value:     req.Value,
+		UpdatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	methodName := "SetStudentCustomValueTx"
+	url := fmt.Sprintf("/students/%d/custom_fields", studentID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(value, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, value)
+		},
+	})
+
+	// create a test case for Invalid Value response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Value",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.StudentCustomValue{}, fmt.Errorf("%w: birthday must be a date", db.ErrInvalidCustomFieldValue)).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		},
+	})
+
+	// create a test case for Custom Field Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.StudentCustomValue{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Missing Value response
+	testCases = append(testCases, testCase{
+		name:       "Missing Value",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       setStudentCustomValueRequest{FieldID: fieldID},
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// deleteStudentCustomValueTestCasesBuilder creates a slice of test cases for the deleteStudentCustomValue API
+func deleteStudentCustomValueTestCasesBuilder() testCases {
+	var testCases testCases
+
+	arg := db.DeleteStudentCustomValueParams{
+		StudentID: util.RandomInt64(1, 1000),
+		FieldID:   util.RandomInt64(1, 1000),
+	}
+
+	methodName := "DeleteStudentCustomValue"
+	url := fmt.Sprintf("/students/%d/custom_fields/%d", arg.StudentID, arg.FieldID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(int64(0), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+func TestValidateCustomFieldOptions(t *testing.T) {
+	require.NoError(t, validateCustomFieldOptions(db.CustomFieldTypeEnum, []string{"a", "b"}))
+	require.NoError(t, validateCustomFieldOptions(db.CustomFieldTypeNumber, nil))
+	require.ErrorIs(t, validateCustomFieldOptions(db.CustomFieldTypeEnum, []string{"a", " "}), errInvalidCustomFieldOptions)
+	require.ErrorIs(t, validateCustomFieldOptions(db.CustomFieldTypeDate, []string{"a"}), errInvalidCustomFieldOptions)
+}
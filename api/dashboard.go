@@ -0,0 +1,234 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// dashboardCacheTTL is how long a dashboard response is served from the cache.
+const dashboardCacheTTL = 30 * time.Second
+
+// upcomingUnpaidLessonsLimit is the maximum number of upcoming unpaid lessons shown on the dashboard.
+const upcomingUnpaidLessonsLimit = 10
+
+// upcomingExamsDays is the number of days ahead of which upcoming exams are shown on the dashboard,
+// and upcomingExamsLimit is the maximum number of upcoming exams shown.
+const (
+	upcomingExamsDays  = 30
+	upcomingExamsLimit = 10
+)
+
+// overdueTasksLimit is the maximum number of overdue tasks shown on the dashboard.
+const overdueTasksLimit = 10
+
+// dashboardResponse contains the home screen summary.
+// RevenueLastMonthToDate is last month's revenue up to the same day of the month, to compare with RevenueMonthToDate.
+type dashboardResponse struct {
+	TodayLessons           []db.GetDashboardLessonsRow      `json:"today_lessons"`
+	WeekLessons            []db.GetDashboardLessonsRow      `json:"week_lessons"`
+	OutstandingBalance     db.GetOutstandingBalanceRow      `json:"outstanding_balance"`
+	RevenueMonthToDate     float64                          `json:"revenue_month_to_date"`
+	RevenueLastMonthToDate float64                          `json:"revenue_last_month_to_date"`
+	RevenueLastMonth       float64                          `json:"revenue_last_month"`
+	NewStudentsThisMonth   int64                            `json:"new_students_this_month"`
+	UpcomingUnpaidLessons  []db.GetUpcomingUnpaidLessonsRow `json:"upcoming_unpaid_lessons"`
+	UpcomingExams          []db.GetUpcomingExamsRow         `json:"upcoming_exams"`
+	OverdueTasks           []db.ListDueTasksRow             `json:"overdue_tasks"`
+	GeneratedAt            time.Time                        `json:"generated_at"`
+}
+
+// dashboardCache holds the last dashboard response until it expires.
+type dashboardCache struct {
+	mu        sync.Mutex
+	rsp       dashboardResponse
+	expiresAt time.Time
+}
+
+// get returns the cached dashboard response, and false if it expired at now.
+func (c *dashboardCache) get(now time.Time) (dashboardResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.rsp, now.Before(c.expiresAt)
+}
+
+// set caches rsp until expiresAt, unless a response built later is already cached.
+func (c *dashboardCache) set(rsp dashboardResponse, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if expiresAt.After(c.expiresAt) {
+		c.rsp = rsp
+		c.expiresAt = expiresAt
+	}
+}
+
+// dashboardPeriods contains the time boundaries used by the dashboard, all in UTC.
+// Weeks start on Monday.
+type dashboardPeriods struct {
+	now            time.Time
+	today          time.Time
+	tomorrow       time.Time
+	weekStart      time.Time
+	nextWeekStart  time.Time
+	monthStart     time.Time
+	lastMonthStart time.Time
+	lastMonthToNow time.Time
+}
+
+// newDashboardPeriods returns the dashboard time boundaries relative to now.
+func newDashboardPeriods(now time.Time) dashboardPeriods {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	weekStart := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastMonthStart := monthStart.AddDate(0, -1, 0)
+
+	// the same point in time last month, capped to the end of last month
+	lastMonthToNow := lastMonthStart.Add(now.Sub(monthStart))
+	if lastMonthToNow.After(monthStart) {
+		lastMonthToNow = monthStart
+	}
+
+	return dashboardPeriods{
+		now:            now,
+		today:          today,
+		tomorrow:       today.AddDate(0, 0, 1),
+		weekStart:      weekStart,
+		nextWeekStart:  weekStart.AddDate(0, 0, 7),
+		monthStart:     monthStart,
+		lastMonthStart: lastMonthStart,
+		lastMonthToNow: lastMonthToNow,
+	}
+}
+
+// runConcurrently runs all fns concurrently and waits for them to finish.
+// The context passed to fns is canceled once any of them fails, and the first error is returned.
+func runConcurrently(ctx context.Context, fns ...func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for _, fn := range fns {
+		wg.Add(1)
+		go func(fn func(ctx context.Context) error) {
+			defer wg.Done()
+			if err := fn(ctx); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(fn)
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// buildDashboard runs all the dashboard aggregate queries concurrently.
+func (server *Server) buildDashboard(ctx context.Context, now time.Time) (dashboardResponse, error) {
+	p := newDashboardPeriods(now)
+	rsp := dashboardResponse{GeneratedAt: p.now}
+
+	err := runConcurrently(ctx,
+		func(ctx context.Context) (err error) {
+			rsp.TodayLessons, err = server.store.GetDashboardLessons(ctx, db.GetDashboardLessonsParams{
+				StartDatetime: p.today,
+				EndDatetime:   p.tomorrow,
+			})
+			return
+		},
+		func(ctx context.Context) (err error) {
+			rsp.WeekLessons, err = server.store.GetDashboardLessons(ctx, db.GetDashboardLessonsParams{
+				StartDatetime: p.weekStart,
+				EndDatetime:   p.nextWeekStart,
+			})
+			return
+		},
+		func(ctx context.Context) (err error) {
+			rsp.OutstandingBalance, err = server.store.GetOutstandingBalance(ctx)
+			return
+		},
+		func(ctx context.Context) (err error) {
+			rsp.RevenueMonthToDate, err = server.store.GetInvoicedAmount(ctx, db.GetInvoicedAmountParams{
+				StartDatetime: p.monthStart,
+				EndDatetime:   p.now,
+			})
+			return
+		},
+		func(ctx context.Context) (err error) {
+			rsp.RevenueLastMonthToDate, err = server.store.GetInvoicedAmount(ctx, db.GetInvoicedAmountParams{
+				StartDatetime: p.lastMonthStart,
+				EndDatetime:   p.lastMonthToNow,
+			})
+			return
+		},
+		func(ctx context.Context) (err error) {
+			rsp.RevenueLastMonth, err = server.store.GetInvoicedAmount(ctx, db.GetInvoicedAmountParams{
+				StartDatetime: p.lastMonthStart,
+				EndDatetime:   p.monthStart,
+			})
+			return
+		},
+		func(ctx context.Context) (err error) {
+			rsp.NewStudentsThisMonth, err = server.store.CountNewStudents(ctx, db.CountNewStudentsParams{
+				StartDatetime: p.monthStart,
+				EndDatetime:   p.now,
+			})
+			return
+		},
+		func(ctx context.Context) (err error) {
+			rsp.UpcomingUnpaidLessons, err = server.store.GetUpcomingUnpaidLessons(ctx, db.GetUpcomingUnpaidLessonsParams{
+				StartDatetime: p.now,
+				Limit:         upcomingUnpaidLessonsLimit,
+			})
+			return
+		},
+		func(ctx context.Context) (err error) {
+			rsp.UpcomingExams, err = server.store.GetUpcomingExams(ctx, db.GetUpcomingExamsParams{
+				StartDatetime: p.now,
+				EndDatetime:   p.today.AddDate(0, 0, upcomingExamsDays+1),
+				Limit:         upcomingExamsLimit,
+			})
+			return
+		},
+		func(ctx context.Context) (err error) {
+			rsp.OverdueTasks, err = server.store.ListDueTasks(ctx, db.ListDueTasksParams{
+				DueBefore: p.now,
+				Limit:     overdueTasksLimit,
+			})
+			return
+		},
+	)
+
+	return rsp, err
+}
+
+// getDashboard returns the home screen summary, from the cache if it hasn't expired.
+// The cache isn't locked while the dashboard is built, so a slow build doesn't block other requests.
+func (server *Server) getDashboard(ctx *gin.Context) {
+	now := time.Now()
+	if rsp, ok := server.dashboard.get(now); ok {
+		ctx.JSON(http.StatusOK, rsp)
+		return
+	}
+
+	rsp, err := server.buildDashboard(ctx, now)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.dashboard.set(rsp, now.Add(dashboardCacheTTL))
+
+	ctx.JSON(http.StatusOK, rsp)
+}
@@ -0,0 +1,323 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardAPIs(t *testing.T) {
+	tests := tests{
+		"Test_getDashboard": getDashboardTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomDashboardLesson() db.GetDashboardLessonsRow {
+	return db.GetDashboardLessonsRow{
+		LessonID:       util.RandomInt64(1, 1000),
+		LessonDatetime: util.RandomDatetime(),
+		Duration:       util.RandomLessonDuration(),
+		LocationName:   util.RandomName(),
+		SubjectName:    util.RandomName(),
+		StudentsCount:  util.RandomInt64(1, 5),
+		InvoicedAmount: util.RandomInvoiceAmount(),
+	}
+}
+
+// dashboardStubs contains the values returned by the stubs of the dashboard store methods.
+type dashboardStubs struct {
+	todayLessons          []db.GetDashboardLessonsRow
+	weekLessons           []db.GetDashboardLessonsRow
+	outstandingBalance    db.GetOutstandingBalanceRow
+	newStudents           int64
+	upcomingUnpaidLessons []db.GetUpcomingUnpaidLessonsRow
+	upcomingExams         []db.GetUpcomingExamsRow
+	overdueTasks          []db.ListDueTasksRow
+}
+
+func randomDashboardStubs() dashboardStubs {
+	student := randomStudent()
+
+	return dashboardStubs{
+		todayLessons: []db.GetDashboardLessonsRow{randomDashboardLesson()},
+		weekLessons:  []db.GetDashboardLessonsRow{randomDashboardLesson(), randomDashboardLesson()},
+		outstandingBalance: db.GetOutstandingBalanceRow{
+			OutstandingAmount: util.RandomInvoiceAmount(),
+			CreditAmount:      util.RandomPaymentAmount(),
+			StudentsCount:     util.RandomInt64(1, 10),
+		},
+		newStudents: util.RandomInt64(1, 10),
+		upcomingUnpaidLessons: []db.GetUpcomingUnpaidLessonsRow{
+			{
+				LessonID:       util.RandomInt64(1, 1000),
+				LessonDatetime: util.RandomDatetime(),
+				InvoiceID:      util.RandomInt64(1, 1000),
+				Amount:         util.RandomInvoiceAmount(),
+				StudentID:      student.StudentID,
+				FirstName:      student.FirstName,
+				LastName:       student.LastName,
+				Balance:        util.RandomInvoiceAmount(),
+			},
+		},
+		upcomingExams: []db.GetUpcomingExamsRow{
+			{
+				ExamID:       util.RandomInt64(1, 1000),
+				Name:         util.RandomName(),
+				ExamDatetime: util.RandomDatetime(),
+				StudentID:    student.StudentID,
+				FirstName:    student.FirstName,
+				LastName:     student.LastName,
+				SubjectName:  util.RandomName(),
+			},
+		},
+		overdueTasks: []db.ListDueTasksRow{
+			{
+				TaskID:    util.RandomInt64(1, 1000),
+				Title:     util.RandomNote(),
+				DueAt:     time.Now().UTC().AddDate(0, 0, -2).Truncate(time.Second),
+				Priority:  db.TaskPriorityHigh,
+				Status:    db.TaskStatusOpen,
+				StudentID: sql.NullInt64{Int64: student.StudentID, Valid: true},
+				FirstName: sql.NullString{String: student.FirstName, Valid: true},
+				LastName:  sql.NullString{String: student.LastName, Valid: true},
+			},
+		},
+	}
+}
+
+// invoicedAmount is the stub of GetInvoicedAmount. It returns the number of hours in the date range,
+// so each revenue figure of the dashboard can be checked against its expected date range.
+func invoicedAmount(ctx context.Context, arg db.GetInvoicedAmountParams) (float64, error) {
+	return arg.EndDatetime.Sub(arg.StartDatetime).Hours(), nil
+}
+
+// build sets the stubs of all the dashboard store methods.
+func (stubs dashboardStubs) build(mockStore *mocks.MockStore) {
+	mockStore.On("GetDashboardLessons", mock.Anything, mock.MatchedBy(func(arg db.GetDashboardLessonsParams) bool {
+		return arg.EndDatetime.Sub(arg.StartDatetime) == 24*time.Hour
+	})).Return(stubs.todayLessons, nil).Once()
+
+	mockStore.On("GetDashboardLessons", mock.Anything, mock.MatchedBy(func(arg db.GetDashboardLessonsParams) bool {
+		return arg.EndDatetime.Sub(arg.StartDatetime) == 7*24*time.Hour
+	})).Return(stubs.weekLessons, nil).Once()
+
+	mockStore.On("GetOutstandingBalance", mock.Anything).
+		Return(stubs.outstandingBalance, nil).
+		Once()
+
+	mockStore.On("GetInvoicedAmount", mock.Anything, mock.Anything).
+		Return(invoicedAmount).
+		Times(3)
+
+	mockStore.On("CountNewStudents", mock.Anything, mock.Anything).
+		Return(stubs.newStudents, nil).
+		Once()
+
+	mockStore.On("GetUpcomingUnpaidLessons", mock.Anything, mock.MatchedBy(func(arg db.GetUpcomingUnpaidLessonsParams) bool {
+		return arg.Limit == upcomingUnpaidLessonsLimit
+	})).Return(stubs.upcomingUnpaidLessons, nil).Once()
+
+	mockStore.On("GetUpcomingExams", mock.Anything, mock.MatchedBy(func(arg db.GetUpcomingExamsParams) bool {
+		return arg.Limit == upcomingExamsLimit && arg.EndDatetime.Sub(arg.StartDatetime) > upcomingExamsDays*24*time.Hour
+	})).Return(stubs.upcomingExams, nil).Once()
+
+	mockStore.On("ListDueTasks", mock.Anything, mock.MatchedBy(func(arg db.ListDueTasksParams) bool {
+		return arg.Limit == overdueTasksLimit && time.Since(arg.DueBefore) < time.Minute
+	})).Return(stubs.overdueTasks, nil).Once()
+}
+
+// response returns the dashboard response expected from the stubs, for a dashboard generated at generatedAt.
+func (stubs dashboardStubs) response(generatedAt time.Time) dashboardResponse {
+	p := newDashboardPeriods(generatedAt)
+
+	return dashboardResponse{
+		TodayLessons:           stubs.todayLessons,
+		WeekLessons:            stubs.weekLessons,
+		OutstandingBalance:     stubs.outstandingBalance,
+		RevenueMonthToDate:     p.now.Sub(p.monthStart).Hours(),
+		RevenueLastMonthToDate: p.lastMonthToNow.Sub(p.lastMonthStart).Hours(),
+		RevenueLastMonth:       p.monthStart.Sub(p.lastMonthStart).Hours(),
+		NewStudentsThisMonth:   stubs.newStudents,
+		UpcomingUnpaidLessons:  stubs.upcomingUnpaidLessons,
+		UpcomingExams:          stubs.upcomingExams,
+		OverdueTasks:           stubs.overdueTasks,
+		GeneratedAt:            p.now,
+	}
+}
+
+// requireDashboardResponse asserts that the recorder body is the dashboard response expected from the stubs.
+func requireDashboardResponse(t *testing.T, stubs dashboardStubs, recorder *httptest.ResponseRecorder) {
+	var rsp dashboardResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.WithinDuration(t, time.Now(), rsp.GeneratedAt, time.Minute)
+
+	requireBodyMatchStruct(t, recorder.Body, stubs.response(rsp.GeneratedAt))
+}
+
+// getDashboardTestCasesBuilder creates a slice of test cases for the getDashboard API
+func getDashboardTestCasesBuilder() testCases {
+	var testCases testCases
+
+	stubs := randomDashboardStubs()
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/dashboard",
+		body:       nil,
+		buildStub:  stubs.build,
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireDashboardResponse(t, stubs, recorder)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        "/dashboard",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetOutstandingBalance", mock.Anything).
+				Return(db.GetOutstandingBalanceRow{}, sql.ErrConnDone).
+				Once()
+
+			// the other queries run concurrently, and may or may not be called before the error cancels them
+			mockStore.On("GetDashboardLessons", mock.Anything, mock.Anything).Return([]db.GetDashboardLessonsRow{}, nil).Maybe()
+			mockStore.On("GetInvoicedAmount", mock.Anything, mock.Anything).Return(0.0, nil).Maybe()
+			mockStore.On("CountNewStudents", mock.Anything, mock.Anything).Return(int64(0), nil).Maybe()
+			mockStore.On("GetUpcomingUnpaidLessons", mock.Anything, mock.Anything).Return([]db.GetUpcomingUnpaidLessonsRow{}, nil).Maybe()
+			mockStore.On("GetUpcomingExams", mock.Anything, mock.Anything).Return([]db.GetUpcomingExamsRow{}, nil).Maybe()
+			mockStore.On("ListDueTasks", mock.Anything, mock.Anything).Return([]db.ListDueTasksRow{}, nil).Maybe()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+func TestDashboardCache(t *testing.T) {
+	stubs := randomDashboardStubs()
+
+	// the store is queried only once, the second request is served from the cache
+	mockStore := mocks.NewMockStore(t)
+	stubs.build(mockStore)
+
+	server := newTestServer(mockStore)
+
+	for i := 0; i < 2; i++ {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, "/dashboard", nil)
+		require.NoError(t, err)
+
+		server.router.ServeHTTP(recorder, request)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		requireDashboardResponse(t, stubs, recorder)
+	}
+
+	// once expired, the dashboard is built again
+	server.dashboard.expiresAt = time.Now().Add(-time.Second)
+	stubs.build(mockStore)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/dashboard", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestDashboardCacheUnlocked(t *testing.T) {
+	stubs := randomDashboardStubs()
+
+	mockStore := mocks.NewMockStore(t)
+	stubs.build(mockStore)
+
+	server := newTestServer(mockStore)
+
+	// the cache isn't locked while the dashboard is built
+	var unlocked bool
+	for _, call := range mockStore.ExpectedCalls {
+		if call.Method == "GetOutstandingBalance" {
+			call.Run(func(args mock.Arguments) {
+				if server.dashboard.mu.TryLock() {
+					unlocked = true
+					server.dashboard.mu.Unlock()
+				}
+			})
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/dashboard", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.True(t, unlocked)
+
+	// a response built earlier doesn't replace a later one
+	cached, ok := server.dashboard.get(time.Now())
+	require.True(t, ok)
+	server.dashboard.set(dashboardResponse{}, time.Now())
+
+	rsp, ok := server.dashboard.get(time.Now())
+	require.True(t, ok)
+	require.Equal(t, cached, rsp)
+}
+
+func TestNewDashboardPeriods(t *testing.T) {
+	// Sunday, the last day of a 31 days month
+	now := time.Date(2024, time.March, 31, 15, 30, 0, 0, time.UTC)
+	p := newDashboardPeriods(now)
+
+	require.Equal(t, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC), p.today)
+	require.Equal(t, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), p.tomorrow)
+	require.Equal(t, time.Date(2024, time.March, 25, 0, 0, 0, 0, time.UTC), p.weekStart)
+	require.Equal(t, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), p.nextWeekStart)
+	require.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), p.monthStart)
+	require.Equal(t, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), p.lastMonthStart)
+	require.Equal(t, p.monthStart, p.lastMonthToNow)
+
+	// Monday, in the middle of the month
+	now = time.Date(2024, time.March, 11, 8, 0, 0, 0, time.UTC)
+	p = newDashboardPeriods(now)
+
+	require.Equal(t, time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC), p.weekStart)
+	require.Equal(t, time.Date(2024, time.February, 11, 8, 0, 0, 0, time.UTC), p.lastMonthToNow)
+}
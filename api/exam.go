@@ -0,0 +1,178 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type createExamRequest struct {
+	StudentID     int64           `json:"student_id" binding:"required,min=1"`
+	SubjectID     int64           `json:"subject_id" binding:"required,min=1"`
+	CollegeID     int64           `json:"college_id" binding:"omitempty,min=1"`
+	Name          string          `json:"name" binding:"required"`
+	ExamDatetime  time.Time       `json:"exam_datetime" binding:"required"`
+	TargetGrade   sql.NullFloat64 `json:"target_grade"`
+	AchievedGrade sql.NullFloat64 `json:"achieved_grade"`
+	Notes         sql.NullString  `json:"notes"`
+}
+
+// createExam creates an exam of a student in a subject. The exam is taken for the college of the student, unless a college is given.
+func (server *Server) createExam(ctx *gin.Context) {
+	var req createExamRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateExamParams{
+		StudentID:     req.StudentID,
+		SubjectID:     req.SubjectID,
+		CollegeID:     sql.NullInt64{Int64: req.CollegeID, Valid: req.CollegeID != 0},
+		Name:          req.Name,
+		ExamDatetime:  req.ExamDatetime,
+		TargetGrade:   req.TargetGrade,
+		AchievedGrade: req.AchievedGrade,
+		Notes:         req.Notes,
+	}
+
+	exam, err := server.store.CreateExam(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exam)
+}
+
+type getExamRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getExam(ctx *gin.Context) {
+	var req getExamRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	exam, err := server.store.GetExam(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exam)
+}
+
+type listExamsRequest struct {
+	StudentID int64     `form:"student_id" binding:"omitempty,min=1"`
+	SubjectID int64     `form:"subject_id" binding:"omitempty,min=1"`
+	StartDate time.Time `form:"start_date" time_format:"2006-01-02" time_utc:"1"`
+	EndDate   time.Time `form:"end_date" time_format:"2006-01-02" time_utc:"1"`
+	PageID    int32     `form:"page_id" binding:"required,min=1"`
+	PageSize  int32     `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listExams returns the exams, earliest first, optionally of a single student or subject, or within a date range.
+func (server *Server) listExams(ctx *gin.Context) {
+	var req listExamsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListExamsParams{
+		StudentID:     sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0},
+		SubjectID:     sql.NullInt64{Int64: req.SubjectID, Valid: req.SubjectID != 0},
+		StartDatetime: sql.NullTime{Time: req.StartDate, Valid: !req.StartDate.IsZero()},
+		Limit:         req.PageSize,
+		Offset:        (req.PageID - 1) * req.PageSize,
+	}
+
+	// the end date is inclusive
+	if !req.EndDate.IsZero() {
+		arg.EndDatetime = sql.NullTime{Time: req.EndDate.AddDate(0, 0, 1), Valid: true}
+	}
+
+	exams, err := server.store.ListExams(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exams)
+}
+
+type updateExamRequest struct {
+	ExamID        int64           `json:"exam_id" binding:"required"`
+	SubjectID     int64           `json:"subject_id" binding:"required,min=1"`
+	CollegeID     sql.NullInt64   `json:"college_id"`
+	Name          string          `json:"name" binding:"required"`
+	ExamDatetime  time.Time       `json:"exam_datetime" binding:"required"`
+	TargetGrade   sql.NullFloat64 `json:"target_grade"`
+	AchievedGrade sql.NullFloat64 `json:"achieved_grade"`
+	Notes         sql.NullString  `json:"notes"`
+}
+
+// updateExam updates an exam, such as recording its achieved grade.
+func (server *Server) updateExam(ctx *gin.Context) {
+	var req updateExamRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateExamParams{
+		ExamID:        req.ExamID,
+		SubjectID:     req.SubjectID,
+		CollegeID:     req.CollegeID,
+		Name:          req.Name,
+		ExamDatetime:  req.ExamDatetime,
+		TargetGrade:   req.TargetGrade,
+		AchievedGrade: req.AchievedGrade,
+		Notes:         req.Notes,
+	}
+
+	err := server.store.UpdateExam(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Exam updated successfully"))
+}
+
+func (server *Server) deleteExam(ctx *gin.Context) {
+	var req getExamRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeleteExam(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Exam deleted successfully"))
+}
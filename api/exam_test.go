@@ -0,0 +1,371 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExamAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createExam": createExamTestCasesBuilder(),
+		"Test_getExam":    getExamTestCasesBuilder(),
+		"Test_listExams":  listExamsTestCasesBuilder(),
+		"Test_updateExam": updateExamTestCasesBuilder(),
+		"Test_deleteExam": deleteExamTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomExam() db.Exam {
+	return db.Exam{
+		ExamID:       util.RandomInt64(1, 1000),
+		StudentID:    util.RandomInt64(1, 1000),
+		SubjectID:    util.RandomInt64(1, 1000),
+		CollegeID:    sql.NullInt64{Int64: util.RandomInt64(1, 1000), Valid: true},
+		Name:         util.RandomName(),
+		ExamDatetime: time.Now().UTC().AddDate(0, 1, 0).Truncate(time.Second),
+		TargetGrade:  sql.NullFloat64{Float64: util.RandomFloat64(60, 100), Valid: true},
+		Notes:        sql.NullString{String: util.RandomNote(), Valid: true},
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// createExamTestCasesBuilder creates a slice of test cases for the createExam API
+func createExamTestCasesBuilder() testCases {
+	var testCases testCases
+
+	exam := randomExam()
+	req := createExamRequest{
+		StudentID:    exam.StudentID,
+		SubjectID:    exam.SubjectID,
+		CollegeID:    exam.CollegeID.Int64,
+		Name:         exam.Name,
+		ExamDatetime: exam.ExamDatetime,
+		TargetGrade:  exam.TargetGrade,
+		Notes:        exam.Notes,
+	}
+
+	arg := db.CreateExamParams{
+		StudentID:    exam.StudentID,
+		SubjectID:    exam.SubjectID,
+		CollegeID:    exam.CollegeID,
+		Name:         exam.Name,
+		ExamDatetime: exam.ExamDatetime,
+		TargetGrade:  exam.TargetGrade,
+		Notes:        exam.Notes,
+	}
+
+	methodName := "CreateExam"
+	url := "/exams"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(exam, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, exam)
+		},
+	})
+
+	// create a test case for StatusOK response of an exam for the college of the student
+	studentCollegeReq := req
+	studentCollegeReq.CollegeID = 0
+
+	studentCollegeArg := arg
+	studentCollegeArg.CollegeID = sql.NullInt64{}
+
+	testCases = append(testCases, testCase{
+		name:       "OK Student College",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       studentCollegeReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, studentCollegeArg).
+				Return(exam, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.Exam{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Missing Subject response
+	invalidReq := req
+	invalidReq.SubjectID = 0
+
+	testCases = append(testCases, testCase{
+		name:       "Missing Subject",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getExamTestCasesBuilder creates a slice of test cases for the getExam API
+func getExamTestCasesBuilder() testCases {
+	var testCases testCases
+
+	exam := randomExam()
+	methodName := "GetExam"
+	url := fmt.Sprintf("/exams/%d", exam.ExamID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, exam.ExamID).
+				Return(exam, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, exam)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, exam.ExamID).
+				Return(db.Exam{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// listExamsTestCasesBuilder creates a slice of test cases for the listExams API
+func listExamsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	var exams []db.Exam
+	for i := 0; i < 5; i++ {
+		exams = append(exams, randomExam())
+	}
+
+	arg := db.ListExamsParams{
+		StudentID:     sql.NullInt64{Int64: exams[0].StudentID, Valid: true},
+		StartDatetime: sql.NullTime{Time: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		EndDatetime:   sql.NullTime{Time: time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		Limit:         5,
+		Offset:        0,
+	}
+
+	methodName := "ListExams"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/exams?student_id=%d&start_date=2024-06-01&end_date=2024-06-30&page_id=1&page_size=5", exams[0].StudentID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(exams, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, exams)
+		},
+	})
+
+	// create a test case for Invalid Page ID response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Page ID",
+		httpMethod: http.MethodGet,
+		url:        "/exams?page_id=0&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// updateExamTestCasesBuilder creates a slice of test cases for the updateExam API
+func updateExamTestCasesBuilder() testCases {
+	var testCases testCases
+
+	exam := randomExam()
+	exam.AchievedGrade = sql.NullFloat64{Float64: util.RandomFloat64(60, 100), Valid: true}
+
+	req := updateExamRequest{
+		ExamID:        exam.ExamID,
+		SubjectID:     exam.SubjectID,
+		CollegeID:     exam.CollegeID,
+		Name:          exam.Name,
+		ExamDatetime:  exam.ExamDatetime,
+		TargetGrade:   exam.TargetGrade,
+		AchievedGrade: exam.AchievedGrade,
+		Notes:         exam.Notes,
+	}
+
+	arg := db.UpdateExamParams{
+		ExamID:        exam.ExamID,
+		SubjectID:     exam.SubjectID,
+		CollegeID:     exam.CollegeID,
+		Name:          exam.Name,
+		ExamDatetime:  exam.ExamDatetime,
+		TargetGrade:   exam.TargetGrade,
+		AchievedGrade: exam.AchievedGrade,
+		Notes:         exam.Notes,
+	}
+
+	methodName := "UpdateExam"
+	url := "/exams"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// deleteExamTestCasesBuilder creates a slice of test cases for the deleteExam API
+func deleteExamTestCasesBuilder() testCases {
+	var testCases testCases
+
+	examID := util.RandomInt64(1, 1000)
+	methodName := "DeleteExam"
+	url := fmt.Sprintf("/exams/%d", examID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, examID).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, examID).
+				Return(int64(0), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
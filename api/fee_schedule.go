@@ -0,0 +1,139 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type createFeeScheduleRequest struct {
+	StudentID     int64          `json:"student_id" binding:"omitempty,min=1"`
+	SubjectID     int64          `json:"subject_id" binding:"omitempty,min=1"`
+	HourlyFee     float64        `json:"hourly_fee" binding:"min=0"`
+	EffectiveFrom time.Time      `json:"effective_from" binding:"required"`
+	Notes         sql.NullString `json:"notes"`
+}
+
+// createFeeSchedule creates the hourly fee of a student, a subject, a student in a subject, or of all lessons,
+// for lessons from its effective time. Fee schedules aren't updated, so the fees of past lessons are kept.
+func (server *Server) createFeeSchedule(ctx *gin.Context) {
+	var req createFeeScheduleRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateFeeScheduleParams{
+		StudentID:     sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0},
+		SubjectID:     sql.NullInt64{Int64: req.SubjectID, Valid: req.SubjectID != 0},
+		HourlyFee:     req.HourlyFee,
+		EffectiveFrom: req.EffectiveFrom,
+		Notes:         req.Notes,
+	}
+
+	schedule, err := server.store.CreateFeeSchedule(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+type getFeeScheduleRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getFeeSchedule(ctx *gin.Context) {
+	var req getFeeScheduleRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	schedule, err := server.store.GetFeeSchedule(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+type listFeeSchedulesRequest struct {
+	StudentID int64 `form:"student_id" binding:"omitempty,min=1"`
+	SubjectID int64 `form:"subject_id" binding:"omitempty,min=1"`
+	PageID    int32 `form:"page_id" binding:"required,min=1"`
+	PageSize  int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listFeeSchedules returns the fee schedules, latest effective first, optionally of a single student or subject.
+func (server *Server) listFeeSchedules(ctx *gin.Context) {
+	var req listFeeSchedulesRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListFeeSchedulesParams{
+		StudentID: sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0},
+		SubjectID: sql.NullInt64{Int64: req.SubjectID, Valid: req.SubjectID != 0},
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	}
+
+	schedules, err := server.store.ListFeeSchedules(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedules)
+}
+
+type resolveHourlyFeeRequest struct {
+	StudentID      int64     `form:"student_id" binding:"required,min=1"`
+	SubjectID      int64     `form:"subject_id" binding:"required,min=1"`
+	LessonDatetime time.Time `form:"lesson_datetime" binding:"required"`
+}
+
+// resolveHourlyFee returns the hourly fee of a student in a subject at a lesson datetime, and the fee schedule it is taken from.
+func (server *Server) resolveHourlyFee(ctx *gin.Context) {
+	var req resolveHourlyFeeRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.GetApplicableFeeScheduleParams{
+		StudentID:      req.StudentID,
+		SubjectID:      req.SubjectID,
+		LessonDatetime: req.LessonDatetime,
+	}
+
+	fee, err := server.store.ResolveHourlyFeeTx(ctx, arg)
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows, errors.Is(err, db.ErrNoHourlyFee):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, fee)
+}
@@ -0,0 +1,359 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFeeScheduleAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createFeeSchedule": createFeeScheduleTestCasesBuilder(),
+		"Test_getFeeSchedule":    getFeeScheduleTestCasesBuilder(),
+		"Test_listFeeSchedules":  listFeeSchedulesTestCasesBuilder(),
+		"Test_resolveHourlyFee":  resolveHourlyFeeTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomFeeSchedule() db.FeeSchedule {
+	return db.FeeSchedule{
+		FeeScheduleID: util.RandomInt64(1, 1000),
+		StudentID:     sql.NullInt64{Int64: util.RandomInt64(1, 1000), Valid: true},
+		SubjectID:     sql.NullInt64{Int64: util.RandomInt64(1, 1000), Valid: true},
+		HourlyFee:     util.RandomFloat64(100, 300),
+		EffectiveFrom: time.Now().UTC().Truncate(time.Second),
+		Notes:         sql.NullString{String: util.RandomNote(), Valid: true},
+		CreatedAt:     time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// createFeeScheduleTestCasesBuilder creates a slice of test cases for the createFeeSchedule API
+func createFeeScheduleTestCasesBuilder() testCases {
+	var testCases testCases
+
+	schedule := randomFeeSchedule()
+	req := createFeeScheduleRequest{
+		StudentID:     schedule.StudentID.Int64,
+		SubjectID:     schedule.SubjectID.Int64,
+		HourlyFee:     schedule.HourlyFee,
+		EffectiveFrom: schedule.EffectiveFrom,
+		Notes:         schedule.Notes,
+	}
+
+	arg := db.CreateFeeScheduleParams{
+		StudentID:     schedule.StudentID,
+		SubjectID:     schedule.SubjectID,
+		HourlyFee:     schedule.HourlyFee,
+		EffectiveFrom: schedule.EffectiveFrom,
+		Notes:         schedule.Notes,
+	}
+
+	methodName := "CreateFeeSchedule"
+	url := "/fee_schedules"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(schedule, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, schedule)
+		},
+	})
+
+	// create a test case for StatusOK response of a fee schedule of all lessons
+	defaultReq := req
+	defaultReq.StudentID = 0
+	defaultReq.SubjectID = 0
+
+	defaultArg := arg
+	defaultArg.StudentID = sql.NullInt64{}
+	defaultArg.SubjectID = sql.NullInt64{}
+
+	testCases = append(testCases, testCase{
+		name:       "OK All Lessons",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       defaultReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, defaultArg).
+				Return(schedule, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.FeeSchedule{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Hourly Fee response
+	invalidReq := req
+	invalidReq.HourlyFee = -10
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Hourly Fee",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Missing Effective From response
+	invalidReq = req
+	invalidReq.EffectiveFrom = time.Time{}
+
+	testCases = append(testCases, testCase{
+		name:       "Missing Effective From",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getFeeScheduleTestCasesBuilder creates a slice of test cases for the getFeeSchedule API
+func getFeeScheduleTestCasesBuilder() testCases {
+	var testCases testCases
+
+	schedule := randomFeeSchedule()
+	id := schedule.FeeScheduleID
+	methodName := "GetFeeSchedule"
+	url := fmt.Sprintf("/fee_schedules/%d", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(schedule, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, schedule)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.FeeSchedule{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/fee_schedules/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// listFeeSchedulesTestCasesBuilder creates a slice of test cases for the listFeeSchedules API
+func listFeeSchedulesTestCasesBuilder() testCases {
+	var testCases testCases
+
+	var schedules []db.FeeSchedule
+	for i := 0; i < 5; i++ {
+		schedules = append(schedules, randomFeeSchedule())
+	}
+
+	methodName := "ListFeeSchedules"
+	arg := db.ListFeeSchedulesParams{
+		SubjectID: sql.NullInt64{Int64: 7, Valid: true},
+		Limit:     5,
+		Offset:    0,
+	}
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/fee_schedules?subject_id=7&page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(schedules, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, schedules)
+		},
+	})
+
+	// create a test case for Invalid Page ID response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Page ID",
+		httpMethod: http.MethodGet,
+		url:        "/fee_schedules?page_id=0&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// resolveHourlyFeeTestCasesBuilder creates a slice of test cases for the resolveHourlyFee API
+func resolveHourlyFeeTestCasesBuilder() testCases {
+	var testCases testCases
+
+	schedule := randomFeeSchedule()
+	fee := db.ResolvedHourlyFee{
+		HourlyFee:     schedule.HourlyFee,
+		FeeScheduleID: sql.NullInt64{Int64: schedule.FeeScheduleID, Valid: true},
+	}
+
+	arg := db.GetApplicableFeeScheduleParams{
+		StudentID:      schedule.StudentID.Int64,
+		SubjectID:      schedule.SubjectID.Int64,
+		LessonDatetime: schedule.EffectiveFrom.Add(time.Hour),
+	}
+
+	methodName := "ResolveHourlyFeeTx"
+	requestURL := fmt.Sprintf("/hourly_fees?student_id=%d&subject_id=%d&lesson_datetime=%s",
+		arg.StudentID, arg.SubjectID, url.QueryEscape(arg.LessonDatetime.Format(time.RFC3339)))
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        requestURL,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(fee, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, fee)
+		},
+	})
+
+	// create a test case for No Hourly Fee response
+	testCases = append(testCases, testCase{
+		name:       "No Hourly Fee",
+		httpMethod: http.MethodGet,
+		url:        requestURL,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.ResolvedHourlyFee{}, db.ErrNoHourlyFee).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Missing Subject response
+	testCases = append(testCases, testCase{
+		name:       "Missing Subject",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/hourly_fees?student_id=%d", arg.StudentID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
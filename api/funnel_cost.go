@@ -0,0 +1,129 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type createFunnelCostRequest struct {
+	FunnelID int64          `json:"funnel_id" binding:"required,min=1"`
+	CostDate time.Time      `json:"cost_date" binding:"required"`
+	Amount   float64        `json:"amount" binding:"required,gt=0"`
+	Notes    sql.NullString `json:"notes"`
+}
+
+func (server *Server) createFunnelCost(ctx *gin.Context) {
+	var req createFunnelCostRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateFunnelCostParams{
+		FunnelID: req.FunnelID,
+		CostDate: req.CostDate,
+		Amount:   req.Amount,
+		Notes:    req.Notes,
+	}
+
+	funnelCost, err := server.store.CreateFunnelCost(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, funnelCost)
+}
+
+type getFunnelCostRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getFunnelCost(ctx *gin.Context) {
+	var req getFunnelCostRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	funnelCost, err := server.store.GetFunnelCost(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, funnelCost)
+}
+
+type listFunnelCostsRequest struct {
+	FunnelID int64 `form:"funnel_id" binding:"required,min=1"`
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listFunnelCosts(ctx *gin.Context) {
+	var req listFunnelCostsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListFunnelCostsParams{
+		FunnelID: req.FunnelID,
+		Limit:    req.PageSize,
+		Offset:   (req.PageID - 1) * req.PageSize,
+	}
+
+	funnelCosts, err := server.store.ListFunnelCosts(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, funnelCosts)
+}
+
+type updateFunnelCostRequest struct {
+	FunnelCostID int64          `json:"funnel_cost_id" binding:"required"`
+	FunnelID     int64          `json:"funnel_id" binding:"required,min=1"`
+	CostDate     time.Time      `json:"cost_date" binding:"required"`
+	Amount       float64        `json:"amount" binding:"required,gt=0"`
+	Notes        sql.NullString `json:"notes"`
+}
+
+func (server *Server) updateFunnelCost(ctx *gin.Context) {
+	var req updateFunnelCostRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateFunnelCostParams{
+		FunnelCostID: req.FunnelCostID,
+		FunnelID:     req.FunnelID,
+		CostDate:     req.CostDate,
+		Amount:       req.Amount,
+		Notes:        req.Notes,
+	}
+
+	err := server.store.UpdateFunnelCost(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Funnel cost updated successfully"))
+}
@@ -0,0 +1,365 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFunnelCostAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createFunnelCostAPI": createFunnelCostTestCasesBuilder(),
+		"Test_getFunnelCost":       getFunnelCostTestCasesBuilder(),
+		"Test_listFunnelCosts":     listFunnelCostsTestCasesBuilder(),
+		"Test_updateFunnelCost":    updateFunnelCostTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+// randomFunnelCost creates a new random FunnelCost struct.
+func randomFunnelCost() db.FunnelCost {
+	return db.FunnelCost{
+		FunnelCostID: util.RandomInt64(1, 1000),
+		FunnelID:     util.RandomInt64(1, 1000),
+		CostDate:     util.RandomDatetime().UTC().Truncate(24 * time.Hour),
+		Amount:       util.RandomPaymentAmount(),
+		Notes:        sql.NullString{String: util.RandomNote(), Valid: true},
+	}
+}
+
+// createFunnelCostTestCasesBuilder creates a slice of test cases for the createFunnelCost API
+func createFunnelCostTestCasesBuilder() testCases {
+	var testCases testCases
+
+	funnelCost := randomFunnelCost()
+	arg := db.CreateFunnelCostParams{
+		FunnelID: funnelCost.FunnelID,
+		CostDate: funnelCost.CostDate,
+		Amount:   funnelCost.Amount,
+		Notes:    funnelCost.Notes,
+	}
+
+	methodName := "CreateFunnelCost"
+	url := "/funnel_costs"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       arg,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(funnelCost, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, funnelCost)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       arg,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.FunnelCost{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Body Data response by passing a negative amount
+	invalidArg := arg
+	invalidArg.Amount = -1
+	testCases = append(testCases, testCase{
+		name:       "Invalid Amount",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidArg,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Invalid Body Data response by passing no arguments
+	testCases = append(testCases, testCase{
+		name:       "Invalid Body Data",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getFunnelCostTestCasesBuilder creates a slice of test cases for the getFunnelCost API
+func getFunnelCostTestCasesBuilder() testCases {
+	var testCases testCases
+
+	funnelCost := randomFunnelCost()
+	id := funnelCost.FunnelCostID
+	methodName := "GetFunnelCost"
+	url := fmt.Sprintf("/funnel_costs/%d", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(funnelCost, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, funnelCost)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.FunnelCost{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.FunnelCost{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/funnel_costs/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// listFunnelCostsTestCasesBuilder creates a slice of test cases for the listFunnelCosts API
+func listFunnelCostsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	n := 5
+	funnelID := util.RandomInt64(1, 1000)
+	funnelCosts := make([]db.FunnelCost, n)
+	for i := 0; i < n; i++ {
+		funnelCosts[i] = randomFunnelCost()
+		funnelCosts[i].FunnelID = funnelID
+	}
+
+	arg := db.ListFunnelCostsParams{
+		FunnelID: funnelID,
+		Limit:    int32(n),
+		Offset:   0,
+	}
+
+	methodName := "ListFunnelCosts"
+	url := fmt.Sprintf("/funnel_costs?funnel_id=%d&page_id=%d&page_size=%d", funnelID, 1, n)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(funnelCosts, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, funnelCosts)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return([]db.FunnelCost{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid FunnelID response by passing url without funnel_id
+	testCases = append(testCases, testCase{
+		name:       "Missing Funnel_ID Parameter",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/funnel_costs?page_id=%d&page_size=%d", 1, n),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Invalid PageSize response by passing url with page_size=10000
+	testCases = append(testCases, testCase{
+		name:       "Invalid Page_Size Parameter",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/funnel_costs?funnel_id=%d&page_id=%d&page_size=%d", funnelID, 1, 10000),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// updateFunnelCostTestCasesBuilder creates a slice of test cases for the updateFunnelCost API
+func updateFunnelCostTestCasesBuilder() testCases {
+	var testCases testCases
+
+	funnelCost := randomFunnelCost()
+	arg := db.UpdateFunnelCostParams{
+		FunnelCostID: funnelCost.FunnelCostID,
+		FunnelID:     funnelCost.FunnelID,
+		CostDate:     funnelCost.CostDate,
+		Amount:       funnelCost.Amount,
+		Notes:        funnelCost.Notes,
+	}
+
+	methodName := "UpdateFunnelCost"
+	url := "/funnel_costs"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       arg,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       arg,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Body Data response by passing no arguments
+	testCases = append(testCases, testCase{
+		name:       "Invalid Body Data",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type groupPricingTierRequest struct {
+	MinStudents int32   `json:"min_students" binding:"required,min=1"`
+	HourlyFee   float64 `json:"hourly_fee" binding:"min=0"`
+}
+
+type createGroupPricingPlanRequest struct {
+	Name      string                    `json:"name" binding:"required"`
+	Mode      string                    `json:"mode" binding:"required,oneof=per_head split tiered"`
+	HourlyFee float64                   `json:"hourly_fee" binding:"min=0"`
+	Tiers     []groupPricingTierRequest `json:"tiers" binding:"required_if=Mode tiered,dive"`
+}
+
+// groupPricingTiers converts the tiers of a request to the tiers parameters of a group pricing plan.
+func groupPricingTiers(tiers []groupPricingTierRequest) []db.GroupPricingTierParams {
+	var result []db.GroupPricingTierParams
+	for _, tier := range tiers {
+		result = append(result, db.GroupPricingTierParams{
+			MinStudents: tier.MinStudents,
+			HourlyFee:   tier.HourlyFee,
+		})
+	}
+	return result
+}
+
+// createGroupPricingPlan creates a group pricing plan, with the tiers of a tiered plan.
+func (server *Server) createGroupPricingPlan(ctx *gin.Context) {
+	var req createGroupPricingPlanRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.GroupPricingPlanTxParams{
+		Name:      req.Name,
+		Mode:      req.Mode,
+		HourlyFee: req.HourlyFee,
+		Tiers:     groupPricingTiers(req.Tiers),
+	}
+
+	plan, err := server.store.CreateGroupPricingPlanTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plan)
+}
+
+type getGroupPricingPlanRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getGroupPricingPlan(ctx *gin.Context) {
+	var req getGroupPricingPlanRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	plan, err := server.store.GetGroupPricingPlanTx(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plan)
+}
+
+type listGroupPricingPlansRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listGroupPricingPlans(ctx *gin.Context) {
+	var req listGroupPricingPlansRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListGroupPricingPlansParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	plans, err := server.store.ListGroupPricingPlans(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plans)
+}
+
+type updateGroupPricingPlanRequest struct {
+	PlanID    int64                     `json:"plan_id" binding:"required,min=1"`
+	Name      string                    `json:"name" binding:"required"`
+	Mode      string                    `json:"mode" binding:"required,oneof=per_head split tiered"`
+	HourlyFee float64                   `json:"hourly_fee" binding:"min=0"`
+	Tiers     []groupPricingTierRequest `json:"tiers" binding:"required_if=Mode tiered,dive"`
+}
+
+// updateGroupPricingPlan updates a group pricing plan and replaces its tiers.
+// Lessons already invoiced keep their invoices until students are added or removed.
+func (server *Server) updateGroupPricingPlan(ctx *gin.Context) {
+	var req updateGroupPricingPlanRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.GroupPricingPlanTxParams{
+		PlanID:    req.PlanID,
+		Name:      req.Name,
+		Mode:      req.Mode,
+		HourlyFee: req.HourlyFee,
+		Tiers:     groupPricingTiers(req.Tiers),
+	}
+
+	_, err := server.store.UpdateGroupPricingPlanTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Group pricing plan updated successfully"))
+}
+
+type groupLessonStudentRequest struct {
+	StudentID int64          `json:"student_id" binding:"required,min=1"`
+	Discount  float64        `json:"discount" binding:"min=0,max=1"`
+	Notes     sql.NullString `json:"notes"`
+}
+
+type createGroupLessonRequest struct {
+	LessonDatetime time.Time                   `json:"lesson_datetime" binding:"required"`
+	Duration       int64                       `json:"duration" binding:"required,min=1"`
+	LocationID     int64                       `json:"location_id" binding:"required,min=1"`
+	SubjectID      int64                       `json:"subject_id" binding:"required,min=1"`
+	Notes          sql.NullString              `json:"notes"`
+	PricingPlanID  int64                       `json:"pricing_plan_id" binding:"required,min=1"`
+	Students       []groupLessonStudentRequest `json:"students" binding:"required,min=1,dive"`
+}
+
+// createGroupLesson creates a lesson priced by a group pricing plan, and the invoices of its students.
+func (server *Server) createGroupLesson(ctx *gin.Context) {
+	var req createGroupLessonRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateGroupLessonTxParams{
+		LessonDatetime: req.LessonDatetime,
+		Duration:       req.Duration,
+		LocationID:     req.LocationID,
+		SubjectID:      req.SubjectID,
+		Notes:          req.Notes,
+		PricingPlanID:  req.PricingPlanID,
+	}
+
+	for _, student := range req.Students {
+		arg.Students = append(arg.Students, db.GroupLessonStudentParams{
+			StudentID: student.StudentID,
+			Discount:  student.Discount,
+			Notes:     student.Notes,
+		})
+	}
+
+	lesson, err := server.store.CreateGroupLessonTx(ctx, arg)
+	if err != nil {
+		server.lessonStudentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lesson)
+}
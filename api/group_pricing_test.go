@@ -0,0 +1,511 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGroupPricingAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createGroupPricingPlan": createGroupPricingPlanTestCasesBuilder(),
+		"Test_getGroupPricingPlan":    getGroupPricingPlanTestCasesBuilder(),
+		"Test_listGroupPricingPlans":  listGroupPricingPlansTestCasesBuilder(),
+		"Test_updateGroupPricingPlan": updateGroupPricingPlanTestCasesBuilder(),
+		"Test_createGroupLesson":      createGroupLessonTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomGroupPricingPlan(mode string) db.GroupPricingPlanWithTiers {
+	plan := db.GroupPricingPlanWithTiers{
+		Plan: db.GroupPricingPlan{
+			PlanID:    util.RandomInt64(1, 1000),
+			Name:      util.RandomName(),
+			Mode:      mode,
+			HourlyFee: util.RandomFloat64(100, 300),
+			CreatedAt: time.Now().UTC().Truncate(time.Second),
+		},
+	}
+
+	if mode == db.PricingModeTiered {
+		plan.Plan.HourlyFee = 0
+		plan.Tiers = []db.GroupPricingTier{
+			{PlanID: plan.Plan.PlanID, MinStudents: 1, HourlyFee: 200},
+			{PlanID: plan.Plan.PlanID, MinStudents: 3, HourlyFee: 150},
+		}
+	}
+
+	return plan
+}
+
+func randomGroupLesson(plan db.GroupPricingPlanWithTiers, n int) db.LessonWithInvoices {
+	lesson := db.LessonWithInvoices{
+		Lesson: db.Lesson{
+			LessonID:       util.RandomInt64(1, 1000),
+			LessonDatetime: time.Now().UTC().Truncate(time.Second),
+			Duration:       60,
+			LocationID:     util.RandomInt64(1, 1000),
+			SubjectID:      util.RandomInt64(1, 1000),
+			PricingPlanID:  sql.NullInt64{Int64: plan.Plan.PlanID, Valid: true},
+		},
+	}
+
+	for i := 0; i < n; i++ {
+		lesson.Invoices = append(lesson.Invoices, db.Invoice{
+			InvoiceID:       util.RandomInt64(1, 1000),
+			StudentID:       int64(i + 1),
+			LessonID:        lesson.Lesson.LessonID,
+			InvoiceDatetime: lesson.Lesson.LessonDatetime,
+			HourlyFee:       plan.Plan.HourlyFee,
+			Duration:        lesson.Lesson.Duration,
+			Amount:          plan.Plan.HourlyFee,
+		})
+	}
+
+	return lesson
+}
+
+// createGroupPricingPlanTestCasesBuilder creates a slice of test cases for the createGroupPricingPlan API
+func createGroupPricingPlanTestCasesBuilder() testCases {
+	var testCases testCases
+
+	plan := randomGroupPricingPlan(db.PricingModeTiered)
+	req := createGroupPricingPlanRequest{
+		Name: plan.Plan.Name,
+		Mode: plan.Plan.Mode,
+	}
+
+	arg := db.GroupPricingPlanTxParams{
+		Name: plan.Plan.Name,
+		Mode: plan.Plan.Mode,
+	}
+
+	for _, tier := range plan.Tiers {
+		req.Tiers = append(req.Tiers, groupPricingTierRequest{MinStudents: tier.MinStudents, HourlyFee: tier.HourlyFee})
+		arg.Tiers = append(arg.Tiers, db.GroupPricingTierParams{MinStudents: tier.MinStudents, HourlyFee: tier.HourlyFee})
+	}
+
+	methodName := "CreateGroupPricingPlanTx"
+	url := "/group_pricing_plans"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(plan, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, plan)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.GroupPricingPlanWithTiers{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Mode response
+	invalidReq := req
+	invalidReq.Mode = "flat"
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Mode",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Missing Tiers response of a tiered plan
+	invalidReq = req
+	invalidReq.Tiers = nil
+
+	testCases = append(testCases, testCase{
+		name:       "Missing Tiers",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Invalid Tier response
+	invalidReq = req
+	invalidReq.Tiers = []groupPricingTierRequest{{MinStudents: 0, HourlyFee: 100}}
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Tier",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getGroupPricingPlanTestCasesBuilder creates a slice of test cases for the getGroupPricingPlan API
+func getGroupPricingPlanTestCasesBuilder() testCases {
+	var testCases testCases
+
+	plan := randomGroupPricingPlan(db.PricingModeSplit)
+	id := plan.Plan.PlanID
+	methodName := "GetGroupPricingPlanTx"
+	url := fmt.Sprintf("/group_pricing_plans/%d", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(plan, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, plan)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.GroupPricingPlanWithTiers{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/group_pricing_plans/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// listGroupPricingPlansTestCasesBuilder creates a slice of test cases for the listGroupPricingPlans API
+func listGroupPricingPlansTestCasesBuilder() testCases {
+	var testCases testCases
+
+	var plans []db.GroupPricingPlan
+	for i := 0; i < 5; i++ {
+		plans = append(plans, randomGroupPricingPlan(db.PricingModePerHead).Plan)
+	}
+
+	methodName := "ListGroupPricingPlans"
+	arg := db.ListGroupPricingPlansParams{
+		Limit:  5,
+		Offset: 5,
+	}
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/group_pricing_plans?page_id=2&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(plans, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, plans)
+		},
+	})
+
+	// create a test case for Invalid Page Size response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Page Size",
+		httpMethod: http.MethodGet,
+		url:        "/group_pricing_plans?page_id=1&page_size=20",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// updateGroupPricingPlanTestCasesBuilder creates a slice of test cases for the updateGroupPricingPlan API
+func updateGroupPricingPlanTestCasesBuilder() testCases {
+	var testCases testCases
+
+	plan := randomGroupPricingPlan(db.PricingModeSplit)
+	req := updateGroupPricingPlanRequest{
+		PlanID:    plan.Plan.PlanID,
+		Name:      util.RandomName(),
+		Mode:      db.PricingModePerHead,
+		HourlyFee: plan.Plan.HourlyFee,
+	}
+
+	arg := db.GroupPricingPlanTxParams{
+		PlanID:    req.PlanID,
+		Name:      req.Name,
+		Mode:      req.Mode,
+		HourlyFee: req.HourlyFee,
+	}
+
+	methodName := "UpdateGroupPricingPlanTx"
+	url := "/group_pricing_plans"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(plan, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.GroupPricingPlanWithTiers{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Hourly Fee response
+	invalidReq := req
+	invalidReq.HourlyFee = -1
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Hourly Fee",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// createGroupLessonTestCasesBuilder creates a slice of test cases for the createGroupLesson API
+func createGroupLessonTestCasesBuilder() testCases {
+	var testCases testCases
+
+	plan := randomGroupPricingPlan(db.PricingModeSplit)
+	lesson := randomGroupLesson(plan, 2)
+
+	req := createGroupLessonRequest{
+		LessonDatetime: lesson.Lesson.LessonDatetime,
+		Duration:       lesson.Lesson.Duration,
+		LocationID:     lesson.Lesson.LocationID,
+		SubjectID:      lesson.Lesson.SubjectID,
+		PricingPlanID:  plan.Plan.PlanID,
+	}
+
+	arg := db.CreateGroupLessonTxParams{
+		LessonDatetime: req.LessonDatetime,
+		Duration:       req.Duration,
+		LocationID:     req.LocationID,
+		SubjectID:      req.SubjectID,
+		PricingPlanID:  req.PricingPlanID,
+	}
+
+	for _, invoice := range lesson.Invoices {
+		req.Students = append(req.Students, groupLessonStudentRequest{StudentID: invoice.StudentID, Discount: 0.1})
+		arg.Students = append(arg.Students, db.GroupLessonStudentParams{StudentID: invoice.StudentID, Discount: 0.1})
+	}
+
+	methodName := "CreateGroupLessonTx"
+	url := "/group_lessons"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(lesson, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, lesson)
+		},
+	})
+
+	// create a test case for Not Found response of a missing plan
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.LessonWithInvoices{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for No Pricing Tier response
+	testCases = append(testCases, testCase{
+		name:       "No Pricing Tier",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.LessonWithInvoices{}, db.ErrNoPricingTier).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Discount response
+	invalidReq := req
+	invalidReq.Students = []groupLessonStudentRequest{{StudentID: 1, Discount: 1.5}}
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Discount",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Missing Students response
+	invalidReq = req
+	invalidReq.Students = nil
+
+	testCases = append(testCases, testCase{
+		name:       "Missing Students",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
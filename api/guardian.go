@@ -0,0 +1,342 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type createGuardianRequest struct {
+	FirstName   string         `json:"first_name" binding:"required"`
+	LastName    string         `json:"last_name" binding:"required"`
+	Email       sql.NullString `json:"email"`
+	PhoneNumber sql.NullString `json:"phone_number"`
+	Address     sql.NullString `json:"address"`
+	Notes       sql.NullString `json:"notes"`
+}
+
+func (server *Server) createGuardian(ctx *gin.Context) {
+	var req createGuardianRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateGuardianParams{
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		Email:       req.Email,
+		PhoneNumber: req.PhoneNumber,
+		Address:     req.Address,
+		Notes:       req.Notes,
+	}
+
+	guardian, err := server.store.CreateGuardian(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, guardian)
+}
+
+type getGuardianRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getGuardian(ctx *gin.Context) {
+	var req getGuardianRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guardian, err := server.store.GetGuardian(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, guardian)
+}
+
+type listGuardiansRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listGuardians(ctx *gin.Context) {
+	var req listGuardiansRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListGuardiansParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	guardians, err := server.store.ListGuardians(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, guardians)
+}
+
+type updateGuardianRequest struct {
+	GuardianID  int64          `json:"guardian_id" binding:"required"`
+	FirstName   string         `json:"first_name" binding:"required"`
+	LastName    string         `json:"last_name" binding:"required"`
+	Email       sql.NullString `json:"email"`
+	PhoneNumber sql.NullString `json:"phone_number"`
+	Address     sql.NullString `json:"address"`
+	Notes       sql.NullString `json:"notes"`
+}
+
+func (server *Server) updateGuardian(ctx *gin.Context) {
+	var req updateGuardianRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateGuardianParams{
+		GuardianID:  req.GuardianID,
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		Email:       req.Email,
+		PhoneNumber: req.PhoneNumber,
+		Address:     req.Address,
+		Notes:       req.Notes,
+	}
+
+	err := server.store.UpdateGuardian(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Guardian updated successfully"))
+}
+
+// getGuardianStudents returns the students of a guardian, with the relationship of the guardian to each student.
+func (server *Server) getGuardianStudents(ctx *gin.Context) {
+	var req getGuardianRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	students, err := server.store.GetGuardianStudents(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, students)
+}
+
+type studentUriRequest struct {
+	StudentID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getStudentGuardians returns the guardians of a student, the billing contact first.
+func (server *Server) getStudentGuardians(ctx *gin.Context) {
+	var uri studentUriRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guardians, err := server.store.GetStudentGuardians(ctx, uri.StudentID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, guardians)
+}
+
+type linkStudentGuardianRequest struct {
+	GuardianID     int64  `json:"guardian_id" binding:"required,min=1"`
+	Relationship   string `json:"relationship" binding:"required,oneof=parent guardian grandparent sibling spouse other"`
+	BillingContact bool   `json:"billing_contact"`
+}
+
+// linkStudentGuardian links a guardian to a student, or updates the relationship and billing contact flag of a linked guardian.
+// The invoices, receipts and statements of the student are sent to the billing contact.
+func (server *Server) linkStudentGuardian(ctx *gin.Context) {
+	var uri studentUriRequest
+	var req linkStudentGuardianRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpsertStudentGuardianParams{
+		StudentID:      uri.StudentID,
+		GuardianID:     req.GuardianID,
+		Relationship:   req.Relationship,
+		BillingContact: req.BillingContact,
+	}
+
+	link, err := server.store.LinkStudentGuardianTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, link)
+}
+
+type unlinkStudentGuardianRequest struct {
+	StudentID  int64 `uri:"id" binding:"required,min=1"`
+	GuardianID int64 `uri:"guardian_id" binding:"required,min=1"`
+}
+
+func (server *Server) unlinkStudentGuardian(ctx *gin.Context) {
+	var req unlinkStudentGuardianRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeleteStudentGuardian(ctx, db.DeleteStudentGuardianParams{
+		StudentID:  req.StudentID,
+		GuardianID: req.GuardianID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Guardian unlinked successfully"))
+}
+
+type guardianReceiptPaymentRequest struct {
+	PaymentDatetime time.Time `json:"payment_datetime" binding:"required"`
+	Amount          float64   `json:"amount" binding:"min=0.01"`
+	PaymentMethodID int64     `json:"payment_method_id" binding:"required,min=1"`
+}
+
+type guardianReceiptAllocationRequest struct {
+	StudentID int64   `json:"student_id" binding:"required,min=1"`
+	Amount    float64 `json:"amount" binding:"min=0.01"`
+}
+
+type createGuardianReceiptRequest struct {
+	GuardianID      int64                              `json:"guardian_id" binding:"required,min=1"`
+	ReceiptDatetime time.Time                          `json:"receipt_datetime" binding:"required"`
+	Notes           sql.NullString                     `json:"notes"`
+	Payments        []guardianReceiptPaymentRequest    `json:"payments" binding:"required,min=1,dive"`
+	Allocations     []guardianReceiptAllocationRequest `json:"allocations" binding:"required,min=1,unique=StudentID,dive"`
+}
+
+// createGuardianReceipt creates a single receipt of a guardian paying for one or more of their students,
+// allocated to a receipt of each student.
+func (server *Server) createGuardianReceipt(ctx *gin.Context) {
+	var req createGuardianReceiptRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateGuardianReceiptTxParams{
+		GuardianID:      req.GuardianID,
+		ReceiptDatetime: req.ReceiptDatetime,
+		Notes:           req.Notes,
+	}
+
+	for _, payment := range req.Payments {
+		arg.Payments = append(arg.Payments, db.CreateReceiptTxPaymentParams{
+			PaymentDatetime: payment.PaymentDatetime,
+			Amount:          payment.Amount,
+			PaymentMethodID: payment.PaymentMethodID,
+		})
+	}
+
+	for _, allocation := range req.Allocations {
+		arg.Allocations = append(arg.Allocations, db.GuardianReceiptAllocation{
+			StudentID: allocation.StudentID,
+			Amount:    allocation.Amount,
+		})
+	}
+
+	receipt, err := server.store.CreateGuardianReceiptTx(ctx, arg)
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, db.ErrNotGuardianStudent), errors.Is(err, db.ErrAllocationMismatch):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, receipt)
+}
+
+type getGuardianReceiptRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getGuardianReceipt returns a guardian receipt with the receipts of the students it is allocated to.
+func (server *Server) getGuardianReceipt(ctx *gin.Context) {
+	var req getGuardianReceiptRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	receipt, err := server.store.GetGuardianReceiptTx(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, receipt)
+}
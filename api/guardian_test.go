@@ -0,0 +1,791 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGuardianAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createGuardian":        createGuardianTestCasesBuilder(),
+		"Test_getGuardian":           getGuardianTestCasesBuilder(),
+		"Test_linkStudentGuardian":   linkStudentGuardianTestCasesBuilder(),
+		"Test_unlinkStudentGuardian": unlinkStudentGuardianTestCasesBuilder(),
+		"Test_createGuardianReceipt": createGuardianReceiptTestCasesBuilder(),
+		"Test_getGuardianReceipt":    getGuardianReceiptTestCasesBuilder(),
+		"Test_getStudentGuardians":   getStudentGuardiansTestCasesBuilder(),
+		"Test_updateGuardian":        updateGuardianTestCasesBuilder(),
+		"Test_listGuardians":         listGuardiansTestCasesBuilder(),
+		"Test_getGuardianStudents":   getGuardianStudentsTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomGuardian() db.Guardian {
+	return db.Guardian{
+		GuardianID:  util.RandomInt64(1, 1000),
+		FirstName:   util.RandomName(),
+		LastName:    util.RandomName(),
+		Email:       sql.NullString{String: util.RandomEmail(), Valid: true},
+		PhoneNumber: sql.NullString{String: util.RandomPhoneNumber(), Valid: true},
+		Address:     sql.NullString{String: util.RandomAddress(), Valid: true},
+		Notes:       sql.NullString{String: util.RandomNote(), Valid: true},
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// randomGuardianReceipt returns a guardian receipt of a guardian, allocated to n students.
+func randomGuardianReceipt(guardianID int64, n int) db.GuardianReceiptWithReceipts {
+	result := db.GuardianReceiptWithReceipts{
+		GuardianReceipt: db.GuardianReceipt{
+			GuardianReceiptID: util.RandomInt64(1, 1000),
+			GuardianID:        guardianID,
+			ReceiptDatetime:   time.Now().UTC().Truncate(time.Second),
+			Notes:             sql.NullString{String: util.RandomNote(), Valid: true},
+		},
+	}
+
+	for i := 0; i < n; i++ {
+		receipt := db.Receipt{
+			ReceiptID:         util.RandomInt64(1, 1000),
+			StudentID:         util.RandomInt64(1, 1000),
+			ReceiptDatetime:   result.GuardianReceipt.ReceiptDatetime,
+			Amount:            float64(util.RandomInt64(100, 300)),
+			Notes:             result.GuardianReceipt.Notes,
+			GuardianReceiptID: sql.NullInt64{Int64: result.GuardianReceipt.GuardianReceiptID, Valid: true},
+		}
+
+		result.GuardianReceipt.Amount += receipt.Amount
+		result.Receipts = append(result.Receipts, db.ReceiptWithPayments{
+			Receipt: receipt,
+			Payments: db.Payments{{
+				PaymentID:       util.RandomInt64(1, 1000),
+				ReceiptID:       receipt.ReceiptID,
+				PaymentDatetime: receipt.ReceiptDatetime,
+				Amount:          receipt.Amount,
+				PaymentMethodID: util.RandomInt64(1, 10),
+			}},
+		})
+	}
+
+	return result
+}
+
+// createGuardianTestCasesBuilder creates a slice of test cases for the createGuardian API
+func createGuardianTestCasesBuilder() testCases {
+	var testCases testCases
+
+	guardian := randomGuardian()
+	req := createGuardianRequest{
+		FirstName:   guardian.FirstName,
+		LastName:    guardian.LastName,
+		Email:       guardian.Email,
+		PhoneNumber: guardian.PhoneNumber,
+		Address:     guardian.Address,
+		Notes:       guardian.Notes,
+	}
+
+	arg := db.CreateGuardianParams{
+		FirstName:   guardian.FirstName,
+		LastName:    guardian.LastName,
+		Email:       guardian.Email,
+		PhoneNumber: guardian.PhoneNumber,
+		Address:     guardian.Address,
+		Notes:       guardian.Notes,
+	}
+
+	methodName := "CreateGuardian"
+	url := "/guardians"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(guardian, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, guardian)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.Guardian{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Missing Last Name response
+	invalidReq := req
+	invalidReq.LastName = ""
+
+	testCases = append(testCases, testCase{
+		name:       "Missing Last Name",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getGuardianTestCasesBuilder creates a slice of test cases for the getGuardian API
+func getGuardianTestCasesBuilder() testCases {
+	var testCases testCases
+
+	guardian := randomGuardian()
+	methodName := "GetGuardian"
+	url := fmt.Sprintf("/guardians/%d", guardian.GuardianID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, guardian.GuardianID).
+				Return(guardian, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, guardian)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, guardian.GuardianID).
+				Return(db.Guardian{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/guardians/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// listGuardiansTestCasesBuilder creates a slice of test cases for the listGuardians API
+func listGuardiansTestCasesBuilder() testCases {
+	var testCases testCases
+
+	var guardians []db.Guardian
+	for i := 0; i < 5; i++ {
+		guardians = append(guardians, randomGuardian())
+	}
+
+	arg := db.ListGuardiansParams{
+		Limit:  5,
+		Offset: 5,
+	}
+
+	methodName := "ListGuardians"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/guardians?page_id=2&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(guardians, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, guardians)
+		},
+	})
+
+	// create a test case for Invalid Page Size response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Page Size",
+		httpMethod: http.MethodGet,
+		url:        "/guardians?page_id=1&page_size=20",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// updateGuardianTestCasesBuilder creates a slice of test cases for the updateGuardian API
+func updateGuardianTestCasesBuilder() testCases {
+	var testCases testCases
+
+	guardian := randomGuardian()
+	req := updateGuardianRequest{
+		GuardianID:  guardian.GuardianID,
+		FirstName:   guardian.FirstName,
+		LastName:    guardian.LastName,
+		Email:       guardian.Email,
+		PhoneNumber: guardian.PhoneNumber,
+		Address:     guardian.Address,
+		Notes:       guardian.Notes,
+	}
+
+	arg := db.UpdateGuardianParams{
+		GuardianID:  guardian.GuardianID,
+		FirstName:   guardian.FirstName,
+		LastName:    guardian.LastName,
+		Email:       guardian.Email,
+		PhoneNumber: guardian.PhoneNumber,
+		Address:     guardian.Address,
+		Notes:       guardian.Notes,
+	}
+
+	methodName := "UpdateGuardian"
+	url := "/guardians"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// getGuardianStudentsTestCasesBuilder creates a slice of test cases for the getGuardianStudents API
+func getGuardianStudentsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	guardian := randomGuardian()
+	students := []db.GetGuardianStudentsRow{
+		{
+			StudentID:      util.RandomInt64(1, 1000),
+			FirstName:      util.RandomName(),
+			LastName:       guardian.LastName,
+			Relationship:   db.GuardianRelationshipParent,
+			BillingContact: true,
+		},
+		{
+			StudentID:    util.RandomInt64(1, 1000),
+			FirstName:    util.RandomName(),
+			LastName:     guardian.LastName,
+			Relationship: db.GuardianRelationshipParent,
+		},
+	}
+
+	methodName := "GetGuardianStudents"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/guardians/%d/students", guardian.GuardianID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, guardian.GuardianID).
+				Return(students, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, students)
+		},
+	})
+
+	return testCases
+}
+
+// getStudentGuardiansTestCasesBuilder creates a slice of test cases for the getStudentGuardians API
+func getStudentGuardiansTestCasesBuilder() testCases {
+	var testCases testCases
+
+	studentID := util.RandomInt64(1, 1000)
+	guardian := randomGuardian()
+	guardians := []db.GetStudentGuardiansRow{
+		{
+			GuardianID:     guardian.GuardianID,
+			FirstName:      guardian.FirstName,
+			LastName:       guardian.LastName,
+			Email:          guardian.Email,
+			CreatedAt:      guardian.CreatedAt,
+			Relationship:   db.GuardianRelationshipParent,
+			BillingContact: true,
+		},
+	}
+
+	methodName := "GetStudentGuardians"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/students/%d/guardians", studentID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, studentID).
+				Return(guardians, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, guardians)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/students/%d/guardians", studentID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(nil, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// linkStudentGuardianTestCasesBuilder creates a slice of test cases for the linkStudentGuardian API
+func linkStudentGuardianTestCasesBuilder() testCases {
+	var testCases testCases
+
+	link := db.StudentGuardian{
+		StudentID:      util.RandomInt64(1, 1000),
+		GuardianID:     util.RandomInt64(1, 1000),
+		Relationship:   db.GuardianRelationshipParent,
+		BillingContact: true,
+		CreatedAt:      time.Now().UTC().Truncate(time.Second),
+	}
+
+	req := linkStudentGuardianRequest{
+		GuardianID:     link.GuardianID,
+		Relationship:   link.Relationship,
+		BillingContact: link.BillingContact,
+	}
+
+	arg := db.UpsertStudentGuardianParams{
+		StudentID:      link.StudentID,
+		GuardianID:     link.GuardianID,
+		Relationship:   link.Relationship,
+		BillingContact: link.BillingContact,
+	}
+
+	methodName := "LinkStudentGuardianTx"
+	url := fmt.Sprintf("/students/%d/guardians", link.StudentID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(link, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, link)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.StudentGuardian{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Relationship response
+	invalidReq := req
+	invalidReq.Relationship = "neighbour"
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Relationship",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// unlinkStudentGuardianTestCasesBuilder creates a slice of test cases for the unlinkStudentGuardian API
+func unlinkStudentGuardianTestCasesBuilder() testCases {
+	var testCases testCases
+
+	arg := db.DeleteStudentGuardianParams{
+		StudentID:  util.RandomInt64(1, 1000),
+		GuardianID: util.RandomInt64(1, 1000),
+	}
+
+	methodName := "DeleteStudentGuardian"
+	url := fmt.Sprintf("/students/%d/guardians/%d", arg.StudentID, arg.GuardianID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(int64(0), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// createGuardianReceiptTestCasesBuilder creates a slice of test cases for the createGuardianReceipt API
+func createGuardianReceiptTestCasesBuilder() testCases {
+	var testCases testCases
+
+	result := randomGuardianReceipt(util.RandomInt64(1, 1000), 2)
+	paymentMethodID := util.RandomInt64(1, 10)
+
+	req := createGuardianReceiptRequest{
+		GuardianID:      result.GuardianReceipt.GuardianID,
+		ReceiptDatetime: result.GuardianReceipt.ReceiptDatetime,
+		Notes:           result.GuardianReceipt.Notes,
+		Payments: []guardianReceiptPaymentRequest{{
+			PaymentDatetime: result.GuardianReceipt.ReceiptDatetime,
+			Amount:          result.GuardianReceipt.Amount,
+			PaymentMethodID: paymentMethodID,
+		}},
+	}
+
+	arg := db.CreateGuardianReceiptTxParams{
+		GuardianID:      req.GuardianID,
+		ReceiptDatetime: req.ReceiptDatetime,
+		Notes:           req.Notes,
+		Payments: []db.CreateReceiptTxPaymentParams{{
+			PaymentDatetime: result.GuardianReceipt.ReceiptDatetime,
+			Amount:          result.GuardianReceipt.Amount,
+			PaymentMethodID: paymentMethodID,
+		}},
+	}
+
+	for _, receipt := range result.Receipts {
+		req.Allocations = append(req.Allocations, guardianReceiptAllocationRequest{
+			StudentID: receipt.Receipt.StudentID,
+			Amount:    receipt.Receipt.Amount,
+		})
+		arg.Allocations = append(arg.Allocations, db.GuardianReceiptAllocation{
+			StudentID: receipt.Receipt.StudentID,
+			Amount:    receipt.Receipt.Amount,
+		})
+	}
+
+	methodName := "CreateGuardianReceiptTx"
+	url := "/guardian_receipts"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(result, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, result)
+		},
+	})
+
+	// create a test case for Not Guardian Student response
+	testCases = append(testCases, testCase{
+		name:       "Not Guardian Student",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.GuardianReceiptWithReceipts{}, db.ErrNotGuardianStudent).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		},
+	})
+
+	// create a test case for Allocation Mismatch response
+	testCases = append(testCases, testCase{
+		name:       "Allocation Mismatch",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.GuardianReceiptWithReceipts{}, db.ErrAllocationMismatch).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		},
+	})
+
+	// create a test case for Guardian Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Guardian Not Found",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.GuardianReceiptWithReceipts{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Duplicate Student response
+	duplicateReq := req
+	duplicateReq.Allocations = []guardianReceiptAllocationRequest{req.Allocations[0], req.Allocations[0]}
+
+	testCases = append(testCases, testCase{
+		name:       "Duplicate Student",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       duplicateReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Allocation Under A Cent response
+	underCentReq := req
+	underCentReq.Allocations = append([]guardianReceiptAllocationRequest{}, req.Allocations...)
+	underCentReq.Allocations[0].Amount = 0.004
+
+	testCases = append(testCases, testCase{
+		name:       "Allocation Under A Cent",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       underCentReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Missing Allocations response
+	missingReq := req
+	missingReq.Allocations = nil
+
+	testCases = append(testCases, testCase{
+		name:       "Missing Allocations",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       missingReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getGuardianReceiptTestCasesBuilder creates a slice of test cases for the getGuardianReceipt API
+func getGuardianReceiptTestCasesBuilder() testCases {
+	var testCases testCases
+
+	result := randomGuardianReceipt(util.RandomInt64(1, 1000), 2)
+	methodName := "GetGuardianReceiptTx"
+	url := fmt.Sprintf("/guardian_receipts/%d", result.GuardianReceipt.GuardianReceiptID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, result.GuardianReceipt.GuardianReceiptID).
+				Return(result, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, result)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, result.GuardianReceipt.GuardianReceiptID).
+				Return(db.GuardianReceiptWithReceipts{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
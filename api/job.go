@@ -0,0 +1,139 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/jobs"
+)
+
+// jobResponse describes a background job, and its latest run if it has run.
+type jobResponse struct {
+	Name      string     `json:"name"`
+	Schedule  string     `json:"schedule"`
+	NextRunAt time.Time  `json:"next_run_at"`
+	LastRun   *db.JobRun `json:"last_run"`
+}
+
+// listJobs returns the background jobs registered with the scheduler.
+func (server *Server) listJobs(ctx *gin.Context) {
+	runs, err := server.store.GetLatestJobRuns(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	lastRuns := make(map[string]db.JobRun, len(runs))
+	for _, run := range runs {
+		lastRuns[run.JobName] = run
+	}
+
+	infos := server.scheduler.Jobs()
+	rsp := make([]jobResponse, 0, len(infos))
+
+	for _, info := range infos {
+		job := jobResponse{
+			Name:      info.Name,
+			Schedule:  info.Schedule,
+			NextRunAt: info.NextRunAt,
+		}
+
+		if run, ok := lastRuns[info.Name]; ok {
+			job.LastRun = &run
+		}
+
+		rsp = append(rsp, job)
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type runJobRequest struct {
+	Name string `uri:"name" binding:"required"`
+}
+
+// runJob triggers a background job immediately. The job runs in the background,
+// and the response contains the started run, which can be followed with getJobRun.
+func (server *Server) runJob(ctx *gin.Context) {
+	var req runJobRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	run, err := server.scheduler.Trigger(ctx, req.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, jobs.ErrJobNotFound):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, db.ErrJobLocked):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, run)
+}
+
+type getJobRunRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getJobRun(ctx *gin.Context) {
+	var req getJobRunRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	run, err := server.store.GetJobRun(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, run)
+}
+
+type listJobRunsRequest struct {
+	JobName  string `form:"job_name"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listJobRuns returns the history of the job runs, newest first, optionally of a single job.
+func (server *Server) listJobRuns(ctx *gin.Context) {
+	var req listJobRunsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListJobRunsParams{
+		JobName: sql.NullString{String: req.JobName, Valid: req.JobName != ""},
+		Limit:   req.PageSize,
+		Offset:  (req.PageID - 1) * req.PageSize,
+	}
+
+	runs, err := server.store.ListJobRuns(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, runs)
+}
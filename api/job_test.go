@@ -0,0 +1,401 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/jobs"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testJobName is the name of the job registered with the scheduler of the test server.
+const testJobName = "test_job"
+
+// newTestScheduler creates a scheduler with a single job, that does nothing and runs hourly.
+func newTestScheduler(mockStore *mocks.MockStore) *jobs.Scheduler {
+	scheduler := jobs.NewScheduler(mockStore)
+
+	err := scheduler.Add(testJobName, jobs.MustParseSchedule("@hourly"), func(ctx context.Context) error {
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return scheduler
+}
+
+func TestJobAPIs(t *testing.T) {
+	tests := tests{
+		"Test_listJobs":    listJobsTestCasesBuilder(),
+		"Test_runJob":      runJobTestCasesBuilder(),
+		"Test_getJobRun":   getJobRunTestCasesBuilder(),
+		"Test_listJobRuns": listJobRunsTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomJobRun(jobName string) db.JobRun {
+	startedAt := time.Now().UTC().Truncate(time.Second)
+
+	return db.JobRun{
+		RunID:       util.RandomInt64(1, 1000),
+		JobName:     jobName,
+		Trigger:     db.JobTriggerSchedule,
+		Status:      db.JobRunStatusSucceeded,
+		ScheduledAt: startedAt,
+		StartedAt:   startedAt,
+		FinishedAt:  sql.NullTime{Time: startedAt.Add(time.Second), Valid: true},
+	}
+}
+
+// listJobsTestCasesBuilder creates a slice of test cases for the listJobs API
+func listJobsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	lastRun := randomJobRun(testJobName)
+	methodName := "GetLatestJobRuns"
+	url := "/admin/jobs"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			// runs of jobs that are no longer registered are ignored
+			mockStore.On(methodName, mock.Anything).
+				Return([]db.JobRun{lastRun, randomJobRun("removed_job")}, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+
+			var rsp []jobResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+			require.Len(t, rsp, 1)
+
+			require.Equal(t, testJobName, rsp[0].Name)
+			require.Equal(t, "@hourly", rsp[0].Schedule)
+			require.Equal(t, time.Now().Truncate(time.Hour).Add(time.Hour).Unix(), rsp[0].NextRunAt.Unix())
+			require.Equal(t, &lastRun, rsp[0].LastRun)
+		},
+	})
+
+	// create a test case for StatusOK response of a job that hasn't run yet
+	testCases = append(testCases, testCase{
+		name:       "Never Run",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything).
+				Return([]db.JobRun{}, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+
+			var rsp []jobResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+			require.Len(t, rsp, 1)
+			require.Nil(t, rsp[0].LastRun)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything).
+				Return([]db.JobRun{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// lockJob is the stub of LockJobTx when the lock is available.
+func lockJob(ctx context.Context, jobName string, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// runJobTestCasesBuilder creates a slice of test cases for the runJob API
+func runJobTestCasesBuilder() testCases {
+	var testCases testCases
+
+	run := randomJobRun(testJobName)
+	run.Trigger = db.JobTriggerManual
+	run.Status = db.JobRunStatusRunning
+	run.FinishedAt = sql.NullTime{}
+
+	url := fmt.Sprintf("/admin/jobs/%s/run", testJobName)
+
+	// create a test case for StatusAccepted response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("LockJobTx", mock.Anything, testJobName, mock.Anything).
+				Return(lockJob).
+				Once()
+
+			mockStore.On("CreateJobRun", mock.Anything, mock.MatchedBy(func(arg db.CreateJobRunParams) bool {
+				return arg.JobName == testJobName &&
+					arg.Trigger == db.JobTriggerManual &&
+					time.Since(arg.ScheduledAt) < time.Minute
+			})).Return(run, nil).Once()
+
+			// the job keeps running after the response is sent
+			mockStore.On("FinishJobRun", mock.Anything, mock.Anything).
+				Return(db.JobRun{}, nil).
+				Maybe()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusAccepted, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, run)
+		},
+	})
+
+	// create a test case for Conflict response of a job that is already running
+	testCases = append(testCases, testCase{
+		name:       "Already Running",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("LockJobTx", mock.Anything, testJobName, mock.Anything).
+				Return(db.ErrJobLocked).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusConflict, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("LockJobTx", mock.Anything, testJobName, mock.Anything).
+				Return(lockJob).
+				Once()
+
+			mockStore.On("CreateJobRun", mock.Anything, mock.Anything).
+				Return(db.JobRun{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response of a job that isn't registered
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodPost,
+		url:        "/admin/jobs/unknown_job/run",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("LockJobTx", mock.Anything, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+			mockStore.On("LockJobTx", mock.Anything, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getJobRunTestCasesBuilder creates a slice of test cases for the getJobRun API
+func getJobRunTestCasesBuilder() testCases {
+	var testCases testCases
+
+	run := randomJobRun(testJobName)
+	id := run.RunID
+	methodName := "GetJobRun"
+	url := fmt.Sprintf("/admin/job_runs/%d", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(run, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, run)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.JobRun{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/admin/job_runs/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// listJobRunsTestCasesBuilder creates a slice of test cases for the listJobRuns API
+func listJobRunsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	var runs []db.JobRun
+	for i := 0; i < 5; i++ {
+		runs = append(runs, randomJobRun(testJobName))
+	}
+
+	methodName := "ListJobRuns"
+
+	// create a test case for StatusOK response
+	arg := db.ListJobRunsParams{
+		Limit:  5,
+		Offset: 5,
+	}
+
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/admin/job_runs?page_id=2&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(runs, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, runs)
+		},
+	})
+
+	// create a test case for StatusOK response filtered by job name
+	jobArg := db.ListJobRunsParams{
+		JobName: sql.NullString{String: testJobName, Valid: true},
+		Limit:   5,
+		Offset:  0,
+	}
+
+	testCases = append(testCases, testCase{
+		name:       "Job Name",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/admin/job_runs?job_name=%s&page_id=1&page_size=5", testJobName),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, jobArg).
+				Return(runs, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, runs)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        "/admin/job_runs?page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return([]db.JobRun{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Page ID response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Page ID",
+		httpMethod: http.MethodGet,
+		url:        "/admin/job_runs?page_id=0&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
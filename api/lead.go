@@ -0,0 +1,214 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type createLeadRequest struct {
+	FirstName   string         `json:"first_name" binding:"required"`
+	LastName    string         `json:"last_name" binding:"required"`
+	Email       sql.NullString `json:"email"`
+	PhoneNumber sql.NullString `json:"phone_number"`
+	FunnelID    sql.NullInt64  `json:"funnel_id"`
+	StageID     int64          `json:"stage_id" binding:"required,min=1"`
+	Notes       sql.NullString `json:"notes"`
+}
+
+// createLead adds an open lead to a stage of the pipeline.
+func (server *Server) createLead(ctx *gin.Context) {
+	var req createLeadRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateLeadParams{
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		Email:       req.Email,
+		PhoneNumber: req.PhoneNumber,
+		FunnelID:    req.FunnelID,
+		StageID:     req.StageID,
+		Notes:       req.Notes,
+	}
+
+	lead, err := server.store.CreateLead(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lead)
+}
+
+type getLeadRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getLead(ctx *gin.Context) {
+	var req getLeadRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	lead, err := server.store.GetLead(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lead)
+}
+
+type listLeadsRequest struct {
+	FunnelID int64  `form:"funnel_id" binding:"omitempty,min=1"`
+	StageID  int64  `form:"stage_id" binding:"omitempty,min=1"`
+	Status   string `form:"status" binding:"omitempty,oneof=open converted lost"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listLeads returns the leads, newest first, optionally of a single funnel or stage, or with a single status.
+func (server *Server) listLeads(ctx *gin.Context) {
+	var req listLeadsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListLeadsParams{
+		FunnelID: sql.NullInt64{Int64: req.FunnelID, Valid: req.FunnelID != 0},
+		StageID:  sql.NullInt64{Int64: req.StageID, Valid: req.StageID != 0},
+		Status:   sql.NullString{String: req.Status, Valid: req.Status != ""},
+		Limit:    req.PageSize,
+		Offset:   (req.PageID - 1) * req.PageSize,
+	}
+
+	leads, err := server.store.ListLeads(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, leads)
+}
+
+type updateLeadRequest struct {
+	LeadID      int64          `json:"lead_id" binding:"required,min=1"`
+	FirstName   string         `json:"first_name" binding:"required"`
+	LastName    string         `json:"last_name" binding:"required"`
+	Email       sql.NullString `json:"email"`
+	PhoneNumber sql.NullString `json:"phone_number"`
+	FunnelID    sql.NullInt64  `json:"funnel_id"`
+	StageID     int64          `json:"stage_id" binding:"required,min=1"`
+	Status      string         `json:"status" binding:"required,oneof=open lost"`
+	Notes       sql.NullString `json:"notes"`
+}
+
+// updateLead updates a lead, moving it through the pipeline stages or marking it as lost.
+// Leads are converted only by convertLead, and a converted lead keeps its status.
+func (server *Server) updateLead(ctx *gin.Context) {
+	var req updateLeadRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateLeadParams{
+		LeadID:      req.LeadID,
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		Email:       req.Email,
+		PhoneNumber: req.PhoneNumber,
+		FunnelID:    req.FunnelID,
+		StageID:     req.StageID,
+		Notes:       req.Notes,
+		Status:      req.Status,
+	}
+
+	err := server.store.UpdateLead(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Lead updated successfully"))
+}
+
+// deleteLead deletes a lead with its tasks.
+func (server *Server) deleteLead(ctx *gin.Context) {
+	var req getLeadRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeleteLead(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Lead deleted successfully"))
+}
+
+type convertLeadRequest struct {
+	CollegeID sql.NullInt64   `json:"college_id"`
+	HourlyFee sql.NullFloat64 `json:"hourly_fee"`
+}
+
+// convertLead converts a lead into a new student, carrying the funnel of the lead.
+func (server *Server) convertLead(ctx *gin.Context) {
+	var uri getLeadRequest
+	var req convertLeadRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.ConvertLeadTx(ctx, db.ConvertLeadTxParams{
+		LeadID:    uri.ID,
+		CollegeID: req.CollegeID,
+		HourlyFee: req.HourlyFee,
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, db.ErrLeadConverted):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,441 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLeadAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createLead":  createLeadTestCasesBuilder(),
+		"Test_getLead":     getLeadTestCasesBuilder(),
+		"Test_listLeads":   listLeadsTestCasesBuilder(),
+		"Test_updateLead":  updateLeadTestCasesBuilder(),
+		"Test_deleteLead":  deleteLeadTestCasesBuilder(),
+		"Test_convertLead": convertLeadTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomLead() db.Lead {
+	return db.Lead{
+		LeadID:      util.RandomInt64(1, 1000),
+		FirstName:   util.RandomName(),
+		LastName:    util.RandomName(),
+		Email:       sql.NullString{String: util.RandomEmail(), Valid: true},
+		PhoneNumber: sql.NullString{String: util.RandomPhoneNumber(), Valid: true},
+		FunnelID:    sql.NullInt64{Int64: util.RandomInt64(1, 1000), Valid: true},
+		StageID:     util.RandomInt64(1, 1000),
+		Status:      db.LeadStatusOpen,
+		Notes:       sql.NullString{String: util.RandomNote(), Valid: true},
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// createLeadTestCasesBuilder creates a slice of test cases for the createLead API
+func createLeadTestCasesBuilder() testCases {
+	var testCases testCases
+
+	lead := randomLead()
+	req := createLeadRequest{
+		FirstName:   lead.FirstName,
+		LastName:    lead.LastName,
+		Email:       lead.Email,
+		PhoneNumber: lead.PhoneNumber,
+		FunnelID:    lead.FunnelID,
+		StageID:     lead.StageID,
+		Notes:       lead.Notes,
+	}
+
+	arg := db.CreateLeadParams{
+		FirstName:   lead.FirstName,
+		LastName:    lead.LastName,
+		Email:       lead.Email,
+		PhoneNumber: lead.PhoneNumber,
+		FunnelID:    lead.FunnelID,
+		StageID:     lead.StageID,
+		Notes:       lead.Notes,
+	}
+
+	methodName := "CreateLead"
+	url := "/leads"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(lead, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, lead)
+		},
+	})
+
+	// create a test case for Missing Stage response
+	invalidReq := req
+	invalidReq.StageID = 0
+	testCases = append(testCases, testCase{
+		name:       "Missing Stage",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.Lead{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// getLeadTestCasesBuilder creates a slice of test cases for the getLead API
+func getLeadTestCasesBuilder() testCases {
+	var testCases testCases
+
+	lead := randomLead()
+	methodName := "GetLead"
+	url := fmt.Sprintf("/leads/%d", lead.LeadID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, lead.LeadID).
+				Return(lead, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, lead)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, lead.LeadID).
+				Return(db.Lead{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// listLeadsTestCasesBuilder creates a slice of test cases for the listLeads API
+func listLeadsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	leads := []db.Lead{randomLead(), randomLead()}
+	stageID := util.RandomInt64(1, 1000)
+
+	arg := db.ListLeadsParams{
+		StageID: sql.NullInt64{Int64: stageID, Valid: true},
+		Status:  sql.NullString{String: db.LeadStatusOpen, Valid: true},
+		Limit:   10,
+		Offset:  0,
+	}
+
+	methodName := "ListLeads"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/leads?stage_id=%d&status=open&page_id=1&page_size=10", stageID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(leads, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, leads)
+		},
+	})
+
+	// create a test case for Invalid Status response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Status",
+		httpMethod: http.MethodGet,
+		url:        "/leads?status=won&page_id=1&page_size=10",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// updateLeadTestCasesBuilder creates a slice of test cases for the updateLead API
+func updateLeadTestCasesBuilder() testCases {
+	var testCases testCases
+
+	lead := randomLead()
+	req := updateLeadRequest{
+		LeadID:      lead.LeadID,
+		FirstName:   lead.FirstName,
+		LastName:    lead.LastName,
+		Email:       lead.Email,
+		PhoneNumber: lead.PhoneNumber,
+		FunnelID:    lead.FunnelID,
+		StageID:     lead.StageID,
+		Status:      db.LeadStatusLost,
+		Notes:       lead.Notes,
+	}
+
+	arg := db.UpdateLeadParams{
+		LeadID:      lead.LeadID,
+		FirstName:   lead.FirstName,
+		LastName:    lead.LastName,
+		Email:       lead.Email,
+		PhoneNumber: lead.PhoneNumber,
+		FunnelID:    lead.FunnelID,
+		StageID:     lead.StageID,
+		Notes:       lead.Notes,
+		Status:      db.LeadStatusLost,
+	}
+
+	methodName := "UpdateLead"
+	url := "/leads"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Converted Status response, as leads are converted only by convertLead
+	invalidReq := req
+	invalidReq.Status = db.LeadStatusConverted
+	testCases = append(testCases, testCase{
+		name:       "Converted Status",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// deleteLeadTestCasesBuilder creates a slice of test cases for the deleteLead API
+func deleteLeadTestCasesBuilder() testCases {
+	var testCases testCases
+
+	leadID := util.RandomInt64(1, 1000)
+	methodName := "DeleteLead"
+	url := fmt.Sprintf("/leads/%d", leadID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, leadID).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, leadID).
+				Return(int64(0), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// convertLeadTestCasesBuilder creates a slice of test cases for the convertLead API
+func convertLeadTestCasesBuilder() testCases {
+	var testCases testCases
+
+	lead := randomLead()
+	student := randomStudent()
+	student.FunnelID = lead.FunnelID
+
+	lead.Status = db.LeadStatusConverted
+	lead.StudentID = sql.NullInt64{Int64: student.StudentID, Valid: true}
+	lead.ConvertedAt = sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true}
+	result := db.ConvertedLead{Lead: lead, Student: student}
+
+	req := convertLeadRequest{
+		HourlyFee: student.HourlyFee,
+	}
+
+	arg := db.ConvertLeadTxParams{
+		LeadID:    lead.LeadID,
+		HourlyFee: student.HourlyFee,
+	}
+
+	methodName := "ConvertLeadTx"
+	url := fmt.Sprintf("/leads/%d/convert", lead.LeadID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(result, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, result)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.ConvertedLead{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Conflict response of a converted lead
+	testCases = append(testCases, testCase{
+		name:       "Already Converted",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.ConvertedLead{}, db.ErrLeadConverted).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusConflict, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.ConvertedLead{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
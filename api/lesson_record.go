@@ -0,0 +1,123 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// getLessonRecords returns the lesson records of the students of a lesson.
+func (server *Server) getLessonRecords(ctx *gin.Context) {
+	var req lessonUriRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	records, err := server.store.GetLessonRecordsByLesson(ctx, req.LessonID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, records)
+}
+
+type upsertLessonRecordRequest struct {
+	StudentID      int64          `json:"student_id" binding:"required,min=1"`
+	Topics         sql.NullString `json:"topics"`
+	Homework       sql.NullString `json:"homework"`
+	HomeworkStatus string         `json:"homework_status" binding:"omitempty,oneof=none assigned partial completed missed"`
+	Rating         int32          `json:"rating" binding:"omitempty,min=1,max=5"`
+	Notes          sql.NullString `json:"notes"`
+}
+
+// upsertLessonRecord records the topics covered, the homework assigned and its status, and the tutor rating
+// of a student of a lesson, replacing the previous lesson record of the student.
+func (server *Server) upsertLessonRecord(ctx *gin.Context) {
+	var uri lessonUriRequest
+	var req upsertLessonRecordRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpsertLessonRecordParams{
+		LessonID:       uri.LessonID,
+		StudentID:      req.StudentID,
+		Topics:         req.Topics,
+		Homework:       req.Homework,
+		HomeworkStatus: req.HomeworkStatus,
+		Rating:         sql.NullInt32{Int32: req.Rating, Valid: req.Rating != 0},
+		Notes:          req.Notes,
+	}
+
+	record, err := server.store.UpsertLessonRecordTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, record)
+}
+
+type getStudentProgressRequest struct {
+	SubjectID int64     `form:"subject_id" binding:"omitempty,min=1"`
+	StartDate time.Time `form:"start_date" time_format:"2006-01-02" time_utc:"1"`
+	EndDate   time.Time `form:"end_date" time_format:"2006-01-02" time_utc:"1"`
+	PageID    int32     `form:"page_id" binding:"required,min=1"`
+	PageSize  int32     `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// getStudentProgress returns the progress timeline of a student: the lessons of the student, latest first,
+// with the attendance and lesson record of the student, optionally of a single subject or date range.
+func (server *Server) getStudentProgress(ctx *gin.Context) {
+	var uri studentUriRequest
+	var req getStudentProgressRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.GetStudentProgressParams{
+		StudentID:     uri.StudentID,
+		SubjectID:     sql.NullInt64{Int64: req.SubjectID, Valid: req.SubjectID != 0},
+		StartDatetime: sql.NullTime{Time: req.StartDate, Valid: !req.StartDate.IsZero()},
+		Limit:         req.PageSize,
+		Offset:        (req.PageID - 1) * req.PageSize,
+	}
+
+	// the end date is inclusive
+	if !req.EndDate.IsZero() {
+		arg.EndDatetime = sql.NullTime{Time: req.EndDate.AddDate(0, 0, 1), Valid: true}
+	}
+
+	progress, err := server.store.GetStudentProgress(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, progress)
+}
@@ -0,0 +1,315 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLessonRecordAPIs(t *testing.T) {
+	tests := tests{
+		"Test_getLessonRecords":   getLessonRecordsTestCasesBuilder(),
+		"Test_upsertLessonRecord": upsertLessonRecordTestCasesBuilder(),
+		"Test_getStudentProgress": getStudentProgressTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomLessonRecord() db.LessonRecord {
+	return db.LessonRecord{
+		LessonID:       util.RandomInt64(1, 1000),
+		StudentID:      util.RandomInt64(1, 1000),
+		Topics:         sql.NullString{String: util.RandomNote(), Valid: true},
+		Homework:       sql.NullString{String: util.RandomNote(), Valid: true},
+		HomeworkStatus: db.HomeworkStatusAssigned,
+		Rating:         sql.NullInt32{Int32: int32(util.RandomInt64(1, 5)), Valid: true},
+		Notes:          sql.NullString{String: util.RandomNote(), Valid: true},
+		CreatedAt:      time.Now().UTC().Truncate(time.Second),
+		UpdatedAt:      time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// getLessonRecordsTestCasesBuilder creates a slice of test cases for the getLessonRecords API
+func getLessonRecordsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	record := randomLessonRecord()
+	records := []db.LessonRecord{record}
+
+	methodName := "GetLessonRecordsByLesson"
+	url := fmt.Sprintf("/lessons/%d/records", record.LessonID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, record.LessonID).
+				Return(records, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, records)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(nil, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// upsertLessonRecordTestCasesBuilder creates a slice of test cases for the upsertLessonRecord API
+func upsertLessonRecordTestCasesBuilder() testCases {
+	var testCases testCases
+
+	record := randomLessonRecord()
+	req := upsertLessonRecordRequest{
+		StudentID:      record.StudentID,
+		Topics:         record.Topics,
+		Homework:       record.Homework,
+		HomeworkStatus: record.HomeworkStatus,
+		Rating:         record.Rating.Int32,
+		Notes:          record.Notes,
+	}
+
+	arg := db.UpsertLessonRecordParams{
+		LessonID:       record.LessonID,
+		StudentID:      record.StudentID,
+		Topics:         record.Topics,
+		Homework:       record.Homework,
+		HomeworkStatus: record.HomeworkStatus,
+		Rating:         record.Rating,
+		Notes:          record.Notes,
+	}
+
+	methodName := "UpsertLessonRecordTx"
+	url := fmt.Sprintf("/lessons/%d/records", record.LessonID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(record, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, record)
+		},
+	})
+
+	// create a test case for StatusOK response without a rating
+	unratedReq := req
+	unratedReq.Rating = 0
+
+	unratedArg := arg
+	unratedArg.Rating = sql.NullInt32{}
+
+	testCases = append(testCases, testCase{
+		name:       "OK Unrated",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       unratedReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, unratedArg).
+				Return(record, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Student Not In Lesson response
+	testCases = append(testCases, testCase{
+		name:       "Student Not In Lesson",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.LessonRecord{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Rating response
+	invalidReq := req
+	invalidReq.Rating = 6
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Rating",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Invalid Homework Status response
+	invalidReq = req
+	invalidReq.HomeworkStatus = "lost"
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Homework Status",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getStudentProgressTestCasesBuilder creates a slice of test cases for the getStudentProgress API
+func getStudentProgressTestCasesBuilder() testCases {
+	var testCases testCases
+
+	record := randomLessonRecord()
+	progress := []db.GetStudentProgressRow{
+		{
+			LessonID:         record.LessonID,
+			LessonDatetime:   time.Now().UTC().Truncate(time.Second),
+			Duration:         util.RandomLessonDuration(),
+			SubjectID:        util.RandomInt64(1, 1000),
+			SubjectName:      util.RandomName(),
+			AttendanceStatus: db.AttendanceStatusAttended,
+			Topics:           record.Topics,
+			Homework:         record.Homework,
+			HomeworkStatus:   sql.NullString{String: record.HomeworkStatus, Valid: true},
+			Rating:           record.Rating,
+			Notes:            record.Notes,
+			UpdatedAt:        sql.NullTime{Time: record.UpdatedAt, Valid: true},
+		},
+	}
+
+	arg := db.GetStudentProgressParams{
+		StudentID: record.StudentID,
+		Limit:     5,
+		Offset:    0,
+	}
+
+	rangeArg := db.GetStudentProgressParams{
+		StudentID:     record.StudentID,
+		SubjectID:     sql.NullInt64{Int64: progress[0].SubjectID, Valid: true},
+		StartDatetime: sql.NullTime{Time: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		EndDatetime:   sql.NullTime{Time: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		Limit:         5,
+		Offset:        5,
+	}
+
+	methodName := "GetStudentProgress"
+	url := fmt.Sprintf("/students/%d/progress", record.StudentID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url + "?page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(progress, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, progress)
+		},
+	})
+
+	// create a test case for StatusOK response of a subject and date range
+	testCases = append(testCases, testCase{
+		name:       "OK Subject Date Range",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("%s?subject_id=%d&start_date=2024-03-01&end_date=2024-03-31&page_id=2&page_size=5", url, progress[0].SubjectID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, rangeArg).
+				Return(progress, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Date response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Date",
+		httpMethod: http.MethodGet,
+		url:        url + "?start_date=March&page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
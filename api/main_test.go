@@ -37,16 +37,33 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+// multipartBody is a multipart form body of a test request, sent as is instead of as json
+type multipartBody struct {
+	contentType string
+	data        []byte
+}
+
+// newTestServer creates a server with the mock db, the test scheduler and the test blob store
+func newTestServer(mockStore *mocks.MockStore) *Server {
+	return NewServer(mockStore, newTestScheduler(mockStore), testBlobs, testAttachmentLimits)
+}
+
 // sendRequestToTestServer start test server and send the test request
 func (tc *testCase) sendRequestToServer(t *testing.T, mockStore *mocks.MockStore) *httptest.ResponseRecorder {
 	// start test server and send request
-	server := NewServer(mockStore)
+	server := newTestServer(mockStore)
 	recorder := httptest.NewRecorder()
 
 	var reader io.Reader = nil
+	contentType := ""
 
 	// creating new reader with arguments passed
-	if tc.body != nil {
+	switch body := tc.body.(type) {
+	case nil:
+	case multipartBody:
+		reader = bytes.NewReader(body.data)
+		contentType = body.contentType
+	default:
 		jsonData, err := json.Marshal(tc.body)
 		require.NoError(t, err)
 
@@ -56,6 +73,10 @@ func (tc *testCase) sendRequestToServer(t *testing.T, mockStore *mocks.MockStore
 	request, err := http.NewRequest(tc.httpMethod, tc.url, reader)
 	require.NoError(t, err)
 
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
 	server.router.ServeHTTP(recorder, request)
 
 	return recorder
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type createPackageProductRequest struct {
+	Name         string        `json:"name" binding:"required"`
+	Unit         string        `json:"unit" binding:"required,oneof=lesson hour"`
+	Quantity     int32         `json:"quantity" binding:"required,min=1"`
+	Price        float64       `json:"price" binding:"min=0"`
+	ValidityDays sql.NullInt32 `json:"validity_days"`
+}
+
+// createPackageProduct creates an active package product, of a number of lessons or hours sold at a price.
+func (server *Server) createPackageProduct(ctx *gin.Context) {
+	var req createPackageProductRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreatePackageProductParams{
+		Name:         req.Name,
+		Unit:         req.Unit,
+		Quantity:     req.Quantity,
+		Price:        req.Price,
+		ValidityDays: req.ValidityDays,
+		Active:       true,
+	}
+
+	product, err := server.store.CreatePackageProduct(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, product)
+}
+
+type getPackageProductRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getPackageProduct(ctx *gin.Context) {
+	var req getPackageProductRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	product, err := server.store.GetPackageProduct(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, product)
+}
+
+type listPackageProductsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listPackageProducts(ctx *gin.Context) {
+	var req listPackageProductsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListPackageProductsParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	products, err := server.store.ListPackageProducts(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, products)
+}
+
+type updatePackageProductRequest struct {
+	ProductID    int64         `json:"product_id" binding:"required,min=1"`
+	Name         string        `json:"name" binding:"required"`
+	Unit         string        `json:"unit" binding:"required,oneof=lesson hour"`
+	Quantity     int32         `json:"quantity" binding:"required,min=1"`
+	Price        float64       `json:"price" binding:"min=0"`
+	ValidityDays sql.NullInt32 `json:"validity_days"`
+	Active       bool          `json:"active"`
+}
+
+// updatePackageProduct updates a package product. Packages already purchased keep the units, price and expiry of their purchase.
+func (server *Server) updatePackageProduct(ctx *gin.Context) {
+	var req updatePackageProductRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdatePackageProductParams{
+		ProductID:    req.ProductID,
+		Name:         req.Name,
+		Unit:         req.Unit,
+		Quantity:     req.Quantity,
+		Price:        req.Price,
+		ValidityDays: req.ValidityDays,
+		Active:       req.Active,
+	}
+
+	err := server.store.UpdatePackageProduct(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Package product updated successfully"))
+}
+
+type purchasePackageRequest struct {
+	StudentID        int64          `json:"student_id" binding:"required,min=1"`
+	ProductID        int64          `json:"product_id" binding:"required,min=1"`
+	PurchaseDatetime time.Time      `json:"purchase_datetime" binding:"required"`
+	PaymentMethodID  int64          `json:"payment_method_id" binding:"required,min=1"`
+	Notes            sql.NullString `json:"notes"`
+}
+
+// purchasePackage records the purchase of a package by a student, and the receipt of its payment.
+func (server *Server) purchasePackage(ctx *gin.Context) {
+	var req purchasePackageRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.PurchasePackageTxParams{
+		StudentID:        req.StudentID,
+		ProductID:        req.ProductID,
+		PurchaseDatetime: req.PurchaseDatetime,
+		PaymentMethodID:  req.PaymentMethodID,
+		Notes:            req.Notes,
+	}
+
+	purchase, err := server.store.PurchasePackageTx(ctx, arg)
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, db.ErrPackageProductInactive):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, purchase)
+}
+
+type getStudentPackageRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getStudentPackage(ctx *gin.Context) {
+	var req getStudentPackageRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	pkg, err := server.store.GetStudentPackage(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pkg)
+}
+
+type listStudentPackagesRequest struct {
+	StudentID int64 `form:"student_id" binding:"omitempty,min=1"`
+	PageID    int32 `form:"page_id" binding:"required,min=1"`
+	PageSize  int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listStudentPackages returns the purchased packages, newest first, optionally of a single student.
+func (server *Server) listStudentPackages(ctx *gin.Context) {
+	var req listStudentPackagesRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListStudentPackagesParams{
+		StudentID: sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0},
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	}
+
+	packages, err := server.store.ListStudentPackages(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, packages)
+}
+
+type getPackageBalanceRequest struct {
+	StudentID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getPackageBalance returns the packages of a student that aren't used up or expired, and their remaining balances.
+func (server *Server) getPackageBalance(ctx *gin.Context) {
+	var req getPackageBalanceRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	balance, err := server.store.GetStudentPackageBalanceTx(ctx, req.StudentID, time.Now().UTC())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, balance)
+}
@@ -0,0 +1,708 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPackageAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createPackageProduct": createPackageProductTestCasesBuilder(),
+		"Test_getPackageProduct":    getPackageProductTestCasesBuilder(),
+		"Test_listPackageProducts":  listPackageProductsTestCasesBuilder(),
+		"Test_updatePackageProduct": updatePackageProductTestCasesBuilder(),
+		"Test_purchasePackage":      purchasePackageTestCasesBuilder(),
+		"Test_getStudentPackage":    getStudentPackageTestCasesBuilder(),
+		"Test_listStudentPackages":  listStudentPackagesTestCasesBuilder(),
+		"Test_getPackageBalance":    getPackageBalanceTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomPackageProduct() db.PackageProduct {
+	return db.PackageProduct{
+		ProductID:    util.RandomInt64(1, 1000),
+		Name:         util.RandomName(),
+		Unit:         db.PackageUnitLesson,
+		Quantity:     10,
+		Price:        util.RandomFloat64(500, 1500),
+		ValidityDays: sql.NullInt32{Int32: 180, Valid: true},
+		Active:       true,
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func randomStudentPackage(product db.PackageProduct) db.StudentPackage {
+	purchasedAt := time.Now().UTC().Truncate(time.Second)
+
+	return db.StudentPackage{
+		PackageID:      util.RandomInt64(1, 1000),
+		StudentID:      util.RandomInt64(1, 1000),
+		ProductID:      product.ProductID,
+		ReceiptID:      util.RandomInt64(1, 1000),
+		Unit:           product.Unit,
+		TotalUnits:     int64(product.Quantity),
+		RemainingUnits: int64(product.Quantity) - 2,
+		Price:          product.Price,
+		PurchasedAt:    purchasedAt,
+		ExpiresAt:      sql.NullTime{Time: purchasedAt.AddDate(0, 0, int(product.ValidityDays.Int32)), Valid: true},
+	}
+}
+
+// createPackageProductTestCasesBuilder creates a slice of test cases for the createPackageProduct API
+func createPackageProductTestCasesBuilder() testCases {
+	var testCases testCases
+
+	product := randomPackageProduct()
+	req := createPackageProductRequest{
+		Name:         product.Name,
+		Unit:         product.Unit,
+		Quantity:     product.Quantity,
+		Price:        product.Price,
+		ValidityDays: product.ValidityDays,
+	}
+
+	arg := db.CreatePackageProductParams{
+		Name:         product.Name,
+		Unit:         product.Unit,
+		Quantity:     product.Quantity,
+		Price:        product.Price,
+		ValidityDays: product.ValidityDays,
+		Active:       true,
+	}
+
+	methodName := "CreatePackageProduct"
+	url := "/package_products"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(product, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, product)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.PackageProduct{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Unit response
+	invalidReq := req
+	invalidReq.Unit = "week"
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Unit",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Invalid Quantity response
+	invalidReq = req
+	invalidReq.Quantity = 0
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Quantity",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getPackageProductTestCasesBuilder creates a slice of test cases for the getPackageProduct API
+func getPackageProductTestCasesBuilder() testCases {
+	var testCases testCases
+
+	product := randomPackageProduct()
+	id := product.ProductID
+	methodName := "GetPackageProduct"
+	url := fmt.Sprintf("/package_products/%d", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(product, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, product)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.PackageProduct{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/package_products/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// listPackageProductsTestCasesBuilder creates a slice of test cases for the listPackageProducts API
+func listPackageProductsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	var products []db.PackageProduct
+	for i := 0; i < 5; i++ {
+		products = append(products, randomPackageProduct())
+	}
+
+	methodName := "ListPackageProducts"
+	arg := db.ListPackageProductsParams{
+		Limit:  5,
+		Offset: 5,
+	}
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/package_products?page_id=2&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(products, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, products)
+		},
+	})
+
+	// create a test case for Invalid Page Size response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Page Size",
+		httpMethod: http.MethodGet,
+		url:        "/package_products?page_id=1&page_size=20",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// updatePackageProductTestCasesBuilder creates a slice of test cases for the updatePackageProduct API
+func updatePackageProductTestCasesBuilder() testCases {
+	var testCases testCases
+
+	product := randomPackageProduct()
+	req := updatePackageProductRequest{
+		ProductID:    product.ProductID,
+		Name:         util.RandomName(),
+		Unit:         db.PackageUnitHour,
+		Quantity:     5,
+		Price:        product.Price,
+		ValidityDays: sql.NullInt32{},
+		Active:       false,
+	}
+
+	arg := db.UpdatePackageProductParams{
+		ProductID:    req.ProductID,
+		Name:         req.Name,
+		Unit:         req.Unit,
+		Quantity:     req.Quantity,
+		Price:        req.Price,
+		ValidityDays: req.ValidityDays,
+		Active:       req.Active,
+	}
+
+	methodName := "UpdatePackageProduct"
+	url := "/package_products"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response
+	invalidReq := req
+	invalidReq.ProductID = 0
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// purchasePackageTestCasesBuilder creates a slice of test cases for the purchasePackage API
+func purchasePackageTestCasesBuilder() testCases {
+	var testCases testCases
+
+	product := randomPackageProduct()
+	pkg := randomStudentPackage(product)
+	purchase := db.PackagePurchase{
+		Package: pkg,
+		Receipt: db.ReceiptWithPayments{
+			Receipt: db.Receipt{
+				ReceiptID:       pkg.ReceiptID,
+				StudentID:       pkg.StudentID,
+				ReceiptDatetime: pkg.PurchasedAt,
+				Amount:          pkg.Price,
+			},
+			Payments: db.Payments{{
+				PaymentID:       util.RandomInt64(1, 1000),
+				ReceiptID:       pkg.ReceiptID,
+				PaymentDatetime: pkg.PurchasedAt,
+				Amount:          pkg.Price,
+				PaymentMethodID: util.RandomInt64(1, 1000),
+			}},
+		},
+	}
+
+	req := purchasePackageRequest{
+		StudentID:        pkg.StudentID,
+		ProductID:        product.ProductID,
+		PurchaseDatetime: pkg.PurchasedAt,
+		PaymentMethodID:  purchase.Receipt.Payments[0].PaymentMethodID,
+		Notes:            sql.NullString{String: util.RandomNote(), Valid: true},
+	}
+
+	matchArg := mock.MatchedBy(func(arg db.PurchasePackageTxParams) bool {
+		return arg.StudentID == req.StudentID &&
+			arg.ProductID == req.ProductID &&
+			arg.PurchaseDatetime.Equal(req.PurchaseDatetime) &&
+			arg.PaymentMethodID == req.PaymentMethodID &&
+			arg.Notes == req.Notes
+	})
+
+	methodName := "PurchasePackageTx"
+	url := "/student_packages"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, matchArg).
+				Return(purchase, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, purchase)
+		},
+	})
+
+	// create a test case for Not Found response of a product that doesn't exist
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.PackagePurchase{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Bad Request response of an inactive product
+	testCases = append(testCases, testCase{
+		name:       "Inactive Product",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.PackagePurchase{}, db.ErrPackageProductInactive).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.PackagePurchase{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Payment Method response
+	invalidReq := req
+	invalidReq.PaymentMethodID = 0
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Payment Method",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getStudentPackageTestCasesBuilder creates a slice of test cases for the getStudentPackage API
+func getStudentPackageTestCasesBuilder() testCases {
+	var testCases testCases
+
+	pkg := randomStudentPackage(randomPackageProduct())
+	id := pkg.PackageID
+	methodName := "GetStudentPackage"
+	url := fmt.Sprintf("/student_packages/%d", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(pkg, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, pkg)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.StudentPackage{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// listStudentPackagesTestCasesBuilder creates a slice of test cases for the listStudentPackages API
+func listStudentPackagesTestCasesBuilder() testCases {
+	var testCases testCases
+
+	product := randomPackageProduct()
+	var packages []db.StudentPackage
+	for i := 0; i < 5; i++ {
+		packages = append(packages, randomStudentPackage(product))
+	}
+
+	methodName := "ListStudentPackages"
+
+	// create a test case for StatusOK response
+	arg := db.ListStudentPackagesParams{
+		Limit:  5,
+		Offset: 0,
+	}
+
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/student_packages?page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(packages, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, packages)
+		},
+	})
+
+	// create a test case for StatusOK response filtered by student
+	studentArg := db.ListStudentPackagesParams{
+		StudentID: sql.NullInt64{Int64: packages[0].StudentID, Valid: true},
+		Limit:     5,
+		Offset:    5,
+	}
+
+	testCases = append(testCases, testCase{
+		name:       "Student",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/student_packages?student_id=%d&page_id=2&page_size=5", packages[0].StudentID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, studentArg).
+				Return(packages[:1], nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, packages[:1])
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        "/student_packages?page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return([]db.StudentPackage{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// getPackageBalanceTestCasesBuilder creates a slice of test cases for the getPackageBalance API
+func getPackageBalanceTestCasesBuilder() testCases {
+	var testCases testCases
+
+	pkg := randomStudentPackage(randomPackageProduct())
+	balance := db.StudentPackageBalance{
+		StudentID: pkg.StudentID,
+		Balances: []db.GetStudentPackageBalancesRow{{
+			Unit:            pkg.Unit,
+			Packages:        1,
+			RemainingUnits:  pkg.RemainingUnits,
+			RemainingAmount: pkg.Price * float64(pkg.RemainingUnits) / float64(pkg.TotalUnits),
+		}},
+		Packages: []db.StudentPackage{pkg},
+	}
+
+	id := pkg.StudentID
+	methodName := "GetStudentPackageBalanceTx"
+	url := fmt.Sprintf("/package_balances/%d", id)
+
+	matchAsOf := mock.MatchedBy(func(asOf time.Time) bool {
+		return time.Since(asOf) < time.Minute
+	})
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id, matchAsOf).
+				Return(balance, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, balance)
+		},
+	})
+
+	// create a test case for Not Found response of a student that doesn't exist
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id, mock.Anything).
+				Return(db.StudentPackageBalance{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id, mock.Anything).
+				Return(db.StudentPackageBalance{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/package_balances/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type createPipelineStageRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Position int32  `json:"position" binding:"min=0"`
+}
+
+// createPipelineStage adds a stage to the lead pipeline, ordered by its position.
+func (server *Server) createPipelineStage(ctx *gin.Context) {
+	var req createPipelineStageRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreatePipelineStageParams{
+		Name:     req.Name,
+		Position: req.Position,
+	}
+
+	stage, err := server.store.CreatePipelineStage(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stage)
+}
+
+type getPipelineStageRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getPipelineStage(ctx *gin.Context) {
+	var req getPipelineStageRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	stage, err := server.store.GetPipelineStage(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stage)
+}
+
+// listPipelineStages returns the stages of the lead pipeline in their order.
+func (server *Server) listPipelineStages(ctx *gin.Context) {
+	stages, err := server.store.ListPipelineStages(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stages)
+}
+
+type updatePipelineStageRequest struct {
+	StageID  int64  `json:"stage_id" binding:"required,min=1"`
+	Name     string `json:"name" binding:"required"`
+	Position int32  `json:"position" binding:"min=0"`
+}
+
+func (server *Server) updatePipelineStage(ctx *gin.Context) {
+	var req updatePipelineStageRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdatePipelineStageParams{
+		StageID:  req.StageID,
+		Name:     req.Name,
+		Position: req.Position,
+	}
+
+	err := server.store.UpdatePipelineStage(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Pipeline stage updated successfully"))
+}
+
+// deletePipelineStage deletes a pipeline stage. A stage with leads can't be deleted.
+func (server *Server) deletePipelineStage(ctx *gin.Context) {
+	var req getPipelineStageRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeletePipelineStage(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Pipeline stage deleted successfully"))
+}
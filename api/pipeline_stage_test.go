@@ -0,0 +1,277 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPipelineStageAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createPipelineStage": createPipelineStageTestCasesBuilder(),
+		"Test_getPipelineStage":    getPipelineStageTestCasesBuilder(),
+		"Test_listPipelineStages":  listPipelineStagesTestCasesBuilder(),
+		"Test_updatePipelineStage": updatePipelineStageTestCasesBuilder(),
+		"Test_deletePipelineStage": deletePipelineStageTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomPipelineStage() db.PipelineStage {
+	return db.PipelineStage{
+		StageID:   util.RandomInt64(1, 1000),
+		Name:      util.RandomName(),
+		Position:  int32(util.RandomInt64(0, 10)),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// createPipelineStageTestCasesBuilder creates a slice of test cases for the createPipelineStage API
+func createPipelineStageTestCasesBuilder() testCases {
+	var testCases testCases
+
+	stage := randomPipelineStage()
+	req := createPipelineStageRequest{
+		Name:     stage.Name,
+		Position: stage.Position,
+	}
+
+	arg := db.CreatePipelineStageParams{
+		Name:     stage.Name,
+		Position: stage.Position,
+	}
+
+	methodName := "CreatePipelineStage"
+	url := "/pipeline_stages"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(stage, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, stage)
+		},
+	})
+
+	// create a test case for Invalid Position response
+	invalidReq := req
+	invalidReq.Position = -1
+	testCases = append(testCases, testCase{
+		name:       "Invalid Position",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.PipelineStage{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// getPipelineStageTestCasesBuilder creates a slice of test cases for the getPipelineStage API
+func getPipelineStageTestCasesBuilder() testCases {
+	var testCases testCases
+
+	stage := randomPipelineStage()
+	methodName := "GetPipelineStage"
+	url := fmt.Sprintf("/pipeline_stages/%d", stage.StageID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, stage.StageID).
+				Return(stage, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, stage)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, stage.StageID).
+				Return(db.PipelineStage{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// listPipelineStagesTestCasesBuilder creates a slice of test cases for the listPipelineStages API
+func listPipelineStagesTestCasesBuilder() testCases {
+	var testCases testCases
+
+	stages := []db.PipelineStage{randomPipelineStage(), randomPipelineStage()}
+	methodName := "ListPipelineStages"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/pipeline_stages",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything).
+				Return(stages, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, stages)
+		},
+	})
+
+	return testCases
+}
+
+// updatePipelineStageTestCasesBuilder creates a slice of test cases for the updatePipelineStage API
+func updatePipelineStageTestCasesBuilder() testCases {
+	var testCases testCases
+
+	stage := randomPipelineStage()
+	req := updatePipelineStageRequest{
+		StageID:  stage.StageID,
+		Name:     stage.Name,
+		Position: stage.Position,
+	}
+
+	arg := db.UpdatePipelineStageParams{
+		StageID:  stage.StageID,
+		Name:     stage.Name,
+		Position: stage.Position,
+	}
+
+	methodName := "UpdatePipelineStage"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        "/pipeline_stages",
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// deletePipelineStageTestCasesBuilder creates a slice of test cases for the deletePipelineStage API
+func deletePipelineStageTestCasesBuilder() testCases {
+	var testCases testCases
+
+	stageID := util.RandomInt64(1, 1000)
+	methodName := "DeletePipelineStage"
+	url := fmt.Sprintf("/pipeline_stages/%d", stageID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, stageID).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, stageID).
+				Return(int64(0), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/github-real-lb/tutor-management-web/billing"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// dateLayout is the layout of the dates in request bodies, such as 2024-03-31.
+const dateLayout = "2006-01-02"
+
+// errInvalidPeriod is returned when the end date of a period is before its start date.
+var errInvalidPeriod = errors.New("end_date must not be before start_date")
+
+type createProgressReportsRequest struct {
+	StartDate string `json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate   string `json:"end_date" binding:"required,datetime=2006-01-02"`
+	StudentID int64  `json:"student_id" binding:"omitempty,min=1"`
+}
+
+// createProgressReports creates the progress reports of a period, for all students with lessons in the period
+// or for a single student, and emails them to the students or their billing contacts. The end date is inclusive.
+// Students that already have a progress report of the period are skipped, and only the created reports are returned.
+func (server *Server) createProgressReports(ctx *gin.Context) {
+	var req createProgressReportsRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	start, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	end, err := time.Parse(dateLayout, req.EndDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if end.Before(start) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidPeriod))
+		return
+	}
+
+	studentID := sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0}
+
+	// the end date is inclusive
+	reports, err := server.store.CreateProgressReportsTx(ctx, start, end.AddDate(0, 0, 1), studentID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reports)
+}
+
+type getProgressReportRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// bindProgressReportDocument binds the progress report ID and gets the progress report document.
+// In case of an error the error response is sent and ok is false.
+func (server *Server) bindProgressReportDocument(ctx *gin.Context) (doc db.ProgressReportDocument, ok bool) {
+	var req getProgressReportRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return doc, false
+	}
+
+	doc, err := server.store.GetProgressReportDocumentTx(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return doc, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return doc, false
+	}
+
+	return doc, true
+}
+
+// getProgressReport returns a progress report with its student, subjects and lessons.
+func (server *Server) getProgressReport(ctx *gin.Context) {
+	doc, ok := server.bindProgressReportDocument(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, doc)
+}
+
+// getProgressReportPDF returns a progress report as a PDF file.
+func (server *Server) getProgressReportPDF(ctx *gin.Context) {
+	doc, ok := server.bindProgressReportDocument(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.Header("Content-Disposition", attachmentDisposition(progressReportFilename(doc, "pdf")))
+	ctx.Data(http.StatusOK, "application/pdf", billing.ProgressReportPDF(doc))
+}
+
+// getProgressReportHTML returns a progress report as an HTML page.
+func (server *Server) getProgressReportHTML(ctx *gin.Context) {
+	doc, ok := server.bindProgressReportDocument(ctx)
+	if !ok {
+		return
+	}
+
+	html, err := billing.ProgressReportHTML(doc)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", html)
+}
+
+// progressReportFilename returns the file name of a progress report with the extension ext.
+func progressReportFilename(doc db.ProgressReportDocument, ext string) string {
+	return fmt.Sprintf("progress-report-%d-%s.%s", doc.ProgressReport.StudentID, doc.ProgressReport.PeriodStart.UTC().Format(dateLayout), ext)
+}
+
+type listProgressReportsRequest struct {
+	StudentID int64  `form:"student_id" binding:"omitempty,min=1"`
+	StartDate string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	PageID    int32  `form:"page_id" binding:"required,min=1"`
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listProgressReports returns the progress reports, newest first, optionally of a single student
+// or of the periods starting on a date.
+func (server *Server) listProgressReports(ctx *gin.Context) {
+	var req listProgressReportsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListProgressReportsParams{
+		StudentID: sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0},
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	}
+
+	if req.StartDate != "" {
+		start, err := time.Parse(dateLayout, req.StartDate)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		arg.PeriodStart = sql.NullTime{Time: start, Valid: true}
+	}
+
+	reports, err := server.store.ListProgressReports(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reports)
+}
@@ -0,0 +1,428 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressReportAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createProgressReports": createProgressReportsTestCasesBuilder(),
+		"Test_getProgressReport":     getProgressReportTestCasesBuilder(),
+		"Test_getProgressReportPDF":  getProgressReportPDFTestCasesBuilder(),
+		"Test_getProgressReportHTML": getProgressReportHTMLTestCasesBuilder(),
+		"Test_listProgressReports":   listProgressReportsTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomProgressReport() db.ProgressReport {
+	return db.ProgressReport{
+		ProgressReportID:       util.RandomInt64(1, 1000),
+		StudentID:              util.RandomInt64(1, 1000),
+		PeriodStart:            time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:              time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		LessonsCount:           4,
+		AttendedCount:          3,
+		Hours:                  4.5,
+		HomeworkAssignedCount:  3,
+		HomeworkCompletedCount: 2,
+		CreatedAt:              time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func randomProgressReportDocument() db.ProgressReportDocument {
+	report := randomProgressReport()
+	student := randomStudent()
+	student.StudentID = report.StudentID
+
+	subject := db.GetProgressReportSubjectsRow{
+		SubjectID:     util.RandomInt64(1, 1000),
+		Name:          util.RandomName(),
+		LessonsCount:  report.LessonsCount,
+		AttendedCount: report.AttendedCount,
+		Hours:         report.Hours,
+	}
+
+	return db.ProgressReportDocument{
+		ProgressReport: report,
+		Student:        student,
+		Subjects:       []db.GetProgressReportSubjectsRow{subject},
+		Lessons: []db.GetProgressReportLessonsRow{{
+			LessonID:         util.RandomInt64(1, 1000),
+			LessonDatetime:   report.PeriodStart.Add(48 * time.Hour),
+			Duration:         90,
+			SubjectID:        subject.SubjectID,
+			SubjectName:      subject.Name,
+			AttendanceStatus: db.AttendanceStatusAttended,
+			Topics:           sql.NullString{String: util.RandomNote(), Valid: true},
+			Homework:         sql.NullString{String: util.RandomNote(), Valid: true},
+			HomeworkStatus:   sql.NullString{String: db.HomeworkStatusCompleted, Valid: true},
+			Notes:            sql.NullString{String: util.RandomNote(), Valid: true},
+		}},
+	}
+}
+
+// createProgressReportsTestCasesBuilder creates a slice of test cases for the createProgressReports API
+func createProgressReportsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	reports := []db.ProgressReport{randomProgressReport(), randomProgressReport()}
+	start := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	methodName := "CreateProgressReportsTx"
+	url := "/progress_reports"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       createProgressReportsRequest{StartDate: "2024-03-01", EndDate: "2024-03-31"},
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, start, end, sql.NullInt64{}).
+				Return(reports, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, reports)
+		},
+	})
+
+	// create a test case for StatusOK response of a single student
+	studentID := sql.NullInt64{Int64: reports[0].StudentID, Valid: true}
+	testCases = append(testCases, testCase{
+		name:       "OK Student",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       createProgressReportsRequest{StartDate: "2024-03-01", EndDate: "2024-03-31", StudentID: studentID.Int64},
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, start, end, studentID).
+				Return(reports[:1], nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       createProgressReportsRequest{StartDate: "2024-03-01", EndDate: "2024-03-31"},
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create test cases for Bad Request responses
+	invalidBodies := map[string]createProgressReportsRequest{
+		"Missing End Date": {StartDate: "2024-03-01"},
+		"Invalid Date":     {StartDate: "2024-03-01", EndDate: "March"},
+		"Invalid Period":   {StartDate: "2024-03-31", EndDate: "2024-03-01"},
+	}
+
+	for name, body := range invalidBodies {
+		testCases = append(testCases, testCase{
+			name:       name,
+			httpMethod: http.MethodPost,
+			url:        url,
+			body:       body,
+			buildStub: func(mockStore *mocks.MockStore) {
+				mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Times(0)
+			},
+			checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+				mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Unset()
+			},
+		})
+	}
+
+	return testCases
+}
+
+// getProgressReportTestCasesBuilder creates a slice of test cases for the getProgressReport API
+func getProgressReportTestCasesBuilder() testCases {
+	var testCases testCases
+
+	doc := randomProgressReportDocument()
+	id := doc.ProgressReport.ProgressReportID
+	methodName := "GetProgressReportDocumentTx"
+	url := fmt.Sprintf("/progress_reports/%d", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(doc, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, doc)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.ProgressReportDocument{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.ProgressReportDocument{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/progress_reports/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getProgressReportPDFTestCasesBuilder creates a slice of test cases for the getProgressReportPDF API
+func getProgressReportPDFTestCasesBuilder() testCases {
+	var testCases testCases
+
+	doc := randomProgressReportDocument()
+	id := doc.ProgressReport.ProgressReportID
+	methodName := "GetProgressReportDocumentTx"
+	url := fmt.Sprintf("/progress_reports/%d/pdf", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(doc, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+
+			filename := fmt.Sprintf("progress-report-%d-2024-03-01.pdf", doc.ProgressReport.StudentID)
+			assert.Equal(t, "attachment; filename="+filename, recorder.Header().Get("Content-Disposition"))
+
+			require.True(t, bytes.HasPrefix(recorder.Body.Bytes(), []byte("%PDF-")))
+			require.Contains(t, recorder.Body.String(), doc.Student.FirstName)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.ProgressReportDocument{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// getProgressReportHTMLTestCasesBuilder creates a slice of test cases for the getProgressReportHTML API
+func getProgressReportHTMLTestCasesBuilder() testCases {
+	var testCases testCases
+
+	doc := randomProgressReportDocument()
+	id := doc.ProgressReport.ProgressReportID
+	methodName := "GetProgressReportDocumentTx"
+	url := fmt.Sprintf("/progress_reports/%d/html", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(doc, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+			require.Contains(t, recorder.Body.String(), "<!DOCTYPE html>")
+			require.Contains(t, recorder.Body.String(), doc.Subjects[0].Name)
+			require.Contains(t, recorder.Body.String(), doc.Lessons[0].Topics.String)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.ProgressReportDocument{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// listProgressReportsTestCasesBuilder creates a slice of test cases for the listProgressReports API
+func listProgressReportsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	reports := []db.ProgressReport{randomProgressReport(), randomProgressReport()}
+
+	arg := db.ListProgressReportsParams{
+		Limit:  5,
+		Offset: 5,
+	}
+
+	filterArg := db.ListProgressReportsParams{
+		StudentID:   sql.NullInt64{Int64: reports[0].StudentID, Valid: true},
+		PeriodStart: sql.NullTime{Time: reports[0].PeriodStart, Valid: true},
+		Limit:       5,
+		Offset:      0,
+	}
+
+	methodName := "ListProgressReports"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/progress_reports?page_id=2&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(reports, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, reports)
+		},
+	})
+
+	// create a test case for StatusOK response of a student and period
+	testCases = append(testCases, testCase{
+		name:       "OK Student Period",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/progress_reports?student_id=%d&start_date=2024-03-01&page_id=1&page_size=5", reports[0].StudentID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, filterArg).
+				Return(reports[:1], nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Date response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Date",
+		httpMethod: http.MethodGet,
+		url:        "/progress_reports?start_date=march&page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
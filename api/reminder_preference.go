@@ -0,0 +1,79 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type getReminderPreferenceRequest struct {
+	StudentID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getReminderPreference returns the reminder preference of a student.
+// Students without a reminder preference get the default one.
+func (server *Server) getReminderPreference(ctx *gin.Context) {
+	var req getReminderPreferenceRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	preference, err := server.store.GetReminderPreference(ctx, req.StudentID)
+	if err == sql.ErrNoRows {
+		student, err := server.store.GetStudent(ctx, req.StudentID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		preference = db.ReminderPreference{
+			StudentID:   student.StudentID,
+			Channel:     db.ReminderChannelEmail,
+			LeadMinutes: db.DefaultReminderLeadMinutes,
+			UpdatedAt:   student.CreatedAt,
+		}
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preference)
+}
+
+type updateReminderPreferenceRequest struct {
+	StudentID   int64  `json:"student_id" binding:"required,min=1"`
+	Channel     string `json:"channel" binding:"required,oneof=none email sms webhook"`
+	LeadMinutes int32  `json:"lead_minutes" binding:"required,min=1,max=10080"`
+}
+
+func (server *Server) updateReminderPreference(ctx *gin.Context) {
+	var req updateReminderPreferenceRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpsertReminderPreferenceParams{
+		StudentID:   req.StudentID,
+		Channel:     req.Channel,
+		LeadMinutes: req.LeadMinutes,
+	}
+
+	_, err := server.store.UpsertReminderPreference(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Reminder preference updated successfully"))
+}
@@ -0,0 +1,247 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReminderPreferenceAPIs(t *testing.T) {
+	tests := tests{
+		"Test_getReminderPreference":    getReminderPreferenceTestCasesBuilder(),
+		"Test_updateReminderPreference": updateReminderPreferenceTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomReminderPreference() db.ReminderPreference {
+	channels := []string{db.ReminderChannelNone, db.ReminderChannelEmail, db.ReminderChannelSMS, db.ReminderChannelWebhook}
+
+	return db.ReminderPreference{
+		StudentID:   util.RandomInt64(1, 1000),
+		Channel:     channels[util.RandomInt64(0, int64(len(channels)-1))],
+		LeadMinutes: int32(util.RandomInt64(1, db.MaxReminderLeadMinutes)),
+		UpdatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// getReminderPreferenceTestCasesBuilder creates a slice of test cases for the getReminderPreference API
+func getReminderPreferenceTestCasesBuilder() testCases {
+	var testCases testCases
+
+	preference := randomReminderPreference()
+	id := preference.StudentID
+	methodName := "GetReminderPreference"
+	url := fmt.Sprintf("/reminder_preferences/%d", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(preference, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, preference)
+		},
+	})
+
+	// create a test case for StatusOK response with the default preference of a student without a preference
+	student := randomStudent()
+	student.StudentID = id
+	student.CreatedAt = time.Now().UTC().Truncate(time.Second)
+
+	testCases = append(testCases, testCase{
+		name:       "Default Preference",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.ReminderPreference{}, sql.ErrNoRows).
+				Once()
+
+			mockStore.On("GetStudent", mock.Anything, id).
+				Return(student, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, db.ReminderPreference{
+				StudentID:   id,
+				Channel:     db.ReminderChannelEmail,
+				LeadMinutes: db.DefaultReminderLeadMinutes,
+				UpdatedAt:   student.CreatedAt,
+			})
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.ReminderPreference{}, sql.ErrNoRows).
+				Once()
+
+			mockStore.On("GetStudent", mock.Anything, id).
+				Return(db.Student{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.ReminderPreference{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/reminder_preferences/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// updateReminderPreferenceTestCasesBuilder creates a slice of test cases for the updateReminderPreference API
+func updateReminderPreferenceTestCasesBuilder() testCases {
+	var testCases testCases
+
+	preference := randomReminderPreference()
+	arg := db.UpsertReminderPreferenceParams{
+		StudentID:   preference.StudentID,
+		Channel:     preference.Channel,
+		LeadMinutes: preference.LeadMinutes,
+	}
+
+	methodName := "UpsertReminderPreference"
+	url := "/reminder_preferences"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       arg,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(preference, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       arg,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.ReminderPreference{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Channel response by passing an unknown channel
+	invalidArg := arg
+	invalidArg.Channel = "pigeon"
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Channel",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       invalidArg,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Invalid Lead Minutes response by passing more than a week
+	invalidArg = arg
+	invalidArg.LeadMinutes = db.MaxReminderLeadMinutes + 1
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Lead Minutes",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       invalidArg,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
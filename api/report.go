@@ -0,0 +1,300 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// defaultReportPeriod is the grouping period used when a report request doesn't state one.
+const defaultReportPeriod = "month"
+
+// reportRequest contains the query parameters shared by all reports.
+// StartDate and EndDate are both inclusive.
+// Period is the time unit used to group the report rows (day, week, month, quarter or year).
+type reportRequest struct {
+	StartDate time.Time `form:"start_date" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	EndDate   time.Time `form:"end_date" binding:"required,gtefield=StartDate" time_format:"2006-01-02" time_utc:"1"`
+	Period    string    `form:"period" binding:"omitempty,oneof=day week month quarter year"`
+}
+
+// bindReportRequest binds and validates the report query parameters.
+// In case of an error a Bad Request response is sent and ok is false.
+func bindReportRequest(ctx *gin.Context) (req reportRequest, ok bool) {
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return req, false
+	}
+
+	if req.Period == "" {
+		req.Period = defaultReportPeriod
+	}
+
+	return req, true
+}
+
+// endDatetime returns the exclusive upper bound of the report date range.
+func (req reportRequest) endDatetime() time.Time {
+	return req.EndDate.AddDate(0, 0, 1)
+}
+
+func (server *Server) getRevenueReport(ctx *gin.Context) {
+	req, ok := bindReportRequest(ctx)
+	if !ok {
+		return
+	}
+
+	arg := db.GetRevenueReportParams{
+		Period:        req.Period,
+		StartDatetime: req.StartDate,
+		EndDatetime:   req.endDatetime(),
+	}
+
+	report, err := server.store.GetRevenueReport(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func (server *Server) getSubjectHoursReport(ctx *gin.Context) {
+	req, ok := bindReportRequest(ctx)
+	if !ok {
+		return
+	}
+
+	arg := db.GetSubjectHoursReportParams{
+		Period:        req.Period,
+		StartDatetime: req.StartDate,
+		EndDatetime:   req.endDatetime(),
+	}
+
+	report, err := server.store.GetSubjectHoursReport(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// getExamOutcomeReport returns the number of exams, graded exams and exams meeting their target grade,
+// and the average target and achieved grades, of each subject.
+func (server *Server) getExamOutcomeReport(ctx *gin.Context) {
+	req, ok := bindReportRequest(ctx)
+	if !ok {
+		return
+	}
+
+	arg := db.GetExamOutcomeReportParams{
+		Period:        req.Period,
+		StartDatetime: req.StartDate,
+		EndDatetime:   req.endDatetime(),
+	}
+
+	report, err := server.store.GetExamOutcomeReport(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func (server *Server) getLocationHoursReport(ctx *gin.Context) {
+	req, ok := bindReportRequest(ctx)
+	if !ok {
+		return
+	}
+
+	arg := db.GetLocationHoursReportParams{
+		Period:        req.Period,
+		StartDatetime: req.StartDate,
+		EndDatetime:   req.endDatetime(),
+	}
+
+	report, err := server.store.GetLocationHoursReport(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func (server *Server) getCollegeRevenueReport(ctx *gin.Context) {
+	req, ok := bindReportRequest(ctx)
+	if !ok {
+		return
+	}
+
+	arg := db.GetCollegeRevenueReportParams{
+		Period:        req.Period,
+		StartDatetime: req.StartDate,
+		EndDatetime:   req.endDatetime(),
+	}
+
+	report, err := server.store.GetCollegeRevenueReport(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func (server *Server) getFunnelRevenueReport(ctx *gin.Context) {
+	req, ok := bindReportRequest(ctx)
+	if !ok {
+		return
+	}
+
+	arg := db.GetFunnelRevenueReportParams{
+		Period:        req.Period,
+		StartDatetime: req.StartDate,
+		EndDatetime:   req.endDatetime(),
+	}
+
+	report, err := server.store.GetFunnelRevenueReport(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// defaultRetentionDays is the number of days used to decide whether a student is retained,
+// when a funnel cohort report request doesn't state it.
+const defaultRetentionDays = 30
+
+// funnelCohortReportRequest contains the query parameters of the funnel cohort report.
+// Students are grouped into cohorts by the Period their record was created in.
+// A student is retained if they had a lesson in the last RetentionDays days.
+type funnelCohortReportRequest struct {
+	StartDate     time.Time `form:"start_date" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	EndDate       time.Time `form:"end_date" binding:"required,gtefield=StartDate" time_format:"2006-01-02" time_utc:"1"`
+	Period        string    `form:"period" binding:"omitempty,oneof=day week month quarter year"`
+	RetentionDays int       `form:"retention_days" binding:"omitempty,min=1,max=365"`
+}
+
+// funnelCohortResponse is a single row of the funnel cohort report.
+// ReturnOnInvestment is the cohort's lifetime revenue less its acquisition cost, relative to the acquisition cost.
+// It is null if no acquisition cost was recorded for the funnel in the cohort's period.
+type funnelCohortResponse struct {
+	db.GetFunnelCohortReportRow
+	ReturnOnInvestment sql.NullFloat64 `json:"return_on_investment"`
+}
+
+// newFunnelCohortResponse creates a funnelCohortResponse from a funnel cohort report row.
+func newFunnelCohortResponse(row db.GetFunnelCohortReportRow) funnelCohortResponse {
+	rsp := funnelCohortResponse{GetFunnelCohortReportRow: row}
+
+	if row.AcquisitionCost.Valid && row.AcquisitionCost.Float64 > 0 {
+		rsp.ReturnOnInvestment = sql.NullFloat64{
+			Float64: (row.LifetimeRevenue - row.AcquisitionCost.Float64) / row.AcquisitionCost.Float64,
+			Valid:   true,
+		}
+	}
+
+	return rsp
+}
+
+func (server *Server) getFunnelCohortReport(ctx *gin.Context) {
+	var req funnelCohortReportRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Period == "" {
+		req.Period = defaultReportPeriod
+	}
+
+	if req.RetentionDays == 0 {
+		req.RetentionDays = defaultRetentionDays
+	}
+
+	arg := db.GetFunnelCohortReportParams{
+		Period:        req.Period,
+		StartDatetime: req.StartDate,
+		EndDatetime:   req.EndDate.AddDate(0, 0, 1),
+		RetainedSince: time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -req.RetentionDays),
+	}
+
+	report, err := server.store.GetFunnelCohortReport(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]funnelCohortResponse, len(report))
+	for i, row := range report {
+		rsp[i] = newFunnelCohortResponse(row)
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// lifecycleReportRequest contains the query parameters of the student lifecycle report.
+// The thresholds default to db.DefaultLifecycleThresholds.
+// Status filters the report to students whose computed lifecycle status matches it.
+type lifecycleReportRequest struct {
+	AtRiskWeeks   int    `form:"at_risk_weeks" binding:"omitempty,min=1,max=52"`
+	LapsedWeeks   int    `form:"lapsed_weeks" binding:"omitempty,min=1,max=104"`
+	RecentWeeks   int    `form:"recent_weeks" binding:"omitempty,min=1,max=52"`
+	BaselineWeeks int    `form:"baseline_weeks" binding:"omitempty,min=1,max=104"`
+	Status        string `form:"status" binding:"omitempty,oneof=active at-risk lapsed"`
+}
+
+// thresholds returns the lifecycle thresholds of the request, using the defaults for any threshold not stated.
+func (req lifecycleReportRequest) thresholds() db.LifecycleThresholds {
+	thresholds := db.DefaultLifecycleThresholds
+
+	if req.AtRiskWeeks != 0 {
+		thresholds.AtRiskWeeks = req.AtRiskWeeks
+	}
+	if req.LapsedWeeks != 0 {
+		thresholds.LapsedWeeks = req.LapsedWeeks
+	}
+	if req.RecentWeeks != 0 {
+		thresholds.RecentWeeks = req.RecentWeeks
+	}
+	if req.BaselineWeeks != 0 {
+		thresholds.BaselineWeeks = req.BaselineWeeks
+	}
+
+	return thresholds
+}
+
+func (server *Server) getLifecycleReport(ctx *gin.Context) {
+	var req lifecycleReportRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := req.thresholds().Params(time.Now().UTC())
+
+	report, err := server.store.GetStudentsLifecycle(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]db.GetStudentsLifecycleRow, 0, len(report))
+	for _, row := range report {
+		if req.Status == "" || row.ComputedStatus == req.Status {
+			rsp = append(rsp, row)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
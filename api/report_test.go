@@ -0,0 +1,524 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// reportStartDate and reportEndDate are the date range used for the reports test cases.
+var (
+	reportStartDate = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	reportEndDate   = time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+)
+
+func TestReportAPIs(t *testing.T) {
+	tests := tests{
+		"Test_getRevenueReport":        getRevenueReportTestCasesBuilder(),
+		"Test_getSubjectHoursReport":   getSubjectHoursReportTestCasesBuilder(),
+		"Test_getLocationHoursReport":  getLocationHoursReportTestCasesBuilder(),
+		"Test_getExamOutcomeReport":    getExamOutcomeReportTestCasesBuilder(),
+		"Test_getCollegeRevenueReport": getCollegeRevenueReportTestCasesBuilder(),
+		"Test_getFunnelRevenueReport":  getFunnelRevenueReportTestCasesBuilder(),
+		"Test_getFunnelCohortReport":   getFunnelCohortReportTestCasesBuilder(),
+		"Test_getLifecycleReport":      getLifecycleReportTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+// reportURL returns the url of a report with the date range query parameters.
+func reportURL(path string, startDate, endDate time.Time, period string) string {
+	url := fmt.Sprintf("%s?start_date=%s&end_date=%s", path, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	if period != "" {
+		url = fmt.Sprintf("%s&period=%s", url, period)
+	}
+	return url
+}
+
+// reportTestCasesBuilder creates a slice of test cases for a report API.
+// arg is the expected input of the store method, and report and emptyReport are its returned values.
+func reportTestCasesBuilder(methodName, path string, arg, report, emptyReport interface{}) testCases {
+	var testCases testCases
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        reportURL(path, reportStartDate, reportEndDate, "month"),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(report, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, report)
+		},
+	})
+
+	// create a test case for StatusOK response using the default period
+	testCases = append(testCases, testCase{
+		name:       "Default Period",
+		httpMethod: http.MethodGet,
+		url:        reportURL(path, reportStartDate, reportEndDate, ""),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(report, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, report)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        reportURL(path, reportStartDate, reportEndDate, "month"),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(emptyReport, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Period response by passing url with period=decade
+	testCases = append(testCases, testCase{
+		name:       "Invalid Period Parameter",
+		httpMethod: http.MethodGet,
+		url:        reportURL(path, reportStartDate, reportEndDate, "decade"),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Invalid Date Range response by passing end_date before start_date
+	testCases = append(testCases, testCase{
+		name:       "Invalid Date Range",
+		httpMethod: http.MethodGet,
+		url:        reportURL(path, reportEndDate, reportStartDate, "month"),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Missing Date response by passing no query parameters
+	testCases = append(testCases, testCase{
+		name:       "Missing Date Parameters",
+		httpMethod: http.MethodGet,
+		url:        path,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getRevenueReportTestCasesBuilder creates a slice of test cases for the getRevenueReport API
+func getRevenueReportTestCasesBuilder() testCases {
+	report := []db.GetRevenueReportRow{
+		{
+			PeriodStart:     reportStartDate,
+			InvoicedAmount:  util.RandomInvoiceAmount(),
+			CollectedAmount: util.RandomPaymentAmount(),
+		},
+		{
+			PeriodStart:     reportStartDate.AddDate(0, 1, 0),
+			InvoicedAmount:  util.RandomInvoiceAmount(),
+			CollectedAmount: util.RandomPaymentAmount(),
+		},
+	}
+
+	arg := db.GetRevenueReportParams{
+		Period:        "month",
+		StartDatetime: reportStartDate,
+		EndDatetime:   reportEndDate.AddDate(0, 0, 1),
+	}
+
+	return reportTestCasesBuilder("GetRevenueReport", "/reports/revenue", arg, report, []db.GetRevenueReportRow{})
+}
+
+// getSubjectHoursReportTestCasesBuilder creates a slice of test cases for the getSubjectHoursReport API
+func getSubjectHoursReportTestCasesBuilder() testCases {
+	subject := randomLessonSubject()
+	report := []db.GetSubjectHoursReportRow{
+		{
+			PeriodStart:  reportStartDate,
+			SubjectID:    subject.SubjectID,
+			Name:         subject.Name,
+			LessonsCount: util.RandomInt64(1, 20),
+			Hours:        util.RandomFloat64(1, 40),
+		},
+	}
+
+	arg := db.GetSubjectHoursReportParams{
+		Period:        "month",
+		StartDatetime: reportStartDate,
+		EndDatetime:   reportEndDate.AddDate(0, 0, 1),
+	}
+
+	return reportTestCasesBuilder("GetSubjectHoursReport", "/reports/hours/subjects", arg, report, []db.GetSubjectHoursReportRow{})
+}
+
+// getLocationHoursReportTestCasesBuilder creates a slice of test cases for the getLocationHoursReport API
+func getLocationHoursReportTestCasesBuilder() testCases {
+	location := randomLessonLocation()
+	report := []db.GetLocationHoursReportRow{
+		{
+			PeriodStart:  reportStartDate,
+			LocationID:   location.LocationID,
+			Name:         location.Name,
+			LessonsCount: util.RandomInt64(1, 20),
+			Hours:        util.RandomFloat64(1, 40),
+		},
+	}
+
+	arg := db.GetLocationHoursReportParams{
+		Period:        "month",
+		StartDatetime: reportStartDate,
+		EndDatetime:   reportEndDate.AddDate(0, 0, 1),
+	}
+
+	return reportTestCasesBuilder("GetLocationHoursReport", "/reports/hours/locations", arg, report, []db.GetLocationHoursReportRow{})
+}
+
+// getExamOutcomeReportTestCasesBuilder creates a slice of test cases for the getExamOutcomeReport API
+func getExamOutcomeReportTestCasesBuilder() testCases {
+	subject := randomLessonSubject()
+	report := []db.GetExamOutcomeReportRow{
+		{
+			PeriodStart:            reportStartDate,
+			SubjectID:              subject.SubjectID,
+			Name:                   subject.Name,
+			ExamsCount:             4,
+			GradedCount:            3,
+			TargetMetCount:         2,
+			AverageTargetGrade:     util.RandomFloat64(60, 100),
+			AverageAchievedGrade:   util.RandomFloat64(60, 100),
+			AverageGradeDifference: util.RandomFloat64(-10, 10),
+		},
+	}
+
+	arg := db.GetExamOutcomeReportParams{
+		Period:        "month",
+		StartDatetime: reportStartDate,
+		EndDatetime:   reportEndDate.AddDate(0, 0, 1),
+	}
+
+	return reportTestCasesBuilder("GetExamOutcomeReport", "/reports/exams/subjects", arg, report, []db.GetExamOutcomeReportRow{})
+}
+
+// getCollegeRevenueReportTestCasesBuilder creates a slice of test cases for the getCollegeRevenueReport API
+func getCollegeRevenueReportTestCasesBuilder() testCases {
+	college := randomCollege()
+	report := []db.GetCollegeRevenueReportRow{
+		{
+			PeriodStart:    reportStartDate,
+			CollegeID:      sql.NullInt64{Int64: college.CollegeID, Valid: true},
+			Name:           sql.NullString{String: college.Name, Valid: true},
+			StudentsCount:  util.RandomInt64(1, 20),
+			InvoicedAmount: util.RandomInvoiceAmount(),
+		},
+		{
+			PeriodStart:    reportStartDate,
+			CollegeID:      sql.NullInt64{},
+			Name:           sql.NullString{},
+			StudentsCount:  util.RandomInt64(1, 20),
+			InvoicedAmount: util.RandomInvoiceAmount(),
+		},
+	}
+
+	arg := db.GetCollegeRevenueReportParams{
+		Period:        "month",
+		StartDatetime: reportStartDate,
+		EndDatetime:   reportEndDate.AddDate(0, 0, 1),
+	}
+
+	return reportTestCasesBuilder("GetCollegeRevenueReport", "/reports/revenue/colleges", arg, report, []db.GetCollegeRevenueReportRow{})
+}
+
+// getFunnelRevenueReportTestCasesBuilder creates a slice of test cases for the getFunnelRevenueReport API
+func getFunnelRevenueReportTestCasesBuilder() testCases {
+	funnel := randomFunnel()
+	report := []db.GetFunnelRevenueReportRow{
+		{
+			PeriodStart:    reportStartDate,
+			FunnelID:       sql.NullInt64{Int64: funnel.FunnelID, Valid: true},
+			Name:           sql.NullString{String: funnel.Name, Valid: true},
+			StudentsCount:  util.RandomInt64(1, 20),
+			InvoicedAmount: util.RandomInvoiceAmount(),
+		},
+	}
+
+	arg := db.GetFunnelRevenueReportParams{
+		Period:        "month",
+		StartDatetime: reportStartDate,
+		EndDatetime:   reportEndDate.AddDate(0, 0, 1),
+	}
+
+	return reportTestCasesBuilder("GetFunnelRevenueReport", "/reports/revenue/funnels", arg, report, []db.GetFunnelRevenueReportRow{})
+}
+
+// getFunnelCohortReportTestCasesBuilder creates a slice of test cases for the getFunnelCohortReport API
+func getFunnelCohortReportTestCasesBuilder() testCases {
+	var testCases testCases
+
+	funnel := randomFunnel()
+	report := []db.GetFunnelCohortReportRow{
+		{
+			CohortStart:          reportStartDate,
+			FunnelID:             sql.NullInt64{Int64: funnel.FunnelID, Valid: true},
+			Name:                 sql.NullString{String: funnel.Name, Valid: true},
+			StudentsCount:        10,
+			ConvertedCount:       8,
+			AvgDaysToFirstLesson: sql.NullFloat64{Float64: util.RandomFloat64(1, 30), Valid: true},
+			RetainedCount:        5,
+			LifetimeRevenue:      3000.00,
+			AcquisitionCost:      sql.NullFloat64{Float64: 1000.00, Valid: true},
+		},
+		{
+			CohortStart:     reportStartDate.AddDate(0, 1, 0),
+			StudentsCount:   2,
+			LifetimeRevenue: 0,
+		},
+	}
+
+	rsp := []funnelCohortResponse{
+		{GetFunnelCohortReportRow: report[0], ReturnOnInvestment: sql.NullFloat64{Float64: 2.0, Valid: true}},
+		{GetFunnelCohortReportRow: report[1]},
+	}
+
+	// matchArg checks the arguments passed to GetFunnelCohortReport, expecting retention of retentionDays.
+	matchArg := func(retentionDays int) interface{} {
+		return mock.MatchedBy(func(arg db.GetFunnelCohortReportParams) bool {
+			retainedSince := time.Now().UTC().AddDate(0, 0, -retentionDays)
+			return arg.Period == "month" &&
+				arg.StartDatetime.Equal(reportStartDate) &&
+				arg.EndDatetime.Equal(reportEndDate.AddDate(0, 0, 1)) &&
+				retainedSince.Sub(arg.RetainedSince) < 24*time.Hour
+		})
+	}
+
+	methodName := "GetFunnelCohortReport"
+	path := "/reports/funnels/cohorts"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        reportURL(path, reportStartDate, reportEndDate, "month") + "&retention_days=60",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, matchArg(60)).
+				Return(report, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, rsp)
+		},
+	})
+
+	// create a test case for StatusOK response using the default period and retention days
+	testCases = append(testCases, testCase{
+		name:       "Default Parameters",
+		httpMethod: http.MethodGet,
+		url:        reportURL(path, reportStartDate, reportEndDate, ""),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, matchArg(defaultRetentionDays)).
+				Return(report, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, rsp)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        reportURL(path, reportStartDate, reportEndDate, "month"),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return([]db.GetFunnelCohortReportRow{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Retention Days response by passing url with retention_days=1000
+	testCases = append(testCases, testCase{
+		name:       "Invalid Retention_Days Parameter",
+		httpMethod: http.MethodGet,
+		url:        reportURL(path, reportStartDate, reportEndDate, "month") + "&retention_days=1000",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getLifecycleReportTestCasesBuilder creates a slice of test cases for the getLifecycleReport API
+func getLifecycleReportTestCasesBuilder() testCases {
+	var testCases testCases
+
+	statuses := []string{db.LifecycleStatusActive, db.LifecycleStatusAtRisk, db.LifecycleStatusLapsed}
+	report := make([]db.GetStudentsLifecycleRow, len(statuses))
+	for i, status := range statuses {
+		student := randomStudent()
+		report[i] = db.GetStudentsLifecycleRow{
+			StudentID:            student.StudentID,
+			FirstName:            student.FirstName,
+			LastName:             student.LastName,
+			LifecycleStatus:      student.LifecycleStatus,
+			LastLessonDatetime:   sql.NullTime{Time: util.RandomDatetime(), Valid: true},
+			RecentLessonsCount:   util.RandomInt64(0, 4),
+			BaselineLessonsCount: util.RandomInt64(0, 12),
+			ComputedStatus:       status,
+		}
+	}
+
+	// matchArg checks the arguments passed to GetStudentsLifecycle, expecting the thresholds to be used.
+	matchArg := func(thresholds db.LifecycleThresholds) interface{} {
+		return mock.MatchedBy(func(arg db.GetStudentsLifecycleParams) bool {
+			return time.Since(arg.AsOf) < time.Minute &&
+				arg == thresholds.Params(arg.AsOf)
+		})
+	}
+
+	methodName := "GetStudentsLifecycle"
+	path := "/reports/lifecycle"
+
+	// create a test case for StatusOK response using the default thresholds
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        path,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, matchArg(db.DefaultLifecycleThresholds)).
+				Return(report, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, report)
+		},
+	})
+
+	// create a test case for StatusOK response filtered by status with custom thresholds
+	thresholds := db.LifecycleThresholds{AtRiskWeeks: 2, LapsedWeeks: 6, RecentWeeks: 3, BaselineWeeks: 9}
+
+	testCases = append(testCases, testCase{
+		name:       "Status Filter",
+		httpMethod: http.MethodGet,
+		url: fmt.Sprintf("%s?status=%s&at_risk_weeks=%d&lapsed_weeks=%d&recent_weeks=%d&baseline_weeks=%d",
+			path, db.LifecycleStatusAtRisk, thresholds.AtRiskWeeks, thresholds.LapsedWeeks, thresholds.RecentWeeks, thresholds.BaselineWeeks),
+		body: nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, matchArg(thresholds)).
+				Return(report, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, report[1:2])
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        path,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return([]db.GetStudentsLifecycleRow{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Status response by passing url with status=unknown
+	testCases = append(testCases, testCase{
+		name:       "Invalid Status Parameter",
+		httpMethod: http.MethodGet,
+		url:        path + "?status=unknown",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
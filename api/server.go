@@ -1,23 +1,50 @@
 package api
 
 import (
+	"context"
+	"mime"
+
 	"github.com/gin-gonic/gin"
 	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/jobs"
+	"github.com/github-real-lb/tutor-management-web/storage"
 )
 
+// JobScheduler runs the background jobs of the service.
+type JobScheduler interface {
+	Jobs() []jobs.JobInfo
+	Trigger(ctx context.Context, name string) (db.JobRun, error)
+}
+
+// AttachmentLimits limits the files uploaded as attachments.
+type AttachmentLimits struct {
+	// MaxSize is the maximum size of a file in bytes. Files of any size are accepted if it is zero.
+	MaxSize int64
+	// ContentTypes are the MIME types of the files that are accepted. Files of any type are accepted if it is empty.
+	ContentTypes []string
+}
+
 // Server serves all HTTP requests for the Tutor Management service.
 type Server struct {
-	store  db.Store
-	router *gin.Engine
+	store            db.Store
+	scheduler        JobScheduler
+	blobs            storage.BlobStore
+	attachmentLimits AttachmentLimits
+	router           *gin.Engine
+	dashboard        dashboardCache
 }
 
 // NewServer creates a new HTTP server and setup routing.
-func NewServer(store db.Store) *Server {
+// The files of the attachments are stored in blobs.
+func NewServer(store db.Store, scheduler JobScheduler, blobs storage.BlobStore, limits AttachmentLimits) *Server {
 	// creating the server type with a gin router
 	router := gin.Default()
 	server := &Server{
-		store:  store,
-		router: router}
+		store:            store,
+		scheduler:        scheduler,
+		blobs:            blobs,
+		attachmentLimits: limits,
+		router:           router}
 
 	// adding the colleges HTTP handlers to the router
 	router.POST("/colleges", server.createCollege)
@@ -31,6 +58,12 @@ func NewServer(store db.Store) *Server {
 	router.GET("/funnels", server.listFunnels)
 	router.PUT("/funnels", server.updateFunnel)
 
+	// adding the funnel costs HTTP handlers to the router
+	router.POST("/funnel_costs", server.createFunnelCost)
+	router.GET("/funnel_costs/:id", server.getFunnelCost)
+	router.GET("/funnel_costs", server.listFunnelCosts)
+	router.PUT("/funnel_costs", server.updateFunnelCost)
+
 	// adding the lesson locations HTTP handlers to the router
 	router.POST("/lesson_locations", server.createLessonLocation)
 	router.GET("/lesson_locations/:id", server.getLessonLocation)
@@ -53,8 +86,192 @@ func NewServer(store db.Store) *Server {
 	router.POST("/students", server.createStudent)
 	router.GET("/students/:id", server.getStudent)
 	router.GET("/students", server.listStudents)
+	router.GET("/students/export", server.exportStudents)
 	router.PUT("/students", server.updateStudent)
 
+	// adding the tags HTTP handlers to the router
+	router.POST("/tags", server.createTag)
+	router.GET("/tags/:id", server.getTag)
+	router.GET("/tags", server.listTags)
+	router.PUT("/tags", server.updateTag)
+	router.DELETE("/tags/:id", server.deleteTag)
+	router.GET("/students/:id/tags", server.getStudentTags)
+	router.POST("/students/:id/tags", server.addStudentTag)
+	router.DELETE("/students/:id/tags/:tag_id", server.removeStudentTag)
+
+	// adding the custom fields HTTP handlers to the router
+	router.POST("/custom_fields", server.createCustomField)
+	router.GET("/custom_fields/:id", server.getCustomField)
+	router.GET("/custom_fields", server.listCustomFields)
+	router.PUT("/custom_fields", server.updateCustomField)
+	router.DELETE("/custom_fields/:id", server.deleteCustomField)
+	router.GET("/students/:id/custom_fields", server.getStudentCustomValues)
+	router.PUT("/students/:id/custom_fields", server.setStudentCustomValue)
+	router.DELETE("/students/:id/custom_fields/:field_id", server.deleteStudentCustomValue)
+
+	// adding the guardians HTTP handlers to the router
+	router.POST("/guardians", server.createGuardian)
+	router.GET("/guardians/:id", server.getGuardian)
+	router.GET("/guardians", server.listGuardians)
+	router.PUT("/guardians", server.updateGuardian)
+	router.GET("/guardians/:id/students", server.getGuardianStudents)
+	router.GET("/students/:id/guardians", server.getStudentGuardians)
+	router.POST("/students/:id/guardians", server.linkStudentGuardian)
+	router.DELETE("/students/:id/guardians/:guardian_id", server.unlinkStudentGuardian)
+	router.POST("/guardian_receipts", server.createGuardianReceipt)
+	router.GET("/guardian_receipts/:id", server.getGuardianReceipt)
+
+	// adding the lesson packages HTTP handlers to the router
+	router.POST("/package_products", server.createPackageProduct)
+	router.GET("/package_products/:id", server.getPackageProduct)
+	router.GET("/package_products", server.listPackageProducts)
+	router.PUT("/package_products", server.updatePackageProduct)
+	router.POST("/student_packages", server.purchasePackage)
+	router.GET("/student_packages/:id", server.getStudentPackage)
+	router.GET("/student_packages", server.listStudentPackages)
+	router.GET("/package_balances/:id", server.getPackageBalance)
+
+	// adding the fee schedules HTTP handlers to the router
+	router.POST("/fee_schedules", server.createFeeSchedule)
+	router.GET("/fee_schedules/:id", server.getFeeSchedule)
+	router.GET("/fee_schedules", server.listFeeSchedules)
+	router.GET("/hourly_fees", server.resolveHourlyFee)
+
+	// adding the group pricing HTTP handlers to the router
+	router.POST("/group_pricing_plans", server.createGroupPricingPlan)
+	router.GET("/group_pricing_plans/:id", server.getGroupPricingPlan)
+	router.GET("/group_pricing_plans", server.listGroupPricingPlans)
+	router.PUT("/group_pricing_plans", server.updateGroupPricingPlan)
+	router.POST("/group_lessons", server.createGroupLesson)
+
+	// adding the lesson attendance HTTP handlers to the router
+	router.POST("/lessons/:id/students", server.addLessonStudent)
+	router.DELETE("/lessons/:id/students/:student_id", server.removeLessonStudent)
+	router.GET("/lessons/:id/attendance", server.getLessonAttendance)
+	router.PUT("/lessons/:id/attendance", server.updateLessonAttendance)
+
+	// adding the lesson records HTTP handlers to the router
+	router.GET("/lessons/:id/records", server.getLessonRecords)
+	router.PUT("/lessons/:id/records", server.upsertLessonRecord)
+	router.GET("/students/:id/progress", server.getStudentProgress)
+
+	// adding the exams HTTP handlers to the router
+	router.POST("/exams", server.createExam)
+	router.GET("/exams/:id", server.getExam)
+	router.GET("/exams", server.listExams)
+	router.PUT("/exams", server.updateExam)
+	router.DELETE("/exams/:id", server.deleteExam)
+
+	// adding the attachments HTTP handlers to the router
+	router.POST("/attachments", server.createAttachment)
+	router.GET("/attachments/:id", server.getAttachment)
+	router.GET("/attachments/:id/download", server.downloadAttachment)
+	router.GET("/attachments", server.listAttachments)
+	router.DELETE("/attachments/:id", server.deleteAttachment)
+
+	// adding the availability HTTP handlers to the router
+	router.POST("/availability_windows", server.createAvailabilityWindow)
+	router.GET("/availability_windows/:id", server.getAvailabilityWindow)
+	router.GET("/availability_windows", server.listAvailabilityWindows)
+	router.PUT("/availability_windows", server.updateAvailabilityWindow)
+	router.DELETE("/availability_windows/:id", server.deleteAvailabilityWindow)
+	router.POST("/availability_exceptions", server.createAvailabilityException)
+	router.GET("/availability_exceptions/:id", server.getAvailabilityException)
+	router.GET("/availability_exceptions", server.listAvailabilityExceptions)
+	router.DELETE("/availability_exceptions/:id", server.deleteAvailabilityException)
+
+	// adding the public booking HTTP handlers to the router
+	router.GET("/booking/slots", server.listBookingSlots)
+	router.POST("/booking/requests", server.createBookingRequest)
+
+	// adding the booking requests HTTP handlers to the router
+	router.GET("/booking_requests/:id", server.getBookingRequest)
+	router.GET("/booking_requests", server.listBookingRequests)
+	router.POST("/booking_requests/:id/approve", server.approveBookingRequest)
+	router.POST("/booking_requests/:id/decline", server.declineBookingRequest)
+
+	// adding the waitlist HTTP handlers to the router
+	router.POST("/waitlist_entries", server.createWaitlistEntry)
+	router.GET("/waitlist_entries/:id", server.getWaitlistEntry)
+	router.GET("/waitlist_entries", server.listWaitlistEntries)
+	router.PUT("/waitlist_entries", server.updateWaitlistEntry)
+	router.DELETE("/waitlist_entries/:id", server.deleteWaitlistEntry)
+
+	// adding the lead pipeline HTTP handlers to the router
+	router.POST("/pipeline_stages", server.createPipelineStage)
+	router.GET("/pipeline_stages/:id", server.getPipelineStage)
+	router.GET("/pipeline_stages", server.listPipelineStages)
+	router.PUT("/pipeline_stages", server.updatePipelineStage)
+	router.DELETE("/pipeline_stages/:id", server.deletePipelineStage)
+	router.POST("/leads", server.createLead)
+	router.GET("/leads/:id", server.getLead)
+	router.GET("/leads", server.listLeads)
+	router.PUT("/leads", server.updateLead)
+	router.DELETE("/leads/:id", server.deleteLead)
+	router.POST("/leads/:id/convert", server.convertLead)
+
+	// adding the tasks HTTP handlers to the router
+	router.POST("/tasks", server.createTask)
+	router.GET("/tasks/:id", server.getTask)
+	router.GET("/tasks", server.listTasks)
+	router.PUT("/tasks", server.updateTask)
+	router.DELETE("/tasks/:id", server.deleteTask)
+
+	// adding the activities HTTP handlers to the router
+	router.POST("/activities", server.createActivity)
+	router.GET("/activities/:id", server.getActivity)
+	router.GET("/activities", server.listActivities)
+	router.PUT("/activities", server.updateActivity)
+	router.DELETE("/activities/:id", server.deleteActivity)
+	router.GET("/students/:id/timeline", server.getStudentTimeline)
+
+	// adding the reminder preferences HTTP handlers to the router
+	router.GET("/reminder_preferences/:id", server.getReminderPreference)
+	router.PUT("/reminder_preferences", server.updateReminderPreference)
+
+	// adding the webhooks HTTP handlers to the router
+	router.POST("/webhook_subscriptions", server.createWebhookSubscription)
+	router.GET("/webhook_subscriptions/:id", server.getWebhookSubscription)
+	router.GET("/webhook_subscriptions", server.listWebhookSubscriptions)
+	router.PUT("/webhook_subscriptions", server.updateWebhookSubscription)
+	router.GET("/webhook_deliveries/:id", server.getWebhookDelivery)
+	router.GET("/webhook_deliveries", server.listWebhookDeliveries)
+	router.POST("/webhook_deliveries/:id/replay", server.replayWebhookDelivery)
+
+	// adding the statements HTTP handlers to the router
+	router.POST("/statements", server.createStatements)
+	router.GET("/statements/:id", server.getStatement)
+	router.GET("/statements/:id/pdf", server.getStatementPDF)
+	router.GET("/statements", server.listStatements)
+
+	// adding the progress reports HTTP handlers to the router
+	router.POST("/progress_reports", server.createProgressReports)
+	router.GET("/progress_reports/:id", server.getProgressReport)
+	router.GET("/progress_reports/:id/pdf", server.getProgressReportPDF)
+	router.GET("/progress_reports/:id/html", server.getProgressReportHTML)
+	router.GET("/progress_reports", server.listProgressReports)
+
+	// adding the dashboard HTTP handler to the router
+	router.GET("/dashboard", server.getDashboard)
+
+	// adding the reports HTTP handlers to the router
+	router.GET("/reports/revenue", server.getRevenueReport)
+	router.GET("/reports/revenue/colleges", server.getCollegeRevenueReport)
+	router.GET("/reports/revenue/funnels", server.getFunnelRevenueReport)
+	router.GET("/reports/funnels/cohorts", server.getFunnelCohortReport)
+	router.GET("/reports/hours/subjects", server.getSubjectHoursReport)
+	router.GET("/reports/hours/locations", server.getLocationHoursReport)
+	router.GET("/reports/exams/subjects", server.getExamOutcomeReport)
+	router.GET("/reports/aging", server.getAgingReport)
+	router.GET("/reports/aging/:id", server.getStudentAgingReport)
+	router.GET("/reports/lifecycle", server.getLifecycleReport)
+
+	// adding the background jobs admin HTTP handlers to the router
+	router.GET("/admin/jobs", server.listJobs)
+	router.POST("/admin/jobs/:name/run", server.runJob)
+	router.GET("/admin/job_runs/:id", server.getJobRun)
+	router.GET("/admin/job_runs", server.listJobRuns)
+
 	return server
 }
 
@@ -70,3 +287,9 @@ func errorResponse(err error) gin.H {
 func okResponse(s string) gin.H {
 	return gin.H{"message": s}
 }
+
+// attachmentDisposition returns the Content-Disposition header of a file downloaded as filename.
+// Names that aren't plain ASCII are encoded as filename*, as by RFC 6266.
+func attachmentDisposition(filename string) string {
+	return mime.FormatMediaType("attachment", map[string]string{"filename": filename})
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/github-real-lb/tutor-management-web/billing"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// monthLayout is the layout of the statement months in requests, such as 2024-03.
+const monthLayout = "2006-01"
+
+type createStatementsRequest struct {
+	Month     string `json:"month" binding:"required,datetime=2006-01"`
+	StudentID int64  `json:"student_id" binding:"omitempty,min=1"`
+}
+
+// createStatements runs the billing of a month, for all students or for a single student.
+// Students that already have a statement of the month are skipped, and only the created statements are returned.
+func (server *Server) createStatements(ctx *gin.Context) {
+	var req createStatementsRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	month, err := time.Parse(monthLayout, req.Month)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	studentID := sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0}
+
+	statements, err := server.store.CreateMonthlyStatementsTx(ctx, month, studentID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, statements)
+}
+
+type getStatementRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getStatement returns a statement with its student, invoices and receipts.
+func (server *Server) getStatement(ctx *gin.Context) {
+	var req getStatementRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	doc, err := server.store.GetStatementDocumentTx(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, doc)
+}
+
+// getStatementPDF returns a statement as a PDF file.
+func (server *Server) getStatementPDF(ctx *gin.Context) {
+	var req getStatementRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	doc, err := server.store.GetStatementDocumentTx(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	filename := fmt.Sprintf("statement-%d-%s.pdf", doc.Statement.StudentID, doc.Statement.PeriodStart.UTC().Format(monthLayout))
+	ctx.Header("Content-Disposition", attachmentDisposition(filename))
+	ctx.Data(http.StatusOK, "application/pdf", billing.StatementPDF(doc))
+}
+
+type listStatementsRequest struct {
+	StudentID int64  `form:"student_id" binding:"omitempty,min=1"`
+	Month     string `form:"month" binding:"omitempty,datetime=2006-01"`
+	PageID    int32  `form:"page_id" binding:"required,min=1"`
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listStatements returns the statements, newest first, optionally of a single student or month.
+func (server *Server) listStatements(ctx *gin.Context) {
+	var req listStatementsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListStatementsParams{
+		StudentID: sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0},
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	}
+
+	if req.Month != "" {
+		month, err := time.Parse(monthLayout, req.Month)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		arg.PeriodStart = sql.NullTime{Time: month, Valid: true}
+	}
+
+	statements, err := server.store.ListStatements(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, statements)
+}
@@ -0,0 +1,417 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createStatements": createStatementsTestCasesBuilder(),
+		"Test_getStatement":     getStatementTestCasesBuilder(),
+		"Test_getStatementPDF":  getStatementPDFTestCasesBuilder(),
+		"Test_listStatements":   listStatementsTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomStatement() db.Statement {
+	start, end := db.StatementPeriod(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC))
+
+	statement := db.Statement{
+		StatementID:     util.RandomInt64(1, 1000),
+		StudentID:       util.RandomInt64(1, 1000),
+		PeriodStart:     start,
+		PeriodEnd:       end,
+		PreviousBalance: util.RandomInvoiceAmount(),
+		InvoicedAmount:  util.RandomInvoiceAmount(),
+		PaymentsAmount:  util.RandomPaymentAmount(),
+		CreatedAt:       time.Now().UTC().Truncate(time.Second),
+	}
+	statement.TotalDue = statement.PreviousBalance + statement.InvoicedAmount - statement.PaymentsAmount
+
+	return statement
+}
+
+func randomStatementDocument() db.StatementDocument {
+	statement := randomStatement()
+	student := randomStudent()
+	student.StudentID = statement.StudentID
+
+	return db.StatementDocument{
+		Statement: statement,
+		Student:   student,
+		Invoices: []db.Invoice{{
+			InvoiceID:       util.RandomInt64(1, 1000),
+			StudentID:       statement.StudentID,
+			InvoiceDatetime: statement.PeriodStart.Add(48 * time.Hour),
+			HourlyFee:       util.RandomHourlyFee(),
+			Duration:        util.RandomLessonDuration(),
+			Amount:          statement.InvoicedAmount,
+		}},
+		Receipts: []db.Receipt{{
+			ReceiptID:       util.RandomInt64(1, 1000),
+			StudentID:       statement.StudentID,
+			ReceiptDatetime: statement.PeriodStart.Add(72 * time.Hour),
+			Amount:          statement.PaymentsAmount,
+		}},
+	}
+}
+
+// createStatementsTestCasesBuilder creates a slice of test cases for the createStatements API
+func createStatementsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	statements := []db.Statement{randomStatement(), randomStatement()}
+	month := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	methodName := "CreateMonthlyStatementsTx"
+	url := "/statements"
+
+	// create a test case for StatusOK response of all students
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       createStatementsRequest{Month: "2024-03"},
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, month, sql.NullInt64{}).
+				Return(statements, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, statements)
+		},
+	})
+
+	// create a test case for StatusOK response of a single student
+	testCases = append(testCases, testCase{
+		name:       "Student",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       createStatementsRequest{Month: "2024-03", StudentID: statements[0].StudentID},
+		buildStub: func(mockStore *mocks.MockStore) {
+			studentID := sql.NullInt64{Int64: statements[0].StudentID, Valid: true}
+			mockStore.On(methodName, mock.Anything, month, studentID).
+				Return(statements[:1], nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, statements[:1])
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       createStatementsRequest{Month: "2024-03"},
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything).
+				Return([]db.Statement{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create test cases for Bad Request responses of invalid months
+	for _, month := range []string{"", "2024-13", "03-2024", "2024-03-01"} {
+		testCases = append(testCases, testCase{
+			name:       fmt.Sprintf("Invalid Month %q", month),
+			httpMethod: http.MethodPost,
+			url:        url,
+			body:       createStatementsRequest{Month: month},
+			buildStub: func(mockStore *mocks.MockStore) {
+				mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything).Times(0)
+			},
+			checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+				mockStore.On(methodName, mock.Anything, mock.Anything, mock.Anything).Unset()
+			},
+		})
+	}
+
+	return testCases
+}
+
+// getStatementTestCasesBuilder creates a slice of test cases for the getStatement API
+func getStatementTestCasesBuilder() testCases {
+	var testCases testCases
+
+	doc := randomStatementDocument()
+	id := doc.Statement.StatementID
+	methodName := "GetStatementDocumentTx"
+	url := fmt.Sprintf("/statements/%d", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(doc, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, doc)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.StatementDocument{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.StatementDocument{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/statements/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getStatementPDFTestCasesBuilder creates a slice of test cases for the getStatementPDF API
+func getStatementPDFTestCasesBuilder() testCases {
+	var testCases testCases
+
+	doc := randomStatementDocument()
+	id := doc.Statement.StatementID
+	methodName := "GetStatementDocumentTx"
+	url := fmt.Sprintf("/statements/%d/pdf", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(doc, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+
+			filename := fmt.Sprintf("statement-%d-2024-03.pdf", doc.Statement.StudentID)
+			assert.Equal(t, "attachment; filename="+filename, recorder.Header().Get("Content-Disposition"))
+
+			require.True(t, bytes.HasPrefix(recorder.Body.Bytes(), []byte("%PDF-")))
+			require.Contains(t, recorder.Body.String(), doc.Student.FirstName)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.StatementDocument{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/statements/0/pdf",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// listStatementsTestCasesBuilder creates a slice of test cases for the listStatements API
+func listStatementsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	var statements []db.Statement
+	for i := 0; i < 5; i++ {
+		statements = append(statements, randomStatement())
+	}
+
+	methodName := "ListStatements"
+
+	// create a test case for StatusOK response
+	arg := db.ListStatementsParams{
+		Limit:  5,
+		Offset: 5,
+	}
+
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/statements?page_id=2&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(statements, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, statements)
+		},
+	})
+
+	// create a test case for StatusOK response filtered by student and month
+	filterArg := db.ListStatementsParams{
+		StudentID:   sql.NullInt64{Int64: statements[0].StudentID, Valid: true},
+		PeriodStart: sql.NullTime{Time: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		Limit:       5,
+		Offset:      0,
+	}
+
+	testCases = append(testCases, testCase{
+		name:       "Filters",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/statements?student_id=%d&month=2024-03&page_id=1&page_size=5", statements[0].StudentID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, filterArg).
+				Return(statements[:1], nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, statements[:1])
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        "/statements?page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return([]db.Statement{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Month response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Month",
+		httpMethod: http.MethodGet,
+		url:        "/statements?month=march&page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Invalid Page ID response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Page ID",
+		httpMethod: http.MethodGet,
+		url:        "/statements?page_id=0&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
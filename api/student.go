@@ -3,6 +3,8 @@ package api
 import (
 	"database/sql"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
@@ -40,7 +42,7 @@ func (server *Server) createStudent(ctx *gin.Context) {
 		Notes:       req.Notes,
 	}
 
-	student, err := server.store.CreateStudent(ctx, arg)
+	student, err := server.store.CreateStudentTx(ctx, arg)
 
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -76,11 +78,54 @@ func (server *Server) getStudent(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, student)
 }
 
+// studentsFilterRequest contains the filters of the students search and export.
+// FieldValue is matched against the values of the custom field FieldID.
+type studentsFilterRequest struct {
+	LifecycleStatus string `form:"lifecycle_status" binding:"omitempty,oneof=active at-risk lapsed"`
+	TagID           int64  `form:"tag_id" binding:"omitempty,min=1"`
+	FieldID         int64  `form:"field_id" binding:"omitempty,min=1"`
+	FieldValue      string `form:"field_value" binding:"required_with=FieldID"`
+}
+
+// bindStudentsFilter binds the students filters, and normalizes the custom field value to the type of its field.
+// In case of an error the error response is sent and ok is false.
+func (server *Server) bindStudentsFilter(ctx *gin.Context) (req studentsFilterRequest, ok bool) {
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return req, false
+	}
+
+	if req.FieldID == 0 {
+		return req, true
+	}
+
+	field, err := server.store.GetCustomField(ctx, req.FieldID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return req, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return req, false
+	}
+
+	req.FieldValue, err = field.NormalizeValue(req.FieldValue)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return req, false
+	}
+
+	return req, true
+}
+
 type listStudentsRequest struct {
 	PageID   int32 `form:"page_id" binding:"required,min=1"`
 	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
 }
 
+// listStudents returns the students by name, optionally with a single lifecycle status, with a tag,
+// or with a value of a custom field.
 func (server *Server) listStudents(ctx *gin.Context) {
 	var req listStudentsRequest
 
@@ -89,9 +134,18 @@ func (server *Server) listStudents(ctx *gin.Context) {
 		return
 	}
 
+	filter, ok := server.bindStudentsFilter(ctx)
+	if !ok {
+		return
+	}
+
 	arg := db.ListStudentsParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		LifecycleStatus: sql.NullString{String: filter.LifecycleStatus, Valid: filter.LifecycleStatus != ""},
+		TagID:           sql.NullInt64{Int64: filter.TagID, Valid: filter.TagID != 0},
+		FieldID:         sql.NullInt64{Int64: filter.FieldID, Valid: filter.FieldID != 0},
+		FieldValue:      sql.NullString{String: filter.FieldValue, Valid: filter.FieldID != 0},
+		Limit:           req.PageSize,
+		Offset:          (req.PageID - 1) * req.PageSize,
 	}
 
 	students, err := server.store.ListStudents(ctx, arg)
@@ -103,6 +157,55 @@ func (server *Server) listStudents(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, students)
 }
 
+// exportStudents returns the students matching the filters of listStudents as a CSV file, by name,
+// with their tags separated by semicolons and a column for every custom field.
+func (server *Server) exportStudents(ctx *gin.Context) {
+	filter, ok := server.bindStudentsFilter(ctx)
+	if !ok {
+		return
+	}
+
+	export, err := server.store.ExportStudentsTx(ctx, db.ExportStudentsParams{
+		LifecycleStatus: sql.NullString{String: filter.LifecycleStatus, Valid: filter.LifecycleStatus != ""},
+		TagID:           sql.NullInt64{Int64: filter.TagID, Valid: filter.TagID != 0},
+		FieldID:         sql.NullInt64{Int64: filter.FieldID, Valid: filter.FieldID != 0},
+		FieldValue:      sql.NullString{String: filter.FieldValue, Valid: filter.FieldID != 0},
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	header := []string{
+		"student_id", "first_name", "last_name", "email", "phone_number", "lifecycle_status", "tags",
+	}
+	for _, field := range export.Fields {
+		header = append(header, field.Name)
+	}
+
+	records := [][]string{header}
+	for _, exported := range export.Students {
+		student := exported.Student
+		record := []string{
+			strconv.FormatInt(student.StudentID, 10),
+			student.FirstName,
+			student.LastName,
+			student.Email.String,
+			student.PhoneNumber.String,
+			student.LifecycleStatus,
+			strings.Join(exported.Tags, ";"),
+		}
+
+		for _, field := range export.Fields {
+			record = append(record, exported.Values[field.FieldID])
+		}
+
+		records = append(records, record)
+	}
+
+	csvResponse(ctx, "students.csv", records)
+}
+
 type updateStudentRequest struct {
 	StudentID   int64           `json:"student_id" binding:"required"`
 	FirstName   string          `json:"first_name" binding:"required"`
@@ -2,9 +2,11 @@ package api
 
 import (
 	"database/sql"
+	"encoding/csv"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/github-real-lb/tutor-management-web/db/mocks"
@@ -12,6 +14,7 @@ import (
 	"github.com/github-real-lb/tutor-management-web/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStudentAPIs(t *testing.T) {
@@ -19,6 +22,7 @@ func TestStudentAPIs(t *testing.T) {
 		"Test_createStudentAPI": createStudentTestCasesBuilder(),
 		"Test_getStudent":       getStudentTestCasesBuilder(),
 		"Test_listStudents":     listStudentsTestCasesBuilder(),
+		"Test_exportStudents":   exportStudentsTestCasesBuilder(),
 		"Test_updateStudent":    updateStudentTestCasesBuilder(),
 	}
 
@@ -45,16 +49,17 @@ func TestStudentAPIs(t *testing.T) {
 // randomStudent creates a new random Student struct.
 func randomStudent() db.Student {
 	return db.Student{
-		StudentID:   util.RandomInt64(1, 1000),
-		FirstName:   util.RandomName(),
-		LastName:    util.RandomName(),
-		Email:       sql.NullString{String: util.RandomEmail(), Valid: true},
-		PhoneNumber: sql.NullString{String: util.RandomPhoneNumber(), Valid: true},
-		Address:     sql.NullString{String: util.RandomAddress(), Valid: true},
-		CollegeID:   sql.NullInt64{Int64: 0, Valid: false},
-		FunnelID:    sql.NullInt64{Int64: 0, Valid: false},
-		HourlyFee:   sql.NullFloat64{Float64: util.RandomHourlyFee(), Valid: true},
-		Notes:       sql.NullString{String: util.RandomNote(), Valid: true},
+		StudentID:       util.RandomInt64(1, 1000),
+		FirstName:       util.RandomName(),
+		LastName:        util.RandomName(),
+		Email:           sql.NullString{String: util.RandomEmail(), Valid: true},
+		PhoneNumber:     sql.NullString{String: util.RandomPhoneNumber(), Valid: true},
+		Address:         sql.NullString{String: util.RandomAddress(), Valid: true},
+		CollegeID:       sql.NullInt64{Int64: 0, Valid: false},
+		FunnelID:        sql.NullInt64{Int64: 0, Valid: false},
+		HourlyFee:       sql.NullFloat64{Float64: util.RandomHourlyFee(), Valid: true},
+		Notes:           sql.NullString{String: util.RandomNote(), Valid: true},
+		LifecycleStatus: db.LifecycleStatusActive,
 	}
 }
 
@@ -75,7 +80,7 @@ func createStudentTestCasesBuilder() testCases {
 		Notes:       student.Notes,
 	}
 
-	methodName := "CreateStudent"
+	methodName := "CreateStudentTx"
 	url := "/students"
 
 	// create a test case for StatusOK response
@@ -247,6 +252,111 @@ func listStudentsTestCasesBuilder() testCases {
 		},
 	})
 
+	// create a test case for StatusOK response filtered by lifecycle status
+	filterArg := arg
+	filterArg.LifecycleStatus = sql.NullString{String: db.LifecycleStatusAtRisk, Valid: true}
+
+	testCases = append(testCases, testCase{
+		name:       "OK Lifecycle Status Filter",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("%s&lifecycle_status=%s", url, db.LifecycleStatusAtRisk),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, filterArg).
+				Return(students, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, students)
+
+		},
+	})
+
+	// create a test case for StatusOK response filtered by tag and by a normalized number custom field value
+	field := randomCustomField(db.CustomFieldTypeNumber)
+	tagID := util.RandomInt64(1, 1000)
+
+	customFilterArg := arg
+	customFilterArg.TagID = sql.NullInt64{Int64: tagID, Valid: true}
+	customFilterArg.FieldID = sql.NullInt64{Int64: field.FieldID, Valid: true}
+	customFilterArg.FieldValue = sql.NullString{String: "7.5", Valid: true}
+
+	testCases = append(testCases, testCase{
+		name:       "OK Tag And Custom Field Filter",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("%s&tag_id=%d&field_id=%d&field_value=7.50", url, tagID, field.FieldID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetCustomField", mock.Anything, field.FieldID).
+				Return(field, nil).
+				Once()
+
+			mockStore.On(methodName, mock.Anything, customFilterArg).
+				Return(students, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, students)
+		},
+	})
+
+	// create a test case for Invalid Custom Field Value response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Custom Field Value",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("%s&field_id=%d&field_value=many", url, field.FieldID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetCustomField", mock.Anything, field.FieldID).
+				Return(field, nil).
+				Once()
+
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Missing Custom Field Value response
+	testCases = append(testCases, testCase{
+		name:       "Missing Custom Field Value",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("%s&field_id=%d", url, field.FieldID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetCustomField", mock.Anything, mock.Anything).Times(0)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On("GetCustomField", mock.Anything, mock.Anything).Unset()
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Custom Field Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Custom Field Not Found",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("%s&field_id=%d&field_value=1", url, field.FieldID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On("GetCustomField", mock.Anything, field.FieldID).
+				Return(db.CustomField{}, sql.ErrNoRows).
+				Once()
+
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
 	// create a test case for Internal Server Error response
 	testCases = append(testCases, testCase{
 		name:       "Internal Error",
@@ -279,6 +389,22 @@ func listStudentsTestCasesBuilder() testCases {
 		},
 	})
 
+	// create a test case for Invalid LifecycleStatus response by passing url with lifecycle_status=unknown
+	testCases = append(testCases, testCase{
+		name:       "Invalid Lifecycle_Status Parameter",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("%s&lifecycle_status=%s", url, "unknown"),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+
+		},
+	})
+
 	// create a test case for Invalid PageSize response by passing url with page_size=10000
 	testCases = append(testCases, testCase{
 		name:       "Invalid Page_Size Parameter",
@@ -367,3 +493,98 @@ func updateStudentTestCasesBuilder() testCases {
 
 	return testCases
 }
+
+// exportStudentsTestCasesBuilder creates a slice of test cases for the exportStudents API
+func exportStudentsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	grade := randomCustomField(db.CustomFieldTypeEnum)
+	birthday := randomCustomField(db.CustomFieldTypeDate)
+
+	tagged := db.ExportedStudent{
+		Student: randomStudent(),
+		Tags:    []string{"exam year", "scholarship"},
+		Values:  map[int64]string{grade.FieldID: grade.Options[0]},
+	}
+	untagged := db.ExportedStudent{
+		Student: randomStudent(),
+		Tags:    []string{},
+		Values:  map[int64]string{birthday.FieldID: "2010-05-04"},
+	}
+
+	export := db.StudentExport{
+		Fields:   []db.CustomField{birthday, grade},
+		Students: []db.ExportedStudent{tagged, untagged},
+	}
+
+	arg := db.ExportStudentsParams{
+		LifecycleStatus: sql.NullString{String: db.LifecycleStatusActive, Valid: true},
+	}
+
+	methodName := "ExportStudentsTx"
+	url := "/students/export?lifecycle_status=active"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(export, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "text/csv", recorder.Header().Get("Content-Type"))
+
+			records, err := csv.NewReader(recorder.Body).ReadAll()
+			require.NoError(t, err)
+			require.Len(t, records, 3)
+			require.Equal(t, []string{
+				"student_id", "first_name", "last_name", "email", "phone_number", "lifecycle_status", "tags",
+				birthday.Name, grade.Name,
+			}, records[0])
+
+			require.Equal(t, strconv.FormatInt(tagged.Student.StudentID, 10), records[1][0])
+			require.Equal(t, "exam year;scholarship", records[1][6])
+			require.Equal(t, []string{"", grade.Options[0]}, records[1][7:])
+			require.Equal(t, "", records[2][6])
+			require.Equal(t, []string{"2010-05-04", ""}, records[2][7:])
+		},
+	})
+
+	// create a test case for Invalid Lifecycle Status response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Lifecycle_Status Parameter",
+		httpMethod: http.MethodGet,
+		url:        "/students/export?lifecycle_status=unknown",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.StudentExport{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
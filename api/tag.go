@@ -0,0 +1,216 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type createTagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func (server *Server) createTag(ctx *gin.Context) {
+	var req createTagRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	tag, err := server.store.CreateTag(ctx, req.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tag)
+}
+
+type getTagRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getTag(ctx *gin.Context) {
+	var req getTagRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	tag, err := server.store.GetTag(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tag)
+}
+
+type listTagsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listTags returns the tags by name.
+func (server *Server) listTags(ctx *gin.Context) {
+	var req listTagsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListTagsParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	tags, err := server.store.ListTags(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tags)
+}
+
+type updateTagRequest struct {
+	TagID int64  `json:"tag_id" binding:"required,min=1"`
+	Name  string `json:"name" binding:"required"`
+}
+
+// updateTag renames a tag.
+func (server *Server) updateTag(ctx *gin.Context) {
+	var req updateTagRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateTagParams{
+		TagID: req.TagID,
+		Name:  req.Name,
+	}
+
+	err := server.store.UpdateTag(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Tag updated successfully"))
+}
+
+// deleteTag deletes a tag and removes it from the students.
+func (server *Server) deleteTag(ctx *gin.Context) {
+	var req getTagRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeleteTag(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Tag deleted successfully"))
+}
+
+// getStudentTags returns the tags of a student by name.
+func (server *Server) getStudentTags(ctx *gin.Context) {
+	var uri studentUriRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	tags, err := server.store.GetStudentTags(ctx, uri.StudentID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tags)
+}
+
+type addStudentTagRequest struct {
+	TagID int64 `json:"tag_id" binding:"required,min=1"`
+}
+
+// addStudentTag tags a student. Tagging a student with one of its tags has no effect.
+func (server *Server) addStudentTag(ctx *gin.Context) {
+	var uri studentUriRequest
+	var req addStudentTagRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.AddStudentTagParams{
+		StudentID: uri.StudentID,
+		TagID:     req.TagID,
+	}
+
+	err := server.store.AddStudentTag(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Tag added successfully"))
+}
+
+type removeStudentTagRequest struct {
+	StudentID int64 `uri:"id" binding:"required,min=1"`
+	TagID     int64 `uri:"tag_id" binding:"required,min=1"`
+}
+
+func (server *Server) removeStudentTag(ctx *gin.Context) {
+	var req removeStudentTagRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.RemoveStudentTag(ctx, db.RemoveStudentTagParams{
+		StudentID: req.StudentID,
+		TagID:     req.TagID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Tag removed successfully"))
+}
@@ -0,0 +1,411 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTagAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createTag":        createTagTestCasesBuilder(),
+		"Test_getTag":           getTagTestCasesBuilder(),
+		"Test_listTags":         listTagsTestCasesBuilder(),
+		"Test_updateTag":        updateTagTestCasesBuilder(),
+		"Test_deleteTag":        deleteTagTestCasesBuilder(),
+		"Test_getStudentTags":   getStudentTagsTestCasesBuilder(),
+		"Test_addStudentTag":    addStudentTagTestCasesBuilder(),
+		"Test_removeStudentTag": removeStudentTagTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomTag() db.Tag {
+	return db.Tag{
+		TagID:     util.RandomInt64(1, 1000),
+		Name:      util.RandomName(),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// createTagTestCasesBuilder creates a slice of test cases for the createTag API
+func createTagTestCasesBuilder() testCases {
+	var testCases testCases
+
+	tag := randomTag()
+	req := createTagRequest{Name: tag.Name}
+
+	methodName := "CreateTag"
+	url := "/tags"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, tag.Name).
+				Return(tag, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, tag)
+		},
+	})
+
+	// create a test case for Missing Name response
+	testCases = append(testCases, testCase{
+		name:       "Missing Name",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       createTagRequest{},
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.Tag{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// getTagTestCasesBuilder creates a slice of test cases for the getTag API
+func getTagTestCasesBuilder() testCases {
+	var testCases testCases
+
+	tag := randomTag()
+	methodName := "GetTag"
+	url := fmt.Sprintf("/tags/%d", tag.TagID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, tag.TagID).
+				Return(tag, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, tag)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, tag.TagID).
+				Return(db.Tag{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// listTagsTestCasesBuilder creates a slice of test cases for the listTags API
+func listTagsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	tags := []db.Tag{randomTag(), randomTag()}
+	methodName := "ListTags"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        "/tags?page_id=2&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, db.ListTagsParams{Limit: 5, Offset: 5}).
+				Return(tags, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, tags)
+		},
+	})
+
+	// create a test case for Invalid Page Size response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Page Size",
+		httpMethod: http.MethodGet,
+		url:        "/tags?page_id=1&page_size=50",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// updateTagTestCasesBuilder creates a slice of test cases for the updateTag API
+func updateTagTestCasesBuilder() testCases {
+	var testCases testCases
+
+	tag := randomTag()
+	req := updateTagRequest{TagID: tag.TagID, Name: tag.Name}
+	arg := db.UpdateTagParams{TagID: tag.TagID, Name: tag.Name}
+
+	methodName := "UpdateTag"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        "/tags",
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// deleteTagTestCasesBuilder creates a slice of test cases for the deleteTag API
+func deleteTagTestCasesBuilder() testCases {
+	var testCases testCases
+
+	tagID := util.RandomInt64(1, 1000)
+	methodName := "DeleteTag"
+	url := fmt.Sprintf("/tags/%d", tagID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, tagID).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, tagID).
+				Return(int64(0), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// getStudentTagsTestCasesBuilder creates a slice of test cases for the getStudentTags API
+func getStudentTagsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	studentID := util.RandomInt64(1, 1000)
+	tags := []db.Tag{randomTag(), randomTag()}
+	methodName := "GetStudentTags"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/students/%d/tags", studentID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, studentID).
+				Return(tags, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, tags)
+		},
+	})
+
+	return testCases
+}
+
+// addStudentTagTestCasesBuilder creates a slice of test cases for the addStudentTag API
+func addStudentTagTestCasesBuilder() testCases {
+	var testCases testCases
+
+	studentID := util.RandomInt64(1, 1000)
+	tagID := util.RandomInt64(1, 1000)
+	arg := db.AddStudentTagParams{StudentID: studentID, TagID: tagID}
+
+	methodName := "AddStudentTag"
+	url := fmt.Sprintf("/students/%d/tags", studentID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       addStudentTagRequest{TagID: tagID},
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Missing Tag response
+	testCases = append(testCases, testCase{
+		name:       "Missing Tag",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       addStudentTagRequest{},
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       addStudentTagRequest{TagID: tagID},
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// removeStudentTagTestCasesBuilder creates a slice of test cases for the removeStudentTag API
+func removeStudentTagTestCasesBuilder() testCases {
+	var testCases testCases
+
+	arg := db.RemoveStudentTagParams{
+		StudentID: util.RandomInt64(1, 1000),
+		TagID:     util.RandomInt64(1, 1000),
+	}
+
+	methodName := "RemoveStudentTag"
+	url := fmt.Sprintf("/students/%d/tags/%d", arg.StudentID, arg.TagID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(int64(0), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
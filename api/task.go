@@ -0,0 +1,207 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+type createTaskRequest struct {
+	LeadID     sql.NullInt64  `json:"lead_id"`
+	StudentID  sql.NullInt64  `json:"student_id"`
+	LessonID   sql.NullInt64  `json:"lesson_id"`
+	InvoiceID  sql.NullInt64  `json:"invoice_id"`
+	Title      string         `json:"title" binding:"required"`
+	DueAt      time.Time      `json:"due_at" binding:"required"`
+	Priority   string         `json:"priority" binding:"omitempty,oneof=low normal high"`
+	Recurrence string         `json:"recurrence" binding:"omitempty,oneof=none daily weekly monthly"`
+	Notes      sql.NullString `json:"notes"`
+}
+
+// createTask adds an open task due at a date and time, optionally of a lead, student, lesson or invoice.
+// The priority defaults to normal, and the task doesn't recur unless a recurrence is set.
+func (server *Server) createTask(ctx *gin.Context) {
+	var req createTaskRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateTaskParams{
+		LeadID:     req.LeadID,
+		StudentID:  req.StudentID,
+		LessonID:   req.LessonID,
+		InvoiceID:  req.InvoiceID,
+		Title:      req.Title,
+		DueAt:      req.DueAt,
+		Priority:   req.Priority,
+		Recurrence: req.Recurrence,
+		Notes:      req.Notes,
+	}
+
+	if arg.Priority == "" {
+		arg.Priority = db.TaskPriorityNormal
+	}
+
+	if arg.Recurrence == "" {
+		arg.Recurrence = db.TaskRecurrenceNone
+	}
+
+	task, err := server.store.CreateTask(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, task)
+}
+
+type getTaskRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getTask(ctx *gin.Context) {
+	var req getTaskRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	task, err := server.store.GetTask(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, task)
+}
+
+type listTasksRequest struct {
+	LeadID    int64  `form:"lead_id" binding:"omitempty,min=1"`
+	StudentID int64  `form:"student_id" binding:"omitempty,min=1"`
+	LessonID  int64  `form:"lesson_id" binding:"omitempty,min=1"`
+	InvoiceID int64  `form:"invoice_id" binding:"omitempty,min=1"`
+	Status    string `form:"status" binding:"omitempty,oneof=open in_progress done cancelled"`
+	Priority  string `form:"priority" binding:"omitempty,oneof=low normal high"`
+	Overdue   bool   `form:"overdue"`
+	PageID    int32  `form:"page_id" binding:"required,min=1"`
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listTasks returns the tasks by due date, optionally of a single lead, student, lesson or invoice,
+// with a single status or priority, or only the overdue open and in progress tasks.
+func (server *Server) listTasks(ctx *gin.Context) {
+	var req listTasksRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListTasksParams{
+		LeadID:    sql.NullInt64{Int64: req.LeadID, Valid: req.LeadID != 0},
+		StudentID: sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0},
+		LessonID:  sql.NullInt64{Int64: req.LessonID, Valid: req.LessonID != 0},
+		InvoiceID: sql.NullInt64{Int64: req.InvoiceID, Valid: req.InvoiceID != 0},
+		Status:    sql.NullString{String: req.Status, Valid: req.Status != ""},
+		Priority:  sql.NullString{String: req.Priority, Valid: req.Priority != ""},
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	}
+
+	if req.Overdue {
+		arg.OverdueAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	}
+
+	tasks, err := server.store.ListTasks(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tasks)
+}
+
+type updateTaskRequest struct {
+	TaskID     int64          `json:"task_id" binding:"required,min=1"`
+	LeadID     sql.NullInt64  `json:"lead_id"`
+	StudentID  sql.NullInt64  `json:"student_id"`
+	LessonID   sql.NullInt64  `json:"lesson_id"`
+	InvoiceID  sql.NullInt64  `json:"invoice_id"`
+	Title      string         `json:"title" binding:"required"`
+	DueAt      time.Time      `json:"due_at" binding:"required"`
+	Priority   string         `json:"priority" binding:"required,oneof=low normal high"`
+	Recurrence string         `json:"recurrence" binding:"required,oneof=none daily weekly monthly"`
+	Notes      sql.NullString `json:"notes"`
+	Status     string         `json:"status" binding:"required,oneof=open in_progress done cancelled"`
+}
+
+// updateTask updates a task, completing it or reopening it.
+// When a recurring task is done, the next task is created and returned with the task.
+func (server *Server) updateTask(ctx *gin.Context) {
+	var req updateTaskRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateTaskParams{
+		TaskID:     req.TaskID,
+		LeadID:     req.LeadID,
+		StudentID:  req.StudentID,
+		LessonID:   req.LessonID,
+		InvoiceID:  req.InvoiceID,
+		Title:      req.Title,
+		DueAt:      req.DueAt,
+		Priority:   req.Priority,
+		Recurrence: req.Recurrence,
+		Notes:      req.Notes,
+		Status:     req.Status,
+	}
+
+	result, err := server.store.UpdateTaskTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (server *Server) deleteTask(ctx *gin.Context) {
+	var req getTaskRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeleteTask(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Task deleted successfully"))
+}
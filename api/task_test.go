@@ -0,0 +1,444 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTaskAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createTask": createTaskTestCasesBuilder(),
+		"Test_getTask":    getTaskTestCasesBuilder(),
+		"Test_listTasks":  listTasksTestCasesBuilder(),
+		"Test_updateTask": updateTaskTestCasesBuilder(),
+		"Test_deleteTask": deleteTaskTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomTask() db.Task {
+	return db.Task{
+		TaskID:     util.RandomInt64(1, 1000),
+		LeadID:     sql.NullInt64{Int64: util.RandomInt64(1, 1000), Valid: true},
+		StudentID:  sql.NullInt64{Int64: util.RandomInt64(1, 1000), Valid: true},
+		Title:      util.RandomNote(),
+		DueAt:      time.Now().UTC().AddDate(0, 0, 3).Truncate(time.Second),
+		Notes:      sql.NullString{String: util.RandomNote(), Valid: true},
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+		Priority:   db.TaskPriorityHigh,
+		Status:     db.TaskStatusOpen,
+		Recurrence: db.TaskRecurrenceWeekly,
+	}
+}
+
+// createTaskTestCasesBuilder creates a slice of test cases for the createTask API
+func createTaskTestCasesBuilder() testCases {
+	var testCases testCases
+
+	task := randomTask()
+	req := createTaskRequest{
+		LeadID:     task.LeadID,
+		StudentID:  task.StudentID,
+		Title:      task.Title,
+		DueAt:      task.DueAt,
+		Priority:   task.Priority,
+		Recurrence: task.Recurrence,
+		Notes:      task.Notes,
+	}
+
+	arg := db.CreateTaskParams{
+		LeadID:     task.LeadID,
+		StudentID:  task.StudentID,
+		Title:      task.Title,
+		DueAt:      task.DueAt,
+		Priority:   task.Priority,
+		Recurrence: task.Recurrence,
+		Notes:      task.Notes,
+	}
+
+	methodName := "CreateTask"
+	url := "/tasks"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(task, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, task)
+		},
+	})
+
+	// create a test case for StatusOK response of a task without links, priority and recurrence
+	defaultsReq := createTaskRequest{Title: task.Title, DueAt: task.DueAt}
+	defaultsArg := db.CreateTaskParams{
+		Title:      task.Title,
+		DueAt:      task.DueAt,
+		Priority:   db.TaskPriorityNormal,
+		Recurrence: db.TaskRecurrenceNone,
+	}
+	testCases = append(testCases, testCase{
+		name:       "Defaults",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       defaultsReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, defaultsArg).
+				Return(task, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Priority response
+	invalidReq := req
+	invalidReq.Priority = "urgent"
+	testCases = append(testCases, testCase{
+		name:       "Invalid Priority",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Missing Due Date response
+	invalidReq = req
+	invalidReq.DueAt = time.Time{}
+	testCases = append(testCases, testCase{
+		name:       "Missing Due Date",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.Task{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// getTaskTestCasesBuilder creates a slice of test cases for the getTask API
+func getTaskTestCasesBuilder() testCases {
+	var testCases testCases
+
+	task := randomTask()
+	methodName := "GetTask"
+	url := fmt.Sprintf("/tasks/%d", task.TaskID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, task.TaskID).
+				Return(task, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, task)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, task.TaskID).
+				Return(db.Task{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// listTasksTestCasesBuilder creates a slice of test cases for the listTasks API
+func listTasksTestCasesBuilder() testCases {
+	var testCases testCases
+
+	tasks := []db.Task{randomTask(), randomTask()}
+	studentID := util.RandomInt64(1, 1000)
+
+	arg := db.ListTasksParams{
+		StudentID: sql.NullInt64{Int64: studentID, Valid: true},
+		Status:    sql.NullString{String: db.TaskStatusOpen, Valid: true},
+		Priority:  sql.NullString{String: db.TaskPriorityHigh, Valid: true},
+		Limit:     5,
+		Offset:    0,
+	}
+
+	methodName := "ListTasks"
+
+	// create a test case for StatusOK response of the open high priority tasks of a student
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/tasks?student_id=%d&status=open&priority=high&page_id=1&page_size=5", studentID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(tasks, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, tasks)
+		},
+	})
+
+	// create a test case for StatusOK response of all the tasks
+	testCases = append(testCases, testCase{
+		name:       "All Tasks",
+		httpMethod: http.MethodGet,
+		url:        "/tasks?page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, db.ListTasksParams{Limit: 5, Offset: 0}).
+				Return(tasks, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for StatusOK response of the overdue tasks
+	testCases = append(testCases, testCase{
+		name:       "Overdue",
+		httpMethod: http.MethodGet,
+		url:        "/tasks?overdue=true&page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.MatchedBy(func(arg db.ListTasksParams) bool {
+				return arg.OverdueAt.Valid && time.Since(arg.OverdueAt.Time) < time.Minute && !arg.Status.Valid
+			})).Return(tasks, nil).Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Status response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Status",
+		httpMethod: http.MethodGet,
+		url:        "/tasks?status=completed&page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// updateTaskTestCasesBuilder creates a slice of test cases for the updateTask API
+func updateTaskTestCasesBuilder() testCases {
+	var testCases testCases
+
+	task := randomTask()
+	req := updateTaskRequest{
+		TaskID:     task.TaskID,
+		LeadID:     task.LeadID,
+		StudentID:  task.StudentID,
+		Title:      task.Title,
+		DueAt:      task.DueAt,
+		Priority:   task.Priority,
+		Recurrence: task.Recurrence,
+		Notes:      task.Notes,
+		Status:     db.TaskStatusDone,
+	}
+
+	arg := db.UpdateTaskParams{
+		TaskID:     task.TaskID,
+		LeadID:     task.LeadID,
+		StudentID:  task.StudentID,
+		Title:      task.Title,
+		DueAt:      task.DueAt,
+		Priority:   task.Priority,
+		Recurrence: task.Recurrence,
+		Notes:      task.Notes,
+		Status:     db.TaskStatusDone,
+	}
+
+	done := task
+	done.Status = db.TaskStatusDone
+	done.CompletedAt = sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true}
+
+	next := randomTask()
+	next.DueAt = db.NextTaskDueAt(task.DueAt, task.Recurrence)
+	result := db.UpdatedTask{Task: done, NextTask: &next}
+
+	methodName := "UpdateTaskTx"
+
+	// create a test case for StatusOK response of a recurring task being done
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        "/tasks",
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(result, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, result)
+		},
+	})
+
+	// create a test case for Invalid Recurrence response
+	invalidReq := req
+	invalidReq.Recurrence = "yearly"
+	testCases = append(testCases, testCase{
+		name:       "Invalid Recurrence",
+		httpMethod: http.MethodPut,
+		url:        "/tasks",
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodPut,
+		url:        "/tasks",
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(db.UpdatedTask{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// deleteTaskTestCasesBuilder creates a slice of test cases for the deleteTask API
+func deleteTaskTestCasesBuilder() testCases {
+	var testCases testCases
+
+	taskID := util.RandomInt64(1, 1000)
+	methodName := "DeleteTask"
+	url := fmt.Sprintf("/tasks/%d", taskID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, taskID).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, taskID).
+				Return(int64(0), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
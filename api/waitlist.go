@@ -0,0 +1,220 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+var (
+	// errInvalidWaitlistWeekday is returned when the preferred weekday of a waitlist entry isn't between 0 (Sunday) and 6.
+	errInvalidWaitlistWeekday = errors.New("weekday must be between 0 (Sunday) and 6 (Saturday)")
+
+	// errInvalidWaitlistTimes is returned when the preferred times of a waitlist entry aren't a valid range of minutes after midnight.
+	errInvalidWaitlistTimes = errors.New("start_minute and end_minute must be set together, with start_minute before end_minute and end_minute at most 1440")
+)
+
+// validateWaitlistPreferences returns an error if the preferred day or times of a waitlist entry are invalid.
+// Both the weekday and the times are optional, and an entry without them matches any lesson of its subject.
+func validateWaitlistPreferences(weekday, startMinute, endMinute sql.NullInt32) error {
+	if weekday.Valid && (weekday.Int32 < 0 || weekday.Int32 > 6) {
+		return errInvalidWaitlistWeekday
+	}
+
+	if startMinute.Valid != endMinute.Valid {
+		return errInvalidWaitlistTimes
+	}
+
+	if startMinute.Valid && (startMinute.Int32 < 0 || startMinute.Int32 >= endMinute.Int32 || endMinute.Int32 > 1440) {
+		return errInvalidWaitlistTimes
+	}
+
+	return nil
+}
+
+type createWaitlistEntryRequest struct {
+	StudentID   int64          `json:"student_id" binding:"required,min=1"`
+	SubjectID   int64          `json:"subject_id" binding:"required,min=1"`
+	Weekday     sql.NullInt32  `json:"weekday"`
+	StartMinute sql.NullInt32  `json:"start_minute"`
+	EndMinute   sql.NullInt32  `json:"end_minute"`
+	Timezone    string         `json:"timezone"`
+	Notes       sql.NullString `json:"notes"`
+}
+
+// createWaitlistEntry adds a student to the waitlist of a subject, optionally on a preferred weekday and between preferred times.
+// The times are minutes after midnight in the timezone of the entry, UTC unless given.
+func (server *Server) createWaitlistEntry(ctx *gin.Context) {
+	var req createWaitlistEntryRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := validateWaitlistPreferences(req.Weekday, req.StartMinute, req.EndMinute); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	timezone, err := availabilityTimezone(req.Timezone)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateWaitlistEntryParams{
+		StudentID:   req.StudentID,
+		SubjectID:   req.SubjectID,
+		Weekday:     req.Weekday,
+		StartMinute: req.StartMinute,
+		EndMinute:   req.EndMinute,
+		Timezone:    timezone,
+		Notes:       req.Notes,
+	}
+
+	entry, err := server.store.CreateWaitlistEntry(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+type getWaitlistEntryRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getWaitlistEntry(ctx *gin.Context) {
+	var req getWaitlistEntryRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	entry, err := server.store.GetWaitlistEntry(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+type listWaitlistEntriesRequest struct {
+	StudentID int64  `form:"student_id" binding:"omitempty,min=1"`
+	SubjectID int64  `form:"subject_id" binding:"omitempty,min=1"`
+	Status    string `form:"status" binding:"omitempty,oneof=waiting notified withdrawn"`
+	PageID    int32  `form:"page_id" binding:"required,min=1"`
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listWaitlistEntries returns the waitlist in first-come order, optionally of a single student or subject, or with a single status.
+func (server *Server) listWaitlistEntries(ctx *gin.Context) {
+	var req listWaitlistEntriesRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListWaitlistEntriesParams{
+		StudentID: sql.NullInt64{Int64: req.StudentID, Valid: req.StudentID != 0},
+		SubjectID: sql.NullInt64{Int64: req.SubjectID, Valid: req.SubjectID != 0},
+		Status:    sql.NullString{String: req.Status, Valid: req.Status != ""},
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	}
+
+	entries, err := server.store.ListWaitlistEntries(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entries)
+}
+
+type updateWaitlistEntryRequest struct {
+	WaitlistEntryID int64          `json:"waitlist_entry_id" binding:"required,min=1"`
+	SubjectID       int64          `json:"subject_id" binding:"required,min=1"`
+	Weekday         sql.NullInt32  `json:"weekday"`
+	StartMinute     sql.NullInt32  `json:"start_minute"`
+	EndMinute       sql.NullInt32  `json:"end_minute"`
+	Timezone        string         `json:"timezone"`
+	Status          string         `json:"status" binding:"required,oneof=waiting notified withdrawn"`
+	Notes           sql.NullString `json:"notes"`
+}
+
+// updateWaitlistEntry updates a waitlist entry. A notified student who didn't book the slot
+// can be put back on the waitlist by setting the status to waiting, keeping their place in the queue.
+func (server *Server) updateWaitlistEntry(ctx *gin.Context) {
+	var req updateWaitlistEntryRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := validateWaitlistPreferences(req.Weekday, req.StartMinute, req.EndMinute); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	timezone, err := availabilityTimezone(req.Timezone)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateWaitlistEntryParams{
+		WaitlistEntryID: req.WaitlistEntryID,
+		SubjectID:       req.SubjectID,
+		Weekday:         req.Weekday,
+		StartMinute:     req.StartMinute,
+		EndMinute:       req.EndMinute,
+		Timezone:        timezone,
+		Status:          req.Status,
+		Notes:           req.Notes,
+	}
+
+	err = server.store.UpdateWaitlistEntry(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Waitlist entry updated successfully"))
+}
+
+func (server *Server) deleteWaitlistEntry(ctx *gin.Context) {
+	var req getWaitlistEntryRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeleteWaitlistEntry(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Waitlist entry deleted successfully"))
+}
@@ -0,0 +1,427 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitlistAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createWaitlistEntry": createWaitlistEntryTestCasesBuilder(),
+		"Test_getWaitlistEntry":    getWaitlistEntryTestCasesBuilder(),
+		"Test_listWaitlistEntries": listWaitlistEntriesTestCasesBuilder(),
+		"Test_updateWaitlistEntry": updateWaitlistEntryTestCasesBuilder(),
+		"Test_deleteWaitlistEntry": deleteWaitlistEntryTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomWaitlistEntry() db.WaitlistEntry {
+	start := int32(util.RandomInt64(8, 16) * 60)
+
+	return db.WaitlistEntry{
+		WaitlistEntryID: util.RandomInt64(1, 1000),
+		StudentID:       util.RandomInt64(1, 1000),
+		SubjectID:       util.RandomInt64(1, 1000),
+		Weekday:         sql.NullInt32{Int32: int32(util.RandomInt64(0, 6)), Valid: true},
+		StartMinute:     sql.NullInt32{Int32: start, Valid: true},
+		EndMinute:       sql.NullInt32{Int32: start + 180, Valid: true},
+		Timezone:        "Asia/Jerusalem",
+		Status:          db.WaitlistStatusWaiting,
+		Notes:           sql.NullString{String: util.RandomNote(), Valid: true},
+		CreatedAt:       time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// createWaitlistEntryTestCasesBuilder creates a slice of test cases for the createWaitlistEntry API
+func createWaitlistEntryTestCasesBuilder() testCases {
+	var testCases testCases
+
+	entry := randomWaitlistEntry()
+	req := createWaitlistEntryRequest{
+		StudentID:   entry.StudentID,
+		SubjectID:   entry.SubjectID,
+		Weekday:     entry.Weekday,
+		StartMinute: entry.StartMinute,
+		EndMinute:   entry.EndMinute,
+		Timezone:    entry.Timezone,
+		Notes:       entry.Notes,
+	}
+
+	arg := db.CreateWaitlistEntryParams{
+		StudentID:   entry.StudentID,
+		SubjectID:   entry.SubjectID,
+		Weekday:     entry.Weekday,
+		StartMinute: entry.StartMinute,
+		EndMinute:   entry.EndMinute,
+		Timezone:    entry.Timezone,
+		Notes:       entry.Notes,
+	}
+
+	methodName := "CreateWaitlistEntry"
+	url := "/waitlist_entries"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(entry, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, entry)
+		},
+	})
+
+	// create a test case for StatusOK response of an entry without preferences, in UTC
+	anyReq := req
+	anyReq.Weekday = sql.NullInt32{}
+	anyReq.StartMinute = sql.NullInt32{}
+	anyReq.EndMinute = sql.NullInt32{}
+	anyReq.Timezone = ""
+	anyArg := arg
+	anyArg.Weekday = sql.NullInt32{}
+	anyArg.StartMinute = sql.NullInt32{}
+	anyArg.EndMinute = sql.NullInt32{}
+	anyArg.Timezone = "UTC"
+	testCases = append(testCases, testCase{
+		name:       "No Preferences",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       anyReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, anyArg).
+				Return(entry, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create test cases for Bad Request responses of invalid preferences
+	invalidReqs := map[string]func(req *createWaitlistEntryRequest){
+		"Invalid Weekday":  func(req *createWaitlistEntryRequest) { req.Weekday.Int32 = 7 },
+		"Invalid Times":    func(req *createWaitlistEntryRequest) { req.EndMinute = req.StartMinute },
+		"Missing End":      func(req *createWaitlistEntryRequest) { req.EndMinute = sql.NullInt32{} },
+		"Invalid Timezone": func(req *createWaitlistEntryRequest) { req.Timezone = "Nowhere/Nothing" },
+		"Missing Subject":  func(req *createWaitlistEntryRequest) { req.SubjectID = 0 },
+	}
+
+	for name, invalidate := range invalidReqs {
+		invalidReq := req
+		invalidate(&invalidReq)
+		testCases = append(testCases, testCase{
+			name:       name,
+			httpMethod: http.MethodPost,
+			url:        url,
+			body:       invalidReq,
+			buildStub: func(mockStore *mocks.MockStore) {
+				mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+			},
+			checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+				mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+			},
+		})
+	}
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.WaitlistEntry{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// getWaitlistEntryTestCasesBuilder creates a slice of test cases for the getWaitlistEntry API
+func getWaitlistEntryTestCasesBuilder() testCases {
+	var testCases testCases
+
+	entry := randomWaitlistEntry()
+	methodName := "GetWaitlistEntry"
+	url := fmt.Sprintf("/waitlist_entries/%d", entry.WaitlistEntryID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, entry.WaitlistEntryID).
+				Return(entry, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, entry)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, entry.WaitlistEntryID).
+				Return(db.WaitlistEntry{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/waitlist_entries/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// listWaitlistEntriesTestCasesBuilder creates a slice of test cases for the listWaitlistEntries API
+func listWaitlistEntriesTestCasesBuilder() testCases {
+	var testCases testCases
+
+	entries := []db.WaitlistEntry{randomWaitlistEntry(), randomWaitlistEntry()}
+	subjectID := util.RandomInt64(1, 1000)
+
+	arg := db.ListWaitlistEntriesParams{
+		SubjectID: sql.NullInt64{Int64: subjectID, Valid: true},
+		Status:    sql.NullString{String: db.WaitlistStatusWaiting, Valid: true},
+		Limit:     5,
+		Offset:    5,
+	}
+
+	methodName := "ListWaitlistEntries"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        fmt.Sprintf("/waitlist_entries?subject_id=%d&status=waiting&page_id=2&page_size=5", subjectID),
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(entries, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, entries)
+		},
+	})
+
+	// create a test case for Invalid Status response
+	testCases = append(testCases, testCase{
+		name:       "Invalid Status",
+		httpMethod: http.MethodGet,
+		url:        "/waitlist_entries?status=booked&page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// updateWaitlistEntryTestCasesBuilder creates a slice of test cases for the updateWaitlistEntry API
+func updateWaitlistEntryTestCasesBuilder() testCases {
+	var testCases testCases
+
+	entry := randomWaitlistEntry()
+	req := updateWaitlistEntryRequest{
+		WaitlistEntryID: entry.WaitlistEntryID,
+		SubjectID:       entry.SubjectID,
+		Weekday:         entry.Weekday,
+		StartMinute:     entry.StartMinute,
+		EndMinute:       entry.EndMinute,
+		Timezone:        entry.Timezone,
+		Status:          db.WaitlistStatusWithdrawn,
+		Notes:           entry.Notes,
+	}
+
+	arg := db.UpdateWaitlistEntryParams{
+		WaitlistEntryID: entry.WaitlistEntryID,
+		SubjectID:       entry.SubjectID,
+		Weekday:         entry.Weekday,
+		StartMinute:     entry.StartMinute,
+		EndMinute:       entry.EndMinute,
+		Timezone:        entry.Timezone,
+		Status:          db.WaitlistStatusWithdrawn,
+		Notes:           entry.Notes,
+	}
+
+	methodName := "UpdateWaitlistEntry"
+	url := "/waitlist_entries"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Status response
+	invalidReq := req
+	invalidReq.Status = "booked"
+	testCases = append(testCases, testCase{
+		name:       "Invalid Status",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Invalid Times response
+	invalidReq = req
+	invalidReq.StartMinute = sql.NullInt32{}
+	testCases = append(testCases, testCase{
+		name:       "Invalid Times",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// deleteWaitlistEntryTestCasesBuilder creates a slice of test cases for the deleteWaitlistEntry API
+func deleteWaitlistEntryTestCasesBuilder() testCases {
+	var testCases testCases
+
+	entryID := util.RandomInt64(1, 1000)
+	methodName := "DeleteWaitlistEntry"
+	url := fmt.Sprintf("/waitlist_entries/%d", entryID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, entryID).
+				Return(int64(1), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodDelete,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, entryID).
+				Return(int64(0), nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+func TestValidateWaitlistPreferences(t *testing.T) {
+	valid := func(n int32) sql.NullInt32 { return sql.NullInt32{Int32: n, Valid: true} }
+
+	require.NoError(t, validateWaitlistPreferences(sql.NullInt32{}, sql.NullInt32{}, sql.NullInt32{}))
+	require.NoError(t, validateWaitlistPreferences(valid(0), valid(0), valid(1440)))
+	require.ErrorIs(t, validateWaitlistPreferences(valid(-1), sql.NullInt32{}, sql.NullInt32{}), errInvalidWaitlistWeekday)
+	require.ErrorIs(t, validateWaitlistPreferences(sql.NullInt32{}, valid(600), sql.NullInt32{}), errInvalidWaitlistTimes)
+	require.ErrorIs(t, validateWaitlistPreferences(sql.NullInt32{}, valid(600), valid(600)), errInvalidWaitlistTimes)
+	require.ErrorIs(t, validateWaitlistPreferences(sql.NullInt32{}, valid(600), valid(1441)), errInvalidWaitlistTimes)
+}
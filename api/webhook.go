@@ -0,0 +1,214 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/webhook"
+)
+
+type createWebhookSubscriptionRequest struct {
+	Url    string   `json:"url" binding:"required,url"`
+	Events []string `json:"events" binding:"required,min=1,dive,oneof=student.created lesson.created lesson.deleted receipt.created invoice.updated"`
+}
+
+// createWebhookSubscription creates an active webhook subscription.
+// The secret used to sign the payloads is generated by the server, and returned in the response.
+func (server *Server) createWebhookSubscription(ctx *gin.Context) {
+	var req createWebhookSubscriptionRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateWebhookSubscriptionParams{
+		Url:    req.Url,
+		Secret: secret,
+		Events: req.Events,
+		Active: true,
+	}
+
+	subscription, err := server.store.CreateWebhookSubscription(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, subscription)
+}
+
+type getWebhookSubscriptionRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getWebhookSubscription(ctx *gin.Context) {
+	var req getWebhookSubscriptionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	subscription, err := server.store.GetWebhookSubscription(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, subscription)
+}
+
+type listWebhookSubscriptionsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listWebhookSubscriptions(ctx *gin.Context) {
+	var req listWebhookSubscriptionsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListWebhookSubscriptionsParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	subscriptions, err := server.store.ListWebhookSubscriptions(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, subscriptions)
+}
+
+type updateWebhookSubscriptionRequest struct {
+	SubscriptionID int64    `json:"subscription_id" binding:"required,min=1"`
+	Url            string   `json:"url" binding:"required,url"`
+	Events         []string `json:"events" binding:"required,min=1,dive,oneof=student.created lesson.created lesson.deleted receipt.created invoice.updated"`
+	Active         bool     `json:"active"`
+}
+
+func (server *Server) updateWebhookSubscription(ctx *gin.Context) {
+	var req updateWebhookSubscriptionRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateWebhookSubscriptionParams{
+		SubscriptionID: req.SubscriptionID,
+		Url:            req.Url,
+		Events:         req.Events,
+		Active:         req.Active,
+	}
+
+	err := server.store.UpdateWebhookSubscription(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, okResponse("Webhook subscription updated successfully"))
+}
+
+type getWebhookDeliveryRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getWebhookDelivery(ctx *gin.Context) {
+	var req getWebhookDeliveryRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	delivery, err := server.store.GetWebhookDelivery(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, delivery)
+}
+
+type listWebhookDeliveriesRequest struct {
+	SubscriptionID int64 `form:"subscription_id" binding:"required,min=1"`
+	PageID         int32 `form:"page_id" binding:"required,min=1"`
+	PageSize       int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listWebhookDeliveries returns the delivery log of a webhook subscription, newest first.
+func (server *Server) listWebhookDeliveries(ctx *gin.Context) {
+	var req listWebhookDeliveriesRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListWebhookDeliveriesParams{
+		SubscriptionID: req.SubscriptionID,
+		Limit:          req.PageSize,
+		Offset:         (req.PageID - 1) * req.PageSize,
+	}
+
+	deliveries, err := server.store.ListWebhookDeliveries(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+type replayWebhookDeliveryRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// replayWebhookDelivery posts the payload of a previous delivery again, as a new delivery.
+func (server *Server) replayWebhookDelivery(ctx *gin.Context) {
+	var req replayWebhookDeliveryRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	delivery, err := server.store.ReplayWebhookDelivery(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, delivery)
+}
@@ -0,0 +1,599 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookAPIs(t *testing.T) {
+	tests := tests{
+		"Test_createWebhookSubscription": createWebhookSubscriptionTestCasesBuilder(),
+		"Test_getWebhookSubscription":    getWebhookSubscriptionTestCasesBuilder(),
+		"Test_listWebhookSubscriptions":  listWebhookSubscriptionsTestCasesBuilder(),
+		"Test_updateWebhookSubscription": updateWebhookSubscriptionTestCasesBuilder(),
+		"Test_getWebhookDelivery":        getWebhookDeliveryTestCasesBuilder(),
+		"Test_listWebhookDeliveries":     listWebhookDeliveriesTestCasesBuilder(),
+		"Test_replayWebhookDelivery":     replayWebhookDeliveryTestCasesBuilder(),
+	}
+
+	for key, tcs := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					// start mock db and build the stub
+					mockStore := mocks.NewMockStore(t)
+					tc.buildStub(mockStore)
+
+					// send test request to server
+					recorder := tc.sendRequestToServer(t, mockStore)
+
+					// check response
+					tc.checkResponse(t, mockStore, recorder)
+				})
+			}
+
+		})
+	}
+}
+
+func randomWebhookSubscription() db.WebhookSubscription {
+	return db.WebhookSubscription{
+		SubscriptionID: util.RandomInt64(1, 1000),
+		Url:            fmt.Sprintf("https://%s.example.com/webhooks", util.RandomString(8)),
+		Secret:         util.RandomString(64),
+		Events:         []string{db.WebhookEventStudentCreated, db.WebhookEventReceiptCreated},
+		Active:         true,
+		CreatedAt:      time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func randomWebhookDelivery() db.WebhookDelivery {
+	return db.WebhookDelivery{
+		DeliveryID:     util.RandomInt64(1, 1000),
+		SubscriptionID: util.RandomInt64(1, 1000),
+		Event:          db.WebhookEventLessonCreated,
+		Payload:        json.RawMessage(`{"event":"lesson.created"}`),
+		Status:         db.WebhookStatusDelivered,
+		Attempts:       1,
+		ResponseStatus: sql.NullInt32{Int32: http.StatusOK, Valid: true},
+		NextAttemptAt:  time.Now().UTC().Truncate(time.Second),
+		DeliveredAt:    sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true},
+		CreatedAt:      time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// createWebhookSubscriptionTestCasesBuilder creates a slice of test cases for the createWebhookSubscription API
+func createWebhookSubscriptionTestCasesBuilder() testCases {
+	var testCases testCases
+
+	subscription := randomWebhookSubscription()
+	req := createWebhookSubscriptionRequest{
+		Url:    subscription.Url,
+		Events: subscription.Events,
+	}
+
+	// the secret is generated by the server
+	matchArg := mock.MatchedBy(func(arg db.CreateWebhookSubscriptionParams) bool {
+		return arg.Url == req.Url &&
+			assert.ObjectsAreEqual(req.Events, arg.Events) &&
+			len(arg.Secret) == 64 &&
+			arg.Active
+	})
+
+	methodName := "CreateWebhookSubscription"
+	url := "/webhook_subscriptions"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, matchArg).
+				Return(subscription, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, subscription)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       req,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.WebhookSubscription{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid URL response
+	invalidReq := req
+	invalidReq.Url = "not a url"
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid URL",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for Invalid Event response by passing an unknown event
+	invalidReq = req
+	invalidReq.Events = []string{db.WebhookEventStudentCreated, "student.deleted"}
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Event",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	// create a test case for No Events response
+	invalidReq = req
+	invalidReq.Events = []string{}
+
+	testCases = append(testCases, testCase{
+		name:       "No Events",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       invalidReq,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getWebhookSubscriptionTestCasesBuilder creates a slice of test cases for the getWebhookSubscription API
+func getWebhookSubscriptionTestCasesBuilder() testCases {
+	var testCases testCases
+
+	subscription := randomWebhookSubscription()
+	id := subscription.SubscriptionID
+	methodName := "GetWebhookSubscription"
+	url := fmt.Sprintf("/webhook_subscriptions/%d", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(subscription, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, subscription)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.WebhookSubscription{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.WebhookSubscription{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodGet,
+		url:        "/webhook_subscriptions/0",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// listWebhookSubscriptionsTestCasesBuilder creates a slice of test cases for the listWebhookSubscriptions API
+func listWebhookSubscriptionsTestCasesBuilder() testCases {
+	var testCases testCases
+
+	var subscriptions []db.WebhookSubscription
+	for i := 0; i < 5; i++ {
+		subscriptions = append(subscriptions, randomWebhookSubscription())
+	}
+
+	arg := db.ListWebhookSubscriptionsParams{
+		Limit:  5,
+		Offset: 5,
+	}
+
+	methodName := "ListWebhookSubscriptions"
+	url := "/webhook_subscriptions?page_id=2&page_size=5"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(subscriptions, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, subscriptions)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return([]db.WebhookSubscription{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Page Size response by passing page_size above the maximum
+	testCases = append(testCases, testCase{
+		name:       "Invalid Page Size",
+		httpMethod: http.MethodGet,
+		url:        "/webhook_subscriptions?page_id=1&page_size=20",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// updateWebhookSubscriptionTestCasesBuilder creates a slice of test cases for the updateWebhookSubscription API
+func updateWebhookSubscriptionTestCasesBuilder() testCases {
+	var testCases testCases
+
+	subscription := randomWebhookSubscription()
+	arg := db.UpdateWebhookSubscriptionParams{
+		SubscriptionID: subscription.SubscriptionID,
+		Url:            subscription.Url,
+		Events:         []string{db.WebhookEventInvoiceUpdated},
+		Active:         false,
+	}
+
+	methodName := "UpdateWebhookSubscription"
+	url := "/webhook_subscriptions"
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       arg,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       arg,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid Event response by passing an unknown event
+	invalidArg := arg
+	invalidArg.Events = []string{"invoice.deleted"}
+
+	testCases = append(testCases, testCase{
+		name:       "Invalid Event",
+		httpMethod: http.MethodPut,
+		url:        url,
+		body:       invalidArg,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// getWebhookDeliveryTestCasesBuilder creates a slice of test cases for the getWebhookDelivery API
+func getWebhookDeliveryTestCasesBuilder() testCases {
+	var testCases testCases
+
+	delivery := randomWebhookDelivery()
+	id := delivery.DeliveryID
+	methodName := "GetWebhookDelivery"
+	url := fmt.Sprintf("/webhook_deliveries/%d", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(delivery, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, delivery)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.WebhookDelivery{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	return testCases
+}
+
+// listWebhookDeliveriesTestCasesBuilder creates a slice of test cases for the listWebhookDeliveries API
+func listWebhookDeliveriesTestCasesBuilder() testCases {
+	var testCases testCases
+
+	subscriptionID := util.RandomInt64(1, 1000)
+
+	var deliveries []db.WebhookDelivery
+	for i := 0; i < 5; i++ {
+		delivery := randomWebhookDelivery()
+		delivery.SubscriptionID = subscriptionID
+		deliveries = append(deliveries, delivery)
+	}
+
+	arg := db.ListWebhookDeliveriesParams{
+		SubscriptionID: subscriptionID,
+		Limit:          5,
+		Offset:         0,
+	}
+
+	methodName := "ListWebhookDeliveries"
+	url := fmt.Sprintf("/webhook_deliveries?subscription_id=%d&page_id=1&page_size=5", subscriptionID)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, arg).
+				Return(deliveries, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, deliveries)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodGet,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return([]db.WebhookDelivery{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Missing Subscription response
+	testCases = append(testCases, testCase{
+		name:       "Missing Subscription",
+		httpMethod: http.MethodGet,
+		url:        "/webhook_deliveries?page_id=1&page_size=5",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
+
+// replayWebhookDeliveryTestCasesBuilder creates a slice of test cases for the replayWebhookDelivery API
+func replayWebhookDeliveryTestCasesBuilder() testCases {
+	var testCases testCases
+
+	original := randomWebhookDelivery()
+	id := original.DeliveryID
+
+	replay := original
+	replay.DeliveryID = id + 1
+	replay.Status = db.WebhookStatusPending
+	replay.Attempts = 0
+	replay.ResponseStatus = sql.NullInt32{}
+	replay.DeliveredAt = sql.NullTime{}
+
+	methodName := "ReplayWebhookDelivery"
+	url := fmt.Sprintf("/webhook_deliveries/%d/replay", id)
+
+	// create a test case for StatusOK response
+	testCases = append(testCases, testCase{
+		name:       "OK",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(replay, nil).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			requireBodyMatchStruct(t, recorder.Body, replay)
+		},
+	})
+
+	// create a test case for Not Found response
+	testCases = append(testCases, testCase{
+		name:       "Not Found",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, id).
+				Return(db.WebhookDelivery{}, sql.ErrNoRows).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		},
+	})
+
+	// create a test case for Internal Server Error response
+	testCases = append(testCases, testCase{
+		name:       "Internal Error",
+		httpMethod: http.MethodPost,
+		url:        url,
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).
+				Return(db.WebhookDelivery{}, sql.ErrConnDone).
+				Once()
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		},
+	})
+
+	// create a test case for Invalid ID response by passing url with id=0
+	testCases = append(testCases, testCase{
+		name:       "Invalid ID",
+		httpMethod: http.MethodPost,
+		url:        "/webhook_deliveries/0/replay",
+		body:       nil,
+		buildStub: func(mockStore *mocks.MockStore) {
+			mockStore.On(methodName, mock.Anything, mock.Anything).Times(0)
+		},
+		checkResponse: func(t *testing.T, mockStore *mocks.MockStore, recorder *httptest.ResponseRecorder) {
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockStore.On(methodName, mock.Anything, mock.Anything).Unset()
+		},
+	})
+
+	return testCases
+}
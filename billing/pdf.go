@@ -0,0 +1,118 @@
+package billing
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page size and margins of the PDF documents, in points (1/72 inch). Pages are A4.
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	margin     = 50.0
+)
+
+// PDF fonts. The regular and bold fonts are proportional, and the mono font is used for columns of figures.
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+	fontMono    = "F3"
+)
+
+// pdfFonts are the standard PDF fonts used by the documents, by their resource name.
+// Standard fonts are available in every PDF reader, so they aren't embedded.
+var pdfFonts = []struct{ name, baseFont string }{
+	{fontRegular, "Helvetica"},
+	{fontBold, "Helvetica-Bold"},
+	{fontMono, "Courier"},
+}
+
+// pdfDocument is a minimal PDF writer of text documents.
+// It supports the standard fonts with the WinAnsi (Latin-1) encoding, text and lines.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+}
+
+// addPage starts a new page. All drawing is done on the last page.
+func (d *pdfDocument) addPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+// text draws s with its baseline starting at x, y. The origin is the bottom left corner of the page.
+func (d *pdfDocument) text(x, y float64, font string, size float64, s string) {
+	page := d.pages[len(d.pages)-1]
+	fmt.Fprintf(page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfString(s))
+}
+
+// line draws a line from x1, y1 to x2, y2.
+func (d *pdfDocument) line(x1, y1, x2, y2 float64) {
+	page := d.pages[len(d.pages)-1]
+	fmt.Fprintf(page, "%.2f w %.2f %.2f m %.2f %.2f l S\n", 0.5, x1, y1, x2, y2)
+}
+
+// bytes returns the PDF file of the document.
+func (d *pdfDocument) bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+
+	// objects are numbered from 1, in the order they are written
+	writeObject := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// the fonts are objects 3.., and the pages and their contents follow them
+	firstPage := 3 + len(pdfFonts)
+
+	var fonts, kids strings.Builder
+	for i, font := range pdfFonts {
+		fmt.Fprintf(&fonts, "/%s %d 0 R ", font.name, 3+i)
+	}
+	for i := range d.pages {
+		fmt.Fprintf(&kids, "%d 0 R ", firstPage+2*i)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.TrimSpace(kids.String()), len(d.pages)))
+
+	for _, font := range pdfFonts {
+		writeObject(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font.baseFont))
+	}
+
+	for i, page := range d.pages {
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fonts.String(), firstPage+2*i+1))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// pdfString escapes s for a PDF literal string, and encodes it as Latin-1.
+// Characters outside of Latin-1 are replaced by '?'.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r < 32:
+			b.WriteByte(' ')
+		case r > 255:
+			b.WriteByte('?')
+		default:
+			b.WriteByte(byte(r))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,148 @@
+package billing
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+//go:embed templates/progress_report.html
+var templatesFS embed.FS
+
+// progressReportTemplate is the HTML template of the progress reports.
+var progressReportTemplate = template.Must(template.New("progress_report.html").Funcs(template.FuncMap{
+	"date":           func(t time.Time) string { return t.UTC().Format("02 Jan 2006") },
+	"hours":          formatHours,
+	"period":         ProgressReportPeriod,
+	"homeworkStatus": homeworkStatus,
+}).ParseFS(templatesFS, "templates/progress_report.html"))
+
+// wrapWidth is the number of characters of the wrapped lines of text of the progress reports.
+// Helvetica glyphs are about 0.5 em wide on average, so the lines fit within the margins.
+const wrapWidth = 95
+
+// ProgressReportHTML returns the HTML page of a progress report.
+func ProgressReportHTML(doc db.ProgressReportDocument) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := progressReportTemplate.Execute(&buf, doc); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ProgressReportPDF returns the PDF file of a progress report.
+func ProgressReportPDF(doc db.ProgressReportDocument) []byte {
+	w := newStatementWriter()
+	report := doc.ProgressReport
+
+	w.textLine(fontBold, titleSize, "Progress Report")
+	w.advance(lineHeight / 2)
+	w.textLine(fontRegular, textSize, fmt.Sprintf("Student: %s %s", doc.Student.FirstName, doc.Student.LastName))
+	if doc.BillingContact != nil {
+		w.textLine(fontRegular, textSize, fmt.Sprintf("Prepared for: %s %s", doc.BillingContact.FirstName, doc.BillingContact.LastName))
+	}
+	w.textLine(fontRegular, textSize, "Period: "+ProgressReportPeriod(report))
+	w.rule()
+
+	w.textLine(fontRegular, textSize, fmt.Sprintf("Lessons attended: %d of %d", report.AttendedCount, report.LessonsCount))
+	w.textLine(fontRegular, textSize, "Hours: "+formatHours(report.Hours))
+	w.textLine(fontRegular, textSize, fmt.Sprintf("Homework completed: %d of %d", report.HomeworkCompletedCount, report.HomeworkAssignedCount))
+
+	w.advance(lineHeight / 2)
+	w.textLine(fontBold, headingSize, "Subjects")
+	for _, subject := range doc.Subjects {
+		label := fmt.Sprintf("%s, %d of %d lessons attended", subject.Name, subject.AttendedCount, subject.LessonsCount)
+		w.textLine(fontRegular, textSize, label+", "+formatHours(subject.Hours)+" hours")
+	}
+
+	w.advance(lineHeight / 2)
+	w.textLine(fontBold, headingSize, "Lessons")
+	for _, lesson := range doc.Lessons {
+		w.advance(lineHeight / 2)
+
+		label := fmt.Sprintf("%s  %s", lesson.LessonDatetime.UTC().Format("02 Jan 2006"), lesson.SubjectName)
+		if lesson.AttendanceStatus != db.AttendanceStatusAttended {
+			label += " (" + lesson.AttendanceStatus + ")"
+		}
+		w.textLine(fontBold, textSize, label)
+
+		if lesson.Topics.Valid && lesson.Topics.String != "" {
+			w.wrappedLines(fontRegular, "Topics: "+lesson.Topics.String)
+		}
+		if lesson.Homework.Valid && lesson.Homework.String != "" {
+			homework := "Homework: " + lesson.Homework.String
+			if status := homeworkStatus(lesson); status != "" {
+				homework += " (" + status + ")"
+			}
+			w.wrappedLines(fontRegular, homework)
+		}
+		if lesson.Notes.Valid && lesson.Notes.String != "" {
+			w.wrappedLines(fontRegular, "Tutor comments: "+lesson.Notes.String)
+		}
+	}
+
+	return w.doc.bytes()
+}
+
+// wrappedLines writes s on new lines, wrapped at wrapWidth characters.
+func (w *statementWriter) wrappedLines(font, s string) {
+	for _, line := range wrap(s, wrapWidth) {
+		w.textLine(font, textSize, line)
+	}
+}
+
+// wrap splits s into lines of at most width characters, breaking lines between words.
+// Words longer than width are put on their own line.
+func wrap(s string, width int) []string {
+	var lines []string
+	var line strings.Builder
+
+	for _, word := range strings.Fields(s) {
+		if line.Len() > 0 && line.Len()+1+len(word) > width {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteByte(' ')
+		}
+		line.WriteString(word)
+	}
+
+	if line.Len() > 0 {
+		lines = append(lines, line.String())
+	}
+
+	return lines
+}
+
+// homeworkStatus returns the homework status of a lesson, or an empty string if no homework was assigned.
+func homeworkStatus(lesson db.GetProgressReportLessonsRow) string {
+	if !lesson.HomeworkStatus.Valid || lesson.HomeworkStatus.String == db.HomeworkStatusNone {
+		return ""
+	}
+	return lesson.HomeworkStatus.String
+}
+
+// formatHours formats a number of hours with one decimal.
+func formatHours(hours float64) string {
+	return fmt.Sprintf("%.1f", hours)
+}
+
+// ProgressReportPeriod returns the period of a progress report as shown on the report, such as March 2024.
+// The end of the period is exclusive.
+func ProgressReportPeriod(report db.ProgressReport) string {
+	start, end := report.PeriodStart.UTC(), report.PeriodEnd.UTC()
+
+	// a calendar month is shown by its name
+	if start.Day() == 1 && end.Equal(start.AddDate(0, 1, 0)) && start.Equal(start.Truncate(24*time.Hour)) {
+		return start.Format("January 2006")
+	}
+
+	return start.Format("02 Jan 2006") + " - " + end.AddDate(0, 0, -1).Format("02 Jan 2006")
+}
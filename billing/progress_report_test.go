@@ -0,0 +1,114 @@
+package billing
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomProgressReportDocument(lessons int) db.ProgressReportDocument {
+	doc := db.ProgressReportDocument{
+		ProgressReport: db.ProgressReport{
+			ProgressReportID: util.RandomInt64(1, 1000),
+			StudentID:        util.RandomInt64(1, 1000),
+			PeriodStart:      time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:        time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Student: db.Student{
+			FirstName: util.RandomName(),
+			LastName:  "O'Brien <Jr>",
+		},
+		Subjects: []db.GetProgressReportSubjectsRow{
+			{SubjectID: util.RandomInt64(1, 1000), Name: util.RandomName()},
+		},
+	}
+
+	for i := 0; i < lessons; i++ {
+		lesson := db.GetProgressReportLessonsRow{
+			LessonID:         int64(i + 1),
+			LessonDatetime:   time.Date(2024, time.March, 1+i%28, 10, 0, 0, 0, time.UTC),
+			Duration:         60,
+			SubjectID:        doc.Subjects[0].SubjectID,
+			SubjectName:      doc.Subjects[0].Name,
+			AttendanceStatus: db.AttendanceStatusAttended,
+			Topics:           sql.NullString{String: util.RandomNote(), Valid: true},
+			Homework:         sql.NullString{String: util.RandomNote(), Valid: true},
+			HomeworkStatus:   sql.NullString{String: db.HomeworkStatusCompleted, Valid: true},
+			Notes:            sql.NullString{String: strings.Repeat(util.RandomName()+" ", 30), Valid: true},
+		}
+		doc.Lessons = append(doc.Lessons, lesson)
+
+		doc.ProgressReport.LessonsCount++
+		doc.ProgressReport.AttendedCount++
+		doc.ProgressReport.Hours++
+		doc.ProgressReport.HomeworkAssignedCount++
+		doc.ProgressReport.HomeworkCompletedCount++
+	}
+
+	doc.Subjects[0].LessonsCount = doc.ProgressReport.LessonsCount
+	doc.Subjects[0].AttendedCount = doc.ProgressReport.AttendedCount
+	doc.Subjects[0].Hours = doc.ProgressReport.Hours
+
+	return doc
+}
+
+func TestProgressReportPDF(t *testing.T) {
+	doc := randomProgressReportDocument(3)
+	doc.Lessons[1].AttendanceStatus = db.AttendanceStatusAbsent
+
+	pdf := ProgressReportPDF(doc)
+	require.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+
+	require.Contains(t, string(pdf), "(Period: March 2024)")
+	require.Contains(t, string(pdf), pdfString("Student: "+doc.Student.FirstName+" "+doc.Student.LastName))
+	require.Contains(t, string(pdf), "(Lessons attended: 3 of 3)")
+	require.Contains(t, string(pdf), "(Hours: 3.0)")
+	require.Contains(t, string(pdf), "(Homework completed: 3 of 3)")
+	require.Contains(t, string(pdf), pdfString("Topics: "+doc.Lessons[0].Topics.String))
+	require.Contains(t, string(pdf), pdfString("Homework: "+doc.Lessons[0].Homework.String+" (completed)"))
+	require.Contains(t, string(pdf), pdfString("02 Mar 2024  "+doc.Lessons[1].SubjectName+" (absent)"))
+	require.Contains(t, string(pdf), "(Tutor comments: ")
+}
+
+func TestProgressReportPDFPages(t *testing.T) {
+	pdf := ProgressReportPDF(randomProgressReportDocument(40))
+	require.NotContains(t, string(pdf), "/Count 1 ")
+}
+
+func TestProgressReportHTML(t *testing.T) {
+	doc := randomProgressReportDocument(2)
+	doc.BillingContact = &db.Guardian{FirstName: util.RandomName(), LastName: util.RandomName()}
+
+	html, err := ProgressReportHTML(doc)
+	require.NoError(t, err)
+
+	require.Contains(t, string(html), "Period: March 2024")
+	require.Contains(t, string(html), "O&#39;Brien &lt;Jr&gt;")
+	require.Contains(t, string(html), "Prepared for: "+doc.BillingContact.FirstName)
+	require.Contains(t, string(html), "<td class=\"number\">2 of 2</td>")
+	require.Contains(t, string(html), doc.Lessons[1].Topics.String)
+	require.Contains(t, string(html), "(completed)")
+}
+
+func TestProgressReportPeriod(t *testing.T) {
+	report := db.ProgressReport{
+		PeriodStart: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.Equal(t, "March 2024", ProgressReportPeriod(report))
+
+	report.PeriodEnd = time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, "01 Mar 2024 - 31 May 2024", ProgressReportPeriod(report))
+}
+
+func TestWrap(t *testing.T) {
+	require.Equal(t, []string{"one two", "three", "fourteen"}, wrap("one two three fourteen", 8))
+	require.Empty(t, wrap("  ", 8))
+}
@@ -0,0 +1,127 @@
+package billing
+
+import (
+	"fmt"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// Font sizes and line height of the statement, in points.
+const (
+	titleSize   = 18.0
+	headingSize = 12.0
+	textSize    = 10.0
+	lineHeight  = 15.0
+)
+
+// amountX is the right edge of the amounts column.
+const amountX = pageWidth - margin
+
+// statementWriter lays out a statement from the top of the page down, starting new pages as needed.
+type statementWriter struct {
+	doc pdfDocument
+	y   float64
+}
+
+func newStatementWriter() *statementWriter {
+	w := &statementWriter{}
+	w.newPage()
+	return w
+}
+
+func (w *statementWriter) newPage() {
+	w.doc.addPage()
+	w.y = pageHeight - margin
+}
+
+// advance moves the cursor down by height, and starts a new page if it reaches the bottom margin.
+func (w *statementWriter) advance(height float64) {
+	w.y -= height
+	if w.y < margin {
+		w.newPage()
+		w.y -= height
+	}
+}
+
+// textLine writes s on a new line.
+func (w *statementWriter) textLine(font string, size float64, s string) {
+	w.advance(lineHeight)
+	w.doc.text(margin, w.y, font, size, s)
+}
+
+// amountLine writes a label on a new line, with amount aligned to the right of the page.
+func (w *statementWriter) amountLine(font, label string, amount float64) {
+	w.advance(lineHeight)
+	w.doc.text(margin, w.y, font, textSize, label)
+
+	// Courier glyphs are 0.6 em wide, so the width of the amount is known
+	s := formatAmount(amount)
+	width := float64(len(s)) * 0.6 * textSize
+	w.doc.text(amountX-width, w.y, fontMono, textSize, s)
+}
+
+// rule draws a horizontal line across the page, below the current line.
+func (w *statementWriter) rule() {
+	w.advance(lineHeight / 2)
+	w.doc.line(margin, w.y, pageWidth-margin, w.y)
+}
+
+// StatementPDF returns the PDF file of a monthly statement.
+func StatementPDF(doc db.StatementDocument) []byte {
+	w := newStatementWriter()
+	statement := doc.Statement
+
+	w.textLine(fontBold, titleSize, "Monthly Statement")
+	w.advance(lineHeight / 2)
+	w.textLine(fontRegular, textSize, fmt.Sprintf("Statement #%d", statement.StatementID))
+	w.textLine(fontRegular, textSize, fmt.Sprintf("Student: %s %s", doc.Student.FirstName, doc.Student.LastName))
+	if doc.Student.Address.Valid {
+		w.textLine(fontRegular, textSize, doc.Student.Address.String)
+	}
+	if doc.BillingContact != nil {
+		w.textLine(fontRegular, textSize, fmt.Sprintf("Bill to: %s %s", doc.BillingContact.FirstName, doc.BillingContact.LastName))
+		if doc.BillingContact.Address.Valid {
+			w.textLine(fontRegular, textSize, doc.BillingContact.Address.String)
+		}
+	}
+	w.textLine(fontRegular, textSize, "Period: "+statement.PeriodStart.UTC().Format("January 2006"))
+	w.rule()
+
+	w.amountLine(fontRegular, "Previous balance", statement.PreviousBalance)
+
+	w.advance(lineHeight / 2)
+	w.textLine(fontBold, headingSize, "Lessons")
+	if len(doc.Invoices) == 0 {
+		w.textLine(fontRegular, textSize, "No lessons this month.")
+	}
+	for _, invoice := range doc.Invoices {
+		label := fmt.Sprintf("%s  Invoice #%d, %d minutes at %s per hour",
+			invoice.InvoiceDatetime.UTC().Format("02 Jan 2006"), invoice.InvoiceID, invoice.Duration, formatAmount(invoice.HourlyFee))
+		if invoice.Discount > 0 {
+			label += fmt.Sprintf(", %s discount", formatAmount(invoice.Discount))
+		}
+		w.amountLine(fontRegular, label, invoice.Amount)
+	}
+	w.amountLine(fontBold, "Invoiced this month", statement.InvoicedAmount)
+
+	w.advance(lineHeight / 2)
+	w.textLine(fontBold, headingSize, "Payments")
+	if len(doc.Receipts) == 0 {
+		w.textLine(fontRegular, textSize, "No payments this month.")
+	}
+	for _, receipt := range doc.Receipts {
+		label := fmt.Sprintf("%s  Receipt #%d", receipt.ReceiptDatetime.UTC().Format("02 Jan 2006"), receipt.ReceiptID)
+		w.amountLine(fontRegular, label, receipt.Amount)
+	}
+	w.amountLine(fontBold, "Payments this month", statement.PaymentsAmount)
+
+	w.rule()
+	w.amountLine(fontBold, "Total due", statement.TotalDue)
+
+	return w.doc.bytes()
+}
+
+// formatAmount formats an amount with two decimals, as in the emails.
+func formatAmount(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
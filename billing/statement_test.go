@@ -0,0 +1,107 @@
+package billing
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomStatementDocument(invoices int) db.StatementDocument {
+	doc := db.StatementDocument{
+		Statement: db.Statement{
+			StatementID:     util.RandomInt64(1, 1000),
+			PeriodStart:     time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:       time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+			PreviousBalance: util.RandomInvoiceAmount(),
+		},
+		Student: db.Student{
+			StudentID: util.RandomInt64(1, 1000),
+			FirstName: util.RandomName(),
+			LastName:  "O'Brien (Jr)",
+			Address:   sql.NullString{String: util.RandomAddress(), Valid: true},
+		},
+	}
+
+	for i := 0; i < invoices; i++ {
+		invoice := db.Invoice{
+			InvoiceID:       int64(i + 1),
+			InvoiceDatetime: time.Date(2024, time.March, 1+i%28, 10, 0, 0, 0, time.UTC),
+			HourlyFee:       util.RandomHourlyFee(),
+			Duration:        util.RandomLessonDuration(),
+			Amount:          util.RandomInvoiceAmount(),
+		}
+		doc.Invoices = append(doc.Invoices, invoice)
+		doc.Statement.InvoicedAmount += invoice.Amount
+	}
+
+	receipt := db.Receipt{
+		ReceiptID:       util.RandomInt64(1, 1000),
+		ReceiptDatetime: time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC),
+		Amount:          util.RandomPaymentAmount(),
+	}
+	doc.Receipts = append(doc.Receipts, receipt)
+	doc.Statement.PaymentsAmount = receipt.Amount
+
+	doc.Statement.TotalDue = doc.Statement.PreviousBalance + doc.Statement.InvoicedAmount - doc.Statement.PaymentsAmount
+	return doc
+}
+
+func TestStatementPDF(t *testing.T) {
+	doc := randomStatementDocument(3)
+
+	pdf := StatementPDF(doc)
+	require.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	require.Contains(t, string(pdf), "/Count 1 ")
+
+	require.Contains(t, string(pdf), "(Period: March 2024)")
+	require.Contains(t, string(pdf), pdfString("Student: "+doc.Student.FirstName+" "+doc.Student.LastName))
+	require.Contains(t, string(pdf), "O'Brien \\(Jr\\)")
+	require.Contains(t, string(pdf), "("+formatAmount(doc.Statement.PreviousBalance)+")")
+	require.Contains(t, string(pdf), "("+formatAmount(doc.Invoices[2].Amount)+")")
+	require.Contains(t, string(pdf), fmt.Sprintf("Receipt #%d", doc.Receipts[0].ReceiptID))
+	require.Contains(t, string(pdf), "("+formatAmount(doc.Statement.TotalDue)+")")
+}
+
+func TestStatementPDFPages(t *testing.T) {
+	doc := randomStatementDocument(100)
+
+	pdf := StatementPDF(doc)
+	require.Contains(t, string(pdf), "/Count 3 ")
+	require.Contains(t, string(pdf), "(Total due)")
+}
+
+func TestPDFCrossReference(t *testing.T) {
+	pdf := StatementPDF(randomStatementDocument(1))
+
+	// every object offset in the cross reference table points to the object
+	xref := bytes.Index(pdf, []byte("\nxref\n")) + 1
+	require.Positive(t, xref)
+
+	lines := strings.Split(string(pdf[xref:]), "\n")
+
+	var start, count int
+	_, err := fmt.Sscanf(lines[1], "%d %d", &start, &count)
+	require.NoError(t, err)
+	require.Equal(t, 0, start)
+
+	for i, entry := range lines[3 : 3+count-1] {
+		var offset int
+		_, err := fmt.Sscanf(entry, "%d 00000 n", &offset)
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(pdf[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))))
+	}
+}
+
+func TestPDFString(t *testing.T) {
+	require.Equal(t, `a\(b\)\\c`, pdfString(`a(b)\c`))
+	require.Equal(t, "a b", pdfString("a\nb"))
+	require.Equal(t, "caf\xe9 ?", pdfString("café €"))
+}
@@ -0,0 +1,218 @@
+// Package booking computes the free lesson slots of the tutor, from the weekly availability windows
+// and the exceptions to them, minus the existing lessons.
+package booking
+
+import (
+	"sort"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+
+	// the timezones of the availability windows are loaded from the embedded timezone database
+	// if the system has none
+	_ "time/tzdata"
+)
+
+// SlotStep is the interval between the start times of the offered slots.
+// Slots start at whole multiples of the step, such as on the hour and half past.
+const SlotStep = 30 * time.Minute
+
+// Slot is a free slot for a lesson, at the location the tutor is available at.
+type Slot struct {
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	LocationID int64     `json:"location_id"`
+}
+
+// interval is a period of time, and the location of the lessons booked in it for available periods.
+type interval struct {
+	start, end time.Time
+	locationID int64
+}
+
+// FreeSlots returns the free slots of duration between start and end, by start time,
+// in the location of start. It returns an error if the timezone of an availability window is invalid.
+func FreeSlots(availability db.Availability, start, end time.Time, duration time.Duration) ([]Slot, error) {
+	free, err := freeIntervals(availability, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var slots []Slot
+	for _, iv := range free {
+		t := iv.start.Truncate(SlotStep)
+		if t.Before(iv.start) {
+			t = t.Add(SlotStep)
+		}
+
+		for ; !t.Add(duration).After(iv.end); t = t.Add(SlotStep) {
+			// overlapping windows of different locations offer a slot once
+			if n := len(slots); n > 0 && !slots[n-1].Start.Before(t) {
+				continue
+			}
+
+			slots = append(slots, Slot{
+				Start:      t.In(start.Location()),
+				End:        t.Add(duration).In(start.Location()),
+				LocationID: iv.locationID,
+			})
+		}
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool { return slots[i].Start.Before(slots[j].Start) })
+	return slots, nil
+}
+
+// IsFree returns the free slot of duration starting at start, and false if the tutor isn't available
+// for the whole slot. The slot doesn't have to start at a multiple of SlotStep.
+func IsFree(availability db.Availability, start time.Time, duration time.Duration) (Slot, bool, error) {
+	end := start.Add(duration)
+
+	free, err := freeIntervals(availability, start, end)
+	if err != nil {
+		return Slot{}, false, err
+	}
+
+	for _, iv := range free {
+		if !iv.start.After(start) && !iv.end.Before(end) {
+			return Slot{Start: start, End: end, LocationID: iv.locationID}, true, nil
+		}
+	}
+
+	return Slot{}, false, nil
+}
+
+// freeIntervals returns the periods between start and end the tutor is available and has no lessons, by start time.
+func freeIntervals(availability db.Availability, start, end time.Time) ([]interval, error) {
+	available, err := availableIntervals(availability, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var busy []interval
+	for _, lesson := range availability.Lessons {
+		busy = append(busy, interval{
+			start: lesson.LessonDatetime,
+			end:   lesson.LessonDatetime.Add(time.Duration(lesson.Duration) * time.Minute),
+		})
+	}
+	for _, exception := range availability.Exceptions {
+		if !exception.Available {
+			busy = append(busy, interval{start: exception.StartDatetime, end: exception.EndDatetime})
+		}
+	}
+
+	var free []interval
+	for _, iv := range merge(available) {
+		iv = clip(iv, start, end)
+		if !iv.start.Before(iv.end) {
+			continue
+		}
+		free = append(free, subtract(iv, busy)...)
+	}
+
+	sort.SliceStable(free, func(i, j int) bool { return free[i].start.Before(free[j].start) })
+	return free, nil
+}
+
+// availableIntervals returns the periods of the availability windows on the days from start to end,
+// and the available exceptions.
+func availableIntervals(availability db.Availability, start, end time.Time) ([]interval, error) {
+	var available []interval
+
+	for _, window := range availability.Windows {
+		loc, err := time.LoadLocation(window.Timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		// the day before start is included, for windows ending after midnight in the timezone of start
+		from := start.In(loc)
+		day := time.Date(from.Year(), from.Month(), from.Day()-1, 0, 0, 0, 0, loc)
+		for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+			if int32(day.Weekday()) != window.Weekday {
+				continue
+			}
+
+			available = append(available, interval{
+				start:      time.Date(day.Year(), day.Month(), day.Day(), 0, int(window.StartMinute), 0, 0, loc),
+				end:        time.Date(day.Year(), day.Month(), day.Day(), 0, int(window.EndMinute), 0, 0, loc),
+				locationID: window.LocationID,
+			})
+		}
+	}
+
+	for _, exception := range availability.Exceptions {
+		if exception.Available {
+			available = append(available, interval{
+				start:      exception.StartDatetime,
+				end:        exception.EndDatetime,
+				locationID: exception.LocationID.Int64,
+			})
+		}
+	}
+
+	return available, nil
+}
+
+// merge joins the touching and overlapping intervals at the same location, so a slot can cross
+// the boundary between two back-to-back windows.
+func merge(intervals []interval) []interval {
+	sorted := append([]interval(nil), intervals...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].locationID != sorted[j].locationID {
+			return sorted[i].locationID < sorted[j].locationID
+		}
+		return sorted[i].start.Before(sorted[j].start)
+	})
+
+	var merged []interval
+	for _, iv := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && merged[last].locationID == iv.locationID && !iv.start.After(merged[last].end) {
+			if iv.end.After(merged[last].end) {
+				merged[last].end = iv.end
+			}
+			continue
+		}
+		merged = append(merged, iv)
+	}
+
+	return merged
+}
+
+// clip returns the part of iv between start and end.
+func clip(iv interval, start, end time.Time) interval {
+	if iv.start.Before(start) {
+		iv.start = start
+	}
+	if iv.end.After(end) {
+		iv.end = end
+	}
+	return iv
+}
+
+// subtract returns the parts of iv that don't overlap any of the busy periods.
+func subtract(iv interval, busy []interval) []interval {
+	parts := []interval{iv}
+
+	for _, b := range busy {
+		var next []interval
+		for _, p := range parts {
+			if !b.start.Before(p.end) || !b.end.After(p.start) {
+				next = append(next, p)
+				continue
+			}
+
+			if p.start.Before(b.start) {
+				next = append(next, interval{start: p.start, end: b.start, locationID: p.locationID})
+			}
+			if b.end.Before(p.end) {
+				next = append(next, interval{start: b.end, end: p.end, locationID: p.locationID})
+			}
+		}
+		parts = next
+	}
+
+	return parts
+}
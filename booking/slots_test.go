@@ -0,0 +1,157 @@
+package booking
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+// monday is a Monday at midnight UTC
+var monday = time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+
+func slotStarts(slots []Slot) []time.Time {
+	var starts []time.Time
+	for _, slot := range slots {
+		starts = append(starts, slot.Start)
+	}
+	return starts
+}
+
+func at(day time.Time, hour, minute int) time.Time {
+	return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+func TestFreeSlots(t *testing.T) {
+	availability := db.Availability{
+		Windows: []db.AvailabilityWindow{
+			{Weekday: int32(time.Monday), StartMinute: 16 * 60, EndMinute: 18 * 60, Timezone: "UTC", LocationID: 1},
+			{Weekday: int32(time.Wednesday), StartMinute: 10 * 60, EndMinute: 11 * 60, Timezone: "UTC", LocationID: 1},
+		},
+		Lessons: []db.Lesson{
+			{LessonDatetime: at(monday, 16, 30), Duration: 30},
+		},
+	}
+
+	// the lesson is excluded, and the windows of other days are outside the period
+	slots, err := FreeSlots(availability, monday, monday.AddDate(0, 0, 1), 30*time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{at(monday, 16, 0), at(monday, 17, 0), at(monday, 17, 30)}, slotStarts(slots))
+	require.Equal(t, at(monday, 16, 30), slots[0].End)
+	require.Equal(t, int64(1), slots[0].LocationID)
+
+	slots, err = FreeSlots(availability, monday, monday.AddDate(0, 0, 1), time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{at(monday, 17, 0)}, slotStarts(slots))
+
+	// the weekly windows repeat every week
+	slots, err = FreeSlots(availability, monday, monday.AddDate(0, 0, 14), time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{
+		at(monday, 17, 0),
+		at(monday.AddDate(0, 0, 2), 10, 0),
+		at(monday.AddDate(0, 0, 7), 16, 0),
+		at(monday.AddDate(0, 0, 7), 16, 30),
+		at(monday.AddDate(0, 0, 7), 17, 0),
+		at(monday.AddDate(0, 0, 9), 10, 0),
+	}, slotStarts(slots))
+
+	// slots before the start of the period aren't offered
+	slots, err = FreeSlots(availability, at(monday, 17, 10), monday.AddDate(0, 0, 1), 30*time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{at(monday, 17, 30)}, slotStarts(slots))
+}
+
+func TestFreeSlotsExceptions(t *testing.T) {
+	wednesday := monday.AddDate(0, 0, 2)
+
+	availability := db.Availability{
+		Windows: []db.AvailabilityWindow{
+			{Weekday: int32(time.Monday), StartMinute: 16 * 60, EndMinute: 18 * 60, Timezone: "UTC", LocationID: 1},
+		},
+		Exceptions: []db.AvailabilityException{
+			// unavailable in the first hour of the Monday window
+			{StartDatetime: at(monday, 15, 0), EndDatetime: at(monday, 17, 0), Available: false},
+			// available on Wednesday at another location
+			{StartDatetime: at(wednesday, 9, 0), EndDatetime: at(wednesday, 10, 0), Available: true, LocationID: sql.NullInt64{Int64: 2, Valid: true}},
+		},
+	}
+
+	slots, err := FreeSlots(availability, monday, monday.AddDate(0, 0, 7), time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []Slot{
+		{Start: at(monday, 17, 0), End: at(monday, 18, 0), LocationID: 1},
+		{Start: at(wednesday, 9, 0), End: at(wednesday, 10, 0), LocationID: 2},
+	}, slots)
+}
+
+func TestFreeSlotsTimezone(t *testing.T) {
+	availability := db.Availability{
+		Windows: []db.AvailabilityWindow{
+			// 09:00 to 10:00 in Jerusalem is 06:00 to 07:00 UTC in the summer
+			{Weekday: int32(time.Monday), StartMinute: 9 * 60, EndMinute: 10 * 60, Timezone: "Asia/Jerusalem", LocationID: 1},
+		},
+	}
+
+	slots, err := FreeSlots(availability, monday, monday.AddDate(0, 0, 1), time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{at(monday, 6, 0)}, slotStarts(slots))
+
+	availability.Windows[0].Timezone = "Nowhere/Nothing"
+	_, err = FreeSlots(availability, monday, monday.AddDate(0, 0, 1), time.Hour)
+	require.Error(t, err)
+}
+
+func TestIsFree(t *testing.T) {
+	availability := db.Availability{
+		Windows: []db.AvailabilityWindow{
+			{Weekday: int32(time.Monday), StartMinute: 16 * 60, EndMinute: 18 * 60, Timezone: "UTC", LocationID: 3},
+		},
+		Lessons: []db.Lesson{
+			{LessonDatetime: at(monday, 17, 0), Duration: 60},
+		},
+	}
+
+	slot, ok, err := IsFree(availability, at(monday, 16, 15), 45*time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, Slot{Start: at(monday, 16, 15), End: at(monday, 17, 0), LocationID: 3}, slot)
+
+	// overlapping the lesson
+	_, ok, err = IsFree(availability, at(monday, 16, 30), time.Hour)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// outside the window
+	_, ok, err = IsFree(availability, at(monday, 15, 30), time.Hour)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestIsFreeBackToBackWindows(t *testing.T) {
+	availability := db.Availability{
+		Windows: []db.AvailabilityWindow{
+			{Weekday: int32(time.Monday), StartMinute: 17 * 60, EndMinute: 18 * 60, Timezone: "UTC", LocationID: 1},
+			{Weekday: int32(time.Monday), StartMinute: 16 * 60, EndMinute: 17 * 60, Timezone: "UTC", LocationID: 1},
+			{Weekday: int32(time.Monday), StartMinute: 16*60 + 30, EndMinute: 17*60 + 30, Timezone: "UTC", LocationID: 1},
+			{Weekday: int32(time.Monday), StartMinute: 18 * 60, EndMinute: 19 * 60, Timezone: "UTC", LocationID: 2},
+		},
+	}
+
+	// crossing the boundary between the windows at the same location
+	slot, ok, err := IsFree(availability, at(monday, 16, 30), time.Hour)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, Slot{Start: at(monday, 16, 30), End: at(monday, 17, 30), LocationID: 1}, slot)
+
+	slots, err := FreeSlots(availability, monday, monday.AddDate(0, 0, 1), 2*time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{at(monday, 16, 0)}, slotStarts(slots))
+
+	// the windows at different locations aren't merged
+	_, ok, err = IsFree(availability, at(monday, 17, 30), time.Hour)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "invoices_invoice_datetime_idx";
@@ -0,0 +1 @@
+CREATE INDEX ON "invoices" ("invoice_datetime");
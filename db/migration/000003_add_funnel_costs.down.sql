@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "funnel_costs";
+DROP INDEX IF EXISTS "students_created_at_idx";
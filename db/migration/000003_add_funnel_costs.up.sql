@@ -0,0 +1,17 @@
+CREATE TABLE "funnel_costs" (
+  "funnel_cost_id" bigserial PRIMARY KEY,
+  "funnel_id" bigint NOT NULL,
+  "cost_date" date NOT NULL,
+  "amount" float NOT NULL,
+  "notes" text
+);
+
+CREATE INDEX ON "funnel_costs" ("funnel_id");
+
+CREATE INDEX ON "funnel_costs" ("funnel_id", "cost_date");
+
+CREATE INDEX ON "students" ("created_at");
+
+COMMENT ON COLUMN "funnel_costs"."amount" IS 'acquisition cost spent on the funnel';
+
+ALTER TABLE "funnel_costs" ADD FOREIGN KEY ("funnel_id") REFERENCES "funnels" ("funnel_id");
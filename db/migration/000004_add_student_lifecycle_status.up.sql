@@ -0,0 +1,7 @@
+ALTER TABLE "students" ADD COLUMN "lifecycle_status" varchar NOT NULL DEFAULT 'active';
+
+ALTER TABLE "students" ADD CONSTRAINT "students_lifecycle_status_check" CHECK ("lifecycle_status" IN ('active', 'at-risk', 'lapsed'));
+
+CREATE INDEX ON "students" ("lifecycle_status");
+
+COMMENT ON COLUMN "students"."lifecycle_status" IS 'active, at-risk or lapsed, based on the student lessons frequency';
@@ -0,0 +1,30 @@
+CREATE TABLE "email_outbox" (
+  "email_id" bigserial PRIMARY KEY,
+  "template" varchar NOT NULL,
+  "recipient" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "student_id" bigint,
+  "lesson_id" bigint,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_error" text,
+  "send_at" timestamptz NOT NULL DEFAULT (now()),
+  "sent_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "email_outbox" ADD CONSTRAINT "email_outbox_status_check" CHECK ("status" IN ('pending', 'sent', 'failed'));
+
+CREATE INDEX ON "email_outbox" ("status", "send_at");
+
+CREATE INDEX ON "email_outbox" ("student_id");
+
+CREATE INDEX ON "email_outbox" ("lesson_id");
+
+COMMENT ON COLUMN "email_outbox"."payload" IS 'the data the email template is rendered with';
+
+COMMENT ON COLUMN "email_outbox"."send_at" IS 'the email is not sent before send_at, which is also used to delay retries';
+
+ALTER TABLE "email_outbox" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id") ON DELETE SET NULL;
+
+ALTER TABLE "email_outbox" ADD FOREIGN KEY ("lesson_id") REFERENCES "lessons" ("lesson_id") ON DELETE SET NULL;
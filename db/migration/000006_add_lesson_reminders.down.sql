@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "lesson_reminders";
+
+DROP TABLE IF EXISTS "reminder_preferences";
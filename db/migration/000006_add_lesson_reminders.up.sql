@@ -0,0 +1,40 @@
+CREATE TABLE "reminder_preferences" (
+  "student_id" bigint PRIMARY KEY,
+  "channel" varchar NOT NULL DEFAULT 'email',
+  "lead_minutes" int NOT NULL DEFAULT 1440,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "lesson_reminders" (
+  "reminder_id" bigserial PRIMARY KEY,
+  "lesson_id" bigint NOT NULL,
+  "student_id" bigint NOT NULL,
+  "channel" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'sending',
+  "attempts" int NOT NULL DEFAULT 1,
+  "last_error" text,
+  "claimed_at" timestamptz NOT NULL DEFAULT (now()),
+  "sent_at" timestamptz
+);
+
+ALTER TABLE "reminder_preferences" ADD CONSTRAINT "reminder_preferences_channel_check" CHECK ("channel" IN ('none', 'email', 'sms', 'webhook'));
+
+ALTER TABLE "reminder_preferences" ADD CONSTRAINT "reminder_preferences_lead_minutes_check" CHECK ("lead_minutes" BETWEEN 0 AND 10080);
+
+ALTER TABLE "lesson_reminders" ADD CONSTRAINT "lesson_reminders_status_check" CHECK ("status" IN ('sending', 'sent', 'failed'));
+
+CREATE UNIQUE INDEX ON "lesson_reminders" ("lesson_id", "student_id");
+
+CREATE INDEX ON "lesson_reminders" ("student_id");
+
+COMMENT ON COLUMN "reminder_preferences"."channel" IS 'none, email, sms or webhook';
+
+COMMENT ON COLUMN "reminder_preferences"."lead_minutes" IS 'how many minutes before a lesson the reminder is sent, up to a week';
+
+COMMENT ON COLUMN "lesson_reminders"."status" IS 'sending while claimed by a scheduler, then sent or failed';
+
+ALTER TABLE "reminder_preferences" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id") ON DELETE CASCADE;
+
+ALTER TABLE "lesson_reminders" ADD FOREIGN KEY ("lesson_id") REFERENCES "lessons" ("lesson_id") ON DELETE CASCADE;
+
+ALTER TABLE "lesson_reminders" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id") ON DELETE CASCADE;
@@ -0,0 +1,18 @@
+CREATE TABLE "job_runs" (
+  "run_id" bigserial PRIMARY KEY,
+  "job_name" varchar NOT NULL,
+  "trigger" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'running',
+  "scheduled_at" timestamptz NOT NULL,
+  "started_at" timestamptz NOT NULL DEFAULT (now()),
+  "finished_at" timestamptz,
+  "error" text
+);
+
+ALTER TABLE "job_runs" ADD CONSTRAINT "job_runs_trigger_check" CHECK ("trigger" IN ('schedule', 'manual'));
+
+ALTER TABLE "job_runs" ADD CONSTRAINT "job_runs_status_check" CHECK ("status" IN ('running', 'succeeded', 'failed'));
+
+CREATE INDEX ON "job_runs" ("job_name", "started_at");
+
+COMMENT ON COLUMN "job_runs"."scheduled_at" IS 'the time the run was due, or the time it was triggered manually';
@@ -0,0 +1,21 @@
+CREATE TABLE "statements" (
+  "statement_id" bigserial PRIMARY KEY,
+  "student_id" bigint NOT NULL,
+  "period_start" timestamptz NOT NULL,
+  "period_end" timestamptz NOT NULL,
+  "previous_balance" float NOT NULL,
+  "invoiced_amount" float NOT NULL,
+  "payments_amount" float NOT NULL,
+  "total_due" float NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "statements" ("student_id", "period_start");
+
+CREATE INDEX ON "statements" ("period_start");
+
+COMMENT ON COLUMN "statements"."previous_balance" IS 'invoiced minus paid before the period';
+
+COMMENT ON COLUMN "statements"."total_due" IS 'previous balance plus invoiced minus paid in the period';
+
+ALTER TABLE "statements" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "package_deductions";
+
+DROP TABLE IF EXISTS "student_packages";
+
+DROP TABLE IF EXISTS "package_products";
\ No newline at end of file
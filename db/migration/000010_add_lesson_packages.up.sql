@@ -0,0 +1,78 @@
+CREATE TABLE "package_products" (
+  "product_id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "unit" varchar NOT NULL,
+  "quantity" int NOT NULL,
+  "price" float NOT NULL,
+  "validity_days" int,
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "student_packages" (
+  "package_id" bigserial PRIMARY KEY,
+  "student_id" bigint NOT NULL,
+  "product_id" bigint NOT NULL,
+  "receipt_id" bigint NOT NULL,
+  "unit" varchar NOT NULL,
+  "total_units" bigint NOT NULL,
+  "remaining_units" bigint NOT NULL,
+  "price" float NOT NULL,
+  "purchased_at" timestamptz NOT NULL,
+  "expires_at" timestamptz
+);
+
+CREATE TABLE "package_deductions" (
+  "deduction_id" bigserial PRIMARY KEY,
+  "package_id" bigint NOT NULL,
+  "invoice_id" bigint NOT NULL,
+  "units" bigint NOT NULL,
+  "amount" float NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "package_products" ADD CONSTRAINT "package_products_unit_check" CHECK ("unit" IN ('lesson', 'hour'));
+
+ALTER TABLE "package_products" ADD CONSTRAINT "package_products_quantity_check" CHECK ("quantity" > 0);
+
+ALTER TABLE "package_products" ADD CONSTRAINT "package_products_price_check" CHECK ("price" >= 0);
+
+ALTER TABLE "package_products" ADD CONSTRAINT "package_products_validity_days_check" CHECK ("validity_days" > 0);
+
+ALTER TABLE "student_packages" ADD CONSTRAINT "student_packages_remaining_units_check" CHECK ("remaining_units" BETWEEN 0 AND "total_units");
+
+CREATE UNIQUE INDEX ON "student_packages" ("receipt_id");
+
+CREATE INDEX ON "student_packages" ("student_id", "expires_at");
+
+CREATE UNIQUE INDEX ON "package_deductions" ("invoice_id");
+
+CREATE INDEX ON "package_deductions" ("package_id");
+
+COMMENT ON COLUMN "package_products"."unit" IS 'lesson or hour';
+
+COMMENT ON COLUMN "package_products"."quantity" IS 'number of lessons or hours in the package';
+
+COMMENT ON COLUMN "package_products"."validity_days" IS 'days from the purchase until the package expires, or null if it never expires';
+
+COMMENT ON COLUMN "student_packages"."unit" IS 'lesson or hour, copied from the product';
+
+COMMENT ON COLUMN "student_packages"."total_units" IS 'lessons of a lesson package, or minutes of an hour package';
+
+COMMENT ON COLUMN "student_packages"."remaining_units" IS 'lessons or minutes not yet deducted';
+
+COMMENT ON COLUMN "student_packages"."price" IS 'price paid for the package, copied from the product';
+
+COMMENT ON COLUMN "package_deductions"."units" IS 'lessons or minutes deducted for the invoice';
+
+COMMENT ON COLUMN "package_deductions"."amount" IS 'value of the deducted units, charged by the invoice';
+
+ALTER TABLE "student_packages" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id");
+
+ALTER TABLE "student_packages" ADD FOREIGN KEY ("product_id") REFERENCES "package_products" ("product_id");
+
+ALTER TABLE "student_packages" ADD FOREIGN KEY ("receipt_id") REFERENCES "receipts" ("receipt_id");
+
+ALTER TABLE "package_deductions" ADD FOREIGN KEY ("package_id") REFERENCES "student_packages" ("package_id");
+
+ALTER TABLE "package_deductions" ADD FOREIGN KEY ("invoice_id") REFERENCES "invoices" ("invoice_id");
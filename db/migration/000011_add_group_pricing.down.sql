@@ -0,0 +1,5 @@
+ALTER TABLE "lessons" DROP COLUMN IF EXISTS "pricing_plan_id";
+
+DROP TABLE IF EXISTS "group_pricing_tiers";
+
+DROP TABLE IF EXISTS "group_pricing_plans";
\ No newline at end of file
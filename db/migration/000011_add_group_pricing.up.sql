@@ -0,0 +1,36 @@
+CREATE TABLE "group_pricing_plans" (
+  "plan_id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "mode" varchar NOT NULL,
+  "hourly_fee" float NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "group_pricing_tiers" (
+  "plan_id" bigint NOT NULL,
+  "min_students" int NOT NULL,
+  "hourly_fee" float NOT NULL,
+  PRIMARY KEY ("plan_id", "min_students")
+);
+
+ALTER TABLE "lessons" ADD COLUMN "pricing_plan_id" bigint;
+
+ALTER TABLE "group_pricing_plans" ADD CONSTRAINT "group_pricing_plans_mode_check" CHECK ("mode" IN ('per_head', 'split', 'tiered'));
+
+ALTER TABLE "group_pricing_plans" ADD CONSTRAINT "group_pricing_plans_hourly_fee_check" CHECK ("hourly_fee" >= 0);
+
+ALTER TABLE "group_pricing_tiers" ADD CONSTRAINT "group_pricing_tiers_min_students_check" CHECK ("min_students" > 0);
+
+ALTER TABLE "group_pricing_tiers" ADD CONSTRAINT "group_pricing_tiers_hourly_fee_check" CHECK ("hourly_fee" >= 0);
+
+COMMENT ON COLUMN "group_pricing_plans"."mode" IS 'per_head, split or tiered';
+
+COMMENT ON COLUMN "group_pricing_plans"."hourly_fee" IS 'hourly fee of each student for per_head, or of the whole group for split';
+
+COMMENT ON COLUMN "group_pricing_tiers"."hourly_fee" IS 'hourly fee of each student in groups of min_students or more, up to the next tier';
+
+COMMENT ON COLUMN "lessons"."pricing_plan_id" IS 'group pricing plan of the invoices, or null if each invoice is priced by the caller';
+
+ALTER TABLE "group_pricing_tiers" ADD FOREIGN KEY ("plan_id") REFERENCES "group_pricing_plans" ("plan_id") ON DELETE CASCADE;
+
+ALTER TABLE "lessons" ADD FOREIGN KEY ("pricing_plan_id") REFERENCES "group_pricing_plans" ("plan_id");
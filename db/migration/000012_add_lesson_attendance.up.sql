@@ -0,0 +1,23 @@
+CREATE TABLE "lesson_attendance" (
+  "lesson_id" bigint NOT NULL,
+  "student_id" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'attended',
+  "notes" varchar,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("lesson_id", "student_id")
+);
+
+CREATE INDEX ON "lesson_attendance" ("student_id");
+
+ALTER TABLE "lesson_attendance" ADD CONSTRAINT "lesson_attendance_status_check" CHECK ("status" IN ('attended', 'absent', 'excused'));
+
+COMMENT ON TABLE "lesson_attendance" IS 'students of a lesson, whether invoiced or attending for free';
+
+COMMENT ON COLUMN "lesson_attendance"."status" IS 'attended, absent or excused';
+
+ALTER TABLE "lesson_attendance" ADD FOREIGN KEY ("lesson_id") REFERENCES "lessons" ("lesson_id");
+
+ALTER TABLE "lesson_attendance" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id");
+
+INSERT INTO "lesson_attendance" ("lesson_id", "student_id")
+SELECT DISTINCT "lesson_id", "student_id" FROM "invoices";
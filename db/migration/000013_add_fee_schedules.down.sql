@@ -0,0 +1,3 @@
+ALTER TABLE "invoices" DROP COLUMN IF EXISTS "fee_schedule_id";
+
+DROP TABLE IF EXISTS "fee_schedules";
@@ -0,0 +1,27 @@
+CREATE TABLE "fee_schedules" (
+  "fee_schedule_id" bigserial PRIMARY KEY,
+  "student_id" bigint,
+  "subject_id" bigint,
+  "hourly_fee" float NOT NULL,
+  "effective_from" timestamptz NOT NULL,
+  "notes" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "fee_schedules" (COALESCE("student_id", 0), COALESCE("subject_id", 0), "effective_from");
+
+ALTER TABLE "invoices" ADD COLUMN "fee_schedule_id" bigint;
+
+ALTER TABLE "fee_schedules" ADD CONSTRAINT "fee_schedules_hourly_fee_check" CHECK ("hourly_fee" >= 0);
+
+COMMENT ON TABLE "fee_schedules" IS 'hourly fees of a student, a subject, a student in a subject, or of all lessons if both are null';
+
+COMMENT ON COLUMN "fee_schedules"."effective_from" IS 'the fee applies to lessons from this time until the next schedule with the same student and subject';
+
+COMMENT ON COLUMN "invoices"."fee_schedule_id" IS 'fee schedule of the hourly fee of the invoice, or null if the fee was set otherwise';
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id");
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("subject_id") REFERENCES "lesson_subjects" ("subject_id");
+
+ALTER TABLE "invoices" ADD FOREIGN KEY ("fee_schedule_id") REFERENCES "fee_schedules" ("fee_schedule_id");
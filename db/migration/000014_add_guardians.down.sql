@@ -0,0 +1,7 @@
+ALTER TABLE "receipts" DROP COLUMN IF EXISTS "guardian_receipt_id";
+
+DROP TABLE IF EXISTS "guardian_receipts";
+
+DROP TABLE IF EXISTS "student_guardians";
+
+DROP TABLE IF EXISTS "guardians";
@@ -0,0 +1,57 @@
+CREATE TABLE "guardians" (
+  "guardian_id" bigserial PRIMARY KEY,
+  "first_name" varchar NOT NULL,
+  "last_name" varchar NOT NULL,
+  "email" varchar,
+  "phone_number" varchar,
+  "address" varchar,
+  "notes" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "student_guardians" (
+  "student_id" bigint NOT NULL,
+  "guardian_id" bigint NOT NULL,
+  "relationship" varchar NOT NULL,
+  "billing_contact" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("student_id", "guardian_id")
+);
+
+CREATE TABLE "guardian_receipts" (
+  "guardian_receipt_id" bigserial PRIMARY KEY,
+  "guardian_id" bigint NOT NULL,
+  "receipt_datetime" timestamptz NOT NULL,
+  "amount" float NOT NULL,
+  "notes" text
+);
+
+ALTER TABLE "receipts" ADD COLUMN "guardian_receipt_id" bigint;
+
+CREATE INDEX ON "guardians" ("last_name", "first_name");
+
+CREATE INDEX ON "student_guardians" ("guardian_id");
+
+CREATE UNIQUE INDEX ON "student_guardians" ("student_id") WHERE "billing_contact";
+
+CREATE INDEX ON "guardian_receipts" ("guardian_id", "receipt_datetime");
+
+CREATE INDEX ON "receipts" ("guardian_receipt_id");
+
+ALTER TABLE "student_guardians" ADD CONSTRAINT "student_guardians_relationship_check" CHECK ("relationship" IN ('parent', 'guardian', 'grandparent', 'sibling', 'spouse', 'other'));
+
+COMMENT ON COLUMN "student_guardians"."relationship" IS 'parent, guardian, grandparent, sibling, spouse or other';
+
+COMMENT ON COLUMN "student_guardians"."billing_contact" IS 'the invoices, receipts and statements of the student are sent to the billing contact, at most one per student';
+
+COMMENT ON TABLE "guardian_receipts" IS 'a single receipt of a guardian paying for one or more students, allocated to a receipt of each student';
+
+COMMENT ON COLUMN "receipts"."guardian_receipt_id" IS 'guardian receipt the receipt is allocated from, or null if paid by the student';
+
+ALTER TABLE "student_guardians" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id");
+
+ALTER TABLE "student_guardians" ADD FOREIGN KEY ("guardian_id") REFERENCES "guardians" ("guardian_id");
+
+ALTER TABLE "guardian_receipts" ADD FOREIGN KEY ("guardian_id") REFERENCES "guardians" ("guardian_id");
+
+ALTER TABLE "receipts" ADD FOREIGN KEY ("guardian_receipt_id") REFERENCES "guardian_receipts" ("guardian_receipt_id");
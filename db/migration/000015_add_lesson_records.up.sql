@@ -0,0 +1,30 @@
+CREATE TABLE "lesson_records" (
+  "lesson_id" bigint NOT NULL,
+  "student_id" bigint NOT NULL,
+  "topics" text,
+  "homework" text,
+  "homework_status" varchar NOT NULL DEFAULT 'none',
+  "rating" integer,
+  "notes" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("lesson_id", "student_id")
+);
+
+CREATE INDEX ON "lesson_records" ("student_id");
+
+ALTER TABLE "lesson_records" ADD CONSTRAINT "lesson_records_homework_status_check" CHECK ("homework_status" IN ('none', 'assigned', 'partial', 'completed', 'missed'));
+
+ALTER TABLE "lesson_records" ADD CONSTRAINT "lesson_records_rating_check" CHECK ("rating" BETWEEN 1 AND 5);
+
+COMMENT ON TABLE "lesson_records" IS 'progress of a student in a lesson, recorded by the tutor';
+
+COMMENT ON COLUMN "lesson_records"."topics" IS 'topics covered in the lesson';
+
+COMMENT ON COLUMN "lesson_records"."homework" IS 'homework assigned in the lesson';
+
+COMMENT ON COLUMN "lesson_records"."homework_status" IS 'none, assigned, partial, completed or missed';
+
+COMMENT ON COLUMN "lesson_records"."rating" IS 'tutor rating of the student in the lesson, from 1 to 5';
+
+ALTER TABLE "lesson_records" ADD FOREIGN KEY ("lesson_id", "student_id") REFERENCES "lesson_attendance" ("lesson_id", "student_id");
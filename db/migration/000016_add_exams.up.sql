@@ -0,0 +1,32 @@
+CREATE TABLE "exams" (
+  "exam_id" bigserial PRIMARY KEY,
+  "student_id" bigint NOT NULL,
+  "subject_id" bigint NOT NULL,
+  "college_id" bigint,
+  "name" varchar NOT NULL,
+  "exam_datetime" timestamptz NOT NULL,
+  "target_grade" float,
+  "achieved_grade" float,
+  "notes" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "exams" ("exam_datetime");
+
+CREATE INDEX ON "exams" ("student_id", "exam_datetime");
+
+CREATE INDEX ON "exams" ("subject_id", "exam_datetime");
+
+ALTER TABLE "exams" ADD CONSTRAINT "exams_grades_check" CHECK ("target_grade" >= 0 AND "achieved_grade" >= 0);
+
+COMMENT ON TABLE "exams" IS 'exams of students in a lesson subject, with their target and achieved grades';
+
+COMMENT ON COLUMN "exams"."college_id" IS 'college the exam is taken for, the college of the student by default';
+
+COMMENT ON COLUMN "exams"."achieved_grade" IS 'null until the exam is graded';
+
+ALTER TABLE "exams" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id");
+
+ALTER TABLE "exams" ADD FOREIGN KEY ("subject_id") REFERENCES "lesson_subjects" ("subject_id");
+
+ALTER TABLE "exams" ADD FOREIGN KEY ("college_id") REFERENCES "colleges" ("college_id");
@@ -0,0 +1,24 @@
+CREATE TABLE "progress_reports" (
+  "progress_report_id" bigserial PRIMARY KEY,
+  "student_id" bigint NOT NULL,
+  "period_start" timestamptz NOT NULL,
+  "period_end" timestamptz NOT NULL,
+  "lessons_count" bigint NOT NULL,
+  "attended_count" bigint NOT NULL,
+  "hours" float NOT NULL,
+  "homework_assigned_count" bigint NOT NULL,
+  "homework_completed_count" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "progress_reports" ("student_id", "period_start", "period_end");
+
+CREATE INDEX ON "progress_reports" ("period_start");
+
+COMMENT ON COLUMN "progress_reports"."lessons_count" IS 'lessons of the student in the period, attended or not';
+
+COMMENT ON COLUMN "progress_reports"."hours" IS 'hours of the lessons attended in the period';
+
+COMMENT ON COLUMN "progress_reports"."homework_assigned_count" IS 'lessons of the period with homework assigned';
+
+ALTER TABLE "progress_reports" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id");
@@ -0,0 +1,29 @@
+CREATE TABLE "attachments" (
+  "attachment_id" bigserial PRIMARY KEY,
+  "student_id" bigint,
+  "lesson_id" bigint,
+  "filename" varchar NOT NULL,
+  "content_type" varchar NOT NULL,
+  "size" bigint NOT NULL,
+  "storage_key" varchar UNIQUE NOT NULL,
+  "description" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "attachments" ("student_id");
+
+CREATE INDEX ON "attachments" ("lesson_id");
+
+ALTER TABLE "attachments" ADD CONSTRAINT "attachments_owner_check" CHECK (num_nonnulls("student_id", "lesson_id") = 1);
+
+ALTER TABLE "attachments" ADD CONSTRAINT "attachments_size_check" CHECK ("size" >= 0);
+
+COMMENT ON TABLE "attachments" IS 'files attached to a student or a lesson, such as worksheets, exam papers and signed agreements';
+
+COMMENT ON COLUMN "attachments"."filename" IS 'file name of the uploaded file';
+
+COMMENT ON COLUMN "attachments"."storage_key" IS 'key of the file in the blob storage';
+
+ALTER TABLE "attachments" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id");
+
+ALTER TABLE "attachments" ADD FOREIGN KEY ("lesson_id") REFERENCES "lessons" ("lesson_id");
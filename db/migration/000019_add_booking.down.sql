@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "booking_requests";
+
+DROP TABLE IF EXISTS "availability_exceptions";
+
+DROP TABLE IF EXISTS "availability_windows";
@@ -0,0 +1,95 @@
+CREATE TABLE "availability_windows" (
+  "window_id" bigserial PRIMARY KEY,
+  "weekday" int NOT NULL,
+  "start_minute" int NOT NULL,
+  "end_minute" int NOT NULL,
+  "timezone" varchar NOT NULL DEFAULT 'UTC',
+  "location_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "availability_exceptions" (
+  "exception_id" bigserial PRIMARY KEY,
+  "start_datetime" timestamptz NOT NULL,
+  "end_datetime" timestamptz NOT NULL,
+  "available" boolean NOT NULL,
+  "location_id" bigint,
+  "notes" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "booking_requests" (
+  "booking_request_id" bigserial PRIMARY KEY,
+  "first_name" varchar NOT NULL,
+  "last_name" varchar NOT NULL,
+  "email" varchar,
+  "phone_number" varchar,
+  "subject_id" bigint NOT NULL,
+  "location_id" bigint NOT NULL,
+  "lesson_datetime" timestamptz NOT NULL,
+  "duration" bigint NOT NULL,
+  "notes" text,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "student_id" bigint,
+  "lesson_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "decided_at" timestamptz
+);
+
+CREATE INDEX ON "availability_windows" ("weekday");
+
+CREATE INDEX ON "availability_exceptions" ("start_datetime", "end_datetime");
+
+CREATE INDEX ON "booking_requests" ("status", "created_at");
+
+CREATE INDEX ON "booking_requests" ("lesson_datetime");
+
+ALTER TABLE "availability_windows" ADD CONSTRAINT "availability_windows_weekday_check" CHECK ("weekday" BETWEEN 0 AND 6);
+
+ALTER TABLE "availability_windows" ADD CONSTRAINT "availability_windows_minutes_check" CHECK ("start_minute" >= 0 AND "start_minute" < "end_minute" AND "end_minute" <= 1440);
+
+ALTER TABLE "availability_exceptions" ADD CONSTRAINT "availability_exceptions_period_check" CHECK ("start_datetime" < "end_datetime");
+
+ALTER TABLE "availability_exceptions" ADD CONSTRAINT "availability_exceptions_location_check" CHECK (NOT "available" OR "location_id" IS NOT NULL);
+
+ALTER TABLE "booking_requests" ADD CONSTRAINT "booking_requests_status_check" CHECK ("status" IN ('pending', 'approved', 'declined'));
+
+ALTER TABLE "booking_requests" ADD CONSTRAINT "booking_requests_duration_check" CHECK ("duration" > 0);
+
+COMMENT ON TABLE "availability_windows" IS 'weekly template of the times the tutor is available for lessons';
+
+COMMENT ON COLUMN "availability_windows"."weekday" IS '0 for Sunday through 6 for Saturday';
+
+COMMENT ON COLUMN "availability_windows"."start_minute" IS 'minutes after midnight in the timezone of the window';
+
+COMMENT ON COLUMN "availability_windows"."end_minute" IS 'minutes after midnight in the timezone of the window, exclusive';
+
+COMMENT ON COLUMN "availability_windows"."timezone" IS 'IANA timezone of the window, such as Asia/Jerusalem';
+
+COMMENT ON COLUMN "availability_windows"."location_id" IS 'location of the lessons booked in the window';
+
+COMMENT ON TABLE "availability_exceptions" IS 'exceptions to the weekly availability, such as holidays or extra hours';
+
+COMMENT ON COLUMN "availability_exceptions"."available" IS 'true if the tutor is available in the period, false if the tutor is unavailable';
+
+COMMENT ON COLUMN "availability_exceptions"."location_id" IS 'location of the lessons booked in an available period';
+
+COMMENT ON TABLE "booking_requests" IS 'lessons requested by students, approved or declined by the tutor';
+
+COMMENT ON COLUMN "booking_requests"."status" IS 'pending, approved or declined';
+
+COMMENT ON COLUMN "booking_requests"."student_id" IS 'student of the lesson, set when the request is approved';
+
+COMMENT ON COLUMN "booking_requests"."lesson_id" IS 'lesson created when the request is approved';
+
+ALTER TABLE "availability_windows" ADD FOREIGN KEY ("location_id") REFERENCES "lesson_locations" ("location_id");
+
+ALTER TABLE "availability_exceptions" ADD FOREIGN KEY ("location_id") REFERENCES "lesson_locations" ("location_id");
+
+ALTER TABLE "booking_requests" ADD FOREIGN KEY ("subject_id") REFERENCES "lesson_subjects" ("subject_id");
+
+ALTER TABLE "booking_requests" ADD FOREIGN KEY ("location_id") REFERENCES "lesson_locations" ("location_id");
+
+ALTER TABLE "booking_requests" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id");
+
+ALTER TABLE "booking_requests" ADD FOREIGN KEY ("lesson_id") REFERENCES "lessons" ("lesson_id") ON DELETE SET NULL;
@@ -0,0 +1,40 @@
+CREATE TABLE "waitlist_entries" (
+  "waitlist_entry_id" bigserial PRIMARY KEY,
+  "student_id" bigint NOT NULL,
+  "subject_id" bigint NOT NULL,
+  "weekday" int,
+  "start_minute" int,
+  "end_minute" int,
+  "timezone" varchar NOT NULL DEFAULT 'UTC',
+  "status" varchar NOT NULL DEFAULT 'waiting',
+  "notes" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "notified_at" timestamptz,
+  "notified_lesson_datetime" timestamptz
+);
+
+CREATE INDEX ON "waitlist_entries" ("subject_id", "status", "created_at");
+
+CREATE INDEX ON "waitlist_entries" ("student_id");
+
+ALTER TABLE "waitlist_entries" ADD CONSTRAINT "waitlist_entries_status_check" CHECK ("status" IN ('waiting', 'notified', 'withdrawn'));
+
+ALTER TABLE "waitlist_entries" ADD CONSTRAINT "waitlist_entries_weekday_check" CHECK ("weekday" BETWEEN 0 AND 6);
+
+ALTER TABLE "waitlist_entries" ADD CONSTRAINT "waitlist_entries_minutes_check" CHECK (("start_minute" IS NULL AND "end_minute" IS NULL) OR ("start_minute" >= 0 AND "start_minute" < "end_minute" AND "end_minute" <= 1440));
+
+COMMENT ON TABLE "waitlist_entries" IS 'students waiting for a lesson slot in a subject at their preferred days and times';
+
+COMMENT ON COLUMN "waitlist_entries"."weekday" IS 'preferred day, 0 for Sunday through 6 for Saturday, any day if null';
+
+COMMENT ON COLUMN "waitlist_entries"."start_minute" IS 'start of the preferred times, minutes after midnight in the timezone of the entry, any time if null';
+
+COMMENT ON COLUMN "waitlist_entries"."end_minute" IS 'end of the preferred times, minutes after midnight in the timezone of the entry, exclusive';
+
+COMMENT ON COLUMN "waitlist_entries"."status" IS 'waiting, notified or withdrawn';
+
+COMMENT ON COLUMN "waitlist_entries"."notified_lesson_datetime" IS 'datetime of the deleted lesson the student was notified about';
+
+ALTER TABLE "waitlist_entries" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id");
+
+ALTER TABLE "waitlist_entries" ADD FOREIGN KEY ("subject_id") REFERENCES "lesson_subjects" ("subject_id");
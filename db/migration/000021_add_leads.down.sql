@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "tasks";
+
+DROP TABLE IF EXISTS "leads";
+
+DROP TABLE IF EXISTS "pipeline_stages";
@@ -0,0 +1,71 @@
+CREATE TABLE "pipeline_stages" (
+  "stage_id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "position" int NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "leads" (
+  "lead_id" bigserial PRIMARY KEY,
+  "first_name" varchar NOT NULL,
+  "last_name" varchar NOT NULL,
+  "email" varchar,
+  "phone_number" varchar,
+  "funnel_id" bigint,
+  "stage_id" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'open',
+  "student_id" bigint,
+  "notes" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "converted_at" timestamptz
+);
+
+CREATE TABLE "tasks" (
+  "task_id" bigserial PRIMARY KEY,
+  "lead_id" bigint NOT NULL,
+  "title" varchar NOT NULL,
+  "due_at" timestamptz NOT NULL,
+  "completed_at" timestamptz,
+  "notes" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "pipeline_stages" ("position");
+
+CREATE INDEX ON "leads" ("stage_id");
+
+CREATE INDEX ON "leads" ("funnel_id");
+
+CREATE INDEX ON "leads" ("status", "created_at");
+
+CREATE INDEX ON "tasks" ("lead_id");
+
+CREATE INDEX ON "tasks" ("due_at");
+
+ALTER TABLE "leads" ADD CONSTRAINT "leads_status_check" CHECK ("status" IN ('open', 'converted', 'lost'));
+
+ALTER TABLE "leads" ADD CONSTRAINT "leads_converted_check" CHECK (("status" = 'converted') = ("student_id" IS NOT NULL));
+
+COMMENT ON TABLE "pipeline_stages" IS 'configurable stages leads move through, such as contacted or trial lesson';
+
+COMMENT ON COLUMN "pipeline_stages"."position" IS 'order of the stage in the pipeline, lowest first';
+
+COMMENT ON TABLE "leads" IS 'prospective students, before they are converted into students';
+
+COMMENT ON COLUMN "leads"."funnel_id" IS 'funnel the lead came from, carried to the student on conversion';
+
+COMMENT ON COLUMN "leads"."status" IS 'open, converted or lost';
+
+COMMENT ON COLUMN "leads"."student_id" IS 'student created when the lead is converted';
+
+COMMENT ON TABLE "tasks" IS 'follow-up tasks of the tutor';
+
+COMMENT ON COLUMN "tasks"."completed_at" IS 'null until the task is completed';
+
+ALTER TABLE "leads" ADD FOREIGN KEY ("funnel_id") REFERENCES "funnels" ("funnel_id");
+
+ALTER TABLE "leads" ADD FOREIGN KEY ("stage_id") REFERENCES "pipeline_stages" ("stage_id");
+
+ALTER TABLE "leads" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id");
+
+ALTER TABLE "tasks" ADD FOREIGN KEY ("lead_id") REFERENCES "leads" ("lead_id") ON DELETE CASCADE;
@@ -0,0 +1,26 @@
+CREATE TABLE "activities" (
+  "activity_id" bigserial PRIMARY KEY,
+  "student_id" bigint NOT NULL,
+  "activity_type" varchar NOT NULL,
+  "direction" varchar NOT NULL DEFAULT 'outbound',
+  "occurred_at" timestamptz NOT NULL,
+  "summary" varchar NOT NULL,
+  "notes" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "activities" ("student_id", "occurred_at");
+
+ALTER TABLE "activities" ADD CONSTRAINT "activities_activity_type_check" CHECK ("activity_type" IN ('call', 'email', 'meeting', 'whatsapp'));
+
+ALTER TABLE "activities" ADD CONSTRAINT "activities_direction_check" CHECK ("direction" IN ('inbound', 'outbound'));
+
+COMMENT ON TABLE "activities" IS 'interactions of the tutor with students, logged for the student timeline';
+
+COMMENT ON COLUMN "activities"."activity_type" IS 'call, email, meeting or whatsapp';
+
+COMMENT ON COLUMN "activities"."direction" IS 'inbound if the student reached out, outbound if the tutor did';
+
+COMMENT ON COLUMN "activities"."occurred_at" IS 'when the interaction took place, which may be before it was logged';
+
+ALTER TABLE "activities" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id") ON DELETE CASCADE;
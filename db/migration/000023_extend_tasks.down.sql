@@ -0,0 +1,15 @@
+ALTER TABLE "tasks" DROP COLUMN IF EXISTS "recurrence";
+
+ALTER TABLE "tasks" DROP COLUMN IF EXISTS "status";
+
+ALTER TABLE "tasks" DROP COLUMN IF EXISTS "priority";
+
+ALTER TABLE "tasks" DROP COLUMN IF EXISTS "invoice_id";
+
+ALTER TABLE "tasks" DROP COLUMN IF EXISTS "lesson_id";
+
+ALTER TABLE "tasks" DROP COLUMN IF EXISTS "student_id";
+
+DELETE FROM "tasks" WHERE "lead_id" IS NULL;
+
+ALTER TABLE "tasks" ALTER COLUMN "lead_id" SET NOT NULL;
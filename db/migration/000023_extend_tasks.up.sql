@@ -0,0 +1,47 @@
+ALTER TABLE "tasks" ALTER COLUMN "lead_id" DROP NOT NULL;
+
+ALTER TABLE "tasks" ADD COLUMN "student_id" bigint;
+
+ALTER TABLE "tasks" ADD COLUMN "lesson_id" bigint;
+
+ALTER TABLE "tasks" ADD COLUMN "invoice_id" bigint;
+
+ALTER TABLE "tasks" ADD COLUMN "priority" varchar NOT NULL DEFAULT 'normal';
+
+ALTER TABLE "tasks" ADD COLUMN "status" varchar NOT NULL DEFAULT 'open';
+
+ALTER TABLE "tasks" ADD COLUMN "recurrence" varchar NOT NULL DEFAULT 'none';
+
+UPDATE "tasks" SET "status" = 'done' WHERE "completed_at" IS NOT NULL;
+
+CREATE INDEX ON "tasks" ("student_id");
+
+CREATE INDEX ON "tasks" ("lesson_id");
+
+CREATE INDEX ON "tasks" ("invoice_id");
+
+CREATE INDEX ON "tasks" ("status", "due_at");
+
+ALTER TABLE "tasks" ADD CONSTRAINT "tasks_priority_check" CHECK ("priority" IN ('low', 'normal', 'high'));
+
+ALTER TABLE "tasks" ADD CONSTRAINT "tasks_status_check" CHECK ("status" IN ('open', 'in_progress', 'done', 'cancelled'));
+
+ALTER TABLE "tasks" ADD CONSTRAINT "tasks_done_check" CHECK (("status" = 'done') = ("completed_at" IS NOT NULL));
+
+ALTER TABLE "tasks" ADD CONSTRAINT "tasks_recurrence_check" CHECK ("recurrence" IN ('none', 'daily', 'weekly', 'monthly'));
+
+COMMENT ON TABLE "tasks" IS 'to-dos and follow-up tasks of the tutor, optionally of a lead, student, lesson or invoice';
+
+COMMENT ON COLUMN "tasks"."priority" IS 'low, normal or high';
+
+COMMENT ON COLUMN "tasks"."status" IS 'open, in_progress, done or cancelled';
+
+COMMENT ON COLUMN "tasks"."completed_at" IS 'null until the task is done';
+
+COMMENT ON COLUMN "tasks"."recurrence" IS 'none, daily, weekly or monthly. The next task is created when a recurring task is done';
+
+ALTER TABLE "tasks" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id") ON DELETE CASCADE;
+
+ALTER TABLE "tasks" ADD FOREIGN KEY ("lesson_id") REFERENCES "lessons" ("lesson_id") ON DELETE SET NULL;
+
+ALTER TABLE "tasks" ADD FOREIGN KEY ("invoice_id") REFERENCES "invoices" ("invoice_id") ON DELETE SET NULL;
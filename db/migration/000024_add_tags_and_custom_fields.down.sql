@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "student_custom_values";
+
+DROP TABLE IF EXISTS "custom_fields";
+
+DROP TABLE IF EXISTS "student_tags";
+
+DROP TABLE IF EXISTS "tags";
@@ -0,0 +1,58 @@
+CREATE TABLE "tags" (
+  "tag_id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "student_tags" (
+  "student_id" bigint NOT NULL,
+  "tag_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("student_id", "tag_id")
+);
+
+CREATE TABLE "custom_fields" (
+  "field_id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "field_type" varchar NOT NULL,
+  "options" varchar[] NOT NULL DEFAULT '{}',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "student_custom_values" (
+  "student_id" bigint NOT NULL,
+  "field_id" bigint NOT NULL,
+  "value" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("student_id", "field_id")
+);
+
+CREATE UNIQUE INDEX ON "tags" ("name");
+
+CREATE INDEX ON "student_tags" ("tag_id");
+
+CREATE UNIQUE INDEX ON "custom_fields" ("name");
+
+CREATE INDEX ON "student_custom_values" ("field_id", "value");
+
+ALTER TABLE "custom_fields" ADD CONSTRAINT "custom_fields_field_type_check" CHECK ("field_type" IN ('text', 'number', 'date', 'enum'));
+
+ALTER TABLE "custom_fields" ADD CONSTRAINT "custom_fields_options_check" CHECK (("field_type" = 'enum') = (cardinality("options") > 0));
+
+COMMENT ON TABLE "tags" IS 'labels attached to students, such as scholarship or exam year';
+
+COMMENT ON TABLE "custom_fields" IS 'typed fields defined by the tutor, such as grade level or referral source';
+
+COMMENT ON COLUMN "custom_fields"."field_type" IS 'text, number, date or enum';
+
+COMMENT ON COLUMN "custom_fields"."options" IS 'the allowed values of an enum field, empty for other types';
+
+COMMENT ON COLUMN "student_custom_values"."value" IS 'the value of the field, numbers and dates (2006-01-02) are stored in their canonical text form';
+
+ALTER TABLE "student_tags" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id") ON DELETE CASCADE;
+
+ALTER TABLE "student_tags" ADD FOREIGN KEY ("tag_id") REFERENCES "tags" ("tag_id") ON DELETE CASCADE;
+
+ALTER TABLE "student_custom_values" ADD FOREIGN KEY ("student_id") REFERENCES "students" ("student_id") ON DELETE CASCADE;
+
+ALTER TABLE "student_custom_values" ADD FOREIGN KEY ("field_id") REFERENCES "custom_fields" ("field_id") ON DELETE CASCADE;
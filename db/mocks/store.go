@@ -7,6 +7,10 @@ import (
 
 	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
 	mock "github.com/stretchr/testify/mock"
+
+	sql "database/sql"
+
+	time "time"
 )
 
 // MockStore is an autogenerated mock type for the Store type
@@ -14,27 +18,27 @@ type MockStore struct {
 	mock.Mock
 }
 
-// CreateCollege provides a mock function with given fields: ctx, name
-func (_m *MockStore) CreateCollege(ctx context.Context, name string) (db.College, error) {
-	ret := _m.Called(ctx, name)
+// AddLessonStudentTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) AddLessonStudentTx(ctx context.Context, arg db.AddLessonStudentTxParams) (db.LessonWithInvoices, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateCollege")
+		panic("no return value specified for AddLessonStudentTx")
 	}
 
-	var r0 db.College
+	var r0 db.LessonWithInvoices
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (db.College, error)); ok {
-		return rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddLessonStudentTxParams) (db.LessonWithInvoices, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) db.College); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddLessonStudentTxParams) db.LessonWithInvoices); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.College)
+		r0 = ret.Get(0).(db.LessonWithInvoices)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, db.AddLessonStudentTxParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,27 +46,45 @@ func (_m *MockStore) CreateCollege(ctx context.Context, name string) (db.College
 	return r0, r1
 }
 
-// CreateFunnel provides a mock function with given fields: ctx, name
-func (_m *MockStore) CreateFunnel(ctx context.Context, name string) (db.Funnel, error) {
-	ret := _m.Called(ctx, name)
+// AddStudentTag provides a mock function with given fields: ctx, arg
+func (_m *MockStore) AddStudentTag(ctx context.Context, arg db.AddStudentTagParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateFunnel")
+		panic("no return value specified for AddStudentTag")
 	}
 
-	var r0 db.Funnel
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddStudentTagParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ApproveBookingRequest provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ApproveBookingRequest(ctx context.Context, arg db.ApproveBookingRequestParams) (db.BookingRequest, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveBookingRequest")
+	}
+
+	var r0 db.BookingRequest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Funnel, error)); ok {
-		return rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ApproveBookingRequestParams) (db.BookingRequest, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) db.Funnel); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ApproveBookingRequestParams) db.BookingRequest); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Funnel)
+		r0 = ret.Get(0).(db.BookingRequest)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, db.ApproveBookingRequestParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,26 +92,26 @@ func (_m *MockStore) CreateFunnel(ctx context.Context, name string) (db.Funnel,
 	return r0, r1
 }
 
-// CreateInvoice provides a mock function with given fields: ctx, arg
-func (_m *MockStore) CreateInvoice(ctx context.Context, arg db.CreateInvoiceParams) (db.Invoice, error) {
+// ApproveBookingRequestTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ApproveBookingRequestTx(ctx context.Context, arg db.ApproveBookingRequestTxParams) (db.ApprovedBookingRequest, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateInvoice")
+		panic("no return value specified for ApproveBookingRequestTx")
 	}
 
-	var r0 db.Invoice
+	var r0 db.ApprovedBookingRequest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateInvoiceParams) (db.Invoice, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ApproveBookingRequestTxParams) (db.ApprovedBookingRequest, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateInvoiceParams) db.Invoice); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ApproveBookingRequestTxParams) db.ApprovedBookingRequest); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Invoice)
+		r0 = ret.Get(0).(db.ApprovedBookingRequest)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateInvoiceParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.ApproveBookingRequestTxParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -98,26 +120,26 @@ func (_m *MockStore) CreateInvoice(ctx context.Context, arg db.CreateInvoicePara
 	return r0, r1
 }
 
-// CreateLesson provides a mock function with given fields: ctx, arg
-func (_m *MockStore) CreateLesson(ctx context.Context, arg db.CreateLessonParams) (db.Lesson, error) {
+// ClaimLessonReminder provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ClaimLessonReminder(ctx context.Context, arg db.ClaimLessonReminderParams) (db.LessonReminder, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateLesson")
+		panic("no return value specified for ClaimLessonReminder")
 	}
 
-	var r0 db.Lesson
+	var r0 db.LessonReminder
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLessonParams) (db.Lesson, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ClaimLessonReminderParams) (db.LessonReminder, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLessonParams) db.Lesson); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ClaimLessonReminderParams) db.LessonReminder); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Lesson)
+		r0 = ret.Get(0).(db.LessonReminder)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateLessonParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.ClaimLessonReminderParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -126,27 +148,29 @@ func (_m *MockStore) CreateLesson(ctx context.Context, arg db.CreateLessonParams
 	return r0, r1
 }
 
-// CreateLessonLocation provides a mock function with given fields: ctx, name
-func (_m *MockStore) CreateLessonLocation(ctx context.Context, name string) (db.LessonLocation, error) {
-	ret := _m.Called(ctx, name)
+// ClaimOutboxEmails provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ClaimOutboxEmails(ctx context.Context, arg db.ClaimOutboxEmailsParams) ([]db.EmailOutbox, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateLessonLocation")
+		panic("no return value specified for ClaimOutboxEmails")
 	}
 
-	var r0 db.LessonLocation
+	var r0 []db.EmailOutbox
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (db.LessonLocation, error)); ok {
-		return rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ClaimOutboxEmailsParams) ([]db.EmailOutbox, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) db.LessonLocation); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ClaimOutboxEmailsParams) []db.EmailOutbox); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.LessonLocation)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.EmailOutbox)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, db.ClaimOutboxEmailsParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -154,27 +178,29 @@ func (_m *MockStore) CreateLessonLocation(ctx context.Context, name string) (db.
 	return r0, r1
 }
 
-// CreateLessonSubject provides a mock function with given fields: ctx, name
-func (_m *MockStore) CreateLessonSubject(ctx context.Context, name string) (db.LessonSubject, error) {
-	ret := _m.Called(ctx, name)
+// ClaimWebhookDeliveries provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ClaimWebhookDeliveries(ctx context.Context, arg db.ClaimWebhookDeliveriesParams) ([]db.ClaimWebhookDeliveriesRow, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateLessonSubject")
+		panic("no return value specified for ClaimWebhookDeliveries")
 	}
 
-	var r0 db.LessonSubject
+	var r0 []db.ClaimWebhookDeliveriesRow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (db.LessonSubject, error)); ok {
-		return rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ClaimWebhookDeliveriesParams) ([]db.ClaimWebhookDeliveriesRow, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) db.LessonSubject); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ClaimWebhookDeliveriesParams) []db.ClaimWebhookDeliveriesRow); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.LessonSubject)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ClaimWebhookDeliveriesRow)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, db.ClaimWebhookDeliveriesParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -182,26 +208,44 @@ func (_m *MockStore) CreateLessonSubject(ctx context.Context, name string) (db.L
 	return r0, r1
 }
 
-// CreateLessonWithInvoicesTx provides a mock function with given fields: ctx, arg
-func (_m *MockStore) CreateLessonWithInvoicesTx(ctx context.Context, arg db.CreateLessonTxParams) (db.LessonWithInvoices, error) {
+// ClearStudentBillingContact provides a mock function with given fields: ctx, studentID
+func (_m *MockStore) ClearStudentBillingContact(ctx context.Context, studentID int64) error {
+	ret := _m.Called(ctx, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearStudentBillingContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, studentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConvertLead provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ConvertLead(ctx context.Context, arg db.ConvertLeadParams) (db.Lead, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateLessonWithInvoicesTx")
+		panic("no return value specified for ConvertLead")
 	}
 
-	var r0 db.LessonWithInvoices
+	var r0 db.Lead
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLessonTxParams) (db.LessonWithInvoices, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ConvertLeadParams) (db.Lead, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLessonTxParams) db.LessonWithInvoices); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ConvertLeadParams) db.Lead); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.LessonWithInvoices)
+		r0 = ret.Get(0).(db.Lead)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateLessonTxParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.ConvertLeadParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -210,26 +254,26 @@ func (_m *MockStore) CreateLessonWithInvoicesTx(ctx context.Context, arg db.Crea
 	return r0, r1
 }
 
-// CreatePayment provides a mock function with given fields: ctx, arg
-func (_m *MockStore) CreatePayment(ctx context.Context, arg db.CreatePaymentParams) (db.Payment, error) {
+// ConvertLeadTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ConvertLeadTx(ctx context.Context, arg db.ConvertLeadTxParams) (db.ConvertedLead, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreatePayment")
+		panic("no return value specified for ConvertLeadTx")
 	}
 
-	var r0 db.Payment
+	var r0 db.ConvertedLead
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePaymentParams) (db.Payment, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ConvertLeadTxParams) (db.ConvertedLead, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePaymentParams) db.Payment); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ConvertLeadTxParams) db.ConvertedLead); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Payment)
+		r0 = ret.Get(0).(db.ConvertedLead)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreatePaymentParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.ConvertLeadTxParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -238,27 +282,27 @@ func (_m *MockStore) CreatePayment(ctx context.Context, arg db.CreatePaymentPara
 	return r0, r1
 }
 
-// CreatePaymentMethod provides a mock function with given fields: ctx, name
-func (_m *MockStore) CreatePaymentMethod(ctx context.Context, name string) (db.PaymentMethod, error) {
-	ret := _m.Called(ctx, name)
+// CountLessonAttachments provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) CountLessonAttachments(ctx context.Context, lessonID sql.NullInt64) (int64, error) {
+	ret := _m.Called(ctx, lessonID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreatePaymentMethod")
+		panic("no return value specified for CountLessonAttachments")
 	}
 
-	var r0 db.PaymentMethod
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (db.PaymentMethod, error)); ok {
-		return rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullInt64) (int64, error)); ok {
+		return rf(ctx, lessonID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) db.PaymentMethod); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullInt64) int64); ok {
+		r0 = rf(ctx, lessonID)
 	} else {
-		r0 = ret.Get(0).(db.PaymentMethod)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, sql.NullInt64) error); ok {
+		r1 = rf(ctx, lessonID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -266,26 +310,26 @@ func (_m *MockStore) CreatePaymentMethod(ctx context.Context, name string) (db.P
 	return r0, r1
 }
 
-// CreateReceipt provides a mock function with given fields: ctx, arg
-func (_m *MockStore) CreateReceipt(ctx context.Context, arg db.CreateReceiptParams) (db.Receipt, error) {
+// CountNewStudents provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CountNewStudents(ctx context.Context, arg db.CountNewStudentsParams) (int64, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateReceipt")
+		panic("no return value specified for CountNewStudents")
 	}
 
-	var r0 db.Receipt
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReceiptParams) (db.Receipt, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountNewStudentsParams) (int64, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReceiptParams) db.Receipt); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountNewStudentsParams) int64); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Receipt)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateReceiptParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CountNewStudentsParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -294,26 +338,26 @@ func (_m *MockStore) CreateReceipt(ctx context.Context, arg db.CreateReceiptPara
 	return r0, r1
 }
 
-// CreateReceiptWithPaymentsTx provides a mock function with given fields: ctx, arg
-func (_m *MockStore) CreateReceiptWithPaymentsTx(ctx context.Context, arg db.CreateReceiptTxParams) (db.ReceiptWithPayments, error) {
+// CreateActivity provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateActivity(ctx context.Context, arg db.CreateActivityParams) (db.Activity, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateReceiptWithPaymentsTx")
+		panic("no return value specified for CreateActivity")
 	}
 
-	var r0 db.ReceiptWithPayments
+	var r0 db.Activity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReceiptTxParams) (db.ReceiptWithPayments, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateActivityParams) (db.Activity, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReceiptTxParams) db.ReceiptWithPayments); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateActivityParams) db.Activity); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.ReceiptWithPayments)
+		r0 = ret.Get(0).(db.Activity)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateReceiptTxParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateActivityParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -322,26 +366,26 @@ func (_m *MockStore) CreateReceiptWithPaymentsTx(ctx context.Context, arg db.Cre
 	return r0, r1
 }
 
-// CreateStudent provides a mock function with given fields: ctx, arg
-func (_m *MockStore) CreateStudent(ctx context.Context, arg db.CreateStudentParams) (db.Student, error) {
+// CreateAttachment provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateAttachment(ctx context.Context, arg db.CreateAttachmentParams) (db.Attachment, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateStudent")
+		panic("no return value specified for CreateAttachment")
 	}
 
-	var r0 db.Student
+	var r0 db.Attachment
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStudentParams) (db.Student, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAttachmentParams) (db.Attachment, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStudentParams) db.Student); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAttachmentParams) db.Attachment); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Student)
+		r0 = ret.Get(0).(db.Attachment)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateStudentParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateAttachmentParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -350,125 +394,6365 @@ func (_m *MockStore) CreateStudent(ctx context.Context, arg db.CreateStudentPara
 	return r0, r1
 }
 
-// DeleteCollege provides a mock function with given fields: ctx, collegeID
-func (_m *MockStore) DeleteCollege(ctx context.Context, collegeID int64) error {
-	ret := _m.Called(ctx, collegeID)
+// CreateAttachmentTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateAttachmentTx(ctx context.Context, arg db.CreateAttachmentParams) (db.Attachment, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteCollege")
+		panic("no return value specified for CreateAttachmentTx")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, collegeID)
+	var r0 db.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAttachmentParams) (db.Attachment, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAttachmentParams) db.Attachment); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.Attachment)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateAttachmentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteFunnel provides a mock function with given fields: ctx, funnelID
-func (_m *MockStore) DeleteFunnel(ctx context.Context, funnelID int64) error {
-	ret := _m.Called(ctx, funnelID)
+// CreateAvailabilityException provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateAvailabilityException(ctx context.Context, arg db.CreateAvailabilityExceptionParams) (db.AvailabilityException, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFunnel")
+		panic("no return value specified for CreateAvailabilityException")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, funnelID)
+	var r0 db.AvailabilityException
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAvailabilityExceptionParams) (db.AvailabilityException, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAvailabilityExceptionParams) db.AvailabilityException); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.AvailabilityException)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateAvailabilityExceptionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteInvoice provides a mock function with given fields: ctx, invoiceID
-func (_m *MockStore) DeleteInvoice(ctx context.Context, invoiceID int64) error {
-	ret := _m.Called(ctx, invoiceID)
+// CreateAvailabilityWindow provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateAvailabilityWindow(ctx context.Context, arg db.CreateAvailabilityWindowParams) (db.AvailabilityWindow, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteInvoice")
+		panic("no return value specified for CreateAvailabilityWindow")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, invoiceID)
+	var r0 db.AvailabilityWindow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAvailabilityWindowParams) (db.AvailabilityWindow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateAvailabilityWindowParams) db.AvailabilityWindow); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.AvailabilityWindow)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateAvailabilityWindowParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteInvoicesByLesson provides a mock function with given fields: ctx, lessonID
-func (_m *MockStore) DeleteInvoicesByLesson(ctx context.Context, lessonID int64) error {
-	ret := _m.Called(ctx, lessonID)
+// CreateBookingRequest provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateBookingRequest(ctx context.Context, arg db.CreateBookingRequestParams) (db.BookingRequest, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteInvoicesByLesson")
+		panic("no return value specified for CreateBookingRequest")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, lessonID)
+	var r0 db.BookingRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateBookingRequestParams) (db.BookingRequest, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateBookingRequestParams) db.BookingRequest); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.BookingRequest)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateBookingRequestParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteLesson provides a mock function with given fields: ctx, lessonID
-func (_m *MockStore) DeleteLesson(ctx context.Context, lessonID int64) error {
-	ret := _m.Called(ctx, lessonID)
+// CreateCollege provides a mock function with given fields: ctx, name
+func (_m *MockStore) CreateCollege(ctx context.Context, name string) (db.College, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCollege")
+	}
+
+	var r0 db.College
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.College, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.College); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(db.College)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCustomField provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateCustomField(ctx context.Context, arg db.CreateCustomFieldParams) (db.CustomField, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCustomField")
+	}
+
+	var r0 db.CustomField
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCustomFieldParams) (db.CustomField, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCustomFieldParams) db.CustomField); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CustomField)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateCustomFieldParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateExam provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateExam(ctx context.Context, arg db.CreateExamParams) (db.Exam, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateExam")
+	}
+
+	var r0 db.Exam
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateExamParams) (db.Exam, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateExamParams) db.Exam); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Exam)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateExamParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateFeeSchedule provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateFeeSchedule(ctx context.Context, arg db.CreateFeeScheduleParams) (db.FeeSchedule, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFeeSchedule")
+	}
+
+	var r0 db.FeeSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateFeeScheduleParams) (db.FeeSchedule, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateFeeScheduleParams) db.FeeSchedule); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.FeeSchedule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateFeeScheduleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateFunnel provides a mock function with given fields: ctx, name
+func (_m *MockStore) CreateFunnel(ctx context.Context, name string) (db.Funnel, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFunnel")
+	}
+
+	var r0 db.Funnel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Funnel, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.Funnel); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(db.Funnel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateFunnelCost provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateFunnelCost(ctx context.Context, arg db.CreateFunnelCostParams) (db.FunnelCost, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFunnelCost")
+	}
+
+	var r0 db.FunnelCost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateFunnelCostParams) (db.FunnelCost, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateFunnelCostParams) db.FunnelCost); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.FunnelCost)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateFunnelCostParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateGroupLessonTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateGroupLessonTx(ctx context.Context, arg db.CreateGroupLessonTxParams) (db.LessonWithInvoices, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGroupLessonTx")
+	}
+
+	var r0 db.LessonWithInvoices
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGroupLessonTxParams) (db.LessonWithInvoices, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGroupLessonTxParams) db.LessonWithInvoices); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.LessonWithInvoices)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateGroupLessonTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateGroupPricingPlan provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateGroupPricingPlan(ctx context.Context, arg db.CreateGroupPricingPlanParams) (db.GroupPricingPlan, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGroupPricingPlan")
+	}
+
+	var r0 db.GroupPricingPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGroupPricingPlanParams) (db.GroupPricingPlan, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGroupPricingPlanParams) db.GroupPricingPlan); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.GroupPricingPlan)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateGroupPricingPlanParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateGroupPricingPlanTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateGroupPricingPlanTx(ctx context.Context, arg db.GroupPricingPlanTxParams) (db.GroupPricingPlanWithTiers, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGroupPricingPlanTx")
+	}
+
+	var r0 db.GroupPricingPlanWithTiers
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GroupPricingPlanTxParams) (db.GroupPricingPlanWithTiers, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GroupPricingPlanTxParams) db.GroupPricingPlanWithTiers); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.GroupPricingPlanWithTiers)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GroupPricingPlanTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateGroupPricingTier provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateGroupPricingTier(ctx context.Context, arg db.CreateGroupPricingTierParams) (db.GroupPricingTier, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGroupPricingTier")
+	}
+
+	var r0 db.GroupPricingTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGroupPricingTierParams) (db.GroupPricingTier, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGroupPricingTierParams) db.GroupPricingTier); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.GroupPricingTier)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateGroupPricingTierParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateGuardian provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateGuardian(ctx context.Context, arg db.CreateGuardianParams) (db.Guardian, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGuardian")
+	}
+
+	var r0 db.Guardian
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGuardianParams) (db.Guardian, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGuardianParams) db.Guardian); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Guardian)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateGuardianParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateGuardianReceipt provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateGuardianReceipt(ctx context.Context, arg db.CreateGuardianReceiptParams) (db.GuardianReceipt, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGuardianReceipt")
+	}
+
+	var r0 db.GuardianReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGuardianReceiptParams) (db.GuardianReceipt, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGuardianReceiptParams) db.GuardianReceipt); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.GuardianReceipt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateGuardianReceiptParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateGuardianReceiptTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateGuardianReceiptTx(ctx context.Context, arg db.CreateGuardianReceiptTxParams) (db.GuardianReceiptWithReceipts, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGuardianReceiptTx")
+	}
+
+	var r0 db.GuardianReceiptWithReceipts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGuardianReceiptTxParams) (db.GuardianReceiptWithReceipts, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateGuardianReceiptTxParams) db.GuardianReceiptWithReceipts); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.GuardianReceiptWithReceipts)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateGuardianReceiptTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateInvoice provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateInvoice(ctx context.Context, arg db.CreateInvoiceParams) (db.Invoice, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvoice")
+	}
+
+	var r0 db.Invoice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateInvoiceParams) (db.Invoice, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateInvoiceParams) db.Invoice); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Invoice)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateInvoiceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateJobRun provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateJobRun(ctx context.Context, arg db.CreateJobRunParams) (db.JobRun, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateJobRun")
+	}
+
+	var r0 db.JobRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateJobRunParams) (db.JobRun, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateJobRunParams) db.JobRun); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.JobRun)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateJobRunParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLead provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateLead(ctx context.Context, arg db.CreateLeadParams) (db.Lead, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLead")
+	}
+
+	var r0 db.Lead
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLeadParams) (db.Lead, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLeadParams) db.Lead); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Lead)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateLeadParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLesson provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateLesson(ctx context.Context, arg db.CreateLessonParams) (db.Lesson, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLesson")
+	}
+
+	var r0 db.Lesson
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLessonParams) (db.Lesson, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLessonParams) db.Lesson); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Lesson)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateLessonParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLessonAttendance provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateLessonAttendance(ctx context.Context, arg db.CreateLessonAttendanceParams) (db.LessonAttendance, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLessonAttendance")
+	}
+
+	var r0 db.LessonAttendance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLessonAttendanceParams) (db.LessonAttendance, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLessonAttendanceParams) db.LessonAttendance); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.LessonAttendance)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateLessonAttendanceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLessonLocation provides a mock function with given fields: ctx, name
+func (_m *MockStore) CreateLessonLocation(ctx context.Context, name string) (db.LessonLocation, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLessonLocation")
+	}
+
+	var r0 db.LessonLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.LessonLocation, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.LessonLocation); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(db.LessonLocation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLessonSubject provides a mock function with given fields: ctx, name
+func (_m *MockStore) CreateLessonSubject(ctx context.Context, name string) (db.LessonSubject, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLessonSubject")
+	}
+
+	var r0 db.LessonSubject
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.LessonSubject, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.LessonSubject); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(db.LessonSubject)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLessonWithInvoicesTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateLessonWithInvoicesTx(ctx context.Context, arg db.CreateLessonTxParams) (db.LessonWithInvoices, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLessonWithInvoicesTx")
+	}
+
+	var r0 db.LessonWithInvoices
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLessonTxParams) (db.LessonWithInvoices, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateLessonTxParams) db.LessonWithInvoices); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.LessonWithInvoices)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateLessonTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateMonthlyStatementsTx provides a mock function with given fields: ctx, month, studentID
+func (_m *MockStore) CreateMonthlyStatementsTx(ctx context.Context, month time.Time, studentID sql.NullInt64) ([]db.Statement, error) {
+	ret := _m.Called(ctx, month, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMonthlyStatementsTx")
+	}
+
+	var r0 []db.Statement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, sql.NullInt64) ([]db.Statement, error)); ok {
+		return rf(ctx, month, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, sql.NullInt64) []db.Statement); ok {
+		r0 = rf(ctx, month, studentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Statement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, sql.NullInt64) error); ok {
+		r1 = rf(ctx, month, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateOutboxEmail provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateOutboxEmail(ctx context.Context, arg db.CreateOutboxEmailParams) (db.EmailOutbox, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOutboxEmail")
+	}
+
+	var r0 db.EmailOutbox
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateOutboxEmailParams) (db.EmailOutbox, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateOutboxEmailParams) db.EmailOutbox); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.EmailOutbox)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateOutboxEmailParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePackageDeduction provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreatePackageDeduction(ctx context.Context, arg db.CreatePackageDeductionParams) (db.PackageDeduction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePackageDeduction")
+	}
+
+	var r0 db.PackageDeduction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePackageDeductionParams) (db.PackageDeduction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePackageDeductionParams) db.PackageDeduction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.PackageDeduction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreatePackageDeductionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePackageProduct provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreatePackageProduct(ctx context.Context, arg db.CreatePackageProductParams) (db.PackageProduct, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePackageProduct")
+	}
+
+	var r0 db.PackageProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePackageProductParams) (db.PackageProduct, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePackageProductParams) db.PackageProduct); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.PackageProduct)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreatePackageProductParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePayment provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreatePayment(ctx context.Context, arg db.CreatePaymentParams) (db.Payment, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePayment")
+	}
+
+	var r0 db.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePaymentParams) (db.Payment, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePaymentParams) db.Payment); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Payment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreatePaymentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePaymentMethod provides a mock function with given fields: ctx, name
+func (_m *MockStore) CreatePaymentMethod(ctx context.Context, name string) (db.PaymentMethod, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePaymentMethod")
+	}
+
+	var r0 db.PaymentMethod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.PaymentMethod, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.PaymentMethod); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(db.PaymentMethod)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePipelineStage provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreatePipelineStage(ctx context.Context, arg db.CreatePipelineStageParams) (db.PipelineStage, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePipelineStage")
+	}
+
+	var r0 db.PipelineStage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePipelineStageParams) (db.PipelineStage, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePipelineStageParams) db.PipelineStage); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.PipelineStage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreatePipelineStageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateProgressReports provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateProgressReports(ctx context.Context, arg db.CreateProgressReportsParams) ([]db.ProgressReport, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateProgressReports")
+	}
+
+	var r0 []db.ProgressReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateProgressReportsParams) ([]db.ProgressReport, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateProgressReportsParams) []db.ProgressReport); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ProgressReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateProgressReportsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateProgressReportsTx provides a mock function with given fields: ctx, start, end, studentID
+func (_m *MockStore) CreateProgressReportsTx(ctx context.Context, start time.Time, end time.Time, studentID sql.NullInt64) ([]db.ProgressReport, error) {
+	ret := _m.Called(ctx, start, end, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateProgressReportsTx")
+	}
+
+	var r0 []db.ProgressReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, sql.NullInt64) ([]db.ProgressReport, error)); ok {
+		return rf(ctx, start, end, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, sql.NullInt64) []db.ProgressReport); ok {
+		r0 = rf(ctx, start, end, studentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ProgressReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, sql.NullInt64) error); ok {
+		r1 = rf(ctx, start, end, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReceipt provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateReceipt(ctx context.Context, arg db.CreateReceiptParams) (db.Receipt, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReceipt")
+	}
+
+	var r0 db.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReceiptParams) (db.Receipt, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReceiptParams) db.Receipt); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Receipt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateReceiptParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReceiptWithPaymentsTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateReceiptWithPaymentsTx(ctx context.Context, arg db.CreateReceiptTxParams) (db.ReceiptWithPayments, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReceiptWithPaymentsTx")
+	}
+
+	var r0 db.ReceiptWithPayments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReceiptTxParams) (db.ReceiptWithPayments, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReceiptTxParams) db.ReceiptWithPayments); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.ReceiptWithPayments)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateReceiptTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateStatements provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateStatements(ctx context.Context, arg db.CreateStatementsParams) ([]db.Statement, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStatements")
+	}
+
+	var r0 []db.Statement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStatementsParams) ([]db.Statement, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStatementsParams) []db.Statement); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Statement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateStatementsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateStudent provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateStudent(ctx context.Context, arg db.CreateStudentParams) (db.Student, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStudent")
+	}
+
+	var r0 db.Student
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStudentParams) (db.Student, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStudentParams) db.Student); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Student)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateStudentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateStudentPackage provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateStudentPackage(ctx context.Context, arg db.CreateStudentPackageParams) (db.StudentPackage, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStudentPackage")
+	}
+
+	var r0 db.StudentPackage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStudentPackageParams) (db.StudentPackage, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStudentPackageParams) db.StudentPackage); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StudentPackage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateStudentPackageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateStudentTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateStudentTx(ctx context.Context, arg db.CreateStudentParams) (db.Student, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStudentTx")
+	}
+
+	var r0 db.Student
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStudentParams) (db.Student, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStudentParams) db.Student); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Student)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateStudentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTag provides a mock function with given fields: ctx, name
+func (_m *MockStore) CreateTag(ctx context.Context, name string) (db.Tag, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTag")
+	}
+
+	var r0 db.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Tag, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.Tag); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(db.Tag)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTask provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateTask(ctx context.Context, arg db.CreateTaskParams) (db.Task, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTask")
+	}
+
+	var r0 db.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTaskParams) (db.Task, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateTaskParams) db.Task); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateTaskParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWaitlistEntry provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateWaitlistEntry(ctx context.Context, arg db.CreateWaitlistEntryParams) (db.WaitlistEntry, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWaitlistEntry")
+	}
+
+	var r0 db.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateWaitlistEntryParams) (db.WaitlistEntry, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateWaitlistEntryParams) db.WaitlistEntry); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.WaitlistEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateWaitlistEntryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWebhookDeliveries provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateWebhookDeliveries(ctx context.Context, arg db.CreateWebhookDeliveriesParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhookDeliveries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateWebhookDeliveriesParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateWebhookSubscription provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateWebhookSubscription(ctx context.Context, arg db.CreateWebhookSubscriptionParams) (db.WebhookSubscription, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhookSubscription")
+	}
+
+	var r0 db.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateWebhookSubscriptionParams) (db.WebhookSubscription, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateWebhookSubscriptionParams) db.WebhookSubscription); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.WebhookSubscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateWebhookSubscriptionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeclineBookingRequest provides a mock function with given fields: ctx, bookingRequestID
+func (_m *MockStore) DeclineBookingRequest(ctx context.Context, bookingRequestID int64) (db.BookingRequest, error) {
+	ret := _m.Called(ctx, bookingRequestID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeclineBookingRequest")
+	}
+
+	var r0 db.BookingRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.BookingRequest, error)); ok {
+		return rf(ctx, bookingRequestID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.BookingRequest); ok {
+		r0 = rf(ctx, bookingRequestID)
+	} else {
+		r0 = ret.Get(0).(db.BookingRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, bookingRequestID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeductStudentPackage provides a mock function with given fields: ctx, arg
+func (_m *MockStore) DeductStudentPackage(ctx context.Context, arg db.DeductStudentPackageParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeductStudentPackage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeductStudentPackageParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteActivity provides a mock function with given fields: ctx, activityID
+func (_m *MockStore) DeleteActivity(ctx context.Context, activityID int64) (int64, error) {
+	ret := _m.Called(ctx, activityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteActivity")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, activityID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, activityID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, activityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAttachment provides a mock function with given fields: ctx, attachmentID
+func (_m *MockStore) DeleteAttachment(ctx context.Context, attachmentID int64) (int64, error) {
+	ret := _m.Called(ctx, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAttachment")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, attachmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, attachmentID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, attachmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAvailabilityException provides a mock function with given fields: ctx, exceptionID
+func (_m *MockStore) DeleteAvailabilityException(ctx context.Context, exceptionID int64) (int64, error) {
+	ret := _m.Called(ctx, exceptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAvailabilityException")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, exceptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, exceptionID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, exceptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAvailabilityWindow provides a mock function with given fields: ctx, windowID
+func (_m *MockStore) DeleteAvailabilityWindow(ctx context.Context, windowID int64) (int64, error) {
+	ret := _m.Called(ctx, windowID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAvailabilityWindow")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, windowID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, windowID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, windowID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteCollege provides a mock function with given fields: ctx, collegeID
+func (_m *MockStore) DeleteCollege(ctx context.Context, collegeID int64) error {
+	ret := _m.Called(ctx, collegeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCollege")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, collegeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCustomField provides a mock function with given fields: ctx, fieldID
+func (_m *MockStore) DeleteCustomField(ctx context.Context, fieldID int64) (int64, error) {
+	ret := _m.Called(ctx, fieldID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCustomField")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, fieldID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, fieldID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, fieldID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteExam provides a mock function with given fields: ctx, examID
+func (_m *MockStore) DeleteExam(ctx context.Context, examID int64) (int64, error) {
+	ret := _m.Called(ctx, examID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExam")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, examID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, examID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, examID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteFunnel provides a mock function with given fields: ctx, funnelID
+func (_m *MockStore) DeleteFunnel(ctx context.Context, funnelID int64) error {
+	ret := _m.Called(ctx, funnelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFunnel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, funnelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFunnelCost provides a mock function with given fields: ctx, funnelCostID
+func (_m *MockStore) DeleteFunnelCost(ctx context.Context, funnelCostID int64) error {
+	ret := _m.Called(ctx, funnelCostID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFunnelCost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, funnelCostID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteGroupPricingTiers provides a mock function with given fields: ctx, planID
+func (_m *MockStore) DeleteGroupPricingTiers(ctx context.Context, planID int64) error {
+	ret := _m.Called(ctx, planID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroupPricingTiers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, planID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteGuardianReceipt provides a mock function with given fields: ctx, guardianReceiptID
+func (_m *MockStore) DeleteGuardianReceipt(ctx context.Context, guardianReceiptID int64) error {
+	ret := _m.Called(ctx, guardianReceiptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGuardianReceipt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, guardianReceiptID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteGuardianReceiptTx provides a mock function with given fields: ctx, guardianReceiptID
+func (_m *MockStore) DeleteGuardianReceiptTx(ctx context.Context, guardianReceiptID int64) error {
+	ret := _m.Called(ctx, guardianReceiptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGuardianReceiptTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, guardianReceiptID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteInvoice provides a mock function with given fields: ctx, invoiceID
+func (_m *MockStore) DeleteInvoice(ctx context.Context, invoiceID int64) error {
+	ret := _m.Called(ctx, invoiceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInvoice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, invoiceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteInvoicesByLesson provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) DeleteInvoicesByLesson(ctx context.Context, lessonID int64) error {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInvoicesByLesson")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteJobRunsBefore provides a mock function with given fields: ctx, startedAt
+func (_m *MockStore) DeleteJobRunsBefore(ctx context.Context, startedAt time.Time) (int64, error) {
+	ret := _m.Called(ctx, startedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteJobRunsBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, startedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, startedAt)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, startedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLead provides a mock function with given fields: ctx, leadID
+func (_m *MockStore) DeleteLead(ctx context.Context, leadID int64) (int64, error) {
+	ret := _m.Called(ctx, leadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLead")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, leadID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, leadID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, leadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLesson provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) DeleteLesson(ctx context.Context, lessonID int64) error {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLesson")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLessonAttendance provides a mock function with given fields: ctx, arg
+func (_m *MockStore) DeleteLessonAttendance(ctx context.Context, arg db.DeleteLessonAttendanceParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLessonAttendance")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteLessonAttendanceParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteLessonAttendanceParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeleteLessonAttendanceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLessonAttendanceByLesson provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) DeleteLessonAttendanceByLesson(ctx context.Context, lessonID int64) error {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLessonAttendanceByLesson")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLessonLocation provides a mock function with given fields: ctx, locationID
+func (_m *MockStore) DeleteLessonLocation(ctx context.Context, locationID int64) error {
+	ret := _m.Called(ctx, locationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLessonLocation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, locationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLessonRecord provides a mock function with given fields: ctx, arg
+func (_m *MockStore) DeleteLessonRecord(ctx context.Context, arg db.DeleteLessonRecordParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLessonRecord")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteLessonRecordParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLessonRecordsByLesson provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) DeleteLessonRecordsByLesson(ctx context.Context, lessonID int64) error {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLessonRecordsByLesson")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLessonSubject provides a mock function with given fields: ctx, subjectID
+func (_m *MockStore) DeleteLessonSubject(ctx context.Context, subjectID int64) error {
+	ret := _m.Called(ctx, subjectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLessonSubject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, subjectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLessonWithInvoicesTx provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) DeleteLessonWithInvoicesTx(ctx context.Context, lessonID int64) error {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLessonWithInvoicesTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePackageDeduction provides a mock function with given fields: ctx, deductionID
+func (_m *MockStore) DeletePackageDeduction(ctx context.Context, deductionID int64) error {
+	ret := _m.Called(ctx, deductionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePackageDeduction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, deductionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePackageDeductionsByLesson provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) DeletePackageDeductionsByLesson(ctx context.Context, lessonID int64) error {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePackageDeductionsByLesson")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePayment provides a mock function with given fields: ctx, paymentID
+func (_m *MockStore) DeletePayment(ctx context.Context, paymentID int64) error {
+	ret := _m.Called(ctx, paymentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, paymentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePaymentMethod provides a mock function with given fields: ctx, paymentMethodID
+func (_m *MockStore) DeletePaymentMethod(ctx context.Context, paymentMethodID int64) error {
+	ret := _m.Called(ctx, paymentMethodID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePaymentMethod")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, paymentMethodID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePaymentsByReceipt provides a mock function with given fields: ctx, receiptID
+func (_m *MockStore) DeletePaymentsByReceipt(ctx context.Context, receiptID int64) error {
+	ret := _m.Called(ctx, receiptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePaymentsByReceipt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, receiptID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePendingOutboxEmailsByLesson provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) DeletePendingOutboxEmailsByLesson(ctx context.Context, lessonID sql.NullInt64) error {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePendingOutboxEmailsByLesson")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullInt64) error); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePipelineStage provides a mock function with given fields: ctx, stageID
+func (_m *MockStore) DeletePipelineStage(ctx context.Context, stageID int64) (int64, error) {
+	ret := _m.Called(ctx, stageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePipelineStage")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, stageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, stageID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, stageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteReceipt provides a mock function with given fields: ctx, receiptID
+func (_m *MockStore) DeleteReceipt(ctx context.Context, receiptID int64) error {
+	ret := _m.Called(ctx, receiptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReceipt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, receiptID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteReceiptWithPaymentsTx provides a mock function with given fields: ctx, receiptID
+func (_m *MockStore) DeleteReceiptWithPaymentsTx(ctx context.Context, receiptID int64) error {
+	ret := _m.Called(ctx, receiptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReceiptWithPaymentsTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, receiptID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteStudent provides a mock function with given fields: ctx, studentID
+func (_m *MockStore) DeleteStudent(ctx context.Context, studentID int64) error {
+	ret := _m.Called(ctx, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStudent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, studentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteStudentCustomValue provides a mock function with given fields: ctx, arg
+func (_m *MockStore) DeleteStudentCustomValue(ctx context.Context, arg db.DeleteStudentCustomValueParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStudentCustomValue")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteStudentCustomValueParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteStudentCustomValueParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeleteStudentCustomValueParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteStudentGuardian provides a mock function with given fields: ctx, arg
+func (_m *MockStore) DeleteStudentGuardian(ctx context.Context, arg db.DeleteStudentGuardianParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStudentGuardian")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteStudentGuardianParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteStudentGuardianParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeleteStudentGuardianParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTag provides a mock function with given fields: ctx, tagID
+func (_m *MockStore) DeleteTag(ctx context.Context, tagID int64) (int64, error) {
+	ret := _m.Called(ctx, tagID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, tagID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, tagID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, tagID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTask provides a mock function with given fields: ctx, taskID
+func (_m *MockStore) DeleteTask(ctx context.Context, taskID int64) (int64, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTask")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWaitlistEntry provides a mock function with given fields: ctx, waitlistEntryID
+func (_m *MockStore) DeleteWaitlistEntry(ctx context.Context, waitlistEntryID int64) (int64, error) {
+	ret := _m.Called(ctx, waitlistEntryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWaitlistEntry")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, waitlistEntryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, waitlistEntryID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, waitlistEntryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnqueueTaskDigestTx provides a mock function with given fields: ctx, recipient, now
+func (_m *MockStore) EnqueueTaskDigestTx(ctx context.Context, recipient string, now time.Time) (db.TaskDigest, error) {
+	ret := _m.Called(ctx, recipient, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueTaskDigestTx")
+	}
+
+	var r0 db.TaskDigest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (db.TaskDigest, error)); ok {
+		return rf(ctx, recipient, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) db.TaskDigest); ok {
+		r0 = rf(ctx, recipient, now)
+	} else {
+		r0 = ret.Get(0).(db.TaskDigest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, recipient, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportStudents provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ExportStudents(ctx context.Context, arg db.ExportStudentsParams) ([]db.Student, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportStudents")
+	}
+
+	var r0 []db.Student
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ExportStudentsParams) ([]db.Student, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ExportStudentsParams) []db.Student); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Student)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ExportStudentsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportStudentsTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ExportStudentsTx(ctx context.Context, arg db.ExportStudentsParams) (db.StudentExport, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportStudentsTx")
+	}
+
+	var r0 db.StudentExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ExportStudentsParams) (db.StudentExport, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ExportStudentsParams) db.StudentExport); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StudentExport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ExportStudentsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FinishJobRun provides a mock function with given fields: ctx, arg
+func (_m *MockStore) FinishJobRun(ctx context.Context, arg db.FinishJobRunParams) (db.JobRun, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishJobRun")
+	}
+
+	var r0 db.JobRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.FinishJobRunParams) (db.JobRun, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.FinishJobRunParams) db.JobRun); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.JobRun)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.FinishJobRunParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveStudentPackages provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetActiveStudentPackages(ctx context.Context, arg db.GetActiveStudentPackagesParams) ([]db.StudentPackage, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveStudentPackages")
+	}
+
+	var r0 []db.StudentPackage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetActiveStudentPackagesParams) ([]db.StudentPackage, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetActiveStudentPackagesParams) []db.StudentPackage); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StudentPackage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetActiveStudentPackagesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActivity provides a mock function with given fields: ctx, activityID
+func (_m *MockStore) GetActivity(ctx context.Context, activityID int64) (db.Activity, error) {
+	ret := _m.Called(ctx, activityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActivity")
+	}
+
+	var r0 db.Activity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Activity, error)); ok {
+		return rf(ctx, activityID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Activity); ok {
+		r0 = rf(ctx, activityID)
+	} else {
+		r0 = ret.Get(0).(db.Activity)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, activityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAgingReport provides a mock function with given fields: ctx, asOfDatetime
+func (_m *MockStore) GetAgingReport(ctx context.Context, asOfDatetime time.Time) ([]db.GetAgingReportRow, error) {
+	ret := _m.Called(ctx, asOfDatetime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAgingReport")
+	}
+
+	var r0 []db.GetAgingReportRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.GetAgingReportRow, error)); ok {
+		return rf(ctx, asOfDatetime)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.GetAgingReportRow); ok {
+		r0 = rf(ctx, asOfDatetime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetAgingReportRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, asOfDatetime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetApplicableFeeSchedule provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetApplicableFeeSchedule(ctx context.Context, arg db.GetApplicableFeeScheduleParams) (db.FeeSchedule, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApplicableFeeSchedule")
+	}
+
+	var r0 db.FeeSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetApplicableFeeScheduleParams) (db.FeeSchedule, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetApplicableFeeScheduleParams) db.FeeSchedule); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.FeeSchedule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetApplicableFeeScheduleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAttachment provides a mock function with given fields: ctx, attachmentID
+func (_m *MockStore) GetAttachment(ctx context.Context, attachmentID int64) (db.Attachment, error) {
+	ret := _m.Called(ctx, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttachment")
+	}
+
+	var r0 db.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Attachment, error)); ok {
+		return rf(ctx, attachmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Attachment); ok {
+		r0 = rf(ctx, attachmentID)
+	} else {
+		r0 = ret.Get(0).(db.Attachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, attachmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAvailabilityException provides a mock function with given fields: ctx, exceptionID
+func (_m *MockStore) GetAvailabilityException(ctx context.Context, exceptionID int64) (db.AvailabilityException, error) {
+	ret := _m.Called(ctx, exceptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAvailabilityException")
+	}
+
+	var r0 db.AvailabilityException
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.AvailabilityException, error)); ok {
+		return rf(ctx, exceptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.AvailabilityException); ok {
+		r0 = rf(ctx, exceptionID)
+	} else {
+		r0 = ret.Get(0).(db.AvailabilityException)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, exceptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAvailabilityTx provides a mock function with given fields: ctx, start, end
+func (_m *MockStore) GetAvailabilityTx(ctx context.Context, start time.Time, end time.Time) (db.Availability, error) {
+	ret := _m.Called(ctx, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAvailabilityTx")
+	}
+
+	var r0 db.Availability
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (db.Availability, error)); ok {
+		return rf(ctx, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) db.Availability); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		r0 = ret.Get(0).(db.Availability)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAvailabilityWindow provides a mock function with given fields: ctx, windowID
+func (_m *MockStore) GetAvailabilityWindow(ctx context.Context, windowID int64) (db.AvailabilityWindow, error) {
+	ret := _m.Called(ctx, windowID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAvailabilityWindow")
+	}
+
+	var r0 db.AvailabilityWindow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.AvailabilityWindow, error)); ok {
+		return rf(ctx, windowID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.AvailabilityWindow); ok {
+		r0 = rf(ctx, windowID)
+	} else {
+		r0 = ret.Get(0).(db.AvailabilityWindow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, windowID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBillingContact provides a mock function with given fields: ctx, studentID
+func (_m *MockStore) GetBillingContact(ctx context.Context, studentID int64) (db.Guardian, error) {
+	ret := _m.Called(ctx, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBillingContact")
+	}
+
+	var r0 db.Guardian
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Guardian, error)); ok {
+		return rf(ctx, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Guardian); ok {
+		r0 = rf(ctx, studentID)
+	} else {
+		r0 = ret.Get(0).(db.Guardian)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookingRequest provides a mock function with given fields: ctx, bookingRequestID
+func (_m *MockStore) GetBookingRequest(ctx context.Context, bookingRequestID int64) (db.BookingRequest, error) {
+	ret := _m.Called(ctx, bookingRequestID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookingRequest")
+	}
+
+	var r0 db.BookingRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.BookingRequest, error)); ok {
+		return rf(ctx, bookingRequestID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.BookingRequest); ok {
+		r0 = rf(ctx, bookingRequestID)
+	} else {
+		r0 = ret.Get(0).(db.BookingRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, bookingRequestID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookingRequestForUpdate provides a mock function with given fields: ctx, bookingRequestID
+func (_m *MockStore) GetBookingRequestForUpdate(ctx context.Context, bookingRequestID int64) (db.BookingRequest, error) {
+	ret := _m.Called(ctx, bookingRequestID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookingRequestForUpdate")
+	}
+
+	var r0 db.BookingRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.BookingRequest, error)); ok {
+		return rf(ctx, bookingRequestID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.BookingRequest); ok {
+		r0 = rf(ctx, bookingRequestID)
+	} else {
+		r0 = ret.Get(0).(db.BookingRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, bookingRequestID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCollege provides a mock function with given fields: ctx, collegeID
+func (_m *MockStore) GetCollege(ctx context.Context, collegeID int64) (db.College, error) {
+	ret := _m.Called(ctx, collegeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCollege")
+	}
+
+	var r0 db.College
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.College, error)); ok {
+		return rf(ctx, collegeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.College); ok {
+		r0 = rf(ctx, collegeID)
+	} else {
+		r0 = ret.Get(0).(db.College)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, collegeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCollegeRevenueReport provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetCollegeRevenueReport(ctx context.Context, arg db.GetCollegeRevenueReportParams) ([]db.GetCollegeRevenueReportRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCollegeRevenueReport")
+	}
+
+	var r0 []db.GetCollegeRevenueReportRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetCollegeRevenueReportParams) ([]db.GetCollegeRevenueReportRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetCollegeRevenueReportParams) []db.GetCollegeRevenueReportRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetCollegeRevenueReportRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetCollegeRevenueReportParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCustomField provides a mock function with given fields: ctx, fieldID
+func (_m *MockStore) GetCustomField(ctx context.Context, fieldID int64) (db.CustomField, error) {
+	ret := _m.Called(ctx, fieldID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomField")
+	}
+
+	var r0 db.CustomField
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.CustomField, error)); ok {
+		return rf(ctx, fieldID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.CustomField); ok {
+		r0 = rf(ctx, fieldID)
+	} else {
+		r0 = ret.Get(0).(db.CustomField)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, fieldID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDashboardLessons provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetDashboardLessons(ctx context.Context, arg db.GetDashboardLessonsParams) ([]db.GetDashboardLessonsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDashboardLessons")
+	}
+
+	var r0 []db.GetDashboardLessonsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetDashboardLessonsParams) ([]db.GetDashboardLessonsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetDashboardLessonsParams) []db.GetDashboardLessonsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetDashboardLessonsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetDashboardLessonsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDueLessonReminders provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetDueLessonReminders(ctx context.Context, arg db.GetDueLessonRemindersParams) ([]db.GetDueLessonRemindersRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueLessonReminders")
+	}
+
+	var r0 []db.GetDueLessonRemindersRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetDueLessonRemindersParams) ([]db.GetDueLessonRemindersRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetDueLessonRemindersParams) []db.GetDueLessonRemindersRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetDueLessonRemindersRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetDueLessonRemindersParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExam provides a mock function with given fields: ctx, examID
+func (_m *MockStore) GetExam(ctx context.Context, examID int64) (db.Exam, error) {
+	ret := _m.Called(ctx, examID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExam")
+	}
+
+	var r0 db.Exam
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Exam, error)); ok {
+		return rf(ctx, examID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Exam); ok {
+		r0 = rf(ctx, examID)
+	} else {
+		r0 = ret.Get(0).(db.Exam)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, examID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExamOutcomeReport provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetExamOutcomeReport(ctx context.Context, arg db.GetExamOutcomeReportParams) ([]db.GetExamOutcomeReportRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExamOutcomeReport")
+	}
+
+	var r0 []db.GetExamOutcomeReportRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetExamOutcomeReportParams) ([]db.GetExamOutcomeReportRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetExamOutcomeReportParams) []db.GetExamOutcomeReportRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetExamOutcomeReportRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetExamOutcomeReportParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFeeSchedule provides a mock function with given fields: ctx, feeScheduleID
+func (_m *MockStore) GetFeeSchedule(ctx context.Context, feeScheduleID int64) (db.FeeSchedule, error) {
+	ret := _m.Called(ctx, feeScheduleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeeSchedule")
+	}
+
+	var r0 db.FeeSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.FeeSchedule, error)); ok {
+		return rf(ctx, feeScheduleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.FeeSchedule); ok {
+		r0 = rf(ctx, feeScheduleID)
+	} else {
+		r0 = ret.Get(0).(db.FeeSchedule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, feeScheduleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFirstMatchingWaitlistEntry provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetFirstMatchingWaitlistEntry(ctx context.Context, arg db.GetFirstMatchingWaitlistEntryParams) (db.WaitlistEntry, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirstMatchingWaitlistEntry")
+	}
+
+	var r0 db.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetFirstMatchingWaitlistEntryParams) (db.WaitlistEntry, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetFirstMatchingWaitlistEntryParams) db.WaitlistEntry); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.WaitlistEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetFirstMatchingWaitlistEntryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFunnel provides a mock function with given fields: ctx, funnelID
+func (_m *MockStore) GetFunnel(ctx context.Context, funnelID int64) (db.Funnel, error) {
+	ret := _m.Called(ctx, funnelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFunnel")
+	}
+
+	var r0 db.Funnel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Funnel, error)); ok {
+		return rf(ctx, funnelID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Funnel); ok {
+		r0 = rf(ctx, funnelID)
+	} else {
+		r0 = ret.Get(0).(db.Funnel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, funnelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFunnelCohortReport provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetFunnelCohortReport(ctx context.Context, arg db.GetFunnelCohortReportParams) ([]db.GetFunnelCohortReportRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFunnelCohortReport")
+	}
+
+	var r0 []db.GetFunnelCohortReportRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetFunnelCohortReportParams) ([]db.GetFunnelCohortReportRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetFunnelCohortReportParams) []db.GetFunnelCohortReportRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetFunnelCohortReportRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetFunnelCohortReportParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFunnelCost provides a mock function with given fields: ctx, funnelCostID
+func (_m *MockStore) GetFunnelCost(ctx context.Context, funnelCostID int64) (db.FunnelCost, error) {
+	ret := _m.Called(ctx, funnelCostID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFunnelCost")
+	}
+
+	var r0 db.FunnelCost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.FunnelCost, error)); ok {
+		return rf(ctx, funnelCostID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.FunnelCost); ok {
+		r0 = rf(ctx, funnelCostID)
+	} else {
+		r0 = ret.Get(0).(db.FunnelCost)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, funnelCostID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFunnelRevenueReport provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetFunnelRevenueReport(ctx context.Context, arg db.GetFunnelRevenueReportParams) ([]db.GetFunnelRevenueReportRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFunnelRevenueReport")
+	}
+
+	var r0 []db.GetFunnelRevenueReportRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetFunnelRevenueReportParams) ([]db.GetFunnelRevenueReportRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetFunnelRevenueReportParams) []db.GetFunnelRevenueReportRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetFunnelRevenueReportRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetFunnelRevenueReportParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupPricingPlan provides a mock function with given fields: ctx, planID
+func (_m *MockStore) GetGroupPricingPlan(ctx context.Context, planID int64) (db.GroupPricingPlan, error) {
+	ret := _m.Called(ctx, planID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupPricingPlan")
+	}
+
+	var r0 db.GroupPricingPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.GroupPricingPlan, error)); ok {
+		return rf(ctx, planID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.GroupPricingPlan); ok {
+		r0 = rf(ctx, planID)
+	} else {
+		r0 = ret.Get(0).(db.GroupPricingPlan)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, planID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupPricingPlanTx provides a mock function with given fields: ctx, planID
+func (_m *MockStore) GetGroupPricingPlanTx(ctx context.Context, planID int64) (db.GroupPricingPlanWithTiers, error) {
+	ret := _m.Called(ctx, planID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupPricingPlanTx")
+	}
+
+	var r0 db.GroupPricingPlanWithTiers
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.GroupPricingPlanWithTiers, error)); ok {
+		return rf(ctx, planID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.GroupPricingPlanWithTiers); ok {
+		r0 = rf(ctx, planID)
+	} else {
+		r0 = ret.Get(0).(db.GroupPricingPlanWithTiers)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, planID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupPricingTiers provides a mock function with given fields: ctx, planID
+func (_m *MockStore) GetGroupPricingTiers(ctx context.Context, planID int64) ([]db.GroupPricingTier, error) {
+	ret := _m.Called(ctx, planID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupPricingTiers")
+	}
+
+	var r0 []db.GroupPricingTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.GroupPricingTier, error)); ok {
+		return rf(ctx, planID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.GroupPricingTier); ok {
+		r0 = rf(ctx, planID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GroupPricingTier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, planID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuardian provides a mock function with given fields: ctx, guardianID
+func (_m *MockStore) GetGuardian(ctx context.Context, guardianID int64) (db.Guardian, error) {
+	ret := _m.Called(ctx, guardianID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuardian")
+	}
+
+	var r0 db.Guardian
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Guardian, error)); ok {
+		return rf(ctx, guardianID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Guardian); ok {
+		r0 = rf(ctx, guardianID)
+	} else {
+		r0 = ret.Get(0).(db.Guardian)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, guardianID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuardianReceipt provides a mock function with given fields: ctx, guardianReceiptID
+func (_m *MockStore) GetGuardianReceipt(ctx context.Context, guardianReceiptID int64) (db.GuardianReceipt, error) {
+	ret := _m.Called(ctx, guardianReceiptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuardianReceipt")
+	}
+
+	var r0 db.GuardianReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.GuardianReceipt, error)); ok {
+		return rf(ctx, guardianReceiptID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.GuardianReceipt); ok {
+		r0 = rf(ctx, guardianReceiptID)
+	} else {
+		r0 = ret.Get(0).(db.GuardianReceipt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, guardianReceiptID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuardianReceiptTx provides a mock function with given fields: ctx, guardianReceiptID
+func (_m *MockStore) GetGuardianReceiptTx(ctx context.Context, guardianReceiptID int64) (db.GuardianReceiptWithReceipts, error) {
+	ret := _m.Called(ctx, guardianReceiptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuardianReceiptTx")
+	}
+
+	var r0 db.GuardianReceiptWithReceipts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.GuardianReceiptWithReceipts, error)); ok {
+		return rf(ctx, guardianReceiptID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.GuardianReceiptWithReceipts); ok {
+		r0 = rf(ctx, guardianReceiptID)
+	} else {
+		r0 = ret.Get(0).(db.GuardianReceiptWithReceipts)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, guardianReceiptID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuardianStudents provides a mock function with given fields: ctx, guardianID
+func (_m *MockStore) GetGuardianStudents(ctx context.Context, guardianID int64) ([]db.GetGuardianStudentsRow, error) {
+	ret := _m.Called(ctx, guardianID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuardianStudents")
+	}
+
+	var r0 []db.GetGuardianStudentsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.GetGuardianStudentsRow, error)); ok {
+		return rf(ctx, guardianID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.GetGuardianStudentsRow); ok {
+		r0 = rf(ctx, guardianID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetGuardianStudentsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, guardianID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvoice provides a mock function with given fields: ctx, invoiceID
+func (_m *MockStore) GetInvoice(ctx context.Context, invoiceID int64) (db.Invoice, error) {
+	ret := _m.Called(ctx, invoiceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvoice")
+	}
+
+	var r0 db.Invoice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Invoice, error)); ok {
+		return rf(ctx, invoiceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Invoice); ok {
+		r0 = rf(ctx, invoiceID)
+	} else {
+		r0 = ret.Get(0).(db.Invoice)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, invoiceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvoiceByLessonStudent provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetInvoiceByLessonStudent(ctx context.Context, arg db.GetInvoiceByLessonStudentParams) (db.Invoice, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvoiceByLessonStudent")
+	}
+
+	var r0 db.Invoice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetInvoiceByLessonStudentParams) (db.Invoice, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetInvoiceByLessonStudentParams) db.Invoice); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Invoice)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetInvoiceByLessonStudentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvoicedAmount provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetInvoicedAmount(ctx context.Context, arg db.GetInvoicedAmountParams) (float64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvoicedAmount")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetInvoicedAmountParams) (float64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetInvoicedAmountParams) float64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetInvoicedAmountParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvoicesByLesson provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) GetInvoicesByLesson(ctx context.Context, lessonID int64) ([]db.Invoice, error) {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvoicesByLesson")
+	}
+
+	var r0 []db.Invoice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.Invoice, error)); ok {
+		return rf(ctx, lessonID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.Invoice); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Invoice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, lessonID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvoicesByStudent provides a mock function with given fields: ctx, studentID
+func (_m *MockStore) GetInvoicesByStudent(ctx context.Context, studentID int64) ([]db.Invoice, error) {
+	ret := _m.Called(ctx, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvoicesByStudent")
+	}
+
+	var r0 []db.Invoice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.Invoice, error)); ok {
+		return rf(ctx, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.Invoice); ok {
+		r0 = rf(ctx, studentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Invoice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvoicesByStudentPeriod provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetInvoicesByStudentPeriod(ctx context.Context, arg db.GetInvoicesByStudentPeriodParams) ([]db.Invoice, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvoicesByStudentPeriod")
+	}
+
+	var r0 []db.Invoice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetInvoicesByStudentPeriodParams) ([]db.Invoice, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetInvoicesByStudentPeriodParams) []db.Invoice); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Invoice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetInvoicesByStudentPeriodParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJobRun provides a mock function with given fields: ctx, runID
+func (_m *MockStore) GetJobRun(ctx context.Context, runID int64) (db.JobRun, error) {
+	ret := _m.Called(ctx, runID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobRun")
+	}
+
+	var r0 db.JobRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.JobRun, error)); ok {
+		return rf(ctx, runID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.JobRun); ok {
+		r0 = rf(ctx, runID)
+	} else {
+		r0 = ret.Get(0).(db.JobRun)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLastScheduledJobRun provides a mock function with given fields: ctx, jobName
+func (_m *MockStore) GetLastScheduledJobRun(ctx context.Context, jobName string) (db.JobRun, error) {
+	ret := _m.Called(ctx, jobName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastScheduledJobRun")
+	}
+
+	var r0 db.JobRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.JobRun, error)); ok {
+		return rf(ctx, jobName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.JobRun); ok {
+		r0 = rf(ctx, jobName)
+	} else {
+		r0 = ret.Get(0).(db.JobRun)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestJobRuns provides a mock function with given fields: ctx
+func (_m *MockStore) GetLatestJobRuns(ctx context.Context) ([]db.JobRun, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestJobRuns")
+	}
+
+	var r0 []db.JobRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.JobRun, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.JobRun); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.JobRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLead provides a mock function with given fields: ctx, leadID
+func (_m *MockStore) GetLead(ctx context.Context, leadID int64) (db.Lead, error) {
+	ret := _m.Called(ctx, leadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLead")
+	}
+
+	var r0 db.Lead
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Lead, error)); ok {
+		return rf(ctx, leadID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Lead); ok {
+		r0 = rf(ctx, leadID)
+	} else {
+		r0 = ret.Get(0).(db.Lead)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, leadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLeadForUpdate provides a mock function with given fields: ctx, leadID
+func (_m *MockStore) GetLeadForUpdate(ctx context.Context, leadID int64) (db.Lead, error) {
+	ret := _m.Called(ctx, leadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLeadForUpdate")
+	}
+
+	var r0 db.Lead
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Lead, error)); ok {
+		return rf(ctx, leadID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Lead); ok {
+		r0 = rf(ctx, leadID)
+	} else {
+		r0 = ret.Get(0).(db.Lead)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, leadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLesson provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) GetLesson(ctx context.Context, lessonID int64) (db.Lesson, error) {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLesson")
+	}
+
+	var r0 db.Lesson
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Lesson, error)); ok {
+		return rf(ctx, lessonID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Lesson); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		r0 = ret.Get(0).(db.Lesson)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, lessonID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLessonAttendance provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetLessonAttendance(ctx context.Context, arg db.GetLessonAttendanceParams) (db.LessonAttendance, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLessonAttendance")
+	}
+
+	var r0 db.LessonAttendance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetLessonAttendanceParams) (db.LessonAttendance, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetLessonAttendanceParams) db.LessonAttendance); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.LessonAttendance)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetLessonAttendanceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLessonAttendanceByLesson provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) GetLessonAttendanceByLesson(ctx context.Context, lessonID int64) ([]db.LessonAttendance, error) {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLessonAttendanceByLesson")
+	}
+
+	var r0 []db.LessonAttendance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.LessonAttendance, error)); ok {
+		return rf(ctx, lessonID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.LessonAttendance); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LessonAttendance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, lessonID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLessonLocation provides a mock function with given fields: ctx, locationID
+func (_m *MockStore) GetLessonLocation(ctx context.Context, locationID int64) (db.LessonLocation, error) {
+	ret := _m.Called(ctx, locationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLessonLocation")
+	}
+
+	var r0 db.LessonLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.LessonLocation, error)); ok {
+		return rf(ctx, locationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.LessonLocation); ok {
+		r0 = rf(ctx, locationID)
+	} else {
+		r0 = ret.Get(0).(db.LessonLocation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, locationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLessonRecord provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetLessonRecord(ctx context.Context, arg db.GetLessonRecordParams) (db.LessonRecord, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLessonRecord")
+	}
+
+	var r0 db.LessonRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetLessonRecordParams) (db.LessonRecord, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetLessonRecordParams) db.LessonRecord); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.LessonRecord)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetLessonRecordParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLessonRecordsByLesson provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) GetLessonRecordsByLesson(ctx context.Context, lessonID int64) ([]db.LessonRecord, error) {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLessonRecordsByLesson")
+	}
+
+	var r0 []db.LessonRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.LessonRecord, error)); ok {
+		return rf(ctx, lessonID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.LessonRecord); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LessonRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, lessonID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLessonRemindersByLesson provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) GetLessonRemindersByLesson(ctx context.Context, lessonID int64) ([]db.LessonReminder, error) {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLessonRemindersByLesson")
+	}
+
+	var r0 []db.LessonReminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.LessonReminder, error)); ok {
+		return rf(ctx, lessonID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.LessonReminder); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LessonReminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, lessonID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLessonSubject provides a mock function with given fields: ctx, subjectID
+func (_m *MockStore) GetLessonSubject(ctx context.Context, subjectID int64) (db.LessonSubject, error) {
+	ret := _m.Called(ctx, subjectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLessonSubject")
+	}
+
+	var r0 db.LessonSubject
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.LessonSubject, error)); ok {
+		return rf(ctx, subjectID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.LessonSubject); ok {
+		r0 = rf(ctx, subjectID)
+	} else {
+		r0 = ret.Get(0).(db.LessonSubject)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, subjectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLessonWithInvoicesTx provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) GetLessonWithInvoicesTx(ctx context.Context, lessonID int64) (db.LessonWithInvoices, error) {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLessonWithInvoicesTx")
+	}
+
+	var r0 db.LessonWithInvoices
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.LessonWithInvoices, error)); ok {
+		return rf(ctx, lessonID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.LessonWithInvoices); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		r0 = ret.Get(0).(db.LessonWithInvoices)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, lessonID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLocationHoursReport provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetLocationHoursReport(ctx context.Context, arg db.GetLocationHoursReportParams) ([]db.GetLocationHoursReportRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLocationHoursReport")
+	}
+
+	var r0 []db.GetLocationHoursReportRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetLocationHoursReportParams) ([]db.GetLocationHoursReportRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetLocationHoursReportParams) []db.GetLocationHoursReportRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetLocationHoursReportRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetLocationHoursReportParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOutboxEmail provides a mock function with given fields: ctx, emailID
+func (_m *MockStore) GetOutboxEmail(ctx context.Context, emailID int64) (db.EmailOutbox, error) {
+	ret := _m.Called(ctx, emailID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOutboxEmail")
+	}
+
+	var r0 db.EmailOutbox
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.EmailOutbox, error)); ok {
+		return rf(ctx, emailID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.EmailOutbox); ok {
+		r0 = rf(ctx, emailID)
+	} else {
+		r0 = ret.Get(0).(db.EmailOutbox)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, emailID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOutboxEmailsByStudent provides a mock function with given fields: ctx, studentID
+func (_m *MockStore) GetOutboxEmailsByStudent(ctx context.Context, studentID int64) ([]db.EmailOutbox, error) {
+	ret := _m.Called(ctx, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOutboxEmailsByStudent")
+	}
+
+	var r0 []db.EmailOutbox
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.EmailOutbox, error)); ok {
+		return rf(ctx, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.EmailOutbox); ok {
+		r0 = rf(ctx, studentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.EmailOutbox)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOutstandingBalance provides a mock function with given fields: ctx
+func (_m *MockStore) GetOutstandingBalance(ctx context.Context) (db.GetOutstandingBalanceRow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOutstandingBalance")
+	}
+
+	var r0 db.GetOutstandingBalanceRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (db.GetOutstandingBalanceRow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) db.GetOutstandingBalanceRow); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(db.GetOutstandingBalanceRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPackageDeductionByInvoice provides a mock function with given fields: ctx, invoiceID
+func (_m *MockStore) GetPackageDeductionByInvoice(ctx context.Context, invoiceID int64) (db.PackageDeduction, error) {
+	ret := _m.Called(ctx, invoiceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPackageDeductionByInvoice")
+	}
+
+	var r0 db.PackageDeduction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.PackageDeduction, error)); ok {
+		return rf(ctx, invoiceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.PackageDeduction); ok {
+		r0 = rf(ctx, invoiceID)
+	} else {
+		r0 = ret.Get(0).(db.PackageDeduction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, invoiceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPackageDeductionsByLesson provides a mock function with given fields: ctx, lessonID
+func (_m *MockStore) GetPackageDeductionsByLesson(ctx context.Context, lessonID int64) ([]db.PackageDeduction, error) {
+	ret := _m.Called(ctx, lessonID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPackageDeductionsByLesson")
+	}
+
+	var r0 []db.PackageDeduction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.PackageDeduction, error)); ok {
+		return rf(ctx, lessonID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.PackageDeduction); ok {
+		r0 = rf(ctx, lessonID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PackageDeduction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, lessonID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPackageDeductionsByPackage provides a mock function with given fields: ctx, packageID
+func (_m *MockStore) GetPackageDeductionsByPackage(ctx context.Context, packageID int64) ([]db.PackageDeduction, error) {
+	ret := _m.Called(ctx, packageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPackageDeductionsByPackage")
+	}
+
+	var r0 []db.PackageDeduction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.PackageDeduction, error)); ok {
+		return rf(ctx, packageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.PackageDeduction); ok {
+		r0 = rf(ctx, packageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PackageDeduction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, packageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPackageProduct provides a mock function with given fields: ctx, productID
+func (_m *MockStore) GetPackageProduct(ctx context.Context, productID int64) (db.PackageProduct, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPackageProduct")
+	}
+
+	var r0 db.PackageProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.PackageProduct, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.PackageProduct); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		r0 = ret.Get(0).(db.PackageProduct)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPayment provides a mock function with given fields: ctx, paymentID
+func (_m *MockStore) GetPayment(ctx context.Context, paymentID int64) (db.Payment, error) {
+	ret := _m.Called(ctx, paymentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPayment")
+	}
+
+	var r0 db.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Payment, error)); ok {
+		return rf(ctx, paymentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Payment); ok {
+		r0 = rf(ctx, paymentID)
+	} else {
+		r0 = ret.Get(0).(db.Payment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, paymentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentMethod provides a mock function with given fields: ctx, paymentMethodID
+func (_m *MockStore) GetPaymentMethod(ctx context.Context, paymentMethodID int64) (db.PaymentMethod, error) {
+	ret := _m.Called(ctx, paymentMethodID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentMethod")
+	}
+
+	var r0 db.PaymentMethod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.PaymentMethod, error)); ok {
+		return rf(ctx, paymentMethodID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.PaymentMethod); ok {
+		r0 = rf(ctx, paymentMethodID)
+	} else {
+		r0 = ret.Get(0).(db.PaymentMethod)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, paymentMethodID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPayments provides a mock function with given fields: ctx, receiptID
+func (_m *MockStore) GetPayments(ctx context.Context, receiptID int64) ([]db.Payment, error) {
+	ret := _m.Called(ctx, receiptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPayments")
+	}
+
+	var r0 []db.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.Payment, error)); ok {
+		return rf(ctx, receiptID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.Payment); ok {
+		r0 = rf(ctx, receiptID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, receiptID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipelineStage provides a mock function with given fields: ctx, stageID
+func (_m *MockStore) GetPipelineStage(ctx context.Context, stageID int64) (db.PipelineStage, error) {
+	ret := _m.Called(ctx, stageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPipelineStage")
+	}
+
+	var r0 db.PipelineStage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.PipelineStage, error)); ok {
+		return rf(ctx, stageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.PipelineStage); ok {
+		r0 = rf(ctx, stageID)
+	} else {
+		r0 = ret.Get(0).(db.PipelineStage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, stageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProgressReport provides a mock function with given fields: ctx, progressReportID
+func (_m *MockStore) GetProgressReport(ctx context.Context, progressReportID int64) (db.ProgressReport, error) {
+	ret := _m.Called(ctx, progressReportID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProgressReport")
+	}
+
+	var r0 db.ProgressReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.ProgressReport, error)); ok {
+		return rf(ctx, progressReportID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.ProgressReport); ok {
+		r0 = rf(ctx, progressReportID)
+	} else {
+		r0 = ret.Get(0).(db.ProgressReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, progressReportID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProgressReportDocumentTx provides a mock function with given fields: ctx, progressReportID
+func (_m *MockStore) GetProgressReportDocumentTx(ctx context.Context, progressReportID int64) (db.ProgressReportDocument, error) {
+	ret := _m.Called(ctx, progressReportID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProgressReportDocumentTx")
+	}
+
+	var r0 db.ProgressReportDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.ProgressReportDocument, error)); ok {
+		return rf(ctx, progressReportID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.ProgressReportDocument); ok {
+		r0 = rf(ctx, progressReportID)
+	} else {
+		r0 = ret.Get(0).(db.ProgressReportDocument)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, progressReportID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProgressReportLessons provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetProgressReportLessons(ctx context.Context, arg db.GetProgressReportLessonsParams) ([]db.GetProgressReportLessonsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProgressReportLessons")
+	}
+
+	var r0 []db.GetProgressReportLessonsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetProgressReportLessonsParams) ([]db.GetProgressReportLessonsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetProgressReportLessonsParams) []db.GetProgressReportLessonsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetProgressReportLessonsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetProgressReportLessonsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProgressReportSubjects provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetProgressReportSubjects(ctx context.Context, arg db.GetProgressReportSubjectsParams) ([]db.GetProgressReportSubjectsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProgressReportSubjects")
+	}
+
+	var r0 []db.GetProgressReportSubjectsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetProgressReportSubjectsParams) ([]db.GetProgressReportSubjectsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetProgressReportSubjectsParams) []db.GetProgressReportSubjectsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetProgressReportSubjectsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetProgressReportSubjectsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceipt provides a mock function with given fields: ctx, receiptID
+func (_m *MockStore) GetReceipt(ctx context.Context, receiptID int64) (db.Receipt, error) {
+	ret := _m.Called(ctx, receiptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceipt")
+	}
+
+	var r0 db.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Receipt, error)); ok {
+		return rf(ctx, receiptID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Receipt); ok {
+		r0 = rf(ctx, receiptID)
+	} else {
+		r0 = ret.Get(0).(db.Receipt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, receiptID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceiptWithPaymentsTx provides a mock function with given fields: ctx, receiptID
+func (_m *MockStore) GetReceiptWithPaymentsTx(ctx context.Context, receiptID int64) (db.ReceiptWithPayments, error) {
+	ret := _m.Called(ctx, receiptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceiptWithPaymentsTx")
+	}
+
+	var r0 db.ReceiptWithPayments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.ReceiptWithPayments, error)); ok {
+		return rf(ctx, receiptID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.ReceiptWithPayments); ok {
+		r0 = rf(ctx, receiptID)
+	} else {
+		r0 = ret.Get(0).(db.ReceiptWithPayments)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, receiptID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceiptsByGuardianReceipt provides a mock function with given fields: ctx, guardianReceiptID
+func (_m *MockStore) GetReceiptsByGuardianReceipt(ctx context.Context, guardianReceiptID sql.NullInt64) ([]db.Receipt, error) {
+	ret := _m.Called(ctx, guardianReceiptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceiptsByGuardianReceipt")
+	}
+
+	var r0 []db.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullInt64) ([]db.Receipt, error)); ok {
+		return rf(ctx, guardianReceiptID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullInt64) []db.Receipt); ok {
+		r0 = rf(ctx, guardianReceiptID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, sql.NullInt64) error); ok {
+		r1 = rf(ctx, guardianReceiptID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceiptsByStudent provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetReceiptsByStudent(ctx context.Context, arg db.GetReceiptsByStudentParams) ([]db.Receipt, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceiptsByStudent")
+	}
+
+	var r0 []db.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetReceiptsByStudentParams) ([]db.Receipt, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetReceiptsByStudentParams) []db.Receipt); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetReceiptsByStudentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceiptsByStudentPeriod provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetReceiptsByStudentPeriod(ctx context.Context, arg db.GetReceiptsByStudentPeriodParams) ([]db.Receipt, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceiptsByStudentPeriod")
+	}
+
+	var r0 []db.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetReceiptsByStudentPeriodParams) ([]db.Receipt, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetReceiptsByStudentPeriodParams) []db.Receipt); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetReceiptsByStudentPeriodParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceiptsWithPaymentsByStudentTx provides a mock function with given fields: ctx, studentID, limit, offset
+func (_m *MockStore) GetReceiptsWithPaymentsByStudentTx(ctx context.Context, studentID int64, limit int, offset int) (db.StudentReceiptsWithPayments, error) {
+	ret := _m.Called(ctx, studentID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceiptsWithPaymentsByStudentTx")
+	}
+
+	var r0 db.StudentReceiptsWithPayments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) (db.StudentReceiptsWithPayments, error)); ok {
+		return rf(ctx, studentID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) db.StudentReceiptsWithPayments); ok {
+		r0 = rf(ctx, studentID, limit, offset)
+	} else {
+		r0 = ret.Get(0).(db.StudentReceiptsWithPayments)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(ctx, studentID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReminderPreference provides a mock function with given fields: ctx, studentID
+func (_m *MockStore) GetReminderPreference(ctx context.Context, studentID int64) (db.ReminderPreference, error) {
+	ret := _m.Called(ctx, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReminderPreference")
+	}
+
+	var r0 db.ReminderPreference
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.ReminderPreference, error)); ok {
+		return rf(ctx, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.ReminderPreference); ok {
+		r0 = rf(ctx, studentID)
+	} else {
+		r0 = ret.Get(0).(db.ReminderPreference)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevenueReport provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetRevenueReport(ctx context.Context, arg db.GetRevenueReportParams) ([]db.GetRevenueReportRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevenueReport")
+	}
+
+	var r0 []db.GetRevenueReportRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetRevenueReportParams) ([]db.GetRevenueReportRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetRevenueReportParams) []db.GetRevenueReportRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetRevenueReportRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetRevenueReportParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatement provides a mock function with given fields: ctx, statementID
+func (_m *MockStore) GetStatement(ctx context.Context, statementID int64) (db.Statement, error) {
+	ret := _m.Called(ctx, statementID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatement")
+	}
+
+	var r0 db.Statement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Statement, error)); ok {
+		return rf(ctx, statementID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Statement); ok {
+		r0 = rf(ctx, statementID)
+	} else {
+		r0 = ret.Get(0).(db.Statement)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, statementID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatementDocumentTx provides a mock function with given fields: ctx, statementID
+func (_m *MockStore) GetStatementDocumentTx(ctx context.Context, statementID int64) (db.StatementDocument, error) {
+	ret := _m.Called(ctx, statementID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatementDocumentTx")
+	}
+
+	var r0 db.StatementDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.StatementDocument, error)); ok {
+		return rf(ctx, statementID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.StatementDocument); ok {
+		r0 = rf(ctx, statementID)
+	} else {
+		r0 = ret.Get(0).(db.StatementDocument)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, statementID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudent provides a mock function with given fields: ctx, studentID
+func (_m *MockStore) GetStudent(ctx context.Context, studentID int64) (db.Student, error) {
+	ret := _m.Called(ctx, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudent")
+	}
+
+	var r0 db.Student
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Student, error)); ok {
+		return rf(ctx, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Student); ok {
+		r0 = rf(ctx, studentID)
+	} else {
+		r0 = ret.Get(0).(db.Student)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentAgingInvoices provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetStudentAgingInvoices(ctx context.Context, arg db.GetStudentAgingInvoicesParams) ([]db.GetStudentAgingInvoicesRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentAgingInvoices")
+	}
+
+	var r0 []db.GetStudentAgingInvoicesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetStudentAgingInvoicesParams) ([]db.GetStudentAgingInvoicesRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetStudentAgingInvoicesParams) []db.GetStudentAgingInvoicesRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetStudentAgingInvoicesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetStudentAgingInvoicesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentCustomValues provides a mock function with given fields: ctx, studentID
+func (_m *MockStore) GetStudentCustomValues(ctx context.Context, studentID int64) ([]db.GetStudentCustomValuesRow, error) {
+	ret := _m.Called(ctx, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentCustomValues")
+	}
+
+	var r0 []db.GetStudentCustomValuesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.GetStudentCustomValuesRow, error)); ok {
+		return rf(ctx, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.GetStudentCustomValuesRow); ok {
+		r0 = rf(ctx, studentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetStudentCustomValuesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentGuardian provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetStudentGuardian(ctx context.Context, arg db.GetStudentGuardianParams) (db.StudentGuardian, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentGuardian")
+	}
+
+	var r0 db.StudentGuardian
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetStudentGuardianParams) (db.StudentGuardian, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetStudentGuardianParams) db.StudentGuardian); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StudentGuardian)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetStudentGuardianParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentGuardians provides a mock function with given fields: ctx, studentID
+func (_m *MockStore) GetStudentGuardians(ctx context.Context, studentID int64) ([]db.GetStudentGuardiansRow, error) {
+	ret := _m.Called(ctx, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentGuardians")
+	}
+
+	var r0 []db.GetStudentGuardiansRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.GetStudentGuardiansRow, error)); ok {
+		return rf(ctx, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.GetStudentGuardiansRow); ok {
+		r0 = rf(ctx, studentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetStudentGuardiansRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentPackage provides a mock function with given fields: ctx, packageID
+func (_m *MockStore) GetStudentPackage(ctx context.Context, packageID int64) (db.StudentPackage, error) {
+	ret := _m.Called(ctx, packageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentPackage")
+	}
+
+	var r0 db.StudentPackage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.StudentPackage, error)); ok {
+		return rf(ctx, packageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.StudentPackage); ok {
+		r0 = rf(ctx, packageID)
+	} else {
+		r0 = ret.Get(0).(db.StudentPackage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, packageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentPackageBalanceTx provides a mock function with given fields: ctx, studentID, asOf
+func (_m *MockStore) GetStudentPackageBalanceTx(ctx context.Context, studentID int64, asOf time.Time) (db.StudentPackageBalance, error) {
+	ret := _m.Called(ctx, studentID, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentPackageBalanceTx")
+	}
+
+	var r0 db.StudentPackageBalance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) (db.StudentPackageBalance, error)); ok {
+		return rf(ctx, studentID, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) db.StudentPackageBalance); ok {
+		r0 = rf(ctx, studentID, asOf)
+	} else {
+		r0 = ret.Get(0).(db.StudentPackageBalance)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, studentID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentPackageBalances provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetStudentPackageBalances(ctx context.Context, arg db.GetStudentPackageBalancesParams) ([]db.GetStudentPackageBalancesRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentPackageBalances")
+	}
+
+	var r0 []db.GetStudentPackageBalancesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetStudentPackageBalancesParams) ([]db.GetStudentPackageBalancesRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetStudentPackageBalancesParams) []db.GetStudentPackageBalancesRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetStudentPackageBalancesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetStudentPackageBalancesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentProgress provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetStudentProgress(ctx context.Context, arg db.GetStudentProgressParams) ([]db.GetStudentProgressRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentProgress")
+	}
+
+	var r0 []db.GetStudentProgressRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetStudentProgressParams) ([]db.GetStudentProgressRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetStudentProgressParams) []db.GetStudentProgressRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetStudentProgressRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetStudentProgressParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentTags provides a mock function with given fields: ctx, studentID
+func (_m *MockStore) GetStudentTags(ctx context.Context, studentID int64) ([]db.Tag, error) {
+	ret := _m.Called(ctx, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentTags")
+	}
+
+	var r0 []db.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.Tag, error)); ok {
+		return rf(ctx, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.Tag); ok {
+		r0 = rf(ctx, studentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentTimeline provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetStudentTimeline(ctx context.Context, arg db.GetStudentTimelineParams) ([]db.GetStudentTimelineRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentTimeline")
+	}
+
+	var r0 []db.GetStudentTimelineRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetStudentTimelineParams) ([]db.GetStudentTimelineRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetStudentTimelineParams) []db.GetStudentTimelineRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetStudentTimelineRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetStudentTimelineParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentsCustomValues provides a mock function with given fields: ctx, studentIds
+func (_m *MockStore) GetStudentsCustomValues(ctx context.Context, studentIds []int64) ([]db.GetStudentsCustomValuesRow, error) {
+	ret := _m.Called(ctx, studentIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentsCustomValues")
+	}
+
+	var r0 []db.GetStudentsCustomValuesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]db.GetStudentsCustomValuesRow, error)); ok {
+		return rf(ctx, studentIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []db.GetStudentsCustomValuesRow); ok {
+		r0 = rf(ctx, studentIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetStudentsCustomValuesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, studentIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentsLifecycle provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetStudentsLifecycle(ctx context.Context, arg db.GetStudentsLifecycleParams) ([]db.GetStudentsLifecycleRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentsLifecycle")
+	}
+
+	var r0 []db.GetStudentsLifecycleRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetStudentsLifecycleParams) ([]db.GetStudentsLifecycleRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetStudentsLifecycleParams) []db.GetStudentsLifecycleRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetStudentsLifecycleRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetStudentsLifecycleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentsTags provides a mock function with given fields: ctx, studentIds
+func (_m *MockStore) GetStudentsTags(ctx context.Context, studentIds []int64) ([]db.GetStudentsTagsRow, error) {
+	ret := _m.Called(ctx, studentIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentsTags")
+	}
+
+	var r0 []db.GetStudentsTagsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]db.GetStudentsTagsRow, error)); ok {
+		return rf(ctx, studentIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []db.GetStudentsTagsRow); ok {
+		r0 = rf(ctx, studentIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetStudentsTagsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, studentIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubjectHoursReport provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetSubjectHoursReport(ctx context.Context, arg db.GetSubjectHoursReportParams) ([]db.GetSubjectHoursReportRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubjectHoursReport")
+	}
+
+	var r0 []db.GetSubjectHoursReportRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetSubjectHoursReportParams) ([]db.GetSubjectHoursReportRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetSubjectHoursReportParams) []db.GetSubjectHoursReportRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetSubjectHoursReportRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetSubjectHoursReportParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTag provides a mock function with given fields: ctx, tagID
+func (_m *MockStore) GetTag(ctx context.Context, tagID int64) (db.Tag, error) {
+	ret := _m.Called(ctx, tagID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTag")
+	}
+
+	var r0 db.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Tag, error)); ok {
+		return rf(ctx, tagID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Tag); ok {
+		r0 = rf(ctx, tagID)
+	} else {
+		r0 = ret.Get(0).(db.Tag)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, tagID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTask provides a mock function with given fields: ctx, taskID
+func (_m *MockStore) GetTask(ctx context.Context, taskID int64) (db.Task, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTask")
+	}
+
+	var r0 db.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Task, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Task); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Get(0).(db.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskForUpdate provides a mock function with given fields: ctx, taskID
+func (_m *MockStore) GetTaskForUpdate(ctx context.Context, taskID int64) (db.Task, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskForUpdate")
+	}
+
+	var r0 db.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Task, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Task); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Get(0).(db.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUpcomingExams provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetUpcomingExams(ctx context.Context, arg db.GetUpcomingExamsParams) ([]db.GetUpcomingExamsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpcomingExams")
+	}
+
+	var r0 []db.GetUpcomingExamsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetUpcomingExamsParams) ([]db.GetUpcomingExamsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetUpcomingExamsParams) []db.GetUpcomingExamsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetUpcomingExamsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetUpcomingExamsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUpcomingUnpaidLessons provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetUpcomingUnpaidLessons(ctx context.Context, arg db.GetUpcomingUnpaidLessonsParams) ([]db.GetUpcomingUnpaidLessonsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpcomingUnpaidLessons")
+	}
+
+	var r0 []db.GetUpcomingUnpaidLessonsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetUpcomingUnpaidLessonsParams) ([]db.GetUpcomingUnpaidLessonsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetUpcomingUnpaidLessonsParams) []db.GetUpcomingUnpaidLessonsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetUpcomingUnpaidLessonsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetUpcomingUnpaidLessonsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsableStudentPackage provides a mock function with given fields: ctx, arg
+func (_m *MockStore) GetUsableStudentPackage(ctx context.Context, arg db.GetUsableStudentPackageParams) (db.StudentPackage, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsableStudentPackage")
+	}
+
+	var r0 db.StudentPackage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetUsableStudentPackageParams) (db.StudentPackage, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetUsableStudentPackageParams) db.StudentPackage); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StudentPackage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetUsableStudentPackageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWaitlistEntry provides a mock function with given fields: ctx, waitlistEntryID
+func (_m *MockStore) GetWaitlistEntry(ctx context.Context, waitlistEntryID int64) (db.WaitlistEntry, error) {
+	ret := _m.Called(ctx, waitlistEntryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWaitlistEntry")
+	}
+
+	var r0 db.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.WaitlistEntry, error)); ok {
+		return rf(ctx, waitlistEntryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.WaitlistEntry); ok {
+		r0 = rf(ctx, waitlistEntryID)
+	} else {
+		r0 = ret.Get(0).(db.WaitlistEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, waitlistEntryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookDelivery provides a mock function with given fields: ctx, deliveryID
+func (_m *MockStore) GetWebhookDelivery(ctx context.Context, deliveryID int64) (db.WebhookDelivery, error) {
+	ret := _m.Called(ctx, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDelivery")
+	}
+
+	var r0 db.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.WebhookDelivery, error)); ok {
+		return rf(ctx, deliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.WebhookDelivery); ok {
+		r0 = rf(ctx, deliveryID)
+	} else {
+		r0 = ret.Get(0).(db.WebhookDelivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookSubscription provides a mock function with given fields: ctx, subscriptionID
+func (_m *MockStore) GetWebhookSubscription(ctx context.Context, subscriptionID int64) (db.WebhookSubscription, error) {
+	ret := _m.Called(ctx, subscriptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookSubscription")
+	}
+
+	var r0 db.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.WebhookSubscription, error)); ok {
+		return rf(ctx, subscriptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.WebhookSubscription); ok {
+		r0 = rf(ctx, subscriptionID)
+	} else {
+		r0 = ret.Get(0).(db.WebhookSubscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, subscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LinkStudentGuardianTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) LinkStudentGuardianTx(ctx context.Context, arg db.UpsertStudentGuardianParams) (db.StudentGuardian, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinkStudentGuardianTx")
+	}
+
+	var r0 db.StudentGuardian
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertStudentGuardianParams) (db.StudentGuardian, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertStudentGuardianParams) db.StudentGuardian); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StudentGuardian)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertStudentGuardianParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListActivities provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListActivities(ctx context.Context, arg db.ListActivitiesParams) ([]db.Activity, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActivities")
+	}
+
+	var r0 []db.Activity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListActivitiesParams) ([]db.Activity, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListActivitiesParams) []db.Activity); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Activity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListActivitiesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAllCustomFields provides a mock function with given fields: ctx
+func (_m *MockStore) ListAllCustomFields(ctx context.Context) ([]db.CustomField, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllCustomFields")
+	}
+
+	var r0 []db.CustomField
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.CustomField, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.CustomField); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.CustomField)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAttachments provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListAttachments(ctx context.Context, arg db.ListAttachmentsParams) ([]db.Attachment, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAttachments")
+	}
+
+	var r0 []db.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListAttachmentsParams) ([]db.Attachment, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListAttachmentsParams) []db.Attachment); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListAttachmentsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAvailabilityExceptions provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListAvailabilityExceptions(ctx context.Context, arg db.ListAvailabilityExceptionsParams) ([]db.AvailabilityException, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAvailabilityExceptions")
+	}
+
+	var r0 []db.AvailabilityException
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListAvailabilityExceptionsParams) ([]db.AvailabilityException, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListAvailabilityExceptionsParams) []db.AvailabilityException); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.AvailabilityException)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListAvailabilityExceptionsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAvailabilityWindows provides a mock function with given fields: ctx
+func (_m *MockStore) ListAvailabilityWindows(ctx context.Context) ([]db.AvailabilityWindow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAvailabilityWindows")
+	}
+
+	var r0 []db.AvailabilityWindow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.AvailabilityWindow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.AvailabilityWindow); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.AvailabilityWindow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBookingRequests provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListBookingRequests(ctx context.Context, arg db.ListBookingRequestsParams) ([]db.BookingRequest, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBookingRequests")
+	}
+
+	var r0 []db.BookingRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListBookingRequestsParams) ([]db.BookingRequest, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListBookingRequestsParams) []db.BookingRequest); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BookingRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListBookingRequestsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListColleges provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListColleges(ctx context.Context, arg db.ListCollegesParams) ([]db.College, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListColleges")
+	}
+
+	var r0 []db.College
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListCollegesParams) ([]db.College, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListCollegesParams) []db.College); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.College)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListCollegesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCustomFields provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListCustomFields(ctx context.Context, arg db.ListCustomFieldsParams) ([]db.CustomField, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCustomFields")
+	}
+
+	var r0 []db.CustomField
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListCustomFieldsParams) ([]db.CustomField, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListCustomFieldsParams) []db.CustomField); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.CustomField)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListCustomFieldsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDueTasks provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListDueTasks(ctx context.Context, arg db.ListDueTasksParams) ([]db.ListDueTasksRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDueTasks")
+	}
+
+	var r0 []db.ListDueTasksRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListDueTasksParams) ([]db.ListDueTasksRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListDueTasksParams) []db.ListDueTasksRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListDueTasksRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListDueTasksParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListExams provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListExams(ctx context.Context, arg db.ListExamsParams) ([]db.Exam, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExams")
+	}
+
+	var r0 []db.Exam
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListExamsParams) ([]db.Exam, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListExamsParams) []db.Exam); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Exam)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListExamsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFeeSchedules provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListFeeSchedules(ctx context.Context, arg db.ListFeeSchedulesParams) ([]db.FeeSchedule, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFeeSchedules")
+	}
+
+	var r0 []db.FeeSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListFeeSchedulesParams) ([]db.FeeSchedule, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListFeeSchedulesParams) []db.FeeSchedule); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeeSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListFeeSchedulesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFunnelCosts provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListFunnelCosts(ctx context.Context, arg db.ListFunnelCostsParams) ([]db.FunnelCost, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFunnelCosts")
+	}
+
+	var r0 []db.FunnelCost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListFunnelCostsParams) ([]db.FunnelCost, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListFunnelCostsParams) []db.FunnelCost); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FunnelCost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListFunnelCostsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFunnels provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListFunnels(ctx context.Context, arg db.ListFunnelsParams) ([]db.Funnel, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFunnels")
+	}
+
+	var r0 []db.Funnel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListFunnelsParams) ([]db.Funnel, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListFunnelsParams) []db.Funnel); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Funnel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListFunnelsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGroupPricingPlans provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListGroupPricingPlans(ctx context.Context, arg db.ListGroupPricingPlansParams) ([]db.GroupPricingPlan, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroupPricingPlans")
+	}
+
+	var r0 []db.GroupPricingPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListGroupPricingPlansParams) ([]db.GroupPricingPlan, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListGroupPricingPlansParams) []db.GroupPricingPlan); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GroupPricingPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListGroupPricingPlansParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGuardianReceipts provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListGuardianReceipts(ctx context.Context, arg db.ListGuardianReceiptsParams) ([]db.GuardianReceipt, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGuardianReceipts")
+	}
+
+	var r0 []db.GuardianReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListGuardianReceiptsParams) ([]db.GuardianReceipt, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListGuardianReceiptsParams) []db.GuardianReceipt); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GuardianReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListGuardianReceiptsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGuardians provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListGuardians(ctx context.Context, arg db.ListGuardiansParams) ([]db.Guardian, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGuardians")
+	}
+
+	var r0 []db.Guardian
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListGuardiansParams) ([]db.Guardian, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListGuardiansParams) []db.Guardian); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Guardian)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListGuardiansParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInvoices provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListInvoices(ctx context.Context, arg db.ListInvoicesParams) ([]db.Invoice, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInvoices")
+	}
+
+	var r0 []db.Invoice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListInvoicesParams) ([]db.Invoice, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListInvoicesParams) []db.Invoice); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Invoice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListInvoicesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListJobRuns provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListJobRuns(ctx context.Context, arg db.ListJobRunsParams) ([]db.JobRun, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJobRuns")
+	}
+
+	var r0 []db.JobRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListJobRunsParams) ([]db.JobRun, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListJobRunsParams) []db.JobRun); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.JobRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListJobRunsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLeads provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListLeads(ctx context.Context, arg db.ListLeadsParams) ([]db.Lead, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLeads")
+	}
+
+	var r0 []db.Lead
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListLeadsParams) ([]db.Lead, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListLeadsParams) []db.Lead); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Lead)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListLeadsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLessonLocations provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListLessonLocations(ctx context.Context, arg db.ListLessonLocationsParams) ([]db.LessonLocation, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLessonLocations")
+	}
+
+	var r0 []db.LessonLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonLocationsParams) ([]db.LessonLocation, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonLocationsParams) []db.LessonLocation); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LessonLocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListLessonLocationsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLessonSubjects provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListLessonSubjects(ctx context.Context, arg db.ListLessonSubjectsParams) ([]db.LessonSubject, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLessonSubjects")
+	}
+
+	var r0 []db.LessonSubject
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonSubjectsParams) ([]db.LessonSubject, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonSubjectsParams) []db.LessonSubject); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LessonSubject)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListLessonSubjectsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLessons provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListLessons(ctx context.Context, arg db.ListLessonsParams) ([]db.Lesson, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLessons")
+	}
+
+	var r0 []db.Lesson
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonsParams) ([]db.Lesson, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonsParams) []db.Lesson); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Lesson)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListLessonsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLessonsBetween provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListLessonsBetween(ctx context.Context, arg db.ListLessonsBetweenParams) ([]db.Lesson, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLessonsBetween")
+	}
+
+	var r0 []db.Lesson
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonsBetweenParams) ([]db.Lesson, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonsBetweenParams) []db.Lesson); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Lesson)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListLessonsBetweenParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOutboxEmails provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListOutboxEmails(ctx context.Context, arg db.ListOutboxEmailsParams) ([]db.EmailOutbox, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOutboxEmails")
+	}
+
+	var r0 []db.EmailOutbox
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListOutboxEmailsParams) ([]db.EmailOutbox, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListOutboxEmailsParams) []db.EmailOutbox); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.EmailOutbox)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListOutboxEmailsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPackageProducts provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListPackageProducts(ctx context.Context, arg db.ListPackageProductsParams) ([]db.PackageProduct, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPackageProducts")
+	}
+
+	var r0 []db.PackageProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListPackageProductsParams) ([]db.PackageProduct, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListPackageProductsParams) []db.PackageProduct); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PackageProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListPackageProductsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPaymentMethods provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListPaymentMethods(ctx context.Context, arg db.ListPaymentMethodsParams) ([]db.PaymentMethod, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPaymentMethods")
+	}
+
+	var r0 []db.PaymentMethod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListPaymentMethodsParams) ([]db.PaymentMethod, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListPaymentMethodsParams) []db.PaymentMethod); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PaymentMethod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListPaymentMethodsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPayments provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListPayments(ctx context.Context, arg db.ListPaymentsParams) ([]db.Payment, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPayments")
+	}
+
+	var r0 []db.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListPaymentsParams) ([]db.Payment, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListPaymentsParams) []db.Payment); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListPaymentsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPipelineStages provides a mock function with given fields: ctx
+func (_m *MockStore) ListPipelineStages(ctx context.Context) ([]db.PipelineStage, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPipelineStages")
+	}
+
+	var r0 []db.PipelineStage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.PipelineStage, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.PipelineStage); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PipelineStage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListProgressReports provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListProgressReports(ctx context.Context, arg db.ListProgressReportsParams) ([]db.ProgressReport, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProgressReports")
+	}
+
+	var r0 []db.ProgressReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListProgressReportsParams) ([]db.ProgressReport, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListProgressReportsParams) []db.ProgressReport); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ProgressReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListProgressReportsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReceipts provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListReceipts(ctx context.Context, arg db.ListReceiptsParams) ([]db.Receipt, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReceipts")
+	}
+
+	var r0 []db.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListReceiptsParams) ([]db.Receipt, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListReceiptsParams) []db.Receipt); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListReceiptsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListStatements provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListStatements(ctx context.Context, arg db.ListStatementsParams) ([]db.Statement, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteLesson")
+		panic("no return value specified for ListStatements")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, lessonID)
+	var r0 []db.Statement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListStatementsParams) ([]db.Statement, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListStatementsParams) []db.Statement); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Statement)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListStatementsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteLessonLocation provides a mock function with given fields: ctx, locationID
-func (_m *MockStore) DeleteLessonLocation(ctx context.Context, locationID int64) error {
-	ret := _m.Called(ctx, locationID)
+// ListStudentPackages provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListStudentPackages(ctx context.Context, arg db.ListStudentPackagesParams) ([]db.StudentPackage, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteLessonLocation")
+		panic("no return value specified for ListStudentPackages")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, locationID)
+	var r0 []db.StudentPackage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListStudentPackagesParams) ([]db.StudentPackage, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListStudentPackagesParams) []db.StudentPackage); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StudentPackage)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListStudentPackagesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteLessonSubject provides a mock function with given fields: ctx, subjectID
-func (_m *MockStore) DeleteLessonSubject(ctx context.Context, subjectID int64) error {
-	ret := _m.Called(ctx, subjectID)
+// ListStudents provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListStudents(ctx context.Context, arg db.ListStudentsParams) ([]db.Student, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteLessonSubject")
+		panic("no return value specified for ListStudents")
+	}
+
+	var r0 []db.Student
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListStudentsParams) ([]db.Student, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListStudentsParams) []db.Student); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Student)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListStudentsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTags provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListTags(ctx context.Context, arg db.ListTagsParams) ([]db.Tag, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTags")
+	}
+
+	var r0 []db.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTagsParams) ([]db.Tag, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTagsParams) []db.Tag); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListTagsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTasks provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListTasks(ctx context.Context, arg db.ListTasksParams) ([]db.Task, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTasks")
+	}
+
+	var r0 []db.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTasksParams) ([]db.Task, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTasksParams) []db.Task); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListTasksParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWaitlistEntries provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListWaitlistEntries(ctx context.Context, arg db.ListWaitlistEntriesParams) ([]db.WaitlistEntry, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWaitlistEntries")
+	}
+
+	var r0 []db.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListWaitlistEntriesParams) ([]db.WaitlistEntry, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListWaitlistEntriesParams) []db.WaitlistEntry); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WaitlistEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListWaitlistEntriesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhookDeliveries provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListWebhookDeliveries(ctx context.Context, arg db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveries")
+	}
+
+	var r0 []db.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListWebhookDeliveriesParams) []db.WebhookDelivery); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListWebhookDeliveriesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhookSubscriptions provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListWebhookSubscriptions(ctx context.Context, arg db.ListWebhookSubscriptionsParams) ([]db.WebhookSubscription, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookSubscriptions")
+	}
+
+	var r0 []db.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListWebhookSubscriptionsParams) ([]db.WebhookSubscription, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListWebhookSubscriptionsParams) []db.WebhookSubscription); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListWebhookSubscriptionsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockJobTx provides a mock function with given fields: ctx, jobName, fn
+func (_m *MockStore) LockJobTx(ctx context.Context, jobName string, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, jobName, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockJobTx")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, subjectID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(context.Context) error) error); ok {
+		r0 = rf(ctx, jobName, fn)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -476,17 +6760,17 @@ func (_m *MockStore) DeleteLessonSubject(ctx context.Context, subjectID int64) e
 	return r0
 }
 
-// DeleteLessonWithInvoicesTx provides a mock function with given fields: ctx, lessonID
-func (_m *MockStore) DeleteLessonWithInvoicesTx(ctx context.Context, lessonID int64) error {
-	ret := _m.Called(ctx, lessonID)
+// LockLessonSchedule provides a mock function with given fields: ctx
+func (_m *MockStore) LockLessonSchedule(ctx context.Context) error {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteLessonWithInvoicesTx")
+		panic("no return value specified for LockLessonSchedule")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, lessonID)
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -494,17 +6778,17 @@ func (_m *MockStore) DeleteLessonWithInvoicesTx(ctx context.Context, lessonID in
 	return r0
 }
 
-// DeletePayment provides a mock function with given fields: ctx, paymentID
-func (_m *MockStore) DeletePayment(ctx context.Context, paymentID int64) error {
-	ret := _m.Called(ctx, paymentID)
+// MarkLessonReminderFailed provides a mock function with given fields: ctx, arg
+func (_m *MockStore) MarkLessonReminderFailed(ctx context.Context, arg db.MarkLessonReminderFailedParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeletePayment")
+		panic("no return value specified for MarkLessonReminderFailed")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, paymentID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkLessonReminderFailedParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -512,17 +6796,17 @@ func (_m *MockStore) DeletePayment(ctx context.Context, paymentID int64) error {
 	return r0
 }
 
-// DeletePaymentMethod provides a mock function with given fields: ctx, paymentMethodID
-func (_m *MockStore) DeletePaymentMethod(ctx context.Context, paymentMethodID int64) error {
-	ret := _m.Called(ctx, paymentMethodID)
+// MarkLessonReminderSent provides a mock function with given fields: ctx, arg
+func (_m *MockStore) MarkLessonReminderSent(ctx context.Context, arg db.MarkLessonReminderSentParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeletePaymentMethod")
+		panic("no return value specified for MarkLessonReminderSent")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, paymentMethodID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkLessonReminderSentParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -530,17 +6814,17 @@ func (_m *MockStore) DeletePaymentMethod(ctx context.Context, paymentMethodID in
 	return r0
 }
 
-// DeletePaymentsByReceipt provides a mock function with given fields: ctx, receiptID
-func (_m *MockStore) DeletePaymentsByReceipt(ctx context.Context, receiptID int64) error {
-	ret := _m.Called(ctx, receiptID)
+// MarkOutboxEmailFailed provides a mock function with given fields: ctx, arg
+func (_m *MockStore) MarkOutboxEmailFailed(ctx context.Context, arg db.MarkOutboxEmailFailedParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeletePaymentsByReceipt")
+		panic("no return value specified for MarkOutboxEmailFailed")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, receiptID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkOutboxEmailFailedParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -548,17 +6832,17 @@ func (_m *MockStore) DeletePaymentsByReceipt(ctx context.Context, receiptID int6
 	return r0
 }
 
-// DeleteReceipt provides a mock function with given fields: ctx, receiptID
-func (_m *MockStore) DeleteReceipt(ctx context.Context, receiptID int64) error {
-	ret := _m.Called(ctx, receiptID)
+// MarkOutboxEmailSent provides a mock function with given fields: ctx, arg
+func (_m *MockStore) MarkOutboxEmailSent(ctx context.Context, arg db.MarkOutboxEmailSentParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteReceipt")
+		panic("no return value specified for MarkOutboxEmailSent")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, receiptID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkOutboxEmailSentParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -566,17 +6850,45 @@ func (_m *MockStore) DeleteReceipt(ctx context.Context, receiptID int64) error {
 	return r0
 }
 
-// DeleteReceiptWithPaymentsTx provides a mock function with given fields: ctx, receiptID
-func (_m *MockStore) DeleteReceiptWithPaymentsTx(ctx context.Context, receiptID int64) error {
-	ret := _m.Called(ctx, receiptID)
+// MarkWaitlistEntryNotified provides a mock function with given fields: ctx, arg
+func (_m *MockStore) MarkWaitlistEntryNotified(ctx context.Context, arg db.MarkWaitlistEntryNotifiedParams) (db.WaitlistEntry, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkWaitlistEntryNotified")
+	}
+
+	var r0 db.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkWaitlistEntryNotifiedParams) (db.WaitlistEntry, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkWaitlistEntryNotifiedParams) db.WaitlistEntry); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.WaitlistEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.MarkWaitlistEntryNotifiedParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkWebhookDeliveryDelivered provides a mock function with given fields: ctx, arg
+func (_m *MockStore) MarkWebhookDeliveryDelivered(ctx context.Context, arg db.MarkWebhookDeliveryDeliveredParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteReceiptWithPaymentsTx")
+		panic("no return value specified for MarkWebhookDeliveryDelivered")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, receiptID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkWebhookDeliveryDeliveredParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -584,17 +6896,17 @@ func (_m *MockStore) DeleteReceiptWithPaymentsTx(ctx context.Context, receiptID
 	return r0
 }
 
-// DeleteStudent provides a mock function with given fields: ctx, studentID
-func (_m *MockStore) DeleteStudent(ctx context.Context, studentID int64) error {
-	ret := _m.Called(ctx, studentID)
+// MarkWebhookDeliveryFailed provides a mock function with given fields: ctx, arg
+func (_m *MockStore) MarkWebhookDeliveryFailed(ctx context.Context, arg db.MarkWebhookDeliveryFailedParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteStudent")
+		panic("no return value specified for MarkWebhookDeliveryFailed")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, studentID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkWebhookDeliveryFailedParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -602,27 +6914,27 @@ func (_m *MockStore) DeleteStudent(ctx context.Context, studentID int64) error {
 	return r0
 }
 
-// GetCollege provides a mock function with given fields: ctx, collegeID
-func (_m *MockStore) GetCollege(ctx context.Context, collegeID int64) (db.College, error) {
-	ret := _m.Called(ctx, collegeID)
+// PurchasePackageTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) PurchasePackageTx(ctx context.Context, arg db.PurchasePackageTxParams) (db.PackagePurchase, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCollege")
+		panic("no return value specified for PurchasePackageTx")
 	}
 
-	var r0 db.College
+	var r0 db.PackagePurchase
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.College, error)); ok {
-		return rf(ctx, collegeID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.PurchasePackageTxParams) (db.PackagePurchase, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.College); ok {
-		r0 = rf(ctx, collegeID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.PurchasePackageTxParams) db.PackagePurchase); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.College)
+		r0 = ret.Get(0).(db.PackagePurchase)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, collegeID)
+	if rf, ok := ret.Get(1).(func(context.Context, db.PurchasePackageTxParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -630,27 +6942,27 @@ func (_m *MockStore) GetCollege(ctx context.Context, collegeID int64) (db.Colleg
 	return r0, r1
 }
 
-// GetFunnel provides a mock function with given fields: ctx, funnelID
-func (_m *MockStore) GetFunnel(ctx context.Context, funnelID int64) (db.Funnel, error) {
-	ret := _m.Called(ctx, funnelID)
+// RemoveLessonStudentTx provides a mock function with given fields: ctx, lessonID, studentID
+func (_m *MockStore) RemoveLessonStudentTx(ctx context.Context, lessonID int64, studentID int64) (db.LessonWithInvoices, error) {
+	ret := _m.Called(ctx, lessonID, studentID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFunnel")
+		panic("no return value specified for RemoveLessonStudentTx")
 	}
 
-	var r0 db.Funnel
+	var r0 db.LessonWithInvoices
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Funnel, error)); ok {
-		return rf(ctx, funnelID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (db.LessonWithInvoices, error)); ok {
+		return rf(ctx, lessonID, studentID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Funnel); ok {
-		r0 = rf(ctx, funnelID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) db.LessonWithInvoices); ok {
+		r0 = rf(ctx, lessonID, studentID)
 	} else {
-		r0 = ret.Get(0).(db.Funnel)
+		r0 = ret.Get(0).(db.LessonWithInvoices)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, funnelID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, lessonID, studentID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -658,27 +6970,27 @@ func (_m *MockStore) GetFunnel(ctx context.Context, funnelID int64) (db.Funnel,
 	return r0, r1
 }
 
-// GetInvoice provides a mock function with given fields: ctx, invoiceID
-func (_m *MockStore) GetInvoice(ctx context.Context, invoiceID int64) (db.Invoice, error) {
-	ret := _m.Called(ctx, invoiceID)
+// RemoveStudentTag provides a mock function with given fields: ctx, arg
+func (_m *MockStore) RemoveStudentTag(ctx context.Context, arg db.RemoveStudentTagParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetInvoice")
+		panic("no return value specified for RemoveStudentTag")
 	}
 
-	var r0 db.Invoice
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Invoice, error)); ok {
-		return rf(ctx, invoiceID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemoveStudentTagParams) (int64, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Invoice); ok {
-		r0 = rf(ctx, invoiceID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemoveStudentTagParams) int64); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Invoice)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, invoiceID)
+	if rf, ok := ret.Get(1).(func(context.Context, db.RemoveStudentTagParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -686,29 +6998,27 @@ func (_m *MockStore) GetInvoice(ctx context.Context, invoiceID int64) (db.Invoic
 	return r0, r1
 }
 
-// GetInvoicesByLesson provides a mock function with given fields: ctx, lessonID
-func (_m *MockStore) GetInvoicesByLesson(ctx context.Context, lessonID int64) ([]db.Invoice, error) {
-	ret := _m.Called(ctx, lessonID)
+// ReplayWebhookDelivery provides a mock function with given fields: ctx, deliveryID
+func (_m *MockStore) ReplayWebhookDelivery(ctx context.Context, deliveryID int64) (db.WebhookDelivery, error) {
+	ret := _m.Called(ctx, deliveryID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetInvoicesByLesson")
+		panic("no return value specified for ReplayWebhookDelivery")
 	}
 
-	var r0 []db.Invoice
+	var r0 db.WebhookDelivery
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.Invoice, error)); ok {
-		return rf(ctx, lessonID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.WebhookDelivery, error)); ok {
+		return rf(ctx, deliveryID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.Invoice); ok {
-		r0 = rf(ctx, lessonID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.WebhookDelivery); ok {
+		r0 = rf(ctx, deliveryID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Invoice)
-		}
+		r0 = ret.Get(0).(db.WebhookDelivery)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, lessonID)
+		r1 = rf(ctx, deliveryID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -716,29 +7026,27 @@ func (_m *MockStore) GetInvoicesByLesson(ctx context.Context, lessonID int64) ([
 	return r0, r1
 }
 
-// GetInvoicesByStudent provides a mock function with given fields: ctx, studentID
-func (_m *MockStore) GetInvoicesByStudent(ctx context.Context, studentID int64) ([]db.Invoice, error) {
-	ret := _m.Called(ctx, studentID)
+// ResolveHourlyFeeTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ResolveHourlyFeeTx(ctx context.Context, arg db.GetApplicableFeeScheduleParams) (db.ResolvedHourlyFee, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetInvoicesByStudent")
+		panic("no return value specified for ResolveHourlyFeeTx")
 	}
 
-	var r0 []db.Invoice
+	var r0 db.ResolvedHourlyFee
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.Invoice, error)); ok {
-		return rf(ctx, studentID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetApplicableFeeScheduleParams) (db.ResolvedHourlyFee, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.Invoice); ok {
-		r0 = rf(ctx, studentID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetApplicableFeeScheduleParams) db.ResolvedHourlyFee); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Invoice)
-		}
+		r0 = ret.Get(0).(db.ResolvedHourlyFee)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, studentID)
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetApplicableFeeScheduleParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -746,55 +7054,45 @@ func (_m *MockStore) GetInvoicesByStudent(ctx context.Context, studentID int64)
 	return r0, r1
 }
 
-// GetLesson provides a mock function with given fields: ctx, lessonID
-func (_m *MockStore) GetLesson(ctx context.Context, lessonID int64) (db.Lesson, error) {
-	ret := _m.Called(ctx, lessonID)
+// RestoreStudentPackage provides a mock function with given fields: ctx, arg
+func (_m *MockStore) RestoreStudentPackage(ctx context.Context, arg db.RestoreStudentPackageParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLesson")
-	}
-
-	var r0 db.Lesson
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Lesson, error)); ok {
-		return rf(ctx, lessonID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Lesson); ok {
-		r0 = rf(ctx, lessonID)
-	} else {
-		r0 = ret.Get(0).(db.Lesson)
+		panic("no return value specified for RestoreStudentPackage")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, lessonID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreStudentPackageParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetLessonLocation provides a mock function with given fields: ctx, locationID
-func (_m *MockStore) GetLessonLocation(ctx context.Context, locationID int64) (db.LessonLocation, error) {
-	ret := _m.Called(ctx, locationID)
+// SetStudentCustomValueTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) SetStudentCustomValueTx(ctx context.Context, arg db.UpsertStudentCustomValueParams) (db.StudentCustomValue, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLessonLocation")
+		panic("no return value specified for SetStudentCustomValueTx")
 	}
 
-	var r0 db.LessonLocation
+	var r0 db.StudentCustomValue
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.LessonLocation, error)); ok {
-		return rf(ctx, locationID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertStudentCustomValueParams) (db.StudentCustomValue, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.LessonLocation); ok {
-		r0 = rf(ctx, locationID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertStudentCustomValueParams) db.StudentCustomValue); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.LessonLocation)
+		r0 = ret.Get(0).(db.StudentCustomValue)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, locationID)
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertStudentCustomValueParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -802,27 +7100,27 @@ func (_m *MockStore) GetLessonLocation(ctx context.Context, locationID int64) (d
 	return r0, r1
 }
 
-// GetLessonSubject provides a mock function with given fields: ctx, subjectID
-func (_m *MockStore) GetLessonSubject(ctx context.Context, subjectID int64) (db.LessonSubject, error) {
-	ret := _m.Called(ctx, subjectID)
+// TryJobLock provides a mock function with given fields: ctx, jobName
+func (_m *MockStore) TryJobLock(ctx context.Context, jobName string) (bool, error) {
+	ret := _m.Called(ctx, jobName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLessonSubject")
+		panic("no return value specified for TryJobLock")
 	}
 
-	var r0 db.LessonSubject
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.LessonSubject, error)); ok {
-		return rf(ctx, subjectID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, jobName)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.LessonSubject); ok {
-		r0 = rf(ctx, subjectID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, jobName)
 	} else {
-		r0 = ret.Get(0).(db.LessonSubject)
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, subjectID)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -830,198 +7128,170 @@ func (_m *MockStore) GetLessonSubject(ctx context.Context, subjectID int64) (db.
 	return r0, r1
 }
 
-// GetLessonWithInvoicesTx provides a mock function with given fields: ctx, lessonID
-func (_m *MockStore) GetLessonWithInvoicesTx(ctx context.Context, lessonID int64) (db.LessonWithInvoices, error) {
-	ret := _m.Called(ctx, lessonID)
+// UpdateActivity provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateActivity(ctx context.Context, arg db.UpdateActivityParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLessonWithInvoicesTx")
-	}
-
-	var r0 db.LessonWithInvoices
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.LessonWithInvoices, error)); ok {
-		return rf(ctx, lessonID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.LessonWithInvoices); ok {
-		r0 = rf(ctx, lessonID)
-	} else {
-		r0 = ret.Get(0).(db.LessonWithInvoices)
+		panic("no return value specified for UpdateActivity")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, lessonID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateActivityParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetPayment provides a mock function with given fields: ctx, paymentID
-func (_m *MockStore) GetPayment(ctx context.Context, paymentID int64) (db.Payment, error) {
-	ret := _m.Called(ctx, paymentID)
+// UpdateAvailabilityWindow provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateAvailabilityWindow(ctx context.Context, arg db.UpdateAvailabilityWindowParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPayment")
-	}
-
-	var r0 db.Payment
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Payment, error)); ok {
-		return rf(ctx, paymentID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Payment); ok {
-		r0 = rf(ctx, paymentID)
-	} else {
-		r0 = ret.Get(0).(db.Payment)
+		panic("no return value specified for UpdateAvailabilityWindow")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, paymentID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateAvailabilityWindowParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetPaymentMethod provides a mock function with given fields: ctx, paymentMethodID
-func (_m *MockStore) GetPaymentMethod(ctx context.Context, paymentMethodID int64) (db.PaymentMethod, error) {
-	ret := _m.Called(ctx, paymentMethodID)
+// UpdateCollege provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateCollege(ctx context.Context, arg db.UpdateCollegeParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPaymentMethod")
+		panic("no return value specified for UpdateCollege")
 	}
 
-	var r0 db.PaymentMethod
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.PaymentMethod, error)); ok {
-		return rf(ctx, paymentMethodID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.PaymentMethod); ok {
-		r0 = rf(ctx, paymentMethodID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateCollegeParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.PaymentMethod)
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, paymentMethodID)
+	return r0
+}
+
+// UpdateCustomField provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateCustomField(ctx context.Context, arg db.UpdateCustomFieldParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCustomField")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateCustomFieldParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetPayments provides a mock function with given fields: ctx, receiptID
-func (_m *MockStore) GetPayments(ctx context.Context, receiptID int64) ([]db.Payment, error) {
-	ret := _m.Called(ctx, receiptID)
+// UpdateExam provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateExam(ctx context.Context, arg db.UpdateExamParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPayments")
+		panic("no return value specified for UpdateExam")
 	}
 
-	var r0 []db.Payment
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.Payment, error)); ok {
-		return rf(ctx, receiptID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.Payment); ok {
-		r0 = rf(ctx, receiptID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateExamParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Payment)
-		}
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateFunnel provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateFunnel(ctx context.Context, arg db.UpdateFunnelParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFunnel")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, receiptID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateFunnelParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetReceipt provides a mock function with given fields: ctx, receiptID
-func (_m *MockStore) GetReceipt(ctx context.Context, receiptID int64) (db.Receipt, error) {
-	ret := _m.Called(ctx, receiptID)
+// UpdateFunnelCost provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateFunnelCost(ctx context.Context, arg db.UpdateFunnelCostParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReceipt")
-	}
-
-	var r0 db.Receipt
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Receipt, error)); ok {
-		return rf(ctx, receiptID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Receipt); ok {
-		r0 = rf(ctx, receiptID)
-	} else {
-		r0 = ret.Get(0).(db.Receipt)
+		panic("no return value specified for UpdateFunnelCost")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, receiptID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateFunnelCostParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetReceiptWithPaymentsTx provides a mock function with given fields: ctx, receiptID
-func (_m *MockStore) GetReceiptWithPaymentsTx(ctx context.Context, receiptID int64) (db.ReceiptWithPayments, error) {
-	ret := _m.Called(ctx, receiptID)
+// UpdateGroupPricingPlan provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateGroupPricingPlan(ctx context.Context, arg db.UpdateGroupPricingPlanParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReceiptWithPaymentsTx")
-	}
-
-	var r0 db.ReceiptWithPayments
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.ReceiptWithPayments, error)); ok {
-		return rf(ctx, receiptID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.ReceiptWithPayments); ok {
-		r0 = rf(ctx, receiptID)
-	} else {
-		r0 = ret.Get(0).(db.ReceiptWithPayments)
+		panic("no return value specified for UpdateGroupPricingPlan")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, receiptID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateGroupPricingPlanParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetReceiptsByStudent provides a mock function with given fields: ctx, arg
-func (_m *MockStore) GetReceiptsByStudent(ctx context.Context, arg db.GetReceiptsByStudentParams) ([]db.Receipt, error) {
+// UpdateGroupPricingPlanTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateGroupPricingPlanTx(ctx context.Context, arg db.GroupPricingPlanTxParams) (db.GroupPricingPlanWithTiers, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReceiptsByStudent")
+		panic("no return value specified for UpdateGroupPricingPlanTx")
 	}
 
-	var r0 []db.Receipt
+	var r0 db.GroupPricingPlanWithTiers
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.GetReceiptsByStudentParams) ([]db.Receipt, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.GroupPricingPlanTxParams) (db.GroupPricingPlanWithTiers, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.GetReceiptsByStudentParams) []db.Receipt); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.GroupPricingPlanTxParams) db.GroupPricingPlanWithTiers); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Receipt)
-		}
+		r0 = ret.Get(0).(db.GroupPricingPlanWithTiers)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.GetReceiptsByStudentParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.GroupPricingPlanTxParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -1030,84 +7300,62 @@ func (_m *MockStore) GetReceiptsByStudent(ctx context.Context, arg db.GetReceipt
 	return r0, r1
 }
 
-// GetReceiptsWithPaymentsByStudentTx provides a mock function with given fields: ctx, studentID, limit, offset
-func (_m *MockStore) GetReceiptsWithPaymentsByStudentTx(ctx context.Context, studentID int64, limit int, offset int) (db.StudentReceiptsWithPayments, error) {
-	ret := _m.Called(ctx, studentID, limit, offset)
+// UpdateGuardian provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateGuardian(ctx context.Context, arg db.UpdateGuardianParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReceiptsWithPaymentsByStudentTx")
-	}
-
-	var r0 db.StudentReceiptsWithPayments
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) (db.StudentReceiptsWithPayments, error)); ok {
-		return rf(ctx, studentID, limit, offset)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) db.StudentReceiptsWithPayments); ok {
-		r0 = rf(ctx, studentID, limit, offset)
-	} else {
-		r0 = ret.Get(0).(db.StudentReceiptsWithPayments)
+		panic("no return value specified for UpdateGuardian")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
-		r1 = rf(ctx, studentID, limit, offset)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateGuardianParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetStudent provides a mock function with given fields: ctx, studentID
-func (_m *MockStore) GetStudent(ctx context.Context, studentID int64) (db.Student, error) {
-	ret := _m.Called(ctx, studentID)
+// UpdateInvoice provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateInvoice(ctx context.Context, arg db.UpdateInvoiceParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStudent")
-	}
-
-	var r0 db.Student
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Student, error)); ok {
-		return rf(ctx, studentID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Student); ok {
-		r0 = rf(ctx, studentID)
-	} else {
-		r0 = ret.Get(0).(db.Student)
+		panic("no return value specified for UpdateInvoice")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, studentID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateInvoiceParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// ListColleges provides a mock function with given fields: ctx, arg
-func (_m *MockStore) ListColleges(ctx context.Context, arg db.ListCollegesParams) ([]db.College, error) {
+// UpdateInvoiceTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateInvoiceTx(ctx context.Context, arg db.UpdateInvoiceParams) (db.Invoice, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListColleges")
+		panic("no return value specified for UpdateInvoiceTx")
 	}
 
-	var r0 []db.College
+	var r0 db.Invoice
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListCollegesParams) ([]db.College, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateInvoiceParams) (db.Invoice, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListCollegesParams) []db.College); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateInvoiceParams) db.Invoice); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.College)
-		}
+		r0 = ret.Get(0).(db.Invoice)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListCollegesParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateInvoiceParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -1116,58 +7364,62 @@ func (_m *MockStore) ListColleges(ctx context.Context, arg db.ListCollegesParams
 	return r0, r1
 }
 
-// ListFunnels provides a mock function with given fields: ctx, arg
-func (_m *MockStore) ListFunnels(ctx context.Context, arg db.ListFunnelsParams) ([]db.Funnel, error) {
+// UpdateLead provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateLead(ctx context.Context, arg db.UpdateLeadParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListFunnels")
+		panic("no return value specified for UpdateLead")
 	}
 
-	var r0 []db.Funnel
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListFunnelsParams) ([]db.Funnel, error)); ok {
-		return rf(ctx, arg)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListFunnelsParams) []db.Funnel); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateLeadParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Funnel)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListFunnelsParams) error); ok {
-		r1 = rf(ctx, arg)
+	return r0
+}
+
+// UpdateLesson provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateLesson(ctx context.Context, arg db.UpdateLessonParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLesson")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateLessonParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// ListInvoices provides a mock function with given fields: ctx, arg
-func (_m *MockStore) ListInvoices(ctx context.Context, arg db.ListInvoicesParams) ([]db.Invoice, error) {
+// UpdateLessonAttendance provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateLessonAttendance(ctx context.Context, arg db.UpdateLessonAttendanceParams) (db.LessonAttendance, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListInvoices")
+		panic("no return value specified for UpdateLessonAttendance")
 	}
 
-	var r0 []db.Invoice
+	var r0 db.LessonAttendance
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListInvoicesParams) ([]db.Invoice, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateLessonAttendanceParams) (db.LessonAttendance, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListInvoicesParams) []db.Invoice); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateLessonAttendanceParams) db.LessonAttendance); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Invoice)
-		}
+		r0 = ret.Get(0).(db.LessonAttendance)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListInvoicesParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateLessonAttendanceParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -1176,209 +7428,207 @@ func (_m *MockStore) ListInvoices(ctx context.Context, arg db.ListInvoicesParams
 	return r0, r1
 }
 
-// ListLessonLocations provides a mock function with given fields: ctx, arg
-func (_m *MockStore) ListLessonLocations(ctx context.Context, arg db.ListLessonLocationsParams) ([]db.LessonLocation, error) {
+// UpdateLessonLocation provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateLessonLocation(ctx context.Context, arg db.UpdateLessonLocationParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListLessonLocations")
+		panic("no return value specified for UpdateLessonLocation")
 	}
 
-	var r0 []db.LessonLocation
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonLocationsParams) ([]db.LessonLocation, error)); ok {
-		return rf(ctx, arg)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonLocationsParams) []db.LessonLocation); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateLessonLocationParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.LessonLocation)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListLessonLocationsParams) error); ok {
-		r1 = rf(ctx, arg)
+	return r0
+}
+
+// UpdateLessonSubject provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateLessonSubject(ctx context.Context, arg db.UpdateLessonSubjectParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLessonSubject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateLessonSubjectParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// ListLessonSubjects provides a mock function with given fields: ctx, arg
-func (_m *MockStore) ListLessonSubjects(ctx context.Context, arg db.ListLessonSubjectsParams) ([]db.LessonSubject, error) {
+// UpdatePackageProduct provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdatePackageProduct(ctx context.Context, arg db.UpdatePackageProductParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListLessonSubjects")
+		panic("no return value specified for UpdatePackageProduct")
 	}
 
-	var r0 []db.LessonSubject
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonSubjectsParams) ([]db.LessonSubject, error)); ok {
-		return rf(ctx, arg)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdatePackageProductParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonSubjectsParams) []db.LessonSubject); ok {
+
+	return r0
+}
+
+// UpdatePayment provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdatePayment(ctx context.Context, arg db.UpdatePaymentParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdatePaymentParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.LessonSubject)
-		}
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePaymentMethod provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdatePaymentMethod(ctx context.Context, arg db.UpdatePaymentMethodParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePaymentMethod")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListLessonSubjectsParams) error); ok {
-		r1 = rf(ctx, arg)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdatePaymentMethodParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// ListLessons provides a mock function with given fields: ctx, arg
-func (_m *MockStore) ListLessons(ctx context.Context, arg db.ListLessonsParams) ([]db.Lesson, error) {
+// UpdatePipelineStage provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdatePipelineStage(ctx context.Context, arg db.UpdatePipelineStageParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListLessons")
+		panic("no return value specified for UpdatePipelineStage")
 	}
 
-	var r0 []db.Lesson
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonsParams) ([]db.Lesson, error)); ok {
-		return rf(ctx, arg)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListLessonsParams) []db.Lesson); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdatePipelineStageParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Lesson)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListLessonsParams) error); ok {
-		r1 = rf(ctx, arg)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// ListPaymentMethods provides a mock function with given fields: ctx, arg
-func (_m *MockStore) ListPaymentMethods(ctx context.Context, arg db.ListPaymentMethodsParams) ([]db.PaymentMethod, error) {
+// UpdateReceipt provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateReceipt(ctx context.Context, arg db.UpdateReceiptParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPaymentMethods")
+		panic("no return value specified for UpdateReceipt")
 	}
 
-	var r0 []db.PaymentMethod
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListPaymentMethodsParams) ([]db.PaymentMethod, error)); ok {
-		return rf(ctx, arg)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListPaymentMethodsParams) []db.PaymentMethod); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReceiptParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.PaymentMethod)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListPaymentMethodsParams) error); ok {
-		r1 = rf(ctx, arg)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// ListPayments provides a mock function with given fields: ctx, arg
-func (_m *MockStore) ListPayments(ctx context.Context, arg db.ListPaymentsParams) ([]db.Payment, error) {
+// UpdateReceiptAmount provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateReceiptAmount(ctx context.Context, arg db.UpdateReceiptAmountParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPayments")
+		panic("no return value specified for UpdateReceiptAmount")
 	}
 
-	var r0 []db.Payment
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListPaymentsParams) ([]db.Payment, error)); ok {
-		return rf(ctx, arg)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListPaymentsParams) []db.Payment); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReceiptAmountParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Payment)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListPaymentsParams) error); ok {
-		r1 = rf(ctx, arg)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// ListReceipts provides a mock function with given fields: ctx, arg
-func (_m *MockStore) ListReceipts(ctx context.Context, arg db.ListReceiptsParams) ([]db.Receipt, error) {
+// UpdateStudent provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateStudent(ctx context.Context, arg db.UpdateStudentParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListReceipts")
+		panic("no return value specified for UpdateStudent")
 	}
 
-	var r0 []db.Receipt
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListReceiptsParams) ([]db.Receipt, error)); ok {
-		return rf(ctx, arg)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListReceiptsParams) []db.Receipt); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateStudentParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Receipt)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListReceiptsParams) error); ok {
-		r1 = rf(ctx, arg)
+	return r0
+}
+
+// UpdateStudentLifecycleStatus provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateStudentLifecycleStatus(ctx context.Context, arg db.UpdateStudentLifecycleStatusParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStudentLifecycleStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateStudentLifecycleStatusParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// ListStudents provides a mock function with given fields: ctx, arg
-func (_m *MockStore) ListStudents(ctx context.Context, arg db.ListStudentsParams) ([]db.Student, error) {
-	ret := _m.Called(ctx, arg)
+// UpdateStudentsLifecycleTx provides a mock function with given fields: ctx, asOf, thresholds
+func (_m *MockStore) UpdateStudentsLifecycleTx(ctx context.Context, asOf time.Time, thresholds db.LifecycleThresholds) (int, error) {
+	ret := _m.Called(ctx, asOf, thresholds)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListStudents")
+		panic("no return value specified for UpdateStudentsLifecycleTx")
 	}
 
-	var r0 []db.Student
+	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListStudentsParams) ([]db.Student, error)); ok {
-		return rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, db.LifecycleThresholds) (int, error)); ok {
+		return rf(ctx, asOf, thresholds)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListStudentsParams) []db.Student); ok {
-		r0 = rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, db.LifecycleThresholds) int); ok {
+		r0 = rf(ctx, asOf, thresholds)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Student)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListStudentsParams) error); ok {
-		r1 = rf(ctx, arg)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, db.LifecycleThresholds) error); ok {
+		r1 = rf(ctx, asOf, thresholds)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1386,16 +7636,16 @@ func (_m *MockStore) ListStudents(ctx context.Context, arg db.ListStudentsParams
 	return r0, r1
 }
 
-// UpdateCollege provides a mock function with given fields: ctx, arg
-func (_m *MockStore) UpdateCollege(ctx context.Context, arg db.UpdateCollegeParams) error {
+// UpdateTag provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateTag(ctx context.Context, arg db.UpdateTagParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateCollege")
+		panic("no return value specified for UpdateTag")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateCollegeParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateTagParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -1404,16 +7654,16 @@ func (_m *MockStore) UpdateCollege(ctx context.Context, arg db.UpdateCollegePara
 	return r0
 }
 
-// UpdateFunnel provides a mock function with given fields: ctx, arg
-func (_m *MockStore) UpdateFunnel(ctx context.Context, arg db.UpdateFunnelParams) error {
+// UpdateTask provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateTask(ctx context.Context, arg db.UpdateTaskParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateFunnel")
+		panic("no return value specified for UpdateTask")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateFunnelParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateTaskParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -1422,52 +7672,44 @@ func (_m *MockStore) UpdateFunnel(ctx context.Context, arg db.UpdateFunnelParams
 	return r0
 }
 
-// UpdateInvoice provides a mock function with given fields: ctx, arg
-func (_m *MockStore) UpdateInvoice(ctx context.Context, arg db.UpdateInvoiceParams) error {
+// UpdateTaskTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateTaskTx(ctx context.Context, arg db.UpdateTaskParams) (db.UpdatedTask, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateInvoice")
+		panic("no return value specified for UpdateTaskTx")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateInvoiceParams) error); ok {
+	var r0 db.UpdatedTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateTaskParams) (db.UpdatedTask, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateTaskParams) db.UpdatedTask); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// UpdateLesson provides a mock function with given fields: ctx, arg
-func (_m *MockStore) UpdateLesson(ctx context.Context, arg db.UpdateLessonParams) error {
-	ret := _m.Called(ctx, arg)
-
-	if len(ret) == 0 {
-		panic("no return value specified for UpdateLesson")
+		r0 = ret.Get(0).(db.UpdatedTask)
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateLessonParams) error); ok {
-		r0 = rf(ctx, arg)
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateTaskParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// UpdateLessonLocation provides a mock function with given fields: ctx, arg
-func (_m *MockStore) UpdateLessonLocation(ctx context.Context, arg db.UpdateLessonLocationParams) error {
+// UpdateWaitlistEntry provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateWaitlistEntry(ctx context.Context, arg db.UpdateWaitlistEntryParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateLessonLocation")
+		panic("no return value specified for UpdateWaitlistEntry")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateLessonLocationParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateWaitlistEntryParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -1476,16 +7718,16 @@ func (_m *MockStore) UpdateLessonLocation(ctx context.Context, arg db.UpdateLess
 	return r0
 }
 
-// UpdateLessonSubject provides a mock function with given fields: ctx, arg
-func (_m *MockStore) UpdateLessonSubject(ctx context.Context, arg db.UpdateLessonSubjectParams) error {
+// UpdateWebhookSubscription provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateWebhookSubscription(ctx context.Context, arg db.UpdateWebhookSubscriptionParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateLessonSubject")
+		panic("no return value specified for UpdateWebhookSubscription")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateLessonSubjectParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateWebhookSubscriptionParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -1494,94 +7736,144 @@ func (_m *MockStore) UpdateLessonSubject(ctx context.Context, arg db.UpdateLesso
 	return r0
 }
 
-// UpdatePayment provides a mock function with given fields: ctx, arg
-func (_m *MockStore) UpdatePayment(ctx context.Context, arg db.UpdatePaymentParams) error {
+// UpsertLessonRecord provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpsertLessonRecord(ctx context.Context, arg db.UpsertLessonRecordParams) (db.LessonRecord, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdatePayment")
+		panic("no return value specified for UpsertLessonRecord")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdatePaymentParams) error); ok {
+	var r0 db.LessonRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertLessonRecordParams) (db.LessonRecord, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertLessonRecordParams) db.LessonRecord); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.LessonRecord)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertLessonRecordParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// UpdatePaymentMethod provides a mock function with given fields: ctx, arg
-func (_m *MockStore) UpdatePaymentMethod(ctx context.Context, arg db.UpdatePaymentMethodParams) error {
+// UpsertLessonRecordTx provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpsertLessonRecordTx(ctx context.Context, arg db.UpsertLessonRecordParams) (db.LessonRecord, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdatePaymentMethod")
+		panic("no return value specified for UpsertLessonRecordTx")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdatePaymentMethodParams) error); ok {
+	var r0 db.LessonRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertLessonRecordParams) (db.LessonRecord, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertLessonRecordParams) db.LessonRecord); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.LessonRecord)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertLessonRecordParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// UpdateReceipt provides a mock function with given fields: ctx, arg
-func (_m *MockStore) UpdateReceipt(ctx context.Context, arg db.UpdateReceiptParams) error {
+// UpsertReminderPreference provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpsertReminderPreference(ctx context.Context, arg db.UpsertReminderPreferenceParams) (db.ReminderPreference, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateReceipt")
+		panic("no return value specified for UpsertReminderPreference")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReceiptParams) error); ok {
+	var r0 db.ReminderPreference
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertReminderPreferenceParams) (db.ReminderPreference, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertReminderPreferenceParams) db.ReminderPreference); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.ReminderPreference)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertReminderPreferenceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// UpdateReceiptAmount provides a mock function with given fields: ctx, arg
-func (_m *MockStore) UpdateReceiptAmount(ctx context.Context, arg db.UpdateReceiptAmountParams) error {
+// UpsertStudentCustomValue provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpsertStudentCustomValue(ctx context.Context, arg db.UpsertStudentCustomValueParams) (db.StudentCustomValue, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateReceiptAmount")
+		panic("no return value specified for UpsertStudentCustomValue")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReceiptAmountParams) error); ok {
+	var r0 db.StudentCustomValue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertStudentCustomValueParams) (db.StudentCustomValue, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertStudentCustomValueParams) db.StudentCustomValue); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.StudentCustomValue)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertStudentCustomValueParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// UpdateStudent provides a mock function with given fields: ctx, arg
-func (_m *MockStore) UpdateStudent(ctx context.Context, arg db.UpdateStudentParams) error {
+// UpsertStudentGuardian provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpsertStudentGuardian(ctx context.Context, arg db.UpsertStudentGuardianParams) (db.StudentGuardian, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateStudent")
+		panic("no return value specified for UpsertStudentGuardian")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateStudentParams) error); ok {
+	var r0 db.StudentGuardian
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertStudentGuardianParams) (db.StudentGuardian, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertStudentGuardianParams) db.StudentGuardian); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.StudentGuardian)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertStudentGuardianParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewMockStore creates a new instance of MockStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
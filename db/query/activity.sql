@@ -0,0 +1,70 @@
+-- name: CreateActivity :one
+INSERT INTO activities (
+  student_id, activity_type, direction, occurred_at, summary, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetActivity :one
+SELECT * FROM activities
+WHERE activity_id = $1 LIMIT 1;
+
+-- name: ListActivities :many
+-- ListActivities lists the activities, latest first, optionally of a single student or type.
+SELECT * FROM activities
+WHERE (sqlc.narg(student_id)::bigint IS NULL OR student_id = sqlc.narg(student_id))
+  AND (sqlc.narg(activity_type)::varchar IS NULL OR activity_type = sqlc.narg(activity_type))
+ORDER BY occurred_at DESC, activity_id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdateActivity :exec
+UPDATE activities
+  set   activity_type = $2,
+        direction = $3,
+        occurred_at = $4,
+        summary = $5,
+        notes = $6
+WHERE activity_id = $1;
+
+-- name: DeleteActivity :execrows
+DELETE FROM activities
+WHERE activity_id = $1;
+
+-- name: GetStudentTimeline :many
+-- GetStudentTimeline returns the activities, lessons, invoices and receipts of a student in one feed, latest first.
+-- The kind of an item is activity, lesson, invoice or receipt, and item_id is the ID of the item in its table.
+-- The activity, subject and amount columns are set only for the kinds of items they belong to.
+SELECT t.kind, t.item_id, t.occurred_at, t.amount,
+       a.activity_type, a.direction, a.summary,
+       s.name AS subject_name,
+       COALESCE(a.notes, l.notes, i.notes, r.notes) AS notes
+FROM (
+  SELECT 'activity'::varchar AS kind, sa.activity_id AS item_id, sa.occurred_at, NULL::float AS amount
+  FROM activities sa
+  WHERE sa.student_id = sqlc.arg(student_id)
+  UNION ALL
+  SELECT 'lesson', sl.lesson_id, sl.lesson_datetime, NULL
+  FROM lesson_attendance la
+  JOIN lessons sl ON sl.lesson_id = la.lesson_id
+  WHERE la.student_id = sqlc.arg(student_id)
+  UNION ALL
+  SELECT 'invoice', si.invoice_id, si.invoice_datetime, si.amount
+  FROM invoices si
+  WHERE si.student_id = sqlc.arg(student_id)
+  UNION ALL
+  SELECT 'receipt', sr.receipt_id, sr.receipt_datetime, sr.amount
+  FROM receipts sr
+  WHERE sr.student_id = sqlc.arg(student_id)
+) t
+LEFT JOIN activities a ON t.kind = 'activity' AND a.activity_id = t.item_id
+LEFT JOIN lessons l ON t.kind = 'lesson' AND l.lesson_id = t.item_id
+LEFT JOIN lesson_subjects s ON s.subject_id = l.subject_id
+LEFT JOIN invoices i ON t.kind = 'invoice' AND i.invoice_id = t.item_id
+LEFT JOIN receipts r ON t.kind = 'receipt' AND r.receipt_id = t.item_id
+WHERE (sqlc.narg(start_datetime)::timestamptz IS NULL OR t.occurred_at >= sqlc.narg(start_datetime))
+  AND (sqlc.narg(end_datetime)::timestamptz IS NULL OR t.occurred_at < sqlc.narg(end_datetime))
+ORDER BY t.occurred_at DESC, t.kind, t.item_id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
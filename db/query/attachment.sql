@@ -0,0 +1,28 @@
+-- name: CreateAttachment :one
+INSERT INTO attachments (
+  student_id, lesson_id, filename, content_type, size, storage_key, description
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetAttachment :one
+SELECT * FROM attachments
+WHERE attachment_id = $1 LIMIT 1;
+
+-- name: ListAttachments :many
+-- ListAttachments lists the attachments, newest first, optionally of a single student or lesson.
+SELECT * FROM attachments
+WHERE (sqlc.narg(student_id)::bigint IS NULL OR student_id = sqlc.narg(student_id))
+  AND (sqlc.narg(lesson_id)::bigint IS NULL OR lesson_id = sqlc.narg(lesson_id))
+ORDER BY created_at DESC, attachment_id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountLessonAttachments :one
+SELECT COUNT(*) FROM attachments
+WHERE lesson_id = $1;
+
+-- name: DeleteAttachment :execrows
+DELETE FROM attachments
+WHERE attachment_id = $1;
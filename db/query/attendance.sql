@@ -0,0 +1,32 @@
+-- name: CreateLessonAttendance :one
+INSERT INTO lesson_attendance (
+  lesson_id, student_id, status, notes
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetLessonAttendance :one
+SELECT * FROM lesson_attendance
+WHERE lesson_id = $1 AND student_id = $2 LIMIT 1;
+
+-- name: GetLessonAttendanceByLesson :many
+SELECT * FROM lesson_attendance
+WHERE lesson_id = $1
+ORDER BY student_id;
+
+-- name: UpdateLessonAttendance :one
+UPDATE lesson_attendance
+  set   status = $3,
+        notes = $4,
+        updated_at = now()
+WHERE lesson_id = $1 AND student_id = $2
+RETURNING *;
+
+-- name: DeleteLessonAttendance :execrows
+DELETE FROM lesson_attendance
+WHERE lesson_id = $1 AND student_id = $2;
+
+-- name: DeleteLessonAttendanceByLesson :exec
+DELETE FROM lesson_attendance
+WHERE lesson_id = $1;
@@ -0,0 +1,52 @@
+-- name: CreateAvailabilityWindow :one
+INSERT INTO availability_windows (
+  weekday, start_minute, end_minute, timezone, location_id
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetAvailabilityWindow :one
+SELECT * FROM availability_windows
+WHERE window_id = $1 LIMIT 1;
+
+-- name: ListAvailabilityWindows :many
+-- ListAvailabilityWindows lists the weekly availability of the tutor, by weekday and time.
+SELECT * FROM availability_windows
+ORDER BY weekday, start_minute, window_id;
+
+-- name: UpdateAvailabilityWindow :exec
+UPDATE availability_windows
+  set   weekday = $2,
+        start_minute = $3,
+        end_minute = $4,
+        timezone = $5,
+        location_id = $6
+WHERE window_id = $1;
+
+-- name: DeleteAvailabilityWindow :execrows
+DELETE FROM availability_windows
+WHERE window_id = $1;
+
+-- name: CreateAvailabilityException :one
+INSERT INTO availability_exceptions (
+  start_datetime, end_datetime, available, location_id, notes
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetAvailabilityException :one
+SELECT * FROM availability_exceptions
+WHERE exception_id = $1 LIMIT 1;
+
+-- name: ListAvailabilityExceptions :many
+-- ListAvailabilityExceptions lists the availability exceptions overlapping a period, by start datetime.
+SELECT * FROM availability_exceptions
+WHERE start_datetime < sqlc.arg(end_datetime)
+  AND end_datetime > sqlc.arg(start_datetime)
+ORDER BY start_datetime, exception_id;
+
+-- name: DeleteAvailabilityException :execrows
+DELETE FROM availability_exceptions
+WHERE exception_id = $1;
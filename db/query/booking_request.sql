@@ -0,0 +1,42 @@
+-- name: CreateBookingRequest :one
+INSERT INTO booking_requests (
+  first_name, last_name, email, phone_number, subject_id, location_id, lesson_datetime, duration, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: GetBookingRequest :one
+SELECT * FROM booking_requests
+WHERE booking_request_id = $1 LIMIT 1;
+
+-- name: GetBookingRequestForUpdate :one
+SELECT * FROM booking_requests
+WHERE booking_request_id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: ListBookingRequests :many
+-- ListBookingRequests lists the booking requests, oldest first, optionally with a single status.
+SELECT * FROM booking_requests
+WHERE (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+ORDER BY created_at, booking_request_id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ApproveBookingRequest :one
+UPDATE booking_requests
+  set   status = 'approved',
+        student_id = $2,
+        lesson_id = $3,
+        decided_at = now()
+WHERE booking_request_id = $1
+RETURNING *;
+
+-- name: DeclineBookingRequest :one
+-- DeclineBookingRequest declines a pending booking request. It returns no rows if the request isn't pending.
+UPDATE booking_requests
+  set   status = 'declined',
+        decided_at = now()
+WHERE booking_request_id = $1
+  AND status = 'pending'
+RETURNING *;
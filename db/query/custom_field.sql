@@ -0,0 +1,62 @@
+-- name: CreateCustomField :one
+INSERT INTO custom_fields (
+  name, field_type, options
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetCustomField :one
+SELECT * FROM custom_fields
+WHERE field_id = $1 LIMIT 1;
+
+-- name: ListCustomFields :many
+SELECT * FROM custom_fields
+ORDER BY name
+LIMIT $1
+OFFSET $2;
+
+-- name: ListAllCustomFields :many
+SELECT * FROM custom_fields
+ORDER BY name;
+
+-- name: UpdateCustomField :exec
+-- UpdateCustomField renames a custom field and updates its enum options. The type of a field is never changed,
+-- as the values of the students would no longer match it.
+UPDATE custom_fields
+  set   name = $2,
+        options = $3
+WHERE field_id = $1;
+
+-- name: DeleteCustomField :execrows
+DELETE FROM custom_fields
+WHERE field_id = $1;
+
+-- name: UpsertStudentCustomValue :one
+INSERT INTO student_custom_values (
+  student_id, field_id, value
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (student_id, field_id) DO UPDATE
+  set   value = EXCLUDED.value,
+        updated_at = now()
+RETURNING *;
+
+-- name: DeleteStudentCustomValue :execrows
+DELETE FROM student_custom_values
+WHERE student_id = $1 AND field_id = $2;
+
+-- name: GetStudentCustomValues :many
+SELECT v.field_id, f.name, f.field_type, v.value, v.updated_at
+FROM student_custom_values v
+JOIN custom_fields f ON f.field_id = v.field_id
+WHERE v.student_id = $1
+ORDER BY f.name;
+
+-- name: GetStudentsCustomValues :many
+-- GetStudentsCustomValues returns the custom field values of several students, by student.
+SELECT student_id, field_id, value
+FROM student_custom_values
+WHERE student_id = ANY(sqlc.arg(student_ids)::bigint[])
+ORDER BY student_id, field_id;
@@ -0,0 +1,80 @@
+-- name: GetDashboardLessons :many
+-- GetDashboardLessons returns the lessons held between the datetimes, with the number of students attending each lesson,
+-- whether invoiced or attending for free, and the amount invoiced for it.
+SELECT l.lesson_id,
+       l.lesson_datetime,
+       l.duration,
+       ll.name AS location_name,
+       ls.name AS subject_name,
+       (SELECT COUNT(*) FROM lesson_attendance la WHERE la.lesson_id = l.lesson_id)::bigint AS students_count,
+       (SELECT COALESCE(SUM(i.amount), 0) FROM invoices i WHERE i.lesson_id = l.lesson_id)::float AS invoiced_amount
+FROM lessons l
+JOIN lesson_locations ll ON ll.location_id = l.location_id
+JOIN lesson_subjects ls ON ls.subject_id = l.subject_id
+WHERE l.lesson_datetime >= sqlc.arg(start_datetime)
+  AND l.lesson_datetime < sqlc.arg(end_datetime)
+ORDER BY l.lesson_datetime;
+
+-- name: GetOutstandingBalance :one
+WITH balances AS (
+  SELECT s.student_id,
+         COALESCE((SELECT SUM(i.amount) FROM invoices i WHERE i.student_id = s.student_id), 0)::float AS invoiced_amount,
+         COALESCE((SELECT SUM(r.amount) FROM receipts r WHERE r.student_id = s.student_id), 0)::float AS received_amount
+  FROM students s
+)
+SELECT COALESCE(SUM(b.invoiced_amount - b.received_amount) FILTER (WHERE b.invoiced_amount > b.received_amount), 0)::float AS outstanding_amount,
+       COALESCE(SUM(b.received_amount - b.invoiced_amount) FILTER (WHERE b.received_amount > b.invoiced_amount), 0)::float AS credit_amount,
+       COUNT(*) FILTER (WHERE b.invoiced_amount > b.received_amount) AS students_count
+FROM balances b;
+
+-- name: GetInvoicedAmount :one
+SELECT COALESCE(SUM(amount), 0)::float AS invoiced_amount
+FROM invoices
+WHERE invoice_datetime >= sqlc.arg(start_datetime)
+  AND invoice_datetime < sqlc.arg(end_datetime);
+
+-- name: CountNewStudents :one
+SELECT COUNT(*) FROM students
+WHERE created_at >= sqlc.arg(start_datetime)
+  AND created_at < sqlc.arg(end_datetime);
+
+-- name: GetUpcomingUnpaidLessons :many
+WITH balances AS (
+  SELECT i.student_id,
+         SUM(i.amount) - COALESCE((SELECT SUM(r.amount) FROM receipts r WHERE r.student_id = i.student_id), 0) AS balance
+  FROM invoices i
+  GROUP BY i.student_id
+)
+SELECT l.lesson_id,
+       l.lesson_datetime,
+       i.invoice_id,
+       i.amount,
+       s.student_id,
+       s.first_name,
+       s.last_name,
+       b.balance::float AS balance
+FROM lessons l
+JOIN invoices i ON i.lesson_id = l.lesson_id
+JOIN students s ON s.student_id = i.student_id
+JOIN balances b ON b.student_id = i.student_id
+WHERE l.lesson_datetime >= sqlc.arg(start_datetime)
+  AND b.balance > 0
+ORDER BY l.lesson_datetime, s.last_name, s.first_name
+LIMIT sqlc.arg('limit');
+
+-- name: GetUpcomingExams :many
+SELECT e.exam_id,
+       e.name,
+       e.exam_datetime,
+       e.target_grade,
+       s.student_id,
+       s.first_name,
+       s.last_name,
+       ls.name AS subject_name
+FROM exams e
+JOIN students s ON s.student_id = e.student_id
+JOIN lesson_subjects ls ON ls.subject_id = e.subject_id
+WHERE e.exam_datetime >= sqlc.arg(start_datetime)
+  AND e.exam_datetime < sqlc.arg(end_datetime)
+ORDER BY e.exam_datetime, s.last_name, s.first_name
+LIMIT sqlc.arg('limit');
@@ -0,0 +1,57 @@
+-- name: CreateOutboxEmail :one
+INSERT INTO email_outbox (
+  template, recipient, payload, student_id, lesson_id, send_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetOutboxEmail :one
+SELECT * FROM email_outbox
+WHERE email_id = $1 LIMIT 1;
+
+-- name: ListOutboxEmails :many
+SELECT * FROM email_outbox
+WHERE sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)
+ORDER BY email_id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ClaimOutboxEmails :many
+-- ClaimOutboxEmails locks pending emails that are due, and postpones them by the lease,
+-- so other dispatchers don't send them while they are being sent.
+UPDATE email_outbox
+  set   send_at = sqlc.arg(lease_until)
+WHERE email_id IN (
+  SELECT e.email_id FROM email_outbox e
+  WHERE e.status = 'pending' AND e.send_at <= sqlc.arg(now)
+  ORDER BY e.send_at
+  LIMIT sqlc.arg('limit')
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkOutboxEmailSent :exec
+UPDATE email_outbox
+  set   status = 'sent',
+        attempts = attempts + 1,
+        last_error = NULL,
+        sent_at = $2
+WHERE email_id = $1;
+
+-- name: MarkOutboxEmailFailed :exec
+UPDATE email_outbox
+  set   status = $2,
+        attempts = attempts + 1,
+        last_error = $3,
+        send_at = $4
+WHERE email_id = $1;
+
+-- name: DeletePendingOutboxEmailsByLesson :exec
+DELETE FROM email_outbox
+WHERE lesson_id = $1 AND status = 'pending';
+
+-- name: GetOutboxEmailsByStudent :many
+SELECT * FROM email_outbox
+WHERE student_id = sqlc.arg(student_id)::bigint
+ORDER BY email_id;
@@ -0,0 +1,39 @@
+-- name: CreateExam :one
+-- CreateExam creates an exam of a student. The exam is taken for the college of the student, unless a college is given.
+INSERT INTO exams (
+  student_id, subject_id, college_id, name, exam_datetime, target_grade, achieved_grade, notes
+) VALUES (
+  sqlc.arg(student_id), sqlc.arg(subject_id),
+  COALESCE(sqlc.narg(college_id)::bigint, (SELECT s.college_id FROM students s WHERE s.student_id = sqlc.arg(student_id))),
+  sqlc.arg(name), sqlc.arg(exam_datetime), sqlc.narg(target_grade), sqlc.narg(achieved_grade), sqlc.narg(notes)
+)
+RETURNING *;
+
+-- name: GetExam :one
+SELECT * FROM exams
+WHERE exam_id = $1 LIMIT 1;
+
+-- name: ListExams :many
+SELECT * FROM exams
+WHERE (sqlc.narg(student_id)::bigint IS NULL OR student_id = sqlc.narg(student_id))
+  AND (sqlc.narg(subject_id)::bigint IS NULL OR subject_id = sqlc.narg(subject_id))
+  AND (sqlc.narg(start_datetime)::timestamptz IS NULL OR exam_datetime >= sqlc.narg(start_datetime))
+  AND (sqlc.narg(end_datetime)::timestamptz IS NULL OR exam_datetime < sqlc.narg(end_datetime))
+ORDER BY exam_datetime, exam_id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdateExam :exec
+UPDATE exams
+  set   subject_id = $2,
+        college_id = $3,
+        name = $4,
+        exam_datetime = $5,
+        target_grade = $6,
+        achieved_grade = $7,
+        notes = $8
+WHERE exam_id = $1;
+
+-- name: DeleteExam :execrows
+DELETE FROM exams
+WHERE exam_id = $1;
@@ -0,0 +1,30 @@
+-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (
+  student_id, subject_id, hourly_fee, effective_from, notes
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetFeeSchedule :one
+SELECT * FROM fee_schedules
+WHERE fee_schedule_id = $1 LIMIT 1;
+
+-- name: ListFeeSchedules :many
+SELECT * FROM fee_schedules
+WHERE (sqlc.narg(student_id)::bigint IS NULL OR student_id = sqlc.narg(student_id))
+  AND (sqlc.narg(subject_id)::bigint IS NULL OR subject_id = sqlc.narg(subject_id))
+ORDER BY effective_from DESC, fee_schedule_id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: GetApplicableFeeSchedule :one
+-- GetApplicableFeeSchedule gets the fee schedule of a student in a subject at lesson_datetime.
+-- A schedule of the student in the subject precedes a schedule of the student, which precedes a schedule of the subject,
+-- which precedes a schedule of all lessons. Among schedules of the same kind the latest effective one applies.
+SELECT * FROM fee_schedules
+WHERE (student_id IS NULL OR student_id = sqlc.arg(student_id)::bigint)
+  AND (subject_id IS NULL OR subject_id = sqlc.arg(subject_id)::bigint)
+  AND effective_from <= sqlc.arg(lesson_datetime)
+ORDER BY student_id IS NULL, subject_id IS NULL, effective_from DESC
+LIMIT 1;
@@ -0,0 +1,30 @@
+-- name: CreateFunnelCost :one
+INSERT INTO funnel_costs (
+  funnel_id, cost_date, amount, notes
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetFunnelCost :one
+SELECT * FROM funnel_costs
+WHERE funnel_cost_id = $1 LIMIT 1;
+
+-- name: ListFunnelCosts :many
+SELECT * FROM funnel_costs
+WHERE funnel_id = $1
+ORDER BY cost_date
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateFunnelCost :exec
+UPDATE funnel_costs
+  set   funnel_id = $2,
+        cost_date = $3,
+        amount = $4,
+        notes = $5
+WHERE funnel_cost_id = $1;
+
+-- name: DeleteFunnelCost :exec
+DELETE FROM funnel_costs
+WHERE funnel_cost_id = $1;
@@ -0,0 +1,41 @@
+-- name: CreateGroupPricingPlan :one
+INSERT INTO group_pricing_plans (
+  name, mode, hourly_fee
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetGroupPricingPlan :one
+SELECT * FROM group_pricing_plans
+WHERE plan_id = $1 LIMIT 1;
+
+-- name: ListGroupPricingPlans :many
+SELECT * FROM group_pricing_plans
+ORDER BY name
+LIMIT $1
+OFFSET $2;
+
+-- name: UpdateGroupPricingPlan :exec
+UPDATE group_pricing_plans
+  set   name = $2,
+        mode = $3,
+        hourly_fee = $4
+WHERE plan_id = $1;
+
+-- name: CreateGroupPricingTier :one
+INSERT INTO group_pricing_tiers (
+  plan_id, min_students, hourly_fee
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetGroupPricingTiers :many
+SELECT * FROM group_pricing_tiers
+WHERE plan_id = $1
+ORDER BY min_students;
+
+-- name: DeleteGroupPricingTiers :exec
+DELETE FROM group_pricing_tiers
+WHERE plan_id = $1;
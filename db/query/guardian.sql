@@ -0,0 +1,99 @@
+-- name: CreateGuardian :one
+INSERT INTO guardians (
+  first_name, last_name, email, phone_number, address, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetGuardian :one
+SELECT * FROM guardians
+WHERE guardian_id = $1 LIMIT 1;
+
+-- name: ListGuardians :many
+SELECT * FROM guardians
+ORDER BY last_name, first_name
+LIMIT $1
+OFFSET $2;
+
+-- name: UpdateGuardian :exec
+UPDATE guardians
+  set   first_name = $2,
+        last_name = $3,
+        email = $4,
+        phone_number = $5,
+        address = $6,
+        notes = $7
+WHERE guardian_id = $1;
+
+-- name: UpsertStudentGuardian :one
+INSERT INTO student_guardians (
+  student_id, guardian_id, relationship, billing_contact
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (student_id, guardian_id) DO UPDATE
+  set   relationship = EXCLUDED.relationship,
+        billing_contact = EXCLUDED.billing_contact
+RETURNING *;
+
+-- name: GetStudentGuardian :one
+SELECT * FROM student_guardians
+WHERE student_id = $1 AND guardian_id = $2 LIMIT 1;
+
+-- name: ClearStudentBillingContact :exec
+UPDATE student_guardians
+  set   billing_contact = false
+WHERE student_id = $1 AND billing_contact;
+
+-- name: DeleteStudentGuardian :execrows
+DELETE FROM student_guardians
+WHERE student_id = $1 AND guardian_id = $2;
+
+-- name: GetStudentGuardians :many
+SELECT g.*, sg.relationship, sg.billing_contact
+FROM student_guardians sg
+JOIN guardians g ON g.guardian_id = sg.guardian_id
+WHERE sg.student_id = $1
+ORDER BY sg.billing_contact DESC, g.last_name, g.first_name;
+
+-- name: GetGuardianStudents :many
+SELECT s.*, sg.relationship, sg.billing_contact
+FROM student_guardians sg
+JOIN students s ON s.student_id = sg.student_id
+WHERE sg.guardian_id = $1
+ORDER BY s.last_name, s.first_name;
+
+-- name: GetBillingContact :one
+SELECT g.* FROM student_guardians sg
+JOIN guardians g ON g.guardian_id = sg.guardian_id
+WHERE sg.student_id = $1 AND sg.billing_contact
+LIMIT 1;
+
+-- name: CreateGuardianReceipt :one
+INSERT INTO guardian_receipts (
+  guardian_id, receipt_datetime, amount, notes
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetGuardianReceipt :one
+SELECT * FROM guardian_receipts
+WHERE guardian_receipt_id = $1 LIMIT 1;
+
+-- name: ListGuardianReceipts :many
+SELECT * FROM guardian_receipts
+WHERE guardian_id = $1
+ORDER BY receipt_datetime DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: GetReceiptsByGuardianReceipt :many
+SELECT * FROM receipts
+WHERE guardian_receipt_id = $1
+ORDER BY student_id;
+
+-- name: DeleteGuardianReceipt :exec
+DELETE FROM guardian_receipts
+WHERE guardian_receipt_id = $1;
@@ -1,8 +1,8 @@
 -- name: CreateInvoice :one
 INSERT INTO invoices (
-  student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes
+  student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes, fee_schedule_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
 )
 RETURNING *;
 
@@ -15,6 +15,11 @@ SELECT * FROM invoices
 WHERE lesson_id = $1
 ORDER BY student_id;
 
+-- name: GetInvoiceByLessonStudent :one
+SELECT * FROM invoices
+WHERE lesson_id = $1 AND student_id = $2
+LIMIT 1;
+
 -- name: GetInvoicesByStudent :many
 SELECT * FROM invoices
 WHERE student_id = $1
@@ -35,7 +40,8 @@ UPDATE invoices
         duration = $6, 
         discount = $7,
         amount =  $8,
-        notes = $9
+        notes = $9,
+        fee_schedule_id = $10
 WHERE invoice_id = $1;
 
 -- name: DeleteInvoice :exec
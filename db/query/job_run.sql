@@ -0,0 +1,48 @@
+-- name: TryJobLock :one
+-- TryJobLock tries to take the advisory lock of a job, without waiting for it.
+-- The lock is held until the end of the transaction, so it must be called within a transaction.
+SELECT pg_try_advisory_xact_lock(hashtext(sqlc.arg(job_name)::text))::boolean AS locked;
+
+-- name: CreateJobRun :one
+INSERT INTO job_runs (
+  job_name, trigger, scheduled_at
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: FinishJobRun :one
+UPDATE job_runs
+  set   status = $2,
+        finished_at = now(),
+        error = $3
+WHERE run_id = $1
+RETURNING *;
+
+-- name: GetJobRun :one
+SELECT * FROM job_runs
+WHERE run_id = $1 LIMIT 1;
+
+-- name: GetLastScheduledJobRun :one
+-- GetLastScheduledJobRun returns the scheduled run of a job that was due last.
+SELECT * FROM job_runs
+WHERE job_name = $1 AND trigger = 'schedule'
+ORDER BY scheduled_at DESC
+LIMIT 1;
+
+-- name: GetLatestJobRuns :many
+-- GetLatestJobRuns returns the latest run of every job.
+SELECT DISTINCT ON (job_name) * FROM job_runs
+ORDER BY job_name, started_at DESC;
+
+-- name: ListJobRuns :many
+SELECT * FROM job_runs
+WHERE sqlc.narg(job_name)::varchar IS NULL OR job_name = sqlc.narg(job_name)
+ORDER BY run_id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: DeleteJobRunsBefore :execrows
+-- DeleteJobRunsBefore deletes the finished runs that started before a specific time.
+DELETE FROM job_runs
+WHERE started_at < $1 AND status <> 'running';
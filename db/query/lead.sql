@@ -0,0 +1,51 @@
+-- name: CreateLead :one
+INSERT INTO leads (
+  first_name, last_name, email, phone_number, funnel_id, stage_id, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetLead :one
+SELECT * FROM leads
+WHERE lead_id = $1 LIMIT 1;
+
+-- name: GetLeadForUpdate :one
+SELECT * FROM leads
+WHERE lead_id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: ListLeads :many
+-- ListLeads lists the leads, newest first, optionally of a single funnel or stage, or with a single status.
+SELECT * FROM leads
+WHERE (sqlc.narg(funnel_id)::bigint IS NULL OR funnel_id = sqlc.narg(funnel_id))
+  AND (sqlc.narg(stage_id)::bigint IS NULL OR stage_id = sqlc.narg(stage_id))
+  AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+ORDER BY created_at DESC, lead_id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdateLead :exec
+-- UpdateLead updates a lead. The status of a converted lead is kept, as a lead is converted only by ConvertLeadTx.
+UPDATE leads
+  set   first_name = $2,
+        last_name = $3,
+        email = $4,
+        phone_number = $5,
+        funnel_id = $6,
+        stage_id = $7,
+        notes = $8,
+        status = CASE WHEN status = 'converted' THEN status ELSE sqlc.arg(status) END
+WHERE lead_id = $1;
+
+-- name: ConvertLead :one
+UPDATE leads
+  set   status = 'converted',
+        student_id = $2,
+        converted_at = now()
+WHERE lead_id = $1
+RETURNING *;
+
+-- name: DeleteLead :execrows
+DELETE FROM leads
+WHERE lead_id = $1;
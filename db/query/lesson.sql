@@ -1,8 +1,8 @@
 -- name: CreateLesson :one
 INSERT INTO lessons (
-  lesson_datetime, duration, location_id, subject_id, notes
+  lesson_datetime, duration, location_id, subject_id, notes, pricing_plan_id
 ) VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6
 )
 RETURNING *;
 
@@ -27,4 +27,17 @@ WHERE lesson_id = $1;
 
 -- name: DeleteLesson :exec
 DELETE FROM lessons
-WHERE lesson_id = $1;
\ No newline at end of file
+WHERE lesson_id = $1;
+
+-- name: LockLessonSchedule :exec
+-- LockLessonSchedule waits for the advisory lock of the lesson schedule, so lessons are created one at a time
+-- and a lesson checked not to overlap others isn't overlapped by a lesson created concurrently.
+-- The lock is held until the end of the transaction, so it must be called within a transaction.
+SELECT pg_advisory_xact_lock(hashtext('lesson_schedule'));
+
+-- name: ListLessonsBetween :many
+-- ListLessonsBetween lists the lessons overlapping a period, by datetime.
+SELECT * FROM lessons
+WHERE lesson_datetime < sqlc.arg(end_datetime)
+  AND lesson_datetime + duration * interval '1 minute' > sqlc.arg(start_datetime)
+ORDER BY lesson_datetime, lesson_id;
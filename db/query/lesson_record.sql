@@ -0,0 +1,49 @@
+-- name: UpsertLessonRecord :one
+INSERT INTO lesson_records (
+  lesson_id, student_id, topics, homework, homework_status, rating, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (lesson_id, student_id) DO UPDATE
+  set   topics = EXCLUDED.topics,
+        homework = EXCLUDED.homework,
+        homework_status = EXCLUDED.homework_status,
+        rating = EXCLUDED.rating,
+        notes = EXCLUDED.notes,
+        updated_at = now()
+RETURNING *;
+
+-- name: GetLessonRecord :one
+SELECT * FROM lesson_records
+WHERE lesson_id = $1 AND student_id = $2 LIMIT 1;
+
+-- name: GetLessonRecordsByLesson :many
+SELECT * FROM lesson_records
+WHERE lesson_id = $1
+ORDER BY student_id;
+
+-- name: DeleteLessonRecord :exec
+DELETE FROM lesson_records
+WHERE lesson_id = $1 AND student_id = $2;
+
+-- name: DeleteLessonRecordsByLesson :exec
+DELETE FROM lesson_records
+WHERE lesson_id = $1;
+
+-- name: GetStudentProgress :many
+-- GetStudentProgress returns the lessons of a student, latest first, with the attendance and lesson record of the student.
+-- Lessons without a record are included, so the timeline shows every lesson of the student.
+SELECT l.lesson_id, l.lesson_datetime, l.duration, l.subject_id, s.name AS subject_name,
+       a.status AS attendance_status,
+       r.topics, r.homework, r.homework_status, r.rating, r.notes, r.updated_at
+FROM lesson_attendance a
+JOIN lessons l ON l.lesson_id = a.lesson_id
+JOIN lesson_subjects s ON s.subject_id = l.subject_id
+LEFT JOIN lesson_records r ON r.lesson_id = a.lesson_id AND r.student_id = a.student_id
+WHERE a.student_id = sqlc.arg(student_id)
+  AND (sqlc.narg(subject_id)::bigint IS NULL OR l.subject_id = sqlc.narg(subject_id))
+  AND (sqlc.narg(start_datetime)::timestamptz IS NULL OR l.lesson_datetime >= sqlc.narg(start_datetime))
+  AND (sqlc.narg(end_datetime)::timestamptz IS NULL OR l.lesson_datetime < sqlc.narg(end_datetime))
+ORDER BY l.lesson_datetime DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
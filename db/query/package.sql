@@ -0,0 +1,123 @@
+-- name: CreatePackageProduct :one
+INSERT INTO package_products (
+  name, unit, quantity, price, validity_days, active
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetPackageProduct :one
+SELECT * FROM package_products
+WHERE product_id = $1 LIMIT 1;
+
+-- name: ListPackageProducts :many
+SELECT * FROM package_products
+ORDER BY name
+LIMIT $1
+OFFSET $2;
+
+-- name: UpdatePackageProduct :exec
+UPDATE package_products
+  set   name = $2,
+        unit = $3,
+        quantity = $4,
+        price = $5,
+        validity_days = $6,
+        active = $7
+WHERE product_id = $1;
+
+-- name: CreateStudentPackage :one
+INSERT INTO student_packages (
+  student_id, product_id, receipt_id, unit, total_units, remaining_units, price, purchased_at, expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetStudentPackage :one
+SELECT * FROM student_packages
+WHERE package_id = $1 LIMIT 1;
+
+-- name: ListStudentPackages :many
+SELECT * FROM student_packages
+WHERE sqlc.narg(student_id)::bigint IS NULL OR student_id = sqlc.narg(student_id)
+ORDER BY purchased_at DESC, package_id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: GetUsableStudentPackage :one
+-- GetUsableStudentPackage locks the package of a student to deduct a lesson of duration minutes held at lesson_datetime from.
+-- The package must not be expired at the lesson time, and must have a lesson or duration minutes remaining.
+-- The package expiring first is used, and packages without an expiry date are used last.
+SELECT * FROM student_packages
+WHERE student_id = sqlc.arg(student_id)
+  AND (expires_at IS NULL OR expires_at > sqlc.arg(lesson_datetime)::timestamptz)
+  AND remaining_units >= CASE unit WHEN 'lesson' THEN 1 ELSE sqlc.arg(duration)::bigint END
+ORDER BY expires_at NULLS LAST, purchased_at, package_id
+LIMIT 1
+FOR UPDATE;
+
+-- name: DeductStudentPackage :exec
+UPDATE student_packages
+  set   remaining_units = remaining_units - sqlc.arg(units)
+WHERE package_id = sqlc.arg(package_id);
+
+-- name: RestoreStudentPackage :exec
+UPDATE student_packages
+  set   remaining_units = remaining_units + sqlc.arg(units)
+WHERE package_id = sqlc.arg(package_id);
+
+-- name: CreatePackageDeduction :one
+INSERT INTO package_deductions (
+  package_id, invoice_id, units, amount
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetPackageDeductionByInvoice :one
+SELECT * FROM package_deductions
+WHERE invoice_id = $1 LIMIT 1;
+
+-- name: DeletePackageDeduction :exec
+DELETE FROM package_deductions
+WHERE deduction_id = $1;
+
+-- name: GetPackageDeductionsByPackage :many
+SELECT * FROM package_deductions
+WHERE package_id = $1
+ORDER BY deduction_id;
+
+-- name: GetPackageDeductionsByLesson :many
+SELECT pd.* FROM package_deductions pd
+JOIN invoices i ON i.invoice_id = pd.invoice_id
+WHERE i.lesson_id = $1
+ORDER BY pd.deduction_id;
+
+-- name: DeletePackageDeductionsByLesson :exec
+DELETE FROM package_deductions pd
+USING invoices i
+WHERE i.invoice_id = pd.invoice_id AND i.lesson_id = $1;
+
+-- name: GetActiveStudentPackages :many
+-- GetActiveStudentPackages returns the packages of a student with units remaining that aren't expired at as_of,
+-- in the order they are deducted from.
+SELECT * FROM student_packages
+WHERE student_id = sqlc.arg(student_id)
+  AND remaining_units > 0
+  AND (expires_at IS NULL OR expires_at > sqlc.arg(as_of)::timestamptz)
+ORDER BY expires_at NULLS LAST, purchased_at, package_id;
+
+-- name: GetStudentPackageBalances :many
+-- GetStudentPackageBalances returns the balances of the active packages of a student at as_of, by unit:
+-- the remaining lessons or minutes, and their value.
+SELECT unit,
+       COUNT(*)::bigint AS packages,
+       SUM(remaining_units)::bigint AS remaining_units,
+       SUM(price * remaining_units / total_units)::float AS remaining_amount
+FROM student_packages
+WHERE student_id = sqlc.arg(student_id)
+  AND remaining_units > 0
+  AND (expires_at IS NULL OR expires_at > sqlc.arg(as_of)::timestamptz)
+GROUP BY unit
+ORDER BY unit;
@@ -0,0 +1,26 @@
+-- name: CreatePipelineStage :one
+INSERT INTO pipeline_stages (
+  name, position
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: GetPipelineStage :one
+SELECT * FROM pipeline_stages
+WHERE stage_id = $1 LIMIT 1;
+
+-- name: ListPipelineStages :many
+-- ListPipelineStages lists the stages in their pipeline order.
+SELECT * FROM pipeline_stages
+ORDER BY position, stage_id;
+
+-- name: UpdatePipelineStage :exec
+UPDATE pipeline_stages
+  set   name = $2,
+        position = $3
+WHERE stage_id = $1;
+
+-- name: DeletePipelineStage :execrows
+DELETE FROM pipeline_stages
+WHERE stage_id = $1;
@@ -0,0 +1,64 @@
+-- name: CreateProgressReports :many
+-- CreateProgressReports creates the progress reports of a period for every student with lessons in the period,
+-- optionally for a single student.
+-- Students that already have a progress report of the period are skipped, so reporting a period is idempotent.
+INSERT INTO progress_reports (
+  student_id, period_start, period_end, lessons_count, attended_count, hours, homework_assigned_count, homework_completed_count
+)
+SELECT a.student_id,
+       sqlc.arg(period_start)::timestamptz,
+       sqlc.arg(period_end)::timestamptz,
+       COUNT(*),
+       COUNT(*) FILTER (WHERE a.status = 'attended'),
+       (COALESCE(SUM(l.duration) FILTER (WHERE a.status = 'attended'), 0) / 60.0)::float,
+       COUNT(r.lesson_id) FILTER (WHERE r.homework_status <> 'none'),
+       COUNT(r.lesson_id) FILTER (WHERE r.homework_status = 'completed')
+FROM lesson_attendance a
+JOIN lessons l ON l.lesson_id = a.lesson_id
+LEFT JOIN lesson_records r ON r.lesson_id = a.lesson_id AND r.student_id = a.student_id
+WHERE l.lesson_datetime >= sqlc.arg(period_start) AND l.lesson_datetime < sqlc.arg(period_end)
+  AND (sqlc.narg(student_id)::bigint IS NULL OR a.student_id = sqlc.narg(student_id))
+GROUP BY a.student_id
+ON CONFLICT (student_id, period_start, period_end) DO NOTHING
+RETURNING *;
+
+-- name: GetProgressReport :one
+SELECT * FROM progress_reports
+WHERE progress_report_id = $1 LIMIT 1;
+
+-- name: ListProgressReports :many
+SELECT * FROM progress_reports
+WHERE (sqlc.narg(student_id)::bigint IS NULL OR student_id = sqlc.narg(student_id))
+  AND (sqlc.narg(period_start)::timestamptz IS NULL OR period_start = sqlc.narg(period_start))
+ORDER BY period_start DESC, student_id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: GetProgressReportSubjects :many
+-- GetProgressReportSubjects returns the lessons and hours of a student in each subject in a period.
+-- Hours are of the attended lessons only.
+SELECT s.subject_id, s.name,
+       COUNT(*) AS lessons_count,
+       COUNT(*) FILTER (WHERE a.status = 'attended') AS attended_count,
+       (COALESCE(SUM(l.duration) FILTER (WHERE a.status = 'attended'), 0) / 60.0)::float AS hours
+FROM lesson_attendance a
+JOIN lessons l ON l.lesson_id = a.lesson_id
+JOIN lesson_subjects s ON s.subject_id = l.subject_id
+WHERE a.student_id = sqlc.arg(student_id)
+  AND l.lesson_datetime >= sqlc.arg(start_datetime) AND l.lesson_datetime < sqlc.arg(end_datetime)
+GROUP BY s.subject_id, s.name
+ORDER BY s.name;
+
+-- name: GetProgressReportLessons :many
+-- GetProgressReportLessons returns the lessons of a student in a period, earliest first,
+-- with the attendance and lesson record of the student.
+SELECT l.lesson_id, l.lesson_datetime, l.duration, l.subject_id, s.name AS subject_name,
+       a.status AS attendance_status,
+       r.topics, r.homework, r.homework_status, r.rating, r.notes
+FROM lesson_attendance a
+JOIN lessons l ON l.lesson_id = a.lesson_id
+JOIN lesson_subjects s ON s.subject_id = l.subject_id
+LEFT JOIN lesson_records r ON r.lesson_id = a.lesson_id AND r.student_id = a.student_id
+WHERE a.student_id = sqlc.arg(student_id)
+  AND l.lesson_datetime >= sqlc.arg(start_datetime) AND l.lesson_datetime < sqlc.arg(end_datetime)
+ORDER BY l.lesson_datetime;
@@ -1,8 +1,8 @@
 -- name: CreateReceipt :one
 INSERT INTO receipts (
-  student_id, receipt_datetime, amount, notes
+  student_id, receipt_datetime, amount, notes, guardian_receipt_id
 ) VALUES (
-  $1, $2, $3, $4
+  $1, $2, $3, $4, $5
 )
 RETURNING *;
 
@@ -0,0 +1,82 @@
+-- name: UpsertReminderPreference :one
+INSERT INTO reminder_preferences (
+  student_id, channel, lead_minutes
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (student_id) DO UPDATE
+  set   channel = EXCLUDED.channel,
+        lead_minutes = EXCLUDED.lead_minutes,
+        updated_at = now()
+RETURNING *;
+
+-- name: GetReminderPreference :one
+SELECT * FROM reminder_preferences
+WHERE student_id = $1 LIMIT 1;
+
+-- name: GetDueLessonReminders :many
+-- GetDueLessonReminders returns the reminders due for students attending lessons held up to horizon,
+-- whether invoiced or attending for free. Students marked absent or excused aren't reminded.
+-- Students without reminder preferences are reminded by email, 24 hours before the lesson.
+-- Reminders already sent, failed after max_attempts, or being sent by another scheduler since stale_before, are excluded.
+SELECT l.lesson_id,
+       l.lesson_datetime,
+       l.duration,
+       s.student_id,
+       s.first_name,
+       s.last_name,
+       s.email,
+       s.phone_number,
+       COALESCE(rp.channel, 'email')::varchar AS channel,
+       COALESCE(rp.lead_minutes, 1440)::int AS lead_minutes
+FROM lessons l
+JOIN lesson_attendance la ON la.lesson_id = l.lesson_id
+JOIN students s ON s.student_id = la.student_id
+LEFT JOIN reminder_preferences rp ON rp.student_id = s.student_id
+LEFT JOIN lesson_reminders lr ON lr.lesson_id = l.lesson_id AND lr.student_id = s.student_id
+WHERE l.lesson_datetime > sqlc.arg(now)::timestamptz
+  AND l.lesson_datetime <= sqlc.arg(horizon)::timestamptz
+  AND l.lesson_datetime - make_interval(mins => COALESCE(rp.lead_minutes, 1440)) <= sqlc.arg(now)::timestamptz
+  AND la.status = 'attended'
+  AND COALESCE(rp.channel, 'email') <> 'none'
+  AND (lr.reminder_id IS NULL
+       OR (lr.status = 'failed' AND lr.attempts < sqlc.arg(max_attempts)::int)
+       OR (lr.status = 'sending' AND lr.claimed_at < sqlc.arg(stale_before)::timestamptz))
+ORDER BY l.lesson_datetime;
+
+-- name: ClaimLessonReminder :one
+-- ClaimLessonReminder claims the reminder of a student for a lesson, so only one scheduler sends it.
+-- A failed reminder is claimed again until it was attempted max_attempts times,
+-- and a reminder claimed before stale_before by a scheduler that stopped is claimed again.
+-- No row is returned if the reminder can't be claimed.
+INSERT INTO lesson_reminders (
+  lesson_id, student_id, channel
+) VALUES (
+  sqlc.arg(lesson_id), sqlc.arg(student_id), sqlc.arg(channel)
+)
+ON CONFLICT (lesson_id, student_id) DO UPDATE
+  set   status = 'sending',
+        channel = EXCLUDED.channel,
+        attempts = lesson_reminders.attempts + 1,
+        claimed_at = now()
+  WHERE (lesson_reminders.status = 'failed' AND lesson_reminders.attempts < sqlc.arg(max_attempts)::int)
+     OR (lesson_reminders.status = 'sending' AND lesson_reminders.claimed_at < sqlc.arg(stale_before)::timestamptz)
+RETURNING *;
+
+-- name: MarkLessonReminderSent :exec
+UPDATE lesson_reminders
+  set   status = 'sent',
+        last_error = NULL,
+        sent_at = $2
+WHERE reminder_id = $1;
+
+-- name: MarkLessonReminderFailed :exec
+UPDATE lesson_reminders
+  set   status = 'failed',
+        last_error = $2
+WHERE reminder_id = $1;
+
+-- name: GetLessonRemindersByLesson :many
+SELECT * FROM lesson_reminders
+WHERE lesson_id = $1
+ORDER BY student_id;
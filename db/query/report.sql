@@ -0,0 +1,191 @@
+-- name: GetRevenueReport :many
+WITH invoiced AS (
+  SELECT date_trunc(sqlc.arg(period)::text, invoice_datetime) AS period_start,
+         SUM(amount) AS amount
+  FROM invoices
+  WHERE invoice_datetime >= sqlc.arg(start_datetime) AND invoice_datetime < sqlc.arg(end_datetime)
+  GROUP BY period_start
+), collected AS (
+  SELECT date_trunc(sqlc.arg(period)::text, receipt_datetime) AS period_start,
+         SUM(amount) AS amount
+  FROM receipts
+  WHERE receipt_datetime >= sqlc.arg(start_datetime) AND receipt_datetime < sqlc.arg(end_datetime)
+  GROUP BY period_start
+)
+SELECT COALESCE(i.period_start, c.period_start)::timestamptz AS period_start,
+       COALESCE(i.amount, 0)::float AS invoiced_amount,
+       COALESCE(c.amount, 0)::float AS collected_amount
+FROM invoiced i
+FULL OUTER JOIN collected c ON c.period_start = i.period_start
+ORDER BY 1;
+
+-- name: GetSubjectHoursReport :many
+SELECT date_trunc(sqlc.arg(period)::text, l.lesson_datetime)::timestamptz AS period_start,
+       s.subject_id,
+       s.name,
+       COUNT(*) AS lessons_count,
+       (SUM(l.duration) / 60.0)::float AS hours
+FROM lessons l
+JOIN lesson_subjects s ON s.subject_id = l.subject_id
+WHERE l.lesson_datetime >= sqlc.arg(start_datetime) AND l.lesson_datetime < sqlc.arg(end_datetime)
+GROUP BY 1, s.subject_id, s.name
+ORDER BY 1, s.name;
+
+-- name: GetLocationHoursReport :many
+SELECT date_trunc(sqlc.arg(period)::text, l.lesson_datetime)::timestamptz AS period_start,
+       loc.location_id,
+       loc.name,
+       COUNT(*) AS lessons_count,
+       (SUM(l.duration) / 60.0)::float AS hours
+FROM lessons l
+JOIN lesson_locations loc ON loc.location_id = l.location_id
+WHERE l.lesson_datetime >= sqlc.arg(start_datetime) AND l.lesson_datetime < sqlc.arg(end_datetime)
+GROUP BY 1, loc.location_id, loc.name
+ORDER BY 1, loc.name;
+
+-- name: GetCollegeRevenueReport :many
+SELECT date_trunc(sqlc.arg(period)::text, i.invoice_datetime)::timestamptz AS period_start,
+       c.college_id,
+       c.name,
+       COUNT(DISTINCT i.student_id) AS students_count,
+       COALESCE(SUM(i.amount), 0)::float AS invoiced_amount
+FROM invoices i
+JOIN students s ON s.student_id = i.student_id
+LEFT JOIN colleges c ON c.college_id = s.college_id
+WHERE i.invoice_datetime >= sqlc.arg(start_datetime) AND i.invoice_datetime < sqlc.arg(end_datetime)
+GROUP BY 1, c.college_id, c.name
+ORDER BY 1, c.name;
+
+-- name: GetFunnelRevenueReport :many
+SELECT date_trunc(sqlc.arg(period)::text, i.invoice_datetime)::timestamptz AS period_start,
+       f.funnel_id,
+       f.name,
+       COUNT(DISTINCT i.student_id) AS students_count,
+       COALESCE(SUM(i.amount), 0)::float AS invoiced_amount
+FROM invoices i
+JOIN students s ON s.student_id = i.student_id
+LEFT JOIN funnels f ON f.funnel_id = s.funnel_id
+WHERE i.invoice_datetime >= sqlc.arg(start_datetime) AND i.invoice_datetime < sqlc.arg(end_datetime)
+GROUP BY 1, f.funnel_id, f.name
+ORDER BY 1, f.name;
+
+-- name: GetAgingReport :many
+WITH paid AS (
+  SELECT student_id, SUM(amount) AS amount
+  FROM receipts
+  WHERE receipt_datetime < sqlc.arg(as_of_datetime)
+  GROUP BY student_id
+), invoiced AS (
+  SELECT student_id, invoice_datetime, amount,
+         SUM(amount) OVER (PARTITION BY student_id ORDER BY invoice_datetime, invoice_id) AS cumulative_amount
+  FROM invoices
+  WHERE invoice_datetime < sqlc.arg(as_of_datetime)
+), unpaid AS (
+  SELECT inv.student_id,
+         EXTRACT(DAY FROM sqlc.arg(as_of_datetime)::timestamptz - inv.invoice_datetime) AS age_days,
+         LEAST(inv.amount, inv.cumulative_amount - COALESCE(p.amount, 0)) AS amount
+  FROM invoiced inv
+  LEFT JOIN paid p ON p.student_id = inv.student_id
+  WHERE inv.cumulative_amount > COALESCE(p.amount, 0)
+)
+SELECT s.student_id,
+       s.first_name,
+       s.last_name,
+       COALESCE(SUM(u.amount) FILTER (WHERE u.age_days <= 30), 0)::float AS days_0_to_30,
+       COALESCE(SUM(u.amount) FILTER (WHERE u.age_days > 30 AND u.age_days <= 60), 0)::float AS days_31_to_60,
+       COALESCE(SUM(u.amount) FILTER (WHERE u.age_days > 60 AND u.age_days <= 90), 0)::float AS days_61_to_90,
+       COALESCE(SUM(u.amount) FILTER (WHERE u.age_days > 90), 0)::float AS days_over_90,
+       COALESCE(SUM(u.amount), 0)::float AS total_amount
+FROM unpaid u
+JOIN students s ON s.student_id = u.student_id
+GROUP BY s.student_id, s.first_name, s.last_name
+ORDER BY total_amount DESC, s.last_name, s.first_name;
+
+-- name: GetStudentAgingInvoices :many
+WITH paid AS (
+  SELECT COALESCE(SUM(r.amount), 0) AS amount
+  FROM receipts r
+  WHERE r.student_id = sqlc.arg(student_id) AND r.receipt_datetime < sqlc.arg(as_of_datetime)
+), invoiced AS (
+  SELECT i.invoice_id, i.lesson_id, i.invoice_datetime, i.amount,
+         SUM(i.amount) OVER (ORDER BY i.invoice_datetime, i.invoice_id) AS cumulative_amount
+  FROM invoices i
+  WHERE i.student_id = sqlc.arg(student_id) AND i.invoice_datetime < sqlc.arg(as_of_datetime)
+)
+SELECT inv.invoice_id,
+       inv.lesson_id,
+       inv.invoice_datetime,
+       inv.amount,
+       LEAST(inv.amount, inv.cumulative_amount - p.amount)::float AS unpaid_amount,
+       EXTRACT(DAY FROM sqlc.arg(as_of_datetime)::timestamptz - inv.invoice_datetime)::bigint AS age_days
+FROM invoiced inv, paid p
+WHERE inv.cumulative_amount > p.amount
+ORDER BY inv.invoice_datetime, inv.invoice_id;
+
+-- name: GetFunnelCohortReport :many
+WITH cohort_students AS (
+  SELECT s.student_id, s.funnel_id, s.created_at,
+         date_trunc(sqlc.arg(period)::text, s.created_at) AS cohort_start
+  FROM students s
+  WHERE s.created_at >= sqlc.arg(start_datetime) AND s.created_at < sqlc.arg(end_datetime)
+), student_lessons AS (
+  SELECT i.student_id,
+         MIN(l.lesson_datetime) AS first_lesson_datetime,
+         MAX(l.lesson_datetime) AS last_lesson_datetime,
+         SUM(i.amount) AS revenue
+  FROM invoices i
+  JOIN lessons l ON l.lesson_id = i.lesson_id
+  GROUP BY i.student_id
+), cohorts AS (
+  SELECT cs.funnel_id, cs.cohort_start, COUNT(*) AS students_count
+  FROM cohort_students cs
+  GROUP BY cs.funnel_id, cs.cohort_start
+), conversions AS (
+  SELECT cs.funnel_id, cs.cohort_start,
+         COUNT(*) AS converted_count,
+         AVG(EXTRACT(EPOCH FROM sl.first_lesson_datetime - cs.created_at) / 86400)::float AS avg_days_to_first_lesson,
+         COUNT(*) FILTER (WHERE sl.last_lesson_datetime >= sqlc.arg(retained_since)::timestamptz) AS retained_count,
+         SUM(sl.revenue)::float AS lifetime_revenue
+  FROM cohort_students cs
+  JOIN student_lessons sl ON sl.student_id = cs.student_id
+  GROUP BY cs.funnel_id, cs.cohort_start
+), costs AS (
+  SELECT fc.funnel_id,
+         date_trunc(sqlc.arg(period)::text, fc.cost_date::timestamptz) AS cohort_start,
+         SUM(fc.amount)::float AS acquisition_cost
+  FROM funnel_costs fc
+  WHERE fc.cost_date >= sqlc.arg(start_datetime)::date AND fc.cost_date < sqlc.arg(end_datetime)::date
+  GROUP BY 1, 2
+)
+SELECT c.cohort_start::timestamptz AS cohort_start,
+       f.funnel_id,
+       f.name,
+       c.students_count,
+       COALESCE(cv.converted_count, 0)::bigint AS converted_count,
+       cv.avg_days_to_first_lesson,
+       COALESCE(cv.retained_count, 0)::bigint AS retained_count,
+       COALESCE(cv.lifetime_revenue, 0)::float AS lifetime_revenue,
+       co.acquisition_cost
+FROM cohorts c
+LEFT JOIN funnels f ON f.funnel_id = c.funnel_id
+LEFT JOIN conversions cv ON cv.funnel_id IS NOT DISTINCT FROM c.funnel_id AND cv.cohort_start = c.cohort_start
+LEFT JOIN costs co ON co.funnel_id = c.funnel_id AND co.cohort_start = c.cohort_start
+ORDER BY 1, f.name;
+
+-- name: GetExamOutcomeReport :many
+-- GetExamOutcomeReport returns the outcomes of the exams of each subject.
+-- Averages are of the graded exams only, and are 0 if there are none.
+SELECT date_trunc(sqlc.arg(period)::text, e.exam_datetime)::timestamptz AS period_start,
+       ls.subject_id,
+       ls.name,
+       COUNT(*) AS exams_count,
+       COUNT(e.achieved_grade) AS graded_count,
+       COUNT(*) FILTER (WHERE e.achieved_grade >= e.target_grade) AS target_met_count,
+       COALESCE(AVG(e.target_grade) FILTER (WHERE e.achieved_grade IS NOT NULL), 0)::float AS average_target_grade,
+       COALESCE(AVG(e.achieved_grade), 0)::float AS average_achieved_grade,
+       COALESCE(AVG(e.achieved_grade - e.target_grade), 0)::float AS average_grade_difference
+FROM exams e
+JOIN lesson_subjects ls ON ls.subject_id = e.subject_id
+WHERE e.exam_datetime >= sqlc.arg(start_datetime) AND e.exam_datetime < sqlc.arg(end_datetime)
+GROUP BY 1, ls.subject_id, ls.name
+ORDER BY 1, ls.name;
@@ -0,0 +1,57 @@
+-- name: CreateStatements :many
+-- CreateStatements creates the statements of a period for every student with invoices or payments in the period,
+-- or with an outstanding balance before it, optionally for a single student.
+-- Students that already have a statement of the period are skipped, so billing a period is idempotent.
+WITH totals AS (
+  SELECT s.student_id,
+         COALESCE((SELECT SUM(i.amount) FROM invoices i
+                   WHERE i.student_id = s.student_id AND i.invoice_datetime < sqlc.arg(period_start)), 0)
+         - COALESCE((SELECT SUM(r.amount) FROM receipts r
+                     WHERE r.student_id = s.student_id AND r.receipt_datetime < sqlc.arg(period_start)), 0) AS previous_balance,
+         COALESCE((SELECT SUM(i.amount) FROM invoices i
+                   WHERE i.student_id = s.student_id
+                     AND i.invoice_datetime >= sqlc.arg(period_start) AND i.invoice_datetime < sqlc.arg(period_end)), 0) AS invoiced_amount,
+         COALESCE((SELECT SUM(r.amount) FROM receipts r
+                   WHERE r.student_id = s.student_id
+                     AND r.receipt_datetime >= sqlc.arg(period_start) AND r.receipt_datetime < sqlc.arg(period_end)), 0) AS payments_amount
+  FROM students s
+  WHERE sqlc.narg(student_id)::bigint IS NULL OR s.student_id = sqlc.narg(student_id)
+)
+INSERT INTO statements (
+  student_id, period_start, period_end, previous_balance, invoiced_amount, payments_amount, total_due
+)
+SELECT t.student_id,
+       sqlc.arg(period_start)::timestamptz,
+       sqlc.arg(period_end)::timestamptz,
+       t.previous_balance::float,
+       t.invoiced_amount::float,
+       t.payments_amount::float,
+       (t.previous_balance + t.invoiced_amount - t.payments_amount)::float
+FROM totals t
+WHERE ABS(t.invoiced_amount) >= 0.005 OR ABS(t.payments_amount) >= 0.005 OR ABS(t.previous_balance) >= 0.005
+ON CONFLICT (student_id, period_start) DO NOTHING
+RETURNING *;
+
+-- name: GetStatement :one
+SELECT * FROM statements
+WHERE statement_id = $1 LIMIT 1;
+
+-- name: ListStatements :many
+SELECT * FROM statements
+WHERE (sqlc.narg(student_id)::bigint IS NULL OR student_id = sqlc.narg(student_id))
+  AND (sqlc.narg(period_start)::timestamptz IS NULL OR period_start = sqlc.narg(period_start))
+ORDER BY period_start DESC, student_id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: GetInvoicesByStudentPeriod :many
+SELECT * FROM invoices
+WHERE student_id = sqlc.arg(student_id)
+  AND invoice_datetime >= sqlc.arg(start_datetime) AND invoice_datetime < sqlc.arg(end_datetime)
+ORDER BY invoice_datetime;
+
+-- name: GetReceiptsByStudentPeriod :many
+SELECT * FROM receipts
+WHERE student_id = sqlc.arg(student_id)
+  AND receipt_datetime >= sqlc.arg(start_datetime) AND receipt_datetime < sqlc.arg(end_datetime)
+ORDER BY receipt_datetime;
@@ -11,10 +11,29 @@ SELECT * FROM students
 WHERE student_id = $1 LIMIT 1;
 
 -- name: ListStudents :many
-SELECT * FROM students
-ORDER BY last_name, first_name
-LIMIT $1
-OFFSET $2;
+-- ListStudents lists the students by name, optionally with a single lifecycle status, with a tag,
+-- or with a value of a custom field.
+SELECT * FROM students s
+WHERE (sqlc.narg(lifecycle_status)::varchar IS NULL OR s.lifecycle_status = sqlc.narg(lifecycle_status))
+  AND (sqlc.narg(tag_id)::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM student_tags st WHERE st.student_id = s.student_id AND st.tag_id = sqlc.narg(tag_id)))
+  AND (sqlc.narg(field_id)::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM student_custom_values v
+    WHERE v.student_id = s.student_id AND v.field_id = sqlc.narg(field_id) AND v.value = sqlc.narg(field_value)::varchar))
+ORDER BY s.last_name, s.first_name
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ExportStudents :many
+-- ExportStudents lists all the students matching the filters of ListStudents, by name.
+SELECT * FROM students s
+WHERE (sqlc.narg(lifecycle_status)::varchar IS NULL OR s.lifecycle_status = sqlc.narg(lifecycle_status))
+  AND (sqlc.narg(tag_id)::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM student_tags st WHERE st.student_id = s.student_id AND st.tag_id = sqlc.narg(tag_id)))
+  AND (sqlc.narg(field_id)::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM student_custom_values v
+    WHERE v.student_id = s.student_id AND v.field_id = sqlc.narg(field_id) AND v.value = sqlc.narg(field_value)::varchar))
+ORDER BY s.last_name, s.first_name, s.student_id;
 
 -- name: UpdateStudent :exec
 UPDATE students
@@ -31,4 +50,40 @@ WHERE student_id = $1;
 
 -- name: DeleteStudent :exec
 DELETE FROM students
-WHERE student_id = $1;
\ No newline at end of file
+WHERE student_id = $1;
+
+-- name: UpdateStudentLifecycleStatus :exec
+UPDATE students
+  set   lifecycle_status = $2
+WHERE student_id = $1;
+
+-- name: GetStudentsLifecycle :many
+WITH student_lessons AS (
+  SELECT i.student_id,
+         MAX(l.lesson_datetime)::timestamptz AS last_lesson_datetime,
+         COUNT(*) FILTER (WHERE l.lesson_datetime >= sqlc.arg(recent_since)::timestamptz) AS recent_lessons_count,
+         COUNT(*) FILTER (WHERE l.lesson_datetime >= sqlc.arg(baseline_since)::timestamptz
+                            AND l.lesson_datetime < sqlc.arg(recent_since)::timestamptz) AS baseline_lessons_count
+  FROM invoices i
+  JOIN lessons l ON l.lesson_id = i.lesson_id
+  WHERE l.lesson_datetime < sqlc.arg(as_of)::timestamptz
+  GROUP BY i.student_id
+)
+SELECT s.student_id,
+       s.first_name,
+       s.last_name,
+       s.lifecycle_status,
+       sl.last_lesson_datetime,
+       COALESCE(sl.recent_lessons_count, 0)::bigint AS recent_lessons_count,
+       COALESCE(sl.baseline_lessons_count, 0)::bigint AS baseline_lessons_count,
+       (CASE
+          WHEN COALESCE(sl.last_lesson_datetime, s.created_at) < sqlc.arg(lapsed_since)::timestamptz THEN 'lapsed'
+          WHEN COALESCE(sl.last_lesson_datetime, s.created_at) < sqlc.arg(at_risk_since)::timestamptz THEN 'at-risk'
+          WHEN COALESCE(sl.recent_lessons_count, 0) * EXTRACT(EPOCH FROM sqlc.arg(recent_since)::timestamptz - sqlc.arg(baseline_since)::timestamptz)
+             < 0.5 * COALESCE(sl.baseline_lessons_count, 0) * EXTRACT(EPOCH FROM sqlc.arg(as_of)::timestamptz - sqlc.arg(recent_since)::timestamptz) THEN 'at-risk'
+          ELSE 'active'
+        END)::varchar AS computed_status
+FROM students s
+LEFT JOIN student_lessons sl ON sl.student_id = s.student_id
+WHERE s.created_at < sqlc.arg(as_of)::timestamptz
+ORDER BY s.last_name, s.first_name;
@@ -0,0 +1,54 @@
+-- name: CreateTag :one
+INSERT INTO tags (
+  name
+) VALUES (
+  $1
+)
+RETURNING *;
+
+-- name: GetTag :one
+SELECT * FROM tags
+WHERE tag_id = $1 LIMIT 1;
+
+-- name: ListTags :many
+SELECT * FROM tags
+ORDER BY name
+LIMIT $1
+OFFSET $2;
+
+-- name: UpdateTag :exec
+UPDATE tags
+  set   name = $2
+WHERE tag_id = $1;
+
+-- name: DeleteTag :execrows
+DELETE FROM tags
+WHERE tag_id = $1;
+
+-- name: AddStudentTag :exec
+-- AddStudentTag tags a student. Tagging a student with one of its tags has no effect.
+INSERT INTO student_tags (
+  student_id, tag_id
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (student_id, tag_id) DO NOTHING;
+
+-- name: RemoveStudentTag :execrows
+DELETE FROM student_tags
+WHERE student_id = $1 AND tag_id = $2;
+
+-- name: GetStudentTags :many
+SELECT t.*
+FROM student_tags st
+JOIN tags t ON t.tag_id = st.tag_id
+WHERE st.student_id = $1
+ORDER BY t.name;
+
+-- name: GetStudentsTags :many
+-- GetStudentsTags returns the tags of several students, by student and tag name.
+SELECT st.student_id, t.tag_id, t.name
+FROM student_tags st
+JOIN tags t ON t.tag_id = st.tag_id
+WHERE st.student_id = ANY(sqlc.arg(student_ids)::bigint[])
+ORDER BY st.student_id, t.name;
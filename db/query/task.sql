@@ -0,0 +1,72 @@
+-- name: CreateTask :one
+INSERT INTO tasks (
+  lead_id, student_id, lesson_id, invoice_id, title, due_at, priority, recurrence, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: GetTask :one
+SELECT * FROM tasks
+WHERE task_id = $1 LIMIT 1;
+
+-- name: GetTaskForUpdate :one
+SELECT * FROM tasks
+WHERE task_id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: ListTasks :many
+-- ListTasks lists the tasks by due date, optionally of a single lead, student, lesson or invoice,
+-- with a single status or priority, or only the overdue open and in progress tasks.
+SELECT * FROM tasks
+WHERE (sqlc.narg(lead_id)::bigint IS NULL OR lead_id = sqlc.narg(lead_id))
+  AND (sqlc.narg(student_id)::bigint IS NULL OR student_id = sqlc.narg(student_id))
+  AND (sqlc.narg(lesson_id)::bigint IS NULL OR lesson_id = sqlc.narg(lesson_id))
+  AND (sqlc.narg(invoice_id)::bigint IS NULL OR invoice_id = sqlc.narg(invoice_id))
+  AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(priority)::varchar IS NULL OR priority = sqlc.narg(priority))
+  AND (sqlc.narg(overdue_at)::timestamptz IS NULL OR (status IN ('open', 'in_progress') AND due_at < sqlc.narg(overdue_at)))
+ORDER BY due_at, task_id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListDueTasks :many
+-- ListDueTasks lists the open and in progress tasks due before a time, by due date and highest priority first,
+-- with the name of their student.
+SELECT t.task_id,
+       t.title,
+       t.due_at,
+       t.priority,
+       t.status,
+       t.lead_id,
+       t.student_id,
+       t.lesson_id,
+       t.invoice_id,
+       s.first_name,
+       s.last_name
+FROM tasks t
+LEFT JOIN students s ON s.student_id = t.student_id
+WHERE t.status IN ('open', 'in_progress')
+  AND t.due_at < sqlc.arg(due_before)
+ORDER BY t.due_at, array_position(ARRAY['high', 'normal', 'low']::varchar[], t.priority), t.task_id
+LIMIT sqlc.arg('limit');
+
+-- name: UpdateTask :exec
+-- UpdateTask updates a task. A task being done is completed now, and a done task keeps its completion time.
+UPDATE tasks
+  set   lead_id = $2,
+        student_id = $3,
+        lesson_id = $4,
+        invoice_id = $5,
+        title = $6,
+        due_at = $7,
+        priority = $8,
+        recurrence = $9,
+        notes = $10,
+        status = $11,
+        completed_at = CASE WHEN $11 = 'done' THEN COALESCE(completed_at, now()) END
+WHERE task_id = $1;
+
+-- name: DeleteTask :execrows
+DELETE FROM tasks
+WHERE task_id = $1;
@@ -0,0 +1,62 @@
+-- name: CreateWaitlistEntry :one
+INSERT INTO waitlist_entries (
+  student_id, subject_id, weekday, start_minute, end_minute, timezone, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetWaitlistEntry :one
+SELECT * FROM waitlist_entries
+WHERE waitlist_entry_id = $1 LIMIT 1;
+
+-- name: ListWaitlistEntries :many
+-- ListWaitlistEntries lists the waitlist entries in first-come order, optionally of a single student or subject, or with a single status.
+SELECT * FROM waitlist_entries
+WHERE (sqlc.narg(student_id)::bigint IS NULL OR student_id = sqlc.narg(student_id))
+  AND (sqlc.narg(subject_id)::bigint IS NULL OR subject_id = sqlc.narg(subject_id))
+  AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+ORDER BY created_at, waitlist_entry_id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdateWaitlistEntry :exec
+UPDATE waitlist_entries
+  set   subject_id = $2,
+        weekday = $3,
+        start_minute = $4,
+        end_minute = $5,
+        timezone = $6,
+        status = $7,
+        notes = $8
+WHERE waitlist_entry_id = $1;
+
+-- name: DeleteWaitlistEntry :execrows
+DELETE FROM waitlist_entries
+WHERE waitlist_entry_id = $1;
+
+-- name: GetFirstMatchingWaitlistEntry :one
+-- GetFirstMatchingWaitlistEntry gets the earliest waiting entry of a subject whose preferred day and times, in the timezone
+-- of the entry, include a lesson from lesson_datetime of duration minutes. The students of the excluded_student_ids are skipped.
+SELECT w.* FROM waitlist_entries w
+WHERE w.status = 'waiting'
+  AND w.subject_id = sqlc.arg(subject_id)
+  AND w.student_id <> ALL(sqlc.arg(excluded_student_ids)::bigint[])
+  AND (w.weekday IS NULL OR w.weekday = EXTRACT(DOW FROM sqlc.arg(lesson_datetime)::timestamptz AT TIME ZONE w.timezone)::int)
+  AND (w.start_minute IS NULL OR (
+    (EXTRACT(HOUR FROM sqlc.arg(lesson_datetime)::timestamptz AT TIME ZONE w.timezone) * 60
+      + EXTRACT(MINUTE FROM sqlc.arg(lesson_datetime)::timestamptz AT TIME ZONE w.timezone))::int >= w.start_minute
+    AND (EXTRACT(HOUR FROM sqlc.arg(lesson_datetime)::timestamptz AT TIME ZONE w.timezone) * 60
+      + EXTRACT(MINUTE FROM sqlc.arg(lesson_datetime)::timestamptz AT TIME ZONE w.timezone))::int + sqlc.arg(duration)::int <= w.end_minute
+  ))
+ORDER BY w.created_at, w.waitlist_entry_id
+LIMIT 1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkWaitlistEntryNotified :one
+UPDATE waitlist_entries
+  set   status = 'notified',
+        notified_at = now(),
+        notified_lesson_datetime = sqlc.arg(lesson_datetime)
+WHERE waitlist_entry_id = sqlc.arg(waitlist_entry_id)
+RETURNING *;
@@ -0,0 +1,93 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (
+  url, secret, events, active
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscriptions
+WHERE subscription_id = $1 LIMIT 1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions
+ORDER BY subscription_id
+LIMIT $1
+OFFSET $2;
+
+-- name: UpdateWebhookSubscription :exec
+UPDATE webhook_subscriptions
+  set   url = $2,
+        events = $3,
+        active = $4
+WHERE subscription_id = $1;
+
+-- name: CreateWebhookDeliveries :exec
+-- CreateWebhookDeliveries adds a delivery of the event for every active subscription to the event.
+INSERT INTO webhook_deliveries (
+  subscription_id, event, payload
+)
+SELECT subscription_id, sqlc.arg(event)::varchar, sqlc.arg(payload)::jsonb
+FROM webhook_subscriptions
+WHERE active AND sqlc.arg(event)::varchar = ANY(events);
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE delivery_id = $1 LIMIT 1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE subscription_id = $1
+ORDER BY delivery_id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: ClaimWebhookDeliveries :many
+-- ClaimWebhookDeliveries locks pending deliveries that are due, and postpones them by the lease,
+-- so other dispatchers don't post them while they are being posted.
+-- The url and secret of the subscription of each delivery are returned with it.
+WITH claimed AS (
+  UPDATE webhook_deliveries
+    set   next_attempt_at = sqlc.arg(lease_until)
+  WHERE delivery_id IN (
+    SELECT d.delivery_id FROM webhook_deliveries d
+    WHERE d.status = 'pending' AND d.next_attempt_at <= sqlc.arg(now)
+    ORDER BY d.next_attempt_at
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+  )
+  RETURNING delivery_id, subscription_id, event, payload, attempts
+)
+SELECT c.delivery_id, c.subscription_id, c.event, c.payload, c.attempts, s.url, s.secret
+FROM claimed c
+JOIN webhook_subscriptions s ON s.subscription_id = c.subscription_id
+ORDER BY c.delivery_id;
+
+-- name: MarkWebhookDeliveryDelivered :exec
+UPDATE webhook_deliveries
+  set   status = 'delivered',
+        attempts = attempts + 1,
+        response_status = $2,
+        last_error = NULL,
+        delivered_at = $3
+WHERE delivery_id = $1;
+
+-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+  set   status = $2,
+        attempts = attempts + 1,
+        response_status = $3,
+        last_error = $4,
+        next_attempt_at = $5
+WHERE delivery_id = $1;
+
+-- name: ReplayWebhookDelivery :one
+-- ReplayWebhookDelivery adds a new delivery of the same event and payload of a previous delivery.
+INSERT INTO webhook_deliveries (
+  subscription_id, event, payload
+)
+SELECT subscription_id, event, payload
+FROM webhook_deliveries
+WHERE webhook_deliveries.delivery_id = $1
+RETURNING *;
@@ -0,0 +1,23 @@
+package db
+
+// Activity types.
+const (
+	ActivityTypeCall     = "call"
+	ActivityTypeEmail    = "email"
+	ActivityTypeMeeting  = "meeting"
+	ActivityTypeWhatsApp = "whatsapp"
+)
+
+// Activity directions.
+const (
+	ActivityDirectionInbound  = "inbound"
+	ActivityDirectionOutbound = "outbound"
+)
+
+// Kinds of the items of the student timeline.
+const (
+	TimelineKindActivity = "activity"
+	TimelineKindLesson   = "lesson"
+	TimelineKindInvoice  = "invoice"
+	TimelineKindReceipt  = "receipt"
+)
@@ -0,0 +1,269 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: activity.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createActivity = `-- name: CreateActivity :one
+INSERT INTO activities (
+  student_id, activity_type, direction, occurred_at, summary, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING activity_id, student_id, activity_type, direction, occurred_at, summary, notes, created_at
+`
+
+type CreateActivityParams struct {
+	StudentID    int64          `json:"student_id"`
+	ActivityType string         `json:"activity_type"`
+	Direction    string         `json:"direction"`
+	OccurredAt   time.Time      `json:"occurred_at"`
+	Summary      string         `json:"summary"`
+	Notes        sql.NullString `json:"notes"`
+}
+
+func (q *Queries) CreateActivity(ctx context.Context, arg CreateActivityParams) (Activity, error) {
+	row := q.db.QueryRowContext(ctx, createActivity,
+		arg.StudentID,
+		arg.ActivityType,
+		arg.Direction,
+		arg.OccurredAt,
+		arg.Summary,
+		arg.Notes,
+	)
+	var i Activity
+	err := row.Scan(
+		&i.ActivityID,
+		&i.StudentID,
+		&i.ActivityType,
+		&i.Direction,
+		&i.OccurredAt,
+		&i.Summary,
+		&i.Notes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteActivity = `-- name: DeleteActivity :execrows
+DELETE FROM activities
+WHERE activity_id = $1
+`
+
+func (q *Queries) DeleteActivity(ctx context.Context, activityID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteActivity, activityID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getActivity = `-- name: GetActivity :one
+SELECT activity_id, student_id, activity_type, direction, occurred_at, summary, notes, created_at FROM activities
+WHERE activity_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetActivity(ctx context.Context, activityID int64) (Activity, error) {
+	row := q.db.QueryRowContext(ctx, getActivity, activityID)
+	var i Activity
+	err := row.Scan(
+		&i.ActivityID,
+		&i.StudentID,
+		&i.ActivityType,
+		&i.Direction,
+		&i.OccurredAt,
+		&i.Summary,
+		&i.Notes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getStudentTimeline = `-- name: GetStudentTimeline :many
+SELECT t.kind, t.item_id, t.occurred_at, t.amount,
+       a.activity_type, a.direction, a.summary,
+       s.name AS subject_name,
+       COALESCE(a.notes, l.notes, i.notes, r.notes) AS notes
+FROM (
+  SELECT 'activity'::varchar AS kind, sa.activity_id AS item_id, sa.occurred_at, NULL::float AS amount
+  FROM activities sa
+  WHERE sa.student_id = $1
+  UNION ALL
+  SELECT 'lesson', sl.lesson_id, sl.lesson_datetime, NULL
+  FROM lesson_attendance la
+  JOIN lessons sl ON sl.lesson_id = la.lesson_id
+  WHERE la.student_id = $1
+  UNION ALL
+  SELECT 'invoice', si.invoice_id, si.invoice_datetime, si.amount
+  FROM invoices si
+  WHERE si.student_id = $1
+  UNION ALL
+  SELECT 'receipt', sr.receipt_id, sr.receipt_datetime, sr.amount
+  FROM receipts sr
+  WHERE sr.student_id = $1
+) t
+LEFT JOIN activities a ON t.kind = 'activity' AND a.activity_id = t.item_id
+LEFT JOIN lessons l ON t.kind = 'lesson' AND l.lesson_id = t.item_id
+LEFT JOIN lesson_subjects s ON s.subject_id = l.subject_id
+LEFT JOIN invoices i ON t.kind = 'invoice' AND i.invoice_id = t.item_id
+LEFT JOIN receipts r ON t.kind = 'receipt' AND r.receipt_id = t.item_id
+WHERE ($2::timestamptz IS NULL OR t.occurred_at >= $2)
+  AND ($3::timestamptz IS NULL OR t.occurred_at < $3)
+ORDER BY t.occurred_at DESC, t.kind, t.item_id DESC
+LIMIT $5
+OFFSET $4
+`
+
+type GetStudentTimelineParams struct {
+	StudentID     int64        `json:"student_id"`
+	StartDatetime sql.NullTime `json:"start_datetime"`
+	EndDatetime   sql.NullTime `json:"end_datetime"`
+	Offset        int32        `json:"offset"`
+	Limit         int32        `json:"limit"`
+}
+
+type GetStudentTimelineRow struct {
+	Kind         string          `json:"kind"`
+	ItemID       int64           `json:"item_id"`
+	OccurredAt   time.Time       `json:"occurred_at"`
+	Amount       sql.NullFloat64 `json:"amount"`
+	ActivityType sql.NullString  `json:"activity_type"`
+	Direction    sql.NullString  `json:"direction"`
+	Summary      sql.NullString  `json:"summary"`
+	SubjectName  sql.NullString  `json:"subject_name"`
+	Notes        sql.NullString  `json:"notes"`
+}
+
+// GetStudentTimeline returns the activities, lessons, invoices and receipts of a student in one feed, latest first.
+// The kind of an item is activity, lesson, invoice or receipt, and item_id is the ID of the item in its table.
+// The activity, subject and amount columns are set only for the kinds of items they belong to.
+func (q *Queries) GetStudentTimeline(ctx context.Context, arg GetStudentTimelineParams) ([]GetStudentTimelineRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStudentTimeline,
+		arg.StudentID,
+		arg.StartDatetime,
+		arg.EndDatetime,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStudentTimelineRow{}
+	for rows.Next() {
+		var i GetStudentTimelineRow
+		if err := rows.Scan(
+			&i.Kind,
+			&i.ItemID,
+			&i.OccurredAt,
+			&i.Amount,
+			&i.ActivityType,
+			&i.Direction,
+			&i.Summary,
+			&i.SubjectName,
+			&i.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActivities = `-- name: ListActivities :many
+SELECT activity_id, student_id, activity_type, direction, occurred_at, summary, notes, created_at FROM activities
+WHERE ($1::bigint IS NULL OR student_id = $1)
+  AND ($2::varchar IS NULL OR activity_type = $2)
+ORDER BY occurred_at DESC, activity_id DESC
+LIMIT $4
+OFFSET $3
+`
+
+type ListActivitiesParams struct {
+	StudentID    sql.NullInt64  `json:"student_id"`
+	ActivityType sql.NullString `json:"activity_type"`
+	Offset       int32          `json:"offset"`
+	Limit        int32          `json:"limit"`
+}
+
+// ListActivities lists the activities, latest first, optionally of a single student or type.
+func (q *Queries) ListActivities(ctx context.Context, arg ListActivitiesParams) ([]Activity, error) {
+	rows, err := q.db.QueryContext(ctx, listActivities,
+		arg.StudentID,
+		arg.ActivityType,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Activity{}
+	for rows.Next() {
+		var i Activity
+		if err := rows.Scan(
+			&i.ActivityID,
+			&i.StudentID,
+			&i.ActivityType,
+			&i.Direction,
+			&i.OccurredAt,
+			&i.Summary,
+			&i.Notes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateActivity = `-- name: UpdateActivity :exec
+UPDATE activities
+  set   activity_type = $2,
+        direction = $3,
+        occurred_at = $4,
+        summary = $5,
+        notes = $6
+WHERE activity_id = $1
+`
+
+type UpdateActivityParams struct {
+	ActivityID   int64          `json:"activity_id"`
+	ActivityType string         `json:"activity_type"`
+	Direction    string         `json:"direction"`
+	OccurredAt   time.Time      `json:"occurred_at"`
+	Summary      string         `json:"summary"`
+	Notes        sql.NullString `json:"notes"`
+}
+
+func (q *Queries) UpdateActivity(ctx context.Context, arg UpdateActivityParams) error {
+	_, err := q.db.ExecContext(ctx, updateActivity,
+		arg.ActivityID,
+		arg.ActivityType,
+		arg.Direction,
+		arg.OccurredAt,
+		arg.Summary,
+		arg.Notes,
+	)
+	return err
+}
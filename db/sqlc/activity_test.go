@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomActivity adds a new random activity of the type with the student, that occurred at occurredAt.
+func createRandomActivity(t *testing.T, student Student, activityType string, occurredAt time.Time) Activity {
+	arg := CreateActivityParams{
+		StudentID:    student.StudentID,
+		ActivityType: activityType,
+		Direction:    ActivityDirectionOutbound,
+		OccurredAt:   occurredAt,
+		Summary:      util.RandomNote(),
+		Notes:        sql.NullString{String: util.RandomNote(), Valid: true},
+	}
+
+	activity, err := testQueries.CreateActivity(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, activity.ActivityID)
+	require.Equal(t, arg.StudentID, activity.StudentID)
+	require.Equal(t, arg.ActivityType, activity.ActivityType)
+	require.Equal(t, arg.Direction, activity.Direction)
+	require.WithinDuration(t, arg.OccurredAt, activity.OccurredAt, time.Second)
+	require.Equal(t, arg.Summary, activity.Summary)
+	require.Equal(t, arg.Notes, activity.Notes)
+
+	return activity
+}
+
+func TestActivities(t *testing.T) {
+	student := createRandomStudent(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	call := createRandomActivity(t, student, ActivityTypeCall, now.Add(-time.Hour))
+	createRandomActivity(t, student, ActivityTypeWhatsApp, now)
+
+	arg := UpdateActivityParams{
+		ActivityID:   call.ActivityID,
+		ActivityType: ActivityTypeMeeting,
+		Direction:    ActivityDirectionInbound,
+		OccurredAt:   call.OccurredAt,
+		Summary:      util.RandomNote(),
+		Notes:        call.Notes,
+	}
+	err := testQueries.UpdateActivity(context.Background(), arg)
+	require.NoError(t, err)
+
+	meeting, err := testQueries.GetActivity(context.Background(), call.ActivityID)
+	require.NoError(t, err)
+	require.Equal(t, ActivityTypeMeeting, meeting.ActivityType)
+	require.Equal(t, ActivityDirectionInbound, meeting.Direction)
+	require.Equal(t, arg.Summary, meeting.Summary)
+
+	activities, err := testQueries.ListActivities(context.Background(), ListActivitiesParams{
+		StudentID: sql.NullInt64{Int64: student.StudentID, Valid: true},
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, activities, 2)
+	require.Equal(t, ActivityTypeWhatsApp, activities[0].ActivityType)
+
+	activities, err = testQueries.ListActivities(context.Background(), ListActivitiesParams{
+		StudentID:    sql.NullInt64{Int64: student.StudentID, Valid: true},
+		ActivityType: sql.NullString{String: ActivityTypeMeeting, Valid: true},
+		Limit:        5,
+		Offset:       0,
+	})
+	require.NoError(t, err)
+	require.Len(t, activities, 1)
+	require.Equal(t, call.ActivityID, activities[0].ActivityID)
+
+	rows, err := testQueries.DeleteActivity(context.Background(), call.ActivityID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = testQueries.GetActivity(context.Background(), call.ActivityID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestGetStudentTimeline(t *testing.T) {
+	student := createRandomStudent(t)
+	day := time.Date(2033, time.October, 3, 0, 0, 0, 0, time.UTC)
+
+	result, err := createRandomLessonWithInvoiceTx(t, day.Add(10*time.Hour), student)
+	require.NoError(t, err)
+	lesson, invoice := result.Lesson, result.Invoices[0]
+
+	receipt, err := testQueries.CreateReceipt(context.Background(), CreateReceiptParams{
+		StudentID:       student.StudentID,
+		ReceiptDatetime: day.Add(12 * time.Hour),
+		Amount:          util.RandomPaymentAmount(),
+		Notes:           sql.NullString{String: util.RandomNote(), Valid: true},
+	})
+	require.NoError(t, err)
+
+	call := createRandomActivity(t, student, ActivityTypeCall, day.Add(9*time.Hour))
+	email := createRandomActivity(t, student, ActivityTypeEmail, day.AddDate(0, 0, 1))
+
+	arg := GetStudentTimelineParams{
+		StudentID: student.StudentID,
+		Limit:     10,
+		Offset:    0,
+	}
+
+	timeline, err := testQueries.GetStudentTimeline(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, timeline, 5)
+
+	// the items are latest first, and the invoice of the lesson comes before it as its kind sorts first
+	require.Equal(t, TimelineKindActivity, timeline[0].Kind)
+	require.Equal(t, email.ActivityID, timeline[0].ItemID)
+	require.Equal(t, sql.NullString{String: ActivityTypeEmail, Valid: true}, timeline[0].ActivityType)
+	require.Equal(t, sql.NullString{String: email.Summary, Valid: true}, timeline[0].Summary)
+	require.Equal(t, email.Notes, timeline[0].Notes)
+	require.False(t, timeline[0].Amount.Valid)
+
+	require.Equal(t, TimelineKindReceipt, timeline[1].Kind)
+	require.Equal(t, receipt.ReceiptID, timeline[1].ItemID)
+	require.Equal(t, sql.NullFloat64{Float64: receipt.Amount, Valid: true}, timeline[1].Amount)
+	require.Equal(t, receipt.Notes, timeline[1].Notes)
+
+	require.Equal(t, TimelineKindInvoice, timeline[2].Kind)
+	require.Equal(t, invoice.InvoiceID, timeline[2].ItemID)
+	require.Equal(t, sql.NullFloat64{Float64: invoice.Amount, Valid: true}, timeline[2].Amount)
+
+	require.Equal(t, TimelineKindLesson, timeline[3].Kind)
+	require.Equal(t, lesson.LessonID, timeline[3].ItemID)
+	require.True(t, timeline[3].SubjectName.Valid)
+	require.False(t, timeline[3].ActivityType.Valid)
+
+	require.Equal(t, TimelineKindActivity, timeline[4].Kind)
+	require.Equal(t, call.ActivityID, timeline[4].ItemID)
+
+	// the period excludes the email of the next day and the call before the lesson
+	arg.StartDatetime = sql.NullTime{Time: day.Add(10 * time.Hour), Valid: true}
+	arg.EndDatetime = sql.NullTime{Time: day.AddDate(0, 0, 1), Valid: true}
+	timeline, err = testQueries.GetStudentTimeline(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, timeline, 3)
+}
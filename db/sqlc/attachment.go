@@ -0,0 +1,35 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrLessonHasAttachments is returned when deleting a lesson with attachments,
+// as the files of the attachments must be deleted from the blob storage first.
+var ErrLessonHasAttachments = errors.New("lesson has attachments")
+
+// CreateAttachmentTx creates an attachment of a student or of a lesson.
+// It returns sql.ErrNoRows if the student or the lesson doesn't exist.
+func (store *SQLStore) CreateAttachmentTx(ctx context.Context, arg CreateAttachmentParams) (Attachment, error) {
+	var result Attachment
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		if arg.StudentID.Valid {
+			_, err = q.GetStudent(ctx, arg.StudentID.Int64)
+		}
+		if err == nil && arg.LessonID.Valid {
+			_, err = q.GetLesson(ctx, arg.LessonID.Int64)
+		}
+		if err != nil {
+			return err
+		}
+
+		result, err = q.CreateAttachment(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,157 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: attachment.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const countLessonAttachments = `-- name: CountLessonAttachments :one
+SELECT COUNT(*) FROM attachments
+WHERE lesson_id = $1
+`
+
+func (q *Queries) CountLessonAttachments(ctx context.Context, lessonID sql.NullInt64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countLessonAttachments, lessonID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAttachment = `-- name: CreateAttachment :one
+INSERT INTO attachments (
+  student_id, lesson_id, filename, content_type, size, storage_key, description
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING attachment_id, student_id, lesson_id, filename, content_type, size, storage_key, description, created_at
+`
+
+type CreateAttachmentParams struct {
+	StudentID   sql.NullInt64  `json:"student_id"`
+	LessonID    sql.NullInt64  `json:"lesson_id"`
+	Filename    string         `json:"filename"`
+	ContentType string         `json:"content_type"`
+	Size        int64          `json:"size"`
+	StorageKey  string         `json:"storage_key"`
+	Description sql.NullString `json:"description"`
+}
+
+func (q *Queries) CreateAttachment(ctx context.Context, arg CreateAttachmentParams) (Attachment, error) {
+	row := q.db.QueryRowContext(ctx, createAttachment,
+		arg.StudentID,
+		arg.LessonID,
+		arg.Filename,
+		arg.ContentType,
+		arg.Size,
+		arg.StorageKey,
+		arg.Description,
+	)
+	var i Attachment
+	err := row.Scan(
+		&i.AttachmentID,
+		&i.StudentID,
+		&i.LessonID,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.StorageKey,
+		&i.Description,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteAttachment = `-- name: DeleteAttachment :execrows
+DELETE FROM attachments
+WHERE attachment_id = $1
+`
+
+func (q *Queries) DeleteAttachment(ctx context.Context, attachmentID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAttachment, attachmentID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getAttachment = `-- name: GetAttachment :one
+SELECT attachment_id, student_id, lesson_id, filename, content_type, size, storage_key, description, created_at FROM attachments
+WHERE attachment_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAttachment(ctx context.Context, attachmentID int64) (Attachment, error) {
+	row := q.db.QueryRowContext(ctx, getAttachment, attachmentID)
+	var i Attachment
+	err := row.Scan(
+		&i.AttachmentID,
+		&i.StudentID,
+		&i.LessonID,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.StorageKey,
+		&i.Description,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAttachments = `-- name: ListAttachments :many
+SELECT attachment_id, student_id, lesson_id, filename, content_type, size, storage_key, description, created_at FROM attachments
+WHERE ($1::bigint IS NULL OR student_id = $1)
+  AND ($2::bigint IS NULL OR lesson_id = $2)
+ORDER BY created_at DESC, attachment_id DESC
+LIMIT $4
+OFFSET $3
+`
+
+type ListAttachmentsParams struct {
+	StudentID sql.NullInt64 `json:"student_id"`
+	LessonID  sql.NullInt64 `json:"lesson_id"`
+	Offset    int32         `json:"offset"`
+	Limit     int32         `json:"limit"`
+}
+
+// ListAttachments lists the attachments, newest first, optionally of a single student or lesson.
+func (q *Queries) ListAttachments(ctx context.Context, arg ListAttachmentsParams) ([]Attachment, error) {
+	rows, err := q.db.QueryContext(ctx, listAttachments,
+		arg.StudentID,
+		arg.LessonID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Attachment{}
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.AttachmentID,
+			&i.StudentID,
+			&i.LessonID,
+			&i.Filename,
+			&i.ContentType,
+			&i.Size,
+			&i.StorageKey,
+			&i.Description,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
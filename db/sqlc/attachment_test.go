@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomAttachment adds a new random attachment of a student or of a lesson.
+func createRandomAttachment(t *testing.T, studentID, lessonID sql.NullInt64) Attachment {
+	store := NewStore(testDB)
+
+	arg := CreateAttachmentParams{
+		StudentID:   studentID,
+		LessonID:    lessonID,
+		Filename:    util.RandomName() + ".pdf",
+		ContentType: "application/pdf",
+		Size:        util.RandomInt64(1, 10000),
+		StorageKey:  fmt.Sprintf("attachments/%s-%d.pdf", util.RandomName(), util.RandomInt64(1, 1000000)),
+		Description: sql.NullString{String: util.RandomNote(), Valid: true},
+	}
+
+	attachment, err := store.CreateAttachmentTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, attachment.AttachmentID)
+	require.Equal(t, arg.StudentID, attachment.StudentID)
+	require.Equal(t, arg.LessonID, attachment.LessonID)
+	require.Equal(t, arg.Filename, attachment.Filename)
+	require.Equal(t, arg.ContentType, attachment.ContentType)
+	require.Equal(t, arg.Size, attachment.Size)
+	require.Equal(t, arg.StorageKey, attachment.StorageKey)
+	require.Equal(t, arg.Description, attachment.Description)
+	require.NotZero(t, attachment.CreatedAt)
+
+	return attachment
+}
+
+func TestCreateAttachmentTx(t *testing.T) {
+	store := NewStore(testDB)
+	student := createRandomStudent(t)
+	createRandomAttachment(t, sql.NullInt64{Int64: student.StudentID, Valid: true}, sql.NullInt64{})
+
+	lesson, err := createRandomLessonWithInvoiceTx(t, util.RandomDatetime(), student)
+	require.NoError(t, err)
+	createRandomAttachment(t, sql.NullInt64{}, sql.NullInt64{Int64: lesson.Lesson.LessonID, Valid: true})
+
+	arg := CreateAttachmentParams{
+		Filename:    util.RandomName(),
+		ContentType: "text/plain",
+		StorageKey:  "attachments/" + util.RandomName(),
+	}
+
+	// the student or the lesson must exist
+	arg.StudentID = sql.NullInt64{Int64: -1, Valid: true}
+	_, err = store.CreateAttachmentTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg.StudentID = sql.NullInt64{}
+	arg.LessonID = sql.NullInt64{Int64: -1, Valid: true}
+	_, err = store.CreateAttachmentTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// an attachment is of exactly one student or lesson
+	arg.StudentID = sql.NullInt64{Int64: student.StudentID, Valid: true}
+	arg.LessonID = sql.NullInt64{Int64: lesson.Lesson.LessonID, Valid: true}
+	_, err = store.CreateAttachmentTx(context.Background(), arg)
+	require.Error(t, err)
+
+	// a lesson with attachments can't be deleted
+	require.Error(t, store.DeleteLessonWithInvoicesTx(context.Background(), lesson.Lesson.LessonID))
+}
+
+func TestGetAttachment(t *testing.T) {
+	attachment1 := createRandomAttachment(t, sql.NullInt64{Int64: createRandomStudent(t).StudentID, Valid: true}, sql.NullInt64{})
+
+	attachment2, err := testQueries.GetAttachment(context.Background(), attachment1.AttachmentID)
+	require.NoError(t, err)
+	require.Equal(t, attachment1, attachment2)
+}
+
+func TestListAttachments(t *testing.T) {
+	studentID := sql.NullInt64{Int64: createRandomStudent(t).StudentID, Valid: true}
+	for i := 0; i < 3; i++ {
+		createRandomAttachment(t, studentID, sql.NullInt64{})
+	}
+
+	attachments, err := testQueries.ListAttachments(context.Background(), ListAttachmentsParams{
+		StudentID: studentID,
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, attachments, 3)
+
+	// newest first
+	for i, attachment := range attachments {
+		require.Equal(t, studentID, attachment.StudentID)
+		if i > 0 {
+			require.Less(t, attachment.AttachmentID, attachments[i-1].AttachmentID)
+		}
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	attachment := createRandomAttachment(t, sql.NullInt64{Int64: createRandomStudent(t).StudentID, Valid: true}, sql.NullInt64{})
+
+	rows, err := testQueries.DeleteAttachment(context.Background(), attachment.AttachmentID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = testQueries.GetAttachment(context.Background(), attachment.AttachmentID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	rows, err = testQueries.DeleteAttachment(context.Background(), attachment.AttachmentID)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
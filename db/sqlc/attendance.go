@@ -0,0 +1,267 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+)
+
+// Attendance statuses of a student of a lesson. Attendance is recorded separately from the invoices of the lesson,
+// so changing the status of a student doesn't change the invoice of the student.
+const (
+	AttendanceStatusAttended = "attended"
+	AttendanceStatusAbsent   = "absent"
+	AttendanceStatusExcused  = "excused"
+)
+
+// ErrStudentInLesson is returned when adding a student that is already in a lesson.
+var ErrStudentInLesson = errors.New("student is already in the lesson")
+
+// AddLessonStudentTxParams contains the input parameters of a student added to an existing lesson, for the AddLessonStudentTx function.
+type AddLessonStudentTxParams struct {
+	LessonID  int64           `json:"lesson_id"`
+	StudentID int64           `json:"student_id"`
+	HourlyFee sql.NullFloat64 `json:"hourly_fee"`
+	Discount  float64         `json:"discount"`
+	Free      bool            `json:"free"`
+	Notes     sql.NullString  `json:"notes"`
+}
+
+// AddLessonStudentTx adds a student to an existing lesson, and records the student as attending the lesson.
+// A student attending for free isn't invoiced. Otherwise the invoice of the student is created as by CreateLessonWithInvoicesTx:
+// if the lesson is priced by a group pricing plan, the invoice is priced by the plan and the invoices of the other students
+// are recalculated for the new number of students, and if not, it is priced by the hourly fee of the params,
+// or else by the hourly fee resolved as by ResolveHourlyFeeTx.
+// It returns ErrStudentInLesson if the student is already in the lesson, and ErrNoHourlyFee if there is no hourly fee to price the invoice.
+func (store *SQLStore) AddLessonStudentTx(ctx context.Context, arg AddLessonStudentTxParams) (LessonWithInvoices, error) {
+	var result LessonWithInvoices
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		lesson, err := q.GetLesson(ctx, arg.LessonID)
+		if err != nil {
+			return err
+		}
+
+		inLesson, err := q.isLessonStudent(ctx, arg.LessonID, arg.StudentID)
+		if err != nil {
+			return err
+		}
+
+		if inLesson {
+			return ErrStudentInLesson
+		}
+
+		if !arg.Free {
+			if lesson.PricingPlanID.Valid {
+				err = q.addGroupLessonInvoice(ctx, lesson, arg)
+			} else {
+				err = q.addLessonInvoice(ctx, lesson, arg)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = q.CreateLessonAttendance(ctx, CreateLessonAttendanceParams{
+			LessonID:  arg.LessonID,
+			StudentID: arg.StudentID,
+			Status:    AttendanceStatusAttended,
+		})
+		if err != nil {
+			return err
+		}
+
+		result, _, err = q.getLessonWithInvoices(ctx, arg.LessonID)
+		return err
+	})
+
+	return result, err
+}
+
+// isLessonStudent returns true if a student is invoiced for a lesson or recorded as attending it, within a transaction.
+func (q *Queries) isLessonStudent(ctx context.Context, lessonID int64, studentID int64) (bool, error) {
+	_, err := q.GetInvoiceByLessonStudent(ctx, GetInvoiceByLessonStudentParams{
+		LessonID:  lessonID,
+		StudentID: studentID,
+	})
+	if err != sql.ErrNoRows {
+		return err == nil, err
+	}
+
+	_, err = q.GetLessonAttendance(ctx, GetLessonAttendanceParams{
+		LessonID:  lessonID,
+		StudentID: studentID,
+	})
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// addLessonInvoice creates the invoice of a student added to a lesson without a group pricing plan, within a transaction.
+func (q *Queries) addLessonInvoice(ctx context.Context, lesson Lesson, arg AddLessonStudentTxParams) error {
+	fee := ResolvedHourlyFee{HourlyFee: arg.HourlyFee.Float64}
+	if !arg.HourlyFee.Valid {
+		var err error
+		fee, err = q.resolveHourlyFee(ctx, GetApplicableFeeScheduleParams{
+			StudentID:      arg.StudentID,
+			SubjectID:      lesson.SubjectID,
+			LessonDatetime: lesson.LessonDatetime,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, _, _, err := q.createLessonInvoice(ctx, lesson, CreateInvoiceParams{
+		StudentID:       arg.StudentID,
+		LessonID:        lesson.LessonID,
+		InvoiceDatetime: lesson.LessonDatetime,
+		HourlyFee:       fee.HourlyFee,
+		Duration:        lesson.Duration,
+		Discount:        arg.Discount,
+		Amount:          round2(fee.HourlyFee * float64(lesson.Duration) / 60.0 * (1.0 - arg.Discount)),
+		Notes:           arg.Notes,
+		FeeScheduleID:   fee.FeeScheduleID,
+	})
+	return err
+}
+
+// addGroupLessonInvoice creates the invoice of a student added to a lesson priced by a group pricing plan,
+// and recalculates the invoices of the other students, within a transaction.
+func (q *Queries) addGroupLessonInvoice(ctx context.Context, lesson Lesson, arg AddLessonStudentTxParams) error {
+	plan, err := q.getGroupPricingPlan(ctx, lesson.PricingPlanID.Int64)
+	if err != nil {
+		return err
+	}
+
+	invoices, err := q.GetInvoicesByLesson(ctx, lesson.LessonID)
+	if err != nil {
+		return err
+	}
+
+	// the new invoice takes its place among the invoices, which are ordered by student
+	index := sort.Search(len(invoices), func(i int) bool { return invoices[i].StudentID > arg.StudentID })
+	discounts := make([]float64, 0, len(invoices)+1)
+	for _, invoice := range invoices[:index] {
+		discounts = append(discounts, invoice.Discount)
+	}
+	discounts = append(discounts, arg.Discount)
+	for _, invoice := range invoices[index:] {
+		discounts = append(discounts, invoice.Discount)
+	}
+
+	shares, err := GroupPricingShares(plan, lesson.Duration, discounts)
+	if err != nil {
+		return err
+	}
+
+	_, _, _, err = q.createLessonInvoice(ctx, lesson, CreateInvoiceParams{
+		StudentID:       arg.StudentID,
+		LessonID:        lesson.LessonID,
+		InvoiceDatetime: lesson.LessonDatetime,
+		HourlyFee:       shares[index].HourlyFee,
+		Duration:        lesson.Duration,
+		Discount:        arg.Discount,
+		Amount:          shares[index].Amount,
+		Notes:           arg.Notes,
+	})
+	if err != nil {
+		return err
+	}
+
+	return q.recalculateLessonInvoices(ctx, lesson, plan)
+}
+
+// RemoveLessonStudentTx removes a student from a lesson by deleting the invoice, attendance and lesson record of the student.
+// The units deducted from a package for the invoice are returned to the package,
+// and if the lesson is priced by a group pricing plan, the invoices of the other students are recalculated.
+// It returns sql.ErrNoRows if the student isn't in the lesson.
+func (store *SQLStore) RemoveLessonStudentTx(ctx context.Context, lessonID int64, studentID int64) (LessonWithInvoices, error) {
+	var result LessonWithInvoices
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		lesson, err := q.GetLesson(ctx, lessonID)
+		if err != nil {
+			return err
+		}
+
+		invoiced := true
+		invoice, err := q.GetInvoiceByLessonStudent(ctx, GetInvoiceByLessonStudentParams{
+			LessonID:  lessonID,
+			StudentID: studentID,
+		})
+		if err == sql.ErrNoRows {
+			invoiced = false
+		} else if err != nil {
+			return err
+		}
+
+		if invoiced {
+			err = q.deleteLessonInvoice(ctx, invoice)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = q.DeleteLessonRecord(ctx, DeleteLessonRecordParams{
+			LessonID:  lessonID,
+			StudentID: studentID,
+		})
+		if err != nil {
+			return err
+		}
+
+		rows, err := q.DeleteLessonAttendance(ctx, DeleteLessonAttendanceParams{
+			LessonID:  lessonID,
+			StudentID: studentID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !invoiced && rows == 0 {
+			return sql.ErrNoRows
+		}
+
+		if invoiced && lesson.PricingPlanID.Valid {
+			plan, err := q.getGroupPricingPlan(ctx, lesson.PricingPlanID.Int64)
+			if err != nil {
+				return err
+			}
+
+			err = q.recalculateLessonInvoices(ctx, lesson, plan)
+			if err != nil {
+				return err
+			}
+		}
+
+		result, _, err = q.getLessonWithInvoices(ctx, lessonID)
+		return err
+	})
+
+	return result, err
+}
+
+// deleteLessonInvoice deletes the invoice of a student of a lesson, and returns the units deducted for it to their package.
+func (q *Queries) deleteLessonInvoice(ctx context.Context, invoice Invoice) error {
+	deduction, err := q.GetPackageDeductionByInvoice(ctx, invoice.InvoiceID)
+	if err == nil {
+		err = q.RestoreStudentPackage(ctx, RestoreStudentPackageParams{
+			PackageID: deduction.PackageID,
+			Units:     deduction.Units,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeletePackageDeduction(ctx, deduction.DeductionID)
+	}
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	return q.DeleteInvoice(ctx, invoice.InvoiceID)
+}
@@ -0,0 +1,165 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: attendance.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createLessonAttendance = `-- name: CreateLessonAttendance :one
+INSERT INTO lesson_attendance (
+  lesson_id, student_id, status, notes
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING lesson_id, student_id, status, notes, updated_at
+`
+
+type CreateLessonAttendanceParams struct {
+	LessonID  int64          `json:"lesson_id"`
+	StudentID int64          `json:"student_id"`
+	Status    string         `json:"status"`
+	Notes     sql.NullString `json:"notes"`
+}
+
+func (q *Queries) CreateLessonAttendance(ctx context.Context, arg CreateLessonAttendanceParams) (LessonAttendance, error) {
+	row := q.db.QueryRowContext(ctx, createLessonAttendance,
+		arg.LessonID,
+		arg.StudentID,
+		arg.Status,
+		arg.Notes,
+	)
+	var i LessonAttendance
+	err := row.Scan(
+		&i.LessonID,
+		&i.StudentID,
+		&i.Status,
+		&i.Notes,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteLessonAttendance = `-- name: DeleteLessonAttendance :execrows
+DELETE FROM lesson_attendance
+WHERE lesson_id = $1 AND student_id = $2
+`
+
+type DeleteLessonAttendanceParams struct {
+	LessonID  int64 `json:"lesson_id"`
+	StudentID int64 `json:"student_id"`
+}
+
+func (q *Queries) DeleteLessonAttendance(ctx context.Context, arg DeleteLessonAttendanceParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteLessonAttendance, arg.LessonID, arg.StudentID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteLessonAttendanceByLesson = `-- name: DeleteLessonAttendanceByLesson :exec
+DELETE FROM lesson_attendance
+WHERE lesson_id = $1
+`
+
+func (q *Queries) DeleteLessonAttendanceByLesson(ctx context.Context, lessonID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteLessonAttendanceByLesson, lessonID)
+	return err
+}
+
+const getLessonAttendance = `-- name: GetLessonAttendance :one
+SELECT lesson_id, student_id, status, notes, updated_at FROM lesson_attendance
+WHERE lesson_id = $1 AND student_id = $2 LIMIT 1
+`
+
+type GetLessonAttendanceParams struct {
+	LessonID  int64 `json:"lesson_id"`
+	StudentID int64 `json:"student_id"`
+}
+
+func (q *Queries) GetLessonAttendance(ctx context.Context, arg GetLessonAttendanceParams) (LessonAttendance, error) {
+	row := q.db.QueryRowContext(ctx, getLessonAttendance, arg.LessonID, arg.StudentID)
+	var i LessonAttendance
+	err := row.Scan(
+		&i.LessonID,
+		&i.StudentID,
+		&i.Status,
+		&i.Notes,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getLessonAttendanceByLesson = `-- name: GetLessonAttendanceByLesson :many
+SELECT lesson_id, student_id, status, notes, updated_at FROM lesson_attendance
+WHERE lesson_id = $1
+ORDER BY student_id
+`
+
+func (q *Queries) GetLessonAttendanceByLesson(ctx context.Context, lessonID int64) ([]LessonAttendance, error) {
+	rows, err := q.db.QueryContext(ctx, getLessonAttendanceByLesson, lessonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LessonAttendance{}
+	for rows.Next() {
+		var i LessonAttendance
+		if err := rows.Scan(
+			&i.LessonID,
+			&i.StudentID,
+			&i.Status,
+			&i.Notes,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateLessonAttendance = `-- name: UpdateLessonAttendance :one
+UPDATE lesson_attendance
+  set   status = $3,
+        notes = $4,
+        updated_at = now()
+WHERE lesson_id = $1 AND student_id = $2
+RETURNING lesson_id, student_id, status, notes, updated_at
+`
+
+type UpdateLessonAttendanceParams struct {
+	LessonID  int64          `json:"lesson_id"`
+	StudentID int64          `json:"student_id"`
+	Status    string         `json:"status"`
+	Notes     sql.NullString `json:"notes"`
+}
+
+func (q *Queries) UpdateLessonAttendance(ctx context.Context, arg UpdateLessonAttendanceParams) (LessonAttendance, error) {
+	row := q.db.QueryRowContext(ctx, updateLessonAttendance,
+		arg.LessonID,
+		arg.StudentID,
+		arg.Status,
+		arg.Notes,
+	)
+	var i LessonAttendance
+	err := row.Scan(
+		&i.LessonID,
+		&i.StudentID,
+		&i.Status,
+		&i.Notes,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
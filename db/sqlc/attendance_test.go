@@ -0,0 +1,141 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddLessonStudentTxHourlyFee(t *testing.T) {
+	store := NewStore(testDB)
+	lesson1 := createRandomLessonWithInvoicesTx(t, 1)
+
+	// the hourly fee of the params prices the invoice
+	arg := AddLessonStudentTxParams{
+		LessonID:  lesson1.Lesson.LessonID,
+		StudentID: createRandomStudent(t).StudentID,
+		HourlyFee: sql.NullFloat64{Float64: 120, Valid: true},
+		Discount:  0.25,
+	}
+
+	lesson2, err := store.AddLessonStudentTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, lesson2.Invoices, 2)
+	require.Len(t, lesson2.Attendance, 2)
+
+	invoice, err := testQueries.GetInvoiceByLessonStudent(context.Background(), GetInvoiceByLessonStudentParams{
+		LessonID:  arg.LessonID,
+		StudentID: arg.StudentID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 120.0, invoice.HourlyFee)
+	require.Equal(t, round2(120*float64(lesson1.Lesson.Duration)/60*0.75), invoice.Amount)
+
+	// the hourly fee of the student prices the invoice if the params have none
+	student := createRandomStudent(t)
+	arg.StudentID = student.StudentID
+	arg.HourlyFee = sql.NullFloat64{}
+	arg.Discount = 0
+
+	_, err = store.AddLessonStudentTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	invoice, err = testQueries.GetInvoiceByLessonStudent(context.Background(), GetInvoiceByLessonStudentParams{
+		LessonID:  arg.LessonID,
+		StudentID: arg.StudentID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, student.HourlyFee.Float64, invoice.HourlyFee)
+
+	_, err = store.AddLessonStudentTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrStudentInLesson)
+}
+
+func TestAddLessonStudentTxFree(t *testing.T) {
+	store := NewStore(testDB)
+	plan := createRandomGroupPricingPlan(t, PricingModeSplit, 100)
+	lesson1 := createRandomGroupLesson(t, plan, 0, 0)
+
+	student := createRandomStudent(t)
+	arg := AddLessonStudentTxParams{
+		LessonID:  lesson1.Lesson.LessonID,
+		StudentID: student.StudentID,
+		Free:      true,
+	}
+
+	// a student attending for free isn't invoiced, and doesn't change the split of the group fee
+	lesson2, err := store.AddLessonStudentTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, lesson1.Invoices, lesson2.Invoices)
+	require.Len(t, lesson2.Attendance, 3)
+
+	_, err = store.AddLessonStudentTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrStudentInLesson)
+
+	lesson3, err := store.RemoveLessonStudentTx(context.Background(), arg.LessonID, student.StudentID)
+	require.NoError(t, err)
+	require.Equal(t, lesson1.Invoices, lesson3.Invoices)
+	require.Len(t, lesson3.Attendance, 2)
+
+	_, err = store.RemoveLessonStudentTx(context.Background(), arg.LessonID, student.StudentID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUpdateLessonAttendance(t *testing.T) {
+	lesson := createRandomLessonWithInvoicesTx(t, 1)
+
+	arg := UpdateLessonAttendanceParams{
+		LessonID:  lesson.Lesson.LessonID,
+		StudentID: lesson.Invoices[0].StudentID,
+		Status:    AttendanceStatusAbsent,
+		Notes:     sql.NullString{String: util.RandomNote(), Valid: true},
+	}
+
+	attendance, err := testQueries.UpdateLessonAttendance(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Status, attendance.Status)
+	require.Equal(t, arg.Notes, attendance.Notes)
+	require.False(t, attendance.UpdatedAt.Before(lesson.Attendance[0].UpdatedAt))
+
+	// the invoice of an absent student isn't changed
+	invoice, err := testQueries.GetInvoice(context.Background(), lesson.Invoices[0].InvoiceID)
+	require.NoError(t, err)
+	require.Equal(t, lesson.Invoices[0], invoice)
+
+	arg.StudentID = createRandomStudent(t).StudentID
+	_, err = testQueries.UpdateLessonAttendance(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestRemoveLessonStudentTxRestoresPackage(t *testing.T) {
+	store := NewStore(testDB)
+	plan := createRandomGroupPricingPlan(t, PricingModePerHead, 100)
+	lesson1 := createRandomGroupLesson(t, plan, 0)
+
+	student := createRandomStudent(t)
+	product := createRandomPackageProduct(t, PackageUnitLesson, 5, 0)
+	purchase := purchaseRandomPackage(t, student, product, lesson1.Lesson.LessonDatetime.AddDate(0, 0, -1))
+
+	lesson2, err := store.AddLessonStudentTx(context.Background(), AddLessonStudentTxParams{
+		LessonID:  lesson1.Lesson.LessonID,
+		StudentID: student.StudentID,
+	})
+	require.NoError(t, err)
+	require.Len(t, lesson2.PackageDeductions, 1)
+
+	pkg, err := testQueries.GetStudentPackage(context.Background(), purchase.Package.PackageID)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), pkg.RemainingUnits)
+
+	lesson3, err := store.RemoveLessonStudentTx(context.Background(), lesson1.Lesson.LessonID, student.StudentID)
+	require.NoError(t, err)
+	require.Empty(t, lesson3.PackageDeductions)
+	require.Len(t, lesson3.Attendance, 1)
+
+	pkg, err = testQueries.GetStudentPackage(context.Background(), purchase.Package.PackageID)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), pkg.RemainingUnits)
+}
@@ -0,0 +1,266 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: availability.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createAvailabilityException = `-- name: CreateAvailabilityException :one
+INSERT INTO availability_exceptions (
+  start_datetime, end_datetime, available, location_id, notes
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING exception_id, start_datetime, end_datetime, available, location_id, notes, created_at
+`
+
+type CreateAvailabilityExceptionParams struct {
+	StartDatetime time.Time      `json:"start_datetime"`
+	EndDatetime   time.Time      `json:"end_datetime"`
+	Available     bool           `json:"available"`
+	LocationID    sql.NullInt64  `json:"location_id"`
+	Notes         sql.NullString `json:"notes"`
+}
+
+func (q *Queries) CreateAvailabilityException(ctx context.Context, arg CreateAvailabilityExceptionParams) (AvailabilityException, error) {
+	row := q.db.QueryRowContext(ctx, createAvailabilityException,
+		arg.StartDatetime,
+		arg.EndDatetime,
+		arg.Available,
+		arg.LocationID,
+		arg.Notes,
+	)
+	var i AvailabilityException
+	err := row.Scan(
+		&i.ExceptionID,
+		&i.StartDatetime,
+		&i.EndDatetime,
+		&i.Available,
+		&i.LocationID,
+		&i.Notes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createAvailabilityWindow = `-- name: CreateAvailabilityWindow :one
+INSERT INTO availability_windows (
+  weekday, start_minute, end_minute, timezone, location_id
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING window_id, weekday, start_minute, end_minute, timezone, location_id, created_at
+`
+
+type CreateAvailabilityWindowParams struct {
+	Weekday     int32  `json:"weekday"`
+	StartMinute int32  `json:"start_minute"`
+	EndMinute   int32  `json:"end_minute"`
+	Timezone    string `json:"timezone"`
+	LocationID  int64  `json:"location_id"`
+}
+
+func (q *Queries) CreateAvailabilityWindow(ctx context.Context, arg CreateAvailabilityWindowParams) (AvailabilityWindow, error) {
+	row := q.db.QueryRowContext(ctx, createAvailabilityWindow,
+		arg.Weekday,
+		arg.StartMinute,
+		arg.EndMinute,
+		arg.Timezone,
+		arg.LocationID,
+	)
+	var i AvailabilityWindow
+	err := row.Scan(
+		&i.WindowID,
+		&i.Weekday,
+		&i.StartMinute,
+		&i.EndMinute,
+		&i.Timezone,
+		&i.LocationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteAvailabilityException = `-- name: DeleteAvailabilityException :execrows
+DELETE FROM availability_exceptions
+WHERE exception_id = $1
+`
+
+func (q *Queries) DeleteAvailabilityException(ctx context.Context, exceptionID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAvailabilityException, exceptionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteAvailabilityWindow = `-- name: DeleteAvailabilityWindow :execrows
+DELETE FROM availability_windows
+WHERE window_id = $1
+`
+
+func (q *Queries) DeleteAvailabilityWindow(ctx context.Context, windowID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAvailabilityWindow, windowID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getAvailabilityException = `-- name: GetAvailabilityException :one
+SELECT exception_id, start_datetime, end_datetime, available, location_id, notes, created_at FROM availability_exceptions
+WHERE exception_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAvailabilityException(ctx context.Context, exceptionID int64) (AvailabilityException, error) {
+	row := q.db.QueryRowContext(ctx, getAvailabilityException, exceptionID)
+	var i AvailabilityException
+	err := row.Scan(
+		&i.ExceptionID,
+		&i.StartDatetime,
+		&i.EndDatetime,
+		&i.Available,
+		&i.LocationID,
+		&i.Notes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getAvailabilityWindow = `-- name: GetAvailabilityWindow :one
+SELECT window_id, weekday, start_minute, end_minute, timezone, location_id, created_at FROM availability_windows
+WHERE window_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAvailabilityWindow(ctx context.Context, windowID int64) (AvailabilityWindow, error) {
+	row := q.db.QueryRowContext(ctx, getAvailabilityWindow, windowID)
+	var i AvailabilityWindow
+	err := row.Scan(
+		&i.WindowID,
+		&i.Weekday,
+		&i.StartMinute,
+		&i.EndMinute,
+		&i.Timezone,
+		&i.LocationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAvailabilityExceptions = `-- name: ListAvailabilityExceptions :many
+SELECT exception_id, start_datetime, end_datetime, available, location_id, notes, created_at FROM availability_exceptions
+WHERE start_datetime < $1
+  AND end_datetime > $2
+ORDER BY start_datetime, exception_id
+`
+
+type ListAvailabilityExceptionsParams struct {
+	EndDatetime   time.Time `json:"end_datetime"`
+	StartDatetime time.Time `json:"start_datetime"`
+}
+
+// ListAvailabilityExceptions lists the availability exceptions overlapping a period, by start datetime.
+func (q *Queries) ListAvailabilityExceptions(ctx context.Context, arg ListAvailabilityExceptionsParams) ([]AvailabilityException, error) {
+	rows, err := q.db.QueryContext(ctx, listAvailabilityExceptions, arg.EndDatetime, arg.StartDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AvailabilityException{}
+	for rows.Next() {
+		var i AvailabilityException
+		if err := rows.Scan(
+			&i.ExceptionID,
+			&i.StartDatetime,
+			&i.EndDatetime,
+			&i.Available,
+			&i.LocationID,
+			&i.Notes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAvailabilityWindows = `-- name: ListAvailabilityWindows :many
+SELECT window_id, weekday, start_minute, end_minute, timezone, location_id, created_at FROM availability_windows
+ORDER BY weekday, start_minute, window_id
+`
+
+// ListAvailabilityWindows lists the weekly availability of the tutor, by weekday and time.
+func (q *Queries) ListAvailabilityWindows(ctx context.Context) ([]AvailabilityWindow, error) {
+	rows, err := q.db.QueryContext(ctx, listAvailabilityWindows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AvailabilityWindow{}
+	for rows.Next() {
+		var i AvailabilityWindow
+		if err := rows.Scan(
+			&i.WindowID,
+			&i.Weekday,
+			&i.StartMinute,
+			&i.EndMinute,
+			&i.Timezone,
+			&i.LocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateAvailabilityWindow = `-- name: UpdateAvailabilityWindow :exec
+UPDATE availability_windows
+  set   weekday = $2,
+        start_minute = $3,
+        end_minute = $4,
+        timezone = $5,
+        location_id = $6
+WHERE window_id = $1
+`
+
+type UpdateAvailabilityWindowParams struct {
+	WindowID    int64  `json:"window_id"`
+	Weekday     int32  `json:"weekday"`
+	StartMinute int32  `json:"start_minute"`
+	EndMinute   int32  `json:"end_minute"`
+	Timezone    string `json:"timezone"`
+	LocationID  int64  `json:"location_id"`
+}
+
+func (q *Queries) UpdateAvailabilityWindow(ctx context.Context, arg UpdateAvailabilityWindowParams) error {
+	_, err := q.db.ExecContext(ctx, updateAvailabilityWindow,
+		arg.WindowID,
+		arg.Weekday,
+		arg.StartMinute,
+		arg.EndMinute,
+		arg.Timezone,
+		arg.LocationID,
+	)
+	return err
+}
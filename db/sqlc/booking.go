@@ -0,0 +1,202 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// Booking request statuses.
+const (
+	BookingStatusPending  = "pending"
+	BookingStatusApproved = "approved"
+	BookingStatusDeclined = "declined"
+)
+
+var (
+	// ErrBookingRequestNotPending is returned when a booking request that was already approved or declined is approved.
+	ErrBookingRequestNotPending = errors.New("booking request is not pending")
+	// ErrBookingSlotTaken is returned when a booking request is approved for a time that overlaps another lesson.
+	ErrBookingSlotTaken = errors.New("booking request overlaps another lesson")
+	// ErrBookingSlotUnavailable is returned when a booking request is approved for a time the tutor was since made unavailable at.
+	ErrBookingSlotUnavailable = errors.New("booking request overlaps a time the tutor isn't available")
+)
+
+// Availability is the availability of the tutor in a period: the weekly availability windows,
+// the exceptions to them and the lessons overlapping the period.
+type Availability struct {
+	Windows    []AvailabilityWindow    `json:"windows"`
+	Exceptions []AvailabilityException `json:"exceptions"`
+	Lessons    []Lesson                `json:"lessons"`
+}
+
+// GetAvailabilityTx gets the availability of the tutor in the period from start to end.
+func (store *SQLStore) GetAvailabilityTx(ctx context.Context, start, end time.Time) (Availability, error) {
+	var result Availability
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Windows, err = q.ListAvailabilityWindows(ctx)
+		if err != nil {
+			return err
+		}
+
+		result.Exceptions, err = q.ListAvailabilityExceptions(ctx, ListAvailabilityExceptionsParams{
+			StartDatetime: start,
+			EndDatetime:   end,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Lessons, err = q.ListLessonsBetween(ctx, ListLessonsBetweenParams{
+			StartDatetime: start,
+			EndDatetime:   end,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ApproveBookingRequestTxParams contains the input parameters of the ApproveBookingRequestTx function.
+// StudentID is the existing student the lesson is booked for, and is ignored if the request already has a student.
+// HourlyFee overrides the hourly fee of the lesson.
+type ApproveBookingRequestTxParams struct {
+	BookingRequestID int64           `json:"booking_request_id"`
+	StudentID        sql.NullInt64   `json:"student_id"`
+	HourlyFee        sql.NullFloat64 `json:"hourly_fee"`
+}
+
+// ApprovedBookingRequest is an approved booking request and the lesson created for it.
+type ApprovedBookingRequest struct {
+	BookingRequest BookingRequest     `json:"booking_request"`
+	Lesson         LessonWithInvoices `json:"lesson"`
+}
+
+// ApproveBookingRequestTx approves a pending booking request and creates its lesson, with the invoice of the student.
+// Unless a student is given, a new student is created with the contact details of the request and the hourly fee.
+// The hourly fee of the invoice is resolved as by ResolveHourlyFeeTx, unless it is given.
+// The lesson schedule is locked from the checks to the end of the transaction, so a lesson created concurrently,
+// by another approval or otherwise, can't overlap the lesson of the request.
+// It returns sql.ErrNoRows if the request or the student doesn't exist, ErrBookingRequestNotPending if the request
+// isn't pending, ErrBookingSlotTaken if the request overlaps another lesson, ErrBookingSlotUnavailable if it overlaps
+// an exception the tutor isn't available at, and ErrNoHourlyFee if there is no hourly fee.
+func (store *SQLStore) ApproveBookingRequestTx(ctx context.Context, arg ApproveBookingRequestTxParams) (ApprovedBookingRequest, error) {
+	var result ApprovedBookingRequest
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		request, err := q.GetBookingRequestForUpdate(ctx, arg.BookingRequestID)
+		if err != nil {
+			return err
+		}
+
+		if request.Status != BookingStatusPending {
+			return ErrBookingRequestNotPending
+		}
+
+		err = q.LockLessonSchedule(ctx)
+		if err != nil {
+			return err
+		}
+
+		end := request.LessonDatetime.Add(time.Duration(request.Duration) * time.Minute)
+		lessons, err := q.ListLessonsBetween(ctx, ListLessonsBetweenParams{
+			StartDatetime: request.LessonDatetime,
+			EndDatetime:   end,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(lessons) > 0 {
+			return ErrBookingSlotTaken
+		}
+
+		// the tutor may have become unavailable since the request was made
+		exceptions, err := q.ListAvailabilityExceptions(ctx, ListAvailabilityExceptionsParams{
+			StartDatetime: request.LessonDatetime,
+			EndDatetime:   end,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, exception := range exceptions {
+			if !exception.Available {
+				return ErrBookingSlotUnavailable
+			}
+		}
+
+		student, err := q.bookingRequestStudent(ctx, request, arg)
+		if err != nil {
+			return err
+		}
+
+		fee := ResolvedHourlyFee{HourlyFee: arg.HourlyFee.Float64}
+		if !arg.HourlyFee.Valid {
+			fee, err = q.resolveHourlyFee(ctx, GetApplicableFeeScheduleParams{
+				StudentID:      student.StudentID,
+				SubjectID:      request.SubjectID,
+				LessonDatetime: request.LessonDatetime,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Lesson, err = q.createLessonWithInvoices(ctx, CreateLessonTxParams{
+			LessonDatetime: request.LessonDatetime,
+			Duration:       request.Duration,
+			LocationID:     request.LocationID,
+			SubjectID:      request.SubjectID,
+			Notes:          request.Notes,
+			LessonInvoicesParams: []CreateLessonTxInvoiceParams{{
+				StudentID:     student.StudentID,
+				HourlyFee:     fee.HourlyFee,
+				Duration:      request.Duration,
+				Amount:        round2(fee.HourlyFee * float64(request.Duration) / 60.0),
+				FeeScheduleID: fee.FeeScheduleID,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.BookingRequest, err = q.ApproveBookingRequest(ctx, ApproveBookingRequestParams{
+			BookingRequestID: request.BookingRequestID,
+			StudentID:        sql.NullInt64{Int64: student.StudentID, Valid: true},
+			LessonID:         sql.NullInt64{Int64: result.Lesson.Lesson.LessonID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// bookingRequestStudent returns the student of a booking request being approved, within a transaction.
+// A new student is created with the contact details of the request if neither the request nor arg has a student.
+func (q *Queries) bookingRequestStudent(ctx context.Context, request BookingRequest, arg ApproveBookingRequestTxParams) (Student, error) {
+	if request.StudentID.Valid {
+		return q.GetStudent(ctx, request.StudentID.Int64)
+	}
+
+	if arg.StudentID.Valid {
+		return q.GetStudent(ctx, arg.StudentID.Int64)
+	}
+
+	student, err := q.CreateStudent(ctx, CreateStudentParams{
+		FirstName:   request.FirstName,
+		LastName:    request.LastName,
+		Email:       request.Email,
+		PhoneNumber: request.PhoneNumber,
+		HourlyFee:   arg.HourlyFee,
+	})
+	if err != nil {
+		return student, err
+	}
+
+	return student, q.enqueueWebhookEvent(ctx, WebhookEventStudentCreated, student)
+}
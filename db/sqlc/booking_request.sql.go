@@ -0,0 +1,249 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: booking_request.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const approveBookingRequest = `-- name: ApproveBookingRequest :one
+UPDATE booking_requests
+  set   status = 'approved',
+        student_id = $2,
+        lesson_id = $3,
+        decided_at = now()
+WHERE booking_request_id = $1
+RETURNING booking_request_id, first_name, last_name, email, phone_number, subject_id, location_id, lesson_datetime, duration, notes, status, student_id, lesson_id, created_at, decided_at
+`
+
+type ApproveBookingRequestParams struct {
+	BookingRequestID int64         `json:"booking_request_id"`
+	StudentID        sql.NullInt64 `json:"student_id"`
+	LessonID         sql.NullInt64 `json:"lesson_id"`
+}
+
+func (q *Queries) ApproveBookingRequest(ctx context.Context, arg ApproveBookingRequestParams) (BookingRequest, error) {
+	row := q.db.QueryRowContext(ctx, approveBookingRequest, arg.BookingRequestID, arg.StudentID, arg.LessonID)
+	var i BookingRequest
+	err := row.Scan(
+		&i.BookingRequestID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.SubjectID,
+		&i.LocationID,
+		&i.LessonDatetime,
+		&i.Duration,
+		&i.Notes,
+		&i.Status,
+		&i.StudentID,
+		&i.LessonID,
+		&i.CreatedAt,
+		&i.DecidedAt,
+	)
+	return i, err
+}
+
+const createBookingRequest = `-- name: CreateBookingRequest :one
+INSERT INTO booking_requests (
+  first_name, last_name, email, phone_number, subject_id, location_id, lesson_datetime, duration, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING booking_request_id, first_name, last_name, email, phone_number, subject_id, location_id, lesson_datetime, duration, notes, status, student_id, lesson_id, created_at, decided_at
+`
+
+type CreateBookingRequestParams struct {
+	FirstName      string         `json:"first_name"`
+	LastName       string         `json:"last_name"`
+	Email          sql.NullString `json:"email"`
+	PhoneNumber    sql.NullString `json:"phone_number"`
+	SubjectID      int64          `json:"subject_id"`
+	LocationID     int64          `json:"location_id"`
+	LessonDatetime time.Time      `json:"lesson_datetime"`
+	Duration       int64          `json:"duration"`
+	Notes          sql.NullString `json:"notes"`
+}
+
+func (q *Queries) CreateBookingRequest(ctx context.Context, arg CreateBookingRequestParams) (BookingRequest, error) {
+	row := q.db.QueryRowContext(ctx, createBookingRequest,
+		arg.FirstName,
+		arg.LastName,
+		arg.Email,
+		arg.PhoneNumber,
+		arg.SubjectID,
+		arg.LocationID,
+		arg.LessonDatetime,
+		arg.Duration,
+		arg.Notes,
+	)
+	var i BookingRequest
+	err := row.Scan(
+		&i.BookingRequestID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.SubjectID,
+		&i.LocationID,
+		&i.LessonDatetime,
+		&i.Duration,
+		&i.Notes,
+		&i.Status,
+		&i.StudentID,
+		&i.LessonID,
+		&i.CreatedAt,
+		&i.DecidedAt,
+	)
+	return i, err
+}
+
+const declineBookingRequest = `-- name: DeclineBookingRequest :one
+UPDATE booking_requests
+  set   status = 'declined',
+        decided_at = now()
+WHERE booking_request_id = $1
+  AND status = 'pending'
+RETURNING booking_request_id, first_name, last_name, email, phone_number, subject_id, location_id, lesson_datetime, duration, notes, status, student_id, lesson_id, created_at, decided_at
+`
+
+// DeclineBookingRequest declines a pending booking request. It returns no rows if the request isn't pending.
+func (q *Queries) DeclineBookingRequest(ctx context.Context, bookingRequestID int64) (BookingRequest, error) {
+	row := q.db.QueryRowContext(ctx, declineBookingRequest, bookingRequestID)
+	var i BookingRequest
+	err := row.Scan(
+		&i.BookingRequestID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.SubjectID,
+		&i.LocationID,
+		&i.LessonDatetime,
+		&i.Duration,
+		&i.Notes,
+		&i.Status,
+		&i.StudentID,
+		&i.LessonID,
+		&i.CreatedAt,
+		&i.DecidedAt,
+	)
+	return i, err
+}
+
+const getBookingRequest = `-- name: GetBookingRequest :one
+SELECT booking_request_id, first_name, last_name, email, phone_number, subject_id, location_id, lesson_datetime, duration, notes, status, student_id, lesson_id, created_at, decided_at FROM booking_requests
+WHERE booking_request_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetBookingRequest(ctx context.Context, bookingRequestID int64) (BookingRequest, error) {
+	row := q.db.QueryRowContext(ctx, getBookingRequest, bookingRequestID)
+	var i BookingRequest
+	err := row.Scan(
+		&i.BookingRequestID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.SubjectID,
+		&i.LocationID,
+		&i.LessonDatetime,
+		&i.Duration,
+		&i.Notes,
+		&i.Status,
+		&i.StudentID,
+		&i.LessonID,
+		&i.CreatedAt,
+		&i.DecidedAt,
+	)
+	return i, err
+}
+
+const getBookingRequestForUpdate = `-- name: GetBookingRequestForUpdate :one
+SELECT booking_request_id, first_name, last_name, email, phone_number, subject_id, location_id, lesson_datetime, duration, notes, status, student_id, lesson_id, created_at, decided_at FROM booking_requests
+WHERE booking_request_id = $1 LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetBookingRequestForUpdate(ctx context.Context, bookingRequestID int64) (BookingRequest, error) {
+	row := q.db.QueryRowContext(ctx, getBookingRequestForUpdate, bookingRequestID)
+	var i BookingRequest
+	err := row.Scan(
+		&i.BookingRequestID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.SubjectID,
+		&i.LocationID,
+		&i.LessonDatetime,
+		&i.Duration,
+		&i.Notes,
+		&i.Status,
+		&i.StudentID,
+		&i.LessonID,
+		&i.CreatedAt,
+		&i.DecidedAt,
+	)
+	return i, err
+}
+
+const listBookingRequests = `-- name: ListBookingRequests :many
+SELECT booking_request_id, first_name, last_name, email, phone_number, subject_id, location_id, lesson_datetime, duration, notes, status, student_id, lesson_id, created_at, decided_at FROM booking_requests
+WHERE ($1::varchar IS NULL OR status = $1)
+ORDER BY created_at, booking_request_id
+LIMIT $3
+OFFSET $2
+`
+
+type ListBookingRequestsParams struct {
+	Status sql.NullString `json:"status"`
+	Offset int32          `json:"offset"`
+	Limit  int32          `json:"limit"`
+}
+
+// ListBookingRequests lists the booking requests, oldest first, optionally with a single status.
+func (q *Queries) ListBookingRequests(ctx context.Context, arg ListBookingRequestsParams) ([]BookingRequest, error) {
+	rows, err := q.db.QueryContext(ctx, listBookingRequests, arg.Status, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BookingRequest{}
+	for rows.Next() {
+		var i BookingRequest
+		if err := rows.Scan(
+			&i.BookingRequestID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.PhoneNumber,
+			&i.SubjectID,
+			&i.LocationID,
+			&i.LessonDatetime,
+			&i.Duration,
+			&i.Notes,
+			&i.Status,
+			&i.StudentID,
+			&i.LessonID,
+			&i.CreatedAt,
+			&i.DecidedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
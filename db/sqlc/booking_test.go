@@ -0,0 +1,370 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// randomBookingDatetime returns a random hour of 2036, so the booked lessons of the tests rarely overlap.
+func randomBookingDatetime() time.Time {
+	return time.Date(2036, time.January, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(util.RandomInt64(0, 365*24-1)) * time.Hour)
+}
+
+// createRandomBookingRequest adds a new pending booking request of an hour at datetime.
+func createRandomBookingRequest(t *testing.T, datetime time.Time) BookingRequest {
+	arg := CreateBookingRequestParams{
+		FirstName:      util.RandomName(),
+		LastName:       util.RandomName(),
+		Email:          sql.NullString{String: util.RandomEmail(), Valid: true},
+		PhoneNumber:    sql.NullString{String: util.RandomPhoneNumber(), Valid: true},
+		SubjectID:      createRandomLessonSubject(t).SubjectID,
+		LocationID:     createRandomLessonLocation(t).LocationID,
+		LessonDatetime: datetime,
+		Duration:       60,
+		Notes:          sql.NullString{String: util.RandomNote(), Valid: true},
+	}
+
+	request, err := testQueries.CreateBookingRequest(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, request.BookingRequestID)
+	require.Equal(t, arg.FirstName, request.FirstName)
+	require.Equal(t, arg.LastName, request.LastName)
+	require.Equal(t, arg.Email, request.Email)
+	require.Equal(t, arg.PhoneNumber, request.PhoneNumber)
+	require.Equal(t, arg.SubjectID, request.SubjectID)
+	require.Equal(t, arg.LocationID, request.LocationID)
+	require.WithinDuration(t, arg.LessonDatetime, request.LessonDatetime, time.Second)
+	require.Equal(t, arg.Duration, request.Duration)
+	require.Equal(t, BookingStatusPending, request.Status)
+	require.False(t, request.StudentID.Valid)
+	require.False(t, request.LessonID.Valid)
+	require.False(t, request.DecidedAt.Valid)
+
+	return request
+}
+
+func TestAvailabilityWindows(t *testing.T) {
+	location := createRandomLessonLocation(t)
+
+	arg := CreateAvailabilityWindowParams{
+		Weekday:     int32(util.RandomInt64(0, 6)),
+		StartMinute: 16 * 60,
+		EndMinute:   19 * 60,
+		Timezone:    "Asia/Jerusalem",
+		LocationID:  location.LocationID,
+	}
+
+	window, err := testQueries.CreateAvailabilityWindow(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, window.WindowID)
+	require.Equal(t, arg.Weekday, window.Weekday)
+	require.Equal(t, arg.StartMinute, window.StartMinute)
+	require.Equal(t, arg.EndMinute, window.EndMinute)
+	require.Equal(t, arg.Timezone, window.Timezone)
+	require.Equal(t, arg.LocationID, window.LocationID)
+
+	err = testQueries.UpdateAvailabilityWindow(context.Background(), UpdateAvailabilityWindowParams{
+		WindowID:    window.WindowID,
+		Weekday:     window.Weekday,
+		StartMinute: 17 * 60,
+		EndMinute:   window.EndMinute,
+		Timezone:    window.Timezone,
+		LocationID:  window.LocationID,
+	})
+	require.NoError(t, err)
+
+	window, err = testQueries.GetAvailabilityWindow(context.Background(), window.WindowID)
+	require.NoError(t, err)
+	require.Equal(t, int32(17*60), window.StartMinute)
+
+	// windows must end after they start
+	arg.EndMinute = arg.StartMinute
+	_, err = testQueries.CreateAvailabilityWindow(context.Background(), arg)
+	require.Error(t, err)
+
+	rows, err := testQueries.DeleteAvailabilityWindow(context.Background(), window.WindowID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
+
+func TestGetAvailabilityTx(t *testing.T) {
+	store := NewStore(testDB)
+	start := randomBookingDatetime()
+	end := start.Add(24 * time.Hour)
+
+	location := createRandomLessonLocation(t)
+	window, err := testQueries.CreateAvailabilityWindow(context.Background(), CreateAvailabilityWindowParams{
+		Weekday:     int32(start.Weekday()),
+		StartMinute: 9 * 60,
+		EndMinute:   12 * 60,
+		Timezone:    "UTC",
+		LocationID:  location.LocationID,
+	})
+	require.NoError(t, err)
+	defer testQueries.DeleteAvailabilityWindow(context.Background(), window.WindowID)
+
+	exception, err := testQueries.CreateAvailabilityException(context.Background(), CreateAvailabilityExceptionParams{
+		StartDatetime: start.Add(2 * time.Hour),
+		EndDatetime:   start.Add(4 * time.Hour),
+		Available:     true,
+		LocationID:    sql.NullInt64{Int64: location.LocationID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	lesson, err := createRandomLessonWithInvoiceTx(t, start.Add(3*time.Hour), createRandomStudent(t))
+	require.NoError(t, err)
+
+	availability, err := store.GetAvailabilityTx(context.Background(), start, end)
+	require.NoError(t, err)
+
+	var windowIDs []int64
+	for _, w := range availability.Windows {
+		windowIDs = append(windowIDs, w.WindowID)
+	}
+	require.Contains(t, windowIDs, window.WindowID)
+
+	var exceptionIDs []int64
+	for _, e := range availability.Exceptions {
+		exceptionIDs = append(exceptionIDs, e.ExceptionID)
+	}
+	require.Contains(t, exceptionIDs, exception.ExceptionID)
+
+	var lessonIDs []int64
+	for _, l := range availability.Lessons {
+		lessonIDs = append(lessonIDs, l.LessonID)
+	}
+	require.Contains(t, lessonIDs, lesson.Lesson.LessonID)
+
+	// exceptions and lessons outside the period are excluded
+	availability, err = store.GetAvailabilityTx(context.Background(), end, end.Add(time.Hour))
+	require.NoError(t, err)
+	for _, e := range availability.Exceptions {
+		require.NotEqual(t, exception.ExceptionID, e.ExceptionID)
+	}
+	for _, l := range availability.Lessons {
+		require.NotEqual(t, lesson.Lesson.LessonID, l.LessonID)
+	}
+}
+
+func TestApproveBookingRequestTx(t *testing.T) {
+	store := NewStore(testDB)
+	request := createRandomBookingRequest(t, randomBookingDatetime())
+	hourlyFee := util.RandomHourlyFee()
+
+	// there is no hourly fee for the new student
+	_, err := store.ApproveBookingRequestTx(context.Background(), ApproveBookingRequestTxParams{
+		BookingRequestID: request.BookingRequestID,
+	})
+	require.ErrorIs(t, err, ErrNoHourlyFee)
+
+	result, err := store.ApproveBookingRequestTx(context.Background(), ApproveBookingRequestTxParams{
+		BookingRequestID: request.BookingRequestID,
+		HourlyFee:        sql.NullFloat64{Float64: hourlyFee, Valid: true},
+	})
+	require.NoError(t, err)
+
+	approved := result.BookingRequest
+	require.Equal(t, BookingStatusApproved, approved.Status)
+	require.True(t, approved.DecidedAt.Valid)
+	require.Equal(t, sql.NullInt64{Int64: result.Lesson.Lesson.LessonID, Valid: true}, approved.LessonID)
+	require.True(t, approved.StudentID.Valid)
+
+	// a new student is created with the contact details of the request
+	student, err := testQueries.GetStudent(context.Background(), approved.StudentID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, request.FirstName, student.FirstName)
+	require.Equal(t, request.LastName, student.LastName)
+	require.Equal(t, request.Email, student.Email)
+	require.Equal(t, sql.NullFloat64{Float64: hourlyFee, Valid: true}, student.HourlyFee)
+
+	lesson := result.Lesson.Lesson
+	require.WithinDuration(t, request.LessonDatetime, lesson.LessonDatetime, time.Second)
+	require.Equal(t, request.Duration, lesson.Duration)
+	require.Equal(t, request.LocationID, lesson.LocationID)
+	require.Equal(t, request.SubjectID, lesson.SubjectID)
+
+	require.Len(t, result.Lesson.Invoices, 1)
+	require.Equal(t, student.StudentID, result.Lesson.Invoices[0].StudentID)
+	require.InDelta(t, hourlyFee, result.Lesson.Invoices[0].Amount, 0.01)
+
+	// a request is approved once
+	_, err = store.ApproveBookingRequestTx(context.Background(), ApproveBookingRequestTxParams{
+		BookingRequestID: request.BookingRequestID,
+	})
+	require.ErrorIs(t, err, ErrBookingRequestNotPending)
+
+	// a request overlapping the lesson can't be approved
+	overlapping := createRandomBookingRequest(t, request.LessonDatetime.Add(30*time.Minute))
+	_, err = store.ApproveBookingRequestTx(context.Background(), ApproveBookingRequestTxParams{
+		BookingRequestID: overlapping.BookingRequestID,
+		StudentID:        sql.NullInt64{Int64: student.StudentID, Valid: true},
+	})
+	require.ErrorIs(t, err, ErrBookingSlotTaken)
+
+	_, err = store.ApproveBookingRequestTx(context.Background(), ApproveBookingRequestTxParams{BookingRequestID: -1})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestApproveBookingRequestTxStudent(t *testing.T) {
+	store := NewStore(testDB)
+	request := createRandomBookingRequest(t, randomBookingDatetime())
+	student := createRandomStudent(t)
+
+	// the lesson is booked for the existing student at the hourly fee of the student
+	result, err := store.ApproveBookingRequestTx(context.Background(), ApproveBookingRequestTxParams{
+		BookingRequestID: request.BookingRequestID,
+		StudentID:        sql.NullInt64{Int64: student.StudentID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, sql.NullInt64{Int64: student.StudentID, Valid: true}, result.BookingRequest.StudentID)
+	require.Len(t, result.Lesson.Invoices, 1)
+	require.Equal(t, student.HourlyFee.Float64, result.Lesson.Invoices[0].HourlyFee)
+}
+
+func TestApproveBookingRequestTxConcurrent(t *testing.T) {
+	store := NewStore(testDB)
+	student := createRandomStudent(t)
+
+	datetime := randomBookingDatetime()
+	requests := []BookingRequest{
+		createRandomBookingRequest(t, datetime),
+		createRandomBookingRequest(t, datetime.Add(30*time.Minute)),
+	}
+
+	// overlapping requests approved concurrently don't both create lessons
+	errs := make(chan error, len(requests))
+	for _, request := range requests {
+		go func(request BookingRequest) {
+			_, err := store.ApproveBookingRequestTx(context.Background(), ApproveBookingRequestTxParams{
+				BookingRequestID: request.BookingRequestID,
+				StudentID:        sql.NullInt64{Int64: student.StudentID, Valid: true},
+			})
+			errs <- err
+		}(request)
+	}
+
+	var approved, taken int
+	for range requests {
+		err := <-errs
+		switch {
+		case err == nil:
+			approved++
+		case errors.Is(err, ErrBookingSlotTaken):
+			taken++
+		default:
+			require.NoError(t, err)
+		}
+	}
+	require.Equal(t, 1, approved)
+	require.Equal(t, 1, taken)
+}
+
+func TestApproveBookingRequestTxUnavailable(t *testing.T) {
+	store := NewStore(testDB)
+	student := createRandomStudent(t)
+	request := createRandomBookingRequest(t, randomBookingDatetime())
+
+	// the tutor was made unavailable at the time of the request after it was made
+	exception, err := testQueries.CreateAvailabilityException(context.Background(), CreateAvailabilityExceptionParams{
+		StartDatetime: request.LessonDatetime.Add(-time.Hour),
+		EndDatetime:   request.LessonDatetime.Add(30 * time.Minute),
+		Available:     false,
+	})
+	require.NoError(t, err)
+
+	arg := ApproveBookingRequestTxParams{
+		BookingRequestID: request.BookingRequestID,
+		StudentID:        sql.NullInt64{Int64: student.StudentID, Valid: true},
+	}
+	_, err = store.ApproveBookingRequestTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrBookingSlotUnavailable)
+
+	_, err = testQueries.DeleteAvailabilityException(context.Background(), exception.ExceptionID)
+	require.NoError(t, err)
+
+	_, err = store.ApproveBookingRequestTx(context.Background(), arg)
+	require.NoError(t, err)
+}
+
+func TestCreateLessonWithInvoicesTxLocksSchedule(t *testing.T) {
+	store := NewStore(testDB)
+	student := createRandomStudent(t)
+	arg := CreateLessonTxParams{
+		LessonDatetime: randomBookingDatetime(),
+		Duration:       60,
+		LocationID:     createRandomLessonLocation(t).LocationID,
+		SubjectID:      createRandomLessonSubject(t).SubjectID,
+		LessonInvoicesParams: []CreateLessonTxInvoiceParams{{
+			StudentID: student.StudentID,
+			HourlyFee: student.HourlyFee.Float64,
+			Duration:  60,
+			Amount:    student.HourlyFee.Float64,
+		}},
+	}
+
+	// a transaction checking the lesson schedule, such as an approval, holds its lock
+	tx, err := testDB.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	err = New(tx).LockLessonSchedule(context.Background())
+	require.NoError(t, err)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := store.CreateLessonWithInvoicesTx(context.Background(), arg)
+		errs <- err
+	}()
+
+	// the lesson isn't created until the lock is released
+	select {
+	case err := <-errs:
+		require.FailNow(t, "lesson created while the lesson schedule was locked", "error: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	require.NoError(t, tx.Commit())
+
+	select {
+	case err := <-errs:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for the lesson to be created")
+	}
+}
+
+func TestDeclineBookingRequest(t *testing.T) {
+	request := createRandomBookingRequest(t, randomBookingDatetime())
+
+	declined, err := testQueries.DeclineBookingRequest(context.Background(), request.BookingRequestID)
+	require.NoError(t, err)
+	require.Equal(t, BookingStatusDeclined, declined.Status)
+	require.True(t, declined.DecidedAt.Valid)
+
+	// a request is declined once
+	_, err = testQueries.DeclineBookingRequest(context.Background(), request.BookingRequestID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListBookingRequests(t *testing.T) {
+	request := createRandomBookingRequest(t, randomBookingDatetime())
+	_, err := testQueries.DeclineBookingRequest(context.Background(), request.BookingRequestID)
+	require.NoError(t, err)
+
+	requests, err := testQueries.ListBookingRequests(context.Background(), ListBookingRequestsParams{
+		Status: sql.NullString{String: BookingStatusPending, Valid: true},
+		Limit:  10,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	for _, r := range requests {
+		require.Equal(t, BookingStatusPending, r.Status)
+		require.NotEqual(t, request.BookingRequestID, r.BookingRequestID)
+	}
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Custom field types.
+const (
+	CustomFieldTypeText   = "text"
+	CustomFieldTypeNumber = "number"
+	CustomFieldTypeDate   = "date"
+	CustomFieldTypeEnum   = "enum"
+)
+
+// CustomFieldDateLayout is the layout of the values of the date custom fields, such as 2024-03-31.
+const CustomFieldDateLayout = "2006-01-02"
+
+// ErrInvalidCustomFieldValue is returned when a value doesn't match the type or the options of its custom field.
+var ErrInvalidCustomFieldValue = errors.New("invalid custom field value")
+
+// NormalizeValue returns the canonical form of a value of the field, as it is stored and searched.
+// Numbers are formatted without trailing zeros, and dates keep CustomFieldDateLayout.
+// It returns ErrInvalidCustomFieldValue if value doesn't match the type of the field, or isn't one of the enum options.
+func (field CustomField) NormalizeValue(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("%w: %s is empty", ErrInvalidCustomFieldValue, field.Name)
+	}
+
+	switch field.FieldType {
+	case CustomFieldTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s must be a number", ErrInvalidCustomFieldValue, field.Name)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case CustomFieldTypeDate:
+		date, err := time.Parse(CustomFieldDateLayout, value)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s must be a date (%s)", ErrInvalidCustomFieldValue, field.Name, CustomFieldDateLayout)
+		}
+		return date.Format(CustomFieldDateLayout), nil
+	case CustomFieldTypeEnum:
+		if !slices.Contains(field.Options, value) {
+			return "", fmt.Errorf("%w: %s must be one of %s", ErrInvalidCustomFieldValue, field.Name, strings.Join(field.Options, ", "))
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+// SetStudentCustomValueTx sets the value of a custom field of a student, in the canonical form of the field type.
+// It returns sql.ErrNoRows if the custom field doesn't exist, and ErrInvalidCustomFieldValue if the value doesn't match the field.
+func (store *SQLStore) SetStudentCustomValueTx(ctx context.Context, arg UpsertStudentCustomValueParams) (StudentCustomValue, error) {
+	var result StudentCustomValue
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		field, err := q.GetCustomField(ctx, arg.FieldID)
+		if err != nil {
+			return err
+		}
+
+		arg.Value, err = field.NormalizeValue(arg.Value)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.UpsertStudentCustomValue(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// ExportedStudent is a student with the names of its tags and its custom field values by field ID.
+type ExportedStudent struct {
+	Student Student          `json:"student"`
+	Tags    []string         `json:"tags"`
+	Values  map[int64]string `json:"values"`
+}
+
+// StudentExport contains the students matching the filters of an export, and all the custom fields.
+type StudentExport struct {
+	Fields   []CustomField     `json:"fields"`
+	Students []ExportedStudent `json:"students"`
+}
+
+// ExportStudentsTx returns all the students matching the filters, with their tags and custom field values.
+func (store *SQLStore) ExportStudentsTx(ctx context.Context, arg ExportStudentsParams) (StudentExport, error) {
+	var result StudentExport
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Fields, err = q.ListAllCustomFields(ctx)
+		if err != nil {
+			return err
+		}
+
+		students, err := q.ExportStudents(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]int64, 0, len(students))
+		index := make(map[int64]int, len(students))
+		result.Students = make([]ExportedStudent, 0, len(students))
+		for i, student := range students {
+			ids = append(ids, student.StudentID)
+			index[student.StudentID] = i
+			result.Students = append(result.Students, ExportedStudent{
+				Student: student,
+				Tags:    []string{},
+				Values:  map[int64]string{},
+			})
+		}
+
+		tags, err := q.GetStudentsTags(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range tags {
+			exported := &result.Students[index[tag.StudentID]]
+			exported.Tags = append(exported.Tags, tag.Name)
+		}
+
+		values, err := q.GetStudentsCustomValues(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, value := range values {
+			result.Students[index[value.StudentID]].Values[value.FieldID] = value.Value
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,297 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: custom_field.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const createCustomField = `-- name: CreateCustomField :one
+INSERT INTO custom_fields (
+  name, field_type, options
+) VALUES (
+  $1, $2, $3
+)
+RETURNING field_id, name, field_type, options, created_at
+`
+
+type CreateCustomFieldParams struct {
+	Name      string   `json:"name"`
+	FieldType string   `json:"field_type"`
+	Options   []string `json:"options"`
+}
+
+func (q *Queries) CreateCustomField(ctx context.Context, arg CreateCustomFieldParams) (CustomField, error) {
+	row := q.db.QueryRowContext(ctx, createCustomField, arg.Name, arg.FieldType, pq.Array(arg.Options))
+	var i CustomField
+	err := row.Scan(
+		&i.FieldID,
+		&i.Name,
+		&i.FieldType,
+		pq.Array(&i.Options),
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteCustomField = `-- name: DeleteCustomField :execrows
+DELETE FROM custom_fields
+WHERE field_id = $1
+`
+
+func (q *Queries) DeleteCustomField(ctx context.Context, fieldID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCustomField, fieldID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteStudentCustomValue = `-- name: DeleteStudentCustomValue :execrows
+DELETE FROM student_custom_values
+WHERE student_id = $1 AND field_id = $2
+`
+
+type DeleteStudentCustomValueParams struct {
+	StudentID int64 `json:"student_id"`
+	FieldID   int64 `json:"field_id"`
+}
+
+func (q *Queries) DeleteStudentCustomValue(ctx context.Context, arg DeleteStudentCustomValueParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteStudentCustomValue, arg.StudentID, arg.FieldID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getCustomField = `-- name: GetCustomField :one
+SELECT field_id, name, field_type, options, created_at FROM custom_fields
+WHERE field_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetCustomField(ctx context.Context, fieldID int64) (CustomField, error) {
+	row := q.db.QueryRowContext(ctx, getCustomField, fieldID)
+	var i CustomField
+	err := row.Scan(
+		&i.FieldID,
+		&i.Name,
+		&i.FieldType,
+		pq.Array(&i.Options),
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getStudentCustomValues = `-- name: GetStudentCustomValues :many
+SELECT v.field_id, f.name, f.field_type, v.value, v.updated_at
+FROM student_custom_values v
+JOIN custom_fields f ON f.field_id = v.field_id
+WHERE v.student_id = $1
+ORDER BY f.name
+`
+
+type GetStudentCustomValuesRow struct {
+	FieldID   int64     `json:"field_id"`
+	Name      string    `json:"name"`
+	FieldType string    `json:"field_type"`
+	Value     string    `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) GetStudentCustomValues(ctx context.Context, studentID int64) ([]GetStudentCustomValuesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStudentCustomValues, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStudentCustomValuesRow{}
+	for rows.Next() {
+		var i GetStudentCustomValuesRow
+		if err := rows.Scan(
+			&i.FieldID,
+			&i.Name,
+			&i.FieldType,
+			&i.Value,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStudentsCustomValues = `-- name: GetStudentsCustomValues :many
+SELECT student_id, field_id, value
+FROM student_custom_values
+WHERE student_id = ANY($1::bigint[])
+ORDER BY student_id, field_id
+`
+
+type GetStudentsCustomValuesRow struct {
+	StudentID int64  `json:"student_id"`
+	FieldID   int64  `json:"field_id"`
+	Value     string `json:"value"`
+}
+
+// GetStudentsCustomValues returns the custom field values of several students, by student.
+func (q *Queries) GetStudentsCustomValues(ctx context.Context, studentIds []int64) ([]GetStudentsCustomValuesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStudentsCustomValues, pq.Array(studentIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStudentsCustomValuesRow{}
+	for rows.Next() {
+		var i GetStudentsCustomValuesRow
+		if err := rows.Scan(&i.StudentID, &i.FieldID, &i.Value); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllCustomFields = `-- name: ListAllCustomFields :many
+SELECT field_id, name, field_type, options, created_at FROM custom_fields
+ORDER BY name
+`
+
+func (q *Queries) ListAllCustomFields(ctx context.Context) ([]CustomField, error) {
+	rows, err := q.db.QueryContext(ctx, listAllCustomFields)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CustomField{}
+	for rows.Next() {
+		var i CustomField
+		if err := rows.Scan(
+			&i.FieldID,
+			&i.Name,
+			&i.FieldType,
+			pq.Array(&i.Options),
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCustomFields = `-- name: ListCustomFields :many
+SELECT field_id, name, field_type, options, created_at FROM custom_fields
+ORDER BY name
+LIMIT $1
+OFFSET $2
+`
+
+type ListCustomFieldsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListCustomFields(ctx context.Context, arg ListCustomFieldsParams) ([]CustomField, error) {
+	rows, err := q.db.QueryContext(ctx, listCustomFields, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CustomField{}
+	for rows.Next() {
+		var i CustomField
+		if err := rows.Scan(
+			&i.FieldID,
+			&i.Name,
+			&i.FieldType,
+			pq.Array(&i.Options),
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateCustomField = `-- name: UpdateCustomField :exec
+UPDATE custom_fields
+  set   name = $2,
+        options = $3
+WHERE field_id = $1
+`
+
+type UpdateCustomFieldParams struct {
+	FieldID int64    `json:"field_id"`
+	Name    string   `json:"name"`
+	Options []string `json:"options"`
+}
+
+// UpdateCustomField renames a custom field and updates its enum options. The type of a field is never changed,
+// as the values of the students would no longer match it.
+func (q *Queries) UpdateCustomField(ctx context.Context, arg UpdateCustomFieldParams) error {
+	_, err := q.db.ExecContext(ctx, updateCustomField, arg.FieldID, arg.Name, pq.Array(arg.Options))
+	return err
+}
+
+const upsertStudentCustomValue = `-- name: UpsertStudentCustomValue :one
+INSERT INTO student_custom_values (
+  student_id, field_id, value
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (student_id, field_id) DO UPDATE
+  set   value = EXCLUDED.value,
+        updated_at = now()
+RETURNING student_id, field_id, value, updated_at
+`
+
+type UpsertStudentCustomValueParams struct {
+	StudentID int64  `json:"student_id"`
+	FieldID   int64  `json:"field_id"`
+	Value     string `json:"value"`
+}
+
+func (q *Queries) UpsertStudentCustomValue(ctx context.Context, arg UpsertStudentCustomValueParams) (StudentCustomValue, error) {
+	row := q.db.QueryRowContext(ctx, upsertStudentCustomValue, arg.StudentID, arg.FieldID, arg.Value)
+	var i StudentCustomValue
+	err := row.Scan(
+		&i.StudentID,
+		&i.FieldID,
+		&i.Value,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
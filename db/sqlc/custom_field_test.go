@@ -0,0 +1,193 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomCustomField adds a new custom field of the field type with a random unique name.
+// Enum fields have the options low, medium and high.
+func createRandomCustomField(t *testing.T, fieldType string) CustomField {
+	arg := CreateCustomFieldParams{
+		Name:      util.RandomName() + " " + util.RandomString(8),
+		FieldType: fieldType,
+		Options:   []string{},
+	}
+
+	if fieldType == CustomFieldTypeEnum {
+		arg.Options = []string{"low", "medium", "high"}
+	}
+
+	field, err := testQueries.CreateCustomField(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, field.FieldID)
+	require.Equal(t, arg.Name, field.Name)
+	require.Equal(t, arg.FieldType, field.FieldType)
+	require.Equal(t, arg.Options, field.Options)
+
+	return field
+}
+
+func TestCustomFields(t *testing.T) {
+	field := createRandomCustomField(t, CustomFieldTypeEnum)
+
+	arg := UpdateCustomFieldParams{
+		FieldID: field.FieldID,
+		Name:    util.RandomName() + " " + util.RandomString(8),
+		Options: []string{"low", "high"},
+	}
+	err := testQueries.UpdateCustomField(context.Background(), arg)
+	require.NoError(t, err)
+
+	updated, err := testQueries.GetCustomField(context.Background(), field.FieldID)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, updated.Name)
+	require.Equal(t, arg.Options, updated.Options)
+	require.Equal(t, CustomFieldTypeEnum, updated.FieldType)
+
+	// enum fields must have options
+	arg.Options = []string{}
+	err = testQueries.UpdateCustomField(context.Background(), arg)
+	require.Error(t, err)
+
+	rows, err := testQueries.DeleteCustomField(context.Background(), field.FieldID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = testQueries.GetCustomField(context.Background(), field.FieldID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestNormalizeCustomFieldValue(t *testing.T) {
+	testCases := []struct {
+		fieldType  string
+		value      string
+		normalized string
+		ok         bool
+	}{
+		{CustomFieldTypeText, " dyslexia ", "dyslexia", true},
+		{CustomFieldTypeText, "  ", "", false},
+		{CustomFieldTypeNumber, "07.50", "7.5", true},
+		{CustomFieldTypeNumber, "ten", "", false},
+		{CustomFieldTypeDate, "2024-03-31", "2024-03-31", true},
+		{CustomFieldTypeDate, "31/03/2024", "", false},
+		{CustomFieldTypeEnum, "medium", "medium", true},
+		{CustomFieldTypeEnum, "Medium", "", false},
+	}
+
+	for _, tc := range testCases {
+		field := CustomField{Name: "field", FieldType: tc.fieldType, Options: []string{"low", "medium", "high"}}
+
+		normalized, err := field.NormalizeValue(tc.value)
+		if tc.ok {
+			require.NoError(t, err)
+			require.Equal(t, tc.normalized, normalized)
+		} else {
+			require.ErrorIs(t, err, ErrInvalidCustomFieldValue)
+		}
+	}
+}
+
+func TestSetStudentCustomValueTx(t *testing.T) {
+	store := NewStore(testDB)
+	student := createRandomStudent(t)
+	field := createRandomCustomField(t, CustomFieldTypeNumber)
+
+	arg := UpsertStudentCustomValueParams{
+		StudentID: student.StudentID,
+		FieldID:   field.FieldID,
+		Value:     "10.0",
+	}
+
+	value, err := store.SetStudentCustomValueTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "10", value.Value)
+
+	// setting the value again replaces it
+	arg.Value = "11"
+	_, err = store.SetStudentCustomValueTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	values, err := testQueries.GetStudentCustomValues(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	require.Equal(t, "11", values[0].Value)
+	require.Equal(t, field.Name, values[0].Name)
+	require.Equal(t, CustomFieldTypeNumber, values[0].FieldType)
+
+	arg.Value = "eleven"
+	_, err = store.SetStudentCustomValueTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInvalidCustomFieldValue)
+
+	arg.FieldID = -1
+	_, err = store.SetStudentCustomValueTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// students are searched by the normalized value
+	students, err := testQueries.ListStudents(context.Background(), ListStudentsParams{
+		FieldID:    sql.NullInt64{Int64: field.FieldID, Valid: true},
+		FieldValue: sql.NullString{String: "11", Valid: true},
+		Limit:      5,
+		Offset:     0,
+	})
+	require.NoError(t, err)
+	require.Len(t, students, 1)
+	require.Equal(t, student.StudentID, students[0].StudentID)
+
+	rows, err := testQueries.DeleteStudentCustomValue(context.Background(), DeleteStudentCustomValueParams{
+		StudentID: student.StudentID,
+		FieldID:   field.FieldID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
+
+func TestExportStudentsTx(t *testing.T) {
+	store := NewStore(testDB)
+	tag := createRandomTag(t)
+	field := createRandomCustomField(t, CustomFieldTypeEnum)
+
+	tagged, untagged := createRandomStudent(t), createRandomStudent(t)
+	err := testQueries.AddStudentTag(context.Background(), AddStudentTagParams{StudentID: tagged.StudentID, TagID: tag.TagID})
+	require.NoError(t, err)
+
+	for _, student := range []Student{tagged, untagged} {
+		_, err = store.SetStudentCustomValueTx(context.Background(), UpsertStudentCustomValueParams{
+			StudentID: student.StudentID,
+			FieldID:   field.FieldID,
+			Value:     "high",
+		})
+		require.NoError(t, err)
+	}
+
+	export, err := store.ExportStudentsTx(context.Background(), ExportStudentsParams{
+		FieldID:    sql.NullInt64{Int64: field.FieldID, Valid: true},
+		FieldValue: sql.NullString{String: "high", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, export.Students, 2)
+	require.Contains(t, export.Fields, field)
+
+	for _, exported := range export.Students {
+		require.Equal(t, "high", exported.Values[field.FieldID])
+
+		if exported.Student.StudentID == tagged.StudentID {
+			require.Equal(t, []string{tag.Name}, exported.Tags)
+		} else {
+			require.Equal(t, untagged.StudentID, exported.Student.StudentID)
+			require.Empty(t, exported.Tags)
+		}
+	}
+
+	// the export is filtered like the students search
+	export, err = store.ExportStudentsTx(context.Background(), ExportStudentsParams{
+		TagID: sql.NullInt64{Int64: tag.TagID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, export.Students, 1)
+	require.Equal(t, tagged.StudentID, export.Students[0].Student.StudentID)
+}
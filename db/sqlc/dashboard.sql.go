@@ -0,0 +1,279 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: dashboard.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const countNewStudents = `-- name: CountNewStudents :one
+SELECT COUNT(*) FROM students
+WHERE created_at >= $1
+  AND created_at < $2
+`
+
+type CountNewStudentsParams struct {
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+func (q *Queries) CountNewStudents(ctx context.Context, arg CountNewStudentsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countNewStudents, arg.StartDatetime, arg.EndDatetime)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getDashboardLessons = `-- name: GetDashboardLessons :many
+SELECT l.lesson_id,
+       l.lesson_datetime,
+       l.duration,
+       ll.name AS location_name,
+       ls.name AS subject_name,
+       (SELECT COUNT(*) FROM lesson_attendance la WHERE la.lesson_id = l.lesson_id)::bigint AS students_count,
+       (SELECT COALESCE(SUM(i.amount), 0) FROM invoices i WHERE i.lesson_id = l.lesson_id)::float AS invoiced_amount
+FROM lessons l
+JOIN lesson_locations ll ON ll.location_id = l.location_id
+JOIN lesson_subjects ls ON ls.subject_id = l.subject_id
+WHERE l.lesson_datetime >= $1
+  AND l.lesson_datetime < $2
+ORDER BY l.lesson_datetime
+`
+
+type GetDashboardLessonsParams struct {
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+type GetDashboardLessonsRow struct {
+	LessonID       int64     `json:"lesson_id"`
+	LessonDatetime time.Time `json:"lesson_datetime"`
+	Duration       int64     `json:"duration"`
+	LocationName   string    `json:"location_name"`
+	SubjectName    string    `json:"subject_name"`
+	StudentsCount  int64     `json:"students_count"`
+	InvoicedAmount float64   `json:"invoiced_amount"`
+}
+
+// GetDashboardLessons returns the lessons held between the datetimes, with the number of students attending each lesson,
+// whether invoiced or attending for free, and the amount invoiced for it.
+func (q *Queries) GetDashboardLessons(ctx context.Context, arg GetDashboardLessonsParams) ([]GetDashboardLessonsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDashboardLessons, arg.StartDatetime, arg.EndDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDashboardLessonsRow{}
+	for rows.Next() {
+		var i GetDashboardLessonsRow
+		if err := rows.Scan(
+			&i.LessonID,
+			&i.LessonDatetime,
+			&i.Duration,
+			&i.LocationName,
+			&i.SubjectName,
+			&i.StudentsCount,
+			&i.InvoicedAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getInvoicedAmount = `-- name: GetInvoicedAmount :one
+SELECT COALESCE(SUM(amount), 0)::float AS invoiced_amount
+FROM invoices
+WHERE invoice_datetime >= $1
+  AND invoice_datetime < $2
+`
+
+type GetInvoicedAmountParams struct {
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+func (q *Queries) GetInvoicedAmount(ctx context.Context, arg GetInvoicedAmountParams) (float64, error) {
+	row := q.db.QueryRowContext(ctx, getInvoicedAmount, arg.StartDatetime, arg.EndDatetime)
+	var invoiced_amount float64
+	err := row.Scan(&invoiced_amount)
+	return invoiced_amount, err
+}
+
+const getOutstandingBalance = `-- name: GetOutstandingBalance :one
+WITH balances AS (
+  SELECT s.student_id,
+         COALESCE((SELECT SUM(i.amount) FROM invoices i WHERE i.student_id = s.student_id), 0)::float AS invoiced_amount,
+         COALESCE((SELECT SUM(r.amount) FROM receipts r WHERE r.student_id = s.student_id), 0)::float AS received_amount
+  FROM students s
+)
+SELECT COALESCE(SUM(b.invoiced_amount - b.received_amount) FILTER (WHERE b.invoiced_amount > b.received_amount), 0)::float AS outstanding_amount,
+       COALESCE(SUM(b.received_amount - b.invoiced_amount) FILTER (WHERE b.received_amount > b.invoiced_amount), 0)::float AS credit_amount,
+       COUNT(*) FILTER (WHERE b.invoiced_amount > b.received_amount) AS students_count
+FROM balances b
+`
+
+type GetOutstandingBalanceRow struct {
+	OutstandingAmount float64 `json:"outstanding_amount"`
+	CreditAmount      float64 `json:"credit_amount"`
+	StudentsCount     int64   `json:"students_count"`
+}
+
+func (q *Queries) GetOutstandingBalance(ctx context.Context) (GetOutstandingBalanceRow, error) {
+	row := q.db.QueryRowContext(ctx, getOutstandingBalance)
+	var i GetOutstandingBalanceRow
+	err := row.Scan(&i.OutstandingAmount, &i.CreditAmount, &i.StudentsCount)
+	return i, err
+}
+
+const getUpcomingExams = `-- name: GetUpcomingExams :many
+SELECT e.exam_id,
+       e.name,
+       e.exam_datetime,
+       e.target_grade,
+       s.student_id,
+       s.first_name,
+       s.last_name,
+       ls.name AS subject_name
+FROM exams e
+JOIN students s ON s.student_id = e.student_id
+JOIN lesson_subjects ls ON ls.subject_id = e.subject_id
+WHERE e.exam_datetime >= $1
+  AND e.exam_datetime < $2
+ORDER BY e.exam_datetime, s.last_name, s.first_name
+LIMIT $3
+`
+
+type GetUpcomingExamsParams struct {
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+	Limit         int32     `json:"limit"`
+}
+
+type GetUpcomingExamsRow struct {
+	ExamID       int64           `json:"exam_id"`
+	Name         string          `json:"name"`
+	ExamDatetime time.Time       `json:"exam_datetime"`
+	TargetGrade  sql.NullFloat64 `json:"target_grade"`
+	StudentID    int64           `json:"student_id"`
+	FirstName    string          `json:"first_name"`
+	LastName     string          `json:"last_name"`
+	SubjectName  string          `json:"subject_name"`
+}
+
+func (q *Queries) GetUpcomingExams(ctx context.Context, arg GetUpcomingExamsParams) ([]GetUpcomingExamsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUpcomingExams, arg.StartDatetime, arg.EndDatetime, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUpcomingExamsRow{}
+	for rows.Next() {
+		var i GetUpcomingExamsRow
+		if err := rows.Scan(
+			&i.ExamID,
+			&i.Name,
+			&i.ExamDatetime,
+			&i.TargetGrade,
+			&i.StudentID,
+			&i.FirstName,
+			&i.LastName,
+			&i.SubjectName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUpcomingUnpaidLessons = `-- name: GetUpcomingUnpaidLessons :many
+WITH balances AS (
+  SELECT i.student_id,
+         SUM(i.amount) - COALESCE((SELECT SUM(r.amount) FROM receipts r WHERE r.student_id = i.student_id), 0) AS balance
+  FROM invoices i
+  GROUP BY i.student_id
+)
+SELECT l.lesson_id,
+       l.lesson_datetime,
+       i.invoice_id,
+       i.amount,
+       s.student_id,
+       s.first_name,
+       s.last_name,
+       b.balance::float AS balance
+FROM lessons l
+JOIN invoices i ON i.lesson_id = l.lesson_id
+JOIN students s ON s.student_id = i.student_id
+JOIN balances b ON b.student_id = i.student_id
+WHERE l.lesson_datetime >= $1
+  AND b.balance > 0
+ORDER BY l.lesson_datetime, s.last_name, s.first_name
+LIMIT $2
+`
+
+type GetUpcomingUnpaidLessonsParams struct {
+	StartDatetime time.Time `json:"start_datetime"`
+	Limit         int32     `json:"limit"`
+}
+
+type GetUpcomingUnpaidLessonsRow struct {
+	LessonID       int64     `json:"lesson_id"`
+	LessonDatetime time.Time `json:"lesson_datetime"`
+	InvoiceID      int64     `json:"invoice_id"`
+	Amount         float64   `json:"amount"`
+	StudentID      int64     `json:"student_id"`
+	FirstName      string    `json:"first_name"`
+	LastName       string    `json:"last_name"`
+	Balance        float64   `json:"balance"`
+}
+
+func (q *Queries) GetUpcomingUnpaidLessons(ctx context.Context, arg GetUpcomingUnpaidLessonsParams) ([]GetUpcomingUnpaidLessonsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUpcomingUnpaidLessons, arg.StartDatetime, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUpcomingUnpaidLessonsRow{}
+	for rows.Next() {
+		var i GetUpcomingUnpaidLessonsRow
+		if err := rows.Scan(
+			&i.LessonID,
+			&i.LessonDatetime,
+			&i.InvoiceID,
+			&i.Amount,
+			&i.StudentID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDashboardLessons(t *testing.T) {
+	day := randomReportDay()
+	lesson, invoice, _ := createRandomReportData(t, day)
+
+	// the invoiced student and a free attendee are counted, and only the invoice is in the invoiced amount
+	for _, studentID := range []int64{invoice.StudentID, createRandomStudent(t).StudentID} {
+		_, err := testQueries.CreateLessonAttendance(context.Background(), CreateLessonAttendanceParams{
+			LessonID:  lesson.LessonID,
+			StudentID: studentID,
+			Status:    AttendanceStatusAttended,
+		})
+		require.NoError(t, err)
+	}
+
+	lessons, err := testQueries.GetDashboardLessons(context.Background(), GetDashboardLessonsParams{
+		StartDatetime: day,
+		EndDatetime:   day.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, lessons)
+
+	found := false
+	for _, row := range lessons {
+		require.False(t, row.LessonDatetime.Before(day))
+		require.True(t, row.LessonDatetime.Before(day.AddDate(0, 0, 1)))
+
+		if row.LessonID == lesson.LessonID {
+			found = true
+			require.Equal(t, int64(2), row.StudentsCount)
+			require.Equal(t, invoice.Amount, row.InvoicedAmount)
+			require.NotEmpty(t, row.LocationName)
+			require.NotEmpty(t, row.SubjectName)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestGetOutstandingBalance(t *testing.T) {
+	balance1, err := testQueries.GetOutstandingBalance(context.Background())
+	require.NoError(t, err)
+
+	// a student with an invoiced lesson and no receipts adds to the outstanding balance
+	createRandomStudentLessons(t, time.Now().UTC().Add(-time.Hour))
+
+	balance2, err := testQueries.GetOutstandingBalance(context.Background())
+	require.NoError(t, err)
+	require.Greater(t, balance2.OutstandingAmount, balance1.OutstandingAmount)
+	require.Equal(t, balance1.StudentsCount+1, balance2.StudentsCount)
+}
+
+func TestGetInvoicedAmount(t *testing.T) {
+	day := randomReportDay()
+	_, invoice, _ := createRandomReportData(t, day)
+
+	amount, err := testQueries.GetInvoicedAmount(context.Background(), GetInvoicedAmountParams{
+		StartDatetime: day,
+		EndDatetime:   day.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, amount, invoice.Amount)
+}
+
+func TestCountNewStudents(t *testing.T) {
+	start := time.Now().UTC().Add(-time.Minute)
+	createRandomStudent(t)
+
+	count, err := testQueries.CountNewStudents(context.Background(), CountNewStudentsParams{
+		StartDatetime: start,
+		EndDatetime:   time.Now().UTC().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(1))
+}
+
+func TestGetUpcomingUnpaidLessons(t *testing.T) {
+	now := time.Now().UTC()
+	student := createRandomStudentLessons(t, now.Add(time.Hour))
+
+	lessons, err := testQueries.GetUpcomingUnpaidLessons(context.Background(), GetUpcomingUnpaidLessonsParams{
+		StartDatetime: now,
+		Limit:         1000,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, lessons)
+
+	found := false
+	for _, row := range lessons {
+		require.False(t, row.LessonDatetime.Before(now))
+		require.Greater(t, row.Balance, 0.0)
+
+		if row.StudentID == student.StudentID {
+			found = true
+			require.Equal(t, row.Amount, row.Balance)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestGetUpcomingExams(t *testing.T) {
+	now := time.Now().UTC()
+	student := createRandomStudent(t)
+	exam := createRandomExam(t, student, createRandomLessonSubject(t), now.Add(48*time.Hour))
+	createRandomExam(t, student, createRandomLessonSubject(t), now.Add(-48*time.Hour))
+
+	exams, err := testQueries.GetUpcomingExams(context.Background(), GetUpcomingExamsParams{
+		StartDatetime: now,
+		EndDatetime:   now.AddDate(0, 0, 30),
+		Limit:         1000,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, exams)
+
+	found := false
+	for _, row := range exams {
+		require.False(t, row.ExamDatetime.Before(now))
+
+		if row.StudentID == student.StudentID {
+			require.False(t, found)
+			found = true
+			require.Equal(t, exam.ExamID, row.ExamID)
+			require.Equal(t, student.FirstName, row.FirstName)
+		}
+	}
+	require.True(t, found)
+}
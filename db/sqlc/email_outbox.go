@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// Email templates of the emails sent to students and guardians, and of the task digest sent to the tutor.
+const (
+	EmailTemplateLessonReminder              = "lesson_reminder"
+	EmailTemplateInvoiceCreated              = "invoice_created"
+	EmailTemplateReceiptConfirmation         = "receipt_confirmation"
+	EmailTemplateMonthlyStatement            = "monthly_statement"
+	EmailTemplateGuardianReceiptConfirmation = "guardian_receipt_confirmation"
+	EmailTemplateProgressReport              = "progress_report"
+	EmailTemplateWaitlistSlotAvailable       = "waitlist_slot_available"
+	EmailTemplateTaskDigest                  = "task_digest"
+)
+
+// Email outbox statuses.
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSent    = "sent"
+	EmailStatusFailed  = "failed"
+)
+
+// EmailPayload is the data an outbox email template is rendered with.
+// Guardian is set if the email is sent to the billing contact of the student, or to a guardian paying for several students.
+type EmailPayload struct {
+	Student  Student   `json:"student"`
+	Guardian *Guardian `json:"guardian,omitempty"`
+	Lesson   *Lesson   `json:"lesson,omitempty"`
+	Invoice  *Invoice  `json:"invoice,omitempty"`
+	Receipt  *Receipt  `json:"receipt,omitempty"`
+	Payments []Payment `json:"payments,omitempty"`
+
+	Statement *Statement `json:"statement,omitempty"`
+	Invoices  []Invoice  `json:"invoices,omitempty"`
+	Receipts  []Receipt  `json:"receipts,omitempty"`
+
+	GuardianReceipt *GuardianReceipt `json:"guardian_receipt,omitempty"`
+	Students        []Student        `json:"students,omitempty"`
+
+	ProgressReport *ProgressReportDocument `json:"progress_report,omitempty"`
+
+	// WaitlistEntry is the waitlist entry of the student offered a freed slot.
+	WaitlistEntry *WaitlistEntry `json:"waitlist_entry,omitempty"`
+	// FreedLesson is the deleted lesson whose slot is offered to a waitlisted student.
+	// It isn't set as the Lesson, as the pending emails of a lesson are removed with the lesson.
+	FreedLesson *Lesson `json:"freed_lesson,omitempty"`
+
+	// TaskDigest is set on the task digest emails of the tutor, which have no student.
+	TaskDigest *TaskDigest `json:"task_digest,omitempty"`
+}
+
+// enqueueStudentEmail adds an email to the outbox, to be sent to the student at sendAt.
+// If the student has a billing contact with an email address, the email is sent to the billing contact instead.
+// Students without an email address are skipped.
+// It must be called within the transaction that changes the data the email is about,
+// so the email is never sent if the transaction is rolled back.
+func (q *Queries) enqueueStudentEmail(ctx context.Context, template string, sendAt time.Time, payload EmailPayload) error {
+	var err error
+	if payload.Guardian == nil {
+		payload.Guardian, err = q.getBillingContact(ctx, payload.Student.StudentID)
+		if err != nil {
+			return err
+		}
+	}
+
+	recipient := payload.Student.Email
+	if payload.Guardian != nil && payload.Guardian.Email.Valid && payload.Guardian.Email.String != "" {
+		recipient = payload.Guardian.Email
+	} else {
+		payload.Guardian = nil
+	}
+
+	if !recipient.Valid || recipient.String == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	var lessonID sql.NullInt64
+	if payload.Lesson != nil {
+		lessonID = sql.NullInt64{Int64: payload.Lesson.LessonID, Valid: true}
+	}
+
+	_, err = q.CreateOutboxEmail(ctx, CreateOutboxEmailParams{
+		Template:  template,
+		Recipient: recipient.String,
+		Payload:   data,
+		StudentID: sql.NullInt64{Int64: payload.Student.StudentID, Valid: true},
+		LessonID:  lessonID,
+		SendAt:    sendAt,
+	})
+	return err
+}
+
+// enqueueInvoiceEmail adds the invoice email of a student for a lesson to the outbox.
+func (q *Queries) enqueueInvoiceEmail(ctx context.Context, lesson Lesson, invoice Invoice) error {
+	student, err := q.GetStudent(ctx, invoice.StudentID)
+	if err != nil {
+		return err
+	}
+
+	payload := EmailPayload{
+		Student: student,
+		Lesson:  &lesson,
+		Invoice: &invoice,
+	}
+
+	return q.enqueueStudentEmail(ctx, EmailTemplateInvoiceCreated, time.Now().UTC(), payload)
+}
+
+// enqueueReceiptEmail adds the receipt confirmation email of a student to the outbox.
+func (q *Queries) enqueueReceiptEmail(ctx context.Context, receipt Receipt, payments []Payment) error {
+	student, err := q.GetStudent(ctx, receipt.StudentID)
+	if err != nil {
+		return err
+	}
+
+	payload := EmailPayload{
+		Student:  student,
+		Receipt:  &receipt,
+		Payments: payments,
+	}
+
+	return q.enqueueStudentEmail(ctx, EmailTemplateReceiptConfirmation, time.Now().UTC(), payload)
+}
@@ -0,0 +1,287 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: email_outbox.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+const claimOutboxEmails = `-- name: ClaimOutboxEmails :many
+UPDATE email_outbox
+  set   send_at = $1
+WHERE email_id IN (
+  SELECT e.email_id FROM email_outbox e
+  WHERE e.status = 'pending' AND e.send_at <= $2
+  ORDER BY e.send_at
+  LIMIT $3
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING email_id, template, recipient, payload, student_id, lesson_id, status, attempts, last_error, send_at, sent_at, created_at
+`
+
+type ClaimOutboxEmailsParams struct {
+	LeaseUntil time.Time `json:"lease_until"`
+	Now        time.Time `json:"now"`
+	Limit      int32     `json:"limit"`
+}
+
+// ClaimOutboxEmails locks pending emails that are due, and postpones them by the lease,
+// so other dispatchers don't send them while they are being sent.
+func (q *Queries) ClaimOutboxEmails(ctx context.Context, arg ClaimOutboxEmailsParams) ([]EmailOutbox, error) {
+	rows, err := q.db.QueryContext(ctx, claimOutboxEmails, arg.LeaseUntil, arg.Now, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EmailOutbox{}
+	for rows.Next() {
+		var i EmailOutbox
+		if err := rows.Scan(
+			&i.EmailID,
+			&i.Template,
+			&i.Recipient,
+			&i.Payload,
+			&i.StudentID,
+			&i.LessonID,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.SendAt,
+			&i.SentAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createOutboxEmail = `-- name: CreateOutboxEmail :one
+INSERT INTO email_outbox (
+  template, recipient, payload, student_id, lesson_id, send_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING email_id, template, recipient, payload, student_id, lesson_id, status, attempts, last_error, send_at, sent_at, created_at
+`
+
+type CreateOutboxEmailParams struct {
+	Template  string          `json:"template"`
+	Recipient string          `json:"recipient"`
+	Payload   json.RawMessage `json:"payload"`
+	StudentID sql.NullInt64   `json:"student_id"`
+	LessonID  sql.NullInt64   `json:"lesson_id"`
+	SendAt    time.Time       `json:"send_at"`
+}
+
+func (q *Queries) CreateOutboxEmail(ctx context.Context, arg CreateOutboxEmailParams) (EmailOutbox, error) {
+	row := q.db.QueryRowContext(ctx, createOutboxEmail,
+		arg.Template,
+		arg.Recipient,
+		arg.Payload,
+		arg.StudentID,
+		arg.LessonID,
+		arg.SendAt,
+	)
+	var i EmailOutbox
+	err := row.Scan(
+		&i.EmailID,
+		&i.Template,
+		&i.Recipient,
+		&i.Payload,
+		&i.StudentID,
+		&i.LessonID,
+		&i.Status,
+		&i.Attempts,
+		&i.LastError,
+		&i.SendAt,
+		&i.SentAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deletePendingOutboxEmailsByLesson = `-- name: DeletePendingOutboxEmailsByLesson :exec
+DELETE FROM email_outbox
+WHERE lesson_id = $1 AND status = 'pending'
+`
+
+func (q *Queries) DeletePendingOutboxEmailsByLesson(ctx context.Context, lessonID sql.NullInt64) error {
+	_, err := q.db.ExecContext(ctx, deletePendingOutboxEmailsByLesson, lessonID)
+	return err
+}
+
+const getOutboxEmail = `-- name: GetOutboxEmail :one
+SELECT email_id, template, recipient, payload, student_id, lesson_id, status, attempts, last_error, send_at, sent_at, created_at FROM email_outbox
+WHERE email_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetOutboxEmail(ctx context.Context, emailID int64) (EmailOutbox, error) {
+	row := q.db.QueryRowContext(ctx, getOutboxEmail, emailID)
+	var i EmailOutbox
+	err := row.Scan(
+		&i.EmailID,
+		&i.Template,
+		&i.Recipient,
+		&i.Payload,
+		&i.StudentID,
+		&i.LessonID,
+		&i.Status,
+		&i.Attempts,
+		&i.LastError,
+		&i.SendAt,
+		&i.SentAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getOutboxEmailsByStudent = `-- name: GetOutboxEmailsByStudent :many
+SELECT email_id, template, recipient, payload, student_id, lesson_id, status, attempts, last_error, send_at, sent_at, created_at FROM email_outbox
+WHERE student_id = $1::bigint
+ORDER BY email_id
+`
+
+func (q *Queries) GetOutboxEmailsByStudent(ctx context.Context, studentID int64) ([]EmailOutbox, error) {
+	rows, err := q.db.QueryContext(ctx, getOutboxEmailsByStudent, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EmailOutbox{}
+	for rows.Next() {
+		var i EmailOutbox
+		if err := rows.Scan(
+			&i.EmailID,
+			&i.Template,
+			&i.Recipient,
+			&i.Payload,
+			&i.StudentID,
+			&i.LessonID,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.SendAt,
+			&i.SentAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOutboxEmails = `-- name: ListOutboxEmails :many
+SELECT email_id, template, recipient, payload, student_id, lesson_id, status, attempts, last_error, send_at, sent_at, created_at FROM email_outbox
+WHERE $1::varchar IS NULL OR status = $1
+ORDER BY email_id DESC
+LIMIT $3
+OFFSET $2
+`
+
+type ListOutboxEmailsParams struct {
+	Status sql.NullString `json:"status"`
+	Offset int32          `json:"offset"`
+	Limit  int32          `json:"limit"`
+}
+
+func (q *Queries) ListOutboxEmails(ctx context.Context, arg ListOutboxEmailsParams) ([]EmailOutbox, error) {
+	rows, err := q.db.QueryContext(ctx, listOutboxEmails, arg.Status, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EmailOutbox{}
+	for rows.Next() {
+		var i EmailOutbox
+		if err := rows.Scan(
+			&i.EmailID,
+			&i.Template,
+			&i.Recipient,
+			&i.Payload,
+			&i.StudentID,
+			&i.LessonID,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.SendAt,
+			&i.SentAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxEmailFailed = `-- name: MarkOutboxEmailFailed :exec
+UPDATE email_outbox
+  set   status = $2,
+        attempts = attempts + 1,
+        last_error = $3,
+        send_at = $4
+WHERE email_id = $1
+`
+
+type MarkOutboxEmailFailedParams struct {
+	EmailID   int64          `json:"email_id"`
+	Status    string         `json:"status"`
+	LastError sql.NullString `json:"last_error"`
+	SendAt    time.Time      `json:"send_at"`
+}
+
+func (q *Queries) MarkOutboxEmailFailed(ctx context.Context, arg MarkOutboxEmailFailedParams) error {
+	_, err := q.db.ExecContext(ctx, markOutboxEmailFailed,
+		arg.EmailID,
+		arg.Status,
+		arg.LastError,
+		arg.SendAt,
+	)
+	return err
+}
+
+const markOutboxEmailSent = `-- name: MarkOutboxEmailSent :exec
+UPDATE email_outbox
+  set   status = 'sent',
+        attempts = attempts + 1,
+        last_error = NULL,
+        sent_at = $2
+WHERE email_id = $1
+`
+
+type MarkOutboxEmailSentParams struct {
+	EmailID int64        `json:"email_id"`
+	SentAt  sql.NullTime `json:"sent_at"`
+}
+
+func (q *Queries) MarkOutboxEmailSent(ctx context.Context, arg MarkOutboxEmailSentParams) error {
+	_, err := q.db.ExecContext(ctx, markOutboxEmailSent, arg.EmailID, arg.SentAt)
+	return err
+}
@@ -0,0 +1,219 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomLessonWithInvoiceTx adds a lesson held at lessonDatetime, with an invoice for each of the students.
+func createRandomLessonWithInvoiceTx(t *testing.T, lessonDatetime time.Time, students ...Student) (LessonWithInvoices, error) {
+	store := NewStore(testDB)
+	location := createRandomLessonLocation(t)
+	subject := createRandomLessonSubject(t)
+
+	arg := CreateLessonTxParams{
+		LessonDatetime: lessonDatetime,
+		Duration:       util.RandomLessonDuration(),
+		LocationID:     location.LocationID,
+		SubjectID:      subject.SubjectID,
+	}
+
+	for _, student := range students {
+		arg.LessonInvoicesParams = append(arg.LessonInvoicesParams, CreateLessonTxInvoiceParams{
+			StudentID: student.StudentID,
+			HourlyFee: student.HourlyFee.Float64,
+			Duration:  arg.Duration,
+			Amount:    student.HourlyFee.Float64 * float64(arg.Duration) / 60.0,
+		})
+	}
+
+	return store.CreateLessonWithInvoicesTx(context.Background(), arg)
+}
+
+// createRandomOutboxEmail adds a new random pending email to the outbox, to be sent at sendAt.
+func createRandomOutboxEmail(t *testing.T, sendAt time.Time) EmailOutbox {
+	student := createRandomStudent(t)
+
+	data, err := json.Marshal(EmailPayload{Student: student})
+	require.NoError(t, err)
+
+	arg := CreateOutboxEmailParams{
+		Template:  EmailTemplateReceiptConfirmation,
+		Recipient: student.Email.String,
+		Payload:   data,
+		StudentID: sql.NullInt64{Int64: student.StudentID, Valid: true},
+		SendAt:    sendAt,
+	}
+
+	email, err := testQueries.CreateOutboxEmail(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, email.EmailID)
+
+	require.Equal(t, arg.Template, email.Template)
+	require.Equal(t, arg.Recipient, email.Recipient)
+	require.JSONEq(t, string(arg.Payload), string(email.Payload))
+	require.Equal(t, arg.StudentID, email.StudentID)
+	require.False(t, email.LessonID.Valid)
+	require.Equal(t, EmailStatusPending, email.Status)
+	require.Zero(t, email.Attempts)
+	require.WithinDuration(t, arg.SendAt, email.SendAt, time.Second)
+	require.False(t, email.SentAt.Valid)
+	require.NotZero(t, email.CreatedAt)
+
+	return email
+}
+
+func TestCreateOutboxEmail(t *testing.T) {
+	createRandomOutboxEmail(t, time.Now().UTC())
+}
+
+func TestClaimOutboxEmails(t *testing.T) {
+	now := time.Now().UTC()
+	due := createRandomOutboxEmail(t, now.Add(-time.Minute))
+	notDue := createRandomOutboxEmail(t, now.Add(time.Hour))
+
+	arg := ClaimOutboxEmailsParams{
+		LeaseUntil: now.Add(5 * time.Minute),
+		Now:        now,
+		Limit:      1000,
+	}
+
+	emails, err := testQueries.ClaimOutboxEmails(context.Background(), arg)
+	require.NoError(t, err)
+
+	claimed := make(map[int64]EmailOutbox)
+	for _, email := range emails {
+		require.WithinDuration(t, arg.LeaseUntil, email.SendAt, time.Second)
+		claimed[email.EmailID] = email
+	}
+	require.Contains(t, claimed, due.EmailID)
+	require.NotContains(t, claimed, notDue.EmailID)
+
+	// claimed emails aren't claimed again until the lease ends
+	emails, err = testQueries.ClaimOutboxEmails(context.Background(), arg)
+	require.NoError(t, err)
+	for _, email := range emails {
+		require.NotEqual(t, due.EmailID, email.EmailID)
+	}
+}
+
+func TestMarkOutboxEmailSent(t *testing.T) {
+	email1 := createRandomOutboxEmail(t, time.Now().UTC())
+
+	arg := MarkOutboxEmailSentParams{
+		EmailID: email1.EmailID,
+		SentAt:  sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	}
+	require.NoError(t, testQueries.MarkOutboxEmailSent(context.Background(), arg))
+
+	email2, err := testQueries.GetOutboxEmail(context.Background(), email1.EmailID)
+	require.NoError(t, err)
+	require.Equal(t, EmailStatusSent, email2.Status)
+	require.Equal(t, int32(1), email2.Attempts)
+	require.WithinDuration(t, arg.SentAt.Time, email2.SentAt.Time, time.Second)
+}
+
+func TestMarkOutboxEmailFailed(t *testing.T) {
+	email1 := createRandomOutboxEmail(t, time.Now().UTC())
+
+	arg := MarkOutboxEmailFailedParams{
+		EmailID:   email1.EmailID,
+		Status:    EmailStatusPending,
+		LastError: sql.NullString{String: util.RandomNote(), Valid: true},
+		SendAt:    time.Now().UTC().Add(time.Minute),
+	}
+	require.NoError(t, testQueries.MarkOutboxEmailFailed(context.Background(), arg))
+
+	email2, err := testQueries.GetOutboxEmail(context.Background(), email1.EmailID)
+	require.NoError(t, err)
+	require.Equal(t, EmailStatusPending, email2.Status)
+	require.Equal(t, int32(1), email2.Attempts)
+	require.Equal(t, arg.LastError, email2.LastError)
+	require.WithinDuration(t, arg.SendAt, email2.SendAt, time.Second)
+}
+
+func TestListOutboxEmails(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		createRandomOutboxEmail(t, time.Now().UTC())
+	}
+
+	arg := ListOutboxEmailsParams{
+		Status: sql.NullString{String: EmailStatusPending, Valid: true},
+		Limit:  5,
+		Offset: 0,
+	}
+
+	emails, err := testQueries.ListOutboxEmails(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, emails, 5)
+
+	for _, email := range emails {
+		require.Equal(t, EmailStatusPending, email.Status)
+	}
+}
+
+func TestCreateLessonWithInvoicesTxEmails(t *testing.T) {
+	student := createRandomStudent(t)
+	lessonDatetime := time.Now().UTC().Add(48 * time.Hour)
+
+	result, err := createRandomLessonWithInvoiceTx(t, lessonDatetime, student)
+	require.NoError(t, err)
+
+	emails, err := testQueries.GetOutboxEmailsByStudent(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Len(t, emails, 1)
+
+	email := emails[0]
+	require.Equal(t, EmailTemplateInvoiceCreated, email.Template)
+	require.WithinDuration(t, time.Now(), email.SendAt, time.Minute)
+	require.Equal(t, student.Email.String, email.Recipient)
+	require.Equal(t, sql.NullInt64{Int64: result.Lesson.LessonID, Valid: true}, email.LessonID)
+
+	var payload EmailPayload
+	require.NoError(t, json.Unmarshal(email.Payload, &payload))
+	require.Equal(t, student.StudentID, payload.Student.StudentID)
+	require.Equal(t, result.Lesson.LessonID, payload.Lesson.LessonID)
+	require.Equal(t, result.Invoices[0].InvoiceID, payload.Invoice.InvoiceID)
+
+	// pending emails of a deleted lesson are removed from the outbox
+	store := NewStore(testDB)
+	require.NoError(t, store.DeleteLessonWithInvoicesTx(context.Background(), result.Lesson.LessonID))
+
+	emails, err = testQueries.GetOutboxEmailsByStudent(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Empty(t, emails)
+}
+
+func TestCreateLessonWithInvoicesTxRollbackEmails(t *testing.T) {
+	student := createRandomStudent(t)
+	missingStudent := Student{StudentID: -1}
+
+	_, err := createRandomLessonWithInvoiceTx(t, time.Now().UTC().Add(48*time.Hour), student, missingStudent)
+	require.Error(t, err)
+
+	// the emails of a rolled back transaction are never sent
+	emails, err := testQueries.GetOutboxEmailsByStudent(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Empty(t, emails)
+}
+
+func TestCreateReceiptWithPaymentsTxEmails(t *testing.T) {
+	result := createRandomReceiptWithPaymentsTx(t, 2)
+
+	emails, err := testQueries.GetOutboxEmailsByStudent(context.Background(), result.Receipt.StudentID)
+	require.NoError(t, err)
+	require.Len(t, emails, 1)
+	require.Equal(t, EmailTemplateReceiptConfirmation, emails[0].Template)
+
+	var payload EmailPayload
+	require.NoError(t, json.Unmarshal(emails[0].Payload, &payload))
+	require.Equal(t, result.Receipt.ReceiptID, payload.Receipt.ReceiptID)
+	require.Equal(t, result.Receipt.Amount, payload.Receipt.Amount)
+	require.Len(t, payload.Payments, 2)
+}
@@ -0,0 +1,196 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: exam.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createExam = `-- name: CreateExam :one
+INSERT INTO exams (
+  student_id, subject_id, college_id, name, exam_datetime, target_grade, achieved_grade, notes
+) VALUES (
+  $1, $2,
+  COALESCE($3::bigint, (SELECT s.college_id FROM students s WHERE s.student_id = $1)),
+  $4, $5, $6, $7, $8
+)
+RETURNING exam_id, student_id, subject_id, college_id, name, exam_datetime, target_grade, achieved_grade, notes, created_at
+`
+
+type CreateExamParams struct {
+	StudentID     int64           `json:"student_id"`
+	SubjectID     int64           `json:"subject_id"`
+	CollegeID     sql.NullInt64   `json:"college_id"`
+	Name          string          `json:"name"`
+	ExamDatetime  time.Time       `json:"exam_datetime"`
+	TargetGrade   sql.NullFloat64 `json:"target_grade"`
+	AchievedGrade sql.NullFloat64 `json:"achieved_grade"`
+	Notes         sql.NullString  `json:"notes"`
+}
+
+// CreateExam creates an exam of a student. The exam is taken for the college of the student, unless a college is given.
+func (q *Queries) CreateExam(ctx context.Context, arg CreateExamParams) (Exam, error) {
+	row := q.db.QueryRowContext(ctx, createExam,
+		arg.StudentID,
+		arg.SubjectID,
+		arg.CollegeID,
+		arg.Name,
+		arg.ExamDatetime,
+		arg.TargetGrade,
+		arg.AchievedGrade,
+		arg.Notes,
+	)
+	var i Exam
+	err := row.Scan(
+		&i.ExamID,
+		&i.StudentID,
+		&i.SubjectID,
+		&i.CollegeID,
+		&i.Name,
+		&i.ExamDatetime,
+		&i.TargetGrade,
+		&i.AchievedGrade,
+		&i.Notes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteExam = `-- name: DeleteExam :execrows
+DELETE FROM exams
+WHERE exam_id = $1
+`
+
+func (q *Queries) DeleteExam(ctx context.Context, examID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExam, examID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getExam = `-- name: GetExam :one
+SELECT exam_id, student_id, subject_id, college_id, name, exam_datetime, target_grade, achieved_grade, notes, created_at FROM exams
+WHERE exam_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetExam(ctx context.Context, examID int64) (Exam, error) {
+	row := q.db.QueryRowContext(ctx, getExam, examID)
+	var i Exam
+	err := row.Scan(
+		&i.ExamID,
+		&i.StudentID,
+		&i.SubjectID,
+		&i.CollegeID,
+		&i.Name,
+		&i.ExamDatetime,
+		&i.TargetGrade,
+		&i.AchievedGrade,
+		&i.Notes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listExams = `-- name: ListExams :many
+SELECT exam_id, student_id, subject_id, college_id, name, exam_datetime, target_grade, achieved_grade, notes, created_at FROM exams
+WHERE ($1::bigint IS NULL OR student_id = $1)
+  AND ($2::bigint IS NULL OR subject_id = $2)
+  AND ($3::timestamptz IS NULL OR exam_datetime >= $3)
+  AND ($4::timestamptz IS NULL OR exam_datetime < $4)
+ORDER BY exam_datetime, exam_id
+LIMIT $6
+OFFSET $5
+`
+
+type ListExamsParams struct {
+	StudentID     sql.NullInt64 `json:"student_id"`
+	SubjectID     sql.NullInt64 `json:"subject_id"`
+	StartDatetime sql.NullTime  `json:"start_datetime"`
+	EndDatetime   sql.NullTime  `json:"end_datetime"`
+	Offset        int32         `json:"offset"`
+	Limit         int32         `json:"limit"`
+}
+
+func (q *Queries) ListExams(ctx context.Context, arg ListExamsParams) ([]Exam, error) {
+	rows, err := q.db.QueryContext(ctx, listExams,
+		arg.StudentID,
+		arg.SubjectID,
+		arg.StartDatetime,
+		arg.EndDatetime,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Exam{}
+	for rows.Next() {
+		var i Exam
+		if err := rows.Scan(
+			&i.ExamID,
+			&i.StudentID,
+			&i.SubjectID,
+			&i.CollegeID,
+			&i.Name,
+			&i.ExamDatetime,
+			&i.TargetGrade,
+			&i.AchievedGrade,
+			&i.Notes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateExam = `-- name: UpdateExam :exec
+UPDATE exams
+  set   subject_id = $2,
+        college_id = $3,
+        name = $4,
+        exam_datetime = $5,
+        target_grade = $6,
+        achieved_grade = $7,
+        notes = $8
+WHERE exam_id = $1
+`
+
+type UpdateExamParams struct {
+	ExamID        int64           `json:"exam_id"`
+	SubjectID     int64           `json:"subject_id"`
+	CollegeID     sql.NullInt64   `json:"college_id"`
+	Name          string          `json:"name"`
+	ExamDatetime  time.Time       `json:"exam_datetime"`
+	TargetGrade   sql.NullFloat64 `json:"target_grade"`
+	AchievedGrade sql.NullFloat64 `json:"achieved_grade"`
+	Notes         sql.NullString  `json:"notes"`
+}
+
+func (q *Queries) UpdateExam(ctx context.Context, arg UpdateExamParams) error {
+	_, err := q.db.ExecContext(ctx, updateExam,
+		arg.ExamID,
+		arg.SubjectID,
+		arg.CollegeID,
+		arg.Name,
+		arg.ExamDatetime,
+		arg.TargetGrade,
+		arg.AchievedGrade,
+		arg.Notes,
+	)
+	return err
+}
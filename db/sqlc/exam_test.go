@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomExam adds a new random exam of a student in a subject, taken at datetime.
+// The exam is taken for the college of the student.
+func createRandomExam(t *testing.T, student Student, subject LessonSubject, datetime time.Time) Exam {
+	arg := CreateExamParams{
+		StudentID:    student.StudentID,
+		SubjectID:    subject.SubjectID,
+		Name:         util.RandomName(),
+		ExamDatetime: datetime,
+		TargetGrade:  sql.NullFloat64{Float64: util.RandomFloat64(60, 100), Valid: true},
+		Notes:        sql.NullString{String: util.RandomNote(), Valid: true},
+	}
+
+	exam, err := testQueries.CreateExam(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, exam.ExamID)
+	require.Equal(t, arg.StudentID, exam.StudentID)
+	require.Equal(t, arg.SubjectID, exam.SubjectID)
+	require.Equal(t, student.CollegeID, exam.CollegeID)
+	require.Equal(t, arg.Name, exam.Name)
+	require.WithinDuration(t, arg.ExamDatetime, exam.ExamDatetime, time.Second)
+	require.Equal(t, arg.TargetGrade, exam.TargetGrade)
+	require.False(t, exam.AchievedGrade.Valid)
+	require.Equal(t, arg.Notes, exam.Notes)
+	require.NotZero(t, exam.CreatedAt)
+
+	return exam
+}
+
+func TestCreateExamCollege(t *testing.T) {
+	student := createRandomStudent(t)
+	subject := createRandomLessonSubject(t)
+	college := createRandomCollege(t)
+
+	exam, err := testQueries.CreateExam(context.Background(), CreateExamParams{
+		StudentID:    student.StudentID,
+		SubjectID:    subject.SubjectID,
+		CollegeID:    sql.NullInt64{Int64: college.CollegeID, Valid: true},
+		Name:         util.RandomName(),
+		ExamDatetime: util.RandomDatetime(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, sql.NullInt64{Int64: college.CollegeID, Valid: true}, exam.CollegeID)
+}
+
+func TestUpdateExam(t *testing.T) {
+	student := createRandomStudent(t)
+	subject := createRandomLessonSubject(t)
+	exam1 := createRandomExam(t, student, subject, util.RandomDatetime())
+
+	arg := UpdateExamParams{
+		ExamID:        exam1.ExamID,
+		SubjectID:     exam1.SubjectID,
+		CollegeID:     exam1.CollegeID,
+		Name:          util.RandomName(),
+		ExamDatetime:  exam1.ExamDatetime,
+		TargetGrade:   exam1.TargetGrade,
+		AchievedGrade: sql.NullFloat64{Float64: util.RandomFloat64(60, 100), Valid: true},
+		Notes:         exam1.Notes,
+	}
+	require.NoError(t, testQueries.UpdateExam(context.Background(), arg))
+
+	exam2, err := testQueries.GetExam(context.Background(), exam1.ExamID)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, exam2.Name)
+	require.Equal(t, arg.AchievedGrade, exam2.AchievedGrade)
+}
+
+func TestListExams(t *testing.T) {
+	student := createRandomStudent(t)
+	subject1 := createRandomLessonSubject(t)
+	subject2 := createRandomLessonSubject(t)
+
+	day := randomReportDay()
+	exam1 := createRandomExam(t, student, subject1, day.Add(10*time.Hour))
+	exam2 := createRandomExam(t, student, subject2, day.AddDate(0, 0, 1).Add(10*time.Hour))
+	createRandomExam(t, student, subject1, day.AddDate(0, 0, 10))
+
+	exams, err := testQueries.ListExams(context.Background(), ListExamsParams{
+		StudentID: sql.NullInt64{Int64: student.StudentID, Valid: true},
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, exams, 3)
+	require.Equal(t, exam1.ExamID, exams[0].ExamID)
+	require.Equal(t, exam2.ExamID, exams[1].ExamID)
+
+	exams, err = testQueries.ListExams(context.Background(), ListExamsParams{
+		StudentID:     sql.NullInt64{Int64: student.StudentID, Valid: true},
+		SubjectID:     sql.NullInt64{Int64: subject1.SubjectID, Valid: true},
+		StartDatetime: sql.NullTime{Time: day, Valid: true},
+		EndDatetime:   sql.NullTime{Time: day.AddDate(0, 0, 2), Valid: true},
+		Limit:         5,
+		Offset:        0,
+	})
+	require.NoError(t, err)
+	require.Len(t, exams, 1)
+	require.Equal(t, exam1.ExamID, exams[0].ExamID)
+}
+
+func TestDeleteExam(t *testing.T) {
+	exam1 := createRandomExam(t, createRandomStudent(t), createRandomLessonSubject(t), util.RandomDatetime())
+
+	rows, err := testQueries.DeleteExam(context.Background(), exam1.ExamID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	exam2, err := testQueries.GetExam(context.Background(), exam1.ExamID)
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, exam2)
+
+	rows, err = testQueries.DeleteExam(context.Background(), exam1.ExamID)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
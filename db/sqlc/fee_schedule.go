@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrNoHourlyFee is returned when there is neither a fee schedule nor an hourly fee of the student to price a lesson of a student.
+var ErrNoHourlyFee = errors.New("no hourly fee for the student")
+
+// ResolvedHourlyFee is the hourly fee of a student at a lesson, and the fee schedule it is taken from.
+// FeeScheduleID is null if the hourly fee is taken from the student.
+type ResolvedHourlyFee struct {
+	HourlyFee     float64       `json:"hourly_fee"`
+	FeeScheduleID sql.NullInt64 `json:"fee_schedule_id"`
+}
+
+// ResolveHourlyFeeTx returns the hourly fee of a student in a subject at a lesson datetime,
+// taken from the applicable fee schedule as by GetApplicableFeeSchedule, or else from the hourly fee of the student.
+// It returns sql.ErrNoRows if the student doesn't exist, and ErrNoHourlyFee if the student has no hourly fee.
+func (store *SQLStore) ResolveHourlyFeeTx(ctx context.Context, arg GetApplicableFeeScheduleParams) (ResolvedHourlyFee, error) {
+	var result ResolvedHourlyFee
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.resolveHourlyFee(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// resolveHourlyFee returns the hourly fee of a student in a subject at a lesson datetime, within a transaction.
+func (q *Queries) resolveHourlyFee(ctx context.Context, arg GetApplicableFeeScheduleParams) (ResolvedHourlyFee, error) {
+	schedule, err := q.GetApplicableFeeSchedule(ctx, arg)
+	if err == nil {
+		return ResolvedHourlyFee{
+			HourlyFee:     schedule.HourlyFee,
+			FeeScheduleID: sql.NullInt64{Int64: schedule.FeeScheduleID, Valid: true},
+		}, nil
+	}
+	if err != sql.ErrNoRows {
+		return ResolvedHourlyFee{}, err
+	}
+
+	student, err := q.GetStudent(ctx, arg.StudentID)
+	if err != nil {
+		return ResolvedHourlyFee{}, err
+	}
+
+	if !student.HourlyFee.Valid {
+		return ResolvedHourlyFee{}, ErrNoHourlyFee
+	}
+
+	return ResolvedHourlyFee{HourlyFee: student.HourlyFee.Float64}, nil
+}
@@ -0,0 +1,155 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: fee_schedule.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createFeeSchedule = `-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (
+  student_id, subject_id, hourly_fee, effective_from, notes
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING fee_schedule_id, student_id, subject_id, hourly_fee, effective_from, notes, created_at
+`
+
+type CreateFeeScheduleParams struct {
+	StudentID     sql.NullInt64  `json:"student_id"`
+	SubjectID     sql.NullInt64  `json:"subject_id"`
+	HourlyFee     float64        `json:"hourly_fee"`
+	EffectiveFrom time.Time      `json:"effective_from"`
+	Notes         sql.NullString `json:"notes"`
+}
+
+func (q *Queries) CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error) {
+	row := q.db.QueryRowContext(ctx, createFeeSchedule,
+		arg.StudentID,
+		arg.SubjectID,
+		arg.HourlyFee,
+		arg.EffectiveFrom,
+		arg.Notes,
+	)
+	var i FeeSchedule
+	err := row.Scan(
+		&i.FeeScheduleID,
+		&i.StudentID,
+		&i.SubjectID,
+		&i.HourlyFee,
+		&i.EffectiveFrom,
+		&i.Notes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getApplicableFeeSchedule = `-- name: GetApplicableFeeSchedule :one
+SELECT fee_schedule_id, student_id, subject_id, hourly_fee, effective_from, notes, created_at FROM fee_schedules
+WHERE (student_id IS NULL OR student_id = $1::bigint)
+  AND (subject_id IS NULL OR subject_id = $2::bigint)
+  AND effective_from <= $3
+ORDER BY student_id IS NULL, subject_id IS NULL, effective_from DESC
+LIMIT 1
+`
+
+type GetApplicableFeeScheduleParams struct {
+	StudentID      int64     `json:"student_id"`
+	SubjectID      int64     `json:"subject_id"`
+	LessonDatetime time.Time `json:"lesson_datetime"`
+}
+
+// GetApplicableFeeSchedule gets the fee schedule of a student in a subject at lesson_datetime.
+// A schedule of the student in the subject precedes a schedule of the student, which precedes a schedule of the subject,
+// which precedes a schedule of all lessons. Among schedules of the same kind the latest effective one applies.
+func (q *Queries) GetApplicableFeeSchedule(ctx context.Context, arg GetApplicableFeeScheduleParams) (FeeSchedule, error) {
+	row := q.db.QueryRowContext(ctx, getApplicableFeeSchedule, arg.StudentID, arg.SubjectID, arg.LessonDatetime)
+	var i FeeSchedule
+	err := row.Scan(
+		&i.FeeScheduleID,
+		&i.StudentID,
+		&i.SubjectID,
+		&i.HourlyFee,
+		&i.EffectiveFrom,
+		&i.Notes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getFeeSchedule = `-- name: GetFeeSchedule :one
+SELECT fee_schedule_id, student_id, subject_id, hourly_fee, effective_from, notes, created_at FROM fee_schedules
+WHERE fee_schedule_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetFeeSchedule(ctx context.Context, feeScheduleID int64) (FeeSchedule, error) {
+	row := q.db.QueryRowContext(ctx, getFeeSchedule, feeScheduleID)
+	var i FeeSchedule
+	err := row.Scan(
+		&i.FeeScheduleID,
+		&i.StudentID,
+		&i.SubjectID,
+		&i.HourlyFee,
+		&i.EffectiveFrom,
+		&i.Notes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listFeeSchedules = `-- name: ListFeeSchedules :many
+SELECT fee_schedule_id, student_id, subject_id, hourly_fee, effective_from, notes, created_at FROM fee_schedules
+WHERE ($1::bigint IS NULL OR student_id = $1)
+  AND ($2::bigint IS NULL OR subject_id = $2)
+ORDER BY effective_from DESC, fee_schedule_id DESC
+LIMIT $4
+OFFSET $3
+`
+
+type ListFeeSchedulesParams struct {
+	StudentID sql.NullInt64 `json:"student_id"`
+	SubjectID sql.NullInt64 `json:"subject_id"`
+	Offset    int32         `json:"offset"`
+	Limit     int32         `json:"limit"`
+}
+
+func (q *Queries) ListFeeSchedules(ctx context.Context, arg ListFeeSchedulesParams) ([]FeeSchedule, error) {
+	rows, err := q.db.QueryContext(ctx, listFeeSchedules,
+		arg.StudentID,
+		arg.SubjectID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FeeSchedule{}
+	for rows.Next() {
+		var i FeeSchedule
+		if err := rows.Scan(
+			&i.FeeScheduleID,
+			&i.StudentID,
+			&i.SubjectID,
+			&i.HourlyFee,
+			&i.EffectiveFrom,
+			&i.Notes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
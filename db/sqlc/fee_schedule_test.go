@@ -0,0 +1,183 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomFeeSchedule adds a new fee schedule of a student and a subject, effective from effectiveFrom.
+// A zero studentID or subjectID makes the schedule apply to all students or subjects.
+func createRandomFeeSchedule(t *testing.T, studentID, subjectID int64, hourlyFee float64, effectiveFrom time.Time) FeeSchedule {
+	arg := CreateFeeScheduleParams{
+		StudentID:     sql.NullInt64{Int64: studentID, Valid: studentID != 0},
+		SubjectID:     sql.NullInt64{Int64: subjectID, Valid: subjectID != 0},
+		HourlyFee:     hourlyFee,
+		EffectiveFrom: effectiveFrom,
+	}
+
+	schedule, err := testQueries.CreateFeeSchedule(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, schedule.FeeScheduleID)
+	require.Equal(t, arg.StudentID, schedule.StudentID)
+	require.Equal(t, arg.SubjectID, schedule.SubjectID)
+	require.Equal(t, arg.HourlyFee, schedule.HourlyFee)
+	require.WithinDuration(t, arg.EffectiveFrom, schedule.EffectiveFrom, time.Second)
+
+	return schedule
+}
+
+func TestResolveHourlyFeeTx(t *testing.T) {
+	store := NewStore(testDB)
+	student := createRandomStudent(t)
+	subject := createRandomLessonSubject(t)
+	start := time.Date(2034, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	arg := GetApplicableFeeScheduleParams{
+		StudentID:      student.StudentID,
+		SubjectID:      subject.SubjectID,
+		LessonDatetime: start.AddDate(0, 6, 0),
+	}
+
+	// without schedules the hourly fee of the student applies
+	fee, err := store.ResolveHourlyFeeTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, student.HourlyFee.Float64, fee.HourlyFee)
+	require.False(t, fee.FeeScheduleID.Valid)
+
+	subjectSchedule := createRandomFeeSchedule(t, 0, subject.SubjectID, 150, start)
+	fee, err = store.ResolveHourlyFeeTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, subjectSchedule.FeeScheduleID, fee.FeeScheduleID.Int64)
+
+	// a schedule of the student precedes a schedule of the subject
+	studentSchedule := createRandomFeeSchedule(t, student.StudentID, 0, 120, start)
+	fee, err = store.ResolveHourlyFeeTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, studentSchedule.FeeScheduleID, fee.FeeScheduleID.Int64)
+	require.Equal(t, 120.0, fee.HourlyFee)
+
+	// a raise applies to lessons from its effective time only
+	raise := createRandomFeeSchedule(t, student.StudentID, 0, 140, start.AddDate(0, 3, 0))
+	fee, err = store.ResolveHourlyFeeTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, raise.FeeScheduleID, fee.FeeScheduleID.Int64)
+
+	arg.LessonDatetime = start.AddDate(0, 1, 0)
+	fee, err = store.ResolveHourlyFeeTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, studentSchedule.FeeScheduleID, fee.FeeScheduleID.Int64)
+
+	// a schedule of the student in the subject precedes all others
+	studentSubjectSchedule := createRandomFeeSchedule(t, student.StudentID, subject.SubjectID, 100, start)
+	fee, err = store.ResolveHourlyFeeTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, studentSubjectSchedule.FeeScheduleID, fee.FeeScheduleID.Int64)
+
+	arg.StudentID = 0
+	_, err = store.ResolveHourlyFeeTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestAddLessonStudentTxFeeSchedule(t *testing.T) {
+	store := NewStore(testDB)
+	lesson1 := createRandomLessonWithInvoicesTx(t, 1)
+	student := createRandomStudent(t)
+	schedule := createRandomFeeSchedule(t, student.StudentID, lesson1.Lesson.SubjectID, 175, lesson1.Lesson.LessonDatetime.AddDate(0, 0, -1))
+
+	_, err := store.AddLessonStudentTx(context.Background(), AddLessonStudentTxParams{
+		LessonID:  lesson1.Lesson.LessonID,
+		StudentID: student.StudentID,
+	})
+	require.NoError(t, err)
+
+	// the invoice records the fee schedule it was priced by
+	invoice, err := testQueries.GetInvoiceByLessonStudent(context.Background(), GetInvoiceByLessonStudentParams{
+		LessonID:  lesson1.Lesson.LessonID,
+		StudentID: student.StudentID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 175.0, invoice.HourlyFee)
+	require.Equal(t, sql.NullInt64{Int64: schedule.FeeScheduleID, Valid: true}, invoice.FeeScheduleID)
+}
+
+func TestListFeeSchedules(t *testing.T) {
+	student := createRandomStudent(t)
+	start := time.Date(2035, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		createRandomFeeSchedule(t, student.StudentID, 0, float64(100+i*10), start.AddDate(0, i, 0))
+	}
+
+	schedules, err := testQueries.ListFeeSchedules(context.Background(), ListFeeSchedulesParams{
+		StudentID: sql.NullInt64{Int64: student.StudentID, Valid: true},
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, schedules, 3)
+	require.True(t, schedules[0].EffectiveFrom.After(schedules[1].EffectiveFrom))
+}
+
+func TestUpdateInvoiceTxFeeSchedule(t *testing.T) {
+	store := NewStore(testDB)
+	student := createRandomStudent(t)
+	subject := createRandomLessonSubject(t)
+	lessonDatetime := time.Date(2034, time.June, 1, 10, 0, 0, 0, time.UTC)
+	schedule := createRandomFeeSchedule(t, student.StudentID, subject.SubjectID, 100, lessonDatetime.AddDate(0, -1, 0))
+
+	lesson, err := store.CreateLessonWithInvoicesTx(context.Background(), CreateLessonTxParams{
+		LessonDatetime: lessonDatetime,
+		Duration:       60,
+		LocationID:     createRandomLessonLocation(t).LocationID,
+		SubjectID:      subject.SubjectID,
+		LessonInvoicesParams: []CreateLessonTxInvoiceParams{{
+			StudentID:     student.StudentID,
+			HourlyFee:     schedule.HourlyFee,
+			Duration:      60,
+			Amount:        schedule.HourlyFee,
+			FeeScheduleID: sql.NullInt64{Int64: schedule.FeeScheduleID, Valid: true},
+		}},
+	})
+	require.NoError(t, err)
+
+	invoice := lesson.Invoices[0]
+	arg := UpdateInvoiceParams{
+		InvoiceID:       invoice.InvoiceID,
+		StudentID:       invoice.StudentID,
+		LessonID:        invoice.LessonID,
+		InvoiceDatetime: invoice.InvoiceDatetime,
+		HourlyFee:       schedule.HourlyFee + 10,
+		Duration:        invoice.Duration,
+		Amount:          schedule.HourlyFee + 10,
+		FeeScheduleID:   invoice.FeeScheduleID,
+	}
+
+	// an overridden hourly fee isn't taken from the fee schedule
+	updated, err := store.UpdateInvoiceTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.HourlyFee, updated.HourlyFee)
+	require.False(t, updated.FeeScheduleID.Valid)
+
+	// the hourly fee of the schedule links the invoice to the schedule again
+	arg.HourlyFee = schedule.HourlyFee
+	arg.Amount = schedule.HourlyFee
+	arg.FeeScheduleID = sql.NullInt64{}
+	updated, err = store.UpdateInvoiceTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, sql.NullInt64{Int64: schedule.FeeScheduleID, Valid: true}, updated.FeeScheduleID)
+
+	// a newer fee schedule applies to the lesson, so the old fee is overridden
+	newer := createRandomFeeSchedule(t, student.StudentID, subject.SubjectID, schedule.HourlyFee+20, lessonDatetime.AddDate(0, 0, -1))
+	updated, err = store.UpdateInvoiceTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, updated.FeeScheduleID.Valid)
+
+	arg.HourlyFee = newer.HourlyFee
+	updated, err = store.UpdateInvoiceTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, sql.NullInt64{Int64: newer.FeeScheduleID, Valid: true}, updated.FeeScheduleID)
+}
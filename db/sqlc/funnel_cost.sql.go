@@ -0,0 +1,145 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: funnel_cost.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createFunnelCost = `-- name: CreateFunnelCost :one
+INSERT INTO funnel_costs (
+  funnel_id, cost_date, amount, notes
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING funnel_cost_id, funnel_id, cost_date, amount, notes
+`
+
+type CreateFunnelCostParams struct {
+	FunnelID int64          `json:"funnel_id"`
+	CostDate time.Time      `json:"cost_date"`
+	Amount   float64        `json:"amount"`
+	Notes    sql.NullString `json:"notes"`
+}
+
+func (q *Queries) CreateFunnelCost(ctx context.Context, arg CreateFunnelCostParams) (FunnelCost, error) {
+	row := q.db.QueryRowContext(ctx, createFunnelCost,
+		arg.FunnelID,
+		arg.CostDate,
+		arg.Amount,
+		arg.Notes,
+	)
+	var i FunnelCost
+	err := row.Scan(
+		&i.FunnelCostID,
+		&i.FunnelID,
+		&i.CostDate,
+		&i.Amount,
+		&i.Notes,
+	)
+	return i, err
+}
+
+const deleteFunnelCost = `-- name: DeleteFunnelCost :exec
+DELETE FROM funnel_costs
+WHERE funnel_cost_id = $1
+`
+
+func (q *Queries) DeleteFunnelCost(ctx context.Context, funnelCostID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteFunnelCost, funnelCostID)
+	return err
+}
+
+const getFunnelCost = `-- name: GetFunnelCost :one
+SELECT funnel_cost_id, funnel_id, cost_date, amount, notes FROM funnel_costs
+WHERE funnel_cost_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetFunnelCost(ctx context.Context, funnelCostID int64) (FunnelCost, error) {
+	row := q.db.QueryRowContext(ctx, getFunnelCost, funnelCostID)
+	var i FunnelCost
+	err := row.Scan(
+		&i.FunnelCostID,
+		&i.FunnelID,
+		&i.CostDate,
+		&i.Amount,
+		&i.Notes,
+	)
+	return i, err
+}
+
+const listFunnelCosts = `-- name: ListFunnelCosts :many
+SELECT funnel_cost_id, funnel_id, cost_date, amount, notes FROM funnel_costs
+WHERE funnel_id = $1
+ORDER BY cost_date
+LIMIT $2
+OFFSET $3
+`
+
+type ListFunnelCostsParams struct {
+	FunnelID int64 `json:"funnel_id"`
+	Limit    int32 `json:"limit"`
+	Offset   int32 `json:"offset"`
+}
+
+func (q *Queries) ListFunnelCosts(ctx context.Context, arg ListFunnelCostsParams) ([]FunnelCost, error) {
+	rows, err := q.db.QueryContext(ctx, listFunnelCosts, arg.FunnelID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FunnelCost{}
+	for rows.Next() {
+		var i FunnelCost
+		if err := rows.Scan(
+			&i.FunnelCostID,
+			&i.FunnelID,
+			&i.CostDate,
+			&i.Amount,
+			&i.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateFunnelCost = `-- name: UpdateFunnelCost :exec
+UPDATE funnel_costs
+  set   funnel_id = $2,
+        cost_date = $3,
+        amount = $4,
+        notes = $5
+WHERE funnel_cost_id = $1
+`
+
+type UpdateFunnelCostParams struct {
+	FunnelCostID int64          `json:"funnel_cost_id"`
+	FunnelID     int64          `json:"funnel_id"`
+	CostDate     time.Time      `json:"cost_date"`
+	Amount       float64        `json:"amount"`
+	Notes        sql.NullString `json:"notes"`
+}
+
+func (q *Queries) UpdateFunnelCost(ctx context.Context, arg UpdateFunnelCostParams) error {
+	_, err := q.db.ExecContext(ctx, updateFunnelCost,
+		arg.FunnelCostID,
+		arg.FunnelID,
+		arg.CostDate,
+		arg.Amount,
+		arg.Notes,
+	)
+	return err
+}
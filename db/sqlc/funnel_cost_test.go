@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomFunnelCost adds a new random acquisition cost of funnel to the database, and returns the FunnelCost data type.
+func createRandomFunnelCost(t *testing.T, funnel Funnel) FunnelCost {
+	arg := CreateFunnelCostParams{
+		FunnelID: funnel.FunnelID,
+		CostDate: util.RandomDatetime().UTC().Truncate(24 * time.Hour),
+		Amount:   util.RandomPaymentAmount(),
+		Notes:    sql.NullString{String: util.RandomNote(), Valid: true},
+	}
+
+	funnelCost, err := testQueries.CreateFunnelCost(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, funnelCost)
+
+	require.NotZero(t, funnelCost.FunnelCostID)
+	require.Equal(t, arg.FunnelID, funnelCost.FunnelID)
+	require.WithinDuration(t, arg.CostDate, funnelCost.CostDate, 24*time.Hour)
+	require.Equal(t, arg.Amount, funnelCost.Amount)
+	require.Equal(t, arg.Notes, funnelCost.Notes)
+
+	return funnelCost
+}
+
+func TestCreateFunnelCost(t *testing.T) {
+	createRandomFunnelCost(t, createRandomFunnel(t))
+}
+
+func TestGetFunnelCost(t *testing.T) {
+	funnelCost1 := createRandomFunnelCost(t, createRandomFunnel(t))
+
+	funnelCost2, err := testQueries.GetFunnelCost(context.Background(), funnelCost1.FunnelCostID)
+	require.NoError(t, err)
+	require.NotEmpty(t, funnelCost2)
+
+	require.Equal(t, funnelCost1.FunnelCostID, funnelCost2.FunnelCostID)
+	require.Equal(t, funnelCost1.FunnelID, funnelCost2.FunnelID)
+	require.WithinDuration(t, funnelCost1.CostDate, funnelCost2.CostDate, time.Second)
+	require.Equal(t, funnelCost1.Amount, funnelCost2.Amount)
+	require.Equal(t, funnelCost1.Notes, funnelCost2.Notes)
+}
+
+func TestUpdateFunnelCost(t *testing.T) {
+	funnelCost1 := createRandomFunnelCost(t, createRandomFunnel(t))
+
+	arg := UpdateFunnelCostParams{
+		FunnelCostID: funnelCost1.FunnelCostID,
+		FunnelID:     funnelCost1.FunnelID,
+		CostDate:     util.RandomDatetime().UTC().Truncate(24 * time.Hour),
+		Amount:       util.RandomPaymentAmount(),
+		Notes:        sql.NullString{String: util.RandomNote(), Valid: true},
+	}
+
+	err := testQueries.UpdateFunnelCost(context.Background(), arg)
+	require.NoError(t, err)
+
+	funnelCost2, err := testQueries.GetFunnelCost(context.Background(), funnelCost1.FunnelCostID)
+	require.NoError(t, err)
+	require.NotEmpty(t, funnelCost2)
+
+	require.Equal(t, arg.FunnelCostID, funnelCost2.FunnelCostID)
+	require.Equal(t, arg.FunnelID, funnelCost2.FunnelID)
+	require.WithinDuration(t, arg.CostDate, funnelCost2.CostDate, 24*time.Hour)
+	require.Equal(t, arg.Amount, funnelCost2.Amount)
+	require.Equal(t, arg.Notes, funnelCost2.Notes)
+}
+
+func TestDeleteFunnelCost(t *testing.T) {
+	funnelCost1 := createRandomFunnelCost(t, createRandomFunnel(t))
+
+	err := testQueries.DeleteFunnelCost(context.Background(), funnelCost1.FunnelCostID)
+	require.NoError(t, err)
+
+	funnelCost2, err := testQueries.GetFunnelCost(context.Background(), funnelCost1.FunnelCostID)
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, funnelCost2)
+}
+
+func TestListFunnelCosts(t *testing.T) {
+	funnel := createRandomFunnel(t)
+	for i := 0; i < 10; i++ {
+		createRandomFunnelCost(t, funnel)
+	}
+
+	arg := ListFunnelCostsParams{
+		FunnelID: funnel.FunnelID,
+		Limit:    5,
+		Offset:   5,
+	}
+
+	funnelCosts, err := testQueries.ListFunnelCosts(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, funnelCosts, int(arg.Limit))
+
+	for _, v := range funnelCosts {
+		require.NotEmpty(t, v)
+		require.Equal(t, funnel.FunnelID, v.FunnelID)
+	}
+}
@@ -0,0 +1,344 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// Group pricing modes. A per_head plan charges each student the hourly fee of the plan,
+// a split plan divides the hourly fee of the plan evenly between the students of the lesson,
+// and a tiered plan charges each student the hourly fee of the tier matching the number of students in the lesson.
+const (
+	PricingModePerHead = "per_head"
+	PricingModeSplit   = "split"
+	PricingModeTiered  = "tiered"
+)
+
+// ErrNoPricingTier is returned when a tiered plan has no tier for the number of students in a lesson.
+var ErrNoPricingTier = errors.New("no pricing tier for the number of students")
+
+// GroupPricingPlanWithTiers is used for a group pricing plan and its tiers, ordered by the minimal number of students.
+type GroupPricingPlanWithTiers struct {
+	Plan  GroupPricingPlan   `json:"plan"`
+	Tiers []GroupPricingTier `json:"tiers"`
+}
+
+// GroupPricingTierParams contains the input parameters of a single tier of a group pricing plan.
+type GroupPricingTierParams struct {
+	MinStudents int32   `json:"min_students"`
+	HourlyFee   float64 `json:"hourly_fee"`
+}
+
+// GroupPricingPlanTxParams contains the input parameters of a group pricing plan and its tiers,
+// for the CreateGroupPricingPlanTx and UpdateGroupPricingPlanTx functions.
+type GroupPricingPlanTxParams struct {
+	PlanID    int64                    `json:"plan_id"`
+	Name      string                   `json:"name"`
+	Mode      string                   `json:"mode"`
+	HourlyFee float64                  `json:"hourly_fee"`
+	Tiers     []GroupPricingTierParams `json:"tiers"`
+}
+
+// CreateGroupPricingPlanTx creates a group pricing plan and its tiers. PlanID is ignored.
+func (store *SQLStore) CreateGroupPricingPlanTx(ctx context.Context, arg GroupPricingPlanTxParams) (GroupPricingPlanWithTiers, error) {
+	var result GroupPricingPlanWithTiers
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Plan, err = q.CreateGroupPricingPlan(ctx, CreateGroupPricingPlanParams{
+			Name:      arg.Name,
+			Mode:      arg.Mode,
+			HourlyFee: arg.HourlyFee,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Tiers, err = q.createGroupPricingTiers(ctx, result.Plan.PlanID, arg.Tiers)
+		return err
+	})
+
+	return result, err
+}
+
+// GetGroupPricingPlanTx gets a group pricing plan and its tiers.
+func (store *SQLStore) GetGroupPricingPlanTx(ctx context.Context, planID int64) (GroupPricingPlanWithTiers, error) {
+	var result GroupPricingPlanWithTiers
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.getGroupPricingPlan(ctx, planID)
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateGroupPricingPlanTx updates a group pricing plan and replaces its tiers.
+// Invoices already issued for lessons priced by the plan are not changed.
+func (store *SQLStore) UpdateGroupPricingPlanTx(ctx context.Context, arg GroupPricingPlanTxParams) (GroupPricingPlanWithTiers, error) {
+	var result GroupPricingPlanWithTiers
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetGroupPricingPlan(ctx, arg.PlanID)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateGroupPricingPlan(ctx, UpdateGroupPricingPlanParams{
+			PlanID:    arg.PlanID,
+			Name:      arg.Name,
+			Mode:      arg.Mode,
+			HourlyFee: arg.HourlyFee,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteGroupPricingTiers(ctx, arg.PlanID)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.createGroupPricingTiers(ctx, arg.PlanID, arg.Tiers)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.getGroupPricingPlan(ctx, arg.PlanID)
+		return err
+	})
+
+	return result, err
+}
+
+// createGroupPricingTiers creates the tiers of a group pricing plan, within a transaction.
+func (q *Queries) createGroupPricingTiers(ctx context.Context, planID int64, tiers []GroupPricingTierParams) ([]GroupPricingTier, error) {
+	var result []GroupPricingTier
+
+	for _, tierArg := range tiers {
+		tier, err := q.CreateGroupPricingTier(ctx, CreateGroupPricingTierParams{
+			PlanID:      planID,
+			MinStudents: tierArg.MinStudents,
+			HourlyFee:   tierArg.HourlyFee,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, tier)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].MinStudents < result[j].MinStudents })
+	return result, nil
+}
+
+// getGroupPricingPlan gets a group pricing plan and its tiers, within a transaction.
+func (q *Queries) getGroupPricingPlan(ctx context.Context, planID int64) (GroupPricingPlanWithTiers, error) {
+	var result GroupPricingPlanWithTiers
+	var err error
+
+	result.Plan, err = q.GetGroupPricingPlan(ctx, planID)
+	if err != nil {
+		return result, err
+	}
+
+	result.Tiers, err = q.GetGroupPricingTiers(ctx, planID)
+	return result, err
+}
+
+// GroupPricingShare is the hourly fee and amount charged to a single student of a group lesson.
+type GroupPricingShare struct {
+	HourlyFee float64 `json:"hourly_fee"`
+	Amount    float64 `json:"amount"`
+}
+
+// GroupPricingShares returns the shares of the students of a group lesson of duration minutes,
+// after applying the discount of each student. The number of students is the number of discounts.
+// In split mode the cents of the group amount are divided so the shares before discounts add up to the group amount,
+// with any remaining cents charged to the first students.
+// It returns ErrNoPricingTier if the plan is tiered and no tier applies to the number of students.
+func GroupPricingShares(plan GroupPricingPlanWithTiers, duration int64, discounts []float64) ([]GroupPricingShare, error) {
+	n := len(discounts)
+	if n == 0 {
+		return nil, nil
+	}
+
+	hourlyFee := plan.Plan.HourlyFee
+	if plan.Plan.Mode == PricingModeTiered {
+		found := false
+		for _, tier := range plan.Tiers {
+			if int(tier.MinStudents) <= n {
+				hourlyFee = tier.HourlyFee
+				found = true
+			}
+		}
+
+		if !found {
+			return nil, ErrNoPricingTier
+		}
+	}
+
+	shares := make([]GroupPricingShare, n)
+	for i, discount := range discounts {
+		var amount float64
+
+		if plan.Plan.Mode == PricingModeSplit {
+			cents := int64(math.Round(hourlyFee * float64(duration) / 60.0 * 100))
+			share := cents / int64(n)
+			if int64(i) < cents%int64(n) {
+				share++
+			}
+
+			shares[i].HourlyFee = round2(hourlyFee / float64(n))
+			amount = float64(share) / 100
+		} else {
+			shares[i].HourlyFee = hourlyFee
+			amount = hourlyFee * float64(duration) / 60.0
+		}
+
+		shares[i].Amount = round2(amount * (1.0 - discount))
+	}
+
+	return shares, nil
+}
+
+// round2 rounds a value to cents.
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// GroupLessonStudentParams contains the input parameters of a single student of a group lesson.
+type GroupLessonStudentParams struct {
+	StudentID int64          `json:"student_id"`
+	Discount  float64        `json:"discount"`
+	Notes     sql.NullString `json:"notes"`
+}
+
+// CreateGroupLessonTxParams contains the input parameters of a group lesson and its students, for the CreateGroupLessonTx function.
+type CreateGroupLessonTxParams struct {
+	LessonDatetime time.Time                  `json:"lesson_datetime"`
+	Duration       int64                      `json:"duration"`
+	LocationID     int64                      `json:"location_id"`
+	SubjectID      int64                      `json:"subject_id"`
+	Notes          sql.NullString             `json:"notes"`
+	PricingPlanID  int64                      `json:"pricing_plan_id"`
+	Students       []GroupLessonStudentParams `json:"students"`
+}
+
+// CreateGroupLessonTx creates a lesson priced by a group pricing plan, with the invoices of its students
+// generated from the shares of the plan. The lesson and invoices are otherwise created as by CreateLessonWithInvoicesTx.
+func (store *SQLStore) CreateGroupLessonTx(ctx context.Context, arg CreateGroupLessonTxParams) (LessonWithInvoices, error) {
+	var result LessonWithInvoices
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		plan, err := q.getGroupPricingPlan(ctx, arg.PricingPlanID)
+		if err != nil {
+			return err
+		}
+
+		students := append([]GroupLessonStudentParams(nil), arg.Students...)
+		sort.Slice(students, func(i, j int) bool { return students[i].StudentID < students[j].StudentID })
+
+		discounts := make([]float64, len(students))
+		for i, student := range students {
+			discounts[i] = student.Discount
+		}
+
+		shares, err := GroupPricingShares(plan, arg.Duration, discounts)
+		if err != nil {
+			return err
+		}
+
+		lessonArg := CreateLessonTxParams{
+			LessonDatetime: arg.LessonDatetime,
+			Duration:       arg.Duration,
+			LocationID:     arg.LocationID,
+			SubjectID:      arg.SubjectID,
+			Notes:          arg.Notes,
+			PricingPlanID:  sql.NullInt64{Int64: arg.PricingPlanID, Valid: true},
+		}
+
+		for i, student := range students {
+			lessonArg.LessonInvoicesParams = append(lessonArg.LessonInvoicesParams, CreateLessonTxInvoiceParams{
+				StudentID: student.StudentID,
+				HourlyFee: shares[i].HourlyFee,
+				Duration:  arg.Duration,
+				Discount:  student.Discount,
+				Amount:    shares[i].Amount,
+				Notes:     student.Notes,
+			})
+		}
+
+		result, err = q.createLessonWithInvoices(ctx, lessonArg)
+		return err
+	})
+
+	return result, err
+}
+
+// recalculateLessonInvoices updates the invoices of a lesson to the shares of a group pricing plan for the number of invoiced students in the lesson.
+// Invoices deducted from packages keep the value of their deducted units, and the invoice.updated webhook event is posted for each changed invoice.
+func (q *Queries) recalculateLessonInvoices(ctx context.Context, lesson Lesson, plan GroupPricingPlanWithTiers) error {
+	invoices, err := q.GetInvoicesByLesson(ctx, lesson.LessonID)
+	if err != nil {
+		return err
+	}
+
+	deductions, err := q.GetPackageDeductionsByLesson(ctx, lesson.LessonID)
+	if err != nil {
+		return err
+	}
+
+	deducted := make(map[int64]bool)
+	for _, deduction := range deductions {
+		deducted[deduction.InvoiceID] = true
+	}
+
+	discounts := make([]float64, len(invoices))
+	for i, invoice := range invoices {
+		discounts[i] = invoice.Discount
+	}
+
+	shares, err := GroupPricingShares(plan, lesson.Duration, discounts)
+	if err != nil {
+		return err
+	}
+
+	for i, invoice := range invoices {
+		if deducted[invoice.InvoiceID] || (invoice.HourlyFee == shares[i].HourlyFee && invoice.Amount == shares[i].Amount) {
+			continue
+		}
+
+		invoice.HourlyFee = shares[i].HourlyFee
+		invoice.Amount = shares[i].Amount
+
+		err = q.UpdateInvoice(ctx, UpdateInvoiceParams{
+			InvoiceID:       invoice.InvoiceID,
+			StudentID:       invoice.StudentID,
+			LessonID:        invoice.LessonID,
+			InvoiceDatetime: invoice.InvoiceDatetime,
+			HourlyFee:       invoice.HourlyFee,
+			Duration:        invoice.Duration,
+			Discount:        invoice.Discount,
+			Amount:          invoice.Amount,
+			Notes:           invoice.Notes,
+			FeeScheduleID:   invoice.FeeScheduleID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.enqueueWebhookEvent(ctx, WebhookEventInvoiceUpdated, invoice)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: group_pricing.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createGroupPricingPlan = `-- name: CreateGroupPricingPlan :one
+INSERT INTO group_pricing_plans (
+  name, mode, hourly_fee
+) VALUES (
+  $1, $2, $3
+)
+RETURNING plan_id, name, mode, hourly_fee, created_at
+`
+
+type CreateGroupPricingPlanParams struct {
+	Name      string  `json:"name"`
+	Mode      string  `json:"mode"`
+	HourlyFee float64 `json:"hourly_fee"`
+}
+
+func (q *Queries) CreateGroupPricingPlan(ctx context.Context, arg CreateGroupPricingPlanParams) (GroupPricingPlan, error) {
+	row := q.db.QueryRowContext(ctx, createGroupPricingPlan, arg.Name, arg.Mode, arg.HourlyFee)
+	var i GroupPricingPlan
+	err := row.Scan(
+		&i.PlanID,
+		&i.Name,
+		&i.Mode,
+		&i.HourlyFee,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createGroupPricingTier = `-- name: CreateGroupPricingTier :one
+INSERT INTO group_pricing_tiers (
+  plan_id, min_students, hourly_fee
+) VALUES (
+  $1, $2, $3
+)
+RETURNING plan_id, min_students, hourly_fee
+`
+
+type CreateGroupPricingTierParams struct {
+	PlanID      int64   `json:"plan_id"`
+	MinStudents int32   `json:"min_students"`
+	HourlyFee   float64 `json:"hourly_fee"`
+}
+
+func (q *Queries) CreateGroupPricingTier(ctx context.Context, arg CreateGroupPricingTierParams) (GroupPricingTier, error) {
+	row := q.db.QueryRowContext(ctx, createGroupPricingTier, arg.PlanID, arg.MinStudents, arg.HourlyFee)
+	var i GroupPricingTier
+	err := row.Scan(&i.PlanID, &i.MinStudents, &i.HourlyFee)
+	return i, err
+}
+
+const deleteGroupPricingTiers = `-- name: DeleteGroupPricingTiers :exec
+DELETE FROM group_pricing_tiers
+WHERE plan_id = $1
+`
+
+func (q *Queries) DeleteGroupPricingTiers(ctx context.Context, planID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteGroupPricingTiers, planID)
+	return err
+}
+
+const getGroupPricingPlan = `-- name: GetGroupPricingPlan :one
+SELECT plan_id, name, mode, hourly_fee, created_at FROM group_pricing_plans
+WHERE plan_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetGroupPricingPlan(ctx context.Context, planID int64) (GroupPricingPlan, error) {
+	row := q.db.QueryRowContext(ctx, getGroupPricingPlan, planID)
+	var i GroupPricingPlan
+	err := row.Scan(
+		&i.PlanID,
+		&i.Name,
+		&i.Mode,
+		&i.HourlyFee,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getGroupPricingTiers = `-- name: GetGroupPricingTiers :many
+SELECT plan_id, min_students, hourly_fee FROM group_pricing_tiers
+WHERE plan_id = $1
+ORDER BY min_students
+`
+
+func (q *Queries) GetGroupPricingTiers(ctx context.Context, planID int64) ([]GroupPricingTier, error) {
+	rows, err := q.db.QueryContext(ctx, getGroupPricingTiers, planID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GroupPricingTier{}
+	for rows.Next() {
+		var i GroupPricingTier
+		if err := rows.Scan(&i.PlanID, &i.MinStudents, &i.HourlyFee); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGroupPricingPlans = `-- name: ListGroupPricingPlans :many
+SELECT plan_id, name, mode, hourly_fee, created_at FROM group_pricing_plans
+ORDER BY name
+LIMIT $1
+OFFSET $2
+`
+
+type ListGroupPricingPlansParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListGroupPricingPlans(ctx context.Context, arg ListGroupPricingPlansParams) ([]GroupPricingPlan, error) {
+	rows, err := q.db.QueryContext(ctx, listGroupPricingPlans, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GroupPricingPlan{}
+	for rows.Next() {
+		var i GroupPricingPlan
+		if err := rows.Scan(
+			&i.PlanID,
+			&i.Name,
+			&i.Mode,
+			&i.HourlyFee,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateGroupPricingPlan = `-- name: UpdateGroupPricingPlan :exec
+UPDATE group_pricing_plans
+  set   name = $2,
+        mode = $3,
+        hourly_fee = $4
+WHERE plan_id = $1
+`
+
+type UpdateGroupPricingPlanParams struct {
+	PlanID    int64   `json:"plan_id"`
+	Name      string  `json:"name"`
+	Mode      string  `json:"mode"`
+	HourlyFee float64 `json:"hourly_fee"`
+}
+
+func (q *Queries) UpdateGroupPricingPlan(ctx context.Context, arg UpdateGroupPricingPlanParams) error {
+	_, err := q.db.ExecContext(ctx, updateGroupPricingPlan,
+		arg.PlanID,
+		arg.Name,
+		arg.Mode,
+		arg.HourlyFee,
+	)
+	return err
+}
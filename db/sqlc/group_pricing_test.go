@@ -0,0 +1,179 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomGroupPricingPlan adds a new group pricing plan of mode with tiers.
+func createRandomGroupPricingPlan(t *testing.T, mode string, hourlyFee float64, tiers ...GroupPricingTierParams) GroupPricingPlanWithTiers {
+	store := NewStore(testDB)
+
+	arg := GroupPricingPlanTxParams{
+		Name:      util.RandomName(),
+		Mode:      mode,
+		HourlyFee: hourlyFee,
+		Tiers:     tiers,
+	}
+
+	plan, err := store.CreateGroupPricingPlanTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, plan.Plan.PlanID)
+	require.Equal(t, arg.Name, plan.Plan.Name)
+	require.Equal(t, arg.Mode, plan.Plan.Mode)
+	require.Equal(t, arg.HourlyFee, plan.Plan.HourlyFee)
+	require.Len(t, plan.Tiers, len(tiers))
+
+	return plan
+}
+
+// createRandomGroupLesson adds a new lesson of 90 minutes priced by plan, with a new student for each discount.
+func createRandomGroupLesson(t *testing.T, plan GroupPricingPlanWithTiers, discounts ...float64) LessonWithInvoices {
+	store := NewStore(testDB)
+
+	arg := CreateGroupLessonTxParams{
+		LessonDatetime: util.RandomDatetime(),
+		Duration:       90,
+		LocationID:     createRandomLessonLocation(t).LocationID,
+		SubjectID:      createRandomLessonSubject(t).SubjectID,
+		PricingPlanID:  plan.Plan.PlanID,
+	}
+
+	for _, discount := range discounts {
+		arg.Students = append(arg.Students, GroupLessonStudentParams{
+			StudentID: createRandomStudent(t).StudentID,
+			Discount:  discount,
+		})
+	}
+
+	lesson, err := store.CreateGroupLessonTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, sql.NullInt64{Int64: plan.Plan.PlanID, Valid: true}, lesson.Lesson.PricingPlanID)
+	require.Len(t, lesson.Invoices, len(discounts))
+
+	return lesson
+}
+
+func TestGroupPricingShares(t *testing.T) {
+	perHead := GroupPricingPlanWithTiers{Plan: GroupPricingPlan{Mode: PricingModePerHead, HourlyFee: 100}}
+	shares, err := GroupPricingShares(perHead, 90, []float64{0, 0.1})
+	require.NoError(t, err)
+	require.Equal(t, []GroupPricingShare{{HourlyFee: 100, Amount: 150}, {HourlyFee: 100, Amount: 135}}, shares)
+
+	// the cents of the group amount are divided evenly, and the remainder charged to the first students
+	split := GroupPricingPlanWithTiers{Plan: GroupPricingPlan{Mode: PricingModeSplit, HourlyFee: 100}}
+	shares, err = GroupPricingShares(split, 60, []float64{0, 0, 0})
+	require.NoError(t, err)
+	require.Equal(t, []GroupPricingShare{{HourlyFee: 33.33, Amount: 33.34}, {HourlyFee: 33.33, Amount: 33.33}, {HourlyFee: 33.33, Amount: 33.33}}, shares)
+
+	tiered := GroupPricingPlanWithTiers{
+		Plan: GroupPricingPlan{Mode: PricingModeTiered},
+		Tiers: []GroupPricingTier{
+			{MinStudents: 2, HourlyFee: 80},
+			{MinStudents: 4, HourlyFee: 60},
+		},
+	}
+	shares, err = GroupPricingShares(tiered, 60, []float64{0, 0, 0})
+	require.NoError(t, err)
+	require.Equal(t, 80.0, shares[0].HourlyFee)
+
+	shares, err = GroupPricingShares(tiered, 60, []float64{0, 0, 0, 0.5})
+	require.NoError(t, err)
+	require.Equal(t, GroupPricingShare{HourlyFee: 60, Amount: 30}, shares[3])
+
+	_, err = GroupPricingShares(tiered, 60, []float64{0})
+	require.ErrorIs(t, err, ErrNoPricingTier)
+
+	shares, err = GroupPricingShares(tiered, 60, nil)
+	require.NoError(t, err)
+	require.Empty(t, shares)
+}
+
+func TestUpdateGroupPricingPlanTx(t *testing.T) {
+	store := NewStore(testDB)
+	plan1 := createRandomGroupPricingPlan(t, PricingModeSplit, 200)
+
+	arg := GroupPricingPlanTxParams{
+		PlanID: plan1.Plan.PlanID,
+		Name:   util.RandomName(),
+		Mode:   PricingModeTiered,
+		Tiers: []GroupPricingTierParams{
+			{MinStudents: 3, HourlyFee: 90},
+			{MinStudents: 1, HourlyFee: 120},
+		},
+	}
+
+	plan2, err := store.UpdateGroupPricingPlanTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, plan2.Plan.Name)
+	require.Equal(t, PricingModeTiered, plan2.Plan.Mode)
+	require.Len(t, plan2.Tiers, 2)
+	require.Equal(t, int32(1), plan2.Tiers[0].MinStudents)
+
+	plan3, err := store.GetGroupPricingPlanTx(context.Background(), plan1.Plan.PlanID)
+	require.NoError(t, err)
+	require.Equal(t, plan2, plan3)
+
+	arg.PlanID = 0
+	_, err = store.UpdateGroupPricingPlanTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCreateGroupLessonTx(t *testing.T) {
+	plan := createRandomGroupPricingPlan(t, PricingModeSplit, 100)
+	lesson := createRandomGroupLesson(t, plan, 0, 0, 0.5)
+
+	// the group amount of 150 is split evenly before the discounts
+	var total float64
+	for _, invoice := range lesson.Invoices {
+		require.Equal(t, 33.33, invoice.HourlyFee)
+		total += invoice.Amount / (1 - invoice.Discount)
+	}
+	require.InDelta(t, 150, total, 0.01)
+}
+
+func TestAddLessonStudentTxGroupPricing(t *testing.T) {
+	store := NewStore(testDB)
+	plan := createRandomGroupPricingPlan(t, PricingModeTiered, 0,
+		GroupPricingTierParams{MinStudents: 1, HourlyFee: 100},
+		GroupPricingTierParams{MinStudents: 3, HourlyFee: 80},
+	)
+	lesson1 := createRandomGroupLesson(t, plan, 0, 0.1)
+	for _, invoice := range lesson1.Invoices {
+		require.Equal(t, 100.0, invoice.HourlyFee)
+	}
+
+	student := createRandomStudent(t)
+	arg := AddLessonStudentTxParams{
+		LessonID:  lesson1.Lesson.LessonID,
+		StudentID: student.StudentID,
+	}
+
+	// the third student moves all the invoices to the second tier
+	lesson2, err := store.AddLessonStudentTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, lesson2.Invoices, 3)
+	for _, invoice := range lesson2.Invoices {
+		require.Equal(t, 80.0, invoice.HourlyFee)
+		require.Equal(t, round2(120*(1-invoice.Discount)), invoice.Amount)
+	}
+
+	_, err = store.AddLessonStudentTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrStudentInLesson)
+
+	// removing the student moves the invoices back to the first tier
+	lesson3, err := store.RemoveLessonStudentTx(context.Background(), lesson1.Lesson.LessonID, student.StudentID)
+	require.NoError(t, err)
+	require.Len(t, lesson3.Invoices, 2)
+	for _, invoice := range lesson3.Invoices {
+		require.Equal(t, 100.0, invoice.HourlyFee)
+		require.Equal(t, round2(150*(1-invoice.Discount)), invoice.Amount)
+	}
+
+	_, err = store.RemoveLessonStudentTx(context.Background(), lesson1.Lesson.LessonID, student.StudentID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
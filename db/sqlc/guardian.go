@@ -0,0 +1,316 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+)
+
+// Relationships of a guardian to a student.
+const (
+	GuardianRelationshipParent      = "parent"
+	GuardianRelationshipGuardian    = "guardian"
+	GuardianRelationshipGrandparent = "grandparent"
+	GuardianRelationshipSibling     = "sibling"
+	GuardianRelationshipSpouse      = "spouse"
+	GuardianRelationshipOther       = "other"
+)
+
+var (
+	// ErrNotGuardianStudent is returned when a guardian receipt is allocated to a student that isn't linked to the guardian.
+	ErrNotGuardianStudent = errors.New("student is not linked to the guardian")
+
+	// ErrAllocationMismatch is returned when the allocations of a guardian receipt don't add up to its payments,
+	// or when an allocation or a payment is less than a cent.
+	ErrAllocationMismatch = errors.New("allocations don't add up to the payments")
+
+	// ErrGuardianReceiptAllocation is returned when a receipt allocated from a guardian receipt is deleted alone,
+	// which would leave the amount of the guardian receipt out of sync with its receipts.
+	ErrGuardianReceiptAllocation = errors.New("receipt is allocated from a guardian receipt")
+)
+
+// LinkStudentGuardianTx links a guardian to a student, or updates the link if they are already linked.
+// Setting the guardian as the billing contact of the student unsets the previous billing contact of the student.
+// It returns sql.ErrNoRows if the student or the guardian doesn't exist.
+func (store *SQLStore) LinkStudentGuardianTx(ctx context.Context, arg UpsertStudentGuardianParams) (StudentGuardian, error) {
+	var result StudentGuardian
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetStudent(ctx, arg.StudentID)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.GetGuardian(ctx, arg.GuardianID)
+		if err != nil {
+			return err
+		}
+
+		if arg.BillingContact {
+			err = q.ClearStudentBillingContact(ctx, arg.StudentID)
+			if err != nil {
+				return err
+			}
+		}
+
+		result, err = q.UpsertStudentGuardian(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// getBillingContact returns the billing contact of a student, or nil if the student has none, within a transaction.
+func (q *Queries) getBillingContact(ctx context.Context, studentID int64) (*Guardian, error) {
+	guardian, err := q.GetBillingContact(ctx, studentID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &guardian, nil
+}
+
+// GuardianReceiptAllocation is the amount of a guardian receipt paid for a single student.
+type GuardianReceiptAllocation struct {
+	StudentID int64   `json:"student_id"`
+	Amount    float64 `json:"amount"`
+}
+
+// CreateGuardianReceiptTxParams contains the input parameters of a guardian receipt, for the CreateGuardianReceiptTx function.
+type CreateGuardianReceiptTxParams struct {
+	GuardianID      int64                          `json:"guardian_id"`
+	ReceiptDatetime time.Time                      `json:"receipt_datetime"`
+	Notes           sql.NullString                 `json:"notes"`
+	Payments        []CreateReceiptTxPaymentParams `json:"payments"`
+	Allocations     []GuardianReceiptAllocation    `json:"allocations"`
+}
+
+// GuardianReceiptWithReceipts is a guardian receipt and the receipts of the students it is allocated to.
+type GuardianReceiptWithReceipts struct {
+	GuardianReceipt GuardianReceipt       `json:"guardian_receipt"`
+	Receipts        []ReceiptWithPayments `json:"receipts"`
+}
+
+// CreateGuardianReceiptTx creates a single receipt of a guardian paying for one or more of their students.
+// The payments are split between the students by the allocations, in the order of both, and a receipt with its
+// share of the payments is created for each student, so the balance of each student is kept.
+// A single receipt confirmation email is sent to the guardian, and the receipt.created webhook event is posted for each student receipt.
+// It returns ErrNotGuardianStudent if a student isn't linked to the guardian,
+// and ErrAllocationMismatch if the allocations don't add up to the payments, or an allocation or a payment is less than a cent.
+func (store *SQLStore) CreateGuardianReceiptTx(ctx context.Context, arg CreateGuardianReceiptTxParams) (GuardianReceiptWithReceipts, error) {
+	var result GuardianReceiptWithReceipts
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		guardian, err := q.GetGuardian(ctx, arg.GuardianID)
+		if err != nil {
+			return err
+		}
+
+		students := make([]Student, 0, len(arg.Allocations))
+		for _, allocation := range arg.Allocations {
+			_, err = q.GetStudentGuardian(ctx, GetStudentGuardianParams{
+				StudentID:  allocation.StudentID,
+				GuardianID: arg.GuardianID,
+			})
+			if err == sql.ErrNoRows {
+				return ErrNotGuardianStudent
+			}
+			if err != nil {
+				return err
+			}
+
+			student, err := q.GetStudent(ctx, allocation.StudentID)
+			if err != nil {
+				return err
+			}
+			students = append(students, student)
+		}
+
+		shares, err := splitGuardianPayments(arg.Payments, arg.Allocations)
+		if err != nil {
+			return err
+		}
+
+		var amount float64
+		for _, payment := range arg.Payments {
+			amount += payment.Amount
+		}
+
+		result.GuardianReceipt, err = q.CreateGuardianReceipt(ctx, CreateGuardianReceiptParams{
+			GuardianID:      arg.GuardianID,
+			ReceiptDatetime: arg.ReceiptDatetime,
+			Amount:          round2(amount),
+			Notes:           arg.Notes,
+		})
+		if err != nil {
+			return err
+		}
+
+		guardianReceiptID := sql.NullInt64{Int64: result.GuardianReceipt.GuardianReceiptID, Valid: true}
+		receipts := make([]Receipt, 0, len(shares))
+		for i, allocation := range arg.Allocations {
+			receipt, err := q.insertReceiptWithPayments(ctx, CreateReceiptTxParams{
+				StudentID:             allocation.StudentID,
+				ReceiptDatetime:       arg.ReceiptDatetime,
+				Notes:                 arg.Notes,
+				ReceiptPaymentsParams: shares[i],
+			}, guardianReceiptID)
+			if err != nil {
+				return err
+			}
+
+			err = q.enqueueWebhookEvent(ctx, WebhookEventReceiptCreated, receipt)
+			if err != nil {
+				return err
+			}
+
+			result.Receipts = append(result.Receipts, receipt)
+			receipts = append(receipts, receipt.Receipt)
+		}
+
+		return q.enqueueGuardianReceiptEmail(ctx, guardian, result.GuardianReceipt, students, receipts)
+	})
+
+	return result, err
+}
+
+// splitGuardianPayments splits the payments of a guardian receipt between the allocations, in the order of both.
+// A payment spanning two allocations is split into a payment of each. Amounts are split in whole cents,
+// so an allocation or a payment that rounds to less than a cent would create an empty receipt and is rejected.
+func splitGuardianPayments(payments []CreateReceiptTxPaymentParams, allocations []GuardianReceiptAllocation) ([][]CreateReceiptTxPaymentParams, error) {
+	remaining := make([]int64, len(payments))
+	var paid int64
+	for i, payment := range payments {
+		remaining[i] = int64(math.Round(payment.Amount * 100))
+		if remaining[i] < 1 {
+			return nil, ErrAllocationMismatch
+		}
+		paid += remaining[i]
+	}
+
+	var allocated int64
+	for _, allocation := range allocations {
+		cents := int64(math.Round(allocation.Amount * 100))
+		if cents < 1 {
+			return nil, ErrAllocationMismatch
+		}
+		allocated += cents
+	}
+
+	if len(allocations) == 0 || paid != allocated {
+		return nil, ErrAllocationMismatch
+	}
+
+	shares := make([][]CreateReceiptTxPaymentParams, len(allocations))
+	p := 0
+	for i, allocation := range allocations {
+		due := int64(math.Round(allocation.Amount * 100))
+		for due > 0 {
+			for remaining[p] == 0 {
+				p++
+			}
+
+			cents := min(due, remaining[p])
+			shares[i] = append(shares[i], CreateReceiptTxPaymentParams{
+				PaymentDatetime: payments[p].PaymentDatetime,
+				Amount:          float64(cents) / 100.0,
+				PaymentMethodID: payments[p].PaymentMethodID,
+			})
+
+			remaining[p] -= cents
+			due -= cents
+		}
+	}
+
+	return shares, nil
+}
+
+// GetGuardianReceiptTx gets a guardian receipt and the receipts of the students it is allocated to, with their payments.
+func (store *SQLStore) GetGuardianReceiptTx(ctx context.Context, guardianReceiptID int64) (GuardianReceiptWithReceipts, error) {
+	var result GuardianReceiptWithReceipts
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.GuardianReceipt, err = q.GetGuardianReceipt(ctx, guardianReceiptID)
+		if err != nil {
+			return err
+		}
+
+		receipts, err := q.GetReceiptsByGuardianReceipt(ctx, sql.NullInt64{Int64: guardianReceiptID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		for _, receipt := range receipts {
+			payments, err := q.GetPayments(ctx, receipt.ReceiptID)
+			if err != nil {
+				return err
+			}
+
+			result.Receipts = append(result.Receipts, ReceiptWithPayments{
+				Receipt:  receipt,
+				Payments: payments,
+			})
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// DeleteGuardianReceiptTx deletes a guardian receipt and the receipts of the students it is allocated to, with their payments.
+func (store *SQLStore) DeleteGuardianReceiptTx(ctx context.Context, guardianReceiptID int64) error {
+	err := store.execTx(ctx, func(q *Queries) error {
+		receipts, err := q.GetReceiptsByGuardianReceipt(ctx, sql.NullInt64{Int64: guardianReceiptID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		for _, receipt := range receipts {
+			err = q.deleteReceiptWithPayments(ctx, receipt.ReceiptID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return q.DeleteGuardianReceipt(ctx, guardianReceiptID)
+	})
+
+	return err
+}
+
+// enqueueGuardianReceiptEmail adds the receipt confirmation email of a guardian receipt to the outbox.
+// Guardians without an email address are skipped.
+func (q *Queries) enqueueGuardianReceiptEmail(ctx context.Context, guardian Guardian, guardianReceipt GuardianReceipt, students []Student, receipts []Receipt) error {
+	if !guardian.Email.Valid || guardian.Email.String == "" {
+		return nil
+	}
+
+	payload := EmailPayload{
+		Guardian:        &guardian,
+		GuardianReceipt: &guardianReceipt,
+		Students:        students,
+		Receipts:        receipts,
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateOutboxEmail(ctx, CreateOutboxEmailParams{
+		Template:  EmailTemplateGuardianReceiptConfirmation,
+		Recipient: guardian.Email.String,
+		Payload:   data,
+		SendAt:    time.Now().UTC(),
+	})
+	return err
+}
@@ -0,0 +1,523 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: guardian.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const clearStudentBillingContact = `-- name: ClearStudentBillingContact :exec
+UPDATE student_guardians
+  set   billing_contact = false
+WHERE student_id = $1 AND billing_contact
+`
+
+func (q *Queries) ClearStudentBillingContact(ctx context.Context, studentID int64) error {
+	_, err := q.db.ExecContext(ctx, clearStudentBillingContact, studentID)
+	return err
+}
+
+const createGuardian = `-- name: CreateGuardian :one
+INSERT INTO guardians (
+  first_name, last_name, email, phone_number, address, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING guardian_id, first_name, last_name, email, phone_number, address, notes, created_at
+`
+
+type CreateGuardianParams struct {
+	FirstName   string         `json:"first_name"`
+	LastName    string         `json:"last_name"`
+	Email       sql.NullString `json:"email"`
+	PhoneNumber sql.NullString `json:"phone_number"`
+	Address     sql.NullString `json:"address"`
+	Notes       sql.NullString `json:"notes"`
+}
+
+func (q *Queries) CreateGuardian(ctx context.Context, arg CreateGuardianParams) (Guardian, error) {
+	row := q.db.QueryRowContext(ctx, createGuardian,
+		arg.FirstName,
+		arg.LastName,
+		arg.Email,
+		arg.PhoneNumber,
+		arg.Address,
+		arg.Notes,
+	)
+	var i Guardian
+	err := row.Scan(
+		&i.GuardianID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.Address,
+		&i.Notes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createGuardianReceipt = `-- name: CreateGuardianReceipt :one
+INSERT INTO guardian_receipts (
+  guardian_id, receipt_datetime, amount, notes
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING guardian_receipt_id, guardian_id, receipt_datetime, amount, notes
+`
+
+type CreateGuardianReceiptParams struct {
+	GuardianID      int64          `json:"guardian_id"`
+	ReceiptDatetime time.Time      `json:"receipt_datetime"`
+	Amount          float64        `json:"amount"`
+	Notes           sql.NullString `json:"notes"`
+}
+
+func (q *Queries) CreateGuardianReceipt(ctx context.Context, arg CreateGuardianReceiptParams) (GuardianReceipt, error) {
+	row := q.db.QueryRowContext(ctx, createGuardianReceipt,
+		arg.GuardianID,
+		arg.ReceiptDatetime,
+		arg.Amount,
+		arg.Notes,
+	)
+	var i GuardianReceipt
+	err := row.Scan(
+		&i.GuardianReceiptID,
+		&i.GuardianID,
+		&i.ReceiptDatetime,
+		&i.Amount,
+		&i.Notes,
+	)
+	return i, err
+}
+
+const deleteGuardianReceipt = `-- name: DeleteGuardianReceipt :exec
+DELETE FROM guardian_receipts
+WHERE guardian_receipt_id = $1
+`
+
+func (q *Queries) DeleteGuardianReceipt(ctx context.Context, guardianReceiptID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteGuardianReceipt, guardianReceiptID)
+	return err
+}
+
+const deleteStudentGuardian = `-- name: DeleteStudentGuardian :execrows
+DELETE FROM student_guardians
+WHERE student_id = $1 AND guardian_id = $2
+`
+
+type DeleteStudentGuardianParams struct {
+	StudentID  int64 `json:"student_id"`
+	GuardianID int64 `json:"guardian_id"`
+}
+
+func (q *Queries) DeleteStudentGuardian(ctx context.Context, arg DeleteStudentGuardianParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteStudentGuardian, arg.StudentID, arg.GuardianID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getBillingContact = `-- name: GetBillingContact :one
+SELECT g.guardian_id, g.first_name, g.last_name, g.email, g.phone_number, g.address, g.notes, g.created_at FROM student_guardians sg
+JOIN guardians g ON g.guardian_id = sg.guardian_id
+WHERE sg.student_id = $1 AND sg.billing_contact
+LIMIT 1
+`
+
+func (q *Queries) GetBillingContact(ctx context.Context, studentID int64) (Guardian, error) {
+	row := q.db.QueryRowContext(ctx, getBillingContact, studentID)
+	var i Guardian
+	err := row.Scan(
+		&i.GuardianID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.Address,
+		&i.Notes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getGuardian = `-- name: GetGuardian :one
+SELECT guardian_id, first_name, last_name, email, phone_number, address, notes, created_at FROM guardians
+WHERE guardian_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetGuardian(ctx context.Context, guardianID int64) (Guardian, error) {
+	row := q.db.QueryRowContext(ctx, getGuardian, guardianID)
+	var i Guardian
+	err := row.Scan(
+		&i.GuardianID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.Address,
+		&i.Notes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getGuardianReceipt = `-- name: GetGuardianReceipt :one
+SELECT guardian_receipt_id, guardian_id, receipt_datetime, amount, notes FROM guardian_receipts
+WHERE guardian_receipt_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetGuardianReceipt(ctx context.Context, guardianReceiptID int64) (GuardianReceipt, error) {
+	row := q.db.QueryRowContext(ctx, getGuardianReceipt, guardianReceiptID)
+	var i GuardianReceipt
+	err := row.Scan(
+		&i.GuardianReceiptID,
+		&i.GuardianID,
+		&i.ReceiptDatetime,
+		&i.Amount,
+		&i.Notes,
+	)
+	return i, err
+}
+
+const getGuardianStudents = `-- name: GetGuardianStudents :many
+SELECT s.student_id, s.first_name, s.last_name, s.email, s.phone_number, s.address, s.college_id, s.funnel_id, s.hourly_fee, s.notes, s.created_at, s.lifecycle_status, sg.relationship, sg.billing_contact
+FROM student_guardians sg
+JOIN students s ON s.student_id = sg.student_id
+WHERE sg.guardian_id = $1
+ORDER BY s.last_name, s.first_name
+`
+
+type GetGuardianStudentsRow struct {
+	StudentID       int64           `json:"student_id"`
+	FirstName       string          `json:"first_name"`
+	LastName        string          `json:"last_name"`
+	Email           sql.NullString  `json:"email"`
+	PhoneNumber     sql.NullString  `json:"phone_number"`
+	Address         sql.NullString  `json:"address"`
+	CollegeID       sql.NullInt64   `json:"college_id"`
+	FunnelID        sql.NullInt64   `json:"funnel_id"`
+	HourlyFee       sql.NullFloat64 `json:"hourly_fee"`
+	Notes           sql.NullString  `json:"notes"`
+	CreatedAt       time.Time       `json:"created_at"`
+	LifecycleStatus string          `json:"lifecycle_status"`
+	Relationship    string          `json:"relationship"`
+	BillingContact  bool            `json:"billing_contact"`
+}
+
+func (q *Queries) GetGuardianStudents(ctx context.Context, guardianID int64) ([]GetGuardianStudentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getGuardianStudents, guardianID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetGuardianStudentsRow{}
+	for rows.Next() {
+		var i GetGuardianStudentsRow
+		if err := rows.Scan(
+			&i.StudentID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.PhoneNumber,
+			&i.Address,
+			&i.CollegeID,
+			&i.FunnelID,
+			&i.HourlyFee,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.LifecycleStatus,
+			&i.Relationship,
+			&i.BillingContact,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReceiptsByGuardianReceipt = `-- name: GetReceiptsByGuardianReceipt :many
+SELECT receipt_id, student_id, receipt_datetime, amount, notes, guardian_receipt_id FROM receipts
+WHERE guardian_receipt_id = $1
+ORDER BY student_id
+`
+
+func (q *Queries) GetReceiptsByGuardianReceipt(ctx context.Context, guardianReceiptID sql.NullInt64) ([]Receipt, error) {
+	rows, err := q.db.QueryContext(ctx, getReceiptsByGuardianReceipt, guardianReceiptID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Receipt{}
+	for rows.Next() {
+		var i Receipt
+		if err := rows.Scan(
+			&i.ReceiptID,
+			&i.StudentID,
+			&i.ReceiptDatetime,
+			&i.Amount,
+			&i.Notes,
+			&i.GuardianReceiptID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStudentGuardian = `-- name: GetStudentGuardian :one
+SELECT student_id, guardian_id, relationship, billing_contact, created_at FROM student_guardians
+WHERE student_id = $1 AND guardian_id = $2 LIMIT 1
+`
+
+type GetStudentGuardianParams struct {
+	StudentID  int64 `json:"student_id"`
+	GuardianID int64 `json:"guardian_id"`
+}
+
+func (q *Queries) GetStudentGuardian(ctx context.Context, arg GetStudentGuardianParams) (StudentGuardian, error) {
+	row := q.db.QueryRowContext(ctx, getStudentGuardian, arg.StudentID, arg.GuardianID)
+	var i StudentGuardian
+	err := row.Scan(
+		&i.StudentID,
+		&i.GuardianID,
+		&i.Relationship,
+		&i.BillingContact,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getStudentGuardians = `-- name: GetStudentGuardians :many
+SELECT g.guardian_id, g.first_name, g.last_name, g.email, g.phone_number, g.address, g.notes, g.created_at, sg.relationship, sg.billing_contact
+FROM student_guardians sg
+JOIN guardians g ON g.guardian_id = sg.guardian_id
+WHERE sg.student_id = $1
+ORDER BY sg.billing_contact DESC, g.last_name, g.first_name
+`
+
+type GetStudentGuardiansRow struct {
+	GuardianID     int64          `json:"guardian_id"`
+	FirstName      string         `json:"first_name"`
+	LastName       string         `json:"last_name"`
+	Email          sql.NullString `json:"email"`
+	PhoneNumber    sql.NullString `json:"phone_number"`
+	Address        sql.NullString `json:"address"`
+	Notes          sql.NullString `json:"notes"`
+	CreatedAt      time.Time      `json:"created_at"`
+	Relationship   string         `json:"relationship"`
+	BillingContact bool           `json:"billing_contact"`
+}
+
+func (q *Queries) GetStudentGuardians(ctx context.Context, studentID int64) ([]GetStudentGuardiansRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStudentGuardians, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStudentGuardiansRow{}
+	for rows.Next() {
+		var i GetStudentGuardiansRow
+		if err := rows.Scan(
+			&i.GuardianID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.PhoneNumber,
+			&i.Address,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.Relationship,
+			&i.BillingContact,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGuardianReceipts = `-- name: ListGuardianReceipts :many
+SELECT guardian_receipt_id, guardian_id, receipt_datetime, amount, notes FROM guardian_receipts
+WHERE guardian_id = $1
+ORDER BY receipt_datetime DESC
+LIMIT $2
+OFFSET $3
+`
+
+type ListGuardianReceiptsParams struct {
+	GuardianID int64 `json:"guardian_id"`
+	Limit      int32 `json:"limit"`
+	Offset     int32 `json:"offset"`
+}
+
+func (q *Queries) ListGuardianReceipts(ctx context.Context, arg ListGuardianReceiptsParams) ([]GuardianReceipt, error) {
+	rows, err := q.db.QueryContext(ctx, listGuardianReceipts, arg.GuardianID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GuardianReceipt{}
+	for rows.Next() {
+		var i GuardianReceipt
+		if err := rows.Scan(
+			&i.GuardianReceiptID,
+			&i.GuardianID,
+			&i.ReceiptDatetime,
+			&i.Amount,
+			&i.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGuardians = `-- name: ListGuardians :many
+SELECT guardian_id, first_name, last_name, email, phone_number, address, notes, created_at FROM guardians
+ORDER BY last_name, first_name
+LIMIT $1
+OFFSET $2
+`
+
+type ListGuardiansParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListGuardians(ctx context.Context, arg ListGuardiansParams) ([]Guardian, error) {
+	rows, err := q.db.QueryContext(ctx, listGuardians, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Guardian{}
+	for rows.Next() {
+		var i Guardian
+		if err := rows.Scan(
+			&i.GuardianID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.PhoneNumber,
+			&i.Address,
+			&i.Notes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateGuardian = `-- name: UpdateGuardian :exec
+UPDATE guardians
+  set   first_name = $2,
+        last_name = $3,
+        email = $4,
+        phone_number = $5,
+        address = $6,
+        notes = $7
+WHERE guardian_id = $1
+`
+
+type UpdateGuardianParams struct {
+	GuardianID  int64          `json:"guardian_id"`
+	FirstName   string         `json:"first_name"`
+	LastName    string         `json:"last_name"`
+	Email       sql.NullString `json:"email"`
+	PhoneNumber sql.NullString `json:"phone_number"`
+	Address     sql.NullString `json:"address"`
+	Notes       sql.NullString `json:"notes"`
+}
+
+func (q *Queries) UpdateGuardian(ctx context.Context, arg UpdateGuardianParams) error {
+	_, err := q.db.ExecContext(ctx, updateGuardian,
+		arg.GuardianID,
+		arg.FirstName,
+		arg.LastName,
+		arg.Email,
+		arg.PhoneNumber,
+		arg.Address,
+		arg.Notes,
+	)
+	return err
+}
+
+const upsertStudentGuardian = `-- name: UpsertStudentGuardian :one
+INSERT INTO student_guardians (
+  student_id, guardian_id, relationship, billing_contact
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (student_id, guardian_id) DO UPDATE
+  set   relationship = EXCLUDED.relationship,
+        billing_contact = EXCLUDED.billing_contact
+RETURNING student_id, guardian_id, relationship, billing_contact, created_at
+`
+
+type UpsertStudentGuardianParams struct {
+	StudentID      int64  `json:"student_id"`
+	GuardianID     int64  `json:"guardian_id"`
+	Relationship   string `json:"relationship"`
+	BillingContact bool   `json:"billing_contact"`
+}
+
+func (q *Queries) UpsertStudentGuardian(ctx context.Context, arg UpsertStudentGuardianParams) (StudentGuardian, error) {
+	row := q.db.QueryRowContext(ctx, upsertStudentGuardian,
+		arg.StudentID,
+		arg.GuardianID,
+		arg.Relationship,
+		arg.BillingContact,
+	)
+	var i StudentGuardian
+	err := row.Scan(
+		&i.StudentID,
+		&i.GuardianID,
+		&i.Relationship,
+		&i.BillingContact,
+		&i.CreatedAt,
+	)
+	return i, err
+}
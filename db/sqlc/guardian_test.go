@@ -0,0 +1,249 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomGuardian adds a new random guardian to the database.
+func createRandomGuardian(t *testing.T) Guardian {
+	arg := CreateGuardianParams{
+		FirstName:   util.RandomName(),
+		LastName:    util.RandomName(),
+		Email:       sql.NullString{String: util.RandomEmail(), Valid: true},
+		PhoneNumber: sql.NullString{String: util.RandomPhoneNumber(), Valid: true},
+		Address:     sql.NullString{String: util.RandomAddress(), Valid: true},
+		Notes:       sql.NullString{String: util.RandomNote(), Valid: true},
+	}
+
+	guardian, err := testQueries.CreateGuardian(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, guardian.GuardianID)
+	require.Equal(t, arg.FirstName, guardian.FirstName)
+	require.Equal(t, arg.LastName, guardian.LastName)
+	require.Equal(t, arg.Email, guardian.Email)
+	require.Equal(t, arg.PhoneNumber, guardian.PhoneNumber)
+	require.Equal(t, arg.Address, guardian.Address)
+	require.Equal(t, arg.Notes, guardian.Notes)
+	require.NotZero(t, guardian.CreatedAt)
+
+	return guardian
+}
+
+// linkRandomGuardian links a guardian to a student as their parent.
+func linkRandomGuardian(t *testing.T, student Student, guardian Guardian, billingContact bool) StudentGuardian {
+	store := NewStore(testDB)
+
+	link, err := store.LinkStudentGuardianTx(context.Background(), UpsertStudentGuardianParams{
+		StudentID:      student.StudentID,
+		GuardianID:     guardian.GuardianID,
+		Relationship:   GuardianRelationshipParent,
+		BillingContact: billingContact,
+	})
+	require.NoError(t, err)
+	require.Equal(t, student.StudentID, link.StudentID)
+	require.Equal(t, guardian.GuardianID, link.GuardianID)
+	require.Equal(t, billingContact, link.BillingContact)
+
+	return link
+}
+
+func TestUpdateGuardian(t *testing.T) {
+	guardian1 := createRandomGuardian(t)
+
+	arg := UpdateGuardianParams{
+		GuardianID:  guardian1.GuardianID,
+		FirstName:   util.RandomName(),
+		LastName:    util.RandomName(),
+		Email:       sql.NullString{String: util.RandomEmail(), Valid: true},
+		PhoneNumber: guardian1.PhoneNumber,
+		Address:     guardian1.Address,
+		Notes:       guardian1.Notes,
+	}
+	require.NoError(t, testQueries.UpdateGuardian(context.Background(), arg))
+
+	guardian2, err := testQueries.GetGuardian(context.Background(), guardian1.GuardianID)
+	require.NoError(t, err)
+	require.Equal(t, arg.FirstName, guardian2.FirstName)
+	require.Equal(t, arg.LastName, guardian2.LastName)
+	require.Equal(t, arg.Email, guardian2.Email)
+}
+
+func TestLinkStudentGuardianTx(t *testing.T) {
+	student := createRandomStudent(t)
+	guardian1 := createRandomGuardian(t)
+	guardian2 := createRandomGuardian(t)
+
+	linkRandomGuardian(t, student, guardian1, true)
+
+	// a new billing contact replaces the previous one
+	linkRandomGuardian(t, student, guardian2, true)
+
+	guardians, err := testQueries.GetStudentGuardians(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Len(t, guardians, 2)
+	require.Equal(t, guardian2.GuardianID, guardians[0].GuardianID)
+	require.True(t, guardians[0].BillingContact)
+	require.False(t, guardians[1].BillingContact)
+
+	contact, err := testQueries.GetBillingContact(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Equal(t, guardian2.GuardianID, contact.GuardianID)
+
+	// linking again updates the link
+	linkRandomGuardian(t, student, guardian2, false)
+	_, err = testQueries.GetBillingContact(context.Background(), student.StudentID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	rows, err := testQueries.DeleteStudentGuardian(context.Background(), DeleteStudentGuardianParams{
+		StudentID:  student.StudentID,
+		GuardianID: guardian1.GuardianID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	students, err := testQueries.GetGuardianStudents(context.Background(), guardian1.GuardianID)
+	require.NoError(t, err)
+	require.Empty(t, students)
+
+	store := NewStore(testDB)
+	_, err = store.LinkStudentGuardianTx(context.Background(), UpsertStudentGuardianParams{
+		StudentID:    student.StudentID,
+		GuardianID:   -1,
+		Relationship: GuardianRelationshipParent,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestBillingContactEmails(t *testing.T) {
+	student := createRandomStudent(t)
+	guardian := createRandomGuardian(t)
+	linkRandomGuardian(t, student, guardian, true)
+
+	_, err := createRandomLessonWithInvoiceTx(t, time.Now().UTC().Add(48*time.Hour), student)
+	require.NoError(t, err)
+
+	// the invoice email of the student is sent to the billing contact
+	emails, err := testQueries.GetOutboxEmailsByStudent(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Len(t, emails, 1)
+	require.Equal(t, guardian.Email.String, emails[0].Recipient)
+}
+
+func TestCreateGuardianReceiptTx(t *testing.T) {
+	store := NewStore(testDB)
+	guardian := createRandomGuardian(t)
+	student1 := createRandomStudent(t)
+	student2 := createRandomStudent(t)
+	linkRandomGuardian(t, student1, guardian, true)
+	linkRandomGuardian(t, student2, guardian, false)
+	paymentMethod := createRandomPaymentMethod(t)
+
+	datetime := util.RandomDatetime()
+	arg := CreateGuardianReceiptTxParams{
+		GuardianID:      guardian.GuardianID,
+		ReceiptDatetime: datetime,
+		Notes:           sql.NullString{String: util.RandomNote(), Valid: true},
+		Payments: []CreateReceiptTxPaymentParams{
+			{PaymentDatetime: datetime, Amount: 150, PaymentMethodID: paymentMethod.PaymentMethodID},
+			{PaymentDatetime: datetime, Amount: 100.5, PaymentMethodID: paymentMethod.PaymentMethodID},
+		},
+		Allocations: []GuardianReceiptAllocation{
+			{StudentID: student1.StudentID, Amount: 120.25},
+			{StudentID: student2.StudentID, Amount: 130.25},
+		},
+	}
+
+	result, err := store.CreateGuardianReceiptTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, 250.5, result.GuardianReceipt.Amount)
+	require.Len(t, result.Receipts, 2)
+
+	// the first payment is split between the students
+	require.Equal(t, 120.25, result.Receipts[0].Receipt.Amount)
+	require.Len(t, result.Receipts[0].Payments, 1)
+	require.Equal(t, 130.25, result.Receipts[1].Receipt.Amount)
+	require.Len(t, result.Receipts[1].Payments, 2)
+	require.Equal(t, 29.75, result.Receipts[1].Payments[0].Amount)
+
+	receipt, err := store.GetGuardianReceiptTx(context.Background(), result.GuardianReceipt.GuardianReceiptID)
+	require.NoError(t, err)
+	require.Len(t, receipt.Receipts, 2)
+	for _, r := range receipt.Receipts {
+		require.Equal(t, sql.NullInt64{Int64: result.GuardianReceipt.GuardianReceiptID, Valid: true}, r.Receipt.GuardianReceiptID)
+	}
+
+	// the allocations must add up to the payments
+	arg.Allocations[1].Amount = 100
+	_, err = store.CreateGuardianReceiptTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrAllocationMismatch)
+
+	// an allocation of less than a cent would create an empty receipt
+	arg.Allocations[0].Amount = 120.248
+	arg.Allocations[1].Amount = 130.25
+	arg.Allocations = append(arg.Allocations, GuardianReceiptAllocation{StudentID: student1.StudentID, Amount: 0.002})
+	_, err = store.CreateGuardianReceiptTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrAllocationMismatch)
+	arg.Allocations = arg.Allocations[:2]
+	arg.Allocations[0].Amount = 120.25
+
+	// students must be linked to the guardian
+	arg.Allocations[1] = GuardianReceiptAllocation{StudentID: createRandomStudent(t).StudentID, Amount: 130.25}
+	_, err = store.CreateGuardianReceiptTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrNotGuardianStudent)
+}
+
+func TestDeleteGuardianReceiptTx(t *testing.T) {
+	store := NewStore(testDB)
+	guardian := createRandomGuardian(t)
+	student1 := createRandomStudent(t)
+	student2 := createRandomStudent(t)
+	linkRandomGuardian(t, student1, guardian, true)
+	linkRandomGuardian(t, student2, guardian, false)
+	paymentMethod := createRandomPaymentMethod(t)
+
+	datetime := util.RandomDatetime()
+	result, err := store.CreateGuardianReceiptTx(context.Background(), CreateGuardianReceiptTxParams{
+		GuardianID:      guardian.GuardianID,
+		ReceiptDatetime: datetime,
+		Payments: []CreateReceiptTxPaymentParams{
+			{PaymentDatetime: datetime, Amount: 200, PaymentMethodID: paymentMethod.PaymentMethodID},
+		},
+		Allocations: []GuardianReceiptAllocation{
+			{StudentID: student1.StudentID, Amount: 120},
+			{StudentID: student2.StudentID, Amount: 80},
+		},
+	})
+	require.NoError(t, err)
+
+	// a receipt of a student can't be deleted alone, as the guardian receipt would keep its amount
+	err = store.DeleteReceiptWithPaymentsTx(context.Background(), result.Receipts[0].Receipt.ReceiptID)
+	require.ErrorIs(t, err, ErrGuardianReceiptAllocation)
+
+	receipt, err := store.GetGuardianReceiptTx(context.Background(), result.GuardianReceipt.GuardianReceiptID)
+	require.NoError(t, err)
+	require.Equal(t, 200.0, receipt.GuardianReceipt.Amount)
+	require.Len(t, receipt.Receipts, 2)
+
+	// deleting the guardian receipt deletes the receipts of all the students
+	err = store.DeleteGuardianReceiptTx(context.Background(), result.GuardianReceipt.GuardianReceiptID)
+	require.NoError(t, err)
+
+	_, err = store.GetGuardianReceiptTx(context.Background(), result.GuardianReceipt.GuardianReceiptID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	for _, r := range result.Receipts {
+		_, err = testQueries.GetReceipt(context.Background(), r.Receipt.ReceiptID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		for _, payment := range r.Payments {
+			_, err = testQueries.GetPayment(context.Background(), payment.PaymentID)
+			require.ErrorIs(t, err, sql.ErrNoRows)
+		}
+	}
+}
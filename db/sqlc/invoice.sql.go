@@ -13,11 +13,11 @@ import (
 
 const createInvoice = `-- name: CreateInvoice :one
 INSERT INTO invoices (
-  student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes
+  student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes, fee_schedule_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
 )
-RETURNING invoice_id, student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes
+RETURNING invoice_id, student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes, fee_schedule_id
 `
 
 type CreateInvoiceParams struct {
@@ -29,6 +29,7 @@ type CreateInvoiceParams struct {
 	Discount        float64        `json:"discount"`
 	Amount          float64        `json:"amount"`
 	Notes           sql.NullString `json:"notes"`
+	FeeScheduleID   sql.NullInt64  `json:"fee_schedule_id"`
 }
 
 func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (Invoice, error) {
@@ -41,6 +42,7 @@ func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (I
 		arg.Discount,
 		arg.Amount,
 		arg.Notes,
+		arg.FeeScheduleID,
 	)
 	var i Invoice
 	err := row.Scan(
@@ -53,6 +55,7 @@ func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (I
 		&i.Discount,
 		&i.Amount,
 		&i.Notes,
+		&i.FeeScheduleID,
 	)
 	return i, err
 }
@@ -78,7 +81,7 @@ func (q *Queries) DeleteInvoicesByLesson(ctx context.Context, lessonID int64) er
 }
 
 const getInvoice = `-- name: GetInvoice :one
-SELECT invoice_id, student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes FROM invoices
+SELECT invoice_id, student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes, fee_schedule_id FROM invoices
 WHERE invoice_id = $1 LIMIT 1
 `
 
@@ -95,12 +98,42 @@ func (q *Queries) GetInvoice(ctx context.Context, invoiceID int64) (Invoice, err
 		&i.Discount,
 		&i.Amount,
 		&i.Notes,
+		&i.FeeScheduleID,
+	)
+	return i, err
+}
+
+const getInvoiceByLessonStudent = `-- name: GetInvoiceByLessonStudent :one
+SELECT invoice_id, student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes, fee_schedule_id FROM invoices
+WHERE lesson_id = $1 AND student_id = $2
+LIMIT 1
+`
+
+type GetInvoiceByLessonStudentParams struct {
+	LessonID  int64 `json:"lesson_id"`
+	StudentID int64 `json:"student_id"`
+}
+
+func (q *Queries) GetInvoiceByLessonStudent(ctx context.Context, arg GetInvoiceByLessonStudentParams) (Invoice, error) {
+	row := q.db.QueryRowContext(ctx, getInvoiceByLessonStudent, arg.LessonID, arg.StudentID)
+	var i Invoice
+	err := row.Scan(
+		&i.InvoiceID,
+		&i.StudentID,
+		&i.LessonID,
+		&i.InvoiceDatetime,
+		&i.HourlyFee,
+		&i.Duration,
+		&i.Discount,
+		&i.Amount,
+		&i.Notes,
+		&i.FeeScheduleID,
 	)
 	return i, err
 }
 
 const getInvoicesByLesson = `-- name: GetInvoicesByLesson :many
-SELECT invoice_id, student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes FROM invoices
+SELECT invoice_id, student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes, fee_schedule_id FROM invoices
 WHERE lesson_id = $1
 ORDER BY student_id
 `
@@ -124,6 +157,7 @@ func (q *Queries) GetInvoicesByLesson(ctx context.Context, lessonID int64) ([]In
 			&i.Discount,
 			&i.Amount,
 			&i.Notes,
+			&i.FeeScheduleID,
 		); err != nil {
 			return nil, err
 		}
@@ -139,7 +173,7 @@ func (q *Queries) GetInvoicesByLesson(ctx context.Context, lessonID int64) ([]In
 }
 
 const getInvoicesByStudent = `-- name: GetInvoicesByStudent :many
-SELECT invoice_id, student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes FROM invoices
+SELECT invoice_id, student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes, fee_schedule_id FROM invoices
 WHERE student_id = $1
 ORDER BY invoice_datetime
 `
@@ -163,6 +197,7 @@ func (q *Queries) GetInvoicesByStudent(ctx context.Context, studentID int64) ([]
 			&i.Discount,
 			&i.Amount,
 			&i.Notes,
+			&i.FeeScheduleID,
 		); err != nil {
 			return nil, err
 		}
@@ -178,7 +213,7 @@ func (q *Queries) GetInvoicesByStudent(ctx context.Context, studentID int64) ([]
 }
 
 const listInvoices = `-- name: ListInvoices :many
-SELECT invoice_id, student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes FROM invoices
+SELECT invoice_id, student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes, fee_schedule_id FROM invoices
 ORDER BY student_id, invoice_datetime
 LIMIT $1
 OFFSET $2
@@ -208,6 +243,7 @@ func (q *Queries) ListInvoices(ctx context.Context, arg ListInvoicesParams) ([]I
 			&i.Discount,
 			&i.Amount,
 			&i.Notes,
+			&i.FeeScheduleID,
 		); err != nil {
 			return nil, err
 		}
@@ -231,7 +267,8 @@ UPDATE invoices
         duration = $6, 
         discount = $7,
         amount =  $8,
-        notes = $9
+        notes = $9,
+        fee_schedule_id = $10
 WHERE invoice_id = $1
 `
 
@@ -245,6 +282,7 @@ type UpdateInvoiceParams struct {
 	Discount        float64        `json:"discount"`
 	Amount          float64        `json:"amount"`
 	Notes           sql.NullString `json:"notes"`
+	FeeScheduleID   sql.NullInt64  `json:"fee_schedule_id"`
 }
 
 func (q *Queries) UpdateInvoice(ctx context.Context, arg UpdateInvoiceParams) error {
@@ -258,6 +296,7 @@ func (q *Queries) UpdateInvoice(ctx context.Context, arg UpdateInvoiceParams) er
 		arg.Discount,
 		arg.Amount,
 		arg.Notes,
+		arg.FeeScheduleID,
 	)
 	return err
 }
@@ -207,17 +207,19 @@ func TestUpdateInvoice(t *testing.T) {
 	invoice1 := createRandomInvoice(t)
 	student := createRandomStudent(t)
 	lesson := createRandomLesson(t)
+	schedule := createRandomFeeSchedule(t, student.StudentID, lesson.SubjectID, util.RandomHourlyFee(), lesson.LessonDatetime)
 
 	arg := UpdateInvoiceParams{
 		InvoiceID:       invoice1.InvoiceID,
 		StudentID:       student.StudentID,
 		LessonID:        lesson.LessonID,
 		InvoiceDatetime: util.RandomDatetime(),
-		HourlyFee:       util.RandomHourlyFee(),
+		HourlyFee:       schedule.HourlyFee,
 		Duration:        util.RandomLessonDuration(),
 		Discount:        util.RandomDiscount(),
 		Amount:          util.RandomInvoiceAmount(),
 		Notes:           sql.NullString{String: util.RandomNote(), Valid: true},
+		FeeScheduleID:   sql.NullInt64{Int64: schedule.FeeScheduleID, Valid: true},
 	}
 	err := testQueries.UpdateInvoice(context.Background(), arg)
 	require.NoError(t, err)
@@ -235,6 +237,7 @@ func TestUpdateInvoice(t *testing.T) {
 	require.Equal(t, arg.HourlyFee, invoice2.HourlyFee)
 	require.Equal(t, arg.Amount, invoice2.Amount)
 	require.Equal(t, arg.Notes, invoice2.Notes)
+	require.Equal(t, arg.FeeScheduleID, invoice2.FeeScheduleID)
 }
 
 func TestDeleteInvoice(t *testing.T) {
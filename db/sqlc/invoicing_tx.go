@@ -20,20 +20,26 @@ func (l Lessons) Len() int           { return len(l) }
 func (l Lessons) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
 func (l Lessons) Less(i, j int) bool { return l[i].LessonDatetime.Before(l[j].LessonDatetime) }
 
-// LessonWithInvoices is used for a single lesson and all the Invoices issued for participating students.
+// LessonWithInvoices is used for a single lesson and all the Invoices issued for participating students,
+// with the deductions from the packages of the students that paid for the lesson with a package,
+// and the attendance of all the students of the lesson, including students attending for free.
 type LessonWithInvoices struct {
-	Lesson   Lesson   `json:"lesson"`
-	Invoices Invoices `json:"invoices"`
+	Lesson            Lesson             `json:"lesson"`
+	Invoices          Invoices           `json:"invoices"`
+	PackageDeductions []PackageDeduction `json:"package_deductions"`
+	Attendance        []LessonAttendance `json:"attendance"`
 }
 
 // CreateLessonTxInvoiceParams contains the input paramaters of a single invoice, for the CreateLessonWithInvoicesTx function.
+// FeeScheduleID is the fee schedule the hourly fee is taken from, as resolved by ResolveHourlyFeeTx.
 type CreateLessonTxInvoiceParams struct {
-	StudentID int64          `json:"student_id"`
-	HourlyFee float64        `json:"hourly_fee"`
-	Duration  int64          `json:"duration"`
-	Discount  float64        `json:"discount"`
-	Amount    float64        `json:"amount"`
-	Notes     sql.NullString `json:"notes"`
+	StudentID     int64          `json:"student_id"`
+	HourlyFee     float64        `json:"hourly_fee"`
+	Duration      int64          `json:"duration"`
+	Discount      float64        `json:"discount"`
+	Amount        float64        `json:"amount"`
+	Notes         sql.NullString `json:"notes"`
+	FeeScheduleID sql.NullInt64  `json:"fee_schedule_id"`
 }
 
 // CreateLessonTxParams contains the input paramaters of a single lesson and its Invoices, for the CreateLessonWithInvoicesTx function.
@@ -43,52 +49,110 @@ type CreateLessonTxParams struct {
 	LocationID           int64                         `json:"location_id"`
 	SubjectID            int64                         `json:"subject_id"`
 	Notes                sql.NullString                `json:"notes"`
+	PricingPlanID        sql.NullInt64                 `json:"pricing_plan_id"`
 	LessonInvoicesParams []CreateLessonTxInvoiceParams `json:"lesson_invoices_params"`
 }
 
-// CreateLessonTx creates a lesson held and invoices for all the students that took part in the lesson.
+// CreateLessonTx creates a lesson held and invoices for all the students that took part in the lesson,
+// and records the students as attending the lesson.
+// The invoice of a student with a usable package is deducted from the package, and charged the value of the deducted units
+// instead of the amount of the invoice params.
+// The invoice emails of the students are added to the email outbox, and the lesson.created webhook event is posted.
 func (store *SQLStore) CreateLessonWithInvoicesTx(ctx context.Context, arg CreateLessonTxParams) (LessonWithInvoices, error) {
 	var result LessonWithInvoices
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = q.createLessonWithInvoices(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// createLessonWithInvoices creates a lesson and its invoices as by CreateLessonWithInvoicesTx, within a transaction.
+// The lesson schedule is locked until the end of the transaction, so lessons aren't created while a booking request is approved.
+func (q *Queries) createLessonWithInvoices(ctx context.Context, arg CreateLessonTxParams) (LessonWithInvoices, error) {
+	var result LessonWithInvoices
+
+	err := q.LockLessonSchedule(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	createLessonArg := CreateLessonParams{
+		LessonDatetime: arg.LessonDatetime,
+		Duration:       arg.Duration,
+		LocationID:     arg.LocationID,
+		SubjectID:      arg.SubjectID,
+		Notes:          arg.Notes,
+		PricingPlanID:  arg.PricingPlanID,
+	}
 
-		createLessonArg := CreateLessonParams{
-			LessonDatetime: arg.LessonDatetime,
-			Duration:       arg.Duration,
-			LocationID:     arg.LocationID,
-			SubjectID:      arg.SubjectID,
-			Notes:          arg.Notes,
+	result.Lesson, err = q.CreateLesson(ctx, createLessonArg)
+	if err != nil {
+		return result, err
+	}
+
+	for _, invoiceArg := range arg.LessonInvoicesParams {
+		createInvoiceArg := CreateInvoiceParams{
+			StudentID:       invoiceArg.StudentID,
+			LessonID:        result.Lesson.LessonID,
+			InvoiceDatetime: result.Lesson.LessonDatetime,
+			HourlyFee:       invoiceArg.HourlyFee,
+			Duration:        invoiceArg.Duration,
+			Discount:        invoiceArg.Discount,
+			Amount:          invoiceArg.Amount,
+			Notes:           invoiceArg.Notes,
+			FeeScheduleID:   invoiceArg.FeeScheduleID,
 		}
 
-		result.Lesson, err = q.CreateLesson(ctx, createLessonArg)
+		invoice, deduction, ok, err := q.createLessonInvoice(ctx, result.Lesson, createInvoiceArg)
 		if err != nil {
-			return err
+			return result, err
 		}
 
-		for _, invoiceArg := range arg.LessonInvoicesParams {
-			createInvoiceArg := CreateInvoiceParams{
-				StudentID: invoiceArg.StudentID,
-				LessonID:  result.Lesson.LessonID,
-				HourlyFee: invoiceArg.HourlyFee,
-				Duration:  invoiceArg.Duration,
-				Discount:  invoiceArg.Discount,
-				Amount:    invoiceArg.Amount,
-				Notes:     invoiceArg.Notes,
-			}
-
-			invoice, err := q.CreateInvoice(ctx, createInvoiceArg)
-			if err != nil {
-				return err
-			}
+		if ok {
+			result.PackageDeductions = append(result.PackageDeductions, deduction)
+		}
 
-			result.Invoices = append(result.Invoices, invoice)
+		attendance, err := q.CreateLessonAttendance(ctx, CreateLessonAttendanceParams{
+			LessonID:  result.Lesson.LessonID,
+			StudentID: invoice.StudentID,
+			Status:    AttendanceStatusAttended,
+		})
+		if err != nil {
+			return result, err
 		}
 
-		return nil
-	})
+		result.Invoices = append(result.Invoices, invoice)
+		result.Attendance = append(result.Attendance, attendance)
+	}
 
-	return result, err
+	return result, q.enqueueWebhookEvent(ctx, WebhookEventLessonCreated, result)
+}
+
+// createLessonInvoice creates the invoice of a student for a lesson, deducted from the package of the student if the student has a usable package,
+// and adds the invoice email to the email outbox. ok is false if the invoice isn't deducted from a package.
+func (q *Queries) createLessonInvoice(ctx context.Context, lesson Lesson, arg CreateInvoiceParams) (invoice Invoice, deduction PackageDeduction, ok bool, err error) {
+	pkg, units, ok, err := q.applyStudentPackage(ctx, &arg)
+	if err != nil {
+		return invoice, deduction, false, err
+	}
+
+	invoice, err = q.CreateInvoice(ctx, arg)
+	if err != nil {
+		return invoice, deduction, false, err
+	}
+
+	if ok {
+		deduction, err = q.deductStudentPackage(ctx, pkg, invoice, units)
+		if err != nil {
+			return invoice, deduction, false, err
+		}
+	}
+
+	return invoice, deduction, ok, q.enqueueInvoiceEmail(ctx, lesson, invoice)
 }
 
 // GetLessonWithInvoicesTx gets a Lesson and all the Invoices releated to it.
@@ -108,16 +172,97 @@ func (store *SQLStore) GetLessonWithInvoicesTx(ctx context.Context, lessonID int
 			return err
 		}
 
+		result.PackageDeductions, err = q.GetPackageDeductionsByLesson(ctx, lessonID)
+		if err != nil {
+			return err
+		}
+
+		result.Attendance, err = q.GetLessonAttendanceByLesson(ctx, lessonID)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 
 	return result, err
 }
 
-// DeleteLessonWithInvoicesTx deletes a Lesson and all the Invoices releated to it.
+// getLessonWithInvoices gets a Lesson and all the Invoices releated to it, within a transaction.
+// ok is false if the lesson doesn't exist.
+func (q *Queries) getLessonWithInvoices(ctx context.Context, lessonID int64) (result LessonWithInvoices, ok bool, err error) {
+	result.Lesson, err = q.GetLesson(ctx, lessonID)
+	if err == sql.ErrNoRows {
+		return result, false, nil
+	}
+	if err != nil {
+		return result, false, err
+	}
+
+	result.Invoices, err = q.GetInvoicesByLesson(ctx, lessonID)
+	if err != nil {
+		return result, false, err
+	}
+
+	result.PackageDeductions, err = q.GetPackageDeductionsByLesson(ctx, lessonID)
+	if err != nil {
+		return result, false, err
+	}
+
+	result.Attendance, err = q.GetLessonAttendanceByLesson(ctx, lessonID)
+	return result, err == nil, err
+}
+
+// DeleteLessonWithInvoicesTx deletes a Lesson and all the Invoices, attendance and lesson records releated to it.
+// The units deducted from packages for the invoices are returned to the packages.
+// Pending emails about the lesson, such as its reminders, are removed from the email outbox,
+// and the lesson.deleted webhook event is posted with the deleted lesson and invoices.
+// The first waitlisted student matching the subject and time of a future lesson is notified that the slot is available.
+// It returns ErrLessonHasAttachments if the lesson has attachments, as their files must be deleted from the blob storage first.
 func (store *SQLStore) DeleteLessonWithInvoicesTx(ctx context.Context, lessonID int64) error {
 	err := store.execTx(ctx, func(q *Queries) error {
-		err := q.DeleteInvoicesByLesson(ctx, lessonID)
+		attachments, err := q.CountLessonAttachments(ctx, sql.NullInt64{Int64: lessonID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		if attachments > 0 {
+			return ErrLessonHasAttachments
+		}
+
+		deleted, ok, err := q.getLessonWithInvoices(ctx, lessonID)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			err = q.enqueueWebhookEvent(ctx, WebhookEventLessonDeleted, deleted)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = q.DeletePendingOutboxEmailsByLesson(ctx, sql.NullInt64{Int64: lessonID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		err = q.restoreLessonPackageDeductions(ctx, lessonID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteInvoicesByLesson(ctx, lessonID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteLessonRecordsByLesson(ctx, lessonID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteLessonAttendanceByLesson(ctx, lessonID)
 		if err != nil {
 			return err
 		}
@@ -127,6 +272,11 @@ func (store *SQLStore) DeleteLessonWithInvoicesTx(ctx context.Context, lessonID
 			return err
 		}
 
+		// the waitlist email is added after the pending emails of the lesson are removed
+		if ok {
+			return q.notifyWaitlist(ctx, deleted)
+		}
+
 		return nil
 	})
 
@@ -94,6 +94,16 @@ func createRandomLessonWithInvoicesTx(t *testing.T, n int) LessonWithInvoices {
 
 		require.Equal(t, result.Lesson.LessonID, invoice.LessonID)
 		require.True(t, result.Lesson.Duration >= invoice.Duration)
+
+		// the invoice is dated at the lesson datetime
+		require.WithinDuration(t, arg.LessonDatetime, invoice.InvoiceDatetime, time.Second)
+	}
+
+	// check the students are recorded as attending the lesson
+	require.Len(t, result.Attendance, n)
+	for i, attendance := range result.Attendance {
+		require.Equal(t, result.Invoices[i].StudentID, attendance.StudentID)
+		require.Equal(t, AttendanceStatusAttended, attendance.Status)
 	}
 
 	return result
@@ -168,3 +178,27 @@ func TestDeleteLessonWithInvoicesTx(t *testing.T) {
 		require.Empty(t, invoice)
 	}
 }
+
+func TestDeleteLessonWithInvoicesTxAttachments(t *testing.T) {
+	store := NewStore(testDB)
+
+	lessonWithInvoices := createRandomLessonWithInvoicesTx(t, 1)
+	lessonID := lessonWithInvoices.Lesson.LessonID
+	attachment := createRandomAttachment(t, sql.NullInt64{}, sql.NullInt64{Int64: lessonID, Valid: true})
+
+	err := store.DeleteLessonWithInvoicesTx(context.Background(), lessonID)
+	require.ErrorIs(t, err, ErrLessonHasAttachments)
+
+	// nothing is deleted
+	_, err = testQueries.GetLesson(context.Background(), lessonID)
+	require.NoError(t, err)
+	_, err = testQueries.GetInvoice(context.Background(), lessonWithInvoices.Invoices[0].InvoiceID)
+	require.NoError(t, err)
+
+	// the lesson can be deleted once its attachments are deleted
+	_, err = testQueries.DeleteAttachment(context.Background(), attachment.AttachmentID)
+	require.NoError(t, err)
+
+	err = store.DeleteLessonWithInvoicesTx(context.Background(), lessonID)
+	require.NoError(t, err)
+}
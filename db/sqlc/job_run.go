@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// Job run triggers.
+const (
+	JobTriggerSchedule = "schedule"
+	JobTriggerManual   = "manual"
+)
+
+// Job run statuses.
+const (
+	JobRunStatusRunning   = "running"
+	JobRunStatusSucceeded = "succeeded"
+	JobRunStatusFailed    = "failed"
+)
+
+// ErrJobLocked is returned by LockJobTx if the job is already running, on this instance or another one.
+var ErrJobLocked = errors.New("job is already running")
+
+// LockJobTx runs fn while holding the Postgres advisory lock of a job, so a job runs on a single instance at a time.
+// The lock is held by a transaction that is open until fn returns. fn should use the store, not the transaction,
+// so its changes are committed independently of the lock.
+// If the lock is held by another transaction fn isn't run, and ErrJobLocked is returned.
+func (store *SQLStore) LockJobTx(ctx context.Context, jobName string, fn func(ctx context.Context) error) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		locked, err := q.TryJobLock(ctx, jobName)
+		if err != nil {
+			return err
+		}
+
+		if !locked {
+			return ErrJobLocked
+		}
+
+		return fn(ctx)
+	})
+}
@@ -0,0 +1,230 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: job_run.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createJobRun = `-- name: CreateJobRun :one
+INSERT INTO job_runs (
+  job_name, trigger, scheduled_at
+) VALUES (
+  $1, $2, $3
+)
+RETURNING run_id, job_name, trigger, status, scheduled_at, started_at, finished_at, error
+`
+
+type CreateJobRunParams struct {
+	JobName     string    `json:"job_name"`
+	Trigger     string    `json:"trigger"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+func (q *Queries) CreateJobRun(ctx context.Context, arg CreateJobRunParams) (JobRun, error) {
+	row := q.db.QueryRowContext(ctx, createJobRun, arg.JobName, arg.Trigger, arg.ScheduledAt)
+	var i JobRun
+	err := row.Scan(
+		&i.RunID,
+		&i.JobName,
+		&i.Trigger,
+		&i.Status,
+		&i.ScheduledAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.Error,
+	)
+	return i, err
+}
+
+const deleteJobRunsBefore = `-- name: DeleteJobRunsBefore :execrows
+DELETE FROM job_runs
+WHERE started_at < $1 AND status <> 'running'
+`
+
+// DeleteJobRunsBefore deletes the finished runs that started before a specific time.
+func (q *Queries) DeleteJobRunsBefore(ctx context.Context, startedAt time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteJobRunsBefore, startedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const finishJobRun = `-- name: FinishJobRun :one
+UPDATE job_runs
+  set   status = $2,
+        finished_at = now(),
+        error = $3
+WHERE run_id = $1
+RETURNING run_id, job_name, trigger, status, scheduled_at, started_at, finished_at, error
+`
+
+type FinishJobRunParams struct {
+	RunID  int64          `json:"run_id"`
+	Status string         `json:"status"`
+	Error  sql.NullString `json:"error"`
+}
+
+func (q *Queries) FinishJobRun(ctx context.Context, arg FinishJobRunParams) (JobRun, error) {
+	row := q.db.QueryRowContext(ctx, finishJobRun, arg.RunID, arg.Status, arg.Error)
+	var i JobRun
+	err := row.Scan(
+		&i.RunID,
+		&i.JobName,
+		&i.Trigger,
+		&i.Status,
+		&i.ScheduledAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.Error,
+	)
+	return i, err
+}
+
+const getJobRun = `-- name: GetJobRun :one
+SELECT run_id, job_name, trigger, status, scheduled_at, started_at, finished_at, error FROM job_runs
+WHERE run_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetJobRun(ctx context.Context, runID int64) (JobRun, error) {
+	row := q.db.QueryRowContext(ctx, getJobRun, runID)
+	var i JobRun
+	err := row.Scan(
+		&i.RunID,
+		&i.JobName,
+		&i.Trigger,
+		&i.Status,
+		&i.ScheduledAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.Error,
+	)
+	return i, err
+}
+
+const getLastScheduledJobRun = `-- name: GetLastScheduledJobRun :one
+SELECT run_id, job_name, trigger, status, scheduled_at, started_at, finished_at, error FROM job_runs
+WHERE job_name = $1 AND trigger = 'schedule'
+ORDER BY scheduled_at DESC
+LIMIT 1
+`
+
+// GetLastScheduledJobRun returns the scheduled run of a job that was due last.
+func (q *Queries) GetLastScheduledJobRun(ctx context.Context, jobName string) (JobRun, error) {
+	row := q.db.QueryRowContext(ctx, getLastScheduledJobRun, jobName)
+	var i JobRun
+	err := row.Scan(
+		&i.RunID,
+		&i.JobName,
+		&i.Trigger,
+		&i.Status,
+		&i.ScheduledAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.Error,
+	)
+	return i, err
+}
+
+const getLatestJobRuns = `-- name: GetLatestJobRuns :many
+SELECT DISTINCT ON (job_name) run_id, job_name, trigger, status, scheduled_at, started_at, finished_at, error FROM job_runs
+ORDER BY job_name, started_at DESC
+`
+
+// GetLatestJobRuns returns the latest run of every job.
+func (q *Queries) GetLatestJobRuns(ctx context.Context) ([]JobRun, error) {
+	rows, err := q.db.QueryContext(ctx, getLatestJobRuns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []JobRun{}
+	for rows.Next() {
+		var i JobRun
+		if err := rows.Scan(
+			&i.RunID,
+			&i.JobName,
+			&i.Trigger,
+			&i.Status,
+			&i.ScheduledAt,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.Error,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobRuns = `-- name: ListJobRuns :many
+SELECT run_id, job_name, trigger, status, scheduled_at, started_at, finished_at, error FROM job_runs
+WHERE $1::varchar IS NULL OR job_name = $1
+ORDER BY run_id DESC
+LIMIT $3
+OFFSET $2
+`
+
+type ListJobRunsParams struct {
+	JobName sql.NullString `json:"job_name"`
+	Offset  int32          `json:"offset"`
+	Limit   int32          `json:"limit"`
+}
+
+func (q *Queries) ListJobRuns(ctx context.Context, arg ListJobRunsParams) ([]JobRun, error) {
+	rows, err := q.db.QueryContext(ctx, listJobRuns, arg.JobName, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []JobRun{}
+	for rows.Next() {
+		var i JobRun
+		if err := rows.Scan(
+			&i.RunID,
+			&i.JobName,
+			&i.Trigger,
+			&i.Status,
+			&i.ScheduledAt,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.Error,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const tryJobLock = `-- name: TryJobLock :one
+SELECT pg_try_advisory_xact_lock(hashtext($1::text))::boolean AS locked
+`
+
+// TryJobLock tries to take the advisory lock of a job, without waiting for it.
+// The lock is held until the end of the transaction, so it must be called within a transaction.
+func (q *Queries) TryJobLock(ctx context.Context, jobName string) (bool, error) {
+	row := q.db.QueryRowContext(ctx, tryJobLock, jobName)
+	var locked bool
+	err := row.Scan(&locked)
+	return locked, err
+}
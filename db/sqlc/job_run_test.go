@@ -0,0 +1,199 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomJobRun adds a new running run of a job to the database.
+func createRandomJobRun(t *testing.T, jobName, trigger string, scheduledAt time.Time) JobRun {
+	arg := CreateJobRunParams{
+		JobName:     jobName,
+		Trigger:     trigger,
+		ScheduledAt: scheduledAt,
+	}
+
+	run, err := testQueries.CreateJobRun(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, run.RunID)
+
+	require.Equal(t, arg.JobName, run.JobName)
+	require.Equal(t, arg.Trigger, run.Trigger)
+	require.Equal(t, JobRunStatusRunning, run.Status)
+	require.WithinDuration(t, arg.ScheduledAt, run.ScheduledAt, time.Second)
+	require.NotZero(t, run.StartedAt)
+	require.False(t, run.FinishedAt.Valid)
+	require.False(t, run.Error.Valid)
+
+	return run
+}
+
+func TestCreateJobRun(t *testing.T) {
+	createRandomJobRun(t, util.RandomName(), JobTriggerSchedule, time.Now())
+}
+
+func TestFinishJobRun(t *testing.T) {
+	run := createRandomJobRun(t, util.RandomName(), JobTriggerManual, time.Now())
+
+	arg := FinishJobRunParams{
+		RunID:  run.RunID,
+		Status: JobRunStatusFailed,
+		Error:  sql.NullString{String: "job failed", Valid: true},
+	}
+
+	finished, err := testQueries.FinishJobRun(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, JobRunStatusFailed, finished.Status)
+	require.Equal(t, arg.Error, finished.Error)
+	require.True(t, finished.FinishedAt.Valid)
+
+	run, err = testQueries.GetJobRun(context.Background(), run.RunID)
+	require.NoError(t, err)
+	require.Equal(t, finished, run)
+}
+
+func TestGetLastScheduledJobRun(t *testing.T) {
+	name := util.RandomName()
+	now := time.Now().UTC()
+
+	_, err := testQueries.GetLastScheduledJobRun(context.Background(), name)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	createRandomJobRun(t, name, JobTriggerSchedule, now.Add(-time.Hour))
+	last := createRandomJobRun(t, name, JobTriggerSchedule, now)
+	createRandomJobRun(t, name, JobTriggerManual, now.Add(time.Minute))
+
+	run, err := testQueries.GetLastScheduledJobRun(context.Background(), name)
+	require.NoError(t, err)
+	require.Equal(t, last.RunID, run.RunID)
+}
+
+func TestGetLatestJobRuns(t *testing.T) {
+	name1 := util.RandomName()
+	name2 := util.RandomName()
+
+	createRandomJobRun(t, name1, JobTriggerSchedule, time.Now())
+	latest1 := createRandomJobRun(t, name1, JobTriggerManual, time.Now())
+	latest2 := createRandomJobRun(t, name2, JobTriggerSchedule, time.Now())
+
+	runs, err := testQueries.GetLatestJobRuns(context.Background())
+	require.NoError(t, err)
+
+	latest := make(map[string]int64)
+	for _, run := range runs {
+		_, ok := latest[run.JobName]
+		require.False(t, ok, "a single run per job is returned")
+		latest[run.JobName] = run.RunID
+	}
+
+	require.Equal(t, latest1.RunID, latest[name1])
+	require.Equal(t, latest2.RunID, latest[name2])
+}
+
+func TestListJobRuns(t *testing.T) {
+	name := util.RandomName()
+	for i := 0; i < 6; i++ {
+		createRandomJobRun(t, name, JobTriggerSchedule, time.Now())
+	}
+
+	arg := ListJobRunsParams{
+		JobName: sql.NullString{String: name, Valid: true},
+		Limit:   5,
+		Offset:  1,
+	}
+
+	runs, err := testQueries.ListJobRuns(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, runs, 5)
+
+	for i, run := range runs {
+		require.Equal(t, name, run.JobName)
+		if i > 0 {
+			require.Less(t, run.RunID, runs[i-1].RunID)
+		}
+	}
+}
+
+func TestDeleteJobRunsBefore(t *testing.T) {
+	running := createRandomJobRun(t, util.RandomName(), JobTriggerSchedule, time.Now())
+	finished := createRandomJobRun(t, util.RandomName(), JobTriggerSchedule, time.Now())
+
+	_, err := testQueries.FinishJobRun(context.Background(), FinishJobRunParams{
+		RunID:  finished.RunID,
+		Status: JobRunStatusSucceeded,
+	})
+	require.NoError(t, err)
+
+	deleted, err := testQueries.DeleteJobRunsBefore(context.Background(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+
+	// running runs are kept
+	_, err = testQueries.GetJobRun(context.Background(), running.RunID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetJobRun(context.Background(), finished.RunID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestLockJobTx(t *testing.T) {
+	store := NewStore(testDB)
+	name := util.RandomName()
+
+	locked := make(chan struct{})
+	release := make(chan struct{})
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- store.LockJobTx(context.Background(), name, func(ctx context.Context) error {
+			close(locked)
+			<-release
+			return nil
+		})
+	}()
+
+	// the test fails fast if the lock can't be taken, instead of waiting for it
+	select {
+	case <-locked:
+	case err := <-errs:
+		require.FailNow(t, "job wasn't locked", "error: %v", err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for the job lock")
+	}
+
+	// the job can't run while the lock is held
+	var ran bool
+	err := store.LockJobTx(context.Background(), name, func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	require.ErrorIs(t, err, ErrJobLocked)
+	require.False(t, ran)
+
+	// other jobs aren't locked
+	err = store.LockJobTx(context.Background(), util.RandomName(), func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	require.NoError(t, err)
+	require.True(t, ran)
+
+	// the lock is released when the transaction ends
+	close(release)
+	select {
+	case err = <-errs:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for the job lock to be released")
+	}
+
+	err = store.LockJobTx(context.Background(), name, func(ctx context.Context) error {
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// Lead statuses.
+const (
+	LeadStatusOpen      = "open"
+	LeadStatusConverted = "converted"
+	LeadStatusLost      = "lost"
+)
+
+// ErrLeadConverted is returned when a lead that was already converted into a student is converted.
+var ErrLeadConverted = errors.New("lead is already converted")
+
+// ConvertLeadTxParams contains the input parameters of the ConvertLeadTx function.
+// CollegeID and HourlyFee are set on the new student.
+type ConvertLeadTxParams struct {
+	LeadID    int64           `json:"lead_id"`
+	CollegeID sql.NullInt64   `json:"college_id"`
+	HourlyFee sql.NullFloat64 `json:"hourly_fee"`
+}
+
+// ConvertedLead is a converted lead and the student created for it.
+type ConvertedLead struct {
+	Lead    Lead    `json:"lead"`
+	Student Student `json:"student"`
+}
+
+// ConvertLeadTx converts an open or lost lead into a new student, with the contact details, funnel and notes of the lead,
+// and posts the student.created webhook event. The tasks of the lead are kept.
+// It returns sql.ErrNoRows if the lead doesn't exist and ErrLeadConverted if the lead was already converted.
+func (store *SQLStore) ConvertLeadTx(ctx context.Context, arg ConvertLeadTxParams) (ConvertedLead, error) {
+	var result ConvertedLead
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		lead, err := q.GetLeadForUpdate(ctx, arg.LeadID)
+		if err != nil {
+			return err
+		}
+
+		if lead.Status == LeadStatusConverted {
+			return ErrLeadConverted
+		}
+
+		result.Student, err = q.CreateStudent(ctx, CreateStudentParams{
+			FirstName:   lead.FirstName,
+			LastName:    lead.LastName,
+			Email:       lead.Email,
+			PhoneNumber: lead.PhoneNumber,
+			CollegeID:   arg.CollegeID,
+			FunnelID:    lead.FunnelID,
+			HourlyFee:   arg.HourlyFee,
+			Notes:       lead.Notes,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.enqueueWebhookEvent(ctx, WebhookEventStudentCreated, result.Student)
+		if err != nil {
+			return err
+		}
+
+		result.Lead, err = q.ConvertLead(ctx, ConvertLeadParams{
+			LeadID:    lead.LeadID,
+			StudentID: sql.NullInt64{Int64: result.Student.StudentID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,258 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: lead.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const convertLead = `-- name: ConvertLead :one
+UPDATE leads
+  set   status = 'converted',
+        student_id = $2,
+        converted_at = now()
+WHERE lead_id = $1
+RETURNING lead_id, first_name, last_name, email, phone_number, funnel_id, stage_id, status, student_id, notes, created_at, converted_at
+`
+
+type ConvertLeadParams struct {
+	LeadID    int64         `json:"lead_id"`
+	StudentID sql.NullInt64 `json:"student_id"`
+}
+
+func (q *Queries) ConvertLead(ctx context.Context, arg ConvertLeadParams) (Lead, error) {
+	row := q.db.QueryRowContext(ctx, convertLead, arg.LeadID, arg.StudentID)
+	var i Lead
+	err := row.Scan(
+		&i.LeadID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.FunnelID,
+		&i.StageID,
+		&i.Status,
+		&i.StudentID,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.ConvertedAt,
+	)
+	return i, err
+}
+
+const createLead = `-- name: CreateLead :one
+INSERT INTO leads (
+  first_name, last_name, email, phone_number, funnel_id, stage_id, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING lead_id, first_name, last_name, email, phone_number, funnel_id, stage_id, status, student_id, notes, created_at, converted_at
+`
+
+type CreateLeadParams struct {
+	FirstName   string         `json:"first_name"`
+	LastName    string         `json:"last_name"`
+	Email       sql.NullString `json:"email"`
+	PhoneNumber sql.NullString `json:"phone_number"`
+	FunnelID    sql.NullInt64  `json:"funnel_id"`
+	StageID     int64          `json:"stage_id"`
+	Notes       sql.NullString `json:"notes"`
+}
+
+func (q *Queries) CreateLead(ctx context.Context, arg CreateLeadParams) (Lead, error) {
+	row := q.db.QueryRowContext(ctx, createLead,
+		arg.FirstName,
+		arg.LastName,
+		arg.Email,
+		arg.PhoneNumber,
+		arg.FunnelID,
+		arg.StageID,
+		arg.Notes,
+	)
+	var i Lead
+	err := row.Scan(
+		&i.LeadID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.FunnelID,
+		&i.StageID,
+		&i.Status,
+		&i.StudentID,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.ConvertedAt,
+	)
+	return i, err
+}
+
+const deleteLead = `-- name: DeleteLead :execrows
+DELETE FROM leads
+WHERE lead_id = $1
+`
+
+func (q *Queries) DeleteLead(ctx context.Context, leadID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteLead, leadID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getLead = `-- name: GetLead :one
+SELECT lead_id, first_name, last_name, email, phone_number, funnel_id, stage_id, status, student_id, notes, created_at, converted_at FROM leads
+WHERE lead_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetLead(ctx context.Context, leadID int64) (Lead, error) {
+	row := q.db.QueryRowContext(ctx, getLead, leadID)
+	var i Lead
+	err := row.Scan(
+		&i.LeadID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.FunnelID,
+		&i.StageID,
+		&i.Status,
+		&i.StudentID,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.ConvertedAt,
+	)
+	return i, err
+}
+
+const getLeadForUpdate = `-- name: GetLeadForUpdate :one
+SELECT lead_id, first_name, last_name, email, phone_number, funnel_id, stage_id, status, student_id, notes, created_at, converted_at FROM leads
+WHERE lead_id = $1 LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetLeadForUpdate(ctx context.Context, leadID int64) (Lead, error) {
+	row := q.db.QueryRowContext(ctx, getLeadForUpdate, leadID)
+	var i Lead
+	err := row.Scan(
+		&i.LeadID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.FunnelID,
+		&i.StageID,
+		&i.Status,
+		&i.StudentID,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.ConvertedAt,
+	)
+	return i, err
+}
+
+const listLeads = `-- name: ListLeads :many
+SELECT lead_id, first_name, last_name, email, phone_number, funnel_id, stage_id, status, student_id, notes, created_at, converted_at FROM leads
+WHERE ($1::bigint IS NULL OR funnel_id = $1)
+  AND ($2::bigint IS NULL OR stage_id = $2)
+  AND ($3::varchar IS NULL OR status = $3)
+ORDER BY created_at DESC, lead_id DESC
+LIMIT $5
+OFFSET $4
+`
+
+type ListLeadsParams struct {
+	FunnelID sql.NullInt64  `json:"funnel_id"`
+	StageID  sql.NullInt64  `json:"stage_id"`
+	Status   sql.NullString `json:"status"`
+	Offset   int32          `json:"offset"`
+	Limit    int32          `json:"limit"`
+}
+
+// ListLeads lists the leads, newest first, optionally of a single funnel or stage, or with a single status.
+func (q *Queries) ListLeads(ctx context.Context, arg ListLeadsParams) ([]Lead, error) {
+	rows, err := q.db.QueryContext(ctx, listLeads,
+		arg.FunnelID,
+		arg.StageID,
+		arg.Status,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Lead{}
+	for rows.Next() {
+		var i Lead
+		if err := rows.Scan(
+			&i.LeadID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.PhoneNumber,
+			&i.FunnelID,
+			&i.StageID,
+			&i.Status,
+			&i.StudentID,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.ConvertedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateLead = `-- name: UpdateLead :exec
+UPDATE leads
+  set   first_name = $2,
+        last_name = $3,
+        email = $4,
+        phone_number = $5,
+        funnel_id = $6,
+        stage_id = $7,
+        notes = $8,
+        status = CASE WHEN status = 'converted' THEN status ELSE $9 END
+WHERE lead_id = $1
+`
+
+type UpdateLeadParams struct {
+	LeadID      int64          `json:"lead_id"`
+	FirstName   string         `json:"first_name"`
+	LastName    string         `json:"last_name"`
+	Email       sql.NullString `json:"email"`
+	PhoneNumber sql.NullString `json:"phone_number"`
+	FunnelID    sql.NullInt64  `json:"funnel_id"`
+	StageID     int64          `json:"stage_id"`
+	Notes       sql.NullString `json:"notes"`
+	Status      string         `json:"status"`
+}
+
+// UpdateLead updates a lead. The status of a converted lead is kept, as a lead is converted only by ConvertLeadTx.
+func (q *Queries) UpdateLead(ctx context.Context, arg UpdateLeadParams) error {
+	_, err := q.db.ExecContext(ctx, updateLead,
+		arg.LeadID,
+		arg.FirstName,
+		arg.LastName,
+		arg.Email,
+		arg.PhoneNumber,
+		arg.FunnelID,
+		arg.StageID,
+		arg.Notes,
+		arg.Status,
+	)
+	return err
+}
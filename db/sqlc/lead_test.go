@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomLead adds a new random open lead of a new funnel to a new pipeline stage.
+func createRandomLead(t *testing.T) Lead {
+	arg := CreateLeadParams{
+		FirstName:   util.RandomName(),
+		LastName:    util.RandomName(),
+		Email:       sql.NullString{String: util.RandomEmail(), Valid: true},
+		PhoneNumber: sql.NullString{String: util.RandomPhoneNumber(), Valid: true},
+		FunnelID:    sql.NullInt64{Int64: createRandomFunnel(t).FunnelID, Valid: true},
+		StageID:     createRandomPipelineStage(t).StageID,
+		Notes:       sql.NullString{String: util.RandomNote(), Valid: true},
+	}
+
+	lead, err := testQueries.CreateLead(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, lead.LeadID)
+	require.Equal(t, arg.FirstName, lead.FirstName)
+	require.Equal(t, arg.LastName, lead.LastName)
+	require.Equal(t, arg.Email, lead.Email)
+	require.Equal(t, arg.PhoneNumber, lead.PhoneNumber)
+	require.Equal(t, arg.FunnelID, lead.FunnelID)
+	require.Equal(t, arg.StageID, lead.StageID)
+	require.Equal(t, arg.Notes, lead.Notes)
+	require.Equal(t, LeadStatusOpen, lead.Status)
+	require.False(t, lead.StudentID.Valid)
+	require.False(t, lead.ConvertedAt.Valid)
+
+	return lead
+}
+
+func TestLeads(t *testing.T) {
+	lead := createRandomLead(t)
+	stage := createRandomPipelineStage(t)
+
+	arg := UpdateLeadParams{
+		LeadID:      lead.LeadID,
+		FirstName:   lead.FirstName,
+		LastName:    lead.LastName,
+		Email:       lead.Email,
+		PhoneNumber: lead.PhoneNumber,
+		FunnelID:    lead.FunnelID,
+		StageID:     stage.StageID,
+		Notes:       lead.Notes,
+		Status:      LeadStatusLost,
+	}
+	err := testQueries.UpdateLead(context.Background(), arg)
+	require.NoError(t, err)
+
+	leads, err := testQueries.ListLeads(context.Background(), ListLeadsParams{
+		StageID: sql.NullInt64{Int64: stage.StageID, Valid: true},
+		Status:  sql.NullString{String: LeadStatusLost, Valid: true},
+		Limit:   5,
+		Offset:  0,
+	})
+	require.NoError(t, err)
+	require.Len(t, leads, 1)
+	require.Equal(t, lead.LeadID, leads[0].LeadID)
+
+	rows, err := testQueries.DeleteLead(context.Background(), lead.LeadID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = testQueries.GetLead(context.Background(), lead.LeadID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestConvertLeadTx(t *testing.T) {
+	store := NewStore(testDB)
+	subscription := createRandomWebhookSubscription(t, WebhookEventStudentCreated)
+	lead := createRandomLead(t)
+
+	arg := ConvertLeadTxParams{
+		LeadID:    lead.LeadID,
+		CollegeID: sql.NullInt64{Int64: createRandomCollege(t).CollegeID, Valid: true},
+		HourlyFee: sql.NullFloat64{Float64: util.RandomHourlyFee(), Valid: true},
+	}
+
+	result, err := store.ConvertLeadTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	// the student carries the contact details, funnel and notes of the lead
+	student := result.Student
+	require.NotZero(t, student.StudentID)
+	require.Equal(t, lead.FirstName, student.FirstName)
+	require.Equal(t, lead.LastName, student.LastName)
+	require.Equal(t, lead.Email, student.Email)
+	require.Equal(t, lead.PhoneNumber, student.PhoneNumber)
+	require.Equal(t, lead.FunnelID, student.FunnelID)
+	require.Equal(t, lead.Notes, student.Notes)
+	require.Equal(t, arg.CollegeID, student.CollegeID)
+	require.Equal(t, arg.HourlyFee, student.HourlyFee)
+
+	require.Equal(t, LeadStatusConverted, result.Lead.Status)
+	require.Equal(t, sql.NullInt64{Int64: student.StudentID, Valid: true}, result.Lead.StudentID)
+	require.True(t, result.Lead.ConvertedAt.Valid)
+
+	deliveries := listSubscriptionDeliveries(t, subscription)
+	require.Len(t, deliveries, 1)
+
+	var createdStudent Student
+	requireWebhookPayload(t, deliveries[0], WebhookEventStudentCreated, &createdStudent)
+	require.Equal(t, student.StudentID, createdStudent.StudentID)
+
+	// a converted lead keeps its status when updated and can't be converted again
+	err = testQueries.UpdateLead(context.Background(), UpdateLeadParams{
+		LeadID:    lead.LeadID,
+		FirstName: lead.FirstName,
+		LastName:  lead.LastName,
+		StageID:   lead.StageID,
+		Status:    LeadStatusOpen,
+	})
+	require.NoError(t, err)
+
+	updated, err := testQueries.GetLead(context.Background(), lead.LeadID)
+	require.NoError(t, err)
+	require.Equal(t, LeadStatusConverted, updated.Status)
+
+	_, err = store.ConvertLeadTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrLeadConverted)
+
+	_, err = store.ConvertLeadTx(context.Background(), ConvertLeadTxParams{LeadID: -1})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
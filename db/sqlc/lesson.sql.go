@@ -13,11 +13,11 @@ import (
 
 const createLesson = `-- name: CreateLesson :one
 INSERT INTO lessons (
-  lesson_datetime, duration, location_id, subject_id, notes
+  lesson_datetime, duration, location_id, subject_id, notes, pricing_plan_id
 ) VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6
 )
-RETURNING lesson_id, lesson_datetime, duration, location_id, subject_id, notes
+RETURNING lesson_id, lesson_datetime, duration, location_id, subject_id, notes, pricing_plan_id
 `
 
 type CreateLessonParams struct {
@@ -26,6 +26,7 @@ type CreateLessonParams struct {
 	LocationID     int64          `json:"location_id"`
 	SubjectID      int64          `json:"subject_id"`
 	Notes          sql.NullString `json:"notes"`
+	PricingPlanID  sql.NullInt64  `json:"pricing_plan_id"`
 }
 
 func (q *Queries) CreateLesson(ctx context.Context, arg CreateLessonParams) (Lesson, error) {
@@ -35,6 +36,7 @@ func (q *Queries) CreateLesson(ctx context.Context, arg CreateLessonParams) (Les
 		arg.LocationID,
 		arg.SubjectID,
 		arg.Notes,
+		arg.PricingPlanID,
 	)
 	var i Lesson
 	err := row.Scan(
@@ -44,6 +46,7 @@ func (q *Queries) CreateLesson(ctx context.Context, arg CreateLessonParams) (Les
 		&i.LocationID,
 		&i.SubjectID,
 		&i.Notes,
+		&i.PricingPlanID,
 	)
 	return i, err
 }
@@ -59,7 +62,7 @@ func (q *Queries) DeleteLesson(ctx context.Context, lessonID int64) error {
 }
 
 const getLesson = `-- name: GetLesson :one
-SELECT lesson_id, lesson_datetime, duration, location_id, subject_id, notes FROM lessons
+SELECT lesson_id, lesson_datetime, duration, location_id, subject_id, notes, pricing_plan_id FROM lessons
 WHERE lesson_id = $1 LIMIT 1
 `
 
@@ -73,12 +76,13 @@ func (q *Queries) GetLesson(ctx context.Context, lessonID int64) (Lesson, error)
 		&i.LocationID,
 		&i.SubjectID,
 		&i.Notes,
+		&i.PricingPlanID,
 	)
 	return i, err
 }
 
 const listLessons = `-- name: ListLessons :many
-SELECT lesson_id, lesson_datetime, duration, location_id, subject_id, notes FROM lessons
+SELECT lesson_id, lesson_datetime, duration, location_id, subject_id, notes, pricing_plan_id FROM lessons
 ORDER BY lesson_datetime
 LIMIT $1
 OFFSET $2
@@ -105,6 +109,7 @@ func (q *Queries) ListLessons(ctx context.Context, arg ListLessonsParams) ([]Les
 			&i.LocationID,
 			&i.SubjectID,
 			&i.Notes,
+			&i.PricingPlanID,
 		); err != nil {
 			return nil, err
 		}
@@ -119,6 +124,62 @@ func (q *Queries) ListLessons(ctx context.Context, arg ListLessonsParams) ([]Les
 	return items, nil
 }
 
+const listLessonsBetween = `-- name: ListLessonsBetween :many
+SELECT lesson_id, lesson_datetime, duration, location_id, subject_id, notes, pricing_plan_id FROM lessons
+WHERE lesson_datetime < $1
+  AND lesson_datetime + duration * interval '1 minute' > $2
+ORDER BY lesson_datetime, lesson_id
+`
+
+type ListLessonsBetweenParams struct {
+	EndDatetime   time.Time `json:"end_datetime"`
+	StartDatetime time.Time `json:"start_datetime"`
+}
+
+// ListLessonsBetween lists the lessons overlapping a period, by datetime.
+func (q *Queries) ListLessonsBetween(ctx context.Context, arg ListLessonsBetweenParams) ([]Lesson, error) {
+	rows, err := q.db.QueryContext(ctx, listLessonsBetween, arg.EndDatetime, arg.StartDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Lesson{}
+	for rows.Next() {
+		var i Lesson
+		if err := rows.Scan(
+			&i.LessonID,
+			&i.LessonDatetime,
+			&i.Duration,
+			&i.LocationID,
+			&i.SubjectID,
+			&i.Notes,
+			&i.PricingPlanID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockLessonSchedule = `-- name: LockLessonSchedule :exec
+SELECT pg_advisory_xact_lock(hashtext('lesson_schedule'))
+`
+
+// LockLessonSchedule waits for the advisory lock of the lesson schedule, so lessons are created one at a time
+// and a lesson checked not to overlap others isn't overlapped by a lesson created concurrently.
+// The lock is held until the end of the transaction, so it must be called within a transaction.
+func (q *Queries) LockLessonSchedule(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, lockLessonSchedule)
+	return err
+}
+
 const updateLesson = `-- name: UpdateLesson :exec
 UPDATE lessons
   set   lesson_datetime = $2, 
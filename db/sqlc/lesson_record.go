@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+)
+
+// Homework statuses of a lesson record. The status of the homework assigned in a lesson
+// is usually updated at the next lesson of the student.
+const (
+	HomeworkStatusNone      = "none"
+	HomeworkStatusAssigned  = "assigned"
+	HomeworkStatusPartial   = "partial"
+	HomeworkStatusCompleted = "completed"
+	HomeworkStatusMissed    = "missed"
+)
+
+// UpsertLessonRecordTx creates or updates the lesson record of a student of a lesson.
+// If the homework status is empty, it is set to assigned if there is homework, and to none if not.
+// It returns sql.ErrNoRows if the student isn't in the lesson.
+func (store *SQLStore) UpsertLessonRecordTx(ctx context.Context, arg UpsertLessonRecordParams) (LessonRecord, error) {
+	var result LessonRecord
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetLessonAttendance(ctx, GetLessonAttendanceParams{
+			LessonID:  arg.LessonID,
+			StudentID: arg.StudentID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.HomeworkStatus == "" {
+			arg.HomeworkStatus = HomeworkStatusNone
+			if arg.Homework.Valid && arg.Homework.String != "" {
+				arg.HomeworkStatus = HomeworkStatusAssigned
+			}
+		}
+
+		result, err = q.UpsertLessonRecord(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,240 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: lesson_record.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const deleteLessonRecord = `-- name: DeleteLessonRecord :exec
+DELETE FROM lesson_records
+WHERE lesson_id = $1 AND student_id = $2
+`
+
+type DeleteLessonRecordParams struct {
+	LessonID  int64 `json:"lesson_id"`
+	StudentID int64 `json:"student_id"`
+}
+
+func (q *Queries) DeleteLessonRecord(ctx context.Context, arg DeleteLessonRecordParams) error {
+	_, err := q.db.ExecContext(ctx, deleteLessonRecord, arg.LessonID, arg.StudentID)
+	return err
+}
+
+const deleteLessonRecordsByLesson = `-- name: DeleteLessonRecordsByLesson :exec
+DELETE FROM lesson_records
+WHERE lesson_id = $1
+`
+
+func (q *Queries) DeleteLessonRecordsByLesson(ctx context.Context, lessonID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteLessonRecordsByLesson, lessonID)
+	return err
+}
+
+const getLessonRecord = `-- name: GetLessonRecord :one
+SELECT lesson_id, student_id, topics, homework, homework_status, rating, notes, created_at, updated_at FROM lesson_records
+WHERE lesson_id = $1 AND student_id = $2 LIMIT 1
+`
+
+type GetLessonRecordParams struct {
+	LessonID  int64 `json:"lesson_id"`
+	StudentID int64 `json:"student_id"`
+}
+
+func (q *Queries) GetLessonRecord(ctx context.Context, arg GetLessonRecordParams) (LessonRecord, error) {
+	row := q.db.QueryRowContext(ctx, getLessonRecord, arg.LessonID, arg.StudentID)
+	var i LessonRecord
+	err := row.Scan(
+		&i.LessonID,
+		&i.StudentID,
+		&i.Topics,
+		&i.Homework,
+		&i.HomeworkStatus,
+		&i.Rating,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getLessonRecordsByLesson = `-- name: GetLessonRecordsByLesson :many
+SELECT lesson_id, student_id, topics, homework, homework_status, rating, notes, created_at, updated_at FROM lesson_records
+WHERE lesson_id = $1
+ORDER BY student_id
+`
+
+func (q *Queries) GetLessonRecordsByLesson(ctx context.Context, lessonID int64) ([]LessonRecord, error) {
+	rows, err := q.db.QueryContext(ctx, getLessonRecordsByLesson, lessonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LessonRecord{}
+	for rows.Next() {
+		var i LessonRecord
+		if err := rows.Scan(
+			&i.LessonID,
+			&i.StudentID,
+			&i.Topics,
+			&i.Homework,
+			&i.HomeworkStatus,
+			&i.Rating,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStudentProgress = `-- name: GetStudentProgress :many
+SELECT l.lesson_id, l.lesson_datetime, l.duration, l.subject_id, s.name AS subject_name,
+       a.status AS attendance_status,
+       r.topics, r.homework, r.homework_status, r.rating, r.notes, r.updated_at
+FROM lesson_attendance a
+JOIN lessons l ON l.lesson_id = a.lesson_id
+JOIN lesson_subjects s ON s.subject_id = l.subject_id
+LEFT JOIN lesson_records r ON r.lesson_id = a.lesson_id AND r.student_id = a.student_id
+WHERE a.student_id = $1
+  AND ($2::bigint IS NULL OR l.subject_id = $2)
+  AND ($3::timestamptz IS NULL OR l.lesson_datetime >= $3)
+  AND ($4::timestamptz IS NULL OR l.lesson_datetime < $4)
+ORDER BY l.lesson_datetime DESC
+LIMIT $6
+OFFSET $5
+`
+
+type GetStudentProgressParams struct {
+	StudentID     int64         `json:"student_id"`
+	SubjectID     sql.NullInt64 `json:"subject_id"`
+	StartDatetime sql.NullTime  `json:"start_datetime"`
+	EndDatetime   sql.NullTime  `json:"end_datetime"`
+	Offset        int32         `json:"offset"`
+	Limit         int32         `json:"limit"`
+}
+
+type GetStudentProgressRow struct {
+	LessonID         int64          `json:"lesson_id"`
+	LessonDatetime   time.Time      `json:"lesson_datetime"`
+	Duration         int64          `json:"duration"`
+	SubjectID        int64          `json:"subject_id"`
+	SubjectName      string         `json:"subject_name"`
+	AttendanceStatus string         `json:"attendance_status"`
+	Topics           sql.NullString `json:"topics"`
+	Homework         sql.NullString `json:"homework"`
+	HomeworkStatus   sql.NullString `json:"homework_status"`
+	Rating           sql.NullInt32  `json:"rating"`
+	Notes            sql.NullString `json:"notes"`
+	UpdatedAt        sql.NullTime   `json:"updated_at"`
+}
+
+// GetStudentProgress returns the lessons of a student, latest first, with the attendance and lesson record of the student.
+// Lessons without a record are included, so the timeline shows every lesson of the student.
+func (q *Queries) GetStudentProgress(ctx context.Context, arg GetStudentProgressParams) ([]GetStudentProgressRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStudentProgress,
+		arg.StudentID,
+		arg.SubjectID,
+		arg.StartDatetime,
+		arg.EndDatetime,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStudentProgressRow{}
+	for rows.Next() {
+		var i GetStudentProgressRow
+		if err := rows.Scan(
+			&i.LessonID,
+			&i.LessonDatetime,
+			&i.Duration,
+			&i.SubjectID,
+			&i.SubjectName,
+			&i.AttendanceStatus,
+			&i.Topics,
+			&i.Homework,
+			&i.HomeworkStatus,
+			&i.Rating,
+			&i.Notes,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertLessonRecord = `-- name: UpsertLessonRecord :one
+INSERT INTO lesson_records (
+  lesson_id, student_id, topics, homework, homework_status, rating, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (lesson_id, student_id) DO UPDATE
+  set   topics = EXCLUDED.topics,
+        homework = EXCLUDED.homework,
+        homework_status = EXCLUDED.homework_status,
+        rating = EXCLUDED.rating,
+        notes = EXCLUDED.notes,
+        updated_at = now()
+RETURNING lesson_id, student_id, topics, homework, homework_status, rating, notes, created_at, updated_at
+`
+
+type UpsertLessonRecordParams struct {
+	LessonID       int64          `json:"lesson_id"`
+	StudentID      int64          `json:"student_id"`
+	Topics         sql.NullString `json:"topics"`
+	Homework       sql.NullString `json:"homework"`
+	HomeworkStatus string         `json:"homework_status"`
+	Rating         sql.NullInt32  `json:"rating"`
+	Notes          sql.NullString `json:"notes"`
+}
+
+func (q *Queries) UpsertLessonRecord(ctx context.Context, arg UpsertLessonRecordParams) (LessonRecord, error) {
+	row := q.db.QueryRowContext(ctx, upsertLessonRecord,
+		arg.LessonID,
+		arg.StudentID,
+		arg.Topics,
+		arg.Homework,
+		arg.HomeworkStatus,
+		arg.Rating,
+		arg.Notes,
+	)
+	var i LessonRecord
+	err := row.Scan(
+		&i.LessonID,
+		&i.StudentID,
+		&i.Topics,
+		&i.Homework,
+		&i.HomeworkStatus,
+		&i.Rating,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
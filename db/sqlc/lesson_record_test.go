@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertLessonRecordTx(t *testing.T) {
+	store := NewStore(testDB)
+	lesson := createRandomLessonWithInvoicesTx(t, 1)
+	studentID := lesson.Invoices[0].StudentID
+
+	arg := UpsertLessonRecordParams{
+		LessonID:  lesson.Lesson.LessonID,
+		StudentID: studentID,
+		Topics:    sql.NullString{String: util.RandomNote(), Valid: true},
+		Homework:  sql.NullString{String: util.RandomNote(), Valid: true},
+		Rating:    sql.NullInt32{Int32: 4, Valid: true},
+	}
+
+	// homework defaults to assigned
+	record1, err := store.UpsertLessonRecordTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Topics, record1.Topics)
+	require.Equal(t, arg.Homework, record1.Homework)
+	require.Equal(t, HomeworkStatusAssigned, record1.HomeworkStatus)
+	require.Equal(t, arg.Rating, record1.Rating)
+
+	// the homework status is updated at the next lesson
+	arg.HomeworkStatus = HomeworkStatusCompleted
+	record2, err := store.UpsertLessonRecordTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, HomeworkStatusCompleted, record2.HomeworkStatus)
+	require.Equal(t, record1.CreatedAt, record2.CreatedAt)
+
+	records, err := testQueries.GetLessonRecordsByLesson(context.Background(), lesson.Lesson.LessonID)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	// students not in the lesson have no records
+	arg.StudentID = createRandomStudent(t).StudentID
+	_, err = store.UpsertLessonRecordTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// the record is deleted with the student
+	_, err = store.RemoveLessonStudentTx(context.Background(), lesson.Lesson.LessonID, studentID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetLessonRecord(context.Background(), GetLessonRecordParams{
+		LessonID:  lesson.Lesson.LessonID,
+		StudentID: studentID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestGetStudentProgress(t *testing.T) {
+	store := NewStore(testDB)
+	lesson1 := createRandomLessonWithInvoicesTx(t, 1)
+	student := Student{StudentID: lesson1.Invoices[0].StudentID}
+
+	lesson2, err := store.AddLessonStudentTx(context.Background(), AddLessonStudentTxParams{
+		LessonID:  createRandomLessonWithInvoicesTx(t, 1).Lesson.LessonID,
+		StudentID: student.StudentID,
+		Free:      true,
+	})
+	require.NoError(t, err)
+
+	_, err = store.UpsertLessonRecordTx(context.Background(), UpsertLessonRecordParams{
+		LessonID:  lesson1.Lesson.LessonID,
+		StudentID: student.StudentID,
+		Topics:    sql.NullString{String: util.RandomNote(), Valid: true},
+	})
+	require.NoError(t, err)
+
+	progress, err := testQueries.GetStudentProgress(context.Background(), GetStudentProgressParams{
+		StudentID: student.StudentID,
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, progress, 2)
+
+	// lessons without a record are part of the timeline
+	for _, row := range progress {
+		require.Equal(t, AttendanceStatusAttended, row.AttendanceStatus)
+		switch row.LessonID {
+		case lesson1.Lesson.LessonID:
+			require.True(t, row.Topics.Valid)
+			require.Equal(t, sql.NullString{String: HomeworkStatusNone, Valid: true}, row.HomeworkStatus)
+		case lesson2.Lesson.LessonID:
+			require.False(t, row.HomeworkStatus.Valid)
+		default:
+			t.Fatalf("unexpected lesson %d", row.LessonID)
+		}
+	}
+
+	progress, err = testQueries.GetStudentProgress(context.Background(), GetStudentProgressParams{
+		StudentID: student.StudentID,
+		SubjectID: sql.NullInt64{Int64: lesson1.Lesson.SubjectID, Valid: true},
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, progress, 1)
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// Student lifecycle statuses, based on the frequency of the student lessons.
+const (
+	LifecycleStatusActive = "active"
+	LifecycleStatusAtRisk = "at-risk"
+	LifecycleStatusLapsed = "lapsed"
+)
+
+const week = 7 * 24 * time.Hour
+
+// LifecycleThresholds contains the rules used to assign a lifecycle status to students.
+// A student is lapsed if they had no lesson in the last LapsedWeeks weeks,
+// and at-risk if they had no lesson in the last AtRiskWeeks weeks,
+// or if their lessons frequency in the last RecentWeeks weeks dropped below half of their frequency
+// in the BaselineWeeks weeks before that.
+type LifecycleThresholds struct {
+	AtRiskWeeks   int `json:"at_risk_weeks"`
+	LapsedWeeks   int `json:"lapsed_weeks"`
+	RecentWeeks   int `json:"recent_weeks"`
+	BaselineWeeks int `json:"baseline_weeks"`
+}
+
+// DefaultLifecycleThresholds are the thresholds used when none are configured.
+var DefaultLifecycleThresholds = LifecycleThresholds{
+	AtRiskWeeks:   3,
+	LapsedWeeks:   8,
+	RecentWeeks:   4,
+	BaselineWeeks: 12,
+}
+
+// Params returns the GetStudentsLifecycle query parameters for the thresholds, as of a specific time.
+func (t LifecycleThresholds) Params(asOf time.Time) GetStudentsLifecycleParams {
+	recentSince := asOf.Add(-time.Duration(t.RecentWeeks) * week)
+
+	return GetStudentsLifecycleParams{
+		LapsedSince:   asOf.Add(-time.Duration(t.LapsedWeeks) * week),
+		AtRiskSince:   asOf.Add(-time.Duration(t.AtRiskWeeks) * week),
+		RecentSince:   recentSince,
+		BaselineSince: recentSince.Add(-time.Duration(t.BaselineWeeks) * week),
+		AsOf:          asOf,
+	}
+}
+
+// UpdateStudentsLifecycleTx classifies all students according to the thresholds as of a specific time,
+// and updates the lifecycle status of every student whose status has changed.
+// It returns the number of students updated.
+func (store *SQLStore) UpdateStudentsLifecycleTx(ctx context.Context, asOf time.Time, thresholds LifecycleThresholds) (int, error) {
+	var updated int
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		students, err := q.GetStudentsLifecycle(ctx, thresholds.Params(asOf))
+		if err != nil {
+			return err
+		}
+
+		for _, student := range students {
+			if student.ComputedStatus == student.LifecycleStatus {
+				continue
+			}
+
+			err = q.UpdateStudentLifecycleStatus(ctx, UpdateStudentLifecycleStatusParams{
+				StudentID:       student.StudentID,
+				LifecycleStatus: student.ComputedStatus,
+			})
+			if err != nil {
+				return err
+			}
+
+			updated++
+		}
+
+		return nil
+	})
+
+	return updated, err
+}
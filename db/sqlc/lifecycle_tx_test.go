@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomStudentLessons creates a new random student with an invoiced lesson at each of the lessons datetimes.
+func createRandomStudentLessons(t *testing.T, lessonsDatetimes ...time.Time) Student {
+	student := createRandomStudent(t)
+	location := createRandomLessonLocation(t)
+	subject := createRandomLessonSubject(t)
+
+	for _, lessonDatetime := range lessonsDatetimes {
+		lesson, err := testQueries.CreateLesson(context.Background(), CreateLessonParams{
+			LessonDatetime: lessonDatetime,
+			Duration:       util.RandomLessonDuration(),
+			LocationID:     location.LocationID,
+			SubjectID:      subject.SubjectID,
+			Notes:          sql.NullString{String: util.RandomNote(), Valid: true},
+		})
+		require.NoError(t, err)
+
+		_, err = testQueries.CreateInvoice(context.Background(), CreateInvoiceParams{
+			StudentID:       student.StudentID,
+			LessonID:        lesson.LessonID,
+			InvoiceDatetime: lesson.LessonDatetime,
+			HourlyFee:       student.HourlyFee.Float64,
+			Duration:        lesson.Duration,
+			Discount:        0,
+			Amount:          util.RandomInvoiceAmount(),
+		})
+		require.NoError(t, err)
+	}
+
+	return student
+}
+
+// weeklyLessons returns a lesson datetime for every week between fromWeek and toWeek (exclusive) weeks before asOf.
+func weeklyLessons(asOf time.Time, fromWeek, toWeek int) []time.Time {
+	var datetimes []time.Time
+	for i := fromWeek; i < toWeek; i++ {
+		datetimes = append(datetimes, asOf.Add(-time.Duration(i)*week-time.Hour))
+	}
+	return datetimes
+}
+
+// createRandomLifecycleStudents creates students expected to be active, at-risk and lapsed as of asOf,
+// according to DefaultLifecycleThresholds.
+func createRandomLifecycleStudents(t *testing.T, asOf time.Time) map[string]Student {
+	return map[string]Student{
+		LifecycleStatusActive: createRandomStudentLessons(t, weeklyLessons(asOf, 0, 16)...),
+		LifecycleStatusAtRisk: createRandomStudentLessons(t, append(weeklyLessons(asOf, 1, 2), weeklyLessons(asOf, 4, 16)...)...),
+		LifecycleStatusLapsed: createRandomStudentLessons(t, weeklyLessons(asOf, 10, 16)...),
+	}
+}
+
+func TestGetStudentsLifecycle(t *testing.T) {
+	asOf := time.Now().UTC().Add(time.Minute)
+	students := createRandomLifecycleStudents(t, asOf)
+
+	rows, err := testQueries.GetStudentsLifecycle(context.Background(), DefaultLifecycleThresholds.Params(asOf))
+	require.NoError(t, err)
+	require.NotEmpty(t, rows)
+
+	computed := make(map[int64]GetStudentsLifecycleRow)
+	for _, row := range rows {
+		computed[row.StudentID] = row
+	}
+
+	for status, student := range students {
+		row, ok := computed[student.StudentID]
+		require.True(t, ok)
+		require.Equal(t, status, row.ComputedStatus)
+		require.Equal(t, LifecycleStatusActive, row.LifecycleStatus)
+		require.True(t, row.LastLessonDatetime.Valid)
+	}
+
+	require.Equal(t, int64(4), computed[students[LifecycleStatusActive].StudentID].RecentLessonsCount)
+	require.Equal(t, int64(12), computed[students[LifecycleStatusActive].StudentID].BaselineLessonsCount)
+	require.Equal(t, int64(1), computed[students[LifecycleStatusAtRisk].StudentID].RecentLessonsCount)
+	require.Equal(t, int64(12), computed[students[LifecycleStatusAtRisk].StudentID].BaselineLessonsCount)
+}
+
+func TestUpdateStudentsLifecycleTx(t *testing.T) {
+	asOf := time.Now().UTC().Add(time.Minute)
+	students := createRandomLifecycleStudents(t, asOf)
+
+	store := NewStore(testDB)
+	updated, err := store.UpdateStudentsLifecycleTx(context.Background(), asOf, DefaultLifecycleThresholds)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, updated, 2)
+
+	for status, student := range students {
+		student, err := testQueries.GetStudent(context.Background(), student.StudentID)
+		require.NoError(t, err)
+		require.Equal(t, status, student.LifecycleStatus)
+	}
+
+	arg := ListStudentsParams{
+		LifecycleStatus: sql.NullString{String: LifecycleStatusLapsed, Valid: true},
+		Limit:           10,
+		Offset:          0,
+	}
+
+	lapsed, err := testQueries.ListStudents(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, lapsed)
+
+	for _, student := range lapsed {
+		require.Equal(t, LifecycleStatusLapsed, student.LifecycleStatus)
+	}
+}
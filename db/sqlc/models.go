@@ -6,19 +6,203 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+// interactions of the tutor with students, logged for the student timeline
+type Activity struct {
+	ActivityID int64 `json:"activity_id"`
+	StudentID  int64 `json:"student_id"`
+	// call, email, meeting or whatsapp
+	ActivityType string `json:"activity_type"`
+	// inbound if the student reached out, outbound if the tutor did
+	Direction string `json:"direction"`
+	// when the interaction took place, which may be before it was logged
+	OccurredAt time.Time      `json:"occurred_at"`
+	Summary    string         `json:"summary"`
+	Notes      sql.NullString `json:"notes"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// files attached to a student or a lesson, such as worksheets, exam papers and signed agreements
+type Attachment struct {
+	AttachmentID int64         `json:"attachment_id"`
+	StudentID    sql.NullInt64 `json:"student_id"`
+	LessonID     sql.NullInt64 `json:"lesson_id"`
+	// file name of the uploaded file
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// key of the file in the blob storage
+	StorageKey  string         `json:"storage_key"`
+	Description sql.NullString `json:"description"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// exceptions to the weekly availability, such as holidays or extra hours
+type AvailabilityException struct {
+	ExceptionID   int64     `json:"exception_id"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+	// true if the tutor is available in the period, false if the tutor is unavailable
+	Available bool `json:"available"`
+	// location of the lessons booked in an available period
+	LocationID sql.NullInt64  `json:"location_id"`
+	Notes      sql.NullString `json:"notes"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// weekly template of the times the tutor is available for lessons
+type AvailabilityWindow struct {
+	WindowID int64 `json:"window_id"`
+	// 0 for Sunday through 6 for Saturday
+	Weekday int32 `json:"weekday"`
+	// minutes after midnight in the timezone of the window
+	StartMinute int32 `json:"start_minute"`
+	// minutes after midnight in the timezone of the window, exclusive
+	EndMinute int32 `json:"end_minute"`
+	// IANA timezone of the window, such as Asia/Jerusalem
+	Timezone string `json:"timezone"`
+	// location of the lessons booked in the window
+	LocationID int64     `json:"location_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// lessons requested by students, approved or declined by the tutor
+type BookingRequest struct {
+	BookingRequestID int64          `json:"booking_request_id"`
+	FirstName        string         `json:"first_name"`
+	LastName         string         `json:"last_name"`
+	Email            sql.NullString `json:"email"`
+	PhoneNumber      sql.NullString `json:"phone_number"`
+	SubjectID        int64          `json:"subject_id"`
+	LocationID       int64          `json:"location_id"`
+	LessonDatetime   time.Time      `json:"lesson_datetime"`
+	Duration         int64          `json:"duration"`
+	Notes            sql.NullString `json:"notes"`
+	// pending, approved or declined
+	Status string `json:"status"`
+	// student of the lesson, set when the request is approved
+	StudentID sql.NullInt64 `json:"student_id"`
+	// lesson created when the request is approved
+	LessonID  sql.NullInt64 `json:"lesson_id"`
+	CreatedAt time.Time     `json:"created_at"`
+	DecidedAt sql.NullTime  `json:"decided_at"`
+}
+
 type College struct {
 	CollegeID int64  `json:"college_id"`
 	Name      string `json:"name"`
 }
 
+// typed fields defined by the tutor, such as grade level or referral source
+type CustomField struct {
+	FieldID int64  `json:"field_id"`
+	Name    string `json:"name"`
+	// text, number, date or enum
+	FieldType string `json:"field_type"`
+	// the allowed values of an enum field, empty for other types
+	Options   []string  `json:"options"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type EmailOutbox struct {
+	EmailID   int64  `json:"email_id"`
+	Template  string `json:"template"`
+	Recipient string `json:"recipient"`
+	// the data the email template is rendered with
+	Payload   json.RawMessage `json:"payload"`
+	StudentID sql.NullInt64   `json:"student_id"`
+	LessonID  sql.NullInt64   `json:"lesson_id"`
+	Status    string          `json:"status"`
+	Attempts  int32           `json:"attempts"`
+	LastError sql.NullString  `json:"last_error"`
+	// the email is not sent before send_at, which is also used to delay retries
+	SendAt    time.Time    `json:"send_at"`
+	SentAt    sql.NullTime `json:"sent_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// exams of students in a lesson subject, with their target and achieved grades
+type Exam struct {
+	ExamID    int64 `json:"exam_id"`
+	StudentID int64 `json:"student_id"`
+	SubjectID int64 `json:"subject_id"`
+	// college the exam is taken for, the college of the student by default
+	CollegeID    sql.NullInt64   `json:"college_id"`
+	Name         string          `json:"name"`
+	ExamDatetime time.Time       `json:"exam_datetime"`
+	TargetGrade  sql.NullFloat64 `json:"target_grade"`
+	// null until the exam is graded
+	AchievedGrade sql.NullFloat64 `json:"achieved_grade"`
+	Notes         sql.NullString  `json:"notes"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// hourly fees of a student, a subject, a student in a subject, or of all lessons if both are null
+type FeeSchedule struct {
+	FeeScheduleID int64         `json:"fee_schedule_id"`
+	StudentID     sql.NullInt64 `json:"student_id"`
+	SubjectID     sql.NullInt64 `json:"subject_id"`
+	HourlyFee     float64       `json:"hourly_fee"`
+	// the fee applies to lessons from this time until the next schedule with the same student and subject
+	EffectiveFrom time.Time      `json:"effective_from"`
+	Notes         sql.NullString `json:"notes"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
 type Funnel struct {
 	FunnelID int64  `json:"funnel_id"`
 	Name     string `json:"name"`
 }
 
+type FunnelCost struct {
+	FunnelCostID int64     `json:"funnel_cost_id"`
+	FunnelID     int64     `json:"funnel_id"`
+	CostDate     time.Time `json:"cost_date"`
+	// acquisition cost spent on the funnel
+	Amount float64        `json:"amount"`
+	Notes  sql.NullString `json:"notes"`
+}
+
+type GroupPricingPlan struct {
+	PlanID int64  `json:"plan_id"`
+	Name   string `json:"name"`
+	// per_head, split or tiered
+	Mode string `json:"mode"`
+	// hourly fee of each student for per_head, or of the whole group for split
+	HourlyFee float64   `json:"hourly_fee"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GroupPricingTier struct {
+	PlanID      int64 `json:"plan_id"`
+	MinStudents int32 `json:"min_students"`
+	// hourly fee of each student in groups of min_students or more, up to the next tier
+	HourlyFee float64 `json:"hourly_fee"`
+}
+
+type Guardian struct {
+	GuardianID  int64          `json:"guardian_id"`
+	FirstName   string         `json:"first_name"`
+	LastName    string         `json:"last_name"`
+	Email       sql.NullString `json:"email"`
+	PhoneNumber sql.NullString `json:"phone_number"`
+	Address     sql.NullString `json:"address"`
+	Notes       sql.NullString `json:"notes"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// a single receipt of a guardian paying for one or more students, allocated to a receipt of each student
+type GuardianReceipt struct {
+	GuardianReceiptID int64          `json:"guardian_receipt_id"`
+	GuardianID        int64          `json:"guardian_id"`
+	ReceiptDatetime   time.Time      `json:"receipt_datetime"`
+	Amount            float64        `json:"amount"`
+	Notes             sql.NullString `json:"notes"`
+}
+
 type Invoice struct {
 	InvoiceID       int64     `json:"invoice_id"`
 	StudentID       int64     `json:"student_id"`
@@ -32,6 +216,39 @@ type Invoice struct {
 	// total amount based on lesson duration, hourly fee and discount
 	Amount float64        `json:"amount"`
 	Notes  sql.NullString `json:"notes"`
+	// fee schedule of the hourly fee of the invoice, or null if the fee was set otherwise
+	FeeScheduleID sql.NullInt64 `json:"fee_schedule_id"`
+}
+
+type JobRun struct {
+	RunID   int64  `json:"run_id"`
+	JobName string `json:"job_name"`
+	Trigger string `json:"trigger"`
+	Status  string `json:"status"`
+	// the time the run was due, or the time it was triggered manually
+	ScheduledAt time.Time      `json:"scheduled_at"`
+	StartedAt   time.Time      `json:"started_at"`
+	FinishedAt  sql.NullTime   `json:"finished_at"`
+	Error       sql.NullString `json:"error"`
+}
+
+// prospective students, before they are converted into students
+type Lead struct {
+	LeadID      int64          `json:"lead_id"`
+	FirstName   string         `json:"first_name"`
+	LastName    string         `json:"last_name"`
+	Email       sql.NullString `json:"email"`
+	PhoneNumber sql.NullString `json:"phone_number"`
+	// funnel the lead came from, carried to the student on conversion
+	FunnelID sql.NullInt64 `json:"funnel_id"`
+	StageID  int64         `json:"stage_id"`
+	// open, converted or lost
+	Status string `json:"status"`
+	// student created when the lead is converted
+	StudentID   sql.NullInt64  `json:"student_id"`
+	Notes       sql.NullString `json:"notes"`
+	CreatedAt   time.Time      `json:"created_at"`
+	ConvertedAt sql.NullTime   `json:"converted_at"`
 }
 
 type Lesson struct {
@@ -42,6 +259,18 @@ type Lesson struct {
 	LocationID int64          `json:"location_id"`
 	SubjectID  int64          `json:"subject_id"`
 	Notes      sql.NullString `json:"notes"`
+	// group pricing plan of the invoices, or null if each invoice is priced by the caller
+	PricingPlanID sql.NullInt64 `json:"pricing_plan_id"`
+}
+
+// students of a lesson, whether invoiced or attending for free
+type LessonAttendance struct {
+	LessonID  int64 `json:"lesson_id"`
+	StudentID int64 `json:"student_id"`
+	// attended, absent or excused
+	Status    string         `json:"status"`
+	Notes     sql.NullString `json:"notes"`
+	UpdatedAt time.Time      `json:"updated_at"`
 }
 
 type LessonLocation struct {
@@ -49,11 +278,66 @@ type LessonLocation struct {
 	Name       string `json:"name"`
 }
 
+// progress of a student in a lesson, recorded by the tutor
+type LessonRecord struct {
+	LessonID  int64 `json:"lesson_id"`
+	StudentID int64 `json:"student_id"`
+	// topics covered in the lesson
+	Topics sql.NullString `json:"topics"`
+	// homework assigned in the lesson
+	Homework sql.NullString `json:"homework"`
+	// none, assigned, partial, completed or missed
+	HomeworkStatus string `json:"homework_status"`
+	// tutor rating of the student in the lesson, from 1 to 5
+	Rating    sql.NullInt32  `json:"rating"`
+	Notes     sql.NullString `json:"notes"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+type LessonReminder struct {
+	ReminderID int64  `json:"reminder_id"`
+	LessonID   int64  `json:"lesson_id"`
+	StudentID  int64  `json:"student_id"`
+	Channel    string `json:"channel"`
+	// sending while claimed by a scheduler, then sent or failed
+	Status    string         `json:"status"`
+	Attempts  int32          `json:"attempts"`
+	LastError sql.NullString `json:"last_error"`
+	ClaimedAt time.Time      `json:"claimed_at"`
+	SentAt    sql.NullTime   `json:"sent_at"`
+}
+
 type LessonSubject struct {
 	SubjectID int64  `json:"subject_id"`
 	Name      string `json:"name"`
 }
 
+type PackageDeduction struct {
+	DeductionID int64 `json:"deduction_id"`
+	PackageID   int64 `json:"package_id"`
+	InvoiceID   int64 `json:"invoice_id"`
+	// lessons or minutes deducted for the invoice
+	Units int64 `json:"units"`
+	// value of the deducted units, charged by the invoice
+	Amount    float64   `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PackageProduct struct {
+	ProductID int64  `json:"product_id"`
+	Name      string `json:"name"`
+	// lesson or hour
+	Unit string `json:"unit"`
+	// number of lessons or hours in the package
+	Quantity int32   `json:"quantity"`
+	Price    float64 `json:"price"`
+	// days from the purchase until the package expires, or null if it never expires
+	ValidityDays sql.NullInt32 `json:"validity_days"`
+	Active       bool          `json:"active"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
 type Payment struct {
 	PaymentID       int64     `json:"payment_id"`
 	ReceiptID       int64     `json:"receipt_id"`
@@ -67,6 +351,31 @@ type PaymentMethod struct {
 	Name            string `json:"name"`
 }
 
+// configurable stages leads move through, such as contacted or trial lesson
+type PipelineStage struct {
+	StageID int64  `json:"stage_id"`
+	Name    string `json:"name"`
+	// order of the stage in the pipeline, lowest first
+	Position  int32     `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ProgressReport struct {
+	ProgressReportID int64     `json:"progress_report_id"`
+	StudentID        int64     `json:"student_id"`
+	PeriodStart      time.Time `json:"period_start"`
+	PeriodEnd        time.Time `json:"period_end"`
+	// lessons of the student in the period, attended or not
+	LessonsCount  int64 `json:"lessons_count"`
+	AttendedCount int64 `json:"attended_count"`
+	// hours of the lessons attended in the period
+	Hours float64 `json:"hours"`
+	// lessons of the period with homework assigned
+	HomeworkAssignedCount  int64     `json:"homework_assigned_count"`
+	HomeworkCompletedCount int64     `json:"homework_completed_count"`
+	CreatedAt              time.Time `json:"created_at"`
+}
+
 type Receipt struct {
 	ReceiptID       int64     `json:"receipt_id"`
 	StudentID       int64     `json:"student_id"`
@@ -74,6 +383,31 @@ type Receipt struct {
 	// total amount of all payments
 	Amount float64        `json:"amount"`
 	Notes  sql.NullString `json:"notes"`
+	// guardian receipt the receipt is allocated from, or null if paid by the student
+	GuardianReceiptID sql.NullInt64 `json:"guardian_receipt_id"`
+}
+
+type ReminderPreference struct {
+	StudentID int64 `json:"student_id"`
+	// none, email, sms or webhook
+	Channel string `json:"channel"`
+	// how many minutes before a lesson the reminder is sent, up to a week
+	LeadMinutes int32     `json:"lead_minutes"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type Statement struct {
+	StatementID int64     `json:"statement_id"`
+	StudentID   int64     `json:"student_id"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	// invoiced minus paid before the period
+	PreviousBalance float64 `json:"previous_balance"`
+	InvoicedAmount  float64 `json:"invoiced_amount"`
+	PaymentsAmount  float64 `json:"payments_amount"`
+	// previous balance plus invoiced minus paid in the period
+	TotalDue  float64   `json:"total_due"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Student struct {
@@ -89,4 +423,122 @@ type Student struct {
 	HourlyFee sql.NullFloat64 `json:"hourly_fee"`
 	Notes     sql.NullString  `json:"notes"`
 	CreatedAt time.Time       `json:"created_at"`
+	// active, at-risk or lapsed, based on the student lessons frequency
+	LifecycleStatus string `json:"lifecycle_status"`
+}
+
+type StudentCustomValue struct {
+	StudentID int64 `json:"student_id"`
+	FieldID   int64 `json:"field_id"`
+	// the value of the field, numbers and dates (2006-01-02) are stored in their canonical text form
+	Value     string    `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type StudentGuardian struct {
+	StudentID  int64 `json:"student_id"`
+	GuardianID int64 `json:"guardian_id"`
+	// parent, guardian, grandparent, sibling, spouse or other
+	Relationship string `json:"relationship"`
+	// the invoices, receipts and statements of the student are sent to the billing contact, at most one per student
+	BillingContact bool      `json:"billing_contact"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type StudentPackage struct {
+	PackageID int64 `json:"package_id"`
+	StudentID int64 `json:"student_id"`
+	ProductID int64 `json:"product_id"`
+	ReceiptID int64 `json:"receipt_id"`
+	// lesson or hour, copied from the product
+	Unit string `json:"unit"`
+	// lessons of a lesson package, or minutes of an hour package
+	TotalUnits int64 `json:"total_units"`
+	// lessons or minutes not yet deducted
+	RemainingUnits int64 `json:"remaining_units"`
+	// price paid for the package, copied from the product
+	Price       float64      `json:"price"`
+	PurchasedAt time.Time    `json:"purchased_at"`
+	ExpiresAt   sql.NullTime `json:"expires_at"`
+}
+
+type StudentTag struct {
+	StudentID int64     `json:"student_id"`
+	TagID     int64     `json:"tag_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// labels attached to students, such as scholarship or exam year
+type Tag struct {
+	TagID     int64     `json:"tag_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// to-dos and follow-up tasks of the tutor, optionally of a lead, student, lesson or invoice
+type Task struct {
+	TaskID int64         `json:"task_id"`
+	LeadID sql.NullInt64 `json:"lead_id"`
+	Title  string        `json:"title"`
+	DueAt  time.Time     `json:"due_at"`
+	// null until the task is done
+	CompletedAt sql.NullTime   `json:"completed_at"`
+	Notes       sql.NullString `json:"notes"`
+	CreatedAt   time.Time      `json:"created_at"`
+	StudentID   sql.NullInt64  `json:"student_id"`
+	LessonID    sql.NullInt64  `json:"lesson_id"`
+	InvoiceID   sql.NullInt64  `json:"invoice_id"`
+	// low, normal or high
+	Priority string `json:"priority"`
+	// open, in_progress, done or cancelled
+	Status string `json:"status"`
+	// none, daily, weekly or monthly. The next task is created when a recurring task is done
+	Recurrence string `json:"recurrence"`
+}
+
+// students waiting for a lesson slot in a subject at their preferred days and times
+type WaitlistEntry struct {
+	WaitlistEntryID int64 `json:"waitlist_entry_id"`
+	StudentID       int64 `json:"student_id"`
+	SubjectID       int64 `json:"subject_id"`
+	// preferred day, 0 for Sunday through 6 for Saturday, any day if null
+	Weekday sql.NullInt32 `json:"weekday"`
+	// start of the preferred times, minutes after midnight in the timezone of the entry, any time if null
+	StartMinute sql.NullInt32 `json:"start_minute"`
+	// end of the preferred times, minutes after midnight in the timezone of the entry, exclusive
+	EndMinute sql.NullInt32 `json:"end_minute"`
+	Timezone  string        `json:"timezone"`
+	// waiting, notified or withdrawn
+	Status     string         `json:"status"`
+	Notes      sql.NullString `json:"notes"`
+	CreatedAt  time.Time      `json:"created_at"`
+	NotifiedAt sql.NullTime   `json:"notified_at"`
+	// datetime of the deleted lesson the student was notified about
+	NotifiedLessonDatetime sql.NullTime `json:"notified_lesson_datetime"`
+}
+
+type WebhookDelivery struct {
+	DeliveryID     int64  `json:"delivery_id"`
+	SubscriptionID int64  `json:"subscription_id"`
+	Event          string `json:"event"`
+	// the JSON body posted to the subscription url
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	ResponseStatus sql.NullInt32   `json:"response_status"`
+	LastError      sql.NullString  `json:"last_error"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	DeliveredAt    sql.NullTime    `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type WebhookSubscription struct {
+	SubscriptionID int64  `json:"subscription_id"`
+	Url            string `json:"url"`
+	// key of the HMAC-SHA256 signature of the payloads
+	Secret string `json:"secret"`
+	// the events posted to the subscription url
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
 }
@@ -0,0 +1,222 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+)
+
+// Package units. Lesson packages contain a number of lessons of any duration,
+// and hour packages contain a number of hours, deducted by the minutes of each lesson.
+const (
+	PackageUnitLesson = "lesson"
+	PackageUnitHour   = "hour"
+)
+
+// ErrPackageProductInactive is returned by PurchasePackageTx if the package product is no longer sold.
+var ErrPackageProductInactive = errors.New("package product is inactive")
+
+// PurchasePackageTxParams contains the input parameters of the purchase of a package by a student, for the PurchasePackageTx function.
+type PurchasePackageTxParams struct {
+	StudentID        int64          `json:"student_id"`
+	ProductID        int64          `json:"product_id"`
+	PurchaseDatetime time.Time      `json:"purchase_datetime"`
+	PaymentMethodID  int64          `json:"payment_method_id"`
+	Notes            sql.NullString `json:"notes"`
+}
+
+// PackagePurchase is used for a package purchased by a student, and the receipt of its payment.
+type PackagePurchase struct {
+	Package StudentPackage      `json:"package"`
+	Receipt ReceiptWithPayments `json:"receipt"`
+}
+
+// PurchasePackageTx records the purchase of a package product by a student.
+// The price of the product is paid by a receipt with a single payment, created as by CreateReceiptWithPaymentsTx,
+// and the package expires validity days after the purchase, if the product has a validity.
+// It returns ErrPackageProductInactive if the product is inactive.
+func (store *SQLStore) PurchasePackageTx(ctx context.Context, arg PurchasePackageTxParams) (PackagePurchase, error) {
+	var result PackagePurchase
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		product, err := q.GetPackageProduct(ctx, arg.ProductID)
+		if err != nil {
+			return err
+		}
+
+		if !product.Active {
+			return ErrPackageProductInactive
+		}
+
+		result.Receipt, err = q.createReceiptWithPayments(ctx, CreateReceiptTxParams{
+			StudentID:       arg.StudentID,
+			ReceiptDatetime: arg.PurchaseDatetime,
+			Notes:           arg.Notes,
+			ReceiptPaymentsParams: []CreateReceiptTxPaymentParams{{
+				PaymentDatetime: arg.PurchaseDatetime,
+				Amount:          product.Price,
+				PaymentMethodID: arg.PaymentMethodID,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+
+		createArg := CreateStudentPackageParams{
+			StudentID:   arg.StudentID,
+			ProductID:   product.ProductID,
+			ReceiptID:   result.Receipt.Receipt.ReceiptID,
+			Unit:        product.Unit,
+			TotalUnits:  int64(product.Quantity),
+			Price:       product.Price,
+			PurchasedAt: arg.PurchaseDatetime,
+		}
+
+		if product.Unit == PackageUnitHour {
+			createArg.TotalUnits *= 60
+		}
+
+		if product.ValidityDays.Valid {
+			createArg.ExpiresAt = sql.NullTime{
+				Time:  arg.PurchaseDatetime.AddDate(0, 0, int(product.ValidityDays.Int32)),
+				Valid: true,
+			}
+		}
+
+		result.Package, err = q.CreateStudentPackage(ctx, createArg)
+		return err
+	})
+
+	return result, err
+}
+
+// getUsableStudentPackage locks the package of a student to deduct a lesson of duration minutes from, within a transaction.
+// ok is false if the student has no usable package.
+func (q *Queries) getUsableStudentPackage(ctx context.Context, studentID int64, lessonDatetime time.Time, duration int64) (pkg StudentPackage, ok bool, err error) {
+	pkg, err = q.GetUsableStudentPackage(ctx, GetUsableStudentPackageParams{
+		StudentID:      studentID,
+		LessonDatetime: lessonDatetime,
+		Duration:       duration,
+	})
+	if err == sql.ErrNoRows {
+		return pkg, false, nil
+	}
+
+	return pkg, err == nil, err
+}
+
+// packageUnits returns the units of a package used by a lesson of duration minutes.
+func packageUnits(pkg StudentPackage, duration int64) int64 {
+	if pkg.Unit == PackageUnitLesson {
+		return 1
+	}
+	return duration
+}
+
+// packageAmount returns the value of units deducted from a package, rounded to cents.
+// The value is the difference between the rounded values of the remaining units before and after the deduction,
+// so the amounts deducted from a package add up to its price.
+func packageAmount(pkg StudentPackage, units int64) float64 {
+	value := func(remaining int64) float64 {
+		return math.Round(pkg.Price*float64(remaining)/float64(pkg.TotalUnits)*100) / 100
+	}
+
+	return math.Round((value(pkg.RemainingUnits)-value(pkg.RemainingUnits-units))*100) / 100
+}
+
+// applyStudentPackage prices an invoice by the package of the student it is deducted from, if the student has a usable package.
+// The invoice is charged the value of the deducted units, so the receipt of the package purchase is used up by its lessons.
+// The package and the units to deduct from it once the invoice is created are returned, and ok is false if there is no usable package.
+func (q *Queries) applyStudentPackage(ctx context.Context, arg *CreateInvoiceParams) (pkg StudentPackage, units int64, ok bool, err error) {
+	pkg, ok, err = q.getUsableStudentPackage(ctx, arg.StudentID, arg.InvoiceDatetime, arg.Duration)
+	if err != nil || !ok {
+		return pkg, 0, ok, err
+	}
+
+	units = packageUnits(pkg, arg.Duration)
+
+	arg.Amount = packageAmount(pkg, units)
+	arg.Discount = 0
+	if arg.Duration > 0 {
+		arg.HourlyFee = math.Round(arg.Amount*60/float64(arg.Duration)*100) / 100
+	}
+
+	return pkg, units, true, nil
+}
+
+// deductStudentPackage deducts the units of an invoice from a package, and records the deduction.
+func (q *Queries) deductStudentPackage(ctx context.Context, pkg StudentPackage, invoice Invoice, units int64) (PackageDeduction, error) {
+	err := q.DeductStudentPackage(ctx, DeductStudentPackageParams{
+		PackageID: pkg.PackageID,
+		Units:     units,
+	})
+	if err != nil {
+		return PackageDeduction{}, err
+	}
+
+	return q.CreatePackageDeduction(ctx, CreatePackageDeductionParams{
+		PackageID: pkg.PackageID,
+		InvoiceID: invoice.InvoiceID,
+		Units:     units,
+		Amount:    invoice.Amount,
+	})
+}
+
+// restoreLessonPackageDeductions returns the units deducted for the invoices of a lesson to their packages,
+// and deletes the deductions.
+func (q *Queries) restoreLessonPackageDeductions(ctx context.Context, lessonID int64) error {
+	deductions, err := q.GetPackageDeductionsByLesson(ctx, lessonID)
+	if err != nil {
+		return err
+	}
+
+	for _, deduction := range deductions {
+		err = q.RestoreStudentPackage(ctx, RestoreStudentPackageParams{
+			PackageID: deduction.PackageID,
+			Units:     deduction.Units,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return q.DeletePackageDeductionsByLesson(ctx, lessonID)
+}
+
+// StudentPackageBalance contains the active packages of a student, and their remaining balances by unit.
+type StudentPackageBalance struct {
+	StudentID int64                          `json:"student_id"`
+	Balances  []GetStudentPackageBalancesRow `json:"balances"`
+	Packages  []StudentPackage               `json:"packages"`
+}
+
+// GetStudentPackageBalanceTx gets the packages of a student that are active at asOf, and their remaining balances.
+// It returns sql.ErrNoRows if the student doesn't exist.
+func (store *SQLStore) GetStudentPackageBalanceTx(ctx context.Context, studentID int64, asOf time.Time) (StudentPackageBalance, error) {
+	result := StudentPackageBalance{StudentID: studentID}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetStudent(ctx, studentID)
+		if err != nil {
+			return err
+		}
+
+		result.Balances, err = q.GetStudentPackageBalances(ctx, GetStudentPackageBalancesParams{
+			StudentID: studentID,
+			AsOf:      asOf,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Packages, err = q.GetActiveStudentPackages(ctx, GetActiveStudentPackagesParams{
+			StudentID: studentID,
+			AsOf:      asOf,
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,591 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: package.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createPackageDeduction = `-- name: CreatePackageDeduction :one
+INSERT INTO package_deductions (
+  package_id, invoice_id, units, amount
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING deduction_id, package_id, invoice_id, units, amount, created_at
+`
+
+type CreatePackageDeductionParams struct {
+	PackageID int64   `json:"package_id"`
+	InvoiceID int64   `json:"invoice_id"`
+	Units     int64   `json:"units"`
+	Amount    float64 `json:"amount"`
+}
+
+func (q *Queries) CreatePackageDeduction(ctx context.Context, arg CreatePackageDeductionParams) (PackageDeduction, error) {
+	row := q.db.QueryRowContext(ctx, createPackageDeduction,
+		arg.PackageID,
+		arg.InvoiceID,
+		arg.Units,
+		arg.Amount,
+	)
+	var i PackageDeduction
+	err := row.Scan(
+		&i.DeductionID,
+		&i.PackageID,
+		&i.InvoiceID,
+		&i.Units,
+		&i.Amount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createPackageProduct = `-- name: CreatePackageProduct :one
+INSERT INTO package_products (
+  name, unit, quantity, price, validity_days, active
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING product_id, name, unit, quantity, price, validity_days, active, created_at
+`
+
+type CreatePackageProductParams struct {
+	Name         string        `json:"name"`
+	Unit         string        `json:"unit"`
+	Quantity     int32         `json:"quantity"`
+	Price        float64       `json:"price"`
+	ValidityDays sql.NullInt32 `json:"validity_days"`
+	Active       bool          `json:"active"`
+}
+
+func (q *Queries) CreatePackageProduct(ctx context.Context, arg CreatePackageProductParams) (PackageProduct, error) {
+	row := q.db.QueryRowContext(ctx, createPackageProduct,
+		arg.Name,
+		arg.Unit,
+		arg.Quantity,
+		arg.Price,
+		arg.ValidityDays,
+		arg.Active,
+	)
+	var i PackageProduct
+	err := row.Scan(
+		&i.ProductID,
+		&i.Name,
+		&i.Unit,
+		&i.Quantity,
+		&i.Price,
+		&i.ValidityDays,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createStudentPackage = `-- name: CreateStudentPackage :one
+INSERT INTO student_packages (
+  student_id, product_id, receipt_id, unit, total_units, remaining_units, price, purchased_at, expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $5, $6, $7, $8
+)
+RETURNING package_id, student_id, product_id, receipt_id, unit, total_units, remaining_units, price, purchased_at, expires_at
+`
+
+type CreateStudentPackageParams struct {
+	StudentID   int64        `json:"student_id"`
+	ProductID   int64        `json:"product_id"`
+	ReceiptID   int64        `json:"receipt_id"`
+	Unit        string       `json:"unit"`
+	TotalUnits  int64        `json:"total_units"`
+	Price       float64      `json:"price"`
+	PurchasedAt time.Time    `json:"purchased_at"`
+	ExpiresAt   sql.NullTime `json:"expires_at"`
+}
+
+func (q *Queries) CreateStudentPackage(ctx context.Context, arg CreateStudentPackageParams) (StudentPackage, error) {
+	row := q.db.QueryRowContext(ctx, createStudentPackage,
+		arg.StudentID,
+		arg.ProductID,
+		arg.ReceiptID,
+		arg.Unit,
+		arg.TotalUnits,
+		arg.Price,
+		arg.PurchasedAt,
+		arg.ExpiresAt,
+	)
+	var i StudentPackage
+	err := row.Scan(
+		&i.PackageID,
+		&i.StudentID,
+		&i.ProductID,
+		&i.ReceiptID,
+		&i.Unit,
+		&i.TotalUnits,
+		&i.RemainingUnits,
+		&i.Price,
+		&i.PurchasedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const deductStudentPackage = `-- name: DeductStudentPackage :exec
+UPDATE student_packages
+  set   remaining_units = remaining_units - $1
+WHERE package_id = $2
+`
+
+type DeductStudentPackageParams struct {
+	Units     int64 `json:"units"`
+	PackageID int64 `json:"package_id"`
+}
+
+func (q *Queries) DeductStudentPackage(ctx context.Context, arg DeductStudentPackageParams) error {
+	_, err := q.db.ExecContext(ctx, deductStudentPackage, arg.Units, arg.PackageID)
+	return err
+}
+
+const deletePackageDeduction = `-- name: DeletePackageDeduction :exec
+DELETE FROM package_deductions
+WHERE deduction_id = $1
+`
+
+func (q *Queries) DeletePackageDeduction(ctx context.Context, deductionID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePackageDeduction, deductionID)
+	return err
+}
+
+const deletePackageDeductionsByLesson = `-- name: DeletePackageDeductionsByLesson :exec
+DELETE FROM package_deductions pd
+USING invoices i
+WHERE i.invoice_id = pd.invoice_id AND i.lesson_id = $1
+`
+
+func (q *Queries) DeletePackageDeductionsByLesson(ctx context.Context, lessonID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePackageDeductionsByLesson, lessonID)
+	return err
+}
+
+const getActiveStudentPackages = `-- name: GetActiveStudentPackages :many
+SELECT package_id, student_id, product_id, receipt_id, unit, total_units, remaining_units, price, purchased_at, expires_at FROM student_packages
+WHERE student_id = $1
+  AND remaining_units > 0
+  AND (expires_at IS NULL OR expires_at > $2::timestamptz)
+ORDER BY expires_at NULLS LAST, purchased_at, package_id
+`
+
+type GetActiveStudentPackagesParams struct {
+	StudentID int64     `json:"student_id"`
+	AsOf      time.Time `json:"as_of"`
+}
+
+// GetActiveStudentPackages returns the packages of a student with units remaining that aren't expired at as_of,
+// in the order they are deducted from.
+func (q *Queries) GetActiveStudentPackages(ctx context.Context, arg GetActiveStudentPackagesParams) ([]StudentPackage, error) {
+	rows, err := q.db.QueryContext(ctx, getActiveStudentPackages, arg.StudentID, arg.AsOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StudentPackage{}
+	for rows.Next() {
+		var i StudentPackage
+		if err := rows.Scan(
+			&i.PackageID,
+			&i.StudentID,
+			&i.ProductID,
+			&i.ReceiptID,
+			&i.Unit,
+			&i.TotalUnits,
+			&i.RemainingUnits,
+			&i.Price,
+			&i.PurchasedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPackageDeductionByInvoice = `-- name: GetPackageDeductionByInvoice :one
+SELECT deduction_id, package_id, invoice_id, units, amount, created_at FROM package_deductions
+WHERE invoice_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetPackageDeductionByInvoice(ctx context.Context, invoiceID int64) (PackageDeduction, error) {
+	row := q.db.QueryRowContext(ctx, getPackageDeductionByInvoice, invoiceID)
+	var i PackageDeduction
+	err := row.Scan(
+		&i.DeductionID,
+		&i.PackageID,
+		&i.InvoiceID,
+		&i.Units,
+		&i.Amount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPackageDeductionsByLesson = `-- name: GetPackageDeductionsByLesson :many
+SELECT pd.deduction_id, pd.package_id, pd.invoice_id, pd.units, pd.amount, pd.created_at FROM package_deductions pd
+JOIN invoices i ON i.invoice_id = pd.invoice_id
+WHERE i.lesson_id = $1
+ORDER BY pd.deduction_id
+`
+
+func (q *Queries) GetPackageDeductionsByLesson(ctx context.Context, lessonID int64) ([]PackageDeduction, error) {
+	rows, err := q.db.QueryContext(ctx, getPackageDeductionsByLesson, lessonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PackageDeduction{}
+	for rows.Next() {
+		var i PackageDeduction
+		if err := rows.Scan(
+			&i.DeductionID,
+			&i.PackageID,
+			&i.InvoiceID,
+			&i.Units,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPackageDeductionsByPackage = `-- name: GetPackageDeductionsByPackage :many
+SELECT deduction_id, package_id, invoice_id, units, amount, created_at FROM package_deductions
+WHERE package_id = $1
+ORDER BY deduction_id
+`
+
+func (q *Queries) GetPackageDeductionsByPackage(ctx context.Context, packageID int64) ([]PackageDeduction, error) {
+	rows, err := q.db.QueryContext(ctx, getPackageDeductionsByPackage, packageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PackageDeduction{}
+	for rows.Next() {
+		var i PackageDeduction
+		if err := rows.Scan(
+			&i.DeductionID,
+			&i.PackageID,
+			&i.InvoiceID,
+			&i.Units,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPackageProduct = `-- name: GetPackageProduct :one
+SELECT product_id, name, unit, quantity, price, validity_days, active, created_at FROM package_products
+WHERE product_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetPackageProduct(ctx context.Context, productID int64) (PackageProduct, error) {
+	row := q.db.QueryRowContext(ctx, getPackageProduct, productID)
+	var i PackageProduct
+	err := row.Scan(
+		&i.ProductID,
+		&i.Name,
+		&i.Unit,
+		&i.Quantity,
+		&i.Price,
+		&i.ValidityDays,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getStudentPackage = `-- name: GetStudentPackage :one
+SELECT package_id, student_id, product_id, receipt_id, unit, total_units, remaining_units, price, purchased_at, expires_at FROM student_packages
+WHERE package_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetStudentPackage(ctx context.Context, packageID int64) (StudentPackage, error) {
+	row := q.db.QueryRowContext(ctx, getStudentPackage, packageID)
+	var i StudentPackage
+	err := row.Scan(
+		&i.PackageID,
+		&i.StudentID,
+		&i.ProductID,
+		&i.ReceiptID,
+		&i.Unit,
+		&i.TotalUnits,
+		&i.RemainingUnits,
+		&i.Price,
+		&i.PurchasedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getStudentPackageBalances = `-- name: GetStudentPackageBalances :many
+SELECT unit,
+       COUNT(*)::bigint AS packages,
+       SUM(remaining_units)::bigint AS remaining_units,
+       SUM(price * remaining_units / total_units)::float AS remaining_amount
+FROM student_packages
+WHERE student_id = $1
+  AND remaining_units > 0
+  AND (expires_at IS NULL OR expires_at > $2::timestamptz)
+GROUP BY unit
+ORDER BY unit
+`
+
+type GetStudentPackageBalancesParams struct {
+	StudentID int64     `json:"student_id"`
+	AsOf      time.Time `json:"as_of"`
+}
+
+type GetStudentPackageBalancesRow struct {
+	Unit            string  `json:"unit"`
+	Packages        int64   `json:"packages"`
+	RemainingUnits  int64   `json:"remaining_units"`
+	RemainingAmount float64 `json:"remaining_amount"`
+}
+
+// GetStudentPackageBalances returns the balances of the active packages of a student at as_of, by unit:
+// the remaining lessons or minutes, and their value.
+func (q *Queries) GetStudentPackageBalances(ctx context.Context, arg GetStudentPackageBalancesParams) ([]GetStudentPackageBalancesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStudentPackageBalances, arg.StudentID, arg.AsOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStudentPackageBalancesRow{}
+	for rows.Next() {
+		var i GetStudentPackageBalancesRow
+		if err := rows.Scan(
+			&i.Unit,
+			&i.Packages,
+			&i.RemainingUnits,
+			&i.RemainingAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsableStudentPackage = `-- name: GetUsableStudentPackage :one
+SELECT package_id, student_id, product_id, receipt_id, unit, total_units, remaining_units, price, purchased_at, expires_at FROM student_packages
+WHERE student_id = $1
+  AND (expires_at IS NULL OR expires_at > $2::timestamptz)
+  AND remaining_units >= CASE unit WHEN 'lesson' THEN 1 ELSE $3::bigint END
+ORDER BY expires_at NULLS LAST, purchased_at, package_id
+LIMIT 1
+FOR UPDATE
+`
+
+type GetUsableStudentPackageParams struct {
+	StudentID      int64     `json:"student_id"`
+	LessonDatetime time.Time `json:"lesson_datetime"`
+	Duration       int64     `json:"duration"`
+}
+
+// GetUsableStudentPackage locks the package of a student to deduct a lesson of duration minutes held at lesson_datetime from.
+// The package must not be expired at the lesson time, and must have a lesson or duration minutes remaining.
+// The package expiring first is used, and packages without an expiry date are used last.
+func (q *Queries) GetUsableStudentPackage(ctx context.Context, arg GetUsableStudentPackageParams) (StudentPackage, error) {
+	row := q.db.QueryRowContext(ctx, getUsableStudentPackage, arg.StudentID, arg.LessonDatetime, arg.Duration)
+	var i StudentPackage
+	err := row.Scan(
+		&i.PackageID,
+		&i.StudentID,
+		&i.ProductID,
+		&i.ReceiptID,
+		&i.Unit,
+		&i.TotalUnits,
+		&i.RemainingUnits,
+		&i.Price,
+		&i.PurchasedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const listPackageProducts = `-- name: ListPackageProducts :many
+SELECT product_id, name, unit, quantity, price, validity_days, active, created_at FROM package_products
+ORDER BY name
+LIMIT $1
+OFFSET $2
+`
+
+type ListPackageProductsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListPackageProducts(ctx context.Context, arg ListPackageProductsParams) ([]PackageProduct, error) {
+	rows, err := q.db.QueryContext(ctx, listPackageProducts, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PackageProduct{}
+	for rows.Next() {
+		var i PackageProduct
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.Name,
+			&i.Unit,
+			&i.Quantity,
+			&i.Price,
+			&i.ValidityDays,
+			&i.Active,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStudentPackages = `-- name: ListStudentPackages :many
+SELECT package_id, student_id, product_id, receipt_id, unit, total_units, remaining_units, price, purchased_at, expires_at FROM student_packages
+WHERE $1::bigint IS NULL OR student_id = $1
+ORDER BY purchased_at DESC, package_id DESC
+LIMIT $3
+OFFSET $2
+`
+
+type ListStudentPackagesParams struct {
+	StudentID sql.NullInt64 `json:"student_id"`
+	Offset    int32         `json:"offset"`
+	Limit     int32         `json:"limit"`
+}
+
+func (q *Queries) ListStudentPackages(ctx context.Context, arg ListStudentPackagesParams) ([]StudentPackage, error) {
+	rows, err := q.db.QueryContext(ctx, listStudentPackages, arg.StudentID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StudentPackage{}
+	for rows.Next() {
+		var i StudentPackage
+		if err := rows.Scan(
+			&i.PackageID,
+			&i.StudentID,
+			&i.ProductID,
+			&i.ReceiptID,
+			&i.Unit,
+			&i.TotalUnits,
+			&i.RemainingUnits,
+			&i.Price,
+			&i.PurchasedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreStudentPackage = `-- name: RestoreStudentPackage :exec
+UPDATE student_packages
+  set   remaining_units = remaining_units + $1
+WHERE package_id = $2
+`
+
+type RestoreStudentPackageParams struct {
+	Units     int64 `json:"units"`
+	PackageID int64 `json:"package_id"`
+}
+
+func (q *Queries) RestoreStudentPackage(ctx context.Context, arg RestoreStudentPackageParams) error {
+	_, err := q.db.ExecContext(ctx, restoreStudentPackage, arg.Units, arg.PackageID)
+	return err
+}
+
+const updatePackageProduct = `-- name: UpdatePackageProduct :exec
+UPDATE package_products
+  set   name = $2,
+        unit = $3,
+        quantity = $4,
+        price = $5,
+        validity_days = $6,
+        active = $7
+WHERE product_id = $1
+`
+
+type UpdatePackageProductParams struct {
+	ProductID    int64         `json:"product_id"`
+	Name         string        `json:"name"`
+	Unit         string        `json:"unit"`
+	Quantity     int32         `json:"quantity"`
+	Price        float64       `json:"price"`
+	ValidityDays sql.NullInt32 `json:"validity_days"`
+	Active       bool          `json:"active"`
+}
+
+func (q *Queries) UpdatePackageProduct(ctx context.Context, arg UpdatePackageProductParams) error {
+	_, err := q.db.ExecContext(ctx, updatePackageProduct,
+		arg.ProductID,
+		arg.Name,
+		arg.Unit,
+		arg.Quantity,
+		arg.Price,
+		arg.ValidityDays,
+		arg.Active,
+	)
+	return err
+}
@@ -0,0 +1,323 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomPackageProduct adds a new active package product of quantity units, valid for validityDays if not zero.
+func createRandomPackageProduct(t *testing.T, unit string, quantity int32, validityDays int32) PackageProduct {
+	arg := CreatePackageProductParams{
+		Name:         util.RandomName(),
+		Unit:         unit,
+		Quantity:     quantity,
+		Price:        util.RandomFloat64(500, 1500),
+		ValidityDays: sql.NullInt32{Int32: validityDays, Valid: validityDays != 0},
+		Active:       true,
+	}
+
+	product, err := testQueries.CreatePackageProduct(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, product.ProductID)
+
+	require.Equal(t, arg.Name, product.Name)
+	require.Equal(t, arg.Unit, product.Unit)
+	require.Equal(t, arg.Quantity, product.Quantity)
+	require.Equal(t, arg.Price, product.Price)
+	require.Equal(t, arg.ValidityDays, product.ValidityDays)
+	require.True(t, product.Active)
+	require.NotZero(t, product.CreatedAt)
+
+	return product
+}
+
+// purchaseRandomPackage adds a purchase of a package product by a student at purchaseDatetime.
+func purchaseRandomPackage(t *testing.T, student Student, product PackageProduct, purchaseDatetime time.Time) PackagePurchase {
+	store := NewStore(testDB)
+	paymentMethod := createRandomPaymentMethod(t)
+
+	purchase, err := store.PurchasePackageTx(context.Background(), PurchasePackageTxParams{
+		StudentID:        student.StudentID,
+		ProductID:        product.ProductID,
+		PurchaseDatetime: purchaseDatetime,
+		PaymentMethodID:  paymentMethod.PaymentMethodID,
+	})
+	require.NoError(t, err)
+
+	return purchase
+}
+
+func TestCreatePackageProduct(t *testing.T) {
+	createRandomPackageProduct(t, PackageUnitLesson, 10, 180)
+}
+
+func TestUpdatePackageProduct(t *testing.T) {
+	product1 := createRandomPackageProduct(t, PackageUnitLesson, 10, 180)
+
+	arg := UpdatePackageProductParams{
+		ProductID:    product1.ProductID,
+		Name:         util.RandomName(),
+		Unit:         PackageUnitHour,
+		Quantity:     5,
+		Price:        product1.Price + 100,
+		ValidityDays: sql.NullInt32{},
+		Active:       false,
+	}
+	err := testQueries.UpdatePackageProduct(context.Background(), arg)
+	require.NoError(t, err)
+
+	product2, err := testQueries.GetPackageProduct(context.Background(), product1.ProductID)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, product2.Name)
+	require.Equal(t, arg.Unit, product2.Unit)
+	require.Equal(t, arg.Quantity, product2.Quantity)
+	require.Equal(t, arg.Price, product2.Price)
+	require.False(t, product2.ValidityDays.Valid)
+	require.False(t, product2.Active)
+}
+
+func TestPurchasePackageTx(t *testing.T) {
+	student := createRandomStudent(t)
+	product := createRandomPackageProduct(t, PackageUnitHour, 10, 90)
+	purchasedAt := time.Now().UTC().Truncate(time.Second)
+
+	purchase := purchaseRandomPackage(t, student, product, purchasedAt)
+
+	// the price is paid by a receipt of the student
+	require.Equal(t, student.StudentID, purchase.Receipt.Receipt.StudentID)
+	require.Equal(t, product.Price, purchase.Receipt.Receipt.Amount)
+	require.Len(t, purchase.Receipt.Payments, 1)
+
+	pkg := purchase.Package
+	require.NotZero(t, pkg.PackageID)
+	require.Equal(t, student.StudentID, pkg.StudentID)
+	require.Equal(t, product.ProductID, pkg.ProductID)
+	require.Equal(t, purchase.Receipt.Receipt.ReceiptID, pkg.ReceiptID)
+	require.Equal(t, PackageUnitHour, pkg.Unit)
+	require.Equal(t, int64(600), pkg.TotalUnits)
+	require.Equal(t, int64(600), pkg.RemainingUnits)
+	require.Equal(t, product.Price, pkg.Price)
+	require.WithinDuration(t, purchasedAt, pkg.PurchasedAt, time.Second)
+	require.True(t, pkg.ExpiresAt.Valid)
+	require.WithinDuration(t, purchasedAt.AddDate(0, 0, 90), pkg.ExpiresAt.Time, time.Second)
+
+	// the receipt confirmation email is sent
+	emails, err := testQueries.GetOutboxEmailsByStudent(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Len(t, emails, 1)
+	require.Equal(t, EmailTemplateReceiptConfirmation, emails[0].Template)
+}
+
+func TestPurchasePackageTxInactiveProduct(t *testing.T) {
+	store := NewStore(testDB)
+	student := createRandomStudent(t)
+	product := createRandomPackageProduct(t, PackageUnitLesson, 10, 0)
+
+	err := testQueries.UpdatePackageProduct(context.Background(), UpdatePackageProductParams{
+		ProductID: product.ProductID,
+		Name:      product.Name,
+		Unit:      product.Unit,
+		Quantity:  product.Quantity,
+		Price:     product.Price,
+		Active:    false,
+	})
+	require.NoError(t, err)
+
+	_, err = store.PurchasePackageTx(context.Background(), PurchasePackageTxParams{
+		StudentID:        student.StudentID,
+		ProductID:        product.ProductID,
+		PurchaseDatetime: time.Now().UTC(),
+		PaymentMethodID:  createRandomPaymentMethod(t).PaymentMethodID,
+	})
+	require.ErrorIs(t, err, ErrPackageProductInactive)
+
+	// no receipt is recorded
+	receipts, err := testQueries.GetReceiptsByStudent(context.Background(), GetReceiptsByStudentParams{
+		StudentID: student.StudentID,
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, receipts)
+}
+
+func TestCreateLessonWithInvoicesTxLessonPackage(t *testing.T) {
+	student := createRandomStudent(t)
+	product := createRandomPackageProduct(t, PackageUnitLesson, 2, 0)
+	purchasedAt := time.Now().UTC().Truncate(time.Second)
+	purchase := purchaseRandomPackage(t, student, product, purchasedAt)
+
+	var invoiced float64
+	for i := 1; i <= 2; i++ {
+		result, err := createRandomLessonWithInvoiceTx(t, purchasedAt.Add(time.Duration(i)*time.Hour), student)
+		require.NoError(t, err)
+		require.Len(t, result.PackageDeductions, 1)
+
+		deduction := result.PackageDeductions[0]
+		require.Equal(t, purchase.Package.PackageID, deduction.PackageID)
+		require.Equal(t, result.Invoices[0].InvoiceID, deduction.InvoiceID)
+		require.Equal(t, int64(1), deduction.Units)
+		require.Equal(t, result.Invoices[0].Amount, deduction.Amount)
+		require.Zero(t, result.Invoices[0].Discount)
+
+		invoiced += result.Invoices[0].Amount
+	}
+
+	// the invoices of the package lessons add up to the package price
+	require.InDelta(t, product.Price, invoiced, 0.001)
+
+	pkg, err := testQueries.GetStudentPackage(context.Background(), purchase.Package.PackageID)
+	require.NoError(t, err)
+	require.Zero(t, pkg.RemainingUnits)
+
+	// lessons are invoiced as usual once the package is used up
+	result, err := createRandomLessonWithInvoiceTx(t, purchasedAt.Add(3*time.Hour), student)
+	require.NoError(t, err)
+	require.Empty(t, result.PackageDeductions)
+	require.InDelta(t, student.HourlyFee.Float64*float64(result.Lesson.Duration)/60.0, result.Invoices[0].Amount, 0.001)
+}
+
+func TestCreateLessonWithInvoicesTxHourPackage(t *testing.T) {
+	student := createRandomStudent(t)
+	product := createRandomPackageProduct(t, PackageUnitHour, 10, 0)
+	purchasedAt := time.Now().UTC().Truncate(time.Second)
+	purchase := purchaseRandomPackage(t, student, product, purchasedAt)
+
+	result, err := createRandomLessonWithInvoiceTx(t, purchasedAt.Add(time.Hour), student)
+	require.NoError(t, err)
+	require.Len(t, result.PackageDeductions, 1)
+	require.Equal(t, result.Lesson.Duration, result.PackageDeductions[0].Units)
+	require.InDelta(t, product.Price*float64(result.Lesson.Duration)/600, result.Invoices[0].Amount, 0.01)
+
+	pkg, err := testQueries.GetStudentPackage(context.Background(), purchase.Package.PackageID)
+	require.NoError(t, err)
+	require.Equal(t, 600-result.Lesson.Duration, pkg.RemainingUnits)
+}
+
+func TestCreateLessonWithInvoicesTxExpiredPackage(t *testing.T) {
+	student := createRandomStudent(t)
+	product := createRandomPackageProduct(t, PackageUnitLesson, 10, 30)
+	purchasedAt := time.Now().UTC().Truncate(time.Second)
+	purchase := purchaseRandomPackage(t, student, product, purchasedAt)
+
+	result, err := createRandomLessonWithInvoiceTx(t, purchasedAt.AddDate(0, 0, 31), student)
+	require.NoError(t, err)
+	require.Empty(t, result.PackageDeductions)
+
+	pkg, err := testQueries.GetStudentPackage(context.Background(), purchase.Package.PackageID)
+	require.NoError(t, err)
+	require.Equal(t, pkg.TotalUnits, pkg.RemainingUnits)
+}
+
+func TestCreateLessonWithInvoicesTxPackageOrder(t *testing.T) {
+	student := createRandomStudent(t)
+	purchasedAt := time.Now().UTC().Truncate(time.Second)
+
+	// the package expiring first is used first, and packages without expiry last
+	noExpiry := purchaseRandomPackage(t, student, createRandomPackageProduct(t, PackageUnitLesson, 5, 0), purchasedAt)
+	later := purchaseRandomPackage(t, student, createRandomPackageProduct(t, PackageUnitLesson, 5, 60), purchasedAt)
+	sooner := purchaseRandomPackage(t, student, createRandomPackageProduct(t, PackageUnitLesson, 5, 30), purchasedAt)
+
+	result, err := createRandomLessonWithInvoiceTx(t, purchasedAt.Add(time.Hour), student)
+	require.NoError(t, err)
+	require.Len(t, result.PackageDeductions, 1)
+	require.Equal(t, sooner.Package.PackageID, result.PackageDeductions[0].PackageID)
+
+	result, err = createRandomLessonWithInvoiceTx(t, purchasedAt.AddDate(0, 0, 45), student)
+	require.NoError(t, err)
+	require.Len(t, result.PackageDeductions, 1)
+	require.Equal(t, later.Package.PackageID, result.PackageDeductions[0].PackageID)
+
+	result, err = createRandomLessonWithInvoiceTx(t, purchasedAt.AddDate(0, 0, 90), student)
+	require.NoError(t, err)
+	require.Len(t, result.PackageDeductions, 1)
+	require.Equal(t, noExpiry.Package.PackageID, result.PackageDeductions[0].PackageID)
+}
+
+func TestDeleteLessonWithInvoicesTxRestoresPackage(t *testing.T) {
+	store := NewStore(testDB)
+	student := createRandomStudent(t)
+	product := createRandomPackageProduct(t, PackageUnitLesson, 10, 0)
+	purchasedAt := time.Now().UTC().Truncate(time.Second)
+	purchase := purchaseRandomPackage(t, student, product, purchasedAt)
+
+	result, err := createRandomLessonWithInvoiceTx(t, purchasedAt.Add(time.Hour), student)
+	require.NoError(t, err)
+	require.Len(t, result.PackageDeductions, 1)
+
+	lesson, err := store.GetLessonWithInvoicesTx(context.Background(), result.Lesson.LessonID)
+	require.NoError(t, err)
+	require.Equal(t, result.PackageDeductions, lesson.PackageDeductions)
+
+	err = store.DeleteLessonWithInvoicesTx(context.Background(), result.Lesson.LessonID)
+	require.NoError(t, err)
+
+	pkg, err := testQueries.GetStudentPackage(context.Background(), purchase.Package.PackageID)
+	require.NoError(t, err)
+	require.Equal(t, pkg.TotalUnits, pkg.RemainingUnits)
+
+	deductions, err := testQueries.GetPackageDeductionsByPackage(context.Background(), purchase.Package.PackageID)
+	require.NoError(t, err)
+	require.Empty(t, deductions)
+}
+
+func TestGetStudentPackageBalanceTx(t *testing.T) {
+	store := NewStore(testDB)
+	student := createRandomStudent(t)
+	purchasedAt := time.Now().UTC().Truncate(time.Second)
+
+	lessons1 := purchaseRandomPackage(t, student, createRandomPackageProduct(t, PackageUnitLesson, 4, 0), purchasedAt)
+	lessons2 := purchaseRandomPackage(t, student, createRandomPackageProduct(t, PackageUnitLesson, 6, 60), purchasedAt)
+	hours := purchaseRandomPackage(t, student, createRandomPackageProduct(t, PackageUnitHour, 2, 30), purchasedAt)
+	purchaseRandomPackage(t, student, createRandomPackageProduct(t, PackageUnitHour, 2, 10), purchasedAt.AddDate(0, 0, -20))
+
+	balance, err := store.GetStudentPackageBalanceTx(context.Background(), student.StudentID, purchasedAt.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, student.StudentID, balance.StudentID)
+
+	// the expired hour package isn't included
+	require.Len(t, balance.Packages, 3)
+	require.Equal(t, hours.Package.PackageID, balance.Packages[0].PackageID)
+	require.Equal(t, lessons2.Package.PackageID, balance.Packages[1].PackageID)
+	require.Equal(t, lessons1.Package.PackageID, balance.Packages[2].PackageID)
+
+	require.Len(t, balance.Balances, 2)
+
+	require.Equal(t, PackageUnitHour, balance.Balances[0].Unit)
+	require.Equal(t, int64(1), balance.Balances[0].Packages)
+	require.Equal(t, int64(120), balance.Balances[0].RemainingUnits)
+	require.InDelta(t, hours.Package.Price, balance.Balances[0].RemainingAmount, 0.001)
+
+	require.Equal(t, PackageUnitLesson, balance.Balances[1].Unit)
+	require.Equal(t, int64(2), balance.Balances[1].Packages)
+	require.Equal(t, int64(10), balance.Balances[1].RemainingUnits)
+	require.InDelta(t, lessons1.Package.Price+lessons2.Package.Price, balance.Balances[1].RemainingAmount, 0.001)
+
+	_, err = store.GetStudentPackageBalanceTx(context.Background(), 0, purchasedAt)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestPackageAmount(t *testing.T) {
+	pkg := StudentPackage{
+		Unit:           PackageUnitLesson,
+		TotalUnits:     3,
+		RemainingUnits: 3,
+		Price:          100,
+	}
+
+	var total float64
+	for pkg.RemainingUnits > 0 {
+		amount := packageAmount(pkg, 1)
+		require.InDelta(t, 33.33, amount, 0.011)
+
+		total += amount
+		pkg.RemainingUnits--
+	}
+
+	require.InDelta(t, 100, total, 0.0001)
+}
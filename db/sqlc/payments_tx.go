@@ -58,49 +58,73 @@ type CreateReceiptTxParams struct {
 
 // CreateReceiptWithPaymentsTx creates a Receipt and all the Payments releated to it.
 // Receipt amount is calculated end updated based on all payments.
+// The receipt confirmation email of the student is added to the email outbox, and the receipt.created webhook event is posted.
 func (store *SQLStore) CreateReceiptWithPaymentsTx(ctx context.Context, arg CreateReceiptTxParams) (ReceiptWithPayments, error) {
 	var result ReceiptWithPayments
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = q.createReceiptWithPayments(ctx, arg)
+		return err
+	})
 
-		createReceiptArg := CreateReceiptParams{
-			StudentID:       arg.StudentID,
-			ReceiptDatetime: arg.ReceiptDatetime,
-			Amount:          0.0,
-			Notes:           arg.Notes,
-		}
+	return result, err
+}
 
-		result.Receipt, err = q.CreateReceipt(ctx, createReceiptArg)
-		if err != nil {
-			return err
-		}
+// createReceiptWithPayments creates a Receipt and all the Payments releated to it, within a transaction.
+func (q *Queries) createReceiptWithPayments(ctx context.Context, arg CreateReceiptTxParams) (ReceiptWithPayments, error) {
+	result, err := q.insertReceiptWithPayments(ctx, arg, sql.NullInt64{})
+	if err != nil {
+		return result, err
+	}
 
-		for _, paymentArg := range arg.ReceiptPaymentsParams {
-			createPaymentArg := CreatePaymentParams{
-				ReceiptID:       result.Receipt.ReceiptID,
-				PaymentDatetime: paymentArg.PaymentDatetime,
-				PaymentMethodID: paymentArg.PaymentMethodID,
-			}
+	err = q.enqueueReceiptEmail(ctx, result.Receipt, result.Payments)
+	if err != nil {
+		return result, err
+	}
 
-			payment, err := q.CreatePayment(ctx, createPaymentArg)
-			if err != nil {
-				return err
-			}
+	return result, q.enqueueWebhookEvent(ctx, WebhookEventReceiptCreated, result)
+}
 
-			result.Receipt.Amount += payment.Amount
-			result.Payments = append(result.Payments, payment)
+// insertReceiptWithPayments creates a Receipt and all the Payments releated to it, allocated from a guardian receipt if guardianReceiptID is valid,
+// without the receipt confirmation email and webhook event, within a transaction.
+func (q *Queries) insertReceiptWithPayments(ctx context.Context, arg CreateReceiptTxParams, guardianReceiptID sql.NullInt64) (ReceiptWithPayments, error) {
+	var result ReceiptWithPayments
+	var err error
+
+	createReceiptArg := CreateReceiptParams{
+		StudentID:         arg.StudentID,
+		ReceiptDatetime:   arg.ReceiptDatetime,
+		Amount:            0.0,
+		Notes:             arg.Notes,
+		GuardianReceiptID: guardianReceiptID,
+	}
+
+	result.Receipt, err = q.CreateReceipt(ctx, createReceiptArg)
+	if err != nil {
+		return result, err
+	}
+
+	for _, paymentArg := range arg.ReceiptPaymentsParams {
+		createPaymentArg := CreatePaymentParams{
+			ReceiptID:       result.Receipt.ReceiptID,
+			PaymentDatetime: paymentArg.PaymentDatetime,
+			Amount:          paymentArg.Amount,
+			PaymentMethodID: paymentArg.PaymentMethodID,
 		}
 
-		err = q.UpdateReceiptAmount(ctx, UpdateReceiptAmountParams{
-			ReceiptID: result.Receipt.ReceiptID,
-			Amount:    result.Receipt.Amount,
-		})
+		payment, err := q.CreatePayment(ctx, createPaymentArg)
 		if err != nil {
-			return err
+			return result, err
 		}
 
-		return nil
+		result.Receipt.Amount += payment.Amount
+		result.Payments = append(result.Payments, payment)
+	}
+
+	err = q.UpdateReceiptAmount(ctx, UpdateReceiptAmountParams{
+		ReceiptID: result.Receipt.ReceiptID,
+		Amount:    result.Receipt.Amount,
 	})
 
 	return result, err
@@ -131,24 +155,38 @@ func (store *SQLStore) GetReceiptWithPaymentsTx(ctx context.Context, receiptID i
 }
 
 // DeleteReceiptWithPaymentsTx deletes a Receipt and all the Payments releated to it.
+// It returns ErrGuardianReceiptAllocation if the receipt is allocated from a guardian receipt,
+// which is deleted as a whole with DeleteGuardianReceiptTx.
 func (store *SQLStore) DeleteReceiptWithPaymentsTx(ctx context.Context, receiptID int64) error {
 	err := store.execTx(ctx, func(q *Queries) error {
-		err := q.DeletePaymentsByReceipt(ctx, receiptID)
+		receipt, err := q.GetReceipt(ctx, receiptID)
+		if err == sql.ErrNoRows {
+			return nil
+		}
 		if err != nil {
 			return err
 		}
 
-		err = q.DeleteReceipt(ctx, receiptID)
-		if err != nil {
-			return err
+		if receipt.GuardianReceiptID.Valid {
+			return ErrGuardianReceiptAllocation
 		}
 
-		return nil
+		return q.deleteReceiptWithPayments(ctx, receiptID)
 	})
 
 	return err
 }
 
+// deleteReceiptWithPayments deletes a Receipt and all the Payments releated to it.
+func (q *Queries) deleteReceiptWithPayments(ctx context.Context, receiptID int64) error {
+	err := q.DeletePaymentsByReceipt(ctx, receiptID)
+	if err != nil {
+		return err
+	}
+
+	return q.DeleteReceipt(ctx, receiptID)
+}
+
 // GetReceiptsWithPaymentsByStudentTx gets all Receipts of a single student, and all the Payments releated to each receipt.
 // limit is used to determine the number of rows (row_count) returned by the query.
 // offset is used to skip a number of rows before beginning to return the rows.
@@ -56,7 +56,7 @@ func createRandomReceiptWithPaymentsTx(t *testing.T, nPayments int) ReceiptWithP
 
 	// check each Payment in Payments
 	amount := 0.0
-	for _, v := range result.Payments {
+	for i, v := range result.Payments {
 		require.NotEmpty(t, v)
 		require.NotZero(t, v.PaymentID)
 
@@ -70,6 +70,9 @@ func createRandomReceiptWithPaymentsTx(t *testing.T, nPayments int) ReceiptWithP
 		require.Equal(t, v.Amount, payment.Amount)
 		require.Equal(t, v.PaymentMethodID, payment.PaymentMethodID)
 
+		// the payment keeps the amount of its params
+		require.Equal(t, arg.ReceiptPaymentsParams[i].Amount, payment.Amount)
+
 		amount += payment.Amount
 	}
 
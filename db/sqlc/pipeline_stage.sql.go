@@ -0,0 +1,118 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: pipeline_stage.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createPipelineStage = `-- name: CreatePipelineStage :one
+INSERT INTO pipeline_stages (
+  name, position
+) VALUES (
+  $1, $2
+)
+RETURNING stage_id, name, position, created_at
+`
+
+type CreatePipelineStageParams struct {
+	Name     string `json:"name"`
+	Position int32  `json:"position"`
+}
+
+func (q *Queries) CreatePipelineStage(ctx context.Context, arg CreatePipelineStageParams) (PipelineStage, error) {
+	row := q.db.QueryRowContext(ctx, createPipelineStage, arg.Name, arg.Position)
+	var i PipelineStage
+	err := row.Scan(
+		&i.StageID,
+		&i.Name,
+		&i.Position,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deletePipelineStage = `-- name: DeletePipelineStage :execrows
+DELETE FROM pipeline_stages
+WHERE stage_id = $1
+`
+
+func (q *Queries) DeletePipelineStage(ctx context.Context, stageID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deletePipelineStage, stageID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getPipelineStage = `-- name: GetPipelineStage :one
+SELECT stage_id, name, position, created_at FROM pipeline_stages
+WHERE stage_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetPipelineStage(ctx context.Context, stageID int64) (PipelineStage, error) {
+	row := q.db.QueryRowContext(ctx, getPipelineStage, stageID)
+	var i PipelineStage
+	err := row.Scan(
+		&i.StageID,
+		&i.Name,
+		&i.Position,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listPipelineStages = `-- name: ListPipelineStages :many
+SELECT stage_id, name, position, created_at FROM pipeline_stages
+ORDER BY position, stage_id
+`
+
+// ListPipelineStages lists the stages in their pipeline order.
+func (q *Queries) ListPipelineStages(ctx context.Context) ([]PipelineStage, error) {
+	rows, err := q.db.QueryContext(ctx, listPipelineStages)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PipelineStage{}
+	for rows.Next() {
+		var i PipelineStage
+		if err := rows.Scan(
+			&i.StageID,
+			&i.Name,
+			&i.Position,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updatePipelineStage = `-- name: UpdatePipelineStage :exec
+UPDATE pipeline_stages
+  set   name = $2,
+        position = $3
+WHERE stage_id = $1
+`
+
+type UpdatePipelineStageParams struct {
+	StageID  int64  `json:"stage_id"`
+	Name     string `json:"name"`
+	Position int32  `json:"position"`
+}
+
+func (q *Queries) UpdatePipelineStage(ctx context.Context, arg UpdatePipelineStageParams) error {
+	_, err := q.db.ExecContext(ctx, updatePipelineStage, arg.StageID, arg.Name, arg.Position)
+	return err
+}
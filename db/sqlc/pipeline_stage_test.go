@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomPipelineStage adds a new random stage to the lead pipeline.
+func createRandomPipelineStage(t *testing.T) PipelineStage {
+	arg := CreatePipelineStageParams{
+		Name:     util.RandomName(),
+		Position: int32(util.RandomInt64(0, 10)),
+	}
+
+	stage, err := testQueries.CreatePipelineStage(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, stage.StageID)
+	require.Equal(t, arg.Name, stage.Name)
+	require.Equal(t, arg.Position, stage.Position)
+	require.NotZero(t, stage.CreatedAt)
+
+	return stage
+}
+
+func TestPipelineStages(t *testing.T) {
+	stage := createRandomPipelineStage(t)
+
+	err := testQueries.UpdatePipelineStage(context.Background(), UpdatePipelineStageParams{
+		StageID:  stage.StageID,
+		Name:     util.RandomName(),
+		Position: stage.Position + 1,
+	})
+	require.NoError(t, err)
+
+	updated, err := testQueries.GetPipelineStage(context.Background(), stage.StageID)
+	require.NoError(t, err)
+	require.Equal(t, stage.Position+1, updated.Position)
+
+	stages, err := testQueries.ListPipelineStages(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, stages)
+	for i := 1; i < len(stages); i++ {
+		require.LessOrEqual(t, stages[i-1].Position, stages[i].Position)
+	}
+
+	rows, err := testQueries.DeletePipelineStage(context.Background(), stage.StageID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = testQueries.GetPipelineStage(context.Background(), stage.StageID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
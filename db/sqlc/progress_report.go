@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// ProgressReportDocument contains everything shown on a progress report: the student and their billing contact,
+// the lessons and hours of the student in each subject, and the lessons of the period with their lesson records.
+type ProgressReportDocument struct {
+	ProgressReport ProgressReport                 `json:"progress_report"`
+	Student        Student                        `json:"student"`
+	BillingContact *Guardian                      `json:"billing_contact"`
+	Subjects       []GetProgressReportSubjectsRow `json:"subjects"`
+	Lessons        []GetProgressReportLessonsRow  `json:"lessons"`
+}
+
+// CreateProgressReportsTx creates the progress reports of the period from start to end, for all students with lessons
+// in the period or for a single student if studentID is valid, and adds the progress report emails of the students
+// to the email outbox. Students that already have a progress report of the period are skipped,
+// so only the created progress reports are returned.
+func (store *SQLStore) CreateProgressReportsTx(ctx context.Context, start, end time.Time, studentID sql.NullInt64) ([]ProgressReport, error) {
+	var result []ProgressReport
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CreateProgressReports(ctx, CreateProgressReportsParams{
+			PeriodStart: start,
+			PeriodEnd:   end,
+			StudentID:   studentID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, report := range result {
+			doc, err := q.getProgressReportDocument(ctx, report)
+			if err != nil {
+				return err
+			}
+
+			payload := EmailPayload{
+				Student:        doc.Student,
+				Guardian:       doc.BillingContact,
+				ProgressReport: &doc,
+			}
+
+			err = q.enqueueStudentEmail(ctx, EmailTemplateProgressReport, time.Now().UTC(), payload)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// GetProgressReportDocumentTx gets a progress report with its student, billing contact, subjects and lessons.
+func (store *SQLStore) GetProgressReportDocumentTx(ctx context.Context, progressReportID int64) (ProgressReportDocument, error) {
+	var result ProgressReportDocument
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		report, err := q.GetProgressReport(ctx, progressReportID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.getProgressReportDocument(ctx, report)
+		return err
+	})
+
+	return result, err
+}
+
+// getProgressReportDocument gets the student, billing contact, subjects and lessons of a progress report.
+func (q *Queries) getProgressReportDocument(ctx context.Context, report ProgressReport) (ProgressReportDocument, error) {
+	result := ProgressReportDocument{ProgressReport: report}
+	var err error
+
+	result.Student, err = q.GetStudent(ctx, report.StudentID)
+	if err != nil {
+		return result, err
+	}
+
+	result.BillingContact, err = q.getBillingContact(ctx, report.StudentID)
+	if err != nil {
+		return result, err
+	}
+
+	result.Subjects, err = q.GetProgressReportSubjects(ctx, GetProgressReportSubjectsParams{
+		StudentID:     report.StudentID,
+		StartDatetime: report.PeriodStart,
+		EndDatetime:   report.PeriodEnd,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Lessons, err = q.GetProgressReportLessons(ctx, GetProgressReportLessonsParams{
+		StudentID:     report.StudentID,
+		StartDatetime: report.PeriodStart,
+		EndDatetime:   report.PeriodEnd,
+	})
+
+	return result, err
+}
@@ -0,0 +1,284 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: progress_report.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createProgressReports = `-- name: CreateProgressReports :many
+INSERT INTO progress_reports (
+  student_id, period_start, period_end, lessons_count, attended_count, hours, homework_assigned_count, homework_completed_count
+)
+SELECT a.student_id,
+       $1::timestamptz,
+       $2::timestamptz,
+       COUNT(*),
+       COUNT(*) FILTER (WHERE a.status = 'attended'),
+       (COALESCE(SUM(l.duration) FILTER (WHERE a.status = 'attended'), 0) / 60.0)::float,
+       COUNT(r.lesson_id) FILTER (WHERE r.homework_status <> 'none'),
+       COUNT(r.lesson_id) FILTER (WHERE r.homework_status = 'completed')
+FROM lesson_attendance a
+JOIN lessons l ON l.lesson_id = a.lesson_id
+LEFT JOIN lesson_records r ON r.lesson_id = a.lesson_id AND r.student_id = a.student_id
+WHERE l.lesson_datetime >= $1 AND l.lesson_datetime < $2
+  AND ($3::bigint IS NULL OR a.student_id = $3)
+GROUP BY a.student_id
+ON CONFLICT (student_id, period_start, period_end) DO NOTHING
+RETURNING progress_report_id, student_id, period_start, period_end, lessons_count, attended_count, hours, homework_assigned_count, homework_completed_count, created_at
+`
+
+type CreateProgressReportsParams struct {
+	PeriodStart time.Time     `json:"period_start"`
+	PeriodEnd   time.Time     `json:"period_end"`
+	StudentID   sql.NullInt64 `json:"student_id"`
+}
+
+// CreateProgressReports creates the progress reports of a period for every student with lessons in the period,
+// optionally for a single student.
+// Students that already have a progress report of the period are skipped, so reporting a period is idempotent.
+func (q *Queries) CreateProgressReports(ctx context.Context, arg CreateProgressReportsParams) ([]ProgressReport, error) {
+	rows, err := q.db.QueryContext(ctx, createProgressReports, arg.PeriodStart, arg.PeriodEnd, arg.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProgressReport{}
+	for rows.Next() {
+		var i ProgressReport
+		if err := rows.Scan(
+			&i.ProgressReportID,
+			&i.StudentID,
+			&i.PeriodStart,
+			&i.PeriodEnd,
+			&i.LessonsCount,
+			&i.AttendedCount,
+			&i.Hours,
+			&i.HomeworkAssignedCount,
+			&i.HomeworkCompletedCount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProgressReport = `-- name: GetProgressReport :one
+SELECT progress_report_id, student_id, period_start, period_end, lessons_count, attended_count, hours, homework_assigned_count, homework_completed_count, created_at FROM progress_reports
+WHERE progress_report_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetProgressReport(ctx context.Context, progressReportID int64) (ProgressReport, error) {
+	row := q.db.QueryRowContext(ctx, getProgressReport, progressReportID)
+	var i ProgressReport
+	err := row.Scan(
+		&i.ProgressReportID,
+		&i.StudentID,
+		&i.PeriodStart,
+		&i.PeriodEnd,
+		&i.LessonsCount,
+		&i.AttendedCount,
+		&i.Hours,
+		&i.HomeworkAssignedCount,
+		&i.HomeworkCompletedCount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getProgressReportLessons = `-- name: GetProgressReportLessons :many
+SELECT l.lesson_id, l.lesson_datetime, l.duration, l.subject_id, s.name AS subject_name,
+       a.status AS attendance_status,
+       r.topics, r.homework, r.homework_status, r.rating, r.notes
+FROM lesson_attendance a
+JOIN lessons l ON l.lesson_id = a.lesson_id
+JOIN lesson_subjects s ON s.subject_id = l.subject_id
+LEFT JOIN lesson_records r ON r.lesson_id = a.lesson_id AND r.student_id = a.student_id
+WHERE a.student_id = $1
+  AND l.lesson_datetime >= $2 AND l.lesson_datetime < $3
+ORDER BY l.lesson_datetime
+`
+
+type GetProgressReportLessonsParams struct {
+	StudentID     int64     `json:"student_id"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+type GetProgressReportLessonsRow struct {
+	LessonID         int64          `json:"lesson_id"`
+	LessonDatetime   time.Time      `json:"lesson_datetime"`
+	Duration         int64          `json:"duration"`
+	SubjectID        int64          `json:"subject_id"`
+	SubjectName      string         `json:"subject_name"`
+	AttendanceStatus string         `json:"attendance_status"`
+	Topics           sql.NullString `json:"topics"`
+	Homework         sql.NullString `json:"homework"`
+	HomeworkStatus   sql.NullString `json:"homework_status"`
+	Rating           sql.NullInt32  `json:"rating"`
+	Notes            sql.NullString `json:"notes"`
+}
+
+// GetProgressReportLessons returns the lessons of a student in a period, earliest first,
+// with the attendance and lesson record of the student.
+func (q *Queries) GetProgressReportLessons(ctx context.Context, arg GetProgressReportLessonsParams) ([]GetProgressReportLessonsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getProgressReportLessons, arg.StudentID, arg.StartDatetime, arg.EndDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProgressReportLessonsRow{}
+	for rows.Next() {
+		var i GetProgressReportLessonsRow
+		if err := rows.Scan(
+			&i.LessonID,
+			&i.LessonDatetime,
+			&i.Duration,
+			&i.SubjectID,
+			&i.SubjectName,
+			&i.AttendanceStatus,
+			&i.Topics,
+			&i.Homework,
+			&i.HomeworkStatus,
+			&i.Rating,
+			&i.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProgressReportSubjects = `-- name: GetProgressReportSubjects :many
+SELECT s.subject_id, s.name,
+       COUNT(*) AS lessons_count,
+       COUNT(*) FILTER (WHERE a.status = 'attended') AS attended_count,
+       (COALESCE(SUM(l.duration) FILTER (WHERE a.status = 'attended'), 0) / 60.0)::float AS hours
+FROM lesson_attendance a
+JOIN lessons l ON l.lesson_id = a.lesson_id
+JOIN lesson_subjects s ON s.subject_id = l.subject_id
+WHERE a.student_id = $1
+  AND l.lesson_datetime >= $2 AND l.lesson_datetime < $3
+GROUP BY s.subject_id, s.name
+ORDER BY s.name
+`
+
+type GetProgressReportSubjectsParams struct {
+	StudentID     int64     `json:"student_id"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+type GetProgressReportSubjectsRow struct {
+	SubjectID     int64   `json:"subject_id"`
+	Name          string  `json:"name"`
+	LessonsCount  int64   `json:"lessons_count"`
+	AttendedCount int64   `json:"attended_count"`
+	Hours         float64 `json:"hours"`
+}
+
+// GetProgressReportSubjects returns the lessons and hours of a student in each subject in a period.
+// Hours are of the attended lessons only.
+func (q *Queries) GetProgressReportSubjects(ctx context.Context, arg GetProgressReportSubjectsParams) ([]GetProgressReportSubjectsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getProgressReportSubjects, arg.StudentID, arg.StartDatetime, arg.EndDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProgressReportSubjectsRow{}
+	for rows.Next() {
+		var i GetProgressReportSubjectsRow
+		if err := rows.Scan(
+			&i.SubjectID,
+			&i.Name,
+			&i.LessonsCount,
+			&i.AttendedCount,
+			&i.Hours,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProgressReports = `-- name: ListProgressReports :many
+SELECT progress_report_id, student_id, period_start, period_end, lessons_count, attended_count, hours, homework_assigned_count, homework_completed_count, created_at FROM progress_reports
+WHERE ($1::bigint IS NULL OR student_id = $1)
+  AND ($2::timestamptz IS NULL OR period_start = $2)
+ORDER BY period_start DESC, student_id
+LIMIT $4
+OFFSET $3
+`
+
+type ListProgressReportsParams struct {
+	StudentID   sql.NullInt64 `json:"student_id"`
+	PeriodStart sql.NullTime  `json:"period_start"`
+	Offset      int32         `json:"offset"`
+	Limit       int32         `json:"limit"`
+}
+
+func (q *Queries) ListProgressReports(ctx context.Context, arg ListProgressReportsParams) ([]ProgressReport, error) {
+	rows, err := q.db.QueryContext(ctx, listProgressReports,
+		arg.StudentID,
+		arg.PeriodStart,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProgressReport{}
+	for rows.Next() {
+		var i ProgressReport
+		if err := rows.Scan(
+			&i.ProgressReportID,
+			&i.StudentID,
+			&i.PeriodStart,
+			&i.PeriodEnd,
+			&i.LessonsCount,
+			&i.AttendedCount,
+			&i.Hours,
+			&i.HomeworkAssignedCount,
+			&i.HomeworkCompletedCount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
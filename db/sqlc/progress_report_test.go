@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomProgressReportData adds three lessons of a new random student in month and one before it.
+// The student attends the first two lessons, with homework completed in the first and assigned in the second,
+// and is absent from the third. It returns the student and the hours of the attended lessons of month.
+func createRandomProgressReportData(t *testing.T, month time.Time) (Student, float64) {
+	store := NewStore(testDB)
+	student := createRandomStudent(t)
+
+	var lessons []Lesson
+	for _, datetime := range []time.Time{month.Add(-48 * time.Hour), month.Add(24 * time.Hour), month.Add(5 * 24 * time.Hour), month.Add(10 * 24 * time.Hour)} {
+		result, err := createRandomLessonWithInvoiceTx(t, datetime, student)
+		require.NoError(t, err)
+		lessons = append(lessons, result.Lesson)
+	}
+
+	for i, status := range []string{HomeworkStatusCompleted, HomeworkStatusAssigned} {
+		_, err := store.UpsertLessonRecordTx(context.Background(), UpsertLessonRecordParams{
+			LessonID:       lessons[i+1].LessonID,
+			StudentID:      student.StudentID,
+			Topics:         sql.NullString{String: util.RandomNote(), Valid: true},
+			Homework:       sql.NullString{String: util.RandomNote(), Valid: true},
+			HomeworkStatus: status,
+			Notes:          sql.NullString{String: util.RandomNote(), Valid: true},
+		})
+		require.NoError(t, err)
+	}
+
+	_, err := testQueries.UpdateLessonAttendance(context.Background(), UpdateLessonAttendanceParams{
+		LessonID:  lessons[3].LessonID,
+		StudentID: student.StudentID,
+		Status:    AttendanceStatusAbsent,
+	})
+	require.NoError(t, err)
+
+	return student, float64(lessons[1].Duration+lessons[2].Duration) / 60.0
+}
+
+func TestCreateProgressReportsTx(t *testing.T) {
+	store := NewStore(testDB)
+	start := time.Date(2033, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	student, hours := createRandomProgressReportData(t, start)
+	studentID := sql.NullInt64{Int64: student.StudentID, Valid: true}
+
+	reports, err := store.CreateProgressReportsTx(context.Background(), start, end, studentID)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+
+	report := reports[0]
+	require.NotZero(t, report.ProgressReportID)
+	require.Equal(t, student.StudentID, report.StudentID)
+	require.True(t, start.Equal(report.PeriodStart))
+	require.True(t, end.Equal(report.PeriodEnd))
+	require.Equal(t, int64(3), report.LessonsCount)
+	require.Equal(t, int64(2), report.AttendedCount)
+	require.InDelta(t, hours, report.Hours, 0.001)
+	require.Equal(t, int64(2), report.HomeworkAssignedCount)
+	require.Equal(t, int64(1), report.HomeworkCompletedCount)
+
+	// the progress report email is added to the outbox
+	emails, err := testQueries.GetOutboxEmailsByStudent(context.Background(), student.StudentID)
+	require.NoError(t, err)
+
+	var payload EmailPayload
+	for _, email := range emails {
+		if email.Template == EmailTemplateProgressReport {
+			require.NoError(t, json.Unmarshal(email.Payload, &payload))
+		}
+	}
+	require.NotNil(t, payload.ProgressReport)
+	require.Equal(t, report.ProgressReportID, payload.ProgressReport.ProgressReport.ProgressReportID)
+	require.Len(t, payload.ProgressReport.Lessons, 3)
+
+	// reporting the period again creates no progress reports
+	reports, err = store.CreateProgressReportsTx(context.Background(), start, end, studentID)
+	require.NoError(t, err)
+	require.Empty(t, reports)
+
+	// students without lessons in the period get no progress report
+	reports, err = store.CreateProgressReportsTx(context.Background(), start, end, sql.NullInt64{Int64: createRandomStudent(t).StudentID, Valid: true})
+	require.NoError(t, err)
+	require.Empty(t, reports)
+}
+
+func TestGetProgressReportDocumentTx(t *testing.T) {
+	store := NewStore(testDB)
+	start := time.Date(2034, time.May, 1, 0, 0, 0, 0, time.UTC)
+	student, hours := createRandomProgressReportData(t, start)
+
+	reports, err := store.CreateProgressReportsTx(context.Background(), start, start.AddDate(0, 1, 0), sql.NullInt64{Int64: student.StudentID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+
+	doc, err := store.GetProgressReportDocumentTx(context.Background(), reports[0].ProgressReportID)
+	require.NoError(t, err)
+	require.Equal(t, reports[0].ProgressReportID, doc.ProgressReport.ProgressReportID)
+	require.Equal(t, student.StudentID, doc.Student.StudentID)
+
+	// every lesson has its own subject
+	require.Len(t, doc.Subjects, 3)
+	total := 0.0
+	for _, subject := range doc.Subjects {
+		require.Equal(t, int64(1), subject.LessonsCount)
+		total += subject.Hours
+	}
+	require.InDelta(t, hours, total, 0.001)
+
+	require.Len(t, doc.Lessons, 3)
+	require.Equal(t, sql.NullString{String: HomeworkStatusCompleted, Valid: true}, doc.Lessons[0].HomeworkStatus)
+	require.Equal(t, AttendanceStatusAbsent, doc.Lessons[2].AttendanceStatus)
+	require.False(t, doc.Lessons[2].Topics.Valid)
+
+	_, err = store.GetProgressReportDocumentTx(context.Background(), -1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListProgressReports(t *testing.T) {
+	store := NewStore(testDB)
+	start := time.Date(2035, time.July, 1, 0, 0, 0, 0, time.UTC)
+	student, _ := createRandomProgressReportData(t, start)
+	studentID := sql.NullInt64{Int64: student.StudentID, Valid: true}
+
+	_, err := store.CreateProgressReportsTx(context.Background(), start, start.AddDate(0, 1, 0), studentID)
+	require.NoError(t, err)
+
+	reports, err := testQueries.ListProgressReports(context.Background(), ListProgressReportsParams{
+		StudentID:   studentID,
+		PeriodStart: sql.NullTime{Time: start, Valid: true},
+		Limit:       5,
+		Offset:      0,
+	})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, student.StudentID, reports[0].StudentID)
+}
@@ -6,66 +6,350 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	// AddStudentTag tags a student. Tagging a student with one of its tags has no effect.
+	AddStudentTag(ctx context.Context, arg AddStudentTagParams) error
+	ApproveBookingRequest(ctx context.Context, arg ApproveBookingRequestParams) (BookingRequest, error)
+	// ClaimLessonReminder claims the reminder of a student for a lesson, so only one scheduler sends it.
+	// A failed reminder is claimed again until it was attempted max_attempts times,
+	// and a reminder claimed before stale_before by a scheduler that stopped is claimed again.
+	// No row is returned if the reminder can't be claimed.
+	ClaimLessonReminder(ctx context.Context, arg ClaimLessonReminderParams) (LessonReminder, error)
+	// ClaimOutboxEmails locks pending emails that are due, and postpones them by the lease,
+	// so other dispatchers don't send them while they are being sent.
+	ClaimOutboxEmails(ctx context.Context, arg ClaimOutboxEmailsParams) ([]EmailOutbox, error)
+	// ClaimWebhookDeliveries locks pending deliveries that are due, and postpones them by the lease,
+	// so other dispatchers don't post them while they are being posted.
+	// The url and secret of the subscription of each delivery are returned with it.
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]ClaimWebhookDeliveriesRow, error)
+	ClearStudentBillingContact(ctx context.Context, studentID int64) error
+	ConvertLead(ctx context.Context, arg ConvertLeadParams) (Lead, error)
+	CountLessonAttachments(ctx context.Context, lessonID sql.NullInt64) (int64, error)
+	CountNewStudents(ctx context.Context, arg CountNewStudentsParams) (int64, error)
+	CreateActivity(ctx context.Context, arg CreateActivityParams) (Activity, error)
+	CreateAttachment(ctx context.Context, arg CreateAttachmentParams) (Attachment, error)
+	CreateAvailabilityException(ctx context.Context, arg CreateAvailabilityExceptionParams) (AvailabilityException, error)
+	CreateAvailabilityWindow(ctx context.Context, arg CreateAvailabilityWindowParams) (AvailabilityWindow, error)
+	CreateBookingRequest(ctx context.Context, arg CreateBookingRequestParams) (BookingRequest, error)
 	CreateCollege(ctx context.Context, name string) (College, error)
+	CreateCustomField(ctx context.Context, arg CreateCustomFieldParams) (CustomField, error)
+	// CreateExam creates an exam of a student. The exam is taken for the college of the student, unless a college is given.
+	CreateExam(ctx context.Context, arg CreateExamParams) (Exam, error)
+	CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error)
 	CreateFunnel(ctx context.Context, name string) (Funnel, error)
+	CreateFunnelCost(ctx context.Context, arg CreateFunnelCostParams) (FunnelCost, error)
+	CreateGroupPricingPlan(ctx context.Context, arg CreateGroupPricingPlanParams) (GroupPricingPlan, error)
+	CreateGroupPricingTier(ctx context.Context, arg CreateGroupPricingTierParams) (GroupPricingTier, error)
+	CreateGuardian(ctx context.Context, arg CreateGuardianParams) (Guardian, error)
+	CreateGuardianReceipt(ctx context.Context, arg CreateGuardianReceiptParams) (GuardianReceipt, error)
 	CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (Invoice, error)
+	CreateJobRun(ctx context.Context, arg CreateJobRunParams) (JobRun, error)
+	CreateLead(ctx context.Context, arg CreateLeadParams) (Lead, error)
 	CreateLesson(ctx context.Context, arg CreateLessonParams) (Lesson, error)
+	CreateLessonAttendance(ctx context.Context, arg CreateLessonAttendanceParams) (LessonAttendance, error)
 	CreateLessonLocation(ctx context.Context, name string) (LessonLocation, error)
 	CreateLessonSubject(ctx context.Context, name string) (LessonSubject, error)
+	CreateOutboxEmail(ctx context.Context, arg CreateOutboxEmailParams) (EmailOutbox, error)
+	CreatePackageDeduction(ctx context.Context, arg CreatePackageDeductionParams) (PackageDeduction, error)
+	CreatePackageProduct(ctx context.Context, arg CreatePackageProductParams) (PackageProduct, error)
 	CreatePayment(ctx context.Context, arg CreatePaymentParams) (Payment, error)
 	CreatePaymentMethod(ctx context.Context, name string) (PaymentMethod, error)
+	CreatePipelineStage(ctx context.Context, arg CreatePipelineStageParams) (PipelineStage, error)
+	// CreateProgressReports creates the progress reports of a period for every student with lessons in the period,
+	// optionally for a single student.
+	// Students that already have a progress report of the period are skipped, so reporting a period is idempotent.
+	CreateProgressReports(ctx context.Context, arg CreateProgressReportsParams) ([]ProgressReport, error)
 	CreateReceipt(ctx context.Context, arg CreateReceiptParams) (Receipt, error)
+	// CreateStatements creates the statements of a period for every student with invoices or payments in the period,
+	// or with an outstanding balance before it, optionally for a single student.
+	// Students that already have a statement of the period are skipped, so billing a period is idempotent.
+	CreateStatements(ctx context.Context, arg CreateStatementsParams) ([]Statement, error)
 	CreateStudent(ctx context.Context, arg CreateStudentParams) (Student, error)
+	CreateStudentPackage(ctx context.Context, arg CreateStudentPackageParams) (StudentPackage, error)
+	CreateTag(ctx context.Context, name string) (Tag, error)
+	CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error)
+	CreateWaitlistEntry(ctx context.Context, arg CreateWaitlistEntryParams) (WaitlistEntry, error)
+	// CreateWebhookDeliveries adds a delivery of the event for every active subscription to the event.
+	CreateWebhookDeliveries(ctx context.Context, arg CreateWebhookDeliveriesParams) error
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
+	// DeclineBookingRequest declines a pending booking request. It returns no rows if the request isn't pending.
+	DeclineBookingRequest(ctx context.Context, bookingRequestID int64) (BookingRequest, error)
+	DeductStudentPackage(ctx context.Context, arg DeductStudentPackageParams) error
+	DeleteActivity(ctx context.Context, activityID int64) (int64, error)
+	DeleteAttachment(ctx context.Context, attachmentID int64) (int64, error)
+	DeleteAvailabilityException(ctx context.Context, exceptionID int64) (int64, error)
+	DeleteAvailabilityWindow(ctx context.Context, windowID int64) (int64, error)
 	DeleteCollege(ctx context.Context, collegeID int64) error
+	DeleteCustomField(ctx context.Context, fieldID int64) (int64, error)
+	DeleteExam(ctx context.Context, examID int64) (int64, error)
 	DeleteFunnel(ctx context.Context, funnelID int64) error
+	DeleteFunnelCost(ctx context.Context, funnelCostID int64) error
+	DeleteGroupPricingTiers(ctx context.Context, planID int64) error
+	DeleteGuardianReceipt(ctx context.Context, guardianReceiptID int64) error
 	DeleteInvoice(ctx context.Context, invoiceID int64) error
 	DeleteInvoicesByLesson(ctx context.Context, lessonID int64) error
+	// DeleteJobRunsBefore deletes the finished runs that started before a specific time.
+	DeleteJobRunsBefore(ctx context.Context, startedAt time.Time) (int64, error)
+	DeleteLead(ctx context.Context, leadID int64) (int64, error)
 	DeleteLesson(ctx context.Context, lessonID int64) error
+	DeleteLessonAttendance(ctx context.Context, arg DeleteLessonAttendanceParams) (int64, error)
+	DeleteLessonAttendanceByLesson(ctx context.Context, lessonID int64) error
 	DeleteLessonLocation(ctx context.Context, locationID int64) error
+	DeleteLessonRecord(ctx context.Context, arg DeleteLessonRecordParams) error
+	DeleteLessonRecordsByLesson(ctx context.Context, lessonID int64) error
 	DeleteLessonSubject(ctx context.Context, subjectID int64) error
+	DeletePackageDeduction(ctx context.Context, deductionID int64) error
+	DeletePackageDeductionsByLesson(ctx context.Context, lessonID int64) error
 	DeletePayment(ctx context.Context, paymentID int64) error
 	DeletePaymentMethod(ctx context.Context, paymentMethodID int64) error
 	DeletePaymentsByReceipt(ctx context.Context, receiptID int64) error
+	DeletePendingOutboxEmailsByLesson(ctx context.Context, lessonID sql.NullInt64) error
+	DeletePipelineStage(ctx context.Context, stageID int64) (int64, error)
 	DeleteReceipt(ctx context.Context, receiptID int64) error
 	DeleteStudent(ctx context.Context, studentID int64) error
+	DeleteStudentCustomValue(ctx context.Context, arg DeleteStudentCustomValueParams) (int64, error)
+	DeleteStudentGuardian(ctx context.Context, arg DeleteStudentGuardianParams) (int64, error)
+	DeleteTag(ctx context.Context, tagID int64) (int64, error)
+	DeleteTask(ctx context.Context, taskID int64) (int64, error)
+	DeleteWaitlistEntry(ctx context.Context, waitlistEntryID int64) (int64, error)
+	// ExportStudents lists all the students matching the filters of ListStudents, by name.
+	ExportStudents(ctx context.Context, arg ExportStudentsParams) ([]Student, error)
+	FinishJobRun(ctx context.Context, arg FinishJobRunParams) (JobRun, error)
+	// GetActiveStudentPackages returns the packages of a student with units remaining that aren't expired at as_of,
+	// in the order they are deducted from.
+	GetActiveStudentPackages(ctx context.Context, arg GetActiveStudentPackagesParams) ([]StudentPackage, error)
+	GetActivity(ctx context.Context, activityID int64) (Activity, error)
+	GetAgingReport(ctx context.Context, asOfDatetime time.Time) ([]GetAgingReportRow, error)
+	// GetApplicableFeeSchedule gets the fee schedule of a student in a subject at lesson_datetime.
+	// A schedule of the student in the subject precedes a schedule of the student, which precedes a schedule of the subject,
+	// which precedes a schedule of all lessons. Among schedules of the same kind the latest effective one applies.
+	GetApplicableFeeSchedule(ctx context.Context, arg GetApplicableFeeScheduleParams) (FeeSchedule, error)
+	GetAttachment(ctx context.Context, attachmentID int64) (Attachment, error)
+	GetAvailabilityException(ctx context.Context, exceptionID int64) (AvailabilityException, error)
+	GetAvailabilityWindow(ctx context.Context, windowID int64) (AvailabilityWindow, error)
+	GetBillingContact(ctx context.Context, studentID int64) (Guardian, error)
+	GetBookingRequest(ctx context.Context, bookingRequestID int64) (BookingRequest, error)
+	GetBookingRequestForUpdate(ctx context.Context, bookingRequestID int64) (BookingRequest, error)
 	GetCollege(ctx context.Context, collegeID int64) (College, error)
+	GetCollegeRevenueReport(ctx context.Context, arg GetCollegeRevenueReportParams) ([]GetCollegeRevenueReportRow, error)
+	GetCustomField(ctx context.Context, fieldID int64) (CustomField, error)
+	// GetDashboardLessons returns the lessons held between the datetimes, with the number of students attending each lesson,
+	// whether invoiced or attending for free, and the amount invoiced for it.
+	GetDashboardLessons(ctx context.Context, arg GetDashboardLessonsParams) ([]GetDashboardLessonsRow, error)
+	// GetDueLessonReminders returns the reminders due for students attending lessons held up to horizon,
+	// whether invoiced or attending for free. Students marked absent or excused aren't reminded.
+	// Students without reminder preferences are reminded by email, 24 hours before the lesson.
+	// Reminders already sent, failed after max_attempts, or being sent by another scheduler since stale_before, are excluded.
+	GetDueLessonReminders(ctx context.Context, arg GetDueLessonRemindersParams) ([]GetDueLessonRemindersRow, error)
+	GetExam(ctx context.Context, examID int64) (Exam, error)
+	// GetExamOutcomeReport returns the outcomes of the exams of each subject.
+	// Averages are of the graded exams only, and are 0 if there are none.
+	GetExamOutcomeReport(ctx context.Context, arg GetExamOutcomeReportParams) ([]GetExamOutcomeReportRow, error)
+	GetFeeSchedule(ctx context.Context, feeScheduleID int64) (FeeSchedule, error)
+	// GetFirstMatchingWaitlistEntry gets the earliest waiting entry of a subject whose preferred day and times, in the timezone
+	// of the entry, include a lesson from lesson_datetime of duration minutes. The students of the excluded_student_ids are skipped.
+	GetFirstMatchingWaitlistEntry(ctx context.Context, arg GetFirstMatchingWaitlistEntryParams) (WaitlistEntry, error)
 	GetFunnel(ctx context.Context, funnelID int64) (Funnel, error)
+	GetFunnelCohortReport(ctx context.Context, arg GetFunnelCohortReportParams) ([]GetFunnelCohortReportRow, error)
+	GetFunnelCost(ctx context.Context, funnelCostID int64) (FunnelCost, error)
+	GetFunnelRevenueReport(ctx context.Context, arg GetFunnelRevenueReportParams) ([]GetFunnelRevenueReportRow, error)
+	GetGroupPricingPlan(ctx context.Context, planID int64) (GroupPricingPlan, error)
+	GetGroupPricingTiers(ctx context.Context, planID int64) ([]GroupPricingTier, error)
+	GetGuardian(ctx context.Context, guardianID int64) (Guardian, error)
+	GetGuardianReceipt(ctx context.Context, guardianReceiptID int64) (GuardianReceipt, error)
+	GetGuardianStudents(ctx context.Context, guardianID int64) ([]GetGuardianStudentsRow, error)
 	GetInvoice(ctx context.Context, invoiceID int64) (Invoice, error)
+	GetInvoiceByLessonStudent(ctx context.Context, arg GetInvoiceByLessonStudentParams) (Invoice, error)
+	GetInvoicedAmount(ctx context.Context, arg GetInvoicedAmountParams) (float64, error)
 	GetInvoicesByLesson(ctx context.Context, lessonID int64) ([]Invoice, error)
 	GetInvoicesByStudent(ctx context.Context, studentID int64) ([]Invoice, error)
+	GetInvoicesByStudentPeriod(ctx context.Context, arg GetInvoicesByStudentPeriodParams) ([]Invoice, error)
+	GetJobRun(ctx context.Context, runID int64) (JobRun, error)
+	// GetLastScheduledJobRun returns the scheduled run of a job that was due last.
+	GetLastScheduledJobRun(ctx context.Context, jobName string) (JobRun, error)
+	// GetLatestJobRuns returns the latest run of every job.
+	GetLatestJobRuns(ctx context.Context) ([]JobRun, error)
+	GetLead(ctx context.Context, leadID int64) (Lead, error)
+	GetLeadForUpdate(ctx context.Context, leadID int64) (Lead, error)
 	GetLesson(ctx context.Context, lessonID int64) (Lesson, error)
+	GetLessonAttendance(ctx context.Context, arg GetLessonAttendanceParams) (LessonAttendance, error)
+	GetLessonAttendanceByLesson(ctx context.Context, lessonID int64) ([]LessonAttendance, error)
 	GetLessonLocation(ctx context.Context, locationID int64) (LessonLocation, error)
+	GetLessonRecord(ctx context.Context, arg GetLessonRecordParams) (LessonRecord, error)
+	GetLessonRecordsByLesson(ctx context.Context, lessonID int64) ([]LessonRecord, error)
+	GetLessonRemindersByLesson(ctx context.Context, lessonID int64) ([]LessonReminder, error)
 	GetLessonSubject(ctx context.Context, subjectID int64) (LessonSubject, error)
+	GetLocationHoursReport(ctx context.Context, arg GetLocationHoursReportParams) ([]GetLocationHoursReportRow, error)
+	GetOutboxEmail(ctx context.Context, emailID int64) (EmailOutbox, error)
+	GetOutboxEmailsByStudent(ctx context.Context, studentID int64) ([]EmailOutbox, error)
+	GetOutstandingBalance(ctx context.Context) (GetOutstandingBalanceRow, error)
+	GetPackageDeductionByInvoice(ctx context.Context, invoiceID int64) (PackageDeduction, error)
+	GetPackageDeductionsByLesson(ctx context.Context, lessonID int64) ([]PackageDeduction, error)
+	GetPackageDeductionsByPackage(ctx context.Context, packageID int64) ([]PackageDeduction, error)
+	GetPackageProduct(ctx context.Context, productID int64) (PackageProduct, error)
 	GetPayment(ctx context.Context, paymentID int64) (Payment, error)
 	GetPaymentMethod(ctx context.Context, paymentMethodID int64) (PaymentMethod, error)
 	GetPayments(ctx context.Context, receiptID int64) ([]Payment, error)
+	GetPipelineStage(ctx context.Context, stageID int64) (PipelineStage, error)
+	GetProgressReport(ctx context.Context, progressReportID int64) (ProgressReport, error)
+	// GetProgressReportLessons returns the lessons of a student in a period, earliest first,
+	// with the attendance and lesson record of the student.
+	GetProgressReportLessons(ctx context.Context, arg GetProgressReportLessonsParams) ([]GetProgressReportLessonsRow, error)
+	// GetProgressReportSubjects returns the lessons and hours of a student in each subject in a period.
+	// Hours are of the attended lessons only.
+	GetProgressReportSubjects(ctx context.Context, arg GetProgressReportSubjectsParams) ([]GetProgressReportSubjectsRow, error)
 	GetReceipt(ctx context.Context, receiptID int64) (Receipt, error)
+	GetReceiptsByGuardianReceipt(ctx context.Context, guardianReceiptID sql.NullInt64) ([]Receipt, error)
 	GetReceiptsByStudent(ctx context.Context, arg GetReceiptsByStudentParams) ([]Receipt, error)
+	GetReceiptsByStudentPeriod(ctx context.Context, arg GetReceiptsByStudentPeriodParams) ([]Receipt, error)
+	GetReminderPreference(ctx context.Context, studentID int64) (ReminderPreference, error)
+	GetRevenueReport(ctx context.Context, arg GetRevenueReportParams) ([]GetRevenueReportRow, error)
+	GetStatement(ctx context.Context, statementID int64) (Statement, error)
 	GetStudent(ctx context.Context, studentID int64) (Student, error)
+	GetStudentAgingInvoices(ctx context.Context, arg GetStudentAgingInvoicesParams) ([]GetStudentAgingInvoicesRow, error)
+	GetStudentCustomValues(ctx context.Context, studentID int64) ([]GetStudentCustomValuesRow, error)
+	GetStudentGuardian(ctx context.Context, arg GetStudentGuardianParams) (StudentGuardian, error)
+	GetStudentGuardians(ctx context.Context, studentID int64) ([]GetStudentGuardiansRow, error)
+	GetStudentPackage(ctx context.Context, packageID int64) (StudentPackage, error)
+	// GetStudentPackageBalances returns the balances of the active packages of a student at as_of, by unit:
+	// the remaining lessons or minutes, and their value.
+	GetStudentPackageBalances(ctx context.Context, arg GetStudentPackageBalancesParams) ([]GetStudentPackageBalancesRow, error)
+	// GetStudentProgress returns the lessons of a student, latest first, with the attendance and lesson record of the student.
+	// Lessons without a record are included, so the timeline shows every lesson of the student.
+	GetStudentProgress(ctx context.Context, arg GetStudentProgressParams) ([]GetStudentProgressRow, error)
+	GetStudentTags(ctx context.Context, studentID int64) ([]Tag, error)
+	// GetStudentTimeline returns the activities, lessons, invoices and receipts of a student in one feed, latest first.
+	// The kind of an item is activity, lesson, invoice or receipt, and item_id is the ID of the item in its table.
+	// The activity, subject and amount columns are set only for the kinds of items they belong to.
+	GetStudentTimeline(ctx context.Context, arg GetStudentTimelineParams) ([]GetStudentTimelineRow, error)
+	// GetStudentsCustomValues returns the custom field values of several students, by student.
+	GetStudentsCustomValues(ctx context.Context, studentIds []int64) ([]GetStudentsCustomValuesRow, error)
+	GetStudentsLifecycle(ctx context.Context, arg GetStudentsLifecycleParams) ([]GetStudentsLifecycleRow, error)
+	// GetStudentsTags returns the tags of several students, by student and tag name.
+	GetStudentsTags(ctx context.Context, studentIds []int64) ([]GetStudentsTagsRow, error)
+	GetSubjectHoursReport(ctx context.Context, arg GetSubjectHoursReportParams) ([]GetSubjectHoursReportRow, error)
+	GetTag(ctx context.Context, tagID int64) (Tag, error)
+	GetTask(ctx context.Context, taskID int64) (Task, error)
+	GetTaskForUpdate(ctx context.Context, taskID int64) (Task, error)
+	GetUpcomingExams(ctx context.Context, arg GetUpcomingExamsParams) ([]GetUpcomingExamsRow, error)
+	GetUpcomingUnpaidLessons(ctx context.Context, arg GetUpcomingUnpaidLessonsParams) ([]GetUpcomingUnpaidLessonsRow, error)
+	// GetUsableStudentPackage locks the package of a student to deduct a lesson of duration minutes held at lesson_datetime from.
+	// The package must not be expired at the lesson time, and must have a lesson or duration minutes remaining.
+	// The package expiring first is used, and packages without an expiry date are used last.
+	GetUsableStudentPackage(ctx context.Context, arg GetUsableStudentPackageParams) (StudentPackage, error)
+	GetWaitlistEntry(ctx context.Context, waitlistEntryID int64) (WaitlistEntry, error)
+	GetWebhookDelivery(ctx context.Context, deliveryID int64) (WebhookDelivery, error)
+	GetWebhookSubscription(ctx context.Context, subscriptionID int64) (WebhookSubscription, error)
+	// ListActivities lists the activities, latest first, optionally of a single student or type.
+	ListActivities(ctx context.Context, arg ListActivitiesParams) ([]Activity, error)
+	ListAllCustomFields(ctx context.Context) ([]CustomField, error)
+	// ListAttachments lists the attachments, newest first, optionally of a single student or lesson.
+	ListAttachments(ctx context.Context, arg ListAttachmentsParams) ([]Attachment, error)
+	// ListAvailabilityExceptions lists the availability exceptions overlapping a period, by start datetime.
+	ListAvailabilityExceptions(ctx context.Context, arg ListAvailabilityExceptionsParams) ([]AvailabilityException, error)
+	// ListAvailabilityWindows lists the weekly availability of the tutor, by weekday and time.
+	ListAvailabilityWindows(ctx context.Context) ([]AvailabilityWindow, error)
+	// ListBookingRequests lists the booking requests, oldest first, optionally with a single status.
+	ListBookingRequests(ctx context.Context, arg ListBookingRequestsParams) ([]BookingRequest, error)
 	ListColleges(ctx context.Context, arg ListCollegesParams) ([]College, error)
+	ListCustomFields(ctx context.Context, arg ListCustomFieldsParams) ([]CustomField, error)
+	// ListDueTasks lists the open and in progress tasks due before a time, by due date and highest priority first,
+	// with the name of their student.
+	ListDueTasks(ctx context.Context, arg ListDueTasksParams) ([]ListDueTasksRow, error)
+	ListExams(ctx context.Context, arg ListExamsParams) ([]Exam, error)
+	ListFeeSchedules(ctx context.Context, arg ListFeeSchedulesParams) ([]FeeSchedule, error)
+	ListFunnelCosts(ctx context.Context, arg ListFunnelCostsParams) ([]FunnelCost, error)
 	ListFunnels(ctx context.Context, arg ListFunnelsParams) ([]Funnel, error)
+	ListGroupPricingPlans(ctx context.Context, arg ListGroupPricingPlansParams) ([]GroupPricingPlan, error)
+	ListGuardianReceipts(ctx context.Context, arg ListGuardianReceiptsParams) ([]GuardianReceipt, error)
+	ListGuardians(ctx context.Context, arg ListGuardiansParams) ([]Guardian, error)
 	ListInvoices(ctx context.Context, arg ListInvoicesParams) ([]Invoice, error)
+	ListJobRuns(ctx context.Context, arg ListJobRunsParams) ([]JobRun, error)
+	// ListLeads lists the leads, newest first, optionally of a single funnel or stage, or with a single status.
+	ListLeads(ctx context.Context, arg ListLeadsParams) ([]Lead, error)
 	ListLessonLocations(ctx context.Context, arg ListLessonLocationsParams) ([]LessonLocation, error)
 	ListLessonSubjects(ctx context.Context, arg ListLessonSubjectsParams) ([]LessonSubject, error)
 	ListLessons(ctx context.Context, arg ListLessonsParams) ([]Lesson, error)
+	// ListLessonsBetween lists the lessons overlapping a period, by datetime.
+	ListLessonsBetween(ctx context.Context, arg ListLessonsBetweenParams) ([]Lesson, error)
+	ListOutboxEmails(ctx context.Context, arg ListOutboxEmailsParams) ([]EmailOutbox, error)
+	ListPackageProducts(ctx context.Context, arg ListPackageProductsParams) ([]PackageProduct, error)
 	ListPaymentMethods(ctx context.Context, arg ListPaymentMethodsParams) ([]PaymentMethod, error)
 	ListPayments(ctx context.Context, arg ListPaymentsParams) ([]Payment, error)
+	// ListPipelineStages lists the stages in their pipeline order.
+	ListPipelineStages(ctx context.Context) ([]PipelineStage, error)
+	ListProgressReports(ctx context.Context, arg ListProgressReportsParams) ([]ProgressReport, error)
 	ListReceipts(ctx context.Context, arg ListReceiptsParams) ([]Receipt, error)
+	ListStatements(ctx context.Context, arg ListStatementsParams) ([]Statement, error)
+	ListStudentPackages(ctx context.Context, arg ListStudentPackagesParams) ([]StudentPackage, error)
+	// ListStudents lists the students by name, optionally with a single lifecycle status, with a tag,
+	// or with a value of a custom field.
 	ListStudents(ctx context.Context, arg ListStudentsParams) ([]Student, error)
+	ListTags(ctx context.Context, arg ListTagsParams) ([]Tag, error)
+	// ListTasks lists the tasks by due date, optionally of a single lead, student, lesson or invoice,
+	// with a single status or priority, or only the overdue open and in progress tasks.
+	ListTasks(ctx context.Context, arg ListTasksParams) ([]Task, error)
+	// ListWaitlistEntries lists the waitlist entries in first-come order, optionally of a single student or subject, or with a single status.
+	ListWaitlistEntries(ctx context.Context, arg ListWaitlistEntriesParams) ([]WaitlistEntry, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookSubscriptions(ctx context.Context, arg ListWebhookSubscriptionsParams) ([]WebhookSubscription, error)
+	// LockLessonSchedule waits for the advisory lock of the lesson schedule, so lessons are created one at a time
+	// and a lesson checked not to overlap others isn't overlapped by a lesson created concurrently.
+	// The lock is held until the end of the transaction, so it must be called within a transaction.
+	LockLessonSchedule(ctx context.Context) error
+	MarkLessonReminderFailed(ctx context.Context, arg MarkLessonReminderFailedParams) error
+	MarkLessonReminderSent(ctx context.Context, arg MarkLessonReminderSentParams) error
+	MarkOutboxEmailFailed(ctx context.Context, arg MarkOutboxEmailFailedParams) error
+	MarkOutboxEmailSent(ctx context.Context, arg MarkOutboxEmailSentParams) error
+	MarkWaitlistEntryNotified(ctx context.Context, arg MarkWaitlistEntryNotifiedParams) (WaitlistEntry, error)
+	MarkWebhookDeliveryDelivered(ctx context.Context, arg MarkWebhookDeliveryDeliveredParams) error
+	MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error
+	RemoveStudentTag(ctx context.Context, arg RemoveStudentTagParams) (int64, error)
+	// ReplayWebhookDelivery adds a new delivery of the same event and payload of a previous delivery.
+	ReplayWebhookDelivery(ctx context.Context, deliveryID int64) (WebhookDelivery, error)
+	RestoreStudentPackage(ctx context.Context, arg RestoreStudentPackageParams) error
+	// TryJobLock tries to take the advisory lock of a job, without waiting for it.
+	// The lock is held until the end of the transaction, so it must be called within a transaction.
+	TryJobLock(ctx context.Context, jobName string) (bool, error)
+	UpdateActivity(ctx context.Context, arg UpdateActivityParams) error
+	UpdateAvailabilityWindow(ctx context.Context, arg UpdateAvailabilityWindowParams) error
 	UpdateCollege(ctx context.Context, arg UpdateCollegeParams) error
+	// UpdateCustomField renames a custom field and updates its enum options. The type of a field is never changed,
+	// as the values of the students would no longer match it.
+	UpdateCustomField(ctx context.Context, arg UpdateCustomFieldParams) error
+	UpdateExam(ctx context.Context, arg UpdateExamParams) error
 	UpdateFunnel(ctx context.Context, arg UpdateFunnelParams) error
+	UpdateFunnelCost(ctx context.Context, arg UpdateFunnelCostParams) error
+	UpdateGroupPricingPlan(ctx context.Context, arg UpdateGroupPricingPlanParams) error
+	UpdateGuardian(ctx context.Context, arg UpdateGuardianParams) error
 	UpdateInvoice(ctx context.Context, arg UpdateInvoiceParams) error
+	// UpdateLead updates a lead. The status of a converted lead is kept, as a lead is converted only by ConvertLeadTx.
+	UpdateLead(ctx context.Context, arg UpdateLeadParams) error
 	UpdateLesson(ctx context.Context, arg UpdateLessonParams) error
+	UpdateLessonAttendance(ctx context.Context, arg UpdateLessonAttendanceParams) (LessonAttendance, error)
 	UpdateLessonLocation(ctx context.Context, arg UpdateLessonLocationParams) error
 	UpdateLessonSubject(ctx context.Context, arg UpdateLessonSubjectParams) error
+	UpdatePackageProduct(ctx context.Context, arg UpdatePackageProductParams) error
 	UpdatePayment(ctx context.Context, arg UpdatePaymentParams) error
 	UpdatePaymentMethod(ctx context.Context, arg UpdatePaymentMethodParams) error
+	UpdatePipelineStage(ctx context.Context, arg UpdatePipelineStageParams) error
 	UpdateReceipt(ctx context.Context, arg UpdateReceiptParams) error
 	UpdateReceiptAmount(ctx context.Context, arg UpdateReceiptAmountParams) error
 	UpdateStudent(ctx context.Context, arg UpdateStudentParams) error
+	UpdateStudentLifecycleStatus(ctx context.Context, arg UpdateStudentLifecycleStatusParams) error
+	UpdateTag(ctx context.Context, arg UpdateTagParams) error
+	// UpdateTask updates a task. A task being done is completed now, and a done task keeps its completion time.
+	UpdateTask(ctx context.Context, arg UpdateTaskParams) error
+	UpdateWaitlistEntry(ctx context.Context, arg UpdateWaitlistEntryParams) error
+	UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) error
+	UpsertLessonRecord(ctx context.Context, arg UpsertLessonRecordParams) (LessonRecord, error)
+	UpsertReminderPreference(ctx context.Context, arg UpsertReminderPreferenceParams) (ReminderPreference, error)
+	UpsertStudentCustomValue(ctx context.Context, arg UpsertStudentCustomValueParams) (StudentCustomValue, error)
+	UpsertStudentGuardian(ctx context.Context, arg UpsertStudentGuardianParams) (StudentGuardian, error)
 }
 
 var _ Querier = (*Queries)(nil)
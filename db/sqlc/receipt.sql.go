@@ -13,18 +13,19 @@ import (
 
 const createReceipt = `-- name: CreateReceipt :one
 INSERT INTO receipts (
-  student_id, receipt_datetime, amount, notes
+  student_id, receipt_datetime, amount, notes, guardian_receipt_id
 ) VALUES (
-  $1, $2, $3, $4
+  $1, $2, $3, $4, $5
 )
-RETURNING receipt_id, student_id, receipt_datetime, amount, notes
+RETURNING receipt_id, student_id, receipt_datetime, amount, notes, guardian_receipt_id
 `
 
 type CreateReceiptParams struct {
-	StudentID       int64          `json:"student_id"`
-	ReceiptDatetime time.Time      `json:"receipt_datetime"`
-	Amount          float64        `json:"amount"`
-	Notes           sql.NullString `json:"notes"`
+	StudentID         int64          `json:"student_id"`
+	ReceiptDatetime   time.Time      `json:"receipt_datetime"`
+	Amount            float64        `json:"amount"`
+	Notes             sql.NullString `json:"notes"`
+	GuardianReceiptID sql.NullInt64  `json:"guardian_receipt_id"`
 }
 
 func (q *Queries) CreateReceipt(ctx context.Context, arg CreateReceiptParams) (Receipt, error) {
@@ -33,6 +34,7 @@ func (q *Queries) CreateReceipt(ctx context.Context, arg CreateReceiptParams) (R
 		arg.ReceiptDatetime,
 		arg.Amount,
 		arg.Notes,
+		arg.GuardianReceiptID,
 	)
 	var i Receipt
 	err := row.Scan(
@@ -41,6 +43,7 @@ func (q *Queries) CreateReceipt(ctx context.Context, arg CreateReceiptParams) (R
 		&i.ReceiptDatetime,
 		&i.Amount,
 		&i.Notes,
+		&i.GuardianReceiptID,
 	)
 	return i, err
 }
@@ -56,7 +59,7 @@ func (q *Queries) DeleteReceipt(ctx context.Context, receiptID int64) error {
 }
 
 const getReceipt = `-- name: GetReceipt :one
-SELECT receipt_id, student_id, receipt_datetime, amount, notes FROM receipts
+SELECT receipt_id, student_id, receipt_datetime, amount, notes, guardian_receipt_id FROM receipts
 WHERE receipt_id = $1 LIMIT 1
 `
 
@@ -69,12 +72,13 @@ func (q *Queries) GetReceipt(ctx context.Context, receiptID int64) (Receipt, err
 		&i.ReceiptDatetime,
 		&i.Amount,
 		&i.Notes,
+		&i.GuardianReceiptID,
 	)
 	return i, err
 }
 
 const getReceiptsByStudent = `-- name: GetReceiptsByStudent :many
-SELECT receipt_id, student_id, receipt_datetime, amount, notes FROM receipts
+SELECT receipt_id, student_id, receipt_datetime, amount, notes, guardian_receipt_id FROM receipts
 WHERE student_id = $1
 ORDER BY receipt_datetime
 LIMIT $2
@@ -102,6 +106,7 @@ func (q *Queries) GetReceiptsByStudent(ctx context.Context, arg GetReceiptsByStu
 			&i.ReceiptDatetime,
 			&i.Amount,
 			&i.Notes,
+			&i.GuardianReceiptID,
 		); err != nil {
 			return nil, err
 		}
@@ -117,7 +122,7 @@ func (q *Queries) GetReceiptsByStudent(ctx context.Context, arg GetReceiptsByStu
 }
 
 const listReceipts = `-- name: ListReceipts :many
-SELECT receipt_id, student_id, receipt_datetime, amount, notes FROM receipts
+SELECT receipt_id, student_id, receipt_datetime, amount, notes, guardian_receipt_id FROM receipts
 ORDER BY student_id, receipt_datetime
 LIMIT $1
 OFFSET $2
@@ -143,6 +148,7 @@ func (q *Queries) ListReceipts(ctx context.Context, arg ListReceiptsParams) ([]R
 			&i.ReceiptDatetime,
 			&i.Amount,
 			&i.Notes,
+			&i.GuardianReceiptID,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,23 @@
+package db
+
+// Reminder channels a student can choose in the reminder preferences.
+const (
+	ReminderChannelNone    = "none"
+	ReminderChannelEmail   = "email"
+	ReminderChannelSMS     = "sms"
+	ReminderChannelWebhook = "webhook"
+)
+
+// Lesson reminder statuses.
+const (
+	ReminderStatusSending = "sending"
+	ReminderStatusSent    = "sent"
+	ReminderStatusFailed  = "failed"
+)
+
+// DefaultReminderLeadMinutes is how many minutes before a lesson a reminder is sent,
+// to students without reminder preferences.
+const DefaultReminderLeadMinutes = 1440
+
+// MaxReminderLeadMinutes is the longest time before a lesson a reminder can be sent.
+const MaxReminderLeadMinutes = 7 * 24 * 60
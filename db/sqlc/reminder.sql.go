@@ -0,0 +1,275 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: reminder.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const claimLessonReminder = `-- name: ClaimLessonReminder :one
+INSERT INTO lesson_reminders (
+  lesson_id, student_id, channel
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (lesson_id, student_id) DO UPDATE
+  set   status = 'sending',
+        channel = EXCLUDED.channel,
+        attempts = lesson_reminders.attempts + 1,
+        claimed_at = now()
+  WHERE (lesson_reminders.status = 'failed' AND lesson_reminders.attempts < $4::int)
+     OR (lesson_reminders.status = 'sending' AND lesson_reminders.claimed_at < $5::timestamptz)
+RETURNING reminder_id, lesson_id, student_id, channel, status, attempts, last_error, claimed_at, sent_at
+`
+
+type ClaimLessonReminderParams struct {
+	LessonID    int64     `json:"lesson_id"`
+	StudentID   int64     `json:"student_id"`
+	Channel     string    `json:"channel"`
+	MaxAttempts int32     `json:"max_attempts"`
+	StaleBefore time.Time `json:"stale_before"`
+}
+
+// ClaimLessonReminder claims the reminder of a student for a lesson, so only one scheduler sends it.
+// A failed reminder is claimed again until it was attempted max_attempts times,
+// and a reminder claimed before stale_before by a scheduler that stopped is claimed again.
+// No row is returned if the reminder can't be claimed.
+func (q *Queries) ClaimLessonReminder(ctx context.Context, arg ClaimLessonReminderParams) (LessonReminder, error) {
+	row := q.db.QueryRowContext(ctx, claimLessonReminder,
+		arg.LessonID,
+		arg.StudentID,
+		arg.Channel,
+		arg.MaxAttempts,
+		arg.StaleBefore,
+	)
+	var i LessonReminder
+	err := row.Scan(
+		&i.ReminderID,
+		&i.LessonID,
+		&i.StudentID,
+		&i.Channel,
+		&i.Status,
+		&i.Attempts,
+		&i.LastError,
+		&i.ClaimedAt,
+		&i.SentAt,
+	)
+	return i, err
+}
+
+const getDueLessonReminders = `-- name: GetDueLessonReminders :many
+SELECT l.lesson_id,
+       l.lesson_datetime,
+       l.duration,
+       s.student_id,
+       s.first_name,
+       s.last_name,
+       s.email,
+       s.phone_number,
+       COALESCE(rp.channel, 'email')::varchar AS channel,
+       COALESCE(rp.lead_minutes, 1440)::int AS lead_minutes
+FROM lessons l
+JOIN lesson_attendance la ON la.lesson_id = l.lesson_id
+JOIN students s ON s.student_id = la.student_id
+LEFT JOIN reminder_preferences rp ON rp.student_id = s.student_id
+LEFT JOIN lesson_reminders lr ON lr.lesson_id = l.lesson_id AND lr.student_id = s.student_id
+WHERE l.lesson_datetime > $1::timestamptz
+  AND l.lesson_datetime <= $2::timestamptz
+  AND l.lesson_datetime - make_interval(mins => COALESCE(rp.lead_minutes, 1440)) <= $1::timestamptz
+  AND la.status = 'attended'
+  AND COALESCE(rp.channel, 'email') <> 'none'
+  AND (lr.reminder_id IS NULL
+       OR (lr.status = 'failed' AND lr.attempts < $3::int)
+       OR (lr.status = 'sending' AND lr.claimed_at < $4::timestamptz))
+ORDER BY l.lesson_datetime
+`
+
+type GetDueLessonRemindersParams struct {
+	Now         time.Time `json:"now"`
+	Horizon     time.Time `json:"horizon"`
+	MaxAttempts int32     `json:"max_attempts"`
+	StaleBefore time.Time `json:"stale_before"`
+}
+
+type GetDueLessonRemindersRow struct {
+	LessonID       int64          `json:"lesson_id"`
+	LessonDatetime time.Time      `json:"lesson_datetime"`
+	Duration       int64          `json:"duration"`
+	StudentID      int64          `json:"student_id"`
+	FirstName      string         `json:"first_name"`
+	LastName       string         `json:"last_name"`
+	Email          sql.NullString `json:"email"`
+	PhoneNumber    sql.NullString `json:"phone_number"`
+	Channel        string         `json:"channel"`
+	LeadMinutes    int32          `json:"lead_minutes"`
+}
+
+// GetDueLessonReminders returns the reminders due for students attending lessons held up to horizon,
+// whether invoiced or attending for free. Students marked absent or excused aren't reminded.
+// Students without reminder preferences are reminded by email, 24 hours before the lesson.
+// Reminders already sent, failed after max_attempts, or being sent by another scheduler since stale_before, are excluded.
+func (q *Queries) GetDueLessonReminders(ctx context.Context, arg GetDueLessonRemindersParams) ([]GetDueLessonRemindersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDueLessonReminders,
+		arg.Now,
+		arg.Horizon,
+		arg.MaxAttempts,
+		arg.StaleBefore,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDueLessonRemindersRow{}
+	for rows.Next() {
+		var i GetDueLessonRemindersRow
+		if err := rows.Scan(
+			&i.LessonID,
+			&i.LessonDatetime,
+			&i.Duration,
+			&i.StudentID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.PhoneNumber,
+			&i.Channel,
+			&i.LeadMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLessonRemindersByLesson = `-- name: GetLessonRemindersByLesson :many
+SELECT reminder_id, lesson_id, student_id, channel, status, attempts, last_error, claimed_at, sent_at FROM lesson_reminders
+WHERE lesson_id = $1
+ORDER BY student_id
+`
+
+func (q *Queries) GetLessonRemindersByLesson(ctx context.Context, lessonID int64) ([]LessonReminder, error) {
+	rows, err := q.db.QueryContext(ctx, getLessonRemindersByLesson, lessonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LessonReminder{}
+	for rows.Next() {
+		var i LessonReminder
+		if err := rows.Scan(
+			&i.ReminderID,
+			&i.LessonID,
+			&i.StudentID,
+			&i.Channel,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.ClaimedAt,
+			&i.SentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReminderPreference = `-- name: GetReminderPreference :one
+SELECT student_id, channel, lead_minutes, updated_at FROM reminder_preferences
+WHERE student_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetReminderPreference(ctx context.Context, studentID int64) (ReminderPreference, error) {
+	row := q.db.QueryRowContext(ctx, getReminderPreference, studentID)
+	var i ReminderPreference
+	err := row.Scan(
+		&i.StudentID,
+		&i.Channel,
+		&i.LeadMinutes,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const markLessonReminderFailed = `-- name: MarkLessonReminderFailed :exec
+UPDATE lesson_reminders
+  set   status = 'failed',
+        last_error = $2
+WHERE reminder_id = $1
+`
+
+type MarkLessonReminderFailedParams struct {
+	ReminderID int64          `json:"reminder_id"`
+	LastError  sql.NullString `json:"last_error"`
+}
+
+func (q *Queries) MarkLessonReminderFailed(ctx context.Context, arg MarkLessonReminderFailedParams) error {
+	_, err := q.db.ExecContext(ctx, markLessonReminderFailed, arg.ReminderID, arg.LastError)
+	return err
+}
+
+const markLessonReminderSent = `-- name: MarkLessonReminderSent :exec
+UPDATE lesson_reminders
+  set   status = 'sent',
+        last_error = NULL,
+        sent_at = $2
+WHERE reminder_id = $1
+`
+
+type MarkLessonReminderSentParams struct {
+	ReminderID int64        `json:"reminder_id"`
+	SentAt     sql.NullTime `json:"sent_at"`
+}
+
+func (q *Queries) MarkLessonReminderSent(ctx context.Context, arg MarkLessonReminderSentParams) error {
+	_, err := q.db.ExecContext(ctx, markLessonReminderSent, arg.ReminderID, arg.SentAt)
+	return err
+}
+
+const upsertReminderPreference = `-- name: UpsertReminderPreference :one
+INSERT INTO reminder_preferences (
+  student_id, channel, lead_minutes
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (student_id) DO UPDATE
+  set   channel = EXCLUDED.channel,
+        lead_minutes = EXCLUDED.lead_minutes,
+        updated_at = now()
+RETURNING student_id, channel, lead_minutes, updated_at
+`
+
+type UpsertReminderPreferenceParams struct {
+	StudentID   int64  `json:"student_id"`
+	Channel     string `json:"channel"`
+	LeadMinutes int32  `json:"lead_minutes"`
+}
+
+func (q *Queries) UpsertReminderPreference(ctx context.Context, arg UpsertReminderPreferenceParams) (ReminderPreference, error) {
+	row := q.db.QueryRowContext(ctx, upsertReminderPreference, arg.StudentID, arg.Channel, arg.LeadMinutes)
+	var i ReminderPreference
+	err := row.Scan(
+		&i.StudentID,
+		&i.Channel,
+		&i.LeadMinutes,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,203 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomReminderPreference(t *testing.T, student Student, channel string, leadMinutes int32) ReminderPreference {
+	arg := UpsertReminderPreferenceParams{
+		StudentID:   student.StudentID,
+		Channel:     channel,
+		LeadMinutes: leadMinutes,
+	}
+
+	preference, err := testQueries.UpsertReminderPreference(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.StudentID, preference.StudentID)
+	require.Equal(t, arg.Channel, preference.Channel)
+	require.Equal(t, arg.LeadMinutes, preference.LeadMinutes)
+	require.NotZero(t, preference.UpdatedAt)
+
+	return preference
+}
+
+func TestUpsertReminderPreference(t *testing.T) {
+	student := createRandomStudent(t)
+	createRandomReminderPreference(t, student, ReminderChannelEmail, DefaultReminderLeadMinutes)
+
+	preference1 := createRandomReminderPreference(t, student, ReminderChannelSMS, int32(util.RandomInt64(1, MaxReminderLeadMinutes)))
+
+	preference2, err := testQueries.GetReminderPreference(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Equal(t, preference1.Channel, preference2.Channel)
+	require.Equal(t, preference1.LeadMinutes, preference2.LeadMinutes)
+}
+
+// dueReminders returns the due reminders as of now, keyed by student ID, for a lesson.
+func dueReminders(t *testing.T, now time.Time, lessonID int64) map[int64]GetDueLessonRemindersRow {
+	rows, err := testQueries.GetDueLessonReminders(context.Background(), GetDueLessonRemindersParams{
+		Now:         now,
+		Horizon:     now.Add(MaxReminderLeadMinutes * time.Minute),
+		MaxAttempts: 2,
+		StaleBefore: now.Add(-10 * time.Minute),
+	})
+	require.NoError(t, err)
+
+	due := make(map[int64]GetDueLessonRemindersRow)
+	for _, row := range rows {
+		if row.LessonID == lessonID {
+			due[row.StudentID] = row
+		}
+	}
+	return due
+}
+
+func TestGetDueLessonReminders(t *testing.T) {
+	now := time.Now().UTC()
+
+	defaultStudent := createRandomStudent(t)
+	smsStudent := createRandomStudent(t)
+	createRandomReminderPreference(t, smsStudent, ReminderChannelSMS, 180)
+	lateStudent := createRandomStudent(t)
+	createRandomReminderPreference(t, lateStudent, ReminderChannelEmail, 30)
+	optedOutStudent := createRandomStudent(t)
+	createRandomReminderPreference(t, optedOutStudent, ReminderChannelNone, DefaultReminderLeadMinutes)
+
+	result, err := createRandomLessonWithInvoiceTx(t, now.Add(2*time.Hour), defaultStudent, smsStudent, lateStudent, optedOutStudent)
+	require.NoError(t, err)
+
+	due := dueReminders(t, now, result.Lesson.LessonID)
+	require.Len(t, due, 2)
+
+	require.Equal(t, ReminderChannelEmail, due[defaultStudent.StudentID].Channel)
+	require.Equal(t, int32(DefaultReminderLeadMinutes), due[defaultStudent.StudentID].LeadMinutes)
+	require.Equal(t, defaultStudent.Email, due[defaultStudent.StudentID].Email)
+
+	require.Equal(t, ReminderChannelSMS, due[smsStudent.StudentID].Channel)
+	require.Equal(t, smsStudent.PhoneNumber, due[smsStudent.StudentID].PhoneNumber)
+
+	// the late student is reminded 30 minutes before the lesson
+	due = dueReminders(t, now.Add(95*time.Minute), result.Lesson.LessonID)
+	require.Contains(t, due, lateStudent.StudentID)
+}
+
+func TestGetDueLessonRemindersAttendance(t *testing.T) {
+	store := NewStore(testDB)
+	now := time.Now().UTC()
+
+	invoicedStudent := createRandomStudent(t)
+	absentStudent := createRandomStudent(t)
+	result, err := createRandomLessonWithInvoiceTx(t, now.Add(2*time.Hour), invoicedStudent, absentStudent)
+	require.NoError(t, err)
+
+	// a free attendee has no invoice, and is reminded as well
+	freeStudent := createRandomStudent(t)
+	_, err = store.AddLessonStudentTx(context.Background(), AddLessonStudentTxParams{
+		LessonID:  result.Lesson.LessonID,
+		StudentID: freeStudent.StudentID,
+		Free:      true,
+	})
+	require.NoError(t, err)
+
+	// a student marked absent isn't reminded
+	_, err = testQueries.UpdateLessonAttendance(context.Background(), UpdateLessonAttendanceParams{
+		LessonID:  result.Lesson.LessonID,
+		StudentID: absentStudent.StudentID,
+		Status:    AttendanceStatusAbsent,
+	})
+	require.NoError(t, err)
+
+	due := dueReminders(t, now, result.Lesson.LessonID)
+	require.Len(t, due, 2)
+	require.Contains(t, due, invoicedStudent.StudentID)
+	require.Contains(t, due, freeStudent.StudentID)
+	require.NotContains(t, due, absentStudent.StudentID)
+}
+
+func TestClaimLessonReminder(t *testing.T) {
+	now := time.Now().UTC()
+	student := createRandomStudent(t)
+
+	result, err := createRandomLessonWithInvoiceTx(t, now.Add(2*time.Hour), student)
+	require.NoError(t, err)
+
+	arg := ClaimLessonReminderParams{
+		LessonID:    result.Lesson.LessonID,
+		StudentID:   student.StudentID,
+		Channel:     ReminderChannelEmail,
+		MaxAttempts: 2,
+		StaleBefore: now.Add(-10 * time.Minute),
+	}
+
+	reminder, err := testQueries.ClaimLessonReminder(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, ReminderStatusSending, reminder.Status)
+	require.Equal(t, int32(1), reminder.Attempts)
+
+	// a claimed reminder can't be claimed again, and isn't due
+	_, err = testQueries.ClaimLessonReminder(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.Empty(t, dueReminders(t, now, result.Lesson.LessonID))
+
+	// a failed reminder is due and claimed again, until it runs out of attempts
+	err = testQueries.MarkLessonReminderFailed(context.Background(), MarkLessonReminderFailedParams{
+		ReminderID: reminder.ReminderID,
+		LastError:  sql.NullString{String: util.RandomNote(), Valid: true},
+	})
+	require.NoError(t, err)
+	require.Contains(t, dueReminders(t, now, result.Lesson.LessonID), student.StudentID)
+
+	reminder, err = testQueries.ClaimLessonReminder(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), reminder.Attempts)
+	require.True(t, reminder.LastError.Valid)
+
+	err = testQueries.MarkLessonReminderFailed(context.Background(), MarkLessonReminderFailedParams{
+		ReminderID: reminder.ReminderID,
+		LastError:  sql.NullString{String: util.RandomNote(), Valid: true},
+	})
+	require.NoError(t, err)
+
+	// a reminder out of attempts isn't due, and can't be claimed
+	require.Empty(t, dueReminders(t, now, result.Lesson.LessonID))
+	_, err = testQueries.ClaimLessonReminder(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestMarkLessonReminderSent(t *testing.T) {
+	now := time.Now().UTC()
+	student := createRandomStudent(t)
+
+	result, err := createRandomLessonWithInvoiceTx(t, now.Add(2*time.Hour), student)
+	require.NoError(t, err)
+
+	reminder, err := testQueries.ClaimLessonReminder(context.Background(), ClaimLessonReminderParams{
+		LessonID:    result.Lesson.LessonID,
+		StudentID:   student.StudentID,
+		Channel:     ReminderChannelEmail,
+		MaxAttempts: 3,
+		StaleBefore: now.Add(-10 * time.Minute),
+	})
+	require.NoError(t, err)
+
+	err = testQueries.MarkLessonReminderSent(context.Background(), MarkLessonReminderSentParams{
+		ReminderID: reminder.ReminderID,
+		SentAt:     sql.NullTime{Time: now, Valid: true},
+	})
+	require.NoError(t, err)
+
+	reminders, err := testQueries.GetLessonRemindersByLesson(context.Background(), result.Lesson.LessonID)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	require.Equal(t, ReminderStatusSent, reminders[0].Status)
+	require.WithinDuration(t, now, reminders[0].SentAt.Time, time.Second)
+
+	// a sent reminder isn't due again
+	require.Empty(t, dueReminders(t, now, result.Lesson.LessonID))
+}
@@ -0,0 +1,615 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: report.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const getAgingReport = `-- name: GetAgingReport :many
+WITH paid AS (
+  SELECT student_id, SUM(amount) AS amount
+  FROM receipts
+  WHERE receipt_datetime < $1
+  GROUP BY student_id
+), invoiced AS (
+  SELECT student_id, invoice_datetime, amount,
+         SUM(amount) OVER (PARTITION BY student_id ORDER BY invoice_datetime, invoice_id) AS cumulative_amount
+  FROM invoices
+  WHERE invoice_datetime < $1
+), unpaid AS (
+  SELECT inv.student_id,
+         EXTRACT(DAY FROM $1::timestamptz - inv.invoice_datetime) AS age_days,
+         LEAST(inv.amount, inv.cumulative_amount - COALESCE(p.amount, 0)) AS amount
+  FROM invoiced inv
+  LEFT JOIN paid p ON p.student_id = inv.student_id
+  WHERE inv.cumulative_amount > COALESCE(p.amount, 0)
+)
+SELECT s.student_id,
+       s.first_name,
+       s.last_name,
+       COALESCE(SUM(u.amount) FILTER (WHERE u.age_days <= 30), 0)::float AS days_0_to_30,
+       COALESCE(SUM(u.amount) FILTER (WHERE u.age_days > 30 AND u.age_days <= 60), 0)::float AS days_31_to_60,
+       COALESCE(SUM(u.amount) FILTER (WHERE u.age_days > 60 AND u.age_days <= 90), 0)::float AS days_61_to_90,
+       COALESCE(SUM(u.amount) FILTER (WHERE u.age_days > 90), 0)::float AS days_over_90,
+       COALESCE(SUM(u.amount), 0)::float AS total_amount
+FROM unpaid u
+JOIN students s ON s.student_id = u.student_id
+GROUP BY s.student_id, s.first_name, s.last_name
+ORDER BY total_amount DESC, s.last_name, s.first_name
+`
+
+type GetAgingReportRow struct {
+	StudentID   int64   `json:"student_id"`
+	FirstName   string  `json:"first_name"`
+	LastName    string  `json:"last_name"`
+	Days0To30   float64 `json:"days_0_to_30"`
+	Days31To60  float64 `json:"days_31_to_60"`
+	Days61To90  float64 `json:"days_61_to_90"`
+	DaysOver90  float64 `json:"days_over_90"`
+	TotalAmount float64 `json:"total_amount"`
+}
+
+func (q *Queries) GetAgingReport(ctx context.Context, asOfDatetime time.Time) ([]GetAgingReportRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAgingReport, asOfDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetAgingReportRow{}
+	for rows.Next() {
+		var i GetAgingReportRow
+		if err := rows.Scan(
+			&i.StudentID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Days0To30,
+			&i.Days31To60,
+			&i.Days61To90,
+			&i.DaysOver90,
+			&i.TotalAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCollegeRevenueReport = `-- name: GetCollegeRevenueReport :many
+SELECT date_trunc($1::text, i.invoice_datetime)::timestamptz AS period_start,
+       c.college_id,
+       c.name,
+       COUNT(DISTINCT i.student_id) AS students_count,
+       COALESCE(SUM(i.amount), 0)::float AS invoiced_amount
+FROM invoices i
+JOIN students s ON s.student_id = i.student_id
+LEFT JOIN colleges c ON c.college_id = s.college_id
+WHERE i.invoice_datetime >= $2 AND i.invoice_datetime < $3
+GROUP BY 1, c.college_id, c.name
+ORDER BY 1, c.name
+`
+
+type GetCollegeRevenueReportParams struct {
+	Period        string    `json:"period"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+type GetCollegeRevenueReportRow struct {
+	PeriodStart    time.Time      `json:"period_start"`
+	CollegeID      sql.NullInt64  `json:"college_id"`
+	Name           sql.NullString `json:"name"`
+	StudentsCount  int64          `json:"students_count"`
+	InvoicedAmount float64        `json:"invoiced_amount"`
+}
+
+func (q *Queries) GetCollegeRevenueReport(ctx context.Context, arg GetCollegeRevenueReportParams) ([]GetCollegeRevenueReportRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCollegeRevenueReport, arg.Period, arg.StartDatetime, arg.EndDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCollegeRevenueReportRow{}
+	for rows.Next() {
+		var i GetCollegeRevenueReportRow
+		if err := rows.Scan(
+			&i.PeriodStart,
+			&i.CollegeID,
+			&i.Name,
+			&i.StudentsCount,
+			&i.InvoicedAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExamOutcomeReport = `-- name: GetExamOutcomeReport :many
+SELECT date_trunc($1::text, e.exam_datetime)::timestamptz AS period_start,
+       ls.subject_id,
+       ls.name,
+       COUNT(*) AS exams_count,
+       COUNT(e.achieved_grade) AS graded_count,
+       COUNT(*) FILTER (WHERE e.achieved_grade >= e.target_grade) AS target_met_count,
+       COALESCE(AVG(e.target_grade) FILTER (WHERE e.achieved_grade IS NOT NULL), 0)::float AS average_target_grade,
+       COALESCE(AVG(e.achieved_grade), 0)::float AS average_achieved_grade,
+       COALESCE(AVG(e.achieved_grade - e.target_grade), 0)::float AS average_grade_difference
+FROM exams e
+JOIN lesson_subjects ls ON ls.subject_id = e.subject_id
+WHERE e.exam_datetime >= $2 AND e.exam_datetime < $3
+GROUP BY 1, ls.subject_id, ls.name
+ORDER BY 1, ls.name
+`
+
+type GetExamOutcomeReportParams struct {
+	Period        string    `json:"period"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+type GetExamOutcomeReportRow struct {
+	PeriodStart            time.Time `json:"period_start"`
+	SubjectID              int64     `json:"subject_id"`
+	Name                   string    `json:"name"`
+	ExamsCount             int64     `json:"exams_count"`
+	GradedCount            int64     `json:"graded_count"`
+	TargetMetCount         int64     `json:"target_met_count"`
+	AverageTargetGrade     float64   `json:"average_target_grade"`
+	AverageAchievedGrade   float64   `json:"average_achieved_grade"`
+	AverageGradeDifference float64   `json:"average_grade_difference"`
+}
+
+// GetExamOutcomeReport returns the outcomes of the exams of each subject.
+// Averages are of the graded exams only, and are 0 if there are none.
+func (q *Queries) GetExamOutcomeReport(ctx context.Context, arg GetExamOutcomeReportParams) ([]GetExamOutcomeReportRow, error) {
+	rows, err := q.db.QueryContext(ctx, getExamOutcomeReport, arg.Period, arg.StartDatetime, arg.EndDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetExamOutcomeReportRow{}
+	for rows.Next() {
+		var i GetExamOutcomeReportRow
+		if err := rows.Scan(
+			&i.PeriodStart,
+			&i.SubjectID,
+			&i.Name,
+			&i.ExamsCount,
+			&i.GradedCount,
+			&i.TargetMetCount,
+			&i.AverageTargetGrade,
+			&i.AverageAchievedGrade,
+			&i.AverageGradeDifference,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFunnelCohortReport = `-- name: GetFunnelCohortReport :many
+WITH cohort_students AS (
+  SELECT s.student_id, s.funnel_id, s.created_at,
+         date_trunc($1::text, s.created_at) AS cohort_start
+  FROM students s
+  WHERE s.created_at >= $2 AND s.created_at < $3
+), student_lessons AS (
+  SELECT i.student_id,
+         MIN(l.lesson_datetime) AS first_lesson_datetime,
+         MAX(l.lesson_datetime) AS last_lesson_datetime,
+         SUM(i.amount) AS revenue
+  FROM invoices i
+  JOIN lessons l ON l.lesson_id = i.lesson_id
+  GROUP BY i.student_id
+), cohorts AS (
+  SELECT cs.funnel_id, cs.cohort_start, COUNT(*) AS students_count
+  FROM cohort_students cs
+  GROUP BY cs.funnel_id, cs.cohort_start
+), conversions AS (
+  SELECT cs.funnel_id, cs.cohort_start,
+         COUNT(*) AS converted_count,
+         AVG(EXTRACT(EPOCH FROM sl.first_lesson_datetime - cs.created_at) / 86400)::float AS avg_days_to_first_lesson,
+         COUNT(*) FILTER (WHERE sl.last_lesson_datetime >= $4::timestamptz) AS retained_count,
+         SUM(sl.revenue)::float AS lifetime_revenue
+  FROM cohort_students cs
+  JOIN student_lessons sl ON sl.student_id = cs.student_id
+  GROUP BY cs.funnel_id, cs.cohort_start
+), costs AS (
+  SELECT fc.funnel_id,
+         date_trunc($1::text, fc.cost_date::timestamptz) AS cohort_start,
+         SUM(fc.amount)::float AS acquisition_cost
+  FROM funnel_costs fc
+  WHERE fc.cost_date >= $2::date AND fc.cost_date < $3::date
+  GROUP BY 1, 2
+)
+SELECT c.cohort_start::timestamptz AS cohort_start,
+       f.funnel_id,
+       f.name,
+       c.students_count,
+       COALESCE(cv.converted_count, 0)::bigint AS converted_count,
+       cv.avg_days_to_first_lesson,
+       COALESCE(cv.retained_count, 0)::bigint AS retained_count,
+       COALESCE(cv.lifetime_revenue, 0)::float AS lifetime_revenue,
+       co.acquisition_cost
+FROM cohorts c
+LEFT JOIN funnels f ON f.funnel_id = c.funnel_id
+LEFT JOIN conversions cv ON cv.funnel_id IS NOT DISTINCT FROM c.funnel_id AND cv.cohort_start = c.cohort_start
+LEFT JOIN costs co ON co.funnel_id = c.funnel_id AND co.cohort_start = c.cohort_start
+ORDER BY 1, f.name
+`
+
+type GetFunnelCohortReportParams struct {
+	Period        string    `json:"period"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+	RetainedSince time.Time `json:"retained_since"`
+}
+
+type GetFunnelCohortReportRow struct {
+	CohortStart          time.Time       `json:"cohort_start"`
+	FunnelID             sql.NullInt64   `json:"funnel_id"`
+	Name                 sql.NullString  `json:"name"`
+	StudentsCount        int64           `json:"students_count"`
+	ConvertedCount       int64           `json:"converted_count"`
+	AvgDaysToFirstLesson sql.NullFloat64 `json:"avg_days_to_first_lesson"`
+	RetainedCount        int64           `json:"retained_count"`
+	LifetimeRevenue      float64         `json:"lifetime_revenue"`
+	AcquisitionCost      sql.NullFloat64 `json:"acquisition_cost"`
+}
+
+func (q *Queries) GetFunnelCohortReport(ctx context.Context, arg GetFunnelCohortReportParams) ([]GetFunnelCohortReportRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFunnelCohortReport,
+		arg.Period,
+		arg.StartDatetime,
+		arg.EndDatetime,
+		arg.RetainedSince,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetFunnelCohortReportRow{}
+	for rows.Next() {
+		var i GetFunnelCohortReportRow
+		if err := rows.Scan(
+			&i.CohortStart,
+			&i.FunnelID,
+			&i.Name,
+			&i.StudentsCount,
+			&i.ConvertedCount,
+			&i.AvgDaysToFirstLesson,
+			&i.RetainedCount,
+			&i.LifetimeRevenue,
+			&i.AcquisitionCost,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFunnelRevenueReport = `-- name: GetFunnelRevenueReport :many
+SELECT date_trunc($1::text, i.invoice_datetime)::timestamptz AS period_start,
+       f.funnel_id,
+       f.name,
+       COUNT(DISTINCT i.student_id) AS students_count,
+       COALESCE(SUM(i.amount), 0)::float AS invoiced_amount
+FROM invoices i
+JOIN students s ON s.student_id = i.student_id
+LEFT JOIN funnels f ON f.funnel_id = s.funnel_id
+WHERE i.invoice_datetime >= $2 AND i.invoice_datetime < $3
+GROUP BY 1, f.funnel_id, f.name
+ORDER BY 1, f.name
+`
+
+type GetFunnelRevenueReportParams struct {
+	Period        string    `json:"period"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+type GetFunnelRevenueReportRow struct {
+	PeriodStart    time.Time      `json:"period_start"`
+	FunnelID       sql.NullInt64  `json:"funnel_id"`
+	Name           sql.NullString `json:"name"`
+	StudentsCount  int64          `json:"students_count"`
+	InvoicedAmount float64        `json:"invoiced_amount"`
+}
+
+func (q *Queries) GetFunnelRevenueReport(ctx context.Context, arg GetFunnelRevenueReportParams) ([]GetFunnelRevenueReportRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFunnelRevenueReport, arg.Period, arg.StartDatetime, arg.EndDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetFunnelRevenueReportRow{}
+	for rows.Next() {
+		var i GetFunnelRevenueReportRow
+		if err := rows.Scan(
+			&i.PeriodStart,
+			&i.FunnelID,
+			&i.Name,
+			&i.StudentsCount,
+			&i.InvoicedAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLocationHoursReport = `-- name: GetLocationHoursReport :many
+SELECT date_trunc($1::text, l.lesson_datetime)::timestamptz AS period_start,
+       loc.location_id,
+       loc.name,
+       COUNT(*) AS lessons_count,
+       (SUM(l.duration) / 60.0)::float AS hours
+FROM lessons l
+JOIN lesson_locations loc ON loc.location_id = l.location_id
+WHERE l.lesson_datetime >= $2 AND l.lesson_datetime < $3
+GROUP BY 1, loc.location_id, loc.name
+ORDER BY 1, loc.name
+`
+
+type GetLocationHoursReportParams struct {
+	Period        string    `json:"period"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+type GetLocationHoursReportRow struct {
+	PeriodStart  time.Time `json:"period_start"`
+	LocationID   int64     `json:"location_id"`
+	Name         string    `json:"name"`
+	LessonsCount int64     `json:"lessons_count"`
+	Hours        float64   `json:"hours"`
+}
+
+func (q *Queries) GetLocationHoursReport(ctx context.Context, arg GetLocationHoursReportParams) ([]GetLocationHoursReportRow, error) {
+	rows, err := q.db.QueryContext(ctx, getLocationHoursReport, arg.Period, arg.StartDatetime, arg.EndDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetLocationHoursReportRow{}
+	for rows.Next() {
+		var i GetLocationHoursReportRow
+		if err := rows.Scan(
+			&i.PeriodStart,
+			&i.LocationID,
+			&i.Name,
+			&i.LessonsCount,
+			&i.Hours,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRevenueReport = `-- name: GetRevenueReport :many
+WITH invoiced AS (
+  SELECT date_trunc($1::text, invoice_datetime) AS period_start,
+         SUM(amount) AS amount
+  FROM invoices
+  WHERE invoice_datetime >= $2 AND invoice_datetime < $3
+  GROUP BY period_start
+), collected AS (
+  SELECT date_trunc($1::text, receipt_datetime) AS period_start,
+         SUM(amount) AS amount
+  FROM receipts
+  WHERE receipt_datetime >= $2 AND receipt_datetime < $3
+  GROUP BY period_start
+)
+SELECT COALESCE(i.period_start, c.period_start)::timestamptz AS period_start,
+       COALESCE(i.amount, 0)::float AS invoiced_amount,
+       COALESCE(c.amount, 0)::float AS collected_amount
+FROM invoiced i
+FULL OUTER JOIN collected c ON c.period_start = i.period_start
+ORDER BY 1
+`
+
+type GetRevenueReportParams struct {
+	Period        string    `json:"period"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+type GetRevenueReportRow struct {
+	PeriodStart     time.Time `json:"period_start"`
+	InvoicedAmount  float64   `json:"invoiced_amount"`
+	CollectedAmount float64   `json:"collected_amount"`
+}
+
+func (q *Queries) GetRevenueReport(ctx context.Context, arg GetRevenueReportParams) ([]GetRevenueReportRow, error) {
+	rows, err := q.db.QueryContext(ctx, getRevenueReport, arg.Period, arg.StartDatetime, arg.EndDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetRevenueReportRow{}
+	for rows.Next() {
+		var i GetRevenueReportRow
+		if err := rows.Scan(&i.PeriodStart, &i.InvoicedAmount, &i.CollectedAmount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStudentAgingInvoices = `-- name: GetStudentAgingInvoices :many
+WITH paid AS (
+  SELECT COALESCE(SUM(r.amount), 0) AS amount
+  FROM receipts r
+  WHERE r.student_id = $2 AND r.receipt_datetime < $1
+), invoiced AS (
+  SELECT i.invoice_id, i.lesson_id, i.invoice_datetime, i.amount,
+         SUM(i.amount) OVER (ORDER BY i.invoice_datetime, i.invoice_id) AS cumulative_amount
+  FROM invoices i
+  WHERE i.student_id = $2 AND i.invoice_datetime < $1
+)
+SELECT inv.invoice_id,
+       inv.lesson_id,
+       inv.invoice_datetime,
+       inv.amount,
+       LEAST(inv.amount, inv.cumulative_amount - p.amount)::float AS unpaid_amount,
+       EXTRACT(DAY FROM $1::timestamptz - inv.invoice_datetime)::bigint AS age_days
+FROM invoiced inv, paid p
+WHERE inv.cumulative_amount > p.amount
+ORDER BY inv.invoice_datetime, inv.invoice_id
+`
+
+type GetStudentAgingInvoicesParams struct {
+	AsOfDatetime time.Time `json:"as_of_datetime"`
+	StudentID    int64     `json:"student_id"`
+}
+
+type GetStudentAgingInvoicesRow struct {
+	InvoiceID       int64     `json:"invoice_id"`
+	LessonID        int64     `json:"lesson_id"`
+	InvoiceDatetime time.Time `json:"invoice_datetime"`
+	Amount          float64   `json:"amount"`
+	UnpaidAmount    float64   `json:"unpaid_amount"`
+	AgeDays         int64     `json:"age_days"`
+}
+
+func (q *Queries) GetStudentAgingInvoices(ctx context.Context, arg GetStudentAgingInvoicesParams) ([]GetStudentAgingInvoicesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStudentAgingInvoices, arg.AsOfDatetime, arg.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStudentAgingInvoicesRow{}
+	for rows.Next() {
+		var i GetStudentAgingInvoicesRow
+		if err := rows.Scan(
+			&i.InvoiceID,
+			&i.LessonID,
+			&i.InvoiceDatetime,
+			&i.Amount,
+			&i.UnpaidAmount,
+			&i.AgeDays,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSubjectHoursReport = `-- name: GetSubjectHoursReport :many
+SELECT date_trunc($1::text, l.lesson_datetime)::timestamptz AS period_start,
+       s.subject_id,
+       s.name,
+       COUNT(*) AS lessons_count,
+       (SUM(l.duration) / 60.0)::float AS hours
+FROM lessons l
+JOIN lesson_subjects s ON s.subject_id = l.subject_id
+WHERE l.lesson_datetime >= $2 AND l.lesson_datetime < $3
+GROUP BY 1, s.subject_id, s.name
+ORDER BY 1, s.name
+`
+
+type GetSubjectHoursReportParams struct {
+	Period        string    `json:"period"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+type GetSubjectHoursReportRow struct {
+	PeriodStart  time.Time `json:"period_start"`
+	SubjectID    int64     `json:"subject_id"`
+	Name         string    `json:"name"`
+	LessonsCount int64     `json:"lessons_count"`
+	Hours        float64   `json:"hours"`
+}
+
+func (q *Queries) GetSubjectHoursReport(ctx context.Context, arg GetSubjectHoursReportParams) ([]GetSubjectHoursReportRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSubjectHoursReport, arg.Period, arg.StartDatetime, arg.EndDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetSubjectHoursReportRow{}
+	for rows.Next() {
+		var i GetSubjectHoursReportRow
+		if err := rows.Scan(
+			&i.PeriodStart,
+			&i.SubjectID,
+			&i.Name,
+			&i.LessonsCount,
+			&i.Hours,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
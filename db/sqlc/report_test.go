@@ -0,0 +1,366 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// randomReportDay returns the start of a random day in the past year, used as a report date range.
+func randomReportDay() time.Time {
+	return util.RandomDatetime().UTC().Truncate(24 * time.Hour)
+}
+
+// createRandomReportData adds a lesson held on day, an invoice and a receipt for a new random student.
+// The student is linked to a new college and funnel. It returns the Lesson, Invoice and Receipt created.
+func createRandomReportData(t *testing.T, day time.Time) (Lesson, Invoice, Receipt) {
+	student := createRandomStudent(t)
+	location := createRandomLessonLocation(t)
+	subject := createRandomLessonSubject(t)
+
+	lesson, err := testQueries.CreateLesson(context.Background(), CreateLessonParams{
+		LessonDatetime: day.Add(10 * time.Hour),
+		Duration:       util.RandomLessonDuration(),
+		LocationID:     location.LocationID,
+		SubjectID:      subject.SubjectID,
+		Notes:          sql.NullString{String: util.RandomNote(), Valid: true},
+	})
+	require.NoError(t, err)
+
+	invoice, err := testQueries.CreateInvoice(context.Background(), CreateInvoiceParams{
+		StudentID:       student.StudentID,
+		LessonID:        lesson.LessonID,
+		InvoiceDatetime: lesson.LessonDatetime,
+		HourlyFee:       student.HourlyFee.Float64,
+		Duration:        lesson.Duration,
+		Discount:        0,
+		Amount:          util.RandomInvoiceAmount(),
+	})
+	require.NoError(t, err)
+
+	receipt, err := testQueries.CreateReceipt(context.Background(), CreateReceiptParams{
+		StudentID:       student.StudentID,
+		ReceiptDatetime: lesson.LessonDatetime,
+		Amount:          util.RandomPaymentAmount(),
+	})
+	require.NoError(t, err)
+
+	return lesson, invoice, receipt
+}
+
+func TestGetRevenueReport(t *testing.T) {
+	day := randomReportDay()
+	_, invoice, receipt := createRandomReportData(t, day)
+
+	report, err := testQueries.GetRevenueReport(context.Background(), GetRevenueReportParams{
+		Period:        "day",
+		StartDatetime: day,
+		EndDatetime:   day.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, report)
+
+	invoiced, collected := 0.0, 0.0
+	for _, row := range report {
+		require.WithinDuration(t, day, row.PeriodStart, 24*time.Hour)
+		invoiced += row.InvoicedAmount
+		collected += row.CollectedAmount
+	}
+
+	require.GreaterOrEqual(t, invoiced, invoice.Amount)
+	require.GreaterOrEqual(t, collected, receipt.Amount)
+}
+
+func TestGetSubjectHoursReport(t *testing.T) {
+	day := randomReportDay()
+	lesson, _, _ := createRandomReportData(t, day)
+
+	report, err := testQueries.GetSubjectHoursReport(context.Background(), GetSubjectHoursReportParams{
+		Period:        "month",
+		StartDatetime: day,
+		EndDatetime:   day.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, report)
+
+	found := false
+	for _, row := range report {
+		if row.SubjectID == lesson.SubjectID {
+			found = true
+			require.Equal(t, int64(1), row.LessonsCount)
+			require.InDelta(t, float64(lesson.Duration)/60.0, row.Hours, 0.001)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestGetLocationHoursReport(t *testing.T) {
+	day := randomReportDay()
+	lesson, _, _ := createRandomReportData(t, day)
+
+	report, err := testQueries.GetLocationHoursReport(context.Background(), GetLocationHoursReportParams{
+		Period:        "month",
+		StartDatetime: day,
+		EndDatetime:   day.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, report)
+
+	found := false
+	for _, row := range report {
+		if row.LocationID == lesson.LocationID {
+			found = true
+			require.Equal(t, int64(1), row.LessonsCount)
+			require.InDelta(t, float64(lesson.Duration)/60.0, row.Hours, 0.001)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestGetCollegeRevenueReport(t *testing.T) {
+	day := randomReportDay()
+	_, invoice, _ := createRandomReportData(t, day)
+
+	student, err := testQueries.GetStudent(context.Background(), invoice.StudentID)
+	require.NoError(t, err)
+
+	report, err := testQueries.GetCollegeRevenueReport(context.Background(), GetCollegeRevenueReportParams{
+		Period:        "year",
+		StartDatetime: day,
+		EndDatetime:   day.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, report)
+
+	found := false
+	for _, row := range report {
+		if row.CollegeID == student.CollegeID {
+			found = true
+			require.Equal(t, int64(1), row.StudentsCount)
+			require.Equal(t, invoice.Amount, row.InvoicedAmount)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestGetFunnelRevenueReport(t *testing.T) {
+	day := randomReportDay()
+	_, invoice, _ := createRandomReportData(t, day)
+
+	student, err := testQueries.GetStudent(context.Background(), invoice.StudentID)
+	require.NoError(t, err)
+
+	report, err := testQueries.GetFunnelRevenueReport(context.Background(), GetFunnelRevenueReportParams{
+		Period:        "year",
+		StartDatetime: day,
+		EndDatetime:   day.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, report)
+
+	found := false
+	for _, row := range report {
+		if row.FunnelID == student.FunnelID {
+			found = true
+			require.Equal(t, int64(1), row.StudentsCount)
+			require.Equal(t, invoice.Amount, row.InvoicedAmount)
+		}
+	}
+	require.True(t, found)
+}
+
+// createRandomAgingData adds a new random student with four invoices of 100.00 each, aged 10, 45, 75 and 120 days
+// before asOf, and a receipt of 150.00. It returns the Student created.
+func createRandomAgingData(t *testing.T, asOf time.Time) Student {
+	student := createRandomStudent(t)
+
+	for _, days := range []int{10, 45, 75, 120} {
+		lesson := createRandomLesson(t)
+
+		_, err := testQueries.CreateInvoice(context.Background(), CreateInvoiceParams{
+			StudentID:       student.StudentID,
+			LessonID:        lesson.LessonID,
+			InvoiceDatetime: asOf.AddDate(0, 0, -days),
+			HourlyFee:       100.00,
+			Duration:        60,
+			Discount:        0,
+			Amount:          100.00,
+		})
+		require.NoError(t, err)
+	}
+
+	_, err := testQueries.CreateReceipt(context.Background(), CreateReceiptParams{
+		StudentID:       student.StudentID,
+		ReceiptDatetime: asOf.AddDate(0, 0, -5),
+		Amount:          150.00,
+	})
+	require.NoError(t, err)
+
+	return student
+}
+
+func TestGetAgingReport(t *testing.T) {
+	asOf := time.Now().UTC()
+	student := createRandomAgingData(t, asOf)
+
+	report, err := testQueries.GetAgingReport(context.Background(), asOf)
+	require.NoError(t, err)
+	require.NotEmpty(t, report)
+
+	found := false
+	for _, row := range report {
+		if row.StudentID == student.StudentID {
+			found = true
+
+			// the receipt pays the oldest invoice, and half of the second oldest invoice
+			require.Equal(t, 100.00, row.Days0To30)
+			require.Equal(t, 100.00, row.Days31To60)
+			require.Equal(t, 50.00, row.Days61To90)
+			require.Equal(t, 0.00, row.DaysOver90)
+			require.Equal(t, 250.00, row.TotalAmount)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestGetStudentAgingInvoices(t *testing.T) {
+	asOf := time.Now().UTC()
+	student := createRandomAgingData(t, asOf)
+
+	invoices, err := testQueries.GetStudentAgingInvoices(context.Background(), GetStudentAgingInvoicesParams{
+		AsOfDatetime: asOf,
+		StudentID:    student.StudentID,
+	})
+	require.NoError(t, err)
+	require.Len(t, invoices, 3)
+
+	require.Equal(t, int64(75), invoices[0].AgeDays)
+	require.Equal(t, 50.00, invoices[0].UnpaidAmount)
+	require.Equal(t, int64(45), invoices[1].AgeDays)
+	require.Equal(t, 100.00, invoices[1].UnpaidAmount)
+	require.Equal(t, int64(10), invoices[2].AgeDays)
+	require.Equal(t, 100.00, invoices[2].UnpaidAmount)
+}
+
+func TestGetFunnelCohortReport(t *testing.T) {
+	// student created now in a new funnel, with a lesson held an hour later
+	student := createRandomStudent(t)
+	lesson := createRandomLesson(t)
+
+	err := testQueries.UpdateLesson(context.Background(), UpdateLessonParams{
+		LessonID:       lesson.LessonID,
+		LessonDatetime: student.CreatedAt.Add(time.Hour),
+		Duration:       lesson.Duration,
+		LocationID:     lesson.LocationID,
+		SubjectID:      lesson.SubjectID,
+		Notes:          lesson.Notes,
+	})
+	require.NoError(t, err)
+
+	invoice, err := testQueries.CreateInvoice(context.Background(), CreateInvoiceParams{
+		StudentID:       student.StudentID,
+		LessonID:        lesson.LessonID,
+		InvoiceDatetime: student.CreatedAt.Add(time.Hour),
+		HourlyFee:       100.00,
+		Duration:        60,
+		Discount:        0,
+		Amount:          100.00,
+	})
+	require.NoError(t, err)
+
+	funnel, err := testQueries.GetFunnel(context.Background(), student.FunnelID.Int64)
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateFunnelCost(context.Background(), CreateFunnelCostParams{
+		FunnelID: funnel.FunnelID,
+		CostDate: student.CreatedAt,
+		Amount:   40.00,
+	})
+	require.NoError(t, err)
+
+	report, err := testQueries.GetFunnelCohortReport(context.Background(), GetFunnelCohortReportParams{
+		Period:        "year",
+		StartDatetime: student.CreatedAt.Add(-24 * time.Hour),
+		EndDatetime:   student.CreatedAt.Add(24 * time.Hour),
+		RetainedSince: student.CreatedAt.Add(-24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, report)
+
+	found := false
+	for _, row := range report {
+		if row.FunnelID.Int64 == funnel.FunnelID {
+			found = true
+
+			require.Equal(t, funnel.Name, row.Name.String)
+			require.Equal(t, int64(1), row.StudentsCount)
+			require.Equal(t, int64(1), row.ConvertedCount)
+			require.Equal(t, int64(1), row.RetainedCount)
+			require.True(t, row.AvgDaysToFirstLesson.Valid)
+			require.InDelta(t, 1.0/24.0, row.AvgDaysToFirstLesson.Float64, 0.001)
+			require.Equal(t, invoice.Amount, row.LifetimeRevenue)
+			require.True(t, row.AcquisitionCost.Valid)
+			require.Equal(t, 40.00, row.AcquisitionCost.Float64)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestGetExamOutcomeReport(t *testing.T) {
+	day := randomReportDay()
+	student := createRandomStudent(t)
+	subject := createRandomLessonSubject(t)
+
+	exam1 := createRandomExam(t, student, subject, day.Add(9*time.Hour))
+	exam2 := createRandomExam(t, student, subject, day.Add(12*time.Hour))
+	createRandomExam(t, student, subject, day.Add(15*time.Hour))
+
+	// the first exam meets its target, the second misses it, and the third is not graded yet
+	for _, exam := range []struct {
+		exam  Exam
+		grade float64
+	}{
+		{exam1, exam1.TargetGrade.Float64 + 5},
+		{exam2, exam2.TargetGrade.Float64 - 10},
+	} {
+		err := testQueries.UpdateExam(context.Background(), UpdateExamParams{
+			ExamID:        exam.exam.ExamID,
+			SubjectID:     exam.exam.SubjectID,
+			CollegeID:     exam.exam.CollegeID,
+			Name:          exam.exam.Name,
+			ExamDatetime:  exam.exam.ExamDatetime,
+			TargetGrade:   exam.exam.TargetGrade,
+			AchievedGrade: sql.NullFloat64{Float64: exam.grade, Valid: true},
+			Notes:         exam.exam.Notes,
+		})
+		require.NoError(t, err)
+	}
+
+	report, err := testQueries.GetExamOutcomeReport(context.Background(), GetExamOutcomeReportParams{
+		Period:        "month",
+		StartDatetime: day,
+		EndDatetime:   day.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, report)
+
+	found := false
+	for _, row := range report {
+		if row.SubjectID == subject.SubjectID {
+			found = true
+
+			require.Equal(t, subject.Name, row.Name)
+			require.Equal(t, int64(3), row.ExamsCount)
+			require.Equal(t, int64(2), row.GradedCount)
+			require.Equal(t, int64(1), row.TargetMetCount)
+			require.InDelta(t, (exam1.TargetGrade.Float64+exam2.TargetGrade.Float64)/2, row.AverageTargetGrade, 0.001)
+			require.InDelta(t, (exam1.TargetGrade.Float64+exam2.TargetGrade.Float64-5)/2, row.AverageAchievedGrade, 0.001)
+			require.InDelta(t, -2.5, row.AverageGradeDifference, 0.001)
+		}
+	}
+	require.True(t, found)
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// StatementDocument contains everything shown on a monthly statement:
+// the student and their billing contact, and the invoices and receipts of the statement period.
+type StatementDocument struct {
+	Statement      Statement `json:"statement"`
+	Student        Student   `json:"student"`
+	BillingContact *Guardian `json:"billing_contact"`
+	Invoices       []Invoice `json:"invoices"`
+	Receipts       []Receipt `json:"receipts"`
+}
+
+// StatementPeriod returns the start and end of the calendar month of t, in UTC.
+func StatementPeriod(t time.Time) (start, end time.Time) {
+	t = t.UTC()
+	start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// CreateMonthlyStatementsTx creates the statements of the calendar month of month, for all students
+// or for a single student if studentID is valid, and adds the statement emails of the students to the email outbox.
+// Students that already have a statement of the month are skipped, so only the created statements are returned.
+func (store *SQLStore) CreateMonthlyStatementsTx(ctx context.Context, month time.Time, studentID sql.NullInt64) ([]Statement, error) {
+	var result []Statement
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		start, end := StatementPeriod(month)
+		result, err = q.CreateStatements(ctx, CreateStatementsParams{
+			PeriodStart: start,
+			PeriodEnd:   end,
+			StudentID:   studentID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, statement := range result {
+			doc, err := q.getStatementDocument(ctx, statement)
+			if err != nil {
+				return err
+			}
+
+			payload := EmailPayload{
+				Student:   doc.Student,
+				Guardian:  doc.BillingContact,
+				Statement: &doc.Statement,
+				Invoices:  doc.Invoices,
+				Receipts:  doc.Receipts,
+			}
+
+			err = q.enqueueStudentEmail(ctx, EmailTemplateMonthlyStatement, time.Now().UTC(), payload)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// GetStatementDocumentTx gets a statement with its student, billing contact, invoices and receipts.
+func (store *SQLStore) GetStatementDocumentTx(ctx context.Context, statementID int64) (StatementDocument, error) {
+	var result StatementDocument
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		statement, err := q.GetStatement(ctx, statementID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.getStatementDocument(ctx, statement)
+		return err
+	})
+
+	return result, err
+}
+
+// getStatementDocument gets the student, billing contact, invoices and receipts of a statement.
+func (q *Queries) getStatementDocument(ctx context.Context, statement Statement) (StatementDocument, error) {
+	result := StatementDocument{Statement: statement}
+	var err error
+
+	result.Student, err = q.GetStudent(ctx, statement.StudentID)
+	if err != nil {
+		return result, err
+	}
+
+	result.BillingContact, err = q.getBillingContact(ctx, statement.StudentID)
+	if err != nil {
+		return result, err
+	}
+
+	result.Invoices, err = q.GetInvoicesByStudentPeriod(ctx, GetInvoicesByStudentPeriodParams{
+		StudentID:     statement.StudentID,
+		StartDatetime: statement.PeriodStart,
+		EndDatetime:   statement.PeriodEnd,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Receipts, err = q.GetReceiptsByStudentPeriod(ctx, GetReceiptsByStudentPeriodParams{
+		StudentID:     statement.StudentID,
+		StartDatetime: statement.PeriodStart,
+		EndDatetime:   statement.PeriodEnd,
+	})
+
+	return result, err
+}
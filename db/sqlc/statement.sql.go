@@ -0,0 +1,252 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: statement.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createStatements = `-- name: CreateStatements :many
+WITH totals AS (
+  SELECT s.student_id,
+         COALESCE((SELECT SUM(i.amount) FROM invoices i
+                   WHERE i.student_id = s.student_id AND i.invoice_datetime < $1), 0)
+         - COALESCE((SELECT SUM(r.amount) FROM receipts r
+                     WHERE r.student_id = s.student_id AND r.receipt_datetime < $1), 0) AS previous_balance,
+         COALESCE((SELECT SUM(i.amount) FROM invoices i
+                   WHERE i.student_id = s.student_id
+                     AND i.invoice_datetime >= $1 AND i.invoice_datetime < $2), 0) AS invoiced_amount,
+         COALESCE((SELECT SUM(r.amount) FROM receipts r
+                   WHERE r.student_id = s.student_id
+                     AND r.receipt_datetime >= $1 AND r.receipt_datetime < $2), 0) AS payments_amount
+  FROM students s
+  WHERE $3::bigint IS NULL OR s.student_id = $3
+)
+INSERT INTO statements (
+  student_id, period_start, period_end, previous_balance, invoiced_amount, payments_amount, total_due
+)
+SELECT t.student_id,
+       $1::timestamptz,
+       $2::timestamptz,
+       t.previous_balance::float,
+       t.invoiced_amount::float,
+       t.payments_amount::float,
+       (t.previous_balance + t.invoiced_amount - t.payments_amount)::float
+FROM totals t
+WHERE ABS(t.invoiced_amount) >= 0.005 OR ABS(t.payments_amount) >= 0.005 OR ABS(t.previous_balance) >= 0.005
+ON CONFLICT (student_id, period_start) DO NOTHING
+RETURNING statement_id, student_id, period_start, period_end, previous_balance, invoiced_amount, payments_amount, total_due, created_at
+`
+
+type CreateStatementsParams struct {
+	PeriodStart time.Time     `json:"period_start"`
+	PeriodEnd   time.Time     `json:"period_end"`
+	StudentID   sql.NullInt64 `json:"student_id"`
+}
+
+// CreateStatements creates the statements of a period for every student with invoices or payments in the period,
+// or with an outstanding balance before it, optionally for a single student.
+// Students that already have a statement of the period are skipped, so billing a period is idempotent.
+func (q *Queries) CreateStatements(ctx context.Context, arg CreateStatementsParams) ([]Statement, error) {
+	rows, err := q.db.QueryContext(ctx, createStatements, arg.PeriodStart, arg.PeriodEnd, arg.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Statement{}
+	for rows.Next() {
+		var i Statement
+		if err := rows.Scan(
+			&i.StatementID,
+			&i.StudentID,
+			&i.PeriodStart,
+			&i.PeriodEnd,
+			&i.PreviousBalance,
+			&i.InvoicedAmount,
+			&i.PaymentsAmount,
+			&i.TotalDue,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getInvoicesByStudentPeriod = `-- name: GetInvoicesByStudentPeriod :many
+SELECT invoice_id, student_id, lesson_id, invoice_datetime, hourly_fee, duration, discount, amount, notes, fee_schedule_id FROM invoices
+WHERE student_id = $1
+  AND invoice_datetime >= $2 AND invoice_datetime < $3
+ORDER BY invoice_datetime
+`
+
+type GetInvoicesByStudentPeriodParams struct {
+	StudentID     int64     `json:"student_id"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+func (q *Queries) GetInvoicesByStudentPeriod(ctx context.Context, arg GetInvoicesByStudentPeriodParams) ([]Invoice, error) {
+	rows, err := q.db.QueryContext(ctx, getInvoicesByStudentPeriod, arg.StudentID, arg.StartDatetime, arg.EndDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Invoice{}
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.InvoiceID,
+			&i.StudentID,
+			&i.LessonID,
+			&i.InvoiceDatetime,
+			&i.HourlyFee,
+			&i.Duration,
+			&i.Discount,
+			&i.Amount,
+			&i.Notes,
+			&i.FeeScheduleID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReceiptsByStudentPeriod = `-- name: GetReceiptsByStudentPeriod :many
+SELECT receipt_id, student_id, receipt_datetime, amount, notes, guardian_receipt_id FROM receipts
+WHERE student_id = $1
+  AND receipt_datetime >= $2 AND receipt_datetime < $3
+ORDER BY receipt_datetime
+`
+
+type GetReceiptsByStudentPeriodParams struct {
+	StudentID     int64     `json:"student_id"`
+	StartDatetime time.Time `json:"start_datetime"`
+	EndDatetime   time.Time `json:"end_datetime"`
+}
+
+func (q *Queries) GetReceiptsByStudentPeriod(ctx context.Context, arg GetReceiptsByStudentPeriodParams) ([]Receipt, error) {
+	rows, err := q.db.QueryContext(ctx, getReceiptsByStudentPeriod, arg.StudentID, arg.StartDatetime, arg.EndDatetime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Receipt{}
+	for rows.Next() {
+		var i Receipt
+		if err := rows.Scan(
+			&i.ReceiptID,
+			&i.StudentID,
+			&i.ReceiptDatetime,
+			&i.Amount,
+			&i.Notes,
+			&i.GuardianReceiptID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStatement = `-- name: GetStatement :one
+SELECT statement_id, student_id, period_start, period_end, previous_balance, invoiced_amount, payments_amount, total_due, created_at FROM statements
+WHERE statement_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetStatement(ctx context.Context, statementID int64) (Statement, error) {
+	row := q.db.QueryRowContext(ctx, getStatement, statementID)
+	var i Statement
+	err := row.Scan(
+		&i.StatementID,
+		&i.StudentID,
+		&i.PeriodStart,
+		&i.PeriodEnd,
+		&i.PreviousBalance,
+		&i.InvoicedAmount,
+		&i.PaymentsAmount,
+		&i.TotalDue,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listStatements = `-- name: ListStatements :many
+SELECT statement_id, student_id, period_start, period_end, previous_balance, invoiced_amount, payments_amount, total_due, created_at FROM statements
+WHERE ($1::bigint IS NULL OR student_id = $1)
+  AND ($2::timestamptz IS NULL OR period_start = $2)
+ORDER BY period_start DESC, student_id
+LIMIT $4
+OFFSET $3
+`
+
+type ListStatementsParams struct {
+	StudentID   sql.NullInt64 `json:"student_id"`
+	PeriodStart sql.NullTime  `json:"period_start"`
+	Offset      int32         `json:"offset"`
+	Limit       int32         `json:"limit"`
+}
+
+func (q *Queries) ListStatements(ctx context.Context, arg ListStatementsParams) ([]Statement, error) {
+	rows, err := q.db.QueryContext(ctx, listStatements,
+		arg.StudentID,
+		arg.PeriodStart,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Statement{}
+	for rows.Next() {
+		var i Statement
+		if err := rows.Scan(
+			&i.StatementID,
+			&i.StudentID,
+			&i.PeriodStart,
+			&i.PeriodEnd,
+			&i.PreviousBalance,
+			&i.InvoicedAmount,
+			&i.PaymentsAmount,
+			&i.TotalDue,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,174 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomStatementData adds a student with an invoice and a receipt before month,
+// and two invoices and a receipt in month.
+func createRandomStatementData(t *testing.T, month time.Time) (student Student, previousBalance, invoiced, paid float64) {
+	start, _ := StatementPeriod(month)
+
+	student = createRandomStudentLessons(t, start.Add(-48*time.Hour), start.Add(24*time.Hour), start.Add(10*24*time.Hour))
+
+	invoices, err := testQueries.GetInvoicesByStudent(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Len(t, invoices, 3)
+
+	for _, invoice := range invoices {
+		if invoice.InvoiceDatetime.Before(start) {
+			previousBalance += invoice.Amount
+		} else {
+			invoiced += invoice.Amount
+		}
+	}
+
+	for _, receiptDatetime := range []time.Time{start.Add(-24 * time.Hour), start.Add(5 * 24 * time.Hour)} {
+		receipt, err := testQueries.CreateReceipt(context.Background(), CreateReceiptParams{
+			StudentID:       student.StudentID,
+			ReceiptDatetime: receiptDatetime,
+			Amount:          util.RandomPaymentAmount(),
+		})
+		require.NoError(t, err)
+
+		if receiptDatetime.Before(start) {
+			previousBalance -= receipt.Amount
+		} else {
+			paid += receipt.Amount
+		}
+	}
+
+	return student, previousBalance, invoiced, paid
+}
+
+func TestStatementPeriod(t *testing.T) {
+	start, end := StatementPeriod(time.Date(2024, time.December, 31, 23, 0, 0, 0, time.FixedZone("", -3*60*60)))
+	require.Equal(t, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), end)
+}
+
+func TestCreateMonthlyStatementsTx(t *testing.T) {
+	store := NewStore(testDB)
+	month := time.Date(2030, time.March, 1, 0, 0, 0, 0, time.UTC)
+	student, previousBalance, invoiced, paid := createRandomStatementData(t, month)
+	studentID := sql.NullInt64{Int64: student.StudentID, Valid: true}
+
+	statements, err := store.CreateMonthlyStatementsTx(context.Background(), month.Add(10*24*time.Hour), studentID)
+	require.NoError(t, err)
+	require.Len(t, statements, 1)
+
+	statement := statements[0]
+	require.NotZero(t, statement.StatementID)
+	require.Equal(t, student.StudentID, statement.StudentID)
+	require.True(t, month.Equal(statement.PeriodStart))
+	require.True(t, month.AddDate(0, 1, 0).Equal(statement.PeriodEnd))
+	require.InDelta(t, previousBalance, statement.PreviousBalance, 0.001)
+	require.InDelta(t, invoiced, statement.InvoicedAmount, 0.001)
+	require.InDelta(t, paid, statement.PaymentsAmount, 0.001)
+	require.InDelta(t, previousBalance+invoiced-paid, statement.TotalDue, 0.001)
+
+	// the statement email is added to the outbox
+	emails, err := testQueries.GetOutboxEmailsByStudent(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Len(t, emails, 1)
+	require.Equal(t, EmailTemplateMonthlyStatement, emails[0].Template)
+
+	var payload EmailPayload
+	require.NoError(t, json.Unmarshal(emails[0].Payload, &payload))
+	require.Equal(t, statement.StatementID, payload.Statement.StatementID)
+	require.Len(t, payload.Invoices, 2)
+	require.Len(t, payload.Receipts, 1)
+
+	// billing the month again creates no statements and sends no emails
+	statements, err = store.CreateMonthlyStatementsTx(context.Background(), month, studentID)
+	require.NoError(t, err)
+	require.Empty(t, statements)
+
+	emails, err = testQueries.GetOutboxEmailsByStudent(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Len(t, emails, 1)
+}
+
+func TestCreateMonthlyStatementsTxAllStudents(t *testing.T) {
+	store := NewStore(testDB)
+	month := time.Date(2031, time.June, 1, 0, 0, 0, 0, time.UTC)
+	student1, _, _, _ := createRandomStatementData(t, month)
+	student2, _, _, _ := createRandomStatementData(t, month)
+
+	statements, err := store.CreateMonthlyStatementsTx(context.Background(), month, sql.NullInt64{})
+	require.NoError(t, err)
+
+	billed := make(map[int64]bool)
+	for _, statement := range statements {
+		billed[statement.StudentID] = true
+	}
+	require.True(t, billed[student1.StudentID])
+	require.True(t, billed[student2.StudentID])
+
+	statements, err = store.CreateMonthlyStatementsTx(context.Background(), month, sql.NullInt64{})
+	require.NoError(t, err)
+	require.Empty(t, statements)
+}
+
+func TestGetStatementDocumentTx(t *testing.T) {
+	store := NewStore(testDB)
+	month := time.Date(2032, time.September, 1, 0, 0, 0, 0, time.UTC)
+	student, _, _, _ := createRandomStatementData(t, month)
+
+	statements, err := store.CreateMonthlyStatementsTx(context.Background(), month, sql.NullInt64{Int64: student.StudentID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, statements, 1)
+
+	doc, err := store.GetStatementDocumentTx(context.Background(), statements[0].StatementID)
+	require.NoError(t, err)
+	require.Equal(t, statements[0].StatementID, doc.Statement.StatementID)
+	require.Equal(t, student.StudentID, doc.Student.StudentID)
+	require.Len(t, doc.Invoices, 2)
+	require.Len(t, doc.Receipts, 1)
+
+	for _, invoice := range doc.Invoices {
+		require.False(t, invoice.InvoiceDatetime.Before(doc.Statement.PeriodStart))
+		require.True(t, invoice.InvoiceDatetime.Before(doc.Statement.PeriodEnd))
+	}
+
+	_, err = store.GetStatementDocumentTx(context.Background(), 0)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListStatements(t *testing.T) {
+	store := NewStore(testDB)
+	month := time.Date(2033, time.January, 1, 0, 0, 0, 0, time.UTC)
+	student, _, _, _ := createRandomStatementData(t, month)
+	studentID := sql.NullInt64{Int64: student.StudentID, Valid: true}
+
+	for i := 0; i < 3; i++ {
+		_, err := store.CreateMonthlyStatementsTx(context.Background(), month.AddDate(0, i, 0), studentID)
+		require.NoError(t, err)
+	}
+
+	statements, err := testQueries.ListStatements(context.Background(), ListStatementsParams{
+		StudentID: studentID,
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, statements, 3)
+	require.True(t, statements[0].PeriodStart.After(statements[1].PeriodStart))
+
+	statements, err = testQueries.ListStatements(context.Background(), ListStatementsParams{
+		StudentID:   studentID,
+		PeriodStart: sql.NullTime{Time: month, Valid: true},
+		Limit:       5,
+		Offset:      0,
+	})
+	require.NoError(t, err)
+	require.Len(t, statements, 1)
+	require.True(t, month.Equal(statements[0].PeriodStart))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 )
 
 // Store provides all functions to execute db queries and transactions
@@ -16,6 +17,36 @@ type Store interface {
 	CreateLessonWithInvoicesTx(ctx context.Context, arg CreateLessonTxParams) (LessonWithInvoices, error)
 	GetLessonWithInvoicesTx(ctx context.Context, lessonID int64) (LessonWithInvoices, error)
 	DeleteLessonWithInvoicesTx(ctx context.Context, lessonID int64) error
+	CreateStudentTx(ctx context.Context, arg CreateStudentParams) (Student, error)
+	UpdateInvoiceTx(ctx context.Context, arg UpdateInvoiceParams) (Invoice, error)
+	PurchasePackageTx(ctx context.Context, arg PurchasePackageTxParams) (PackagePurchase, error)
+	GetStudentPackageBalanceTx(ctx context.Context, studentID int64, asOf time.Time) (StudentPackageBalance, error)
+	CreateGroupPricingPlanTx(ctx context.Context, arg GroupPricingPlanTxParams) (GroupPricingPlanWithTiers, error)
+	GetGroupPricingPlanTx(ctx context.Context, planID int64) (GroupPricingPlanWithTiers, error)
+	UpdateGroupPricingPlanTx(ctx context.Context, arg GroupPricingPlanTxParams) (GroupPricingPlanWithTiers, error)
+	CreateGroupLessonTx(ctx context.Context, arg CreateGroupLessonTxParams) (LessonWithInvoices, error)
+	AddLessonStudentTx(ctx context.Context, arg AddLessonStudentTxParams) (LessonWithInvoices, error)
+	RemoveLessonStudentTx(ctx context.Context, lessonID int64, studentID int64) (LessonWithInvoices, error)
+	ResolveHourlyFeeTx(ctx context.Context, arg GetApplicableFeeScheduleParams) (ResolvedHourlyFee, error)
+	LinkStudentGuardianTx(ctx context.Context, arg UpsertStudentGuardianParams) (StudentGuardian, error)
+	CreateGuardianReceiptTx(ctx context.Context, arg CreateGuardianReceiptTxParams) (GuardianReceiptWithReceipts, error)
+	GetGuardianReceiptTx(ctx context.Context, guardianReceiptID int64) (GuardianReceiptWithReceipts, error)
+	DeleteGuardianReceiptTx(ctx context.Context, guardianReceiptID int64) error
+	UpsertLessonRecordTx(ctx context.Context, arg UpsertLessonRecordParams) (LessonRecord, error)
+	CreateMonthlyStatementsTx(ctx context.Context, month time.Time, studentID sql.NullInt64) ([]Statement, error)
+	GetStatementDocumentTx(ctx context.Context, statementID int64) (StatementDocument, error)
+	CreateProgressReportsTx(ctx context.Context, start, end time.Time, studentID sql.NullInt64) ([]ProgressReport, error)
+	GetProgressReportDocumentTx(ctx context.Context, progressReportID int64) (ProgressReportDocument, error)
+	CreateAttachmentTx(ctx context.Context, arg CreateAttachmentParams) (Attachment, error)
+	GetAvailabilityTx(ctx context.Context, start, end time.Time) (Availability, error)
+	ApproveBookingRequestTx(ctx context.Context, arg ApproveBookingRequestTxParams) (ApprovedBookingRequest, error)
+	ConvertLeadTx(ctx context.Context, arg ConvertLeadTxParams) (ConvertedLead, error)
+	UpdateTaskTx(ctx context.Context, arg UpdateTaskParams) (UpdatedTask, error)
+	EnqueueTaskDigestTx(ctx context.Context, recipient string, now time.Time) (TaskDigest, error)
+	SetStudentCustomValueTx(ctx context.Context, arg UpsertStudentCustomValueParams) (StudentCustomValue, error)
+	ExportStudentsTx(ctx context.Context, arg ExportStudentsParams) (StudentExport, error)
+	LockJobTx(ctx context.Context, jobName string, fn func(ctx context.Context) error) error
+	UpdateStudentsLifecycleTx(ctx context.Context, asOf time.Time, thresholds LifecycleThresholds) (int, error)
 }
 
 // SQLStore provides all functions to execute SQL queries and transactions
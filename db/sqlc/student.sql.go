@@ -8,6 +8,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 const createStudent = `-- name: CreateStudent :one
@@ -16,7 +17,7 @@ INSERT INTO students (
 ) VALUES (
   $1, $2, $3, $4, $5, $6, $7, $8, $9
 )
-RETURNING student_id, first_name, last_name, email, phone_number, address, college_id, funnel_id, hourly_fee, notes, created_at
+RETURNING student_id, first_name, last_name, email, phone_number, address, college_id, funnel_id, hourly_fee, notes, created_at, lifecycle_status
 `
 
 type CreateStudentParams struct {
@@ -56,6 +57,7 @@ func (q *Queries) CreateStudent(ctx context.Context, arg CreateStudentParams) (S
 		&i.HourlyFee,
 		&i.Notes,
 		&i.CreatedAt,
+		&i.LifecycleStatus,
 	)
 	return i, err
 }
@@ -70,8 +72,68 @@ func (q *Queries) DeleteStudent(ctx context.Context, studentID int64) error {
 	return err
 }
 
+const exportStudents = `-- name: ExportStudents :many
+SELECT student_id, first_name, last_name, email, phone_number, address, college_id, funnel_id, hourly_fee, notes, created_at, lifecycle_status FROM students s
+WHERE ($1::varchar IS NULL OR s.lifecycle_status = $1)
+  AND ($2::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM student_tags st WHERE st.student_id = s.student_id AND st.tag_id = $2))
+  AND ($3::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM student_custom_values v
+    WHERE v.student_id = s.student_id AND v.field_id = $3 AND v.value = $4::varchar))
+ORDER BY s.last_name, s.first_name, s.student_id
+`
+
+type ExportStudentsParams struct {
+	LifecycleStatus sql.NullString `json:"lifecycle_status"`
+	TagID           sql.NullInt64  `json:"tag_id"`
+	FieldID         sql.NullInt64  `json:"field_id"`
+	FieldValue      sql.NullString `json:"field_value"`
+}
+
+// ExportStudents lists all the students matching the filters of ListStudents, by name.
+func (q *Queries) ExportStudents(ctx context.Context, arg ExportStudentsParams) ([]Student, error) {
+	rows, err := q.db.QueryContext(ctx, exportStudents,
+		arg.LifecycleStatus,
+		arg.TagID,
+		arg.FieldID,
+		arg.FieldValue,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Student{}
+	for rows.Next() {
+		var i Student
+		if err := rows.Scan(
+			&i.StudentID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.PhoneNumber,
+			&i.Address,
+			&i.CollegeID,
+			&i.FunnelID,
+			&i.HourlyFee,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.LifecycleStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getStudent = `-- name: GetStudent :one
-SELECT student_id, first_name, last_name, email, phone_number, address, college_id, funnel_id, hourly_fee, notes, created_at FROM students
+SELECT student_id, first_name, last_name, email, phone_number, address, college_id, funnel_id, hourly_fee, notes, created_at, lifecycle_status FROM students
 WHERE student_id = $1 LIMIT 1
 `
 
@@ -90,24 +152,133 @@ func (q *Queries) GetStudent(ctx context.Context, studentID int64) (Student, err
 		&i.HourlyFee,
 		&i.Notes,
 		&i.CreatedAt,
+		&i.LifecycleStatus,
 	)
 	return i, err
 }
 
+const getStudentsLifecycle = `-- name: GetStudentsLifecycle :many
+WITH student_lessons AS (
+  SELECT i.student_id,
+         MAX(l.lesson_datetime)::timestamptz AS last_lesson_datetime,
+         COUNT(*) FILTER (WHERE l.lesson_datetime >= $3::timestamptz) AS recent_lessons_count,
+         COUNT(*) FILTER (WHERE l.lesson_datetime >= $4::timestamptz
+                            AND l.lesson_datetime < $3::timestamptz) AS baseline_lessons_count
+  FROM invoices i
+  JOIN lessons l ON l.lesson_id = i.lesson_id
+  WHERE l.lesson_datetime < $5::timestamptz
+  GROUP BY i.student_id
+)
+SELECT s.student_id,
+       s.first_name,
+       s.last_name,
+       s.lifecycle_status,
+       sl.last_lesson_datetime,
+       COALESCE(sl.recent_lessons_count, 0)::bigint AS recent_lessons_count,
+       COALESCE(sl.baseline_lessons_count, 0)::bigint AS baseline_lessons_count,
+       (CASE
+          WHEN COALESCE(sl.last_lesson_datetime, s.created_at) < $1::timestamptz THEN 'lapsed'
+          WHEN COALESCE(sl.last_lesson_datetime, s.created_at) < $2::timestamptz THEN 'at-risk'
+          WHEN COALESCE(sl.recent_lessons_count, 0) * EXTRACT(EPOCH FROM $3::timestamptz - $4::timestamptz)
+             < 0.5 * COALESCE(sl.baseline_lessons_count, 0) * EXTRACT(EPOCH FROM $5::timestamptz - $3::timestamptz) THEN 'at-risk'
+          ELSE 'active'
+        END)::varchar AS computed_status
+FROM students s
+LEFT JOIN student_lessons sl ON sl.student_id = s.student_id
+WHERE s.created_at < $5::timestamptz
+ORDER BY s.last_name, s.first_name
+`
+
+type GetStudentsLifecycleParams struct {
+	LapsedSince   time.Time `json:"lapsed_since"`
+	AtRiskSince   time.Time `json:"at_risk_since"`
+	RecentSince   time.Time `json:"recent_since"`
+	BaselineSince time.Time `json:"baseline_since"`
+	AsOf          time.Time `json:"as_of"`
+}
+
+type GetStudentsLifecycleRow struct {
+	StudentID            int64        `json:"student_id"`
+	FirstName            string       `json:"first_name"`
+	LastName             string       `json:"last_name"`
+	LifecycleStatus      string       `json:"lifecycle_status"`
+	LastLessonDatetime   sql.NullTime `json:"last_lesson_datetime"`
+	RecentLessonsCount   int64        `json:"recent_lessons_count"`
+	BaselineLessonsCount int64        `json:"baseline_lessons_count"`
+	ComputedStatus       string       `json:"computed_status"`
+}
+
+func (q *Queries) GetStudentsLifecycle(ctx context.Context, arg GetStudentsLifecycleParams) ([]GetStudentsLifecycleRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStudentsLifecycle,
+		arg.LapsedSince,
+		arg.AtRiskSince,
+		arg.RecentSince,
+		arg.BaselineSince,
+		arg.AsOf,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStudentsLifecycleRow{}
+	for rows.Next() {
+		var i GetStudentsLifecycleRow
+		if err := rows.Scan(
+			&i.StudentID,
+			&i.FirstName,
+			&i.LastName,
+			&i.LifecycleStatus,
+			&i.LastLessonDatetime,
+			&i.RecentLessonsCount,
+			&i.BaselineLessonsCount,
+			&i.ComputedStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listStudents = `-- name: ListStudents :many
-SELECT student_id, first_name, last_name, email, phone_number, address, college_id, funnel_id, hourly_fee, notes, created_at FROM students
-ORDER BY last_name, first_name
-LIMIT $1
-OFFSET $2
+SELECT student_id, first_name, last_name, email, phone_number, address, college_id, funnel_id, hourly_fee, notes, created_at, lifecycle_status FROM students s
+WHERE ($1::varchar IS NULL OR s.lifecycle_status = $1)
+  AND ($2::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM student_tags st WHERE st.student_id = s.student_id AND st.tag_id = $2))
+  AND ($3::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM student_custom_values v
+    WHERE v.student_id = s.student_id AND v.field_id = $3 AND v.value = $4::varchar))
+ORDER BY s.last_name, s.first_name
+LIMIT $6
+OFFSET $5
 `
 
 type ListStudentsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	LifecycleStatus sql.NullString `json:"lifecycle_status"`
+	TagID           sql.NullInt64  `json:"tag_id"`
+	FieldID         sql.NullInt64  `json:"field_id"`
+	FieldValue      sql.NullString `json:"field_value"`
+	Offset          int32          `json:"offset"`
+	Limit           int32          `json:"limit"`
 }
 
+// ListStudents lists the students by name, optionally with a single lifecycle status, with a tag,
+// or with a value of a custom field.
 func (q *Queries) ListStudents(ctx context.Context, arg ListStudentsParams) ([]Student, error) {
-	rows, err := q.db.QueryContext(ctx, listStudents, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listStudents,
+		arg.LifecycleStatus,
+		arg.TagID,
+		arg.FieldID,
+		arg.FieldValue,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +298,7 @@ func (q *Queries) ListStudents(ctx context.Context, arg ListStudentsParams) ([]S
 			&i.HourlyFee,
 			&i.Notes,
 			&i.CreatedAt,
+			&i.LifecycleStatus,
 		); err != nil {
 			return nil, err
 		}
@@ -183,3 +355,19 @@ func (q *Queries) UpdateStudent(ctx context.Context, arg UpdateStudentParams) er
 	)
 	return err
 }
+
+const updateStudentLifecycleStatus = `-- name: UpdateStudentLifecycleStatus :exec
+UPDATE students
+  set   lifecycle_status = $2
+WHERE student_id = $1
+`
+
+type UpdateStudentLifecycleStatusParams struct {
+	StudentID       int64  `json:"student_id"`
+	LifecycleStatus string `json:"lifecycle_status"`
+}
+
+func (q *Queries) UpdateStudentLifecycleStatus(ctx context.Context, arg UpdateStudentLifecycleStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateStudentLifecycleStatus, arg.StudentID, arg.LifecycleStatus)
+	return err
+}
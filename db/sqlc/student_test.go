@@ -45,6 +45,7 @@ func createRandomStudent(t *testing.T) Student {
 	require.Equal(t, arg.FunnelID, student.FunnelID)
 	require.Equal(t, arg.HourlyFee, student.HourlyFee)
 	require.Equal(t, arg.Notes, student.Notes)
+	require.Equal(t, LifecycleStatusActive, student.LifecycleStatus)
 
 	require.NotZero(t, student.StudentID)
 	require.NotZero(t, student.CreatedAt)
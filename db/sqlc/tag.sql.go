@@ -0,0 +1,211 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: tag.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+const addStudentTag = `-- name: AddStudentTag :exec
+INSERT INTO student_tags (
+  student_id, tag_id
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (student_id, tag_id) DO NOTHING
+`
+
+type AddStudentTagParams struct {
+	StudentID int64 `json:"student_id"`
+	TagID     int64 `json:"tag_id"`
+}
+
+// AddStudentTag tags a student. Tagging a student with one of its tags has no effect.
+func (q *Queries) AddStudentTag(ctx context.Context, arg AddStudentTagParams) error {
+	_, err := q.db.ExecContext(ctx, addStudentTag, arg.StudentID, arg.TagID)
+	return err
+}
+
+const createTag = `-- name: CreateTag :one
+INSERT INTO tags (
+  name
+) VALUES (
+  $1
+)
+RETURNING tag_id, name, created_at
+`
+
+func (q *Queries) CreateTag(ctx context.Context, name string) (Tag, error) {
+	row := q.db.QueryRowContext(ctx, createTag, name)
+	var i Tag
+	err := row.Scan(&i.TagID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const deleteTag = `-- name: DeleteTag :execrows
+DELETE FROM tags
+WHERE tag_id = $1
+`
+
+func (q *Queries) DeleteTag(ctx context.Context, tagID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteTag, tagID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getStudentTags = `-- name: GetStudentTags :many
+SELECT t.tag_id, t.name, t.created_at
+FROM student_tags st
+JOIN tags t ON t.tag_id = st.tag_id
+WHERE st.student_id = $1
+ORDER BY t.name
+`
+
+func (q *Queries) GetStudentTags(ctx context.Context, studentID int64) ([]Tag, error) {
+	rows, err := q.db.QueryContext(ctx, getStudentTags, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tag{}
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(&i.TagID, &i.Name, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStudentsTags = `-- name: GetStudentsTags :many
+SELECT st.student_id, t.tag_id, t.name
+FROM student_tags st
+JOIN tags t ON t.tag_id = st.tag_id
+WHERE st.student_id = ANY($1::bigint[])
+ORDER BY st.student_id, t.name
+`
+
+type GetStudentsTagsRow struct {
+	StudentID int64  `json:"student_id"`
+	TagID     int64  `json:"tag_id"`
+	Name      string `json:"name"`
+}
+
+// GetStudentsTags returns the tags of several students, by student and tag name.
+func (q *Queries) GetStudentsTags(ctx context.Context, studentIds []int64) ([]GetStudentsTagsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStudentsTags, pq.Array(studentIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStudentsTagsRow{}
+	for rows.Next() {
+		var i GetStudentsTagsRow
+		if err := rows.Scan(&i.StudentID, &i.TagID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTag = `-- name: GetTag :one
+SELECT tag_id, name, created_at FROM tags
+WHERE tag_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetTag(ctx context.Context, tagID int64) (Tag, error) {
+	row := q.db.QueryRowContext(ctx, getTag, tagID)
+	var i Tag
+	err := row.Scan(&i.TagID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const listTags = `-- name: ListTags :many
+SELECT tag_id, name, created_at FROM tags
+ORDER BY name
+LIMIT $1
+OFFSET $2
+`
+
+type ListTagsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListTags(ctx context.Context, arg ListTagsParams) ([]Tag, error) {
+	rows, err := q.db.QueryContext(ctx, listTags, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tag{}
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(&i.TagID, &i.Name, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeStudentTag = `-- name: RemoveStudentTag :execrows
+DELETE FROM student_tags
+WHERE student_id = $1 AND tag_id = $2
+`
+
+type RemoveStudentTagParams struct {
+	StudentID int64 `json:"student_id"`
+	TagID     int64 `json:"tag_id"`
+}
+
+func (q *Queries) RemoveStudentTag(ctx context.Context, arg RemoveStudentTagParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeStudentTag, arg.StudentID, arg.TagID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateTag = `-- name: UpdateTag :exec
+UPDATE tags
+  set   name = $2
+WHERE tag_id = $1
+`
+
+type UpdateTagParams struct {
+	TagID int64  `json:"tag_id"`
+	Name  string `json:"name"`
+}
+
+func (q *Queries) UpdateTag(ctx context.Context, arg UpdateTagParams) error {
+	_, err := q.db.ExecContext(ctx, updateTag, arg.TagID, arg.Name)
+	return err
+}
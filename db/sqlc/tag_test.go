@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomTag adds a new tag with a random unique name.
+func createRandomTag(t *testing.T) Tag {
+	name := util.RandomName() + " " + util.RandomString(8)
+
+	tag, err := testQueries.CreateTag(context.Background(), name)
+	require.NoError(t, err)
+	require.NotZero(t, tag.TagID)
+	require.Equal(t, name, tag.Name)
+	require.NotZero(t, tag.CreatedAt)
+
+	return tag
+}
+
+func TestTags(t *testing.T) {
+	tag := createRandomTag(t)
+
+	arg := UpdateTagParams{
+		TagID: tag.TagID,
+		Name:  util.RandomName() + " " + util.RandomString(8),
+	}
+	err := testQueries.UpdateTag(context.Background(), arg)
+	require.NoError(t, err)
+
+	updated, err := testQueries.GetTag(context.Background(), tag.TagID)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, updated.Name)
+
+	tags, err := testQueries.ListTags(context.Background(), ListTagsParams{Limit: 5, Offset: 0})
+	require.NoError(t, err)
+	require.NotEmpty(t, tags)
+
+	rows, err := testQueries.DeleteTag(context.Background(), tag.TagID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = testQueries.GetTag(context.Background(), tag.TagID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestStudentTags(t *testing.T) {
+	student := createRandomStudent(t)
+	first, second := createRandomTag(t), createRandomTag(t)
+
+	for _, tag := range []Tag{first, second, first} {
+		// tagging a student twice has no effect
+		err := testQueries.AddStudentTag(context.Background(), AddStudentTagParams{
+			StudentID: student.StudentID,
+			TagID:     tag.TagID,
+		})
+		require.NoError(t, err)
+	}
+
+	tags, err := testQueries.GetStudentTags(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+
+	other := createRandomStudent(t)
+	err = testQueries.AddStudentTag(context.Background(), AddStudentTagParams{StudentID: other.StudentID, TagID: second.TagID})
+	require.NoError(t, err)
+
+	rows, err := testQueries.GetStudentsTags(context.Background(), []int64{student.StudentID, other.StudentID})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, student.StudentID, rows[0].StudentID)
+	require.Equal(t, other.StudentID, rows[2].StudentID)
+	require.Equal(t, second.Name, rows[2].Name)
+
+	// students are searched by tag
+	students, err := testQueries.ListStudents(context.Background(), ListStudentsParams{
+		TagID:  sql.NullInt64{Int64: first.TagID, Valid: true},
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, students, 1)
+	require.Equal(t, student.StudentID, students[0].StudentID)
+
+	removed, err := testQueries.RemoveStudentTag(context.Background(), RemoveStudentTagParams{
+		StudentID: student.StudentID,
+		TagID:     first.TagID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), removed)
+
+	// deleting a tag removes it from the students
+	_, err = testQueries.DeleteTag(context.Background(), second.TagID)
+	require.NoError(t, err)
+
+	tags, err = testQueries.GetStudentTags(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+}
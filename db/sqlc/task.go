@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Task priorities.
+const (
+	TaskPriorityLow    = "low"
+	TaskPriorityNormal = "normal"
+	TaskPriorityHigh   = "high"
+)
+
+// Task statuses.
+const (
+	TaskStatusOpen       = "open"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusDone       = "done"
+	TaskStatusCancelled  = "cancelled"
+)
+
+// Task recurrences.
+const (
+	TaskRecurrenceNone    = "none"
+	TaskRecurrenceDaily   = "daily"
+	TaskRecurrenceWeekly  = "weekly"
+	TaskRecurrenceMonthly = "monthly"
+)
+
+// taskDigestLimit is the maximum number of tasks in a task digest email.
+const taskDigestLimit = 100
+
+// NextTaskDueAt returns the due date of the task following a recurring task due at dueAt.
+// It returns dueAt if recurrence is none.
+func NextTaskDueAt(dueAt time.Time, recurrence string) time.Time {
+	switch recurrence {
+	case TaskRecurrenceDaily:
+		return dueAt.AddDate(0, 0, 1)
+	case TaskRecurrenceWeekly:
+		return dueAt.AddDate(0, 0, 7)
+	case TaskRecurrenceMonthly:
+		return dueAt.AddDate(0, 1, 0)
+	default:
+		return dueAt
+	}
+}
+
+// UpdatedTask is an updated task, and the next task of a recurring task that is done.
+type UpdatedTask struct {
+	Task     Task  `json:"task"`
+	NextTask *Task `json:"next_task,omitempty"`
+}
+
+// UpdateTaskTx updates a task. When a recurring task is done, the next task is created with the same details,
+// due a day, a week or a month after the task. Updating a task that was already done creates no next task.
+// It returns sql.ErrNoRows if the task doesn't exist.
+func (store *SQLStore) UpdateTaskTx(ctx context.Context, arg UpdateTaskParams) (UpdatedTask, error) {
+	var result UpdatedTask
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		task, err := q.GetTaskForUpdate(ctx, arg.TaskID)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateTask(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		result.Task, err = q.GetTask(ctx, arg.TaskID)
+		if err != nil {
+			return err
+		}
+
+		if task.Status == TaskStatusDone || arg.Status != TaskStatusDone || arg.Recurrence == TaskRecurrenceNone {
+			return nil
+		}
+
+		next, err := q.CreateTask(ctx, CreateTaskParams{
+			LeadID:     arg.LeadID,
+			StudentID:  arg.StudentID,
+			LessonID:   arg.LessonID,
+			InvoiceID:  arg.InvoiceID,
+			Title:      arg.Title,
+			DueAt:      NextTaskDueAt(arg.DueAt, arg.Recurrence),
+			Priority:   arg.Priority,
+			Recurrence: arg.Recurrence,
+			Notes:      arg.Notes,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.NextTask = &next
+		return nil
+	})
+
+	return result, err
+}
+
+// TaskDigest contains the open and in progress tasks that are overdue or due later today.
+type TaskDigest struct {
+	Date     time.Time         `json:"date"`
+	Overdue  []ListDueTasksRow `json:"overdue"`
+	DueToday []ListDueTasksRow `json:"due_today"`
+}
+
+// EnqueueTaskDigestTx adds the task digest email of the day of now (UTC) to the outbox, to be sent to recipient.
+// No email is added if no tasks are overdue or due today. It returns the digest of the email.
+func (store *SQLStore) EnqueueTaskDigestTx(ctx context.Context, recipient string, now time.Time) (TaskDigest, error) {
+	now = now.UTC()
+	digest := TaskDigest{Date: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		tasks, err := q.ListDueTasks(ctx, ListDueTasksParams{
+			DueBefore: digest.Date.AddDate(0, 0, 1),
+			Limit:     taskDigestLimit,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(tasks) == 0 {
+			return nil
+		}
+
+		for _, task := range tasks {
+			if task.DueAt.Before(now) {
+				digest.Overdue = append(digest.Overdue, task)
+			} else {
+				digest.DueToday = append(digest.DueToday, task)
+			}
+		}
+
+		data, err := json.Marshal(EmailPayload{TaskDigest: &digest})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateOutboxEmail(ctx, CreateOutboxEmailParams{
+			Template:  EmailTemplateTaskDigest,
+			Recipient: recipient,
+			Payload:   data,
+			SendAt:    now,
+		})
+		return err
+	})
+
+	return digest, err
+}
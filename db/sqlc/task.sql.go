@@ -0,0 +1,329 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: task.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createTask = `-- name: CreateTask :one
+INSERT INTO tasks (
+  lead_id, student_id, lesson_id, invoice_id, title, due_at, priority, recurrence, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING task_id, lead_id, title, due_at, completed_at, notes, created_at, student_id, lesson_id, invoice_id, priority, status, recurrence
+`
+
+type CreateTaskParams struct {
+	LeadID     sql.NullInt64  `json:"lead_id"`
+	StudentID  sql.NullInt64  `json:"student_id"`
+	LessonID   sql.NullInt64  `json:"lesson_id"`
+	InvoiceID  sql.NullInt64  `json:"invoice_id"`
+	Title      string         `json:"title"`
+	DueAt      time.Time      `json:"due_at"`
+	Priority   string         `json:"priority"`
+	Recurrence string         `json:"recurrence"`
+	Notes      sql.NullString `json:"notes"`
+}
+
+func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error) {
+	row := q.db.QueryRowContext(ctx, createTask,
+		arg.LeadID,
+		arg.StudentID,
+		arg.LessonID,
+		arg.InvoiceID,
+		arg.Title,
+		arg.DueAt,
+		arg.Priority,
+		arg.Recurrence,
+		arg.Notes,
+	)
+	var i Task
+	err := row.Scan(
+		&i.TaskID,
+		&i.LeadID,
+		&i.Title,
+		&i.DueAt,
+		&i.CompletedAt,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.StudentID,
+		&i.LessonID,
+		&i.InvoiceID,
+		&i.Priority,
+		&i.Status,
+		&i.Recurrence,
+	)
+	return i, err
+}
+
+const deleteTask = `-- name: DeleteTask :execrows
+DELETE FROM tasks
+WHERE task_id = $1
+`
+
+func (q *Queries) DeleteTask(ctx context.Context, taskID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteTask, taskID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getTask = `-- name: GetTask :one
+SELECT task_id, lead_id, title, due_at, completed_at, notes, created_at, student_id, lesson_id, invoice_id, priority, status, recurrence FROM tasks
+WHERE task_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetTask(ctx context.Context, taskID int64) (Task, error) {
+	row := q.db.QueryRowContext(ctx, getTask, taskID)
+	var i Task
+	err := row.Scan(
+		&i.TaskID,
+		&i.LeadID,
+		&i.Title,
+		&i.DueAt,
+		&i.CompletedAt,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.StudentID,
+		&i.LessonID,
+		&i.InvoiceID,
+		&i.Priority,
+		&i.Status,
+		&i.Recurrence,
+	)
+	return i, err
+}
+
+const getTaskForUpdate = `-- name: GetTaskForUpdate :one
+SELECT task_id, lead_id, title, due_at, completed_at, notes, created_at, student_id, lesson_id, invoice_id, priority, status, recurrence FROM tasks
+WHERE task_id = $1 LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetTaskForUpdate(ctx context.Context, taskID int64) (Task, error) {
+	row := q.db.QueryRowContext(ctx, getTaskForUpdate, taskID)
+	var i Task
+	err := row.Scan(
+		&i.TaskID,
+		&i.LeadID,
+		&i.Title,
+		&i.DueAt,
+		&i.CompletedAt,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.StudentID,
+		&i.LessonID,
+		&i.InvoiceID,
+		&i.Priority,
+		&i.Status,
+		&i.Recurrence,
+	)
+	return i, err
+}
+
+const listDueTasks = `-- name: ListDueTasks :many
+SELECT t.task_id,
+       t.title,
+       t.due_at,
+       t.priority,
+       t.status,
+       t.lead_id,
+       t.student_id,
+       t.lesson_id,
+       t.invoice_id,
+       s.first_name,
+       s.last_name
+FROM tasks t
+LEFT JOIN students s ON s.student_id = t.student_id
+WHERE t.status IN ('open', 'in_progress')
+  AND t.due_at < $1
+ORDER BY t.due_at, array_position(ARRAY['high', 'normal', 'low']::varchar[], t.priority), t.task_id
+LIMIT $2
+`
+
+type ListDueTasksParams struct {
+	DueBefore time.Time `json:"due_before"`
+	Limit     int32     `json:"limit"`
+}
+
+type ListDueTasksRow struct {
+	TaskID    int64          `json:"task_id"`
+	Title     string         `json:"title"`
+	DueAt     time.Time      `json:"due_at"`
+	Priority  string         `json:"priority"`
+	Status    string         `json:"status"`
+	LeadID    sql.NullInt64  `json:"lead_id"`
+	StudentID sql.NullInt64  `json:"student_id"`
+	LessonID  sql.NullInt64  `json:"lesson_id"`
+	InvoiceID sql.NullInt64  `json:"invoice_id"`
+	FirstName sql.NullString `json:"first_name"`
+	LastName  sql.NullString `json:"last_name"`
+}
+
+// ListDueTasks lists the open and in progress tasks due before a time, by due date and highest priority first,
+// with the name of their student.
+func (q *Queries) ListDueTasks(ctx context.Context, arg ListDueTasksParams) ([]ListDueTasksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listDueTasks, arg.DueBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDueTasksRow{}
+	for rows.Next() {
+		var i ListDueTasksRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.Title,
+			&i.DueAt,
+			&i.Priority,
+			&i.Status,
+			&i.LeadID,
+			&i.StudentID,
+			&i.LessonID,
+			&i.InvoiceID,
+			&i.FirstName,
+			&i.LastName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTasks = `-- name: ListTasks :many
+SELECT task_id, lead_id, title, due_at, completed_at, notes, created_at, student_id, lesson_id, invoice_id, priority, status, recurrence FROM tasks
+WHERE ($1::bigint IS NULL OR lead_id = $1)
+  AND ($2::bigint IS NULL OR student_id = $2)
+  AND ($3::bigint IS NULL OR lesson_id = $3)
+  AND ($4::bigint IS NULL OR invoice_id = $4)
+  AND ($5::varchar IS NULL OR status = $5)
+  AND ($6::varchar IS NULL OR priority = $6)
+  AND ($7::timestamptz IS NULL OR (status IN ('open', 'in_progress') AND due_at < $7))
+ORDER BY due_at, task_id
+LIMIT $9
+OFFSET $8
+`
+
+type ListTasksParams struct {
+	LeadID    sql.NullInt64  `json:"lead_id"`
+	StudentID sql.NullInt64  `json:"student_id"`
+	LessonID  sql.NullInt64  `json:"lesson_id"`
+	InvoiceID sql.NullInt64  `json:"invoice_id"`
+	Status    sql.NullString `json:"status"`
+	Priority  sql.NullString `json:"priority"`
+	OverdueAt sql.NullTime   `json:"overdue_at"`
+	Offset    int32          `json:"offset"`
+	Limit     int32          `json:"limit"`
+}
+
+// ListTasks lists the tasks by due date, optionally of a single lead, student, lesson or invoice,
+// with a single status or priority, or only the overdue open and in progress tasks.
+func (q *Queries) ListTasks(ctx context.Context, arg ListTasksParams) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, listTasks,
+		arg.LeadID,
+		arg.StudentID,
+		arg.LessonID,
+		arg.InvoiceID,
+		arg.Status,
+		arg.Priority,
+		arg.OverdueAt,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.LeadID,
+			&i.Title,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.StudentID,
+			&i.LessonID,
+			&i.InvoiceID,
+			&i.Priority,
+			&i.Status,
+			&i.Recurrence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTask = `-- name: UpdateTask :exec
+UPDATE tasks
+  set   lead_id = $2,
+        student_id = $3,
+        lesson_id = $4,
+        invoice_id = $5,
+        title = $6,
+        due_at = $7,
+        priority = $8,
+        recurrence = $9,
+        notes = $10,
+        status = $11,
+        completed_at = CASE WHEN $11 = 'done' THEN COALESCE(completed_at, now()) END
+WHERE task_id = $1
+`
+
+type UpdateTaskParams struct {
+	TaskID     int64          `json:"task_id"`
+	LeadID     sql.NullInt64  `json:"lead_id"`
+	StudentID  sql.NullInt64  `json:"student_id"`
+	LessonID   sql.NullInt64  `json:"lesson_id"`
+	InvoiceID  sql.NullInt64  `json:"invoice_id"`
+	Title      string         `json:"title"`
+	DueAt      time.Time      `json:"due_at"`
+	Priority   string         `json:"priority"`
+	Recurrence string         `json:"recurrence"`
+	Notes      sql.NullString `json:"notes"`
+	Status     string         `json:"status"`
+}
+
+// UpdateTask updates a task. A task being done is completed now, and a done task keeps its completion time.
+func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) error {
+	_, err := q.db.ExecContext(ctx, updateTask,
+		arg.TaskID,
+		arg.LeadID,
+		arg.StudentID,
+		arg.LessonID,
+		arg.InvoiceID,
+		arg.Title,
+		arg.DueAt,
+		arg.Priority,
+		arg.Recurrence,
+		arg.Notes,
+		arg.Status,
+	)
+	return err
+}
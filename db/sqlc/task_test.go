@@ -0,0 +1,267 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomTask adds a new random open task of the lead, due at dueAt.
+func createRandomTask(t *testing.T, lead Lead, dueAt time.Time) Task {
+	return createRandomTaskWithParams(t, CreateTaskParams{
+		LeadID:     sql.NullInt64{Int64: lead.LeadID, Valid: true},
+		Title:      util.RandomNote(),
+		DueAt:      dueAt,
+		Priority:   TaskPriorityNormal,
+		Recurrence: TaskRecurrenceNone,
+		Notes:      sql.NullString{String: util.RandomNote(), Valid: true},
+	})
+}
+
+// createRandomTaskWithParams adds a new open task with arg.
+func createRandomTaskWithParams(t *testing.T, arg CreateTaskParams) Task {
+
+	task, err := testQueries.CreateTask(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, task.TaskID)
+	require.Equal(t, arg.LeadID, task.LeadID)
+	require.Equal(t, arg.StudentID, task.StudentID)
+	require.Equal(t, arg.LessonID, task.LessonID)
+	require.Equal(t, arg.InvoiceID, task.InvoiceID)
+	require.Equal(t, arg.Title, task.Title)
+	require.WithinDuration(t, arg.DueAt, task.DueAt, time.Second)
+	require.Equal(t, arg.Priority, task.Priority)
+	require.Equal(t, arg.Recurrence, task.Recurrence)
+	require.Equal(t, arg.Notes, task.Notes)
+	require.Equal(t, TaskStatusOpen, task.Status)
+	require.False(t, task.CompletedAt.Valid)
+
+	return task
+}
+
+func TestTasks(t *testing.T) {
+	lead := createRandomLead(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	later := createRandomTask(t, lead, now.AddDate(0, 0, 2))
+	sooner := createRandomTask(t, lead, now.AddDate(0, 0, 1))
+
+	arg := UpdateTaskParams{
+		TaskID:     later.TaskID,
+		LeadID:     later.LeadID,
+		Title:      later.Title,
+		DueAt:      later.DueAt,
+		Priority:   TaskPriorityHigh,
+		Recurrence: later.Recurrence,
+		Notes:      later.Notes,
+		Status:     TaskStatusDone,
+	}
+	err := testQueries.UpdateTask(context.Background(), arg)
+	require.NoError(t, err)
+
+	completed, err := testQueries.GetTask(context.Background(), later.TaskID)
+	require.NoError(t, err)
+	require.True(t, completed.CompletedAt.Valid)
+	require.Equal(t, TaskPriorityHigh, completed.Priority)
+
+	// completing a completed task keeps its completion time
+	err = testQueries.UpdateTask(context.Background(), arg)
+	require.NoError(t, err)
+
+	task, err := testQueries.GetTask(context.Background(), later.TaskID)
+	require.NoError(t, err)
+	require.Equal(t, completed.CompletedAt, task.CompletedAt)
+
+	// the open tasks of the lead are listed by due date
+	tasks, err := testQueries.ListTasks(context.Background(), ListTasksParams{
+		LeadID: sql.NullInt64{Int64: lead.LeadID, Valid: true},
+		Status: sql.NullString{String: TaskStatusOpen, Valid: true},
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Equal(t, sooner.TaskID, tasks[0].TaskID)
+
+	tasks, err = testQueries.ListTasks(context.Background(), ListTasksParams{
+		LeadID: sql.NullInt64{Int64: lead.LeadID, Valid: true},
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	require.Equal(t, sooner.TaskID, tasks[0].TaskID)
+
+	// reopening a task clears its completion time
+	arg.Status = TaskStatusInProgress
+	err = testQueries.UpdateTask(context.Background(), arg)
+	require.NoError(t, err)
+
+	task, err = testQueries.GetTask(context.Background(), later.TaskID)
+	require.NoError(t, err)
+	require.False(t, task.CompletedAt.Valid)
+	require.Equal(t, TaskStatusInProgress, task.Status)
+
+	rows, err := testQueries.DeleteTask(context.Background(), sooner.TaskID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	// the tasks of a deleted lead are deleted with it
+	_, err = testQueries.DeleteLead(context.Background(), lead.LeadID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetTask(context.Background(), later.TaskID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListTasksOverdue(t *testing.T) {
+	student := createRandomStudent(t)
+	studentID := sql.NullInt64{Int64: student.StudentID, Valid: true}
+	now := time.Now().UTC().Truncate(time.Second)
+
+	arg := CreateTaskParams{
+		StudentID:  studentID,
+		Title:      util.RandomNote(),
+		DueAt:      now.Add(-time.Hour),
+		Priority:   TaskPriorityNormal,
+		Recurrence: TaskRecurrenceNone,
+	}
+	overdue := createRandomTaskWithParams(t, arg)
+
+	arg.DueAt = now.Add(time.Hour)
+	createRandomTaskWithParams(t, arg)
+
+	tasks, err := testQueries.ListTasks(context.Background(), ListTasksParams{
+		StudentID: studentID,
+		OverdueAt: sql.NullTime{Time: now, Valid: true},
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Equal(t, overdue.TaskID, tasks[0].TaskID)
+
+	// the tasks of a deleted student are deleted with it
+	err = testQueries.DeleteStudent(context.Background(), student.StudentID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetTask(context.Background(), overdue.TaskID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUpdateTaskTx(t *testing.T) {
+	store := NewStore(testDB)
+	student := createRandomStudent(t)
+	dueAt := time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC)
+
+	task := createRandomTaskWithParams(t, CreateTaskParams{
+		StudentID:  sql.NullInt64{Int64: student.StudentID, Valid: true},
+		Title:      util.RandomNote(),
+		DueAt:      dueAt,
+		Priority:   TaskPriorityHigh,
+		Recurrence: TaskRecurrenceWeekly,
+	})
+
+	arg := UpdateTaskParams{
+		TaskID:     task.TaskID,
+		StudentID:  task.StudentID,
+		Title:      task.Title,
+		DueAt:      task.DueAt,
+		Priority:   task.Priority,
+		Recurrence: task.Recurrence,
+		Notes:      task.Notes,
+		Status:     TaskStatusDone,
+	}
+
+	result, err := store.UpdateTaskTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, TaskStatusDone, result.Task.Status)
+	require.True(t, result.Task.CompletedAt.Valid)
+
+	// the next task of the recurring task is due a week later
+	require.NotNil(t, result.NextTask)
+	require.Equal(t, TaskStatusOpen, result.NextTask.Status)
+	require.Equal(t, task.StudentID, result.NextTask.StudentID)
+	require.Equal(t, task.Title, result.NextTask.Title)
+	require.Equal(t, TaskPriorityHigh, result.NextTask.Priority)
+	require.Equal(t, TaskRecurrenceWeekly, result.NextTask.Recurrence)
+	require.True(t, dueAt.AddDate(0, 0, 7).Equal(result.NextTask.DueAt))
+
+	// updating a done task creates no next task
+	result, err = store.UpdateTaskTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Nil(t, result.NextTask)
+
+	_, err = store.UpdateTaskTx(context.Background(), UpdateTaskParams{TaskID: -1, Status: TaskStatusDone})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestNextTaskDueAt(t *testing.T) {
+	dueAt := time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC)
+
+	require.Equal(t, dueAt, NextTaskDueAt(dueAt, TaskRecurrenceNone))
+	require.Equal(t, time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC), NextTaskDueAt(dueAt, TaskRecurrenceDaily))
+	require.Equal(t, time.Date(2024, time.March, 18, 9, 0, 0, 0, time.UTC), NextTaskDueAt(dueAt, TaskRecurrenceWeekly))
+	require.Equal(t, time.Date(2024, time.April, 11, 9, 0, 0, 0, time.UTC), NextTaskDueAt(dueAt, TaskRecurrenceMonthly))
+}
+
+func TestEnqueueTaskDigestTx(t *testing.T) {
+	store := NewStore(testDB)
+	student := createRandomStudent(t)
+	now := time.Now().UTC().Truncate(24 * time.Hour).Add(12 * time.Hour)
+	recipient := util.RandomEmail()
+
+	arg := CreateTaskParams{
+		StudentID:  sql.NullInt64{Int64: student.StudentID, Valid: true},
+		Title:      util.RandomNote(),
+		DueAt:      now.Add(-time.Hour),
+		Priority:   TaskPriorityHigh,
+		Recurrence: TaskRecurrenceNone,
+	}
+	overdue := createRandomTaskWithParams(t, arg)
+
+	arg.DueAt = now.Add(time.Hour)
+	dueToday := createRandomTaskWithParams(t, arg)
+
+	arg.DueAt = now.Add(24 * time.Hour)
+	tomorrow := createRandomTaskWithParams(t, arg)
+
+	// the tasks are removed, so they don't fill the digests of later test runs
+	t.Cleanup(func() {
+		for _, task := range []Task{overdue, dueToday, tomorrow} {
+			_, err := testQueries.DeleteTask(context.Background(), task.TaskID)
+			require.NoError(t, err)
+		}
+	})
+
+	digest, err := store.EnqueueTaskDigestTx(context.Background(), recipient, now)
+	require.NoError(t, err)
+	require.True(t, now.Truncate(24*time.Hour).Equal(digest.Date))
+	require.Contains(t, taskIDs(digest.Overdue), overdue.TaskID)
+	require.Contains(t, taskIDs(digest.DueToday), dueToday.TaskID)
+	require.NotContains(t, taskIDs(digest.DueToday), tomorrow.TaskID)
+
+	// the digest email is added to the outbox
+	emails, err := testQueries.ListOutboxEmails(context.Background(), ListOutboxEmailsParams{
+		Status: sql.NullString{String: EmailStatusPending, Valid: true},
+		Limit:  1,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, emails, 1)
+	require.Equal(t, EmailTemplateTaskDigest, emails[0].Template)
+	require.Equal(t, recipient, emails[0].Recipient)
+	require.False(t, emails[0].StudentID.Valid)
+}
+
+// taskIDs returns the IDs of the tasks.
+func taskIDs(tasks []ListDueTasksRow) []int64 {
+	ids := make([]int64, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.TaskID)
+	}
+	return ids
+}
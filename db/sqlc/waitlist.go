@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Waitlist entry statuses.
+const (
+	WaitlistStatusWaiting   = "waiting"
+	WaitlistStatusNotified  = "notified"
+	WaitlistStatusWithdrawn = "withdrawn"
+)
+
+// notifyWaitlist notifies the first waitlisted student whose preferences match the time of a deleted lesson
+// that the slot is available, within the transaction deleting the lesson.
+// Entries are matched first-come, and the students of the lesson and lessons in the past are skipped.
+func (q *Queries) notifyWaitlist(ctx context.Context, deleted LessonWithInvoices) error {
+	lesson := deleted.Lesson
+	if !lesson.LessonDatetime.After(time.Now()) {
+		return nil
+	}
+
+	excluded := make([]int64, 0, len(deleted.Attendance))
+	for _, attendance := range deleted.Attendance {
+		excluded = append(excluded, attendance.StudentID)
+	}
+
+	entry, err := q.GetFirstMatchingWaitlistEntry(ctx, GetFirstMatchingWaitlistEntryParams{
+		SubjectID:          lesson.SubjectID,
+		ExcludedStudentIds: excluded,
+		LessonDatetime:     lesson.LessonDatetime,
+		Duration:           int32(lesson.Duration),
+	})
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	entry, err = q.MarkWaitlistEntryNotified(ctx, MarkWaitlistEntryNotifiedParams{
+		WaitlistEntryID: entry.WaitlistEntryID,
+		LessonDatetime:  sql.NullTime{Time: lesson.LessonDatetime, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	student, err := q.GetStudent(ctx, entry.StudentID)
+	if err != nil {
+		return err
+	}
+
+	payload := EmailPayload{
+		Student:       student,
+		WaitlistEntry: &entry,
+		FreedLesson:   &lesson,
+	}
+
+	return q.enqueueStudentEmail(ctx, EmailTemplateWaitlistSlotAvailable, time.Now().UTC(), payload)
+}
@@ -0,0 +1,282 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: waitlist.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const createWaitlistEntry = `-- name: CreateWaitlistEntry :one
+INSERT INTO waitlist_entries (
+  student_id, subject_id, weekday, start_minute, end_minute, timezone, notes
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING waitlist_entry_id, student_id, subject_id, weekday, start_minute, end_minute, timezone, status, notes, created_at, notified_at, notified_lesson_datetime
+`
+
+type CreateWaitlistEntryParams struct {
+	StudentID   int64          `json:"student_id"`
+	SubjectID   int64          `json:"subject_id"`
+	Weekday     sql.NullInt32  `json:"weekday"`
+	StartMinute sql.NullInt32  `json:"start_minute"`
+	EndMinute   sql.NullInt32  `json:"end_minute"`
+	Timezone    string         `json:"timezone"`
+	Notes       sql.NullString `json:"notes"`
+}
+
+func (q *Queries) CreateWaitlistEntry(ctx context.Context, arg CreateWaitlistEntryParams) (WaitlistEntry, error) {
+	row := q.db.QueryRowContext(ctx, createWaitlistEntry,
+		arg.StudentID,
+		arg.SubjectID,
+		arg.Weekday,
+		arg.StartMinute,
+		arg.EndMinute,
+		arg.Timezone,
+		arg.Notes,
+	)
+	var i WaitlistEntry
+	err := row.Scan(
+		&i.WaitlistEntryID,
+		&i.StudentID,
+		&i.SubjectID,
+		&i.Weekday,
+		&i.StartMinute,
+		&i.EndMinute,
+		&i.Timezone,
+		&i.Status,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.NotifiedAt,
+		&i.NotifiedLessonDatetime,
+	)
+	return i, err
+}
+
+const deleteWaitlistEntry = `-- name: DeleteWaitlistEntry :execrows
+DELETE FROM waitlist_entries
+WHERE waitlist_entry_id = $1
+`
+
+func (q *Queries) DeleteWaitlistEntry(ctx context.Context, waitlistEntryID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteWaitlistEntry, waitlistEntryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getFirstMatchingWaitlistEntry = `-- name: GetFirstMatchingWaitlistEntry :one
+SELECT w.waitlist_entry_id, w.student_id, w.subject_id, w.weekday, w.start_minute, w.end_minute, w.timezone, w.status, w.notes, w.created_at, w.notified_at, w.notified_lesson_datetime FROM waitlist_entries w
+WHERE w.status = 'waiting'
+  AND w.subject_id = $1
+  AND w.student_id <> ALL($2::bigint[])
+  AND (w.weekday IS NULL OR w.weekday = EXTRACT(DOW FROM $3::timestamptz AT TIME ZONE w.timezone)::int)
+  AND (w.start_minute IS NULL OR (
+    (EXTRACT(HOUR FROM $3::timestamptz AT TIME ZONE w.timezone) * 60
+      + EXTRACT(MINUTE FROM $3::timestamptz AT TIME ZONE w.timezone))::int >= w.start_minute
+    AND (EXTRACT(HOUR FROM $3::timestamptz AT TIME ZONE w.timezone) * 60
+      + EXTRACT(MINUTE FROM $3::timestamptz AT TIME ZONE w.timezone))::int + $4::int <= w.end_minute
+  ))
+ORDER BY w.created_at, w.waitlist_entry_id
+LIMIT 1
+FOR UPDATE SKIP LOCKED
+`
+
+type GetFirstMatchingWaitlistEntryParams struct {
+	SubjectID          int64     `json:"subject_id"`
+	ExcludedStudentIds []int64   `json:"excluded_student_ids"`
+	LessonDatetime     time.Time `json:"lesson_datetime"`
+	Duration           int32     `json:"duration"`
+}
+
+// GetFirstMatchingWaitlistEntry gets the earliest waiting entry of a subject whose preferred day and times, in the timezone
+// of the entry, include a lesson from lesson_datetime of duration minutes. The students of the excluded_student_ids are skipped.
+func (q *Queries) GetFirstMatchingWaitlistEntry(ctx context.Context, arg GetFirstMatchingWaitlistEntryParams) (WaitlistEntry, error) {
+	row := q.db.QueryRowContext(ctx, getFirstMatchingWaitlistEntry,
+		arg.SubjectID,
+		pq.Array(arg.ExcludedStudentIds),
+		arg.LessonDatetime,
+		arg.Duration,
+	)
+	var i WaitlistEntry
+	err := row.Scan(
+		&i.WaitlistEntryID,
+		&i.StudentID,
+		&i.SubjectID,
+		&i.Weekday,
+		&i.StartMinute,
+		&i.EndMinute,
+		&i.Timezone,
+		&i.Status,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.NotifiedAt,
+		&i.NotifiedLessonDatetime,
+	)
+	return i, err
+}
+
+const getWaitlistEntry = `-- name: GetWaitlistEntry :one
+SELECT waitlist_entry_id, student_id, subject_id, weekday, start_minute, end_minute, timezone, status, notes, created_at, notified_at, notified_lesson_datetime FROM waitlist_entries
+WHERE waitlist_entry_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetWaitlistEntry(ctx context.Context, waitlistEntryID int64) (WaitlistEntry, error) {
+	row := q.db.QueryRowContext(ctx, getWaitlistEntry, waitlistEntryID)
+	var i WaitlistEntry
+	err := row.Scan(
+		&i.WaitlistEntryID,
+		&i.StudentID,
+		&i.SubjectID,
+		&i.Weekday,
+		&i.StartMinute,
+		&i.EndMinute,
+		&i.Timezone,
+		&i.Status,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.NotifiedAt,
+		&i.NotifiedLessonDatetime,
+	)
+	return i, err
+}
+
+const listWaitlistEntries = `-- name: ListWaitlistEntries :many
+SELECT waitlist_entry_id, student_id, subject_id, weekday, start_minute, end_minute, timezone, status, notes, created_at, notified_at, notified_lesson_datetime FROM waitlist_entries
+WHERE ($1::bigint IS NULL OR student_id = $1)
+  AND ($2::bigint IS NULL OR subject_id = $2)
+  AND ($3::varchar IS NULL OR status = $3)
+ORDER BY created_at, waitlist_entry_id
+LIMIT $5
+OFFSET $4
+`
+
+type ListWaitlistEntriesParams struct {
+	StudentID sql.NullInt64  `json:"student_id"`
+	SubjectID sql.NullInt64  `json:"subject_id"`
+	Status    sql.NullString `json:"status"`
+	Offset    int32          `json:"offset"`
+	Limit     int32          `json:"limit"`
+}
+
+// ListWaitlistEntries lists the waitlist entries in first-come order, optionally of a single student or subject, or with a single status.
+func (q *Queries) ListWaitlistEntries(ctx context.Context, arg ListWaitlistEntriesParams) ([]WaitlistEntry, error) {
+	rows, err := q.db.QueryContext(ctx, listWaitlistEntries,
+		arg.StudentID,
+		arg.SubjectID,
+		arg.Status,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WaitlistEntry{}
+	for rows.Next() {
+		var i WaitlistEntry
+		if err := rows.Scan(
+			&i.WaitlistEntryID,
+			&i.StudentID,
+			&i.SubjectID,
+			&i.Weekday,
+			&i.StartMinute,
+			&i.EndMinute,
+			&i.Timezone,
+			&i.Status,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.NotifiedAt,
+			&i.NotifiedLessonDatetime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markWaitlistEntryNotified = `-- name: MarkWaitlistEntryNotified :one
+UPDATE waitlist_entries
+  set   status = 'notified',
+        notified_at = now(),
+        notified_lesson_datetime = $1
+WHERE waitlist_entry_id = $2
+RETURNING waitlist_entry_id, student_id, subject_id, weekday, start_minute, end_minute, timezone, status, notes, created_at, notified_at, notified_lesson_datetime
+`
+
+type MarkWaitlistEntryNotifiedParams struct {
+	LessonDatetime  sql.NullTime `json:"lesson_datetime"`
+	WaitlistEntryID int64        `json:"waitlist_entry_id"`
+}
+
+func (q *Queries) MarkWaitlistEntryNotified(ctx context.Context, arg MarkWaitlistEntryNotifiedParams) (WaitlistEntry, error) {
+	row := q.db.QueryRowContext(ctx, markWaitlistEntryNotified, arg.LessonDatetime, arg.WaitlistEntryID)
+	var i WaitlistEntry
+	err := row.Scan(
+		&i.WaitlistEntryID,
+		&i.StudentID,
+		&i.SubjectID,
+		&i.Weekday,
+		&i.StartMinute,
+		&i.EndMinute,
+		&i.Timezone,
+		&i.Status,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.NotifiedAt,
+		&i.NotifiedLessonDatetime,
+	)
+	return i, err
+}
+
+const updateWaitlistEntry = `-- name: UpdateWaitlistEntry :exec
+UPDATE waitlist_entries
+  set   subject_id = $2,
+        weekday = $3,
+        start_minute = $4,
+        end_minute = $5,
+        timezone = $6,
+        status = $7,
+        notes = $8
+WHERE waitlist_entry_id = $1
+`
+
+type UpdateWaitlistEntryParams struct {
+	WaitlistEntryID int64          `json:"waitlist_entry_id"`
+	SubjectID       int64          `json:"subject_id"`
+	Weekday         sql.NullInt32  `json:"weekday"`
+	StartMinute     sql.NullInt32  `json:"start_minute"`
+	EndMinute       sql.NullInt32  `json:"end_minute"`
+	Timezone        string         `json:"timezone"`
+	Status          string         `json:"status"`
+	Notes           sql.NullString `json:"notes"`
+}
+
+func (q *Queries) UpdateWaitlistEntry(ctx context.Context, arg UpdateWaitlistEntryParams) error {
+	_, err := q.db.ExecContext(ctx, updateWaitlistEntry,
+		arg.WaitlistEntryID,
+		arg.SubjectID,
+		arg.Weekday,
+		arg.StartMinute,
+		arg.EndMinute,
+		arg.Timezone,
+		arg.Status,
+		arg.Notes,
+	)
+	return err
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomWaitlistEntry adds a new waiting entry of the student to the waitlist of the subject, with the preferences in UTC.
+func createRandomWaitlistEntry(t *testing.T, student Student, subjectID int64, weekday, startMinute, endMinute sql.NullInt32) WaitlistEntry {
+	arg := CreateWaitlistEntryParams{
+		StudentID:   student.StudentID,
+		SubjectID:   subjectID,
+		Weekday:     weekday,
+		StartMinute: startMinute,
+		EndMinute:   endMinute,
+		Timezone:    "UTC",
+		Notes:       sql.NullString{String: util.RandomNote(), Valid: true},
+	}
+
+	entry, err := testQueries.CreateWaitlistEntry(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, entry.WaitlistEntryID)
+	require.Equal(t, arg.StudentID, entry.StudentID)
+	require.Equal(t, arg.SubjectID, entry.SubjectID)
+	require.Equal(t, arg.Weekday, entry.Weekday)
+	require.Equal(t, arg.StartMinute, entry.StartMinute)
+	require.Equal(t, arg.EndMinute, entry.EndMinute)
+	require.Equal(t, arg.Timezone, entry.Timezone)
+	require.Equal(t, arg.Notes, entry.Notes)
+	require.Equal(t, WaitlistStatusWaiting, entry.Status)
+	require.False(t, entry.NotifiedAt.Valid)
+	require.False(t, entry.NotifiedLessonDatetime.Valid)
+
+	return entry
+}
+
+func TestWaitlistEntries(t *testing.T) {
+	student := createRandomStudent(t)
+	subject := createRandomLessonSubject(t)
+	entry := createRandomWaitlistEntry(t, student, subject.SubjectID, sql.NullInt32{}, sql.NullInt32{}, sql.NullInt32{})
+
+	err := testQueries.UpdateWaitlistEntry(context.Background(), UpdateWaitlistEntryParams{
+		WaitlistEntryID: entry.WaitlistEntryID,
+		SubjectID:       entry.SubjectID,
+		Weekday:         sql.NullInt32{Int32: 3, Valid: true},
+		StartMinute:     sql.NullInt32{Int32: 16 * 60, Valid: true},
+		EndMinute:       sql.NullInt32{Int32: 19 * 60, Valid: true},
+		Timezone:        "Asia/Jerusalem",
+		Status:          WaitlistStatusWithdrawn,
+		Notes:           entry.Notes,
+	})
+	require.NoError(t, err)
+
+	updated, err := testQueries.GetWaitlistEntry(context.Background(), entry.WaitlistEntryID)
+	require.NoError(t, err)
+	require.Equal(t, sql.NullInt32{Int32: 3, Valid: true}, updated.Weekday)
+	require.Equal(t, "Asia/Jerusalem", updated.Timezone)
+	require.Equal(t, WaitlistStatusWithdrawn, updated.Status)
+
+	entries, err := testQueries.ListWaitlistEntries(context.Background(), ListWaitlistEntriesParams{
+		StudentID: sql.NullInt64{Int64: student.StudentID, Valid: true},
+		Status:    sql.NullString{String: WaitlistStatusWithdrawn, Valid: true},
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, entry.WaitlistEntryID, entries[0].WaitlistEntryID)
+
+	rows, err := testQueries.DeleteWaitlistEntry(context.Background(), entry.WaitlistEntryID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = testQueries.GetWaitlistEntry(context.Background(), entry.WaitlistEntryID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestDeleteLessonWithInvoicesTxNotifiesWaitlist(t *testing.T) {
+	store := NewStore(testDB)
+	attendee := createRandomStudent(t)
+
+	// the lesson is in the morning, so the preferred times of the entries end before midnight
+	datetime := randomBookingDatetime().Truncate(24 * time.Hour).Add(10 * time.Hour)
+	result, err := createRandomLessonWithInvoiceTx(t, datetime, attendee)
+	require.NoError(t, err)
+
+	lesson := result.Lesson
+	weekday := sql.NullInt32{Int32: int32(lesson.LessonDatetime.UTC().Weekday()), Valid: true}
+	otherWeekday := sql.NullInt32{Int32: (weekday.Int32 + 1) % 7, Valid: true}
+	start := int32(lesson.LessonDatetime.UTC().Hour() * 60)
+	duration := int32(lesson.Duration)
+	none := sql.NullInt32{}
+
+	// the students of the lesson and entries of other days or shorter times are skipped
+	createRandomWaitlistEntry(t, attendee, lesson.SubjectID, none, none, none)
+	createRandomWaitlistEntry(t, createRandomStudent(t), lesson.SubjectID, otherWeekday, none, none)
+	createRandomWaitlistEntry(t, createRandomStudent(t), lesson.SubjectID, weekday,
+		sql.NullInt32{Int32: start, Valid: true}, sql.NullInt32{Int32: start + duration - 1, Valid: true})
+
+	first := createRandomWaitlistEntry(t, createRandomStudent(t), lesson.SubjectID, weekday,
+		sql.NullInt32{Int32: start, Valid: true}, sql.NullInt32{Int32: start + duration, Valid: true})
+	second := createRandomWaitlistEntry(t, createRandomStudent(t), lesson.SubjectID, none, none, none)
+
+	err = store.DeleteLessonWithInvoicesTx(context.Background(), lesson.LessonID)
+	require.NoError(t, err)
+
+	// only the first matching entry is notified
+	notified, err := testQueries.GetWaitlistEntry(context.Background(), first.WaitlistEntryID)
+	require.NoError(t, err)
+	require.Equal(t, WaitlistStatusNotified, notified.Status)
+	require.True(t, notified.NotifiedAt.Valid)
+	require.True(t, lesson.LessonDatetime.Equal(notified.NotifiedLessonDatetime.Time))
+
+	waiting, err := testQueries.GetWaitlistEntry(context.Background(), second.WaitlistEntryID)
+	require.NoError(t, err)
+	require.Equal(t, WaitlistStatusWaiting, waiting.Status)
+
+	emails, err := testQueries.GetOutboxEmailsByStudent(context.Background(), first.StudentID)
+	require.NoError(t, err)
+	require.Len(t, emails, 1)
+	require.Equal(t, EmailTemplateWaitlistSlotAvailable, emails[0].Template)
+	require.False(t, emails[0].LessonID.Valid)
+
+	var payload EmailPayload
+	require.NoError(t, json.Unmarshal(emails[0].Payload, &payload))
+	require.Equal(t, first.StudentID, payload.Student.StudentID)
+	require.Equal(t, first.WaitlistEntryID, payload.WaitlistEntry.WaitlistEntryID)
+	require.Equal(t, lesson.LessonID, payload.FreedLesson.LessonID)
+
+	// lessons in the past notify no one
+	past, err := createRandomLessonWithInvoiceTx(t, time.Now().UTC().AddDate(0, 0, -1))
+	require.NoError(t, err)
+	entry := createRandomWaitlistEntry(t, createRandomStudent(t), past.Lesson.SubjectID, none, none, none)
+
+	err = store.DeleteLessonWithInvoicesTx(context.Background(), past.Lesson.LessonID)
+	require.NoError(t, err)
+
+	entry, err = testQueries.GetWaitlistEntry(context.Background(), entry.WaitlistEntryID)
+	require.NoError(t, err)
+	require.Equal(t, WaitlistStatusWaiting, entry.Status)
+}
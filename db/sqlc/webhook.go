@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// Webhook events posted to the webhook subscriptions.
+const (
+	WebhookEventStudentCreated = "student.created"
+	WebhookEventLessonCreated  = "lesson.created"
+	WebhookEventLessonDeleted  = "lesson.deleted"
+	WebhookEventReceiptCreated = "receipt.created"
+	WebhookEventInvoiceUpdated = "invoice.updated"
+)
+
+// WebhookEvents are all the events a webhook subscription can subscribe to.
+var WebhookEvents = []string{
+	WebhookEventStudentCreated,
+	WebhookEventLessonCreated,
+	WebhookEventLessonDeleted,
+	WebhookEventReceiptCreated,
+	WebhookEventInvoiceUpdated,
+}
+
+// Webhook delivery statuses.
+const (
+	WebhookStatusPending   = "pending"
+	WebhookStatusDelivered = "delivered"
+	WebhookStatusFailed    = "failed"
+)
+
+// WebhookPayload is the JSON body posted to the webhook subscriptions for an event.
+type WebhookPayload struct {
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// enqueueWebhookEvent adds a delivery of an event to every active subscription to the event.
+// It must be called within the transaction that changes the data the event is about,
+// so the event is never posted if the transaction is rolled back.
+func (q *Queries) enqueueWebhookEvent(ctx context.Context, event string, data interface{}) error {
+	payload, err := json.Marshal(WebhookPayload{
+		Event:     event,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	return q.CreateWebhookDeliveries(ctx, CreateWebhookDeliveriesParams{
+		Event:   event,
+		Payload: payload,
+	})
+}
+
+// CreateStudentTx creates a student, and posts the student.created webhook event.
+func (store *SQLStore) CreateStudentTx(ctx context.Context, arg CreateStudentParams) (Student, error) {
+	var result Student
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CreateStudent(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return q.enqueueWebhookEvent(ctx, WebhookEventStudentCreated, result)
+	})
+
+	return result, err
+}
+
+// UpdateInvoiceTx updates an invoice, and posts the invoice.updated webhook event.
+// The fee schedule of the invoice is set to the fee schedule applicable to the student at the lesson, as by
+// GetApplicableFeeSchedule, if the hourly fee of the invoice is the fee of the schedule. Otherwise the hourly fee
+// is overridden and the fee schedule is null. It returns sql.ErrNoRows if the invoice or the lesson doesn't exist.
+func (store *SQLStore) UpdateInvoiceTx(ctx context.Context, arg UpdateInvoiceParams) (Invoice, error) {
+	var result Invoice
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		lesson, err := q.GetLesson(ctx, arg.LessonID)
+		if err != nil {
+			return err
+		}
+
+		arg.FeeScheduleID = sql.NullInt64{}
+		schedule, err := q.GetApplicableFeeSchedule(ctx, GetApplicableFeeScheduleParams{
+			StudentID:      arg.StudentID,
+			SubjectID:      lesson.SubjectID,
+			LessonDatetime: lesson.LessonDatetime,
+		})
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if err == nil && schedule.HourlyFee == arg.HourlyFee {
+			arg.FeeScheduleID = sql.NullInt64{Int64: schedule.FeeScheduleID, Valid: true}
+		}
+
+		err = q.UpdateInvoice(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.GetInvoice(ctx, arg.InvoiceID)
+		if err != nil {
+			return err
+		}
+
+		return q.enqueueWebhookEvent(ctx, WebhookEventInvoiceUpdated, result)
+	})
+
+	return result, err
+}
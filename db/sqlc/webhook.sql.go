@@ -0,0 +1,378 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: webhook.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const claimWebhookDeliveries = `-- name: ClaimWebhookDeliveries :many
+WITH claimed AS (
+  UPDATE webhook_deliveries
+    set   next_attempt_at = $1
+  WHERE delivery_id IN (
+    SELECT d.delivery_id FROM webhook_deliveries d
+    WHERE d.status = 'pending' AND d.next_attempt_at <= $2
+    ORDER BY d.next_attempt_at
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+  )
+  RETURNING delivery_id, subscription_id, event, payload, attempts
+)
+SELECT c.delivery_id, c.subscription_id, c.event, c.payload, c.attempts, s.url, s.secret
+FROM claimed c
+JOIN webhook_subscriptions s ON s.subscription_id = c.subscription_id
+ORDER BY c.delivery_id
+`
+
+type ClaimWebhookDeliveriesParams struct {
+	LeaseUntil time.Time `json:"lease_until"`
+	Now        time.Time `json:"now"`
+	Limit      int32     `json:"limit"`
+}
+
+type ClaimWebhookDeliveriesRow struct {
+	DeliveryID     int64           `json:"delivery_id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Attempts       int32           `json:"attempts"`
+	Url            string          `json:"url"`
+	Secret         string          `json:"secret"`
+}
+
+// ClaimWebhookDeliveries locks pending deliveries that are due, and postpones them by the lease,
+// so other dispatchers don't post them while they are being posted.
+// The url and secret of the subscription of each delivery are returned with it.
+func (q *Queries) ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]ClaimWebhookDeliveriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, claimWebhookDeliveries, arg.LeaseUntil, arg.Now, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ClaimWebhookDeliveriesRow{}
+	for rows.Next() {
+		var i ClaimWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.DeliveryID,
+			&i.SubscriptionID,
+			&i.Event,
+			&i.Payload,
+			&i.Attempts,
+			&i.Url,
+			&i.Secret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createWebhookDeliveries = `-- name: CreateWebhookDeliveries :exec
+INSERT INTO webhook_deliveries (
+  subscription_id, event, payload
+)
+SELECT subscription_id, $1::varchar, $2::jsonb
+FROM webhook_subscriptions
+WHERE active AND $1::varchar = ANY(events)
+`
+
+type CreateWebhookDeliveriesParams struct {
+	Event   string          `json:"event"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// CreateWebhookDeliveries adds a delivery of the event for every active subscription to the event.
+func (q *Queries) CreateWebhookDeliveries(ctx context.Context, arg CreateWebhookDeliveriesParams) error {
+	_, err := q.db.ExecContext(ctx, createWebhookDeliveries, arg.Event, arg.Payload)
+	return err
+}
+
+const createWebhookSubscription = `-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (
+  url, secret, events, active
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING subscription_id, url, secret, events, active, created_at
+`
+
+type CreateWebhookSubscriptionParams struct {
+	Url    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+}
+
+func (q *Queries) CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRowContext(ctx, createWebhookSubscription,
+		arg.Url,
+		arg.Secret,
+		pq.Array(arg.Events),
+		arg.Active,
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.SubscriptionID,
+		&i.Url,
+		&i.Secret,
+		pq.Array(&i.Events),
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWebhookDelivery = `-- name: GetWebhookDelivery :one
+SELECT delivery_id, subscription_id, event, payload, status, attempts, response_status, last_error, next_attempt_at, delivered_at, created_at FROM webhook_deliveries
+WHERE delivery_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetWebhookDelivery(ctx context.Context, deliveryID int64) (WebhookDelivery, error) {
+	row := q.db.QueryRowContext(ctx, getWebhookDelivery, deliveryID)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.DeliveryID,
+		&i.SubscriptionID,
+		&i.Event,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.DeliveredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWebhookSubscription = `-- name: GetWebhookSubscription :one
+SELECT subscription_id, url, secret, events, active, created_at FROM webhook_subscriptions
+WHERE subscription_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetWebhookSubscription(ctx context.Context, subscriptionID int64) (WebhookSubscription, error) {
+	row := q.db.QueryRowContext(ctx, getWebhookSubscription, subscriptionID)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.SubscriptionID,
+		&i.Url,
+		&i.Secret,
+		pq.Array(&i.Events),
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT delivery_id, subscription_id, event, payload, status, attempts, response_status, last_error, next_attempt_at, delivered_at, created_at FROM webhook_deliveries
+WHERE subscription_id = $1
+ORDER BY delivery_id DESC
+LIMIT $2
+OFFSET $3
+`
+
+type ListWebhookDeliveriesParams struct {
+	SubscriptionID int64 `json:"subscription_id"`
+	Limit          int32 `json:"limit"`
+	Offset         int32 `json:"offset"`
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookDeliveries, arg.SubscriptionID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookDelivery{}
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.DeliveryID,
+			&i.SubscriptionID,
+			&i.Event,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookSubscriptions = `-- name: ListWebhookSubscriptions :many
+SELECT subscription_id, url, secret, events, active, created_at FROM webhook_subscriptions
+ORDER BY subscription_id
+LIMIT $1
+OFFSET $2
+`
+
+type ListWebhookSubscriptionsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListWebhookSubscriptions(ctx context.Context, arg ListWebhookSubscriptionsParams) ([]WebhookSubscription, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookSubscriptions, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookSubscription{}
+	for rows.Next() {
+		var i WebhookSubscription
+		if err := rows.Scan(
+			&i.SubscriptionID,
+			&i.Url,
+			&i.Secret,
+			pq.Array(&i.Events),
+			&i.Active,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markWebhookDeliveryDelivered = `-- name: MarkWebhookDeliveryDelivered :exec
+UPDATE webhook_deliveries
+  set   status = 'delivered',
+        attempts = attempts + 1,
+        response_status = $2,
+        last_error = NULL,
+        delivered_at = $3
+WHERE delivery_id = $1
+`
+
+type MarkWebhookDeliveryDeliveredParams struct {
+	DeliveryID     int64         `json:"delivery_id"`
+	ResponseStatus sql.NullInt32 `json:"response_status"`
+	DeliveredAt    sql.NullTime  `json:"delivered_at"`
+}
+
+func (q *Queries) MarkWebhookDeliveryDelivered(ctx context.Context, arg MarkWebhookDeliveryDeliveredParams) error {
+	_, err := q.db.ExecContext(ctx, markWebhookDeliveryDelivered, arg.DeliveryID, arg.ResponseStatus, arg.DeliveredAt)
+	return err
+}
+
+const markWebhookDeliveryFailed = `-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+  set   status = $2,
+        attempts = attempts + 1,
+        response_status = $3,
+        last_error = $4,
+        next_attempt_at = $5
+WHERE delivery_id = $1
+`
+
+type MarkWebhookDeliveryFailedParams struct {
+	DeliveryID     int64          `json:"delivery_id"`
+	Status         string         `json:"status"`
+	ResponseStatus sql.NullInt32  `json:"response_status"`
+	LastError      sql.NullString `json:"last_error"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+}
+
+func (q *Queries) MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error {
+	_, err := q.db.ExecContext(ctx, markWebhookDeliveryFailed,
+		arg.DeliveryID,
+		arg.Status,
+		arg.ResponseStatus,
+		arg.LastError,
+		arg.NextAttemptAt,
+	)
+	return err
+}
+
+const replayWebhookDelivery = `-- name: ReplayWebhookDelivery :one
+INSERT INTO webhook_deliveries (
+  subscription_id, event, payload
+)
+SELECT subscription_id, event, payload
+FROM webhook_deliveries
+WHERE webhook_deliveries.delivery_id = $1
+RETURNING delivery_id, subscription_id, event, payload, status, attempts, response_status, last_error, next_attempt_at, delivered_at, created_at
+`
+
+// ReplayWebhookDelivery adds a new delivery of the same event and payload of a previous delivery.
+func (q *Queries) ReplayWebhookDelivery(ctx context.Context, deliveryID int64) (WebhookDelivery, error) {
+	row := q.db.QueryRowContext(ctx, replayWebhookDelivery, deliveryID)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.DeliveryID,
+		&i.SubscriptionID,
+		&i.Event,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.DeliveredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateWebhookSubscription = `-- name: UpdateWebhookSubscription :exec
+UPDATE webhook_subscriptions
+  set   url = $2,
+        events = $3,
+        active = $4
+WHERE subscription_id = $1
+`
+
+type UpdateWebhookSubscriptionParams struct {
+	SubscriptionID int64    `json:"subscription_id"`
+	Url            string   `json:"url"`
+	Events         []string `json:"events"`
+	Active         bool     `json:"active"`
+}
+
+func (q *Queries) UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) error {
+	_, err := q.db.ExecContext(ctx, updateWebhookSubscription,
+		arg.SubscriptionID,
+		arg.Url,
+		pq.Array(arg.Events),
+		arg.Active,
+	)
+	return err
+}
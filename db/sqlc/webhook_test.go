@@ -0,0 +1,343 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomWebhookSubscription adds a new active webhook subscription to the events.
+func createRandomWebhookSubscription(t *testing.T, events ...string) WebhookSubscription {
+	arg := CreateWebhookSubscriptionParams{
+		Url:    fmt.Sprintf("https://%s.example.com/webhooks", util.RandomString(8)),
+		Secret: util.RandomString(64),
+		Events: events,
+		Active: true,
+	}
+
+	subscription, err := testQueries.CreateWebhookSubscription(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, subscription.SubscriptionID)
+
+	require.Equal(t, arg.Url, subscription.Url)
+	require.Equal(t, arg.Secret, subscription.Secret)
+	require.Equal(t, arg.Events, subscription.Events)
+	require.True(t, subscription.Active)
+	require.NotZero(t, subscription.CreatedAt)
+
+	return subscription
+}
+
+// listSubscriptionDeliveries returns the deliveries of a subscription, newest first.
+func listSubscriptionDeliveries(t *testing.T, subscription WebhookSubscription) []WebhookDelivery {
+	deliveries, err := testQueries.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		SubscriptionID: subscription.SubscriptionID,
+		Limit:          100,
+		Offset:         0,
+	})
+	require.NoError(t, err)
+
+	return deliveries
+}
+
+// requireWebhookPayload asserts that a delivery is a pending delivery of event, and unmarshals its data into data.
+func requireWebhookPayload(t *testing.T, delivery WebhookDelivery, event string, data interface{}) {
+	require.Equal(t, event, delivery.Event)
+	require.Equal(t, WebhookStatusPending, delivery.Status)
+	require.Zero(t, delivery.Attempts)
+
+	var payload struct {
+		Event     string          `json:"event"`
+		CreatedAt time.Time       `json:"created_at"`
+		Data      json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(delivery.Payload, &payload))
+	require.Equal(t, event, payload.Event)
+	require.WithinDuration(t, time.Now(), payload.CreatedAt, time.Minute)
+	require.NoError(t, json.Unmarshal(payload.Data, data))
+}
+
+func TestCreateWebhookSubscription(t *testing.T) {
+	createRandomWebhookSubscription(t, WebhookEventStudentCreated)
+}
+
+func TestUpdateWebhookSubscription(t *testing.T) {
+	subscription1 := createRandomWebhookSubscription(t, WebhookEventStudentCreated)
+
+	arg := UpdateWebhookSubscriptionParams{
+		SubscriptionID: subscription1.SubscriptionID,
+		Url:            fmt.Sprintf("https://%s.example.com/webhooks", util.RandomString(8)),
+		Events:         []string{WebhookEventLessonCreated, WebhookEventLessonDeleted},
+		Active:         false,
+	}
+	err := testQueries.UpdateWebhookSubscription(context.Background(), arg)
+	require.NoError(t, err)
+
+	subscription2, err := testQueries.GetWebhookSubscription(context.Background(), arg.SubscriptionID)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Url, subscription2.Url)
+	require.Equal(t, arg.Events, subscription2.Events)
+	require.False(t, subscription2.Active)
+	require.Equal(t, subscription1.Secret, subscription2.Secret)
+}
+
+func TestCreateWebhookDeliveries(t *testing.T) {
+	subscribed := createRandomWebhookSubscription(t, WebhookEventInvoiceUpdated)
+	notSubscribed := createRandomWebhookSubscription(t, WebhookEventStudentCreated)
+	inactive := createRandomWebhookSubscription(t, WebhookEventInvoiceUpdated)
+
+	err := testQueries.UpdateWebhookSubscription(context.Background(), UpdateWebhookSubscriptionParams{
+		SubscriptionID: inactive.SubscriptionID,
+		Url:            inactive.Url,
+		Events:         inactive.Events,
+		Active:         false,
+	})
+	require.NoError(t, err)
+
+	err = testQueries.enqueueWebhookEvent(context.Background(), WebhookEventInvoiceUpdated, map[string]int{"invoice_id": 1})
+	require.NoError(t, err)
+
+	deliveries := listSubscriptionDeliveries(t, subscribed)
+	require.Len(t, deliveries, 1)
+
+	var data map[string]int
+	requireWebhookPayload(t, deliveries[0], WebhookEventInvoiceUpdated, &data)
+	require.Equal(t, 1, data["invoice_id"])
+
+	require.Empty(t, listSubscriptionDeliveries(t, notSubscribed))
+	require.Empty(t, listSubscriptionDeliveries(t, inactive))
+}
+
+func TestClaimWebhookDeliveries(t *testing.T) {
+	subscription := createRandomWebhookSubscription(t, WebhookEventLessonDeleted)
+
+	err := testQueries.enqueueWebhookEvent(context.Background(), WebhookEventLessonDeleted, map[string]int{"lesson_id": 1})
+	require.NoError(t, err)
+
+	delivery := listSubscriptionDeliveries(t, subscription)[0]
+
+	now := time.Now().UTC()
+	arg := ClaimWebhookDeliveriesParams{
+		LeaseUntil: now.Add(5 * time.Minute),
+		Now:        now,
+		Limit:      1000,
+	}
+
+	claimed, err := testQueries.ClaimWebhookDeliveries(context.Background(), arg)
+	require.NoError(t, err)
+
+	var found bool
+	for _, c := range claimed {
+		if c.DeliveryID == delivery.DeliveryID {
+			found = true
+			require.Equal(t, subscription.Url, c.Url)
+			require.Equal(t, subscription.Secret, c.Secret)
+			require.JSONEq(t, string(delivery.Payload), string(c.Payload))
+		}
+	}
+	require.True(t, found)
+
+	// a claimed delivery isn't claimed again until the lease expires
+	claimed, err = testQueries.ClaimWebhookDeliveries(context.Background(), arg)
+	require.NoError(t, err)
+	for _, c := range claimed {
+		require.NotEqual(t, delivery.DeliveryID, c.DeliveryID)
+	}
+}
+
+func TestMarkWebhookDeliveryDelivered(t *testing.T) {
+	subscription := createRandomWebhookSubscription(t, WebhookEventStudentCreated)
+	_, err := NewStore(testDB).CreateStudentTx(context.Background(), CreateStudentParams{
+		FirstName: util.RandomName(),
+		LastName:  util.RandomName(),
+	})
+	require.NoError(t, err)
+
+	delivery := listSubscriptionDeliveries(t, subscription)[0]
+
+	arg := MarkWebhookDeliveryDeliveredParams{
+		DeliveryID:     delivery.DeliveryID,
+		ResponseStatus: sql.NullInt32{Int32: 204, Valid: true},
+		DeliveredAt:    sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	}
+	require.NoError(t, testQueries.MarkWebhookDeliveryDelivered(context.Background(), arg))
+
+	delivered, err := testQueries.GetWebhookDelivery(context.Background(), delivery.DeliveryID)
+	require.NoError(t, err)
+	require.Equal(t, WebhookStatusDelivered, delivered.Status)
+	require.Equal(t, int32(1), delivered.Attempts)
+	require.Equal(t, arg.ResponseStatus, delivered.ResponseStatus)
+	require.WithinDuration(t, arg.DeliveredAt.Time, delivered.DeliveredAt.Time, time.Second)
+}
+
+func TestMarkWebhookDeliveryFailed(t *testing.T) {
+	subscription := createRandomWebhookSubscription(t, WebhookEventStudentCreated)
+	_, err := NewStore(testDB).CreateStudentTx(context.Background(), CreateStudentParams{
+		FirstName: util.RandomName(),
+		LastName:  util.RandomName(),
+	})
+	require.NoError(t, err)
+
+	delivery := listSubscriptionDeliveries(t, subscription)[0]
+
+	arg := MarkWebhookDeliveryFailedParams{
+		DeliveryID:     delivery.DeliveryID,
+		Status:         WebhookStatusPending,
+		ResponseStatus: sql.NullInt32{Int32: 500, Valid: true},
+		LastError:      sql.NullString{String: "internal server error", Valid: true},
+		NextAttemptAt:  time.Now().UTC().Add(time.Minute),
+	}
+	require.NoError(t, testQueries.MarkWebhookDeliveryFailed(context.Background(), arg))
+
+	failed, err := testQueries.GetWebhookDelivery(context.Background(), delivery.DeliveryID)
+	require.NoError(t, err)
+	require.Equal(t, WebhookStatusPending, failed.Status)
+	require.Equal(t, int32(1), failed.Attempts)
+	require.Equal(t, arg.ResponseStatus, failed.ResponseStatus)
+	require.Equal(t, arg.LastError, failed.LastError)
+	require.WithinDuration(t, arg.NextAttemptAt, failed.NextAttemptAt, time.Second)
+	require.False(t, failed.DeliveredAt.Valid)
+}
+
+func TestReplayWebhookDelivery(t *testing.T) {
+	subscription := createRandomWebhookSubscription(t, WebhookEventStudentCreated)
+	_, err := NewStore(testDB).CreateStudentTx(context.Background(), CreateStudentParams{
+		FirstName: util.RandomName(),
+		LastName:  util.RandomName(),
+	})
+	require.NoError(t, err)
+
+	delivery := listSubscriptionDeliveries(t, subscription)[0]
+	err = testQueries.MarkWebhookDeliveryDelivered(context.Background(), MarkWebhookDeliveryDeliveredParams{
+		DeliveryID:     delivery.DeliveryID,
+		ResponseStatus: sql.NullInt32{Int32: 200, Valid: true},
+		DeliveredAt:    sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	})
+	require.NoError(t, err)
+
+	replay, err := testQueries.ReplayWebhookDelivery(context.Background(), delivery.DeliveryID)
+	require.NoError(t, err)
+	require.NotEqual(t, delivery.DeliveryID, replay.DeliveryID)
+	require.Equal(t, delivery.SubscriptionID, replay.SubscriptionID)
+	require.JSONEq(t, string(delivery.Payload), string(replay.Payload))
+	require.Equal(t, WebhookStatusPending, replay.Status)
+	require.Zero(t, replay.Attempts)
+
+	_, err = testQueries.ReplayWebhookDelivery(context.Background(), 0)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestWebhookEventsTx(t *testing.T) {
+	store := NewStore(testDB)
+	subscription := createRandomWebhookSubscription(t, WebhookEvents...)
+
+	// student.created
+	student, err := store.CreateStudentTx(context.Background(), CreateStudentParams{
+		FirstName: util.RandomName(),
+		LastName:  util.RandomName(),
+		HourlyFee: sql.NullFloat64{Float64: util.RandomHourlyFee(), Valid: true},
+	})
+	require.NoError(t, err)
+
+	deliveries := listSubscriptionDeliveries(t, subscription)
+	require.Len(t, deliveries, 1)
+
+	var createdStudent Student
+	requireWebhookPayload(t, deliveries[0], WebhookEventStudentCreated, &createdStudent)
+	require.Equal(t, student.StudentID, createdStudent.StudentID)
+
+	// lesson.created
+	lesson, err := createRandomLessonWithInvoiceTx(t, time.Now().UTC(), student)
+	require.NoError(t, err)
+
+	deliveries = listSubscriptionDeliveries(t, subscription)
+	require.Len(t, deliveries, 2)
+
+	var createdLesson LessonWithInvoices
+	requireWebhookPayload(t, deliveries[0], WebhookEventLessonCreated, &createdLesson)
+	require.Equal(t, lesson.Lesson.LessonID, createdLesson.Lesson.LessonID)
+	require.Len(t, createdLesson.Invoices, 1)
+
+	// invoice.updated
+	arg := UpdateInvoiceParams{
+		InvoiceID:       lesson.Invoices[0].InvoiceID,
+		StudentID:       student.StudentID,
+		LessonID:        lesson.Lesson.LessonID,
+		InvoiceDatetime: lesson.Invoices[0].InvoiceDatetime,
+		HourlyFee:       lesson.Invoices[0].HourlyFee,
+		Duration:        lesson.Invoices[0].Duration,
+		Discount:        util.RandomDiscount(),
+		Amount:          util.RandomInvoiceAmount(),
+	}
+	invoice, err := store.UpdateInvoiceTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Amount, invoice.Amount)
+
+	deliveries = listSubscriptionDeliveries(t, subscription)
+	require.Len(t, deliveries, 3)
+
+	var updatedInvoice Invoice
+	requireWebhookPayload(t, deliveries[0], WebhookEventInvoiceUpdated, &updatedInvoice)
+	require.Equal(t, invoice.InvoiceID, updatedInvoice.InvoiceID)
+	require.Equal(t, arg.Amount, updatedInvoice.Amount)
+
+	// receipt.created
+	paymentMethod := createRandomPaymentMethod(t)
+	receipt, err := store.CreateReceiptWithPaymentsTx(context.Background(), CreateReceiptTxParams{
+		StudentID:       student.StudentID,
+		ReceiptDatetime: time.Now().UTC(),
+		ReceiptPaymentsParams: []CreateReceiptTxPaymentParams{
+			{
+				PaymentDatetime: time.Now().UTC(),
+				Amount:          util.RandomPaymentAmount(),
+				PaymentMethodID: paymentMethod.PaymentMethodID,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	deliveries = listSubscriptionDeliveries(t, subscription)
+	require.Len(t, deliveries, 4)
+
+	var createdReceipt ReceiptWithPayments
+	requireWebhookPayload(t, deliveries[0], WebhookEventReceiptCreated, &createdReceipt)
+	require.Equal(t, receipt.Receipt.ReceiptID, createdReceipt.Receipt.ReceiptID)
+	require.Len(t, createdReceipt.Payments, 1)
+
+	// lesson.deleted
+	err = store.DeleteLessonWithInvoicesTx(context.Background(), lesson.Lesson.LessonID)
+	require.NoError(t, err)
+
+	deliveries = listSubscriptionDeliveries(t, subscription)
+	require.Len(t, deliveries, 5)
+
+	var deletedLesson LessonWithInvoices
+	requireWebhookPayload(t, deliveries[0], WebhookEventLessonDeleted, &deletedLesson)
+	require.Equal(t, lesson.Lesson.LessonID, deletedLesson.Lesson.LessonID)
+	require.Len(t, deletedLesson.Invoices, 1)
+
+	// deleting a lesson that doesn't exist posts no event
+	err = store.DeleteLessonWithInvoicesTx(context.Background(), lesson.Lesson.LessonID)
+	require.NoError(t, err)
+	require.Len(t, listSubscriptionDeliveries(t, subscription), 5)
+}
+
+func TestWebhookEventsTxRollback(t *testing.T) {
+	store := NewStore(testDB)
+	subscription := createRandomWebhookSubscription(t, WebhookEventLessonCreated)
+
+	// creating an invoice for a student that doesn't exist rolls back the lesson and its event
+	_, err := createRandomLessonWithInvoiceTx(t, time.Now().UTC(), Student{StudentID: 0})
+	require.Error(t, err)
+	require.Empty(t, listSubscriptionDeliveries(t, subscription))
+
+	_, err = store.UpdateInvoiceTx(context.Background(), UpdateInvoiceParams{InvoiceID: 0})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// JobRunsCleanupJobName is the name of the job deleting old job runs.
+const JobRunsCleanupJobName = "job_runs_cleanup"
+
+// CleanupJobRuns returns a job that deletes the history of the job runs older than retention.
+func CleanupJobRuns(store db.Store, retention time.Duration) Func {
+	return func(ctx context.Context) error {
+		deleted, err := store.DeleteJobRunsBefore(ctx, time.Now().UTC().Add(-retention))
+		if err != nil {
+			return err
+		}
+
+		log.Printf("job %s deleted %d job runs", JobRunsCleanupJobName, deleted)
+		return nil
+	}
+}
@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanupJobRuns(t *testing.T) {
+	mockStore := mocks.NewMockStore(t)
+	retention := 30 * 24 * time.Hour
+
+	mockStore.On("DeleteJobRunsBefore", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before.Add(retention)) < time.Minute
+	})).Return(int64(5), nil).Once()
+
+	require.NoError(t, CleanupJobRuns(mockStore, retention)(context.Background()))
+
+	mockStore.On("DeleteJobRunsBefore", mock.Anything, mock.Anything).
+		Return(int64(0), sql.ErrConnDone).
+		Once()
+
+	require.ErrorIs(t, CleanupJobRuns(mockStore, retention)(context.Background()), sql.ErrConnDone)
+}
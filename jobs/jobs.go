@@ -0,0 +1,8 @@
+package jobs
+
+import (
+	"context"
+)
+
+// Func is a background job. It is run by the Scheduler until its context is done.
+type Func func(ctx context.Context) error
@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// MonthlyBillingJobName is the name of the job creating the monthly statements.
+const MonthlyBillingJobName = "monthly_billing"
+
+// BillPreviousMonth returns a job that creates the statements of the previous calendar month for all students,
+// and emails them to the students. Students that already have a statement of the month are skipped,
+// so the job can be run again safely.
+func BillPreviousMonth(store db.Store) Func {
+	return func(ctx context.Context) error {
+		start, _ := db.StatementPeriod(time.Now().UTC())
+		month := start.AddDate(0, -1, 0)
+
+		statements, err := store.CreateMonthlyStatementsTx(ctx, month, sql.NullInt64{})
+		if err != nil {
+			return err
+		}
+
+		log.Printf("job %s created %d statements for %s", MonthlyBillingJobName, len(statements), month.Format("2006-01"))
+		return nil
+	}
+}
@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBillPreviousMonth(t *testing.T) {
+	mockStore := mocks.NewMockStore(t)
+
+	thisMonth, _ := db.StatementPeriod(time.Now())
+	previousMonth := thisMonth.AddDate(0, -1, 0)
+
+	mockStore.On("CreateMonthlyStatementsTx", mock.Anything, previousMonth, sql.NullInt64{}).
+		Return([]db.Statement{{StatementID: 1}, {StatementID: 2}}, nil).
+		Once()
+
+	require.NoError(t, BillPreviousMonth(mockStore)(context.Background()))
+
+	mockStore.On("CreateMonthlyStatementsTx", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, sql.ErrConnDone).
+		Once()
+
+	require.ErrorIs(t, BillPreviousMonth(mockStore)(context.Background()), sql.ErrConnDone)
+}
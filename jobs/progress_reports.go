@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// ProgressReportsJobName is the name of the job creating the monthly progress reports.
+const ProgressReportsJobName = "progress_reports"
+
+// ReportPreviousMonthProgress returns a job that creates the progress reports of the previous calendar month
+// for all students with lessons in the month, and emails them to the students or their billing contacts.
+// Students that already have a progress report of the month are skipped, so the job can be run again safely.
+func ReportPreviousMonthProgress(store db.Store) Func {
+	return func(ctx context.Context) error {
+		end, _ := db.StatementPeriod(time.Now().UTC())
+		start := end.AddDate(0, -1, 0)
+
+		reports, err := store.CreateProgressReportsTx(ctx, start, end, sql.NullInt64{})
+		if err != nil {
+			return err
+		}
+
+		log.Printf("job %s created %d progress reports for %s", ProgressReportsJobName, len(reports), start.Format("2006-01"))
+		return nil
+	}
+}
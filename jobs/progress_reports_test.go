@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportPreviousMonthProgress(t *testing.T) {
+	mockStore := mocks.NewMockStore(t)
+
+	thisMonth, _ := db.StatementPeriod(time.Now())
+	previousMonth := thisMonth.AddDate(0, -1, 0)
+
+	mockStore.On("CreateProgressReportsTx", mock.Anything, previousMonth, thisMonth, sql.NullInt64{}).
+		Return([]db.ProgressReport{{ProgressReportID: 1}, {ProgressReportID: 2}}, nil).
+		Once()
+
+	require.NoError(t, ReportPreviousMonthProgress(mockStore)(context.Background()))
+
+	mockStore.On("CreateProgressReportsTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, sql.ErrConnDone).
+		Once()
+
+	require.ErrorIs(t, ReportPreviousMonthProgress(mockStore)(context.Background()), sql.ErrConnDone)
+}
@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// Friday
+	now := time.Date(2024, time.March, 15, 10, 30, 45, 0, time.UTC)
+
+	testCases := []struct {
+		spec string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.March, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.March, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2024, time.March, 16, 3, 0, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2024, time.March, 16, 10, 30, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2024, time.March, 15, 13, 0, 0, 0, time.UTC)},
+		{"0 8,20 * * *", time.Date(2024, time.March, 15, 20, 0, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2024, time.March, 18, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 7", time.Date(2024, time.March, 17, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2024, time.March, 15, 10, 45, 0, 0, time.UTC)},
+		// day of month or day of week, as in standard cron
+		{"0 0 20 * sun", time.Date(2024, time.March, 17, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, time.March, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, time.March, 16, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, time.March, 17, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 1m", time.Date(2024, time.March, 15, 10, 31, 0, 0, time.UTC)},
+		{"@every 2h", time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.spec, func(t *testing.T) {
+			schedule, err := ParseSchedule(tc.spec)
+			require.NoError(t, err)
+			require.Equal(t, tc.spec, schedule.String())
+			require.Equal(t, tc.next, schedule.Next(now))
+		})
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@often",
+		"@every",
+		"@every 10ms",
+		"@every soon",
+	}
+
+	for _, spec := range specs {
+		_, err := ParseSchedule(spec)
+		require.Error(t, err, spec)
+	}
+
+	require.Panics(t, func() { MustParseSchedule("@often") })
+}
+
+func TestScheduleNeverDue(t *testing.T) {
+	schedule, err := ParseSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	require.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestScheduleTimeZone(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	now := time.Date(2024, time.March, 15, 10, 30, 0, 0, loc)
+
+	schedule := MustParseSchedule("0 3 * * *")
+	require.Equal(t, time.Date(2024, time.March, 16, 3, 0, 0, 0, loc), schedule.Next(now))
+}
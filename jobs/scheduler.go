@@ -0,0 +1,275 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// ErrJobNotFound is returned when triggering a job that isn't registered.
+var ErrJobNotFound = errors.New("job not found")
+
+// errAlreadyRun is returned when a scheduled run was already run by another instance.
+var errAlreadyRun = errors.New("scheduled run already run")
+
+// JobInfo describes a job registered with the scheduler.
+type JobInfo struct {
+	Name      string    `json:"name"`
+	Schedule  string    `json:"schedule"`
+	NextRunAt time.Time `json:"next_run_at"`
+}
+
+// job is a job registered with the scheduler.
+type job struct {
+	name     string
+	schedule Schedule
+	run      Func
+	next     time.Time
+}
+
+// Scheduler runs jobs on their schedules, and records every run in the job_runs table.
+// Several instances of the service can run a scheduler with the same jobs:
+// each job run holds a Postgres advisory lock, so a job runs on a single instance at a time,
+// and a scheduled run is skipped if another instance already ran it.
+type Scheduler struct {
+	store db.Store
+	now   func() time.Time
+
+	mu   sync.Mutex
+	jobs map[string]*job
+	ctx  context.Context
+}
+
+// NewScheduler creates a new Scheduler without jobs.
+func NewScheduler(store db.Store) *Scheduler {
+	return &Scheduler{
+		store: store,
+		now:   time.Now,
+		jobs:  make(map[string]*job),
+		ctx:   context.Background(),
+	}
+}
+
+// Add registers a job named name to run on schedule.
+// Jobs must be added before the scheduler is started.
+func (s *Scheduler) Add(name string, schedule Schedule, run Func) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[name]; ok {
+		return fmt.Errorf("job %s is already registered", name)
+	}
+
+	s.jobs[name] = &job{
+		name:     name,
+		schedule: schedule,
+		run:      run,
+		next:     schedule.Next(s.now()),
+	}
+
+	return nil
+}
+
+// Jobs returns the registered jobs, sorted by name.
+func (s *Scheduler) Jobs() []JobInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	infos := make([]JobInfo, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		infos = append(infos, JobInfo{
+			Name:      j.name,
+			Schedule:  j.schedule.String(),
+			NextRunAt: j.next,
+		})
+	}
+
+	sort.Slice(infos, func(i, k int) bool { return infos[i].Name < infos[k].Name })
+	return infos
+}
+
+// Start runs the jobs when they are due, until ctx is done.
+// Each run is started in its own goroutine, so a long running job doesn't delay the others.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	s.ctx = ctx
+	s.mu.Unlock()
+
+	for {
+		timer := time.NewTimer(s.untilNextRun())
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		for _, due := range s.dueJobs() {
+			go func(due dueJob) {
+				_, err := s.run(ctx, due.job, db.JobTriggerSchedule, due.scheduledAt, nil)
+				switch {
+				case err == nil, errors.Is(err, errAlreadyRun):
+				case errors.Is(err, db.ErrJobLocked):
+					log.Printf("job %s skipped: %v", due.job.name, err)
+				default:
+					log.Printf("job %s failed: %v", due.job.name, err)
+				}
+			}(due)
+		}
+	}
+}
+
+// Trigger runs a job immediately, regardless of its schedule.
+// It returns once the run has started, and the job keeps running in the background.
+// It returns db.ErrJobLocked if the job is already running.
+func (s *Scheduler) Trigger(ctx context.Context, name string) (db.JobRun, error) {
+	s.mu.Lock()
+	j, ok := s.jobs[name]
+	runCtx := s.ctx
+	s.mu.Unlock()
+
+	if !ok {
+		return db.JobRun{}, ErrJobNotFound
+	}
+
+	started := make(chan db.JobRun, 1)
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := s.run(runCtx, j, db.JobTriggerManual, s.now(), started)
+		if err != nil {
+			log.Printf("job %s failed: %v", name, err)
+		}
+		done <- err
+	}()
+
+	select {
+	case run := <-started:
+		return run, nil
+	case err := <-done:
+		// the run may have started and finished already
+		select {
+		case run := <-started:
+			return run, nil
+		default:
+			return db.JobRun{}, err
+		}
+	case <-ctx.Done():
+		return db.JobRun{}, ctx.Err()
+	}
+}
+
+// dueJob is a job due at scheduledAt.
+type dueJob struct {
+	job         *job
+	scheduledAt time.Time
+}
+
+// dueJobs returns the jobs that are due, and advances their next run time.
+func (s *Scheduler) dueJobs() []dueJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	var due []dueJob
+	for _, j := range s.jobs {
+		if j.next.IsZero() || j.next.After(now) {
+			continue
+		}
+
+		due = append(due, dueJob{job: j, scheduledAt: j.next})
+		j.next = j.schedule.Next(now)
+	}
+
+	return due
+}
+
+// maxWait is the longest the scheduler sleeps, so it recovers from clock changes.
+const maxWait = time.Minute
+
+// untilNextRun returns the time until the next job is due.
+func (s *Scheduler) untilNextRun() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wait := maxWait
+	now := s.now()
+
+	for _, j := range s.jobs {
+		if j.next.IsZero() {
+			continue
+		}
+		if d := j.next.Sub(now); d < wait {
+			wait = d
+		}
+	}
+
+	if wait < 0 {
+		wait = 0
+	}
+
+	return wait
+}
+
+// run runs a job while holding its lock, and records the run.
+// The run is sent to started, if not nil, once it is recorded.
+// The job error is recorded in the run, and returned.
+func (s *Scheduler) run(ctx context.Context, j *job, trigger string, scheduledAt time.Time, started chan<- db.JobRun) (db.JobRun, error) {
+	var run db.JobRun
+
+	err := s.store.LockJobTx(ctx, j.name, func(ctx context.Context) error {
+		if trigger == db.JobTriggerSchedule {
+			last, err := s.store.GetLastScheduledJobRun(ctx, j.name)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			if err == nil && !last.ScheduledAt.Before(scheduledAt) {
+				return errAlreadyRun
+			}
+		}
+
+		var err error
+		run, err = s.store.CreateJobRun(ctx, db.CreateJobRunParams{
+			JobName:     j.name,
+			Trigger:     trigger,
+			ScheduledAt: scheduledAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		if started != nil {
+			started <- run
+		}
+
+		jobErr := j.run(ctx)
+
+		arg := db.FinishJobRunParams{
+			RunID:  run.RunID,
+			Status: db.JobRunStatusSucceeded,
+		}
+		if jobErr != nil {
+			arg.Status = db.JobRunStatusFailed
+			arg.Error = sql.NullString{String: jobErr.Error(), Valid: true}
+		}
+
+		// the run is recorded even if the job was canceled
+		run, err = s.store.FinishJobRun(context.WithoutCancel(ctx), arg)
+		if err != nil {
+			return err
+		}
+
+		return jobErr
+	})
+
+	return run, err
+}
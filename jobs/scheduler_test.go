@@ -0,0 +1,203 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// lockJob is the stub of LockJobTx when the lock is available.
+func lockJob(ctx context.Context, jobName string, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func randomJobRun(name, trigger string, scheduledAt time.Time) db.JobRun {
+	return db.JobRun{
+		RunID:       util.RandomInt64(1, 1000),
+		JobName:     name,
+		Trigger:     trigger,
+		Status:      db.JobRunStatusRunning,
+		ScheduledAt: scheduledAt,
+		StartedAt:   time.Now().UTC(),
+	}
+}
+
+// matchFinished matches the arguments of FinishJobRun for a run, expecting status.
+func matchFinished(run db.JobRun, status string) interface{} {
+	return mock.MatchedBy(func(arg db.FinishJobRunParams) bool {
+		return arg.RunID == run.RunID && arg.Status == status && arg.Error.Valid == (status == db.JobRunStatusFailed)
+	})
+}
+
+func TestSchedulerAdd(t *testing.T) {
+	scheduler := NewScheduler(mocks.NewMockStore(t))
+	noop := func(ctx context.Context) error { return nil }
+
+	require.NoError(t, scheduler.Add("b", MustParseSchedule("@daily"), noop))
+	require.NoError(t, scheduler.Add("a", Every(time.Minute), noop))
+	require.Error(t, scheduler.Add("a", Every(time.Hour), noop))
+
+	infos := scheduler.Jobs()
+	require.Len(t, infos, 2)
+
+	require.Equal(t, "a", infos[0].Name)
+	require.Equal(t, "@every 1m0s", infos[0].Schedule)
+	require.WithinDuration(t, time.Now(), infos[0].NextRunAt, time.Minute)
+
+	require.Equal(t, "b", infos[1].Name)
+	require.Equal(t, "@daily", infos[1].Schedule)
+}
+
+func TestSchedulerStart(t *testing.T) {
+	mockStore := mocks.NewMockStore(t)
+	scheduler := NewScheduler(mockStore)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := make(chan struct{}, 10)
+	err := scheduler.Add("test", Every(time.Second), func(ctx context.Context) error {
+		runs <- struct{}{}
+		return errors.New("job failed")
+	})
+	require.NoError(t, err)
+
+	run := randomJobRun("test", db.JobTriggerSchedule, time.Now())
+
+	mockStore.On("LockJobTx", mock.Anything, "test", mock.Anything).Return(lockJob)
+	mockStore.On("GetLastScheduledJobRun", mock.Anything, "test").Return(db.JobRun{}, sql.ErrNoRows)
+	mockStore.On("CreateJobRun", mock.Anything, mock.MatchedBy(func(arg db.CreateJobRunParams) bool {
+		return arg.JobName == "test" &&
+			arg.Trigger == db.JobTriggerSchedule &&
+			arg.ScheduledAt.Equal(arg.ScheduledAt.Truncate(time.Second))
+	})).Return(run, nil)
+	mockStore.On("FinishJobRun", mock.Anything, matchFinished(run, db.JobRunStatusFailed)).Return(run, nil)
+
+	go scheduler.Start(ctx)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-runs:
+		case <-time.After(3 * time.Second):
+			t.Fatal("the scheduled job didn't run")
+		}
+	}
+}
+
+func TestSchedulerRun(t *testing.T) {
+	scheduledAt := time.Now().Truncate(time.Minute)
+
+	var calls atomic.Int32
+	j := &job{
+		name:     "test",
+		schedule: Every(time.Minute),
+		run: func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		},
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		mockStore := mocks.NewMockStore(t)
+		scheduler := NewScheduler(mockStore)
+		run := randomJobRun(j.name, db.JobTriggerSchedule, scheduledAt)
+
+		mockStore.On("LockJobTx", mock.Anything, j.name, mock.Anything).Return(lockJob).Once()
+		mockStore.On("GetLastScheduledJobRun", mock.Anything, j.name).
+			Return(randomJobRun(j.name, db.JobTriggerSchedule, scheduledAt.Add(-time.Minute)), nil).
+			Once()
+		mockStore.On("CreateJobRun", mock.Anything, db.CreateJobRunParams{
+			JobName:     j.name,
+			Trigger:     db.JobTriggerSchedule,
+			ScheduledAt: scheduledAt,
+		}).Return(run, nil).Once()
+
+		finished := run
+		finished.Status = db.JobRunStatusSucceeded
+		mockStore.On("FinishJobRun", mock.Anything, matchFinished(run, db.JobRunStatusSucceeded)).Return(finished, nil).Once()
+
+		result, err := scheduler.run(context.Background(), j, db.JobTriggerSchedule, scheduledAt, nil)
+		require.NoError(t, err)
+		require.Equal(t, finished, result)
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("Already Run", func(t *testing.T) {
+		mockStore := mocks.NewMockStore(t)
+		scheduler := NewScheduler(mockStore)
+
+		// another instance already ran the job at the scheduled time
+		mockStore.On("LockJobTx", mock.Anything, j.name, mock.Anything).Return(lockJob).Once()
+		mockStore.On("GetLastScheduledJobRun", mock.Anything, j.name).
+			Return(randomJobRun(j.name, db.JobTriggerSchedule, scheduledAt), nil).
+			Once()
+
+		_, err := scheduler.run(context.Background(), j, db.JobTriggerSchedule, scheduledAt, nil)
+		require.ErrorIs(t, err, errAlreadyRun)
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		mockStore := mocks.NewMockStore(t)
+		scheduler := NewScheduler(mockStore)
+
+		mockStore.On("LockJobTx", mock.Anything, j.name, mock.Anything).Return(db.ErrJobLocked).Once()
+
+		_, err := scheduler.run(context.Background(), j, db.JobTriggerSchedule, scheduledAt, nil)
+		require.ErrorIs(t, err, db.ErrJobLocked)
+		require.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestSchedulerTrigger(t *testing.T) {
+	mockStore := mocks.NewMockStore(t)
+	scheduler := NewScheduler(mockStore)
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	err := scheduler.Add("test", MustParseSchedule("@daily"), func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+
+	run := randomJobRun("test", db.JobTriggerManual, time.Now())
+
+	mockStore.On("LockJobTx", mock.Anything, "test", mock.Anything).Return(lockJob).Once()
+	mockStore.On("CreateJobRun", mock.Anything, mock.MatchedBy(func(arg db.CreateJobRunParams) bool {
+		return arg.JobName == "test" && arg.Trigger == db.JobTriggerManual
+	})).Return(run, nil).Once()
+	mockStore.On("FinishJobRun", mock.Anything, matchFinished(run, db.JobRunStatusSucceeded)).
+		Run(func(args mock.Arguments) { close(done) }).
+		Return(run, nil).
+		Once()
+
+	// Trigger returns while the job is still running
+	started, err := scheduler.Trigger(context.Background(), "test")
+	require.NoError(t, err)
+	require.Equal(t, run, started)
+
+	close(release)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the triggered job didn't finish")
+	}
+
+	_, err = scheduler.Trigger(context.Background(), "unknown")
+	require.ErrorIs(t, err, ErrJobNotFound)
+
+	mockStore.On("LockJobTx", mock.Anything, "test", mock.Anything).Return(db.ErrJobLocked).Once()
+	_, err = scheduler.Trigger(context.Background(), "test")
+	require.ErrorIs(t, err, db.ErrJobLocked)
+}
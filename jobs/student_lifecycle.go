@@ -0,0 +1,26 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// StudentLifecycleJobName is the name of the job updating the students lifecycle status.
+const StudentLifecycleJobName = "student_lifecycle"
+
+// UpdateStudentsLifecycle returns a job that assigns every student a lifecycle status (active, at-risk or lapsed)
+// according to thresholds, based on the lessons the student was invoiced for.
+func UpdateStudentsLifecycle(store db.Store, thresholds db.LifecycleThresholds) Func {
+	return func(ctx context.Context) error {
+		updated, err := store.UpdateStudentsLifecycleTx(ctx, time.Now().UTC(), thresholds)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("job %s updated %d students", StudentLifecycleJobName, updated)
+		return nil
+	}
+}
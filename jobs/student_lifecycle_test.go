@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateStudentsLifecycle(t *testing.T) {
+	mockStore := mocks.NewMockStore(t)
+
+	matchAsOf := mock.MatchedBy(func(asOf time.Time) bool {
+		return time.Since(asOf) < time.Minute
+	})
+
+	mockStore.On("UpdateStudentsLifecycleTx", mock.Anything, matchAsOf, db.DefaultLifecycleThresholds).
+		Return(3, nil).
+		Once()
+
+	job := UpdateStudentsLifecycle(mockStore, db.DefaultLifecycleThresholds)
+	require.NoError(t, job(context.Background()))
+
+	mockStore.On("UpdateStudentsLifecycleTx", mock.Anything, matchAsOf, db.DefaultLifecycleThresholds).
+		Return(0, sql.ErrConnDone).
+		Once()
+
+	require.ErrorIs(t, job(context.Background()), sql.ErrConnDone)
+}
@@ -0,0 +1,26 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// TaskDigestJobName is the name of the job emailing the daily task digest.
+const TaskDigestJobName = "task_digest"
+
+// EmailTaskDigest returns a job that emails recipient the digest of the open tasks that are overdue or due today.
+// No email is sent on days without such tasks.
+func EmailTaskDigest(store db.Store, recipient string) Func {
+	return func(ctx context.Context) error {
+		digest, err := store.EnqueueTaskDigestTx(ctx, recipient, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		log.Printf("job %s found %d overdue tasks and %d tasks due today", TaskDigestJobName, len(digest.Overdue), len(digest.DueToday))
+		return nil
+	}
+}
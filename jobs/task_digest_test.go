@@ -0,0 +1,30 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailTaskDigest(t *testing.T) {
+	mockStore := mocks.NewMockStore(t)
+	recipient := "tutor@example.com"
+
+	mockStore.On("EnqueueTaskDigestTx", mock.Anything, recipient, mock.MatchedBy(func(now time.Time) bool {
+		return time.Since(now) < time.Minute
+	})).Return(db.TaskDigest{Overdue: []db.ListDueTasksRow{{TaskID: 1}}}, nil).Once()
+
+	require.NoError(t, EmailTaskDigest(mockStore, recipient)(context.Background()))
+
+	mockStore.On("EnqueueTaskDigestTx", mock.Anything, mock.Anything, mock.Anything).
+		Return(db.TaskDigest{}, sql.ErrConnDone).
+		Once()
+
+	require.ErrorIs(t, EmailTaskDigest(mockStore, recipient)(context.Background()), sql.ErrConnDone)
+}
@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 
 	"github.com/github-real-lb/tutor-management-web/api"
 	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/jobs"
+	"github.com/github-real-lb/tutor-management-web/notifier"
+	"github.com/github-real-lb/tutor-management-web/reminder"
+	"github.com/github-real-lb/tutor-management-web/storage"
 	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/github-real-lb/tutor-management-web/webhook"
 	_ "github.com/lib/pq"
 )
 
@@ -22,10 +28,102 @@ func main() {
 	}
 
 	store := db.NewStore(conn)
-	server := api.NewServer(store)
+
+	scheduler := jobs.NewScheduler(store)
+
+	if config.JobRunsRetention > 0 {
+		addJob(scheduler, jobs.JobRunsCleanupJobName, jobs.MustParseSchedule("@daily"),
+			jobs.CleanupJobRuns(store, config.JobRunsRetention))
+	}
+
+	if config.StudentLifecycleSchedule != "" {
+		schedule, err := jobs.ParseSchedule(config.StudentLifecycleSchedule)
+		if err != nil {
+			log.Fatal("Cannot parse student lifecycle schedule:", err)
+		}
+
+		addJob(scheduler, jobs.StudentLifecycleJobName, schedule,
+			jobs.UpdateStudentsLifecycle(store, db.DefaultLifecycleThresholds))
+	}
+
+	if config.MonthlyBillingSchedule != "" {
+		schedule, err := jobs.ParseSchedule(config.MonthlyBillingSchedule)
+		if err != nil {
+			log.Fatal("Cannot parse monthly billing schedule:", err)
+		}
+
+		addJob(scheduler, jobs.MonthlyBillingJobName, schedule, jobs.BillPreviousMonth(store))
+	}
+
+	if config.ProgressReportsSchedule != "" {
+		schedule, err := jobs.ParseSchedule(config.ProgressReportsSchedule)
+		if err != nil {
+			log.Fatal("Cannot parse progress reports schedule:", err)
+		}
+
+		addJob(scheduler, jobs.ProgressReportsJobName, schedule, jobs.ReportPreviousMonthProgress(store))
+	}
+
+	if config.TaskDigestSchedule != "" && config.TaskDigestRecipient != "" {
+		schedule, err := jobs.ParseSchedule(config.TaskDigestSchedule)
+		if err != nil {
+			log.Fatal("Cannot parse task digest schedule:", err)
+		}
+
+		addJob(scheduler, jobs.TaskDigestJobName, schedule, jobs.EmailTaskDigest(store, config.TaskDigestRecipient))
+	}
+
+	if config.SMTPHost != "" && config.EmailDispatchInterval > 0 {
+		renderer, err := notifier.NewRenderer()
+		if err != nil {
+			log.Fatal("Cannot parse email templates:", err)
+		}
+
+		dispatcher := notifier.NewDispatcher(store, renderer, notifier.NewSMTPSender(config), config.EmailMaxAttempts)
+		addJob(scheduler, notifier.DispatcherJobName, jobs.Every(config.EmailDispatchInterval), dispatcher.Dispatch)
+	}
+
+	if config.ReminderInterval > 0 {
+		channels := map[string]reminder.Channel{
+			db.ReminderChannelEmail: reminder.NewEmailChannel(store),
+		}
+		if config.SMSGatewayURL != "" {
+			channels[db.ReminderChannelSMS] = reminder.NewSMSChannel(config.SMSGatewayURL, config.SMSGatewayToken, config.SMSFrom)
+		}
+		if config.ReminderWebhookURL != "" {
+			channels[db.ReminderChannelWebhook] = reminder.NewWebhookChannel(config.ReminderWebhookURL)
+		}
+
+		reminders := reminder.NewScheduler(store, channels)
+		addJob(scheduler, reminder.SchedulerJobName, jobs.Every(config.ReminderInterval), reminders.Run)
+	}
+
+	if config.WebhookDispatchInterval > 0 {
+		dispatcher := webhook.NewDispatcher(store, config.WebhookMaxAttempts)
+		addJob(scheduler, webhook.DispatcherJobName, jobs.Every(config.WebhookDispatchInterval), dispatcher.Dispatch)
+	}
+
+	go scheduler.Start(context.Background())
+
+	blobs, err := storage.New(config)
+	if err != nil {
+		log.Fatal("Cannot create blob storage:", err)
+	}
+
+	server := api.NewServer(store, scheduler, blobs, api.AttachmentLimits{
+		MaxSize:      config.AttachmentMaxSize,
+		ContentTypes: config.AttachmentContentTypes,
+	})
 
 	err = server.Start(config.ServerAddress)
 	if err != nil {
 		log.Fatal("Cannot start server:", err)
 	}
 }
+
+// addJob registers a job with the scheduler.
+func addJob(scheduler *jobs.Scheduler, name string, schedule jobs.Schedule, run jobs.Func) {
+	if err := scheduler.Add(name, schedule, run); err != nil {
+		log.Fatal("Cannot add job:", err)
+	}
+}
@@ -0,0 +1,36 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/github-real-lb/tutor-management-web/billing"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// Attachment is a file attached to an email.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// attachments returns the files attached to an email of template, rendered from its payload.
+// Progress reports are attached as a PDF file and an HTML page.
+func attachments(template string, payload db.EmailPayload) ([]Attachment, error) {
+	if template != db.EmailTemplateProgressReport || payload.ProgressReport == nil {
+		return nil, nil
+	}
+
+	doc := *payload.ProgressReport
+	name := fmt.Sprintf("progress-report-%d-%s", doc.ProgressReport.StudentID, doc.ProgressReport.PeriodStart.UTC().Format("2006-01-02"))
+
+	html, err := billing.ProgressReportHTML(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Attachment{
+		{Filename: name + ".pdf", ContentType: "application/pdf", Data: billing.ProgressReportPDF(doc)},
+		{Filename: name + ".html", ContentType: "text/html; charset=utf-8", Data: html},
+	}, nil
+}
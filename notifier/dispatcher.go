@@ -0,0 +1,130 @@
+package notifier
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// DispatcherJobName is the name of the job sending the outbox emails.
+const DispatcherJobName = "email_dispatcher"
+
+const (
+	// dispatchBatchSize is the number of outbox emails claimed at once.
+	dispatchBatchSize = 20
+	// dispatchLease is how long claimed emails are held by a dispatcher before other dispatchers may claim them.
+	dispatchLease = 5 * time.Minute
+	// retryBackoff is the delay before the first retry of a failed email. It is doubled after every attempt.
+	retryBackoff = time.Minute
+	// maxRetryBackoff caps the delay between retries.
+	maxRetryBackoff = 24 * time.Hour
+)
+
+// Dispatcher sends the emails of the email outbox.
+// Emails are claimed from the outbox with a lease, so several dispatchers can run concurrently.
+type Dispatcher struct {
+	store       db.Store
+	renderer    *Renderer
+	sender      Sender
+	maxAttempts int32
+}
+
+// NewDispatcher creates a new Dispatcher.
+// An email that failed to be sent maxAttempts times is marked as failed and isn't retried.
+func NewDispatcher(store db.Store, renderer *Renderer, sender Sender, maxAttempts int) *Dispatcher {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	return &Dispatcher{
+		store:       store,
+		renderer:    renderer,
+		sender:      sender,
+		maxAttempts: int32(maxAttempts),
+	}
+}
+
+// Dispatch sends all the outbox emails that are due.
+// Emails that fail to be sent are retried later with an exponential backoff.
+func (d *Dispatcher) Dispatch(ctx context.Context) error {
+	for {
+		now := time.Now().UTC()
+
+		emails, err := d.store.ClaimOutboxEmails(ctx, db.ClaimOutboxEmailsParams{
+			LeaseUntil: now.Add(dispatchLease),
+			Now:        now,
+			Limit:      dispatchBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, email := range emails {
+			if err = d.send(ctx, email); err != nil {
+				return err
+			}
+		}
+
+		if len(emails) < dispatchBatchSize {
+			return nil
+		}
+	}
+}
+
+// send renders and sends a single email, and records the result in the outbox.
+// Only errors updating the outbox are returned.
+func (d *Dispatcher) send(ctx context.Context, email db.EmailOutbox) error {
+	msg, err := d.renderer.Render(email)
+	if err != nil {
+		// rendering errors aren't resolved by retrying
+		return d.fail(ctx, email, err, true)
+	}
+
+	if err = d.sender.Send(ctx, msg); err != nil {
+		// an invalid recipient isn't resolved by retrying either
+		final := errors.Is(err, ErrInvalidRecipient) || email.Attempts+1 >= d.maxAttempts
+		return d.fail(ctx, email, err, final)
+	}
+
+	return d.store.MarkOutboxEmailSent(ctx, db.MarkOutboxEmailSentParams{
+		EmailID: email.EmailID,
+		SentAt:  sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	})
+}
+
+// fail records a failed attempt to send an email.
+// If final is false the email stays pending, and is retried after the backoff delay.
+func (d *Dispatcher) fail(ctx context.Context, email db.EmailOutbox, sendErr error, final bool) error {
+	log.Printf("sending email %d failed (attempt %d): %v", email.EmailID, email.Attempts+1, sendErr)
+
+	arg := db.MarkOutboxEmailFailedParams{
+		EmailID:   email.EmailID,
+		Status:    db.EmailStatusPending,
+		LastError: sql.NullString{String: sendErr.Error(), Valid: true},
+		SendAt:    time.Now().UTC().Add(backoff(email.Attempts)),
+	}
+
+	if final {
+		arg.Status = db.EmailStatusFailed
+	}
+
+	return d.store.MarkOutboxEmailFailed(ctx, arg)
+}
+
+// backoff returns the delay before retrying an email that was already attempted attempts times.
+func backoff(attempts int32) time.Duration {
+	delay := retryBackoff
+	for i := int32(0); i < attempts && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+
+	return delay
+}
@@ -0,0 +1,160 @@
+package notifier
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDispatcher creates a Dispatcher sending emails through a test SMTP server.
+func newTestDispatcher(t *testing.T, mockStore *mocks.MockStore, server *testSMTPServer, maxAttempts int) *Dispatcher {
+	renderer, err := NewRenderer()
+	require.NoError(t, err)
+
+	return NewDispatcher(mockStore, renderer, NewSMTPSender(server.config()), maxAttempts)
+}
+
+// matchFailed matches the arguments of MarkOutboxEmailFailed for an email, expecting status and a retry after delay.
+func matchFailed(email db.EmailOutbox, status string, delay time.Duration) interface{} {
+	return mock.MatchedBy(func(arg db.MarkOutboxEmailFailedParams) bool {
+		retryAt := time.Now().UTC().Add(delay)
+		return arg.EmailID == email.EmailID &&
+			arg.Status == status &&
+			arg.LastError.Valid &&
+			retryAt.Sub(arg.SendAt) < time.Minute
+	})
+}
+
+func TestDispatch(t *testing.T) {
+	emails := []db.EmailOutbox{
+		randomOutboxEmail(t, db.EmailTemplateInvoiceCreated),
+		randomOutboxEmail(t, db.EmailTemplateLessonReminder),
+	}
+
+	server := newTestSMTPServer(t)
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("ClaimOutboxEmails", mock.Anything, mock.MatchedBy(func(arg db.ClaimOutboxEmailsParams) bool {
+		return arg.Limit == dispatchBatchSize && arg.LeaseUntil.Sub(arg.Now) == dispatchLease
+	})).Return(emails, nil).Once()
+
+	for _, email := range emails {
+		mockStore.On("MarkOutboxEmailSent", mock.Anything, mock.MatchedBy(func(arg db.MarkOutboxEmailSentParams) bool {
+			return arg.EmailID == email.EmailID && arg.SentAt.Valid
+		})).Return(nil).Once()
+	}
+
+	dispatcher := newTestDispatcher(t, mockStore, server, 3)
+	require.NoError(t, dispatcher.Dispatch(context.Background()))
+
+	require.Len(t, server.mails, len(emails))
+	for _, email := range emails {
+		mail := <-server.mails
+		require.Equal(t, []string{email.Recipient}, mail.To)
+	}
+}
+
+func TestDispatchRetry(t *testing.T) {
+	email := randomOutboxEmail(t, db.EmailTemplateReceiptConfirmation)
+	email.Attempts = 1
+
+	server := newTestSMTPServer(t, email.Recipient)
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("ClaimOutboxEmails", mock.Anything, mock.Anything).
+		Return([]db.EmailOutbox{email}, nil).
+		Once()
+
+	mockStore.On("MarkOutboxEmailFailed", mock.Anything, matchFailed(email, db.EmailStatusPending, 2*retryBackoff)).
+		Return(nil).
+		Once()
+
+	dispatcher := newTestDispatcher(t, mockStore, server, 3)
+	require.NoError(t, dispatcher.Dispatch(context.Background()))
+	require.Empty(t, server.mails)
+}
+
+func TestDispatchMaxAttempts(t *testing.T) {
+	email := randomOutboxEmail(t, db.EmailTemplateReceiptConfirmation)
+	email.Attempts = 2
+
+	server := newTestSMTPServer(t, email.Recipient)
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("ClaimOutboxEmails", mock.Anything, mock.Anything).
+		Return([]db.EmailOutbox{email}, nil).
+		Once()
+
+	mockStore.On("MarkOutboxEmailFailed", mock.Anything, matchFailed(email, db.EmailStatusFailed, 4*retryBackoff)).
+		Return(nil).
+		Once()
+
+	dispatcher := newTestDispatcher(t, mockStore, server, 3)
+	require.NoError(t, dispatcher.Dispatch(context.Background()))
+}
+
+func TestDispatchRenderError(t *testing.T) {
+	email := randomOutboxEmail(t, "unknown")
+
+	server := newTestSMTPServer(t)
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("ClaimOutboxEmails", mock.Anything, mock.Anything).
+		Return([]db.EmailOutbox{email}, nil).
+		Once()
+
+	mockStore.On("MarkOutboxEmailFailed", mock.Anything, matchFailed(email, db.EmailStatusFailed, retryBackoff)).
+		Return(nil).
+		Once()
+
+	dispatcher := newTestDispatcher(t, mockStore, server, 3)
+	require.NoError(t, dispatcher.Dispatch(context.Background()))
+	require.Empty(t, server.mails)
+}
+
+func TestDispatchInvalidRecipient(t *testing.T) {
+	email := randomOutboxEmail(t, db.EmailTemplateReceiptConfirmation)
+	email.Recipient += "\r\nBcc: " + util.RandomEmail()
+
+	server := newTestSMTPServer(t)
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("ClaimOutboxEmails", mock.Anything, mock.Anything).
+		Return([]db.EmailOutbox{email}, nil).
+		Once()
+
+	// invalid recipients aren't retried
+	mockStore.On("MarkOutboxEmailFailed", mock.Anything, matchFailed(email, db.EmailStatusFailed, retryBackoff)).
+		Return(nil).
+		Once()
+
+	dispatcher := newTestDispatcher(t, mockStore, server, 3)
+	require.NoError(t, dispatcher.Dispatch(context.Background()))
+	require.Empty(t, server.mails)
+}
+
+func TestDispatchStoreError(t *testing.T) {
+	server := newTestSMTPServer(t)
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("ClaimOutboxEmails", mock.Anything, mock.Anything).
+		Return([]db.EmailOutbox{}, sql.ErrConnDone).
+		Once()
+
+	dispatcher := newTestDispatcher(t, mockStore, server, 3)
+	require.ErrorIs(t, dispatcher.Dispatch(context.Background()), sql.ErrConnDone)
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, retryBackoff, backoff(0))
+	require.Equal(t, 2*retryBackoff, backoff(1))
+	require.Equal(t, 8*retryBackoff, backoff(3))
+	require.Equal(t, maxRetryBackoff, backoff(100))
+}
@@ -0,0 +1,140 @@
+package notifier
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(m.Run())
+}
+
+// testMail is an email received by the test SMTP server.
+type testMail struct {
+	From string
+	To   []string
+	Data string
+}
+
+// testSMTPServer is a minimal local SMTP server used as a stand-in for a real SMTP server.
+// Recipients listed in reject are refused by the server.
+type testSMTPServer struct {
+	listener net.Listener
+	reject   map[string]bool
+	mails    chan testMail
+}
+
+// newTestSMTPServer starts a test SMTP server listening on a random local port.
+// The server is closed when the test ends.
+func newTestSMTPServer(t *testing.T, reject ...string) *testSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &testSMTPServer{
+		listener: listener,
+		reject:   make(map[string]bool),
+		mails:    make(chan testMail, 100),
+	}
+	for _, r := range reject {
+		server.reject[r] = true
+	}
+
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+
+	return server
+}
+
+// config returns a util.Config with the SMTP settings of the test server.
+func (s *testSMTPServer) config() util.Config {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+
+	return util.Config{
+		SMTPHost: host,
+		SMTPPort: portNumber,
+		SMTPFrom: "tutor@localhost",
+	}
+}
+
+func (s *testSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle serves a single SMTP session.
+func (s *testSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(format string, args ...interface{}) {
+		fmt.Fprintf(conn, format+"\r\n", args...)
+	}
+
+	var mail testMail
+	reply("220 localhost test SMTP server")
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			mail = testMail{From: strings.Trim(line[len("MAIL FROM:"):], "<> ")}
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			to := strings.Trim(line[len("RCPT TO:"):], "<> ")
+			if s.reject[to] {
+				reply("550 mailbox unavailable")
+				continue
+			}
+			mail.To = append(mail.To, to)
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+
+			mail.Data = data.String()
+			s.mails <- mail
+			reply("250 OK")
+		case cmd == "RSET":
+			mail = testMail{}
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 command not implemented")
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+)
+
+// smtpTimeout limits the time sending a single email takes, when the context has no deadline.
+const smtpTimeout = 30 * time.Second
+
+// ErrInvalidRecipient is returned when the recipient of a message isn't a valid email address.
+// It isn't resolved by retrying.
+var ErrInvalidRecipient = errors.New("invalid recipient")
+
+// Sender sends rendered emails.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTPSender sends emails through an SMTP server.
+// STARTTLS is used if the server supports it, and authentication is used if a username is configured.
+type SMTPSender struct {
+	host     string
+	addr     string
+	from     string
+	username string
+	password string
+}
+
+// NewSMTPSender creates a new SMTPSender configured by the SMTP settings of config.
+func NewSMTPSender(config util.Config) *SMTPSender {
+	return &SMTPSender{
+		host:     config.SMTPHost,
+		addr:     net.JoinHostPort(config.SMTPHost, strconv.Itoa(config.SMTPPort)),
+		from:     config.SMTPFrom,
+		username: config.SMTPUsername,
+		password: config.SMTPPassword,
+	}
+}
+
+// Send sends msg through the SMTP server.
+// It returns ErrInvalidRecipient if msg.To isn't a valid email address.
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	// the recipient is written to the headers, so it can't be sent as stored
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrInvalidRecipient, msg.To, err)
+	}
+	msg.To = to.Address
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, smtpTimeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+
+	if s.username != "" {
+		if err = c.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+
+	if err = c.Mail(s.from); err != nil {
+		return err
+	}
+
+	if err = c.Rcpt(msg.To); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(s.format(msg)); err != nil {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// format returns msg as a plain text email, with its headers.
+// Emails with attachments are multipart emails, with the plain text body as their first part.
+func (s *SMTPSender) format(msg Message) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	body := strings.ReplaceAll(msg.Body, "\n", "\r\n")
+	if len(msg.Attachments) == 0 {
+		b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+		b.WriteString("\r\n")
+		b.WriteString(body)
+		return b.Bytes()
+	}
+
+	w := multipart.NewWriter(&b)
+	fmt.Fprintf(&b, "Content-Type: multipart/mixed; boundary=%q\r\n", w.Boundary())
+	b.WriteString("\r\n")
+
+	// writing to a bytes.Buffer never fails
+	part, _ := w.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}})
+	part.Write([]byte(body))
+
+	for _, attachment := range msg.Attachments {
+		part, _ = w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		writeBase64Lines(part, attachment.Data)
+	}
+	w.Close()
+
+	return b.Bytes()
+}
+
+// base64LineLength is the maximum length of the lines of base64 encoded attachments.
+const base64LineLength = 76
+
+// writeBase64Lines writes data base64 encoded, split into lines as required in emails.
+func writeBase64Lines(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > base64LineLength {
+		io.WriteString(w, encoded[:base64LineLength]+"\r\n")
+		encoded = encoded[base64LineLength:]
+	}
+	io.WriteString(w, encoded+"\r\n")
+}
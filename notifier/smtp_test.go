@@ -0,0 +1,115 @@
+package notifier
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomMessage() Message {
+	return Message{
+		To:      util.RandomEmail(),
+		Subject: "Lesson on " + util.RandomName(),
+		Body:    util.RandomNote() + "\n",
+	}
+}
+
+func TestSMTPSenderSend(t *testing.T) {
+	server := newTestSMTPServer(t)
+	sender := NewSMTPSender(server.config())
+
+	msg := randomMessage()
+	err := sender.Send(context.Background(), msg)
+	require.NoError(t, err)
+
+	select {
+	case mail := <-server.mails:
+		require.Equal(t, "tutor@localhost", mail.From)
+		require.Equal(t, []string{msg.To}, mail.To)
+		require.Contains(t, mail.Data, "Subject: "+msg.Subject+"\r\n")
+		require.Contains(t, mail.Data, "To: "+msg.To+"\r\n")
+		require.Contains(t, mail.Data, "Content-Type: text/plain; charset=utf-8\r\n")
+		require.Contains(t, mail.Data, "\r\n\r\n"+strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	case <-time.After(time.Second):
+		t.Fatal("email wasn't received by the SMTP server")
+	}
+}
+
+func TestSMTPSenderAttachments(t *testing.T) {
+	server := newTestSMTPServer(t)
+	sender := NewSMTPSender(server.config())
+
+	msg := randomMessage()
+	msg.Attachments = []Attachment{
+		{Filename: "report.pdf", ContentType: "application/pdf", Data: []byte(strings.Repeat("%PDF-1.4 ", 20))},
+	}
+
+	err := sender.Send(context.Background(), msg)
+	require.NoError(t, err)
+
+	select {
+	case mail := <-server.mails:
+		require.Contains(t, mail.Data, "Content-Type: multipart/mixed; boundary=")
+		require.Contains(t, mail.Data, "Content-Type: text/plain; charset=utf-8\r\n")
+		require.Contains(t, mail.Data, strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+		require.Contains(t, mail.Data, "Content-Disposition: attachment; filename=report.pdf\r\n")
+		require.Contains(t, mail.Data, "Content-Transfer-Encoding: base64\r\n")
+
+		// base64 lines are at most 76 characters long
+		encoded := base64.StdEncoding.EncodeToString(msg.Attachments[0].Data)
+		require.Contains(t, mail.Data, encoded[:base64LineLength]+"\r\n"+encoded[base64LineLength:2*base64LineLength]+"\r\n")
+	case <-time.After(time.Second):
+		t.Fatal("email wasn't received by the SMTP server")
+	}
+}
+
+func TestSMTPSenderRejectedRecipient(t *testing.T) {
+	msg := randomMessage()
+	server := newTestSMTPServer(t, msg.To)
+	sender := NewSMTPSender(server.config())
+
+	err := sender.Send(context.Background(), msg)
+	require.Error(t, err)
+	require.Empty(t, server.mails)
+}
+
+func TestSMTPSenderInvalidRecipient(t *testing.T) {
+	server := newTestSMTPServer(t)
+	sender := NewSMTPSender(server.config())
+
+	// a recipient with a line break would add headers to the email
+	msg := randomMessage()
+	msg.To += "\r\nBcc: " + util.RandomEmail()
+
+	err := sender.Send(context.Background(), msg)
+	require.ErrorIs(t, err, ErrInvalidRecipient)
+	require.Empty(t, server.mails)
+
+	// the header has the parsed address
+	msg = randomMessage()
+	to := msg.To
+	msg.To = " " + to + " "
+	require.NoError(t, sender.Send(context.Background(), msg))
+
+	select {
+	case mail := <-server.mails:
+		require.Equal(t, []string{to}, mail.To)
+		require.Contains(t, mail.Data, "To: "+to+"\r\n")
+	case <-time.After(time.Second):
+		t.Fatal("email wasn't received by the SMTP server")
+	}
+}
+
+func TestSMTPSenderUnavailableServer(t *testing.T) {
+	server := newTestSMTPServer(t)
+	config := server.config()
+	server.listener.Close()
+
+	err := NewSMTPSender(config).Send(context.Background(), randomMessage())
+	require.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package notifier
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/billing"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+// templateFuncs are the functions available to the email templates.
+var templateFuncs = template.FuncMap{
+	"amount":   func(amount float64) string { return fmt.Sprintf("%.2f", amount) },
+	"percent":  func(ratio float64) string { return fmt.Sprintf("%.0f%%", ratio*100) },
+	"date":     func(t time.Time) string { return t.UTC().Format("Mon, 02 Jan 2006") },
+	"datetime": func(t time.Time) string { return t.UTC().Format("Mon, 02 Jan 2006 15:04 MST") },
+	"month":    func(t time.Time) string { return t.UTC().Format("January 2006") },
+	"hours":    func(hours float64) string { return fmt.Sprintf("%.1f", hours) },
+	"period":   billing.ProgressReportPeriod,
+}
+
+// Message is a rendered email.
+type Message struct {
+	To          string
+	Subject     string
+	Body        string
+	Attachments []Attachment
+}
+
+// Renderer renders the outbox emails using the email templates.
+// Each template defines a "subject" and a "body" template.
+type Renderer struct {
+	templates map[string]*template.Template
+}
+
+// NewRenderer parses all the email templates.
+func NewRenderer() (*Renderer, error) {
+	names, err := templatesFS.ReadDir("templates")
+	if err != nil {
+		return nil, err
+	}
+
+	renderer := &Renderer{templates: make(map[string]*template.Template, len(names))}
+	for _, entry := range names {
+		name := strings.TrimSuffix(entry.Name(), ".tmpl")
+
+		tmpl, err := template.New(name).Funcs(templateFuncs).ParseFS(templatesFS, "templates/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		renderer.templates[name] = tmpl
+	}
+
+	return renderer, nil
+}
+
+// Render renders an outbox email.
+func (r *Renderer) Render(email db.EmailOutbox) (Message, error) {
+	tmpl, ok := r.templates[email.Template]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown email template %q", email.Template)
+	}
+
+	var payload db.EmailPayload
+	if err := json.Unmarshal(email.Payload, &payload); err != nil {
+		return Message{}, fmt.Errorf("invalid payload of email %d: %w", email.EmailID, err)
+	}
+
+	var subject, body strings.Builder
+	if err := tmpl.ExecuteTemplate(&subject, "subject", payload); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", payload); err != nil {
+		return Message{}, err
+	}
+
+	files, err := attachments(email.Template, payload)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		To:          email.Recipient,
+		Subject:     strings.TrimSpace(subject.String()),
+		Body:        strings.TrimSpace(body.String()) + "\n",
+		Attachments: files,
+	}, nil
+}
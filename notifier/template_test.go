@@ -0,0 +1,286 @@
+package notifier
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomEmailPayload() db.EmailPayload {
+	lesson := db.Lesson{
+		LessonID:       util.RandomInt64(1, 1000),
+		LessonDatetime: util.RandomDatetime(),
+		Duration:       util.RandomLessonDuration(),
+	}
+
+	invoice := db.Invoice{
+		InvoiceID:       util.RandomInt64(1, 1000),
+		LessonID:        lesson.LessonID,
+		InvoiceDatetime: lesson.LessonDatetime,
+		HourlyFee:       util.RandomHourlyFee(),
+		Duration:        lesson.Duration,
+		Discount:        util.RandomDiscount(),
+		Amount:          util.RandomInvoiceAmount(),
+	}
+
+	receipt := db.Receipt{
+		ReceiptID:       util.RandomInt64(1, 1000),
+		ReceiptDatetime: util.RandomDatetime(),
+		Amount:          util.RandomPaymentAmount(),
+	}
+
+	statement := db.Statement{
+		StatementID:     util.RandomInt64(1, 1000),
+		PeriodStart:     time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:       time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		PreviousBalance: util.RandomInvoiceAmount(),
+		InvoicedAmount:  invoice.Amount,
+		PaymentsAmount:  receipt.Amount,
+	}
+	statement.TotalDue = statement.PreviousBalance + statement.InvoicedAmount - statement.PaymentsAmount
+
+	return db.EmailPayload{
+		Student: db.Student{
+			StudentID: util.RandomInt64(1, 1000),
+			FirstName: util.RandomName(),
+			LastName:  util.RandomName(),
+			Email:     sql.NullString{String: util.RandomEmail(), Valid: true},
+		},
+		Lesson:  &lesson,
+		Invoice: &invoice,
+		Receipt: &receipt,
+		Payments: []db.Payment{
+			{
+				PaymentID:       util.RandomInt64(1, 1000),
+				ReceiptID:       receipt.ReceiptID,
+				PaymentDatetime: receipt.ReceiptDatetime,
+				Amount:          receipt.Amount,
+			},
+		},
+		Statement: &statement,
+		Invoices:  []db.Invoice{invoice},
+		Receipts:  []db.Receipt{receipt},
+	}
+}
+
+// randomOutboxEmail returns a pending outbox email of the template, with a random payload.
+func randomOutboxEmail(t *testing.T, template string) db.EmailOutbox {
+	payload := randomEmailPayload()
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	return db.EmailOutbox{
+		EmailID:   util.RandomInt64(1, 1000),
+		Template:  template,
+		Recipient: payload.Student.Email.String,
+		Payload:   data,
+		Status:    db.EmailStatusPending,
+	}
+}
+
+func TestRender(t *testing.T) {
+	renderer, err := NewRenderer()
+	require.NoError(t, err)
+
+	templates := []string{
+		db.EmailTemplateLessonReminder,
+		db.EmailTemplateInvoiceCreated,
+		db.EmailTemplateReceiptConfirmation,
+		db.EmailTemplateMonthlyStatement,
+	}
+
+	for _, template := range templates {
+		t.Run(template, func(t *testing.T) {
+			email := randomOutboxEmail(t, template)
+
+			var payload db.EmailPayload
+			require.NoError(t, json.Unmarshal(email.Payload, &payload))
+
+			msg, err := renderer.Render(email)
+			require.NoError(t, err)
+			require.Equal(t, email.Recipient, msg.To)
+			require.NotEmpty(t, msg.Subject)
+			require.NotContains(t, msg.Subject, "\n")
+			require.Contains(t, msg.Body, "Hi "+payload.Student.FirstName+",")
+		})
+	}
+}
+
+func TestRenderInvoiceCreated(t *testing.T) {
+	renderer, err := NewRenderer()
+	require.NoError(t, err)
+
+	email := randomOutboxEmail(t, db.EmailTemplateInvoiceCreated)
+
+	var payload db.EmailPayload
+	require.NoError(t, json.Unmarshal(email.Payload, &payload))
+
+	msg, err := renderer.Render(email)
+	require.NoError(t, err)
+	require.Contains(t, msg.Subject, payload.Lesson.LessonDatetime.UTC().Format("Mon, 02 Jan 2006"))
+	require.Contains(t, msg.Body, templateFuncs["amount"].(func(float64) string)(payload.Invoice.Amount))
+}
+
+func TestRenderMonthlyStatement(t *testing.T) {
+	renderer, err := NewRenderer()
+	require.NoError(t, err)
+
+	email := randomOutboxEmail(t, db.EmailTemplateMonthlyStatement)
+
+	var payload db.EmailPayload
+	require.NoError(t, json.Unmarshal(email.Payload, &payload))
+
+	amount := templateFuncs["amount"].(func(float64) string)
+
+	msg, err := renderer.Render(email)
+	require.NoError(t, err)
+	require.Equal(t, "Your statement for March 2024", msg.Subject)
+	require.Contains(t, msg.Body, "Previous balance: "+amount(payload.Statement.PreviousBalance))
+	require.Contains(t, msg.Body, "minutes: "+amount(payload.Invoices[0].Amount))
+	require.Contains(t, msg.Body, "Payments this month: "+amount(payload.Statement.PaymentsAmount))
+	require.Contains(t, msg.Body, "Total due: "+amount(payload.Statement.TotalDue))
+}
+
+func TestRenderErrors(t *testing.T) {
+	renderer, err := NewRenderer()
+	require.NoError(t, err)
+
+	email := randomOutboxEmail(t, "unknown")
+	_, err = renderer.Render(email)
+	require.Error(t, err)
+
+	email = randomOutboxEmail(t, db.EmailTemplateLessonReminder)
+	email.Payload = []byte("not json")
+	_, err = renderer.Render(email)
+	require.Error(t, err)
+}
+
+func TestRenderProgressReport(t *testing.T) {
+	renderer, err := NewRenderer()
+	require.NoError(t, err)
+
+	payload := randomEmailPayload()
+	payload.Guardian = &db.Guardian{FirstName: util.RandomName()}
+	payload.ProgressReport = &db.ProgressReportDocument{
+		ProgressReport: db.ProgressReport{
+			StudentID:              payload.Student.StudentID,
+			PeriodStart:            time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:              time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+			LessonsCount:           4,
+			AttendedCount:          3,
+			Hours:                  4.5,
+			HomeworkAssignedCount:  3,
+			HomeworkCompletedCount: 2,
+		},
+		Student: payload.Student,
+		Subjects: []db.GetProgressReportSubjectsRow{
+			{SubjectID: util.RandomInt64(1, 1000), Name: "Calculus", LessonsCount: 4, AttendedCount: 3, Hours: 4.5},
+		},
+	}
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	msg, err := renderer.Render(db.EmailOutbox{
+		EmailID:   util.RandomInt64(1, 1000),
+		Template:  db.EmailTemplateProgressReport,
+		Recipient: util.RandomEmail(),
+		Payload:   data,
+	})
+	require.NoError(t, err)
+	require.Equal(t, payload.Student.FirstName+"'s progress report for March 2024", msg.Subject)
+	require.Contains(t, msg.Body, "Hi "+payload.Guardian.FirstName+",")
+	require.Contains(t, msg.Body, "Lessons attended: 3 of 4")
+	require.Contains(t, msg.Body, "Hours: 4.5")
+	require.Contains(t, msg.Body, "Homework completed: 2 of 3")
+	require.Contains(t, msg.Body, "- Calculus: 3 lessons, 4.5 hours")
+
+	// the report is attached as a PDF file and an HTML page
+	filename := fmt.Sprintf("progress-report-%d-2024-03-01", payload.Student.StudentID)
+	require.Len(t, msg.Attachments, 2)
+	require.Equal(t, filename+".pdf", msg.Attachments[0].Filename)
+	require.Equal(t, "application/pdf", msg.Attachments[0].ContentType)
+	require.True(t, strings.HasPrefix(string(msg.Attachments[0].Data), "%PDF-"))
+	require.Equal(t, filename+".html", msg.Attachments[1].Filename)
+	require.Contains(t, string(msg.Attachments[1].Data), "Calculus")
+
+	// other emails have no attachments
+	msg, err = renderer.Render(randomOutboxEmail(t, db.EmailTemplateMonthlyStatement))
+	require.NoError(t, err)
+	require.Empty(t, msg.Attachments)
+}
+
+func TestRenderWaitlistSlotAvailable(t *testing.T) {
+	renderer, err := NewRenderer()
+	require.NoError(t, err)
+
+	payload := randomEmailPayload()
+	payload.FreedLesson = payload.Lesson
+	payload.Lesson = nil
+	payload.WaitlistEntry = &db.WaitlistEntry{
+		WaitlistEntryID: util.RandomInt64(1, 1000),
+		StudentID:       payload.Student.StudentID,
+		Status:          db.WaitlistStatusNotified,
+	}
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	msg, err := renderer.Render(db.EmailOutbox{
+		EmailID:   util.RandomInt64(1, 1000),
+		Template:  db.EmailTemplateWaitlistSlotAvailable,
+		Recipient: payload.Student.Email.String,
+		Payload:   data,
+	})
+	require.NoError(t, err)
+	require.Contains(t, msg.Subject, payload.FreedLesson.LessonDatetime.UTC().Format("Mon, 02 Jan 2006"))
+	require.Contains(t, msg.Body, "Hi "+payload.Student.FirstName+",")
+	require.Contains(t, msg.Body, fmt.Sprintf("Duration: %d minutes", payload.FreedLesson.Duration))
+}
+
+func TestRenderTaskDigest(t *testing.T) {
+	renderer, err := NewRenderer()
+	require.NoError(t, err)
+
+	student := randomEmailPayload().Student
+	date := time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC)
+	payload := db.EmailPayload{
+		TaskDigest: &db.TaskDigest{
+			Date: date,
+			Overdue: []db.ListDueTasksRow{
+				{
+					TaskID:    util.RandomInt64(1, 1000),
+					Title:     "Call about unpaid lessons",
+					DueAt:     date.Add(-24 * time.Hour),
+					Priority:  db.TaskPriorityHigh,
+					Status:    db.TaskStatusOpen,
+					StudentID: sql.NullInt64{Int64: student.StudentID, Valid: true},
+					FirstName: sql.NullString{String: student.FirstName, Valid: true},
+					LastName:  sql.NullString{String: student.LastName, Valid: true},
+				},
+			},
+		},
+	}
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	msg, err := renderer.Render(db.EmailOutbox{
+		EmailID:   util.RandomInt64(1, 1000),
+		Template:  db.EmailTemplateTaskDigest,
+		Recipient: "tutor@example.com",
+		Payload:   data,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Your tasks for Mon, 11 Mar 2024", msg.Subject)
+	require.Contains(t, msg.Body, "- Call about unpaid lessons ("+student.FirstName+" "+student.LastName+"), due Sun, 10 Mar 2024 00:00 UTC, high priority")
+	require.NotContains(t, msg.Body, "Due today:")
+}
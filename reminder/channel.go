@@ -0,0 +1,183 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// httpTimeout limits the time a reminder request to an SMS gateway or a webhook takes.
+const httpTimeout = 15 * time.Second
+
+// Channel sends a lesson reminder to a student.
+type Channel interface {
+	Send(ctx context.Context, r db.GetDueLessonRemindersRow) error
+}
+
+// reminderText returns the text of a reminder sent as a short message.
+func reminderText(r db.GetDueLessonRemindersRow) string {
+	return fmt.Sprintf("Hi %s, this is a reminder of your lesson on %s, for %d minutes.",
+		r.FirstName, r.LessonDatetime.UTC().Format("Mon, 02 Jan 2006 15:04 MST"), r.Duration)
+}
+
+// EmailChannel sends reminders by adding a lesson reminder email to the email outbox.
+type EmailChannel struct {
+	store db.Store
+}
+
+// NewEmailChannel creates a new EmailChannel.
+func NewEmailChannel(store db.Store) *EmailChannel {
+	return &EmailChannel{store: store}
+}
+
+// Send adds the reminder email to the outbox, from which it is sent by the email dispatcher.
+func (c *EmailChannel) Send(ctx context.Context, r db.GetDueLessonRemindersRow) error {
+	if !r.Email.Valid || r.Email.String == "" {
+		return errors.New("student has no email address")
+	}
+
+	payload := db.EmailPayload{
+		Student: db.Student{
+			StudentID:   r.StudentID,
+			FirstName:   r.FirstName,
+			LastName:    r.LastName,
+			Email:       r.Email,
+			PhoneNumber: r.PhoneNumber,
+		},
+		Lesson: &db.Lesson{
+			LessonID:       r.LessonID,
+			LessonDatetime: r.LessonDatetime,
+			Duration:       r.Duration,
+		},
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.store.CreateOutboxEmail(ctx, db.CreateOutboxEmailParams{
+		Template:  db.EmailTemplateLessonReminder,
+		Recipient: r.Email.String,
+		Payload:   data,
+		StudentID: sql.NullInt64{Int64: r.StudentID, Valid: true},
+		LessonID:  sql.NullInt64{Int64: r.LessonID, Valid: true},
+		SendAt:    time.Now().UTC(),
+	})
+	return err
+}
+
+// postJSON posts body as JSON to url, and fails unless the response status is 2xx.
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, httpTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return fmt.Errorf("%s responded with status %s", url, rsp.Status)
+	}
+
+	return nil
+}
+
+// smsRequest is the body posted to the SMS gateway.
+type smsRequest struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Message string `json:"message"`
+}
+
+// SMSChannel sends reminders as short messages through an HTTP SMS gateway.
+// The gateway is sent a JSON body with the from, to and message fields,
+// and is authorized by a bearer token if one is configured.
+type SMSChannel struct {
+	url    string
+	token  string
+	from   string
+	client *http.Client
+}
+
+// NewSMSChannel creates a new SMSChannel using the gateway at url.
+func NewSMSChannel(url, token, from string) *SMSChannel {
+	return &SMSChannel{
+		url:    url,
+		token:  token,
+		from:   from,
+		client: &http.Client{},
+	}
+}
+
+// Send sends the reminder to the student phone number.
+func (c *SMSChannel) Send(ctx context.Context, r db.GetDueLessonRemindersRow) error {
+	if !r.PhoneNumber.Valid || r.PhoneNumber.String == "" {
+		return errors.New("student has no phone number")
+	}
+
+	header := make(http.Header)
+	if c.token != "" {
+		header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return postJSON(ctx, c.client, c.url, header, smsRequest{
+		From:    c.from,
+		To:      r.PhoneNumber.String,
+		Message: reminderText(r),
+	})
+}
+
+// webhookRequest is the body posted to the reminders webhook.
+type webhookRequest struct {
+	Event    string                      `json:"event"`
+	Message  string                      `json:"message"`
+	Reminder db.GetDueLessonRemindersRow `json:"reminder"`
+}
+
+// WebhookChannel sends reminders by posting them to a webhook, to be delivered by an external service.
+type WebhookChannel struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookChannel creates a new WebhookChannel posting reminders to url.
+func NewWebhookChannel(url string) *WebhookChannel {
+	return &WebhookChannel{
+		url:    url,
+		client: &http.Client{},
+	}
+}
+
+// Send posts the reminder to the webhook.
+func (c *WebhookChannel) Send(ctx context.Context, r db.GetDueLessonRemindersRow) error {
+	return postJSON(ctx, c.client, c.url, nil, webhookRequest{
+		Event:    "lesson.reminder",
+		Message:  reminderText(r),
+		Reminder: r,
+	})
+}
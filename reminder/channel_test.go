@@ -0,0 +1,110 @@
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func randomDueReminder() db.GetDueLessonRemindersRow {
+	return db.GetDueLessonRemindersRow{
+		LessonID:       util.RandomInt64(1, 1000),
+		LessonDatetime: time.Now().UTC().Add(time.Duration(util.RandomInt64(1, 24)) * time.Hour).Truncate(time.Second),
+		Duration:       util.RandomLessonDuration(),
+		StudentID:      util.RandomInt64(1, 1000),
+		FirstName:      util.RandomName(),
+		LastName:       util.RandomName(),
+		Email:          sql.NullString{String: util.RandomEmail(), Valid: true},
+		PhoneNumber:    sql.NullString{String: util.RandomPhoneNumber(), Valid: true},
+		Channel:        db.ReminderChannelEmail,
+		LeadMinutes:    db.DefaultReminderLeadMinutes,
+	}
+}
+
+func TestEmailChannel(t *testing.T) {
+	r := randomDueReminder()
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("CreateOutboxEmail", mock.Anything, mock.MatchedBy(func(arg db.CreateOutboxEmailParams) bool {
+		var payload db.EmailPayload
+		if err := json.Unmarshal(arg.Payload, &payload); err != nil {
+			return false
+		}
+
+		return arg.Template == db.EmailTemplateLessonReminder &&
+			arg.Recipient == r.Email.String &&
+			arg.StudentID == sql.NullInt64{Int64: r.StudentID, Valid: true} &&
+			arg.LessonID == sql.NullInt64{Int64: r.LessonID, Valid: true} &&
+			payload.Student.FirstName == r.FirstName &&
+			payload.Lesson.LessonDatetime.Equal(r.LessonDatetime)
+	})).Return(db.EmailOutbox{}, nil).Once()
+
+	require.NoError(t, NewEmailChannel(mockStore).Send(context.Background(), r))
+
+	// students without an email address can't be reminded by email
+	r.Email = sql.NullString{}
+	require.Error(t, NewEmailChannel(mockStore).Send(context.Background(), r))
+}
+
+func TestSMSChannel(t *testing.T) {
+	r := randomDueReminder()
+
+	var received smsRequest
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+		require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer gateway.Close()
+
+	channel := NewSMSChannel(gateway.URL, "secret", "Tutor")
+	require.NoError(t, channel.Send(context.Background(), r))
+
+	require.Equal(t, "Tutor", received.From)
+	require.Equal(t, r.PhoneNumber.String, received.To)
+	require.Equal(t, reminderText(r), received.Message)
+
+	// students without a phone number can't be reminded by SMS
+	r.PhoneNumber = sql.NullString{}
+	require.Error(t, channel.Send(context.Background(), r))
+}
+
+func TestSMSChannelGatewayError(t *testing.T) {
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer gateway.Close()
+
+	channel := NewSMSChannel(gateway.URL, "", "Tutor")
+	require.Error(t, channel.Send(context.Background(), randomDueReminder()))
+}
+
+func TestWebhookChannel(t *testing.T) {
+	r := randomDueReminder()
+
+	var received webhookRequest
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.Empty(t, req.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&received))
+	}))
+	defer webhook.Close()
+
+	require.NoError(t, NewWebhookChannel(webhook.URL).Send(context.Background(), r))
+
+	require.Equal(t, "lesson.reminder", received.Event)
+	require.Equal(t, reminderText(r), received.Message)
+	require.Equal(t, r.LessonID, received.Reminder.LessonID)
+	require.Equal(t, r.StudentID, received.Reminder.StudentID)
+	require.True(t, r.LessonDatetime.Equal(received.Reminder.LessonDatetime))
+}
@@ -0,0 +1,101 @@
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// SchedulerJobName is the name of the job sending the lesson reminders.
+const SchedulerJobName = "lesson_reminders"
+
+const (
+	// claimTimeout is how long a reminder claimed by a scheduler is held before other schedulers may claim it.
+	claimTimeout = 10 * time.Minute
+	// maxAttempts is the number of times sending a reminder is attempted.
+	maxAttempts = 3
+)
+
+// Scheduler sends the reminders of upcoming lessons to each attending student,
+// through the channel set in the student reminder preferences.
+// Every reminder is claimed in the database before it is sent,
+// so several schedulers can run concurrently without sending a reminder twice.
+type Scheduler struct {
+	store    db.Store
+	channels map[string]Channel
+}
+
+// NewScheduler creates a new Scheduler sending reminders through channels, keyed by the channel name.
+func NewScheduler(store db.Store, channels map[string]Channel) *Scheduler {
+	return &Scheduler{
+		store:    store,
+		channels: channels,
+	}
+}
+
+// Run sends all the reminders that are due.
+// Reminders that fail to be sent are recorded, and retried on the next run.
+func (s *Scheduler) Run(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	due, err := s.store.GetDueLessonReminders(ctx, db.GetDueLessonRemindersParams{
+		Now:         now,
+		Horizon:     now.Add(db.MaxReminderLeadMinutes * time.Minute),
+		MaxAttempts: maxAttempts,
+		StaleBefore: now.Add(-claimTimeout),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range due {
+		reminder, err := s.store.ClaimLessonReminder(ctx, db.ClaimLessonReminderParams{
+			LessonID:    r.LessonID,
+			StudentID:   r.StudentID,
+			Channel:     r.Channel,
+			MaxAttempts: maxAttempts,
+			StaleBefore: now.Add(-claimTimeout),
+		})
+		if err == sql.ErrNoRows {
+			// claimed by another scheduler, or out of attempts
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if sendErr := s.send(ctx, r); sendErr != nil {
+			log.Printf("sending reminder of lesson %d to student %d failed (attempt %d): %v",
+				r.LessonID, r.StudentID, reminder.Attempts, sendErr)
+
+			err = s.store.MarkLessonReminderFailed(ctx, db.MarkLessonReminderFailedParams{
+				ReminderID: reminder.ReminderID,
+				LastError:  sql.NullString{String: sendErr.Error(), Valid: true},
+			})
+		} else {
+			err = s.store.MarkLessonReminderSent(ctx, db.MarkLessonReminderSentParams{
+				ReminderID: reminder.ReminderID,
+				SentAt:     sql.NullTime{Time: time.Now().UTC(), Valid: true},
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// send sends a reminder through the channel of the student.
+func (s *Scheduler) send(ctx context.Context, r db.GetDueLessonRemindersRow) error {
+	channel, ok := s.channels[r.Channel]
+	if !ok {
+		return fmt.Errorf("reminder channel %q is not configured", r.Channel)
+	}
+
+	return channel.Send(ctx, r)
+}
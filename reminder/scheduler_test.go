@@ -0,0 +1,136 @@
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testChannel is a Channel recording the reminders sent through it.
+type testChannel struct {
+	err  error
+	sent []db.GetDueLessonRemindersRow
+}
+
+func (c *testChannel) Send(ctx context.Context, r db.GetDueLessonRemindersRow) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	c.sent = append(c.sent, r)
+	return nil
+}
+
+// matchClaim matches the arguments of ClaimLessonReminder for a due reminder.
+func matchClaim(r db.GetDueLessonRemindersRow) interface{} {
+	return mock.MatchedBy(func(arg db.ClaimLessonReminderParams) bool {
+		return arg.LessonID == r.LessonID &&
+			arg.StudentID == r.StudentID &&
+			arg.Channel == r.Channel &&
+			arg.MaxAttempts == maxAttempts
+	})
+}
+
+func claimedReminder(r db.GetDueLessonRemindersRow) db.LessonReminder {
+	return db.LessonReminder{
+		ReminderID: util.RandomInt64(1, 1000),
+		LessonID:   r.LessonID,
+		StudentID:  r.StudentID,
+		Channel:    r.Channel,
+		Status:     db.ReminderStatusSending,
+		Attempts:   1,
+		ClaimedAt:  time.Now().UTC(),
+	}
+}
+
+func TestSchedulerRun(t *testing.T) {
+	sent := randomDueReminder()
+	claimedElsewhere := randomDueReminder()
+	failed := randomDueReminder()
+	failed.Channel = db.ReminderChannelSMS
+
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("GetDueLessonReminders", mock.Anything, mock.MatchedBy(func(arg db.GetDueLessonRemindersParams) bool {
+		return time.Since(arg.Now) < time.Minute &&
+			arg.Horizon.Sub(arg.Now) == db.MaxReminderLeadMinutes*time.Minute &&
+			arg.MaxAttempts == maxAttempts &&
+			arg.Now.Sub(arg.StaleBefore) == claimTimeout
+	})).Return([]db.GetDueLessonRemindersRow{sent, claimedElsewhere, failed}, nil).Once()
+
+	// the first reminder is claimed and sent
+	sentReminder := claimedReminder(sent)
+	mockStore.On("ClaimLessonReminder", mock.Anything, matchClaim(sent)).
+		Return(sentReminder, nil).
+		Once()
+	mockStore.On("MarkLessonReminderSent", mock.Anything, mock.MatchedBy(func(arg db.MarkLessonReminderSentParams) bool {
+		return arg.ReminderID == sentReminder.ReminderID && arg.SentAt.Valid
+	})).Return(nil).Once()
+
+	// the second reminder was claimed by another scheduler
+	mockStore.On("ClaimLessonReminder", mock.Anything, matchClaim(claimedElsewhere)).
+		Return(db.LessonReminder{}, sql.ErrNoRows).
+		Once()
+
+	// the third reminder fails to be sent
+	failedReminder := claimedReminder(failed)
+	mockStore.On("ClaimLessonReminder", mock.Anything, matchClaim(failed)).
+		Return(failedReminder, nil).
+		Once()
+	mockStore.On("MarkLessonReminderFailed", mock.Anything, mock.MatchedBy(func(arg db.MarkLessonReminderFailedParams) bool {
+		return arg.ReminderID == failedReminder.ReminderID && arg.LastError.String == "gateway down"
+	})).Return(nil).Once()
+
+	emailChannel := &testChannel{}
+	smsChannel := &testChannel{err: errors.New("gateway down")}
+
+	scheduler := NewScheduler(mockStore, map[string]Channel{
+		db.ReminderChannelEmail: emailChannel,
+		db.ReminderChannelSMS:   smsChannel,
+	})
+	require.NoError(t, scheduler.Run(context.Background()))
+
+	require.Equal(t, []db.GetDueLessonRemindersRow{sent}, emailChannel.sent)
+	require.Empty(t, smsChannel.sent)
+}
+
+func TestSchedulerRunUnconfiguredChannel(t *testing.T) {
+	r := randomDueReminder()
+	r.Channel = db.ReminderChannelWebhook
+
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("GetDueLessonReminders", mock.Anything, mock.Anything).
+		Return([]db.GetDueLessonRemindersRow{r}, nil).
+		Once()
+
+	reminder := claimedReminder(r)
+	mockStore.On("ClaimLessonReminder", mock.Anything, matchClaim(r)).
+		Return(reminder, nil).
+		Once()
+	mockStore.On("MarkLessonReminderFailed", mock.Anything, mock.MatchedBy(func(arg db.MarkLessonReminderFailedParams) bool {
+		return arg.ReminderID == reminder.ReminderID && arg.LastError.Valid
+	})).Return(nil).Once()
+
+	scheduler := NewScheduler(mockStore, map[string]Channel{db.ReminderChannelEmail: &testChannel{}})
+	require.NoError(t, scheduler.Run(context.Background()))
+}
+
+func TestSchedulerRunStoreError(t *testing.T) {
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("GetDueLessonReminders", mock.Anything, mock.Anything).
+		Return([]db.GetDueLessonRemindersRow{}, sql.ErrConnDone).
+		Once()
+
+	scheduler := NewScheduler(mockStore, map[string]Channel{})
+	require.ErrorIs(t, scheduler.Run(context.Background()), sql.ErrConnDone)
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	key := "attachments/" + util.RandomName() + ".txt"
+	data := util.RandomNote()
+
+	// getting a blob that doesn't exist
+	_, err = store.Get(ctx, key)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader(data), int64(len(data)), "text/plain"))
+
+	r, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	stored, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, data, string(stored))
+
+	// a blob of the wrong size isn't stored
+	require.Error(t, store.Put(ctx, "short.txt", strings.NewReader(data), int64(len(data)+1), "text/plain"))
+	_, err = store.Get(ctx, "short.txt")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = store.Get(ctx, key)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// deleting a blob that doesn't exist isn't an error
+	require.NoError(t, store.Delete(ctx, key))
+
+	// keys outside the directory are rejected
+	require.ErrorIs(t, store.Put(ctx, "../escape.txt", strings.NewReader(data), int64(len(data)), "text/plain"), ErrInvalidKey)
+
+	_, err = NewLocalStore("")
+	require.Error(t, err)
+}
@@ -0,0 +1,268 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a local stand-in of an S3 compatible server, that keeps the objects of a bucket in memory.
+// Requests must be signed with AWS Signature Version 4 by the access key of the server, and are rejected otherwise.
+type fakeS3 struct {
+	t               *testing.T
+	bucket          string
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	mu              sync.Mutex
+	objects         map[string][]byte
+	types           map[string]string
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := s.verifySignature(r); err != nil {
+		s.t.Errorf("invalid signature of %s %s: %v", r.Method, r.URL.Path, err)
+		http.Error(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>", http.StatusForbidden)
+		return
+	}
+
+	prefix := "/" + s.bucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		http.Error(w, "<Error><Code>NoSuchBucket</Code></Error>", http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil || r.ContentLength != int64(len(data)) {
+			s.t.Errorf("incomplete upload of %s: read %d of %d bytes: %v", key, len(data), r.ContentLength, err)
+			http.Error(w, "<Error><Code>IncompleteBody</Code></Error>", http.StatusBadRequest)
+			return
+		}
+		s.objects[key] = data
+		s.types[key] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		data, ok := s.objects[key]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", s.types[key])
+		w.Write(data)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "<Error><Code>MethodNotAllowed</Code></Error>", http.StatusMethodNotAllowed)
+	}
+}
+
+// verifySignature recomputes the AWS Signature Version 4 of r from its method, path and signed headers,
+// and returns an error if it isn't the signature of the Authorization header.
+func (s *fakeS3) verifySignature(r *http.Request) error {
+	credential, signedHeaders, signature, err := parseAuthorization(r.Header.Get("Authorization"))
+	if err != nil {
+		return err
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	if len(amzDate) != len("20060102T150405Z") {
+		return fmt.Errorf("invalid X-Amz-Date %q", amzDate)
+	}
+	date := amzDate[:8]
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	if credential != s.accessKeyID+"/"+scope {
+		return fmt.Errorf("invalid credential %q", credential)
+	}
+
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash != unsignedPayload {
+		return fmt.Errorf("invalid X-Amz-Content-Sha256 %q", payloadHash)
+	}
+
+	names := strings.Split(signedHeaders, ";")
+	if !slices.IsSorted(names) || !slices.Contains(names, "host") || !slices.Contains(names, "x-amz-date") {
+		return fmt.Errorf("invalid signed headers %q", signedHeaders)
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		r.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	expected := hex.EncodeToString(hmacSHA256(signingKey(s.secretAccessKey, date, s.region, "s3"), stringToSign))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("signature %s doesn't match %s", signature, expected)
+	}
+
+	return nil
+}
+
+// parseAuthorization returns the credential, signed headers and signature of an AWS Signature Version 4 Authorization header.
+func parseAuthorization(auth string) (credential, signedHeaders, signature string, err error) {
+	params, ok := strings.CutPrefix(auth, "AWS4-HMAC-SHA256 ")
+	if !ok {
+		return "", "", "", fmt.Errorf("invalid Authorization %q", auth)
+	}
+
+	for _, param := range strings.Split(params, ", ") {
+		name, value, _ := strings.Cut(param, "=")
+		switch name {
+		case "Credential":
+			credential = value
+		case "SignedHeaders":
+			signedHeaders = value
+		case "Signature":
+			signature = value
+		}
+	}
+
+	if credential == "" || signedHeaders == "" || signature == "" {
+		return "", "", "", fmt.Errorf("invalid Authorization %q", auth)
+	}
+
+	return credential, signedHeaders, signature, nil
+}
+
+func newTestS3Store(t *testing.T, bucket string) (*S3Store, *fakeS3) {
+	fake := &fakeS3{
+		t:               t,
+		bucket:          "attachments",
+		region:          "us-east-1",
+		accessKeyID:     "test-key",
+		secretAccessKey: "test-secret",
+		objects:         make(map[string][]byte),
+		types:           make(map[string]string),
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := NewS3Store(S3Config{
+		Endpoint:        server.URL,
+		Region:          fake.region,
+		Bucket:          bucket,
+		AccessKeyID:     fake.accessKeyID,
+		SecretAccessKey: fake.secretAccessKey,
+	})
+	require.NoError(t, err)
+
+	return store, fake
+}
+
+func TestS3Store(t *testing.T) {
+	store, fake := newTestS3Store(t, "attachments")
+
+	ctx := context.Background()
+	key := "attachments/" + util.RandomName() + ".pdf"
+	data := util.RandomNote()
+
+	_, err := store.Get(ctx, key)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader(data), int64(len(data)), "application/pdf"))
+	require.Equal(t, "application/pdf", fake.types[key])
+
+	r, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	stored, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, data, string(stored))
+
+	require.NoError(t, store.Delete(ctx, key))
+	require.Empty(t, fake.objects)
+
+	_, err = store.Get(ctx, key)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.ErrorIs(t, store.Put(ctx, "../escape", strings.NewReader(data), int64(len(data)), ""), ErrInvalidKey)
+}
+
+func TestS3StoreErrors(t *testing.T) {
+	// the objects of a bucket that doesn't exist aren't found
+	store, _ := newTestS3Store(t, "missing")
+
+	_, err := store.Get(context.Background(), "a.txt")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = store.Put(context.Background(), "a.txt", strings.NewReader("a"), 1, "text/plain")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = NewS3Store(S3Config{Endpoint: "localhost:9000", Region: "us-east-1", Bucket: "attachments"})
+	require.Error(t, err)
+
+	_, err = NewS3Store(S3Config{Endpoint: "http://localhost:9000", Region: "us-east-1"})
+	require.Error(t, err)
+}
+
+func TestS3StoreSignature(t *testing.T) {
+	fake := &fakeS3{region: "us-east-1", accessKeyID: "test-key", secretAccessKey: "test-secret"}
+	store, err := NewS3Store(S3Config{
+		Endpoint:        "http://localhost:9000",
+		Region:          fake.region,
+		Bucket:          "attachments",
+		AccessKeyID:     fake.accessKeyID,
+		SecretAccessKey: fake.secretAccessKey,
+	})
+	require.NoError(t, err)
+
+	req, err := store.newRequest(context.Background(), http.MethodGet, "attachments/a b.pdf", nil)
+	require.NoError(t, err)
+	store.sign(req, time.Now().UTC())
+	req.Host = req.URL.Host
+	require.NoError(t, fake.verifySignature(req))
+
+	// requests signed by another secret, or changed after they were signed, are rejected
+	store.secretAccessKey = "wrong-secret"
+	store.sign(req, time.Now().UTC())
+	require.Error(t, fake.verifySignature(req))
+
+	store.secretAccessKey = fake.secretAccessKey
+	store.sign(req, time.Now().UTC())
+	req.Method = http.MethodDelete
+	require.Error(t, fake.verifySignature(req))
+}
+
+func TestSigningKey(t *testing.T) {
+	// the example of the AWS Signature Version 4 documentation
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	require.Equal(t, "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d", hex.EncodeToString(key))
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+)
+
+// Storage backends.
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// ErrNotFound is returned when a blob doesn't exist.
+var ErrNotFound = errors.New("blob not found")
+
+// ErrInvalidKey is returned when a blob key isn't a relative slash separated path.
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// BlobStore stores files by key. Keys are slash separated relative paths, such as attachments/1f2e.pdf.
+type BlobStore interface {
+	// Put stores the size bytes read from r as the blob key, replacing an existing blob.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get returns a reader of the blob key. The reader must be closed.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete deletes the blob key. Deleting a blob that doesn't exist isn't an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// New creates the BlobStore of the storage backend configured by config.
+func New(config util.Config) (BlobStore, error) {
+	switch config.StorageBackend {
+	case BackendLocal, "":
+		return NewLocalStore(config.StorageDir)
+	case BackendS3:
+		return NewS3Store(S3Config{
+			Endpoint:        config.S3Endpoint,
+			Region:          config.S3Region,
+			Bucket:          config.S3Bucket,
+			AccessKeyID:     config.S3AccessKeyID,
+			SecretAccessKey: config.S3SecretAccessKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", config.StorageBackend)
+	}
+}
+
+// validateKey checks that key is a relative slash separated path, without empty, . or .. segments.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"a", "attachments/1f2e.pdf", "a/b/c.txt"} {
+		require.NoError(t, validateKey(key), key)
+	}
+
+	for _, key := range []string{"", "/a", "a/", "a//b", "../a", "a/./b", "a/../b", `a\b`} {
+		require.ErrorIs(t, validateKey(key), ErrInvalidKey, key)
+	}
+}
+
+func TestNew(t *testing.T) {
+	blobs, err := New(util.Config{StorageDir: t.TempDir()})
+	require.NoError(t, err)
+	require.IsType(t, &LocalStore{}, blobs)
+
+	blobs, err = New(util.Config{
+		StorageBackend: BackendS3,
+		S3Endpoint:     "http://localhost:9000",
+		S3Region:       "us-east-1",
+		S3Bucket:       "attachments",
+	})
+	require.NoError(t, err)
+	require.IsType(t, &S3Store{}, blobs)
+
+	_, err = New(util.Config{StorageBackend: "ftp"})
+	require.Error(t, err)
+}
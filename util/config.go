@@ -1,6 +1,8 @@
 package util
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
@@ -10,6 +12,60 @@ type Config struct {
 	DBDriver      string `mapstructure:"DB_DRIVER"`
 	DBSource      string `mapstructure:"DB_SOURCE"`
 	ServerAddress string `mapstructure:"SERVER_ADDRESS"`
+
+	// JobRunsRetention is how long the history of the background job runs is kept.
+	JobRunsRetention time.Duration `mapstructure:"JOB_RUNS_RETENTION"`
+
+	// StudentLifecycleSchedule is the cron schedule of the students lifecycle status update.
+	StudentLifecycleSchedule string `mapstructure:"STUDENT_LIFECYCLE_SCHEDULE"`
+	// MonthlyBillingSchedule is the cron schedule of the monthly statements of the previous month.
+	MonthlyBillingSchedule string `mapstructure:"MONTHLY_BILLING_SCHEDULE"`
+	// ProgressReportsSchedule is the cron schedule of the progress reports of the previous month.
+	ProgressReportsSchedule string `mapstructure:"PROGRESS_REPORTS_SCHEDULE"`
+	// TaskDigestSchedule is the cron schedule of the digest of the overdue and due tasks, emailed to TaskDigestRecipient.
+	TaskDigestSchedule  string `mapstructure:"TASK_DIGEST_SCHEDULE"`
+	TaskDigestRecipient string `mapstructure:"TASK_DIGEST_RECIPIENT"`
+
+	// SMTP server used to send emails. Emails are not sent if SMTPHost is empty.
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     int    `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom     string `mapstructure:"SMTP_FROM"`
+
+	// EmailDispatchInterval is how often the email outbox is checked for emails to send.
+	EmailDispatchInterval time.Duration `mapstructure:"EMAIL_DISPATCH_INTERVAL"`
+	// EmailMaxAttempts is the number of times sending an email is attempted before it is marked as failed.
+	EmailMaxAttempts int `mapstructure:"EMAIL_MAX_ATTEMPTS"`
+
+	// ReminderInterval is how often upcoming lessons are checked for reminders to send.
+	ReminderInterval time.Duration `mapstructure:"REMINDER_INTERVAL"`
+	// SMS gateway used to send reminders by SMS. The SMS channel is disabled if SMSGatewayURL is empty.
+	SMSGatewayURL   string `mapstructure:"SMS_GATEWAY_URL"`
+	SMSGatewayToken string `mapstructure:"SMS_GATEWAY_TOKEN"`
+	SMSFrom         string `mapstructure:"SMS_FROM"`
+	// ReminderWebhookURL receives the reminders of the webhook channel. The channel is disabled if it is empty.
+	ReminderWebhookURL string `mapstructure:"REMINDER_WEBHOOK_URL"`
+
+	// WebhookDispatchInterval is how often pending webhook deliveries are posted to their subscriptions.
+	WebhookDispatchInterval time.Duration `mapstructure:"WEBHOOK_DISPATCH_INTERVAL"`
+	// WebhookMaxAttempts is the number of times posting a webhook delivery is attempted before it is marked as failed.
+	WebhookMaxAttempts int `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+
+	// StorageBackend is where attachments are stored, local or s3. Local storage keeps them in StorageDir.
+	StorageBackend string `mapstructure:"STORAGE_BACKEND"`
+	StorageDir     string `mapstructure:"STORAGE_DIR"`
+	// S3 compatible server used when StorageBackend is s3.
+	S3Endpoint        string `mapstructure:"S3_ENDPOINT"`
+	S3Region          string `mapstructure:"S3_REGION"`
+	S3Bucket          string `mapstructure:"S3_BUCKET"`
+	S3AccessKeyID     string `mapstructure:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `mapstructure:"S3_SECRET_ACCESS_KEY"`
+
+	// AttachmentMaxSize is the maximum size in bytes of an uploaded attachment.
+	AttachmentMaxSize int64 `mapstructure:"ATTACHMENT_MAX_SIZE"`
+	// AttachmentContentTypes are the comma separated MIME types of the attachments that may be uploaded.
+	AttachmentContentTypes []string `mapstructure:"ATTACHMENT_CONTENT_TYPES"`
 }
 
 // LoadConfig reads configurations from a file or environment variables.
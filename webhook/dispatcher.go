@@ -0,0 +1,157 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+)
+
+// DispatcherJobName is the name of the job posting the webhook deliveries.
+const DispatcherJobName = "webhook_dispatcher"
+
+const (
+	// dispatchBatchSize is the number of deliveries claimed at once.
+	dispatchBatchSize = 20
+	// dispatchLease is how long claimed deliveries are held by a dispatcher before other dispatchers may claim them.
+	dispatchLease = 5 * time.Minute
+	// httpTimeout is the timeout of a single webhook request.
+	httpTimeout = 10 * time.Second
+	// retryBackoff is the delay before the first retry of a failed delivery. It is doubled after every attempt.
+	retryBackoff = 30 * time.Second
+	// maxRetryBackoff caps the delay between retries.
+	maxRetryBackoff = 6 * time.Hour
+	// maxErrorLength is the maximum length of a response body recorded as the delivery error.
+	maxErrorLength = 512
+)
+
+// Dispatcher posts the pending webhook deliveries to their subscriptions.
+// Deliveries are claimed with a lease, so several dispatchers can run concurrently.
+type Dispatcher struct {
+	store       db.Store
+	client      *http.Client
+	maxAttempts int32
+}
+
+// NewDispatcher creates a new Dispatcher.
+// A delivery that failed maxAttempts times is marked as failed and isn't retried.
+func NewDispatcher(store db.Store, maxAttempts int) *Dispatcher {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	return &Dispatcher{
+		store:       store,
+		client:      &http.Client{Timeout: httpTimeout},
+		maxAttempts: int32(maxAttempts),
+	}
+}
+
+// Dispatch posts all the webhook deliveries that are due.
+// Deliveries that fail are retried later with an exponential backoff.
+func (d *Dispatcher) Dispatch(ctx context.Context) error {
+	for {
+		now := time.Now().UTC()
+
+		deliveries, err := d.store.ClaimWebhookDeliveries(ctx, db.ClaimWebhookDeliveriesParams{
+			LeaseUntil: now.Add(dispatchLease),
+			Now:        now,
+			Limit:      dispatchBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, delivery := range deliveries {
+			if err = d.deliver(ctx, delivery); err != nil {
+				return err
+			}
+		}
+
+		if len(deliveries) < dispatchBatchSize {
+			return nil
+		}
+	}
+}
+
+// deliver posts a single delivery, and records the result in the delivery log.
+// Only errors updating the delivery log are returned.
+func (d *Dispatcher) deliver(ctx context.Context, delivery db.ClaimWebhookDeliveriesRow) error {
+	status, err := d.post(ctx, delivery)
+
+	responseStatus := sql.NullInt32{Int32: int32(status), Valid: status != 0}
+
+	if err != nil {
+		log.Printf("posting webhook delivery %d failed (attempt %d): %v", delivery.DeliveryID, delivery.Attempts+1, err)
+
+		arg := db.MarkWebhookDeliveryFailedParams{
+			DeliveryID:     delivery.DeliveryID,
+			Status:         db.WebhookStatusPending,
+			ResponseStatus: responseStatus,
+			LastError:      sql.NullString{String: err.Error(), Valid: true},
+			NextAttemptAt:  time.Now().UTC().Add(backoff(delivery.Attempts)),
+		}
+
+		if delivery.Attempts+1 >= d.maxAttempts {
+			arg.Status = db.WebhookStatusFailed
+		}
+
+		return d.store.MarkWebhookDeliveryFailed(ctx, arg)
+	}
+
+	return d.store.MarkWebhookDeliveryDelivered(ctx, db.MarkWebhookDeliveryDeliveredParams{
+		DeliveryID:     delivery.DeliveryID,
+		ResponseStatus: responseStatus,
+		DeliveredAt:    sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	})
+}
+
+// post sends the signed payload of a delivery to its subscription url.
+// It returns the response status code, or 0 if no response was received.
+func (d *Dispatcher) post(ctx context.Context, delivery db.ClaimWebhookDeliveriesRow) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.DeliveryID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	rsp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(rsp.Body, maxErrorLength))
+		return rsp.StatusCode, fmt.Errorf("responded with status %s: %s", rsp.Status, bytes.TrimSpace(body))
+	}
+
+	return rsp.StatusCode, nil
+}
+
+// backoff returns the delay before retrying a delivery that was already attempted attempts times.
+func backoff(attempts int32) time.Duration {
+	delay := retryBackoff
+	for i := int32(0); i < attempts && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+
+	return delay
+}
@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/db/mocks"
+	db "github.com/github-real-lb/tutor-management-web/db/sqlc"
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testRequest is a webhook request received by a test server.
+type testRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newTestServer starts a webhook receiver responding with status, and sends the received requests to the returned channel.
+func newTestServer(t *testing.T, status int) (*httptest.Server, chan testRequest) {
+	requests := make(chan testRequest, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		requests <- testRequest{header: r.Header, body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+func randomDelivery(t *testing.T, url string) db.ClaimWebhookDeliveriesRow {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(db.WebhookPayload{
+		Event:     db.WebhookEventStudentCreated,
+		CreatedAt: time.Now().UTC(),
+		Data:      map[string]string{"first_name": util.RandomName()},
+	})
+	require.NoError(t, err)
+
+	return db.ClaimWebhookDeliveriesRow{
+		DeliveryID:     util.RandomInt64(1, 1000),
+		SubscriptionID: util.RandomInt64(1, 1000),
+		Event:          db.WebhookEventStudentCreated,
+		Payload:        payload,
+		Url:            url,
+		Secret:         secret,
+	}
+}
+
+// matchFailed matches the arguments of MarkWebhookDeliveryFailed for a delivery, expecting status and a retry after delay.
+func matchFailed(delivery db.ClaimWebhookDeliveriesRow, status string, responseStatus int32, delay time.Duration) interface{} {
+	return mock.MatchedBy(func(arg db.MarkWebhookDeliveryFailedParams) bool {
+		retryAt := time.Now().UTC().Add(delay)
+		return arg.DeliveryID == delivery.DeliveryID &&
+			arg.Status == status &&
+			arg.ResponseStatus.Int32 == responseStatus &&
+			arg.LastError.Valid &&
+			retryAt.Sub(arg.NextAttemptAt) < time.Minute
+	})
+}
+
+func TestDispatch(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusNoContent)
+	delivery := randomDelivery(t, server.URL)
+
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("ClaimWebhookDeliveries", mock.Anything, mock.MatchedBy(func(arg db.ClaimWebhookDeliveriesParams) bool {
+		return arg.Limit == dispatchBatchSize && arg.LeaseUntil.Sub(arg.Now) == dispatchLease
+	})).Return([]db.ClaimWebhookDeliveriesRow{delivery}, nil).Once()
+
+	mockStore.On("MarkWebhookDeliveryDelivered", mock.Anything, mock.MatchedBy(func(arg db.MarkWebhookDeliveryDeliveredParams) bool {
+		return arg.DeliveryID == delivery.DeliveryID &&
+			arg.ResponseStatus == sql.NullInt32{Int32: http.StatusNoContent, Valid: true} &&
+			arg.DeliveredAt.Valid
+	})).Return(nil).Once()
+
+	dispatcher := NewDispatcher(mockStore, 3)
+	require.NoError(t, dispatcher.Dispatch(context.Background()))
+
+	require.Len(t, requests, 1)
+	req := <-requests
+
+	require.Equal(t, []byte(delivery.Payload), req.body)
+	require.Equal(t, "application/json", req.header.Get("Content-Type"))
+	require.Equal(t, delivery.Event, req.header.Get(HeaderEvent))
+	require.Equal(t, strconv.FormatInt(delivery.DeliveryID, 10), req.header.Get(HeaderDelivery))
+	require.True(t, Verify(delivery.Secret, req.header.Get(HeaderTimestamp), req.header.Get(HeaderSignature), req.body))
+}
+
+func TestDispatchRetry(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusInternalServerError)
+	delivery := randomDelivery(t, server.URL)
+	delivery.Attempts = 1
+
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("ClaimWebhookDeliveries", mock.Anything, mock.Anything).
+		Return([]db.ClaimWebhookDeliveriesRow{delivery}, nil).
+		Once()
+
+	mockStore.On("MarkWebhookDeliveryFailed", mock.Anything,
+		matchFailed(delivery, db.WebhookStatusPending, http.StatusInternalServerError, 2*retryBackoff)).
+		Return(nil).
+		Once()
+
+	dispatcher := NewDispatcher(mockStore, 3)
+	require.NoError(t, dispatcher.Dispatch(context.Background()))
+	require.Len(t, requests, 1)
+}
+
+func TestDispatchMaxAttempts(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusBadRequest)
+	delivery := randomDelivery(t, server.URL)
+	delivery.Attempts = 2
+
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("ClaimWebhookDeliveries", mock.Anything, mock.Anything).
+		Return([]db.ClaimWebhookDeliveriesRow{delivery}, nil).
+		Once()
+
+	mockStore.On("MarkWebhookDeliveryFailed", mock.Anything,
+		matchFailed(delivery, db.WebhookStatusFailed, http.StatusBadRequest, 4*retryBackoff)).
+		Return(nil).
+		Once()
+
+	dispatcher := NewDispatcher(mockStore, 3)
+	require.NoError(t, dispatcher.Dispatch(context.Background()))
+}
+
+func TestDispatchConnectionError(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusOK)
+	delivery := randomDelivery(t, server.URL)
+	server.Close()
+
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("ClaimWebhookDeliveries", mock.Anything, mock.Anything).
+		Return([]db.ClaimWebhookDeliveriesRow{delivery}, nil).
+		Once()
+
+	// no response was received, so no response status is recorded
+	mockStore.On("MarkWebhookDeliveryFailed", mock.Anything, mock.MatchedBy(func(arg db.MarkWebhookDeliveryFailedParams) bool {
+		return arg.DeliveryID == delivery.DeliveryID && !arg.ResponseStatus.Valid && arg.LastError.Valid
+	})).Return(nil).Once()
+
+	dispatcher := NewDispatcher(mockStore, 3)
+	require.NoError(t, dispatcher.Dispatch(context.Background()))
+}
+
+func TestDispatchStoreError(t *testing.T) {
+	mockStore := mocks.NewMockStore(t)
+
+	mockStore.On("ClaimWebhookDeliveries", mock.Anything, mock.Anything).
+		Return([]db.ClaimWebhookDeliveriesRow{}, sql.ErrConnDone).
+		Once()
+
+	dispatcher := NewDispatcher(mockStore, 3)
+	require.ErrorIs(t, dispatcher.Dispatch(context.Background()), sql.ErrConnDone)
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, retryBackoff, backoff(0))
+	require.Equal(t, 2*retryBackoff, backoff(1))
+	require.Equal(t, 8*retryBackoff, backoff(3))
+	require.Equal(t, maxRetryBackoff, backoff(100))
+}
@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Headers of the webhook requests.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// secretSize is the number of random bytes of a subscription secret.
+const secretSize = 32
+
+// NewSecret returns a random secret used to sign the payloads posted to a subscription.
+func NewSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// Sign returns the signature of a payload posted at timestamp, as sent in the X-Webhook-Signature header.
+// The signature is the hex encoded HMAC-SHA256 of "<timestamp>.<payload>" keyed with the subscription secret.
+// Receivers should recompute it, and reject requests with an old timestamp to prevent replay attacks.
+func Sign(secret string, timestamp time.Time, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp.Unix())
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the valid signature of a payload posted at the unix timestamp.
+func Verify(secret, timestamp, signature string, payload []byte) bool {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	expected := Sign(secret, time.Unix(unix, 0), payload)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
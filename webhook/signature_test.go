@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/tutor-management-web/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSecret(t *testing.T) {
+	secret1, err := NewSecret()
+	require.NoError(t, err)
+	require.Len(t, secret1, 2*secretSize)
+
+	secret2, err := NewSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, secret1, secret2)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+
+	payload := []byte(`{"event":"student.created","data":{"first_name":"` + util.RandomName() + `"}}`)
+	timestamp := time.Now()
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+
+	signature := Sign(secret, timestamp, payload)
+	require.Regexp(t, "^sha256=[0-9a-f]{64}$", signature)
+	require.True(t, Verify(secret, unix, signature, payload))
+
+	// the signature depends on the secret, the timestamp and the payload
+	require.False(t, Verify("other-secret", unix, signature, payload))
+	require.False(t, Verify(secret, strconv.FormatInt(timestamp.Unix()+1, 10), signature, payload))
+	require.False(t, Verify(secret, unix, signature, append(payload, ' ')))
+	require.False(t, Verify(secret, "invalid", signature, payload))
+}